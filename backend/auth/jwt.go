@@ -7,14 +7,25 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// RoleSpectator marks tokens of read-only spectator accounts
+const RoleSpectator = "spectator"
+
 // Claims represents the JWT claims for authenticated users
+// Spectator tokens have no Steam ID and user ID, but a spectator ID
 type Claims struct {
-	SteamID  string `json:"steam_id"`
-	UserID   uint64 `json:"uid"`
-	Username string `json:"name"`
+	SteamID     string `json:"steam_id"`
+	UserID      uint64 `json:"uid"`
+	Username    string `json:"name"`
+	Role        string `json:"role,omitempty"`
+	SpectatorID uint64 `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// IsSpectator checks if the token belongs to a read-only spectator account
+func (c *Claims) IsSpectator() bool {
+	return c.Role == RoleSpectator
+}
+
 // JWTService handles JWT token generation and validation
 type JWTService struct {
 	secret         []byte
@@ -55,6 +66,32 @@ func (j *JWTService) GenerateToken(steamID string, userID uint64, username strin
 	return tokenString, nil
 }
 
+// GenerateSpectatorToken creates a new JWT token for a spectator account
+func (j *JWTService) GenerateSpectatorToken(spectatorID uint64, name string) (string, error) {
+	now := time.Now()
+	expiresAt := now.AddDate(0, 0, j.expirationDays)
+
+	claims := Claims{
+		Username:    name,
+		Role:        RoleSpectator,
+		SpectatorID: spectatorID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   fmt.Sprintf("%s:%d", RoleSpectator, spectatorID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString(j.secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return tokenString, nil
+}
+
 // ValidateToken validates a JWT token and returns the claims
 func (j *JWTService) ValidateToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
@@ -76,3 +113,70 @@ func (j *JWTService) ValidateToken(tokenString string) (*Claims, error) {
 
 	return claims, nil
 }
+
+// elevationPurpose marks tokens that grant elevated admin access
+const elevationPurpose = "admin_elevation"
+
+// ElevationClaims represents the claims of a short-lived admin elevation token
+// Elevation tokens are issued after the admin password has been verified
+type ElevationClaims struct {
+	SteamID string `json:"steam_id"`
+	Purpose string `json:"purpose"`
+	jwt.RegisteredClaims
+}
+
+// elevationSecret derives the signing key for elevation tokens so they can never be used as login tokens
+func (j *JWTService) elevationSecret() []byte {
+	return append(append([]byte{}, j.secret...), []byte(":"+elevationPurpose)...)
+}
+
+// GenerateElevationToken creates a short-lived elevation token for an admin
+func (j *JWTService) GenerateElevationToken(steamID string, ttl time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	claims := ElevationClaims{
+		SteamID: steamID,
+		Purpose: elevationPurpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   steamID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString(j.elevationSecret())
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign elevation token: %w", err)
+	}
+
+	return tokenString, expiresAt, nil
+}
+
+// ValidateElevationToken validates an elevation token and checks that it belongs to the given admin
+func (j *JWTService) ValidateElevationToken(tokenString, steamID string) error {
+	token, err := jwt.ParseWithClaims(tokenString, &ElevationClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return j.elevationSecret(), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to parse elevation token: %w", err)
+	}
+
+	claims, ok := token.Claims.(*ElevationClaims)
+	if !ok || !token.Valid {
+		return fmt.Errorf("invalid elevation token")
+	}
+	if claims.Purpose != elevationPurpose {
+		return fmt.Errorf("token is not an elevation token")
+	}
+	if claims.SteamID != steamID {
+		return fmt.Errorf("elevation token belongs to another user")
+	}
+
+	return nil
+}
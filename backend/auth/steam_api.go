@@ -2,11 +2,14 @@ package auth
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/metrics"
 )
 
 const (
@@ -31,6 +34,7 @@ type SteamPlayer struct {
 	RealName        string `json:"realname,omitempty"`
 	TimeCreated     int64  `json:"timecreated,omitempty"`
 	LocCountryCode  string `json:"loccountrycode,omitempty"`
+	GameID          string `json:"gameid,omitempty"` // App ID of the running game, only visible on public profiles
 }
 
 // steamAPIResponse represents the API response structure
@@ -115,12 +119,14 @@ func (c *SteamAPIClient) GetPlayerSummaries(steamIDs []string) ([]SteamPlayer, e
 	resp, err := c.httpClient.Get(url)
 	duration := time.Since(start)
 	if err != nil {
+		metrics.SteamAPIErrors.Inc()
 		log.Printf("[STEAM API] ERROR - GetPlayerSummaries failed after %v: %v", duration, err)
 		return nil, fmt.Errorf("failed to call Steam API: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		metrics.SteamAPIErrors.Inc()
 		log.Printf("[STEAM API] ERROR - GetPlayerSummaries returned status %d after %v", resp.StatusCode, duration)
 		return nil, fmt.Errorf("Steam API returned status %d", resp.StatusCode)
 	}
@@ -128,6 +134,7 @@ func (c *SteamAPIClient) GetPlayerSummaries(steamIDs []string) ([]SteamPlayer, e
 	// Parse the response
 	var apiResp steamAPIResponse
 	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		metrics.SteamAPIErrors.Inc()
 		log.Printf("[STEAM API] ERROR - Failed to parse response after %v: %v", duration, err)
 		return nil, fmt.Errorf("failed to parse Steam API response: %w", err)
 	}
@@ -136,6 +143,219 @@ func (c *SteamAPIClient) GetPlayerSummaries(steamIDs []string) ([]SteamPlayer, e
 	return apiResp.Response.Players, nil
 }
 
+// Steam stats errors
+var (
+	ErrSteamProfilePrivate = errors.New("Steam profile or game details are not public")
+	ErrSteamNoStats        = errors.New("game has no achievements or is not owned")
+)
+
+// SteamAchievement represents a player's progress on one Steam achievement
+type SteamAchievement struct {
+	APIName     string `json:"apiname"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Achieved    int    `json:"achieved"`   // 1 if unlocked
+	UnlockTime  int64  `json:"unlocktime"` // Unix time, 0 if locked
+}
+
+// SteamPlayerStats represents a player's achievements in one game
+type SteamPlayerStats struct {
+	GameName     string             `json:"gameName"`
+	Achievements []SteamAchievement `json:"achievements"`
+}
+
+// steamPlayerStatsResponse represents the GetPlayerAchievements response structure
+type steamPlayerStatsResponse struct {
+	PlayerStats struct {
+		SteamPlayerStats
+		Success bool   `json:"success"`
+		Error   string `json:"error"`
+	} `json:"playerstats"`
+}
+
+// steamOwnedGamesResponse represents the GetOwnedGames response structure
+// game_count is missing if the game details of the profile are private
+type steamOwnedGamesResponse struct {
+	Response struct {
+		GameCount *int `json:"game_count"`
+		Games     []struct {
+			AppID           int `json:"appid"`
+			PlaytimeForever int `json:"playtime_forever"`
+		} `json:"games"`
+	} `json:"response"`
+}
+
+// GetOwnedGamePlaytimes fetches the owned games of a player with their playtime in minutes
+// Returns ErrSteamProfilePrivate if the game details of the profile are private
+func (c *SteamAPIClient) GetOwnedGamePlaytimes(steamID string) (map[int]int, error) {
+	if strings.HasPrefix(steamID, "FAKE_") {
+		return map[int]int{}, nil
+	}
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("Steam API key not configured")
+	}
+
+	url := fmt.Sprintf(
+		"%s/IPlayerService/GetOwnedGames/v1/?key=%s&steamid=%s&include_played_free_games=true",
+		steamAPIBaseURL,
+		c.apiKey,
+		steamID,
+	)
+
+	log.Printf("[STEAM API] GET /IPlayerService/GetOwnedGames/v1 - Fetching playtimes for user: %s", steamID)
+	start := time.Now()
+	resp, err := c.httpClient.Get(url)
+	duration := time.Since(start)
+	if err != nil {
+		metrics.SteamAPIErrors.Inc()
+		log.Printf("[STEAM API] ERROR - GetOwnedGames failed for user %s after %v: %v", steamID, duration, err)
+		return nil, fmt.Errorf("failed to call Steam API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		metrics.SteamAPIErrors.Inc()
+		log.Printf("[STEAM API] ERROR - GetOwnedGames returned status %d for user %s after %v", resp.StatusCode, steamID, duration)
+		return nil, fmt.Errorf("Steam API returned status %d", resp.StatusCode)
+	}
+
+	var apiResp steamOwnedGamesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		metrics.SteamAPIErrors.Inc()
+		log.Printf("[STEAM API] ERROR - Failed to parse GetOwnedGames response for user %s: %v", steamID, err)
+		return nil, fmt.Errorf("failed to parse Steam API response: %w", err)
+	}
+	if apiResp.Response.GameCount == nil {
+		log.Printf("[STEAM API] OK - GetOwnedGames: game details of user %s are private (%v)", steamID, duration)
+		return nil, ErrSteamProfilePrivate
+	}
+
+	playtimes := make(map[int]int, len(apiResp.Response.Games))
+	for _, g := range apiResp.Response.Games {
+		playtimes[g.AppID] = g.PlaytimeForever
+	}
+
+	log.Printf("[STEAM API] OK - GetOwnedGames returned %d games for user %s in %v", len(playtimes), steamID, duration)
+	return playtimes, nil
+}
+
+// GetPlayerAchievements fetches a player's achievements in a game
+// language is a Steam language name like "english", achievement names and descriptions are returned in it
+// Returns ErrSteamProfilePrivate for private profiles and ErrSteamNoStats for games without achievements
+func (c *SteamAPIClient) GetPlayerAchievements(steamID string, appID int, language string) (*SteamPlayerStats, error) {
+	if strings.HasPrefix(steamID, "FAKE_") {
+		return nil, ErrSteamNoStats
+	}
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("Steam API key not configured")
+	}
+
+	url := fmt.Sprintf(
+		"%s/ISteamUserStats/GetPlayerAchievements/v1/?key=%s&steamid=%s&appid=%d&l=%s",
+		steamAPIBaseURL,
+		c.apiKey,
+		steamID,
+		appID,
+		language,
+	)
+
+	log.Printf("[STEAM API] GET /ISteamUserStats/GetPlayerAchievements/v1 - Fetching app %d for user: %s", appID, steamID)
+	start := time.Now()
+	resp, err := c.httpClient.Get(url)
+	duration := time.Since(start)
+	if err != nil {
+		metrics.SteamAPIErrors.Inc()
+		log.Printf("[STEAM API] ERROR - GetPlayerAchievements failed for user %s after %v: %v", steamID, duration, err)
+		return nil, fmt.Errorf("failed to call Steam API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusForbidden:
+		return nil, ErrSteamProfilePrivate
+	case http.StatusBadRequest:
+		return nil, ErrSteamNoStats
+	default:
+		metrics.SteamAPIErrors.Inc()
+		log.Printf("[STEAM API] ERROR - GetPlayerAchievements returned status %d for user %s after %v", resp.StatusCode, steamID, duration)
+		return nil, fmt.Errorf("Steam API returned status %d", resp.StatusCode)
+	}
+
+	var apiResp steamPlayerStatsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		metrics.SteamAPIErrors.Inc()
+		log.Printf("[STEAM API] ERROR - Failed to parse GetPlayerAchievements response for user %s: %v", steamID, err)
+		return nil, fmt.Errorf("failed to parse Steam API response: %w", err)
+	}
+	if !apiResp.PlayerStats.Success || len(apiResp.PlayerStats.Achievements) == 0 {
+		return nil, ErrSteamNoStats
+	}
+
+	log.Printf("[STEAM API] OK - GetPlayerAchievements returned %d achievements of app %d for user %s in %v", len(apiResp.PlayerStats.Achievements), appID, steamID, duration)
+	return &apiResp.PlayerStats.SteamPlayerStats, nil
+}
+
+// SteamWishlistItem is a game on the wishlist of a player
+type SteamWishlistItem struct {
+	AppID     int   `json:"appid"`
+	Priority  int   `json:"priority"`   // Position set by the player, 0 = not ranked
+	DateAdded int64 `json:"date_added"` // Unix timestamp
+}
+
+// steamWishlistResponse represents the GetWishlist response structure
+// items is missing if the wishlist is empty or private
+type steamWishlistResponse struct {
+	Response struct {
+		Items []SteamWishlistItem `json:"items"`
+	} `json:"response"`
+}
+
+// GetWishlist fetches the wishlist of a player
+// Private wishlists cannot be told apart from empty ones, both return no items
+func (c *SteamAPIClient) GetWishlist(steamID string) ([]SteamWishlistItem, error) {
+	if strings.HasPrefix(steamID, "FAKE_") {
+		return []SteamWishlistItem{}, nil
+	}
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("Steam API key not configured")
+	}
+
+	url := fmt.Sprintf(
+		"%s/IWishlistService/GetWishlist/v1/?key=%s&steamid=%s",
+		steamAPIBaseURL,
+		c.apiKey,
+		steamID,
+	)
+
+	log.Printf("[STEAM API] GET /IWishlistService/GetWishlist/v1 - Fetching wishlist for user: %s", steamID)
+	start := time.Now()
+	resp, err := c.httpClient.Get(url)
+	duration := time.Since(start)
+	if err != nil {
+		metrics.SteamAPIErrors.Inc()
+		log.Printf("[STEAM API] ERROR - GetWishlist failed for user %s after %v: %v", steamID, duration, err)
+		return nil, fmt.Errorf("failed to call Steam API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		metrics.SteamAPIErrors.Inc()
+		log.Printf("[STEAM API] ERROR - GetWishlist returned status %d for user %s after %v", resp.StatusCode, steamID, duration)
+		return nil, fmt.Errorf("Steam API returned status %d", resp.StatusCode)
+	}
+
+	var apiResp steamWishlistResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		metrics.SteamAPIErrors.Inc()
+		log.Printf("[STEAM API] ERROR - Failed to parse GetWishlist response for user %s: %v", steamID, err)
+		return nil, fmt.Errorf("failed to parse Steam API response: %w", err)
+	}
+
+	log.Printf("[STEAM API] OK - GetWishlist returned %d games for user %s in %v", len(apiResp.Response.Items), steamID, duration)
+	return apiResp.Response.Items, nil
+}
+
 // IsConfigured returns true if the API client has a valid API key
 func (c *SteamAPIClient) IsConfigured() bool {
 	return c.apiKey != ""
@@ -172,11 +392,13 @@ func (c *SteamAPIClient) CheckConnectivity() error {
 	resp, err := c.httpClient.Head(steamCommunityURL)
 	duration := time.Since(start)
 	if err != nil {
+		metrics.SteamAPIErrors.Inc()
 		log.Printf("[STEAM API] ERROR - Steam Community unreachable after %v: %v", duration, err)
 		return fmt.Errorf("cannot reach Steam Community (%s): %w", steamCommunityURL, err)
 	}
 	resp.Body.Close()
 	if resp.StatusCode >= 400 {
+		metrics.SteamAPIErrors.Inc()
 		log.Printf("[STEAM API] ERROR - Steam Community returned status %d after %v", resp.StatusCode, duration)
 		return fmt.Errorf("Steam Community returned status %d", resp.StatusCode)
 	}
@@ -192,15 +414,18 @@ func (c *SteamAPIClient) CheckConnectivity() error {
 		resp, err := c.httpClient.Get(testURL)
 		duration = time.Since(start)
 		if err != nil {
+			metrics.SteamAPIErrors.Inc()
 			log.Printf("[STEAM API] ERROR - Steam Web API unreachable after %v: %v", duration, err)
 			return fmt.Errorf("cannot reach Steam Web API (%s): %w", steamAPIBaseURL, err)
 		}
 		resp.Body.Close()
 		if resp.StatusCode == 401 || resp.StatusCode == 403 {
+			metrics.SteamAPIErrors.Inc()
 			log.Printf("[STEAM API] ERROR - API key invalid/unauthorized (status %d, %v)", resp.StatusCode, duration)
 			return fmt.Errorf("Steam API key is invalid or unauthorized (status %d)", resp.StatusCode)
 		}
 		if resp.StatusCode >= 400 {
+			metrics.SteamAPIErrors.Inc()
 			log.Printf("[STEAM API] ERROR - Steam Web API returned status %d after %v", resp.StatusCode, duration)
 			return fmt.Errorf("Steam Web API returned status %d", resp.StatusCode)
 		}
@@ -2,10 +2,13 @@ package config
 
 import (
 	"log"
+	"net"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	_ "time/tzdata" // Embedded timezone database, container images may not ship one
 
 	"github.com/joho/godotenv"
 )
@@ -17,10 +20,19 @@ type Config struct {
 	FrontendURL string
 	BackendURL  string
 
+	// mDNS / Zeroconf
+	MDNSEnabled      bool   // Advertise the backend on the LAN via mDNS
+	MDNSInstanceName string // Instance name shown to discovering clients
+
 	// Database
 	DBType     string // "sqlite" or "mysql"
 	DBPath     string // SQLite database path
 
+	// SQLite backups
+	BackupDir             string // Directory of the backup snapshots
+	BackupIntervalMinutes int    // Minutes between two scheduled backups (0 = disabled)
+	BackupRetention       int    // Number of backups to keep, older ones are deleted (0 = keep all)
+
 	// MySQL
 	MySQLHost            string
 	MySQLPort            int
@@ -38,6 +50,17 @@ type Config struct {
 	// Steam
 	SteamAPIKey string
 
+	// Steam game sync
+	SteamSyncConcurrency        int    // Parallel store data fetches during a game sync
+	SteamStoreRequestsPerMinute int    // Shared limit of all Steam Store requests, a 429 still pauses the sync
+	StoreCountry                string // Steam Store country code, decides currency and prices (e.g., "de", "us")
+	StoreLanguage               string // Steam Store language of names, genres and descriptions (e.g., "english")
+
+	// Background refresh of stale game cache entries
+	CacheRefreshBatchSize int    // Stale games refreshed per run, oldest first (0 = disabled)
+	CacheRefreshMinutes   int    // Interval between two runs
+	CacheRefreshWindow    string // Time of day the refresh runs, "HH:MM-HH:MM" in the event timezone (empty = all day)
+
 	// JWT
 	JWTSecret         string
 	JWTExpirationDays int
@@ -48,23 +71,130 @@ type Config struct {
 
 	// Voting
 	VotingPaused           bool
-	VotingPausedAt         time.Time // Timestamp when voting was paused (for freezing credit generation)
-	VoteVisibilityMode     string    // "user_choice", "all_secret", "all_public" - Default: user_choice
-	NegativeVotingDisabled bool      // When true, negative achievements cannot be voted
+	VotingPausedAt         time.Time      // Timestamp when voting was paused (for freezing credit generation)
+	VoteVisibilityMode     string         // "user_choice", "all_secret", "all_public" - Default: user_choice
+	NegativeVotingDisabled bool           // When true, negative achievements cannot be voted
+	AchievementDailyLimits map[string]int // Max votes per voter and day per achievement ID (missing = unlimited)
+
+	// Vote streaks
+	StreakThreshold     int // Number of different voters needed for a streak ("on fire")
+	StreakWindowMinutes int // Time window in which the voters must have voted
+	StreakBonusCredits  int // Bonus credits granted to the target of a streak
+
+	// Repeat vote escalation: voting the same negative achievement on the same person again within
+	// the window costs the vote points times 2, 3, ... credits to dampen pile-ons
+	RepeatVoteEscalationMinutes int // Time window of the escalation (0 = disabled)
+	RepeatVoteEscalationMax     int // Maximum cost multiplier
+
+	// Quick vote (Stream Deck & co.)
+	QuickVoteCooldownSeconds int // Minimum seconds between two quick votes of the same user
+
+	// Rate limits per user and minute (0 = unlimited)
+	VoteRateLimitPerMinute int // Vote requests
+	ChatRateLimitPerMinute int // Chat messages
+
+	// Chat moderation
+	ChatDeleteWindowMinutes int    // Minutes in which authors may delete their own messages (0 = admins only)
+	ChatFilterMode          string // "off", "mask" or "reject" messages with blocked words - Default: mask
+	ChatFilterAdminBypass   bool   // Messages of admins are not filtered
+	ChatSlowModeSeconds     int    // Minimum seconds between two chat messages of the same user (0 = off), admins are exempt
 
 	// Ranking
-	MinVotesForRanking int // Minimum total votes before rankings are displayed
+	MinVotesForRanking int      // Minimum total votes before rankings are displayed
+	RankingTieBreakers []string // Rules for players with the same score, in order: earliest, fewest_negative, head_to_head
 
 	// Admin
-	AdminSteamIDs []string
-	AdminPassword string // Optional password for additional admin panel security
+	AdminSteamIDs []string     // Read with Admins or IsAdmin once the server runs, admins are added at runtime
+	adminMu       sync.RWMutex // Guards AdminSteamIDs
+	AdminPassword string       // Optional password for additional admin panel security
+
+	// Account review of new logins (ban evasion heuristics)
+	AccountReviewMinSignals int // Suspicious signals that hold a new account for admin review (0 = disabled)
+	AccountReviewMinAgeDays int // Steam accounts younger than this count as suspicious
 
 	// Games
-	PinnedGameIDs        []int  // App IDs of pinned/featured games
+	PinnedGameIDs        []int  // App IDs of pinned/featured games, seeds the pinned games stored in the DB
 	GameMetadataPath     string // Path to game_metadata.json (can be overridden via ConfigMap)
 
+	// Translations of server texts
+	I18nPath string // Directory with one <language>.json translation file per language
+
+	// Game news (Steam patch notes and announcements of the most commonly owned games)
+	GameNewsEnabled        bool // Fetch news periodically, disable for events without internet access
+	GameNewsTopGames       int  // Number of most commonly owned games to fetch news for (pinned games always included)
+	GameNewsRefreshMinutes int  // Interval between two news fetches
+	GameNewsMaxAgeDays     int  // News older than this are not shown
+
+	// Game deals (discounts and free weekends of pinned and commonly owned games)
+	GameDealsEnabled      bool // Watch the prices for deals
+	GameDealsMinOwners    int  // Minimum number of owners for a game to be watched (pinned games always included)
+	GameDealsCheckMinutes int  // Interval between two price refreshes and deal checks
+
+	// Game sessions (time spent in games during the party)
+	GameSessionPresenceMinutes int // Interval between two checks of the "currently playing" status of the Steam profiles (0 = manual sessions only)
+
+	// Wishlists (games the players wishlisted on Steam, for group purchases)
+	WishlistRefreshMinutes int // Interval between two fetches of all wishlists (0 = disabled)
+
 	// Countdown
 	CountdownTarget time.Time // Target time for countdown (when it reaches zero, voting pause is lifted)
+
+	// Download checklist
+	DownloadReminderMinutes []int // Minutes before the countdown target at which players with missing downloads are reminded
+
+	// Secret vote reveal
+	SecretRevealAt time.Time // Time at which all secret votes are revealed (zero = no reveal scheduled)
+
+	// Event phases
+	ActivePhase string // Name of the event phase whose settings profile is active (empty = none)
+
+	// Event timezone
+	EventTimezone string         // IANA timezone name of the event, e.g. "Europe/Berlin"
+	EventLocation *time.Location // Loaded EventTimezone, server-generated timestamps are rendered in it
+
+	// Data retention
+	EventEndAt         time.Time // End of the event (zero = no anonymization scheduled)
+	AnonymizeAfterDays int       // Days after the event end until personal data is anonymized
+
+	// WebSocket connection limits (0 = unlimited)
+	WSMaxConnectionsPerUser int // Simultaneous connections per user (tabs/devices), the oldest is closed when exceeded
+	WSMaxConnections        int // Total connections of the hub, new users are rejected when reached
+	WSCompressionLevel      int // permessage-deflate level of WebSocket messages (0 = off, 1 = fastest, 9 = smallest)
+
+	// WebSocket fan-out between backend instances
+	WSRedisURL     string // redis:// or rediss:// URL relaying broadcasts between instances (empty = single instance)
+	WSRedisChannel string // Pub/sub channel shared by the instances
+
+	// Prometheus scrape endpoint GET /api/v1/metrics (empty = disabled)
+	MetricsPrometheusToken string // Bearer token scrapers must send
+
+	// LAN-only mode
+	LANAllowedCIDRs []string     // CIDRs of the venue LAN (empty = LAN-only mode disabled)
+	LANAllowedNets  []*net.IPNet // Parsed LANAllowedCIDRs
+	LANOnlyMode     string       // "writes" restricts state-changing requests, "all" the whole API except public read-only endpoints
+	TrustedProxies  []string     // Proxies whose X-Forwarded-For header is trusted for the client IP (empty = none, the connection address is used)
+
+	// Data warehouse export: votes, chat and ranking snapshots as Parquet files on S3/MinIO
+	WarehouseExportIntervalMinutes int    // Minutes between two exports (0 = disabled)
+	WarehouseS3Endpoint            string // e.g. https://s3.eu-central-1.amazonaws.com or http://minio:9000
+	WarehouseS3Region              string
+	WarehouseS3Bucket              string
+	WarehouseS3AccessKey           string
+	WarehouseS3SecretKey           string
+	WarehouseS3Prefix              string // Key prefix of the exported files, e.g. "rate-your-mate/"
+
+	// SMTP mail delivery (empty host = mail disabled)
+	SMTPHost     string
+	SMTPPort     int // 465 uses implicit TLS, other ports STARTTLS if the server offers it
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string // Sender address, e.g. "Rate your Mate <noreply@example.com>"
+
+	// Organizer digest e-mail
+	DigestRecipients []string // E-mail addresses of the organizers (empty = digest disabled)
+	DigestTime       string   // Time of day the digest is sent, "HH:MM" in the event timezone
+
+	loadedValues map[string]string // Values right after Load, used by Describe to detect runtime overrides
 }
 
 // Load reads configuration from environment variables
@@ -80,10 +210,19 @@ func Load() *Config {
 		FrontendURL: getEnv("FRONTEND_URL", "http://localhost:4200"),
 		BackendURL:  getEnv("BACKEND_URL", "http://localhost:8080"),
 
+		// mDNS / Zeroconf
+		MDNSEnabled:      getEnvAsBool("MDNS_ENABLED", false),
+		MDNSInstanceName: getEnv("MDNS_INSTANCE_NAME", "Rate your Mate"),
+
 		// Database
 		DBType: getEnv("DB_TYPE", "sqlite"),
 		DBPath: getEnv("DB_PATH", "data/rate-your-mate.db"),
 
+		// SQLite backups
+		BackupDir:             getEnv("BACKUP_DIR", "data/backups"),
+		BackupIntervalMinutes: getEnvAsInt("BACKUP_INTERVAL_MINUTES", 360),
+		BackupRetention:       getEnvAsInt("BACKUP_RETENTION", 12),
+
 		// MySQL
 		MySQLHost:            getEnv("MYSQL_HOST", "localhost"),
 		MySQLPort:            getEnvAsInt("MYSQL_PORT", 3306),
@@ -103,6 +242,17 @@ func Load() *Config {
 		JWTSecret:         getEnv("JWT_SECRET", ""),
 		JWTExpirationDays: getEnvAsInt("JWT_EXPIRATION_DAYS", 7),
 
+		// Steam game sync
+		SteamSyncConcurrency:        getEnvAsInt("STEAM_SYNC_CONCURRENCY", 4),
+		SteamStoreRequestsPerMinute: getEnvAsInt("STEAM_STORE_REQUESTS_PER_MINUTE", 120),
+		StoreCountry:                strings.ToLower(getEnv("STORE_COUNTRY", "de")),
+		StoreLanguage:               strings.ToLower(getEnv("STORE_LANGUAGE", "english")),
+
+		// Background refresh of stale game cache entries
+		CacheRefreshBatchSize: getEnvAsInt("CACHE_REFRESH_BATCH_SIZE", 20),
+		CacheRefreshMinutes:   getEnvAsInt("CACHE_REFRESH_MINUTES", 10),
+		CacheRefreshWindow:    getEnv("CACHE_REFRESH_WINDOW", "01:00-07:00"),
+
 		// Credits
 		CreditIntervalMinutes: getEnvAsInt("CREDIT_INTERVAL_MINUTES", 10),
 		CreditMax:             getEnvAsInt("CREDIT_MAX", 10),
@@ -110,24 +260,223 @@ func Load() *Config {
 		// Voting visibility - default to user choice
 		VoteVisibilityMode: getEnv("VOTE_VISIBILITY_MODE", "user_choice"),
 
+		// Daily per-achievement vote limits, e.g. "toxic:3,noob:5"
+		AchievementDailyLimits: getEnvAsIntMap("ACHIEVEMENT_DAILY_LIMITS", map[string]int{}),
+
+		// Vote streaks
+		StreakThreshold:     getEnvAsInt("STREAK_THRESHOLD", 3),
+		StreakWindowMinutes: getEnvAsInt("STREAK_WINDOW_MINUTES", 60),
+		StreakBonusCredits:  getEnvAsInt("STREAK_BONUS_CREDITS", 1),
+
+		// Repeat vote escalation
+		RepeatVoteEscalationMinutes: getEnvAsInt("REPEAT_VOTE_ESCALATION_MINUTES", 0),
+		RepeatVoteEscalationMax:     getEnvAsInt("REPEAT_VOTE_ESCALATION_MAX", 3),
+
+		// Quick vote
+		QuickVoteCooldownSeconds: getEnvAsInt("QUICKVOTE_COOLDOWN_SECONDS", 10),
+
+		// Rate limits
+		VoteRateLimitPerMinute: getEnvAsInt("VOTE_RATE_LIMIT_PER_MINUTE", 30),
+		ChatRateLimitPerMinute: getEnvAsInt("CHAT_RATE_LIMIT_PER_MINUTE", 20),
+
+		// Chat moderation
+		ChatDeleteWindowMinutes: getEnvAsInt("CHAT_DELETE_WINDOW_MINUTES", 5),
+		ChatFilterMode:          getEnv("CHAT_FILTER_MODE", "mask"),
+		ChatFilterAdminBypass:   getEnvAsBool("CHAT_FILTER_ADMIN_BYPASS", false),
+		ChatSlowModeSeconds:     getEnvAsInt("CHAT_SLOW_MODE_SECONDS", 0),
+
 		// Ranking
 		MinVotesForRanking: getEnvAsInt("MIN_VOTES_FOR_RANKING", 10),
+		RankingTieBreakers: getEnvAsStringSlice("RANKING_TIE_BREAKERS", []string{"earliest", "fewest_negative", "head_to_head"}),
 
 		// Admin
 		AdminSteamIDs: getEnvAsStringSlice("ADMIN_STEAM_IDS", []string{}),
 		AdminPassword: getEnv("ADMIN_PASSWORD", ""),
+
+		// Account review
+		AccountReviewMinSignals: getEnvAsInt("ACCOUNT_REVIEW_MIN_SIGNALS", 2),
+		AccountReviewMinAgeDays: getEnvAsInt("ACCOUNT_REVIEW_MIN_AGE_DAYS", 30),
+
 		PinnedGameIDs: getEnvAsIntSlice("PINNED_GAME_IDS", []int{}),
 
 		// Game Metadata (default path, can be overridden via ConfigMap mount in K8s)
 		GameMetadataPath: getEnv("GAME_METADATA_PATH", "defaults/game_metadata.json"),
 
+		// Translations (default path, can be overridden via ConfigMap mount in K8s)
+		I18nPath: getEnv("I18N_PATH", "defaults/i18n"),
+
+		// Game news
+		GameNewsEnabled:        getEnvAsBool("GAME_NEWS_ENABLED", true),
+		GameNewsTopGames:       getEnvAsInt("GAME_NEWS_TOP_GAMES", 10),
+		GameNewsRefreshMinutes: getEnvAsInt("GAME_NEWS_REFRESH_MINUTES", 30),
+		GameNewsMaxAgeDays:     getEnvAsInt("GAME_NEWS_MAX_AGE_DAYS", 7),
+
+		// Game deals
+		GameDealsEnabled:      getEnvAsBool("GAME_DEALS_ENABLED", true),
+		GameDealsMinOwners:    getEnvAsInt("GAME_DEALS_MIN_OWNERS", 3),
+		GameDealsCheckMinutes: getEnvAsInt("GAME_DEALS_CHECK_MINUTES", 30),
+
+		// Game sessions
+		GameSessionPresenceMinutes: getEnvAsInt("GAME_SESSION_PRESENCE_MINUTES", 2),
+
+		// Wishlists
+		WishlistRefreshMinutes: getEnvAsInt("WISHLIST_REFRESH_MINUTES", 360),
+
 		// Countdown
 		CountdownTarget: getEnvAsTime("COUNTDOWN_TARGET", time.Time{}),
+
+		// Download checklist
+		DownloadReminderMinutes: getEnvAsIntSlice("DOWNLOAD_REMINDER_MINUTES", []int{1440, 120}),
+
+		// Secret vote reveal
+		SecretRevealAt: getEnvAsTime("SECRET_REVEAL_AT", time.Time{}),
+
+		// Event timezone
+		EventTimezone: getEnv("EVENT_TIMEZONE", "UTC"),
+
+		// Data retention
+		EventEndAt:         getEnvAsTime("EVENT_END_AT", time.Time{}),
+		AnonymizeAfterDays: getEnvAsInt("ANONYMIZE_AFTER_DAYS", 30),
+
+		// WebSocket connection limits
+		WSMaxConnectionsPerUser: getEnvAsInt("WS_MAX_CONNECTIONS_PER_USER", 5),
+		WSMaxConnections:        getEnvAsInt("WS_MAX_CONNECTIONS", 1000),
+		WSCompressionLevel:      getEnvAsInt("WS_COMPRESSION_LEVEL", 1),
+
+		// WebSocket fan-out between backend instances
+		WSRedisURL:     getEnv("WS_REDIS_URL", ""),
+		WSRedisChannel: getEnv("WS_REDIS_CHANNEL", "rate-your-mate:websocket"),
+
+		// Prometheus scrape endpoint
+		MetricsPrometheusToken: getEnv("METRICS_PROMETHEUS_TOKEN", ""),
+
+		// LAN-only mode
+		LANAllowedCIDRs: getEnvAsStringSlice("LAN_ALLOWED_CIDRS", []string{}),
+		LANOnlyMode:     getEnv("LAN_ONLY_MODE", "writes"),
+		TrustedProxies:  getEnvAsStringSlice("TRUSTED_PROXIES", []string{}),
+
+		// Data warehouse export
+		WarehouseExportIntervalMinutes: getEnvAsInt("WAREHOUSE_EXPORT_INTERVAL_MINUTES", 0),
+		WarehouseS3Endpoint:            getEnv("WAREHOUSE_S3_ENDPOINT", "https://s3.amazonaws.com"),
+		WarehouseS3Region:              getEnv("WAREHOUSE_S3_REGION", "us-east-1"),
+		WarehouseS3Bucket:              getEnv("WAREHOUSE_S3_BUCKET", ""),
+		WarehouseS3AccessKey:           getEnv("WAREHOUSE_S3_ACCESS_KEY", ""),
+		WarehouseS3SecretKey:           getEnv("WAREHOUSE_S3_SECRET_KEY", ""),
+		WarehouseS3Prefix:              getEnv("WAREHOUSE_S3_PREFIX", "rate-your-mate/"),
+
+		// SMTP mail delivery
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     getEnvAsInt("SMTP_PORT", 587),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:     getEnv("SMTP_FROM", ""),
+
+		// Organizer digest e-mail
+		DigestRecipients: getEnvAsStringSlice("DIGEST_RECIPIENTS", []string{}),
+		DigestTime:       getEnv("DIGEST_TIME", "08:00"),
+	}
+
+	// Resolve the event timezone (falls back to UTC)
+	location, err := time.LoadLocation(cfg.EventTimezone)
+	if err != nil {
+		log.Printf("WARNING: Unknown EVENT_TIMEZONE %q, using UTC", cfg.EventTimezone)
+		location = time.UTC
+	}
+	cfg.EventLocation = location
+
+	// Validate the digest time
+	if _, err := time.Parse("15:04", cfg.DigestTime); err != nil {
+		log.Printf("WARNING: Invalid DIGEST_TIME %q, using 08:00", cfg.DigestTime)
+		cfg.DigestTime = "08:00"
+	}
+
+	// Parse the LAN allowlist, single IPs are accepted as /32 or /128
+	for _, cidr := range cfg.LANAllowedCIDRs {
+		if !strings.Contains(cidr, "/") {
+			if ip := net.ParseIP(cidr); ip != nil && ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			log.Fatalf("FATAL: Invalid LAN_ALLOWED_CIDRS entry %q: %v", cidr, err)
+		}
+		cfg.LANAllowedNets = append(cfg.LANAllowedNets, ipNet)
+	}
+	if cfg.LANOnlyMode != "writes" && cfg.LANOnlyMode != "all" {
+		log.Printf("WARNING: Unknown LAN_ONLY_MODE %q, using \"writes\"", cfg.LANOnlyMode)
+		cfg.LANOnlyMode = "writes"
 	}
 
+	if cfg.ChatFilterMode != "off" && cfg.ChatFilterMode != "mask" && cfg.ChatFilterMode != "reject" {
+		log.Printf("WARNING: Unknown CHAT_FILTER_MODE %q, using \"mask\"", cfg.ChatFilterMode)
+		cfg.ChatFilterMode = "mask"
+	}
+	if cfg.ChatSlowModeSeconds < 0 || cfg.ChatSlowModeSeconds > 3600 {
+		log.Printf("WARNING: CHAT_SLOW_MODE_SECONDS must be between 0 and 3600, disabling slow mode")
+		cfg.ChatSlowModeSeconds = 0
+	}
+	if cfg.SteamSyncConcurrency < 1 || cfg.SteamSyncConcurrency > 16 {
+		log.Printf("WARNING: STEAM_SYNC_CONCURRENCY must be between 1 and 16, using 4")
+		cfg.SteamSyncConcurrency = 4
+	}
+	if cfg.SteamStoreRequestsPerMinute < 1 {
+		log.Printf("WARNING: STEAM_STORE_REQUESTS_PER_MINUTE must be positive, using 120")
+		cfg.SteamStoreRequestsPerMinute = 120
+	}
+	if !IsValidStoreCountry(cfg.StoreCountry) {
+		log.Printf("WARNING: STORE_COUNTRY must be a two-letter country code, using \"de\"")
+		cfg.StoreCountry = "de"
+	}
+	if !IsValidStoreLanguage(cfg.StoreLanguage) {
+		log.Printf("WARNING: Unknown STORE_LANGUAGE %q, using \"english\"", cfg.StoreLanguage)
+		cfg.StoreLanguage = "english"
+	}
+	if cfg.CacheRefreshBatchSize < 0 {
+		log.Printf("WARNING: CACHE_REFRESH_BATCH_SIZE must not be negative, disabling the background refresh")
+		cfg.CacheRefreshBatchSize = 0
+	}
+	if cfg.CacheRefreshMinutes < 1 {
+		log.Printf("WARNING: CACHE_REFRESH_MINUTES must be positive, using 10")
+		cfg.CacheRefreshMinutes = 10
+	}
+	if _, _, ok := ParseClockWindow(cfg.CacheRefreshWindow); !ok {
+		log.Printf("WARNING: Invalid CACHE_REFRESH_WINDOW %q, refreshing all day", cfg.CacheRefreshWindow)
+		cfg.CacheRefreshWindow = ""
+	}
+	if cfg.GameSessionPresenceMinutes < 0 {
+		log.Printf("WARNING: GAME_SESSION_PRESENCE_MINUTES must not be negative, using manual sessions only")
+		cfg.GameSessionPresenceMinutes = 0
+	}
+	if cfg.WishlistRefreshMinutes < 0 {
+		log.Printf("WARNING: WISHLIST_REFRESH_MINUTES must not be negative, disabling wishlists")
+		cfg.WishlistRefreshMinutes = 0
+	}
+	if cfg.WSCompressionLevel < 0 || cfg.WSCompressionLevel > 9 {
+		log.Printf("WARNING: WS_COMPRESSION_LEVEL must be between 0 and 9, using 1")
+		cfg.WSCompressionLevel = 1
+	}
+
+	// Unknown tie-break rules are dropped, "none" leaves only the username to order tied players
+	tieBreakers := make([]string, 0, len(cfg.RankingTieBreakers))
+	for _, rule := range cfg.RankingTieBreakers {
+		switch rule {
+		case "earliest", "fewest_negative", "head_to_head":
+			tieBreakers = append(tieBreakers, rule)
+		case "none":
+		default:
+			log.Printf("WARNING: Unknown RANKING_TIE_BREAKERS rule %q, ignoring it", rule)
+		}
+	}
+	cfg.RankingTieBreakers = tieBreakers
+
 	// Validate required configuration
 	cfg.validate()
 
+	cfg.loadedValues = cfg.snapshotValues()
+
 	return cfg
 }
 
@@ -214,6 +563,29 @@ func getEnvAsIntSlice(key string, defaultValue []int) []int {
 	return defaultValue
 }
 
+// getEnvAsIntMap reads an environment variable as a comma-separated list of key:value pairs with integer values
+// Invalid pairs are skipped with a warning
+func getEnvAsIntMap(key string, defaultValue map[string]int) map[string]int {
+	if value, exists := os.LookupEnv(key); exists && value != "" {
+		result := make(map[string]int)
+		for _, part := range strings.Split(value, ",") {
+			trimmed := strings.TrimSpace(part)
+			if trimmed == "" {
+				continue
+			}
+			k, v, found := strings.Cut(trimmed, ":")
+			intValue, err := strconv.Atoi(strings.TrimSpace(v))
+			if !found || err != nil {
+				log.Printf("WARNING: Ignoring invalid entry in %s: %s", key, trimmed)
+				continue
+			}
+			result[strings.TrimSpace(k)] = intValue
+		}
+		return result
+	}
+	return defaultValue
+}
+
 // getEnvAsTime reads an environment variable as a time.Time (RFC3339 format) or returns a default value
 // Supports formats like "2024-12-31T18:00:00Z" or "2024-12-31T19:00:00+01:00"
 func getEnvAsTime(key string, defaultValue time.Time) time.Time {
@@ -226,8 +598,21 @@ func getEnvAsTime(key string, defaultValue time.Time) time.Time {
 	return defaultValue
 }
 
+// IsLANAddress checks if the given IP is inside the LAN allowlist
+func (c *Config) IsLANAddress(ip net.IP) bool {
+	for _, ipNet := range c.LANAllowedNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsAdmin checks if the given Steam ID is in the admin list
 func (c *Config) IsAdmin(steamID string) bool {
+	c.adminMu.RLock()
+	defer c.adminMu.RUnlock()
+
 	for _, adminID := range c.AdminSteamIDs {
 		if adminID == steamID {
 			return true
@@ -235,3 +620,83 @@ func (c *Config) IsAdmin(steamID string) bool {
 	}
 	return false
 }
+
+// Admins returns a copy of the admin list
+func (c *Config) Admins() []string {
+	c.adminMu.RLock()
+	defer c.adminMu.RUnlock()
+
+	admins := make([]string, len(c.AdminSteamIDs))
+	copy(admins, c.AdminSteamIDs)
+	return admins
+}
+
+// AddAdmins adds Steam IDs to the admin list, IDs already in the list are skipped
+func (c *Config) AddAdmins(steamIDs ...string) {
+	c.adminMu.Lock()
+	defer c.adminMu.Unlock()
+
+	admins := make([]string, len(c.AdminSteamIDs), len(c.AdminSteamIDs)+len(steamIDs))
+	copy(admins, c.AdminSteamIDs)
+	known := make(map[string]bool, len(admins))
+	for _, steamID := range admins {
+		known[steamID] = true
+	}
+	for _, steamID := range steamIDs {
+		if !known[steamID] {
+			known[steamID] = true
+			admins = append(admins, steamID)
+		}
+	}
+	c.AdminSteamIDs = admins
+}
+
+// storeLanguages are the language names the Steam Store API accepts
+var storeLanguages = map[string]bool{
+	"arabic": true, "brazilian": true, "bulgarian": true, "czech": true, "danish": true, "dutch": true,
+	"english": true, "finnish": true, "french": true, "german": true, "greek": true, "hungarian": true,
+	"indonesian": true, "italian": true, "japanese": true, "koreana": true, "latam": true, "norwegian": true,
+	"polish": true, "portuguese": true, "romanian": true, "russian": true, "schinese": true, "spanish": true,
+	"swedish": true, "tchinese": true, "thai": true, "turkish": true, "ukrainian": true, "vietnamese": true,
+}
+
+// ParseClockWindow parses a time of day window "HH:MM-HH:MM" into offsets from midnight
+// The end may be before the start for windows over midnight, an empty window covers the whole day
+func ParseClockWindow(window string) (start, end time.Duration, ok bool) {
+	if window == "" {
+		return 0, 24 * time.Hour, true
+	}
+	from, to, found := strings.Cut(window, "-")
+	if !found {
+		return 0, 0, false
+	}
+	startTime, err := time.Parse("15:04", strings.TrimSpace(from))
+	if err != nil {
+		return 0, 0, false
+	}
+	endTime, err := time.Parse("15:04", strings.TrimSpace(to))
+	if err != nil {
+		return 0, 0, false
+	}
+	start = time.Duration(startTime.Hour())*time.Hour + time.Duration(startTime.Minute())*time.Minute
+	end = time.Duration(endTime.Hour())*time.Hour + time.Duration(endTime.Minute())*time.Minute
+	return start, end, true
+}
+
+// IsValidStoreCountry checks if the country is a two-letter lowercase code
+func IsValidStoreCountry(country string) bool {
+	if len(country) != 2 {
+		return false
+	}
+	for _, r := range country {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValidStoreLanguage checks if the language is accepted by the Steam Store API
+func IsValidStoreLanguage(language string) bool {
+	return storeLanguages[language]
+}
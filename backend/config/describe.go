@@ -0,0 +1,191 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Sources of an effective configuration value
+const (
+	SourceEnv      = "env"      // Set via environment variable or .env file
+	SourceDefault  = "default"  // Built-in default
+	SourceOverride = "override" // Changed at runtime (admin settings, settings profiles, event phases, admins granted in the database)
+)
+
+// redactedValue replaces secrets that are set
+const redactedValue = "********"
+
+// Entry describes one effective configuration value
+type Entry struct {
+	Key         string      `json:"key"`  // Environment variable name, empty for runtime-only settings
+	Name        string      `json:"name"` // Field name of the setting
+	Description string      `json:"description"`
+	Value       interface{} `json:"value"`  // Redacted for secrets
+	Source      string      `json:"source"` // env, default or override
+	Secret      bool        `json:"secret,omitempty"`
+	IsSet       bool        `json:"is_set"` // Whether the value is non-empty, useful for redacted secrets
+}
+
+// entryDef defines a documented configuration value
+type entryDef struct {
+	key         string
+	name        string
+	description string
+	secret      bool
+	value       func(c *Config) interface{}
+}
+
+// entryDefs lists all documented configuration values in the order of Load
+var entryDefs = []entryDef{
+	{"PORT", "Port", "HTTP port of the backend", false, func(c *Config) interface{} { return c.Port }},
+	{"FRONTEND_URL", "FrontendURL", "Public URL of the frontend, used for CORS and login redirects", false, func(c *Config) interface{} { return c.FrontendURL }},
+	{"BACKEND_URL", "BackendURL", "Public URL of the backend, used for the Steam callback and short links", false, func(c *Config) interface{} { return c.BackendURL }},
+	{"MDNS_ENABLED", "MDNSEnabled", "Advertise the backend on the LAN via mDNS", false, func(c *Config) interface{} { return c.MDNSEnabled }},
+	{"MDNS_INSTANCE_NAME", "MDNSInstanceName", "Instance name shown to discovering clients", false, func(c *Config) interface{} { return c.MDNSInstanceName }},
+	{"DB_TYPE", "DBType", "Database backend: sqlite or mysql", false, func(c *Config) interface{} { return c.DBType }},
+	{"DB_PATH", "DBPath", "SQLite database path", false, func(c *Config) interface{} { return c.DBPath }},
+	{"BACKUP_DIR", "BackupDir", "Directory of the SQLite backup snapshots", false, func(c *Config) interface{} { return c.BackupDir }},
+	{"BACKUP_INTERVAL_MINUTES", "BackupIntervalMinutes", "Minutes between two scheduled SQLite backups (0 = disabled)", false, func(c *Config) interface{} { return c.BackupIntervalMinutes }},
+	{"BACKUP_RETENTION", "BackupRetention", "Number of SQLite backups to keep (0 = all)", false, func(c *Config) interface{} { return c.BackupRetention }},
+	{"MYSQL_HOST", "MySQLHost", "MySQL host", false, func(c *Config) interface{} { return c.MySQLHost }},
+	{"MYSQL_PORT", "MySQLPort", "MySQL port", false, func(c *Config) interface{} { return c.MySQLPort }},
+	{"MYSQL_USER", "MySQLUser", "MySQL user", false, func(c *Config) interface{} { return c.MySQLUser }},
+	{"MYSQL_PASSWORD", "MySQLPassword", "MySQL password", true, func(c *Config) interface{} { return c.MySQLPassword }},
+	{"MYSQL_DATABASE", "MySQLDatabase", "MySQL database name", false, func(c *Config) interface{} { return c.MySQLDatabase }},
+	{"MYSQL_TLS_ENABLED", "MySQLTLSEnabled", "Connect to MySQL via TLS", false, func(c *Config) interface{} { return c.MySQLTLSEnabled }},
+	{"MYSQL_TLS_SKIP_VERIFY", "MySQLTLSSkipVerify", "Skip verification of the MySQL server certificate", false, func(c *Config) interface{} { return c.MySQLTLSSkipVerify }},
+	{"MYSQL_TLS_CA_CERT", "MySQLTLSCACert", "Path to the CA certificate of the MySQL server", false, func(c *Config) interface{} { return c.MySQLTLSCACert }},
+	{"MYSQL_MAX_OPEN_CONNS", "MySQLMaxOpenConns", "Maximum open MySQL connections", false, func(c *Config) interface{} { return c.MySQLMaxOpenConns }},
+	{"MYSQL_MAX_IDLE_CONNS", "MySQLMaxIdleConns", "Maximum idle MySQL connections", false, func(c *Config) interface{} { return c.MySQLMaxIdleConns }},
+	{"MYSQL_CONN_MAX_LIFETIME", "MySQLConnMaxLifetime", "Maximum lifetime of a MySQL connection", false, func(c *Config) interface{} { return c.MySQLConnMaxLifetime.String() }},
+	{"MYSQL_CONN_MAX_IDLE_TIME", "MySQLConnMaxIdleTime", "Maximum idle time of a MySQL connection", false, func(c *Config) interface{} { return c.MySQLConnMaxIdleTime.String() }},
+	{"STEAM_API_KEY", "SteamAPIKey", "Steam Web API key for profiles and game libraries", true, func(c *Config) interface{} { return c.SteamAPIKey }},
+	{"STEAM_SYNC_CONCURRENCY", "SteamSyncConcurrency", "Parallel store data fetches during a game sync", false, func(c *Config) interface{} { return c.SteamSyncConcurrency }},
+	{"STEAM_STORE_REQUESTS_PER_MINUTE", "SteamStoreRequestsPerMinute", "Shared limit of all Steam Store requests", false, func(c *Config) interface{} { return c.SteamStoreRequestsPerMinute }},
+	{"STORE_COUNTRY", "StoreCountry", "Steam Store country code for currency and prices", false, func(c *Config) interface{} { return c.StoreCountry }},
+	{"STORE_LANGUAGE", "StoreLanguage", "Steam Store language of game names and genres", false, func(c *Config) interface{} { return c.StoreLanguage }},
+	{"CACHE_REFRESH_BATCH_SIZE", "CacheRefreshBatchSize", "Stale games refreshed per background run (0 = disabled)", false, func(c *Config) interface{} { return c.CacheRefreshBatchSize }},
+	{"CACHE_REFRESH_MINUTES", "CacheRefreshMinutes", "Minutes between two background refresh runs", false, func(c *Config) interface{} { return c.CacheRefreshMinutes }},
+	{"CACHE_REFRESH_WINDOW", "CacheRefreshWindow", "Time of day (HH:MM-HH:MM, event timezone) the background refresh runs", false, func(c *Config) interface{} { return c.CacheRefreshWindow }},
+	{"JWT_SECRET", "JWTSecret", "Secret used to sign login tokens", true, func(c *Config) interface{} { return c.JWTSecret }},
+	{"JWT_EXPIRATION_DAYS", "JWTExpirationDays", "Days until a login token expires", false, func(c *Config) interface{} { return c.JWTExpirationDays }},
+	{"CREDIT_INTERVAL_MINUTES", "CreditIntervalMinutes", "Minutes between two earned credits", false, func(c *Config) interface{} { return c.CreditIntervalMinutes }},
+	{"CREDIT_MAX", "CreditMax", "Maximum credits a player can hold", false, func(c *Config) interface{} { return c.CreditMax }},
+	{"", "VotingPaused", "Voting is paused by an admin or the countdown", false, func(c *Config) interface{} { return c.VotingPaused }},
+	{"VOTE_VISIBILITY_MODE", "VoteVisibilityMode", "Sender visibility: user_choice, all_secret or all_public", false, func(c *Config) interface{} { return c.VoteVisibilityMode }},
+	{"", "NegativeVotingDisabled", "Negative achievements cannot be voted", false, func(c *Config) interface{} { return c.NegativeVotingDisabled }},
+	{"ACHIEVEMENT_DAILY_LIMITS", "AchievementDailyLimits", "Max votes per voter and day per achievement ID", false, func(c *Config) interface{} { return c.AchievementDailyLimits }},
+	{"STREAK_THRESHOLD", "StreakThreshold", "Different voters needed for an on fire streak", false, func(c *Config) interface{} { return c.StreakThreshold }},
+	{"STREAK_WINDOW_MINUTES", "StreakWindowMinutes", "Time window of a streak in minutes", false, func(c *Config) interface{} { return c.StreakWindowMinutes }},
+	{"STREAK_BONUS_CREDITS", "StreakBonusCredits", "Bonus credits for the target of a streak", false, func(c *Config) interface{} { return c.StreakBonusCredits }},
+	{"REPEAT_VOTE_ESCALATION_MINUTES", "RepeatVoteEscalationMinutes", "Window in which repeated negative votes on the same target cost more credits (0 = disabled)", false, func(c *Config) interface{} { return c.RepeatVoteEscalationMinutes }},
+	{"REPEAT_VOTE_ESCALATION_MAX", "RepeatVoteEscalationMax", "Maximum credit multiplier of repeated negative votes", false, func(c *Config) interface{} { return c.RepeatVoteEscalationMax }},
+	{"QUICKVOTE_COOLDOWN_SECONDS", "QuickVoteCooldownSeconds", "Minimum seconds between two quick votes of a user", false, func(c *Config) interface{} { return c.QuickVoteCooldownSeconds }},
+	{"VOTE_RATE_LIMIT_PER_MINUTE", "VoteRateLimitPerMinute", "Vote requests per user and minute (0 = unlimited)", false, func(c *Config) interface{} { return c.VoteRateLimitPerMinute }},
+	{"CHAT_RATE_LIMIT_PER_MINUTE", "ChatRateLimitPerMinute", "Chat messages per user and minute (0 = unlimited)", false, func(c *Config) interface{} { return c.ChatRateLimitPerMinute }},
+	{"CHAT_DELETE_WINDOW_MINUTES", "ChatDeleteWindowMinutes", "Minutes in which authors may delete their own chat messages (0 = admins only)", false, func(c *Config) interface{} { return c.ChatDeleteWindowMinutes }},
+	{"CHAT_FILTER_MODE", "ChatFilterMode", "Handling of chat messages with blocked words: off, mask or reject", false, func(c *Config) interface{} { return c.ChatFilterMode }},
+	{"CHAT_FILTER_ADMIN_BYPASS", "ChatFilterAdminBypass", "Messages of admins are not filtered", false, func(c *Config) interface{} { return c.ChatFilterAdminBypass }},
+	{"CHAT_SLOW_MODE_SECONDS", "ChatSlowModeSeconds", "Minimum seconds between two chat messages of the same user (0 = off)", false, func(c *Config) interface{} { return c.ChatSlowModeSeconds }},
+	{"MIN_VOTES_FOR_RANKING", "MinVotesForRanking", "Total votes needed before the ranking is shown", false, func(c *Config) interface{} { return c.MinVotesForRanking }},
+	{"RANKING_TIE_BREAKERS", "RankingTieBreakers", "Tie-break rules for players with the same score, in order: earliest, fewest_negative, head_to_head", false, func(c *Config) interface{} { return c.RankingTieBreakers }},
+	{"ADMIN_STEAM_IDS", "AdminSteamIDs", "Steam IDs with admin privileges, including admins granted in the database", false, func(c *Config) interface{} { return c.Admins() }},
+	{"ADMIN_PASSWORD", "AdminPassword", "Optional password for elevated admin actions", true, func(c *Config) interface{} { return c.AdminPassword }},
+	{"ACCOUNT_REVIEW_MIN_SIGNALS", "AccountReviewMinSignals", "Suspicious signals that hold a new account for admin review (0 = disabled)", false, func(c *Config) interface{} { return c.AccountReviewMinSignals }},
+	{"ACCOUNT_REVIEW_MIN_AGE_DAYS", "AccountReviewMinAgeDays", "Steam accounts younger than this many days count as suspicious", false, func(c *Config) interface{} { return c.AccountReviewMinAgeDays }},
+	{"PINNED_GAME_IDS", "PinnedGameIDs", "App IDs that seed the pinned games on the first start, admins manage them at runtime", false, func(c *Config) interface{} { return c.PinnedGameIDs }},
+	{"GAME_METADATA_PATH", "GameMetadataPath", "Path to game_metadata.json", false, func(c *Config) interface{} { return c.GameMetadataPath }},
+	{"I18N_PATH", "I18nPath", "Directory with the translation files of server texts", false, func(c *Config) interface{} { return c.I18nPath }},
+	{"GAME_NEWS_ENABLED", "GameNewsEnabled", "Fetch Steam news of commonly owned games", false, func(c *Config) interface{} { return c.GameNewsEnabled }},
+	{"GAME_NEWS_TOP_GAMES", "GameNewsTopGames", "Number of most commonly owned games to fetch news for", false, func(c *Config) interface{} { return c.GameNewsTopGames }},
+	{"GAME_NEWS_REFRESH_MINUTES", "GameNewsRefreshMinutes", "Minutes between two news fetches", false, func(c *Config) interface{} { return c.GameNewsRefreshMinutes }},
+	{"GAME_NEWS_MAX_AGE_DAYS", "GameNewsMaxAgeDays", "News older than this are not shown", false, func(c *Config) interface{} { return c.GameNewsMaxAgeDays }},
+	{"GAME_DEALS_ENABLED", "GameDealsEnabled", "Watch prices for discounts and free weekends", false, func(c *Config) interface{} { return c.GameDealsEnabled }},
+	{"GAME_DEALS_MIN_OWNERS", "GameDealsMinOwners", "Minimum number of owners for a game to be watched for deals", false, func(c *Config) interface{} { return c.GameDealsMinOwners }},
+	{"GAME_DEALS_CHECK_MINUTES", "GameDealsCheckMinutes", "Minutes between two deal checks, each refreshes the watched prices", false, func(c *Config) interface{} { return c.GameDealsCheckMinutes }},
+	{"GAME_SESSION_PRESENCE_MINUTES", "GameSessionPresenceMinutes", "Minutes between two checks of the Steam playing status (0 = manual sessions only)", false, func(c *Config) interface{} { return c.GameSessionPresenceMinutes }},
+	{"WISHLIST_REFRESH_MINUTES", "WishlistRefreshMinutes", "Minutes between two fetches of all Steam wishlists (0 = disabled)", false, func(c *Config) interface{} { return c.WishlistRefreshMinutes }},
+	{"COUNTDOWN_TARGET", "CountdownTarget", "Event start, voting is unpaused when the countdown ends", false, func(c *Config) interface{} { return describeTime(c.CountdownTarget) }},
+	{"DOWNLOAD_REMINDER_MINUTES", "DownloadReminderMinutes", "Minutes before the countdown target at which missing downloads are reminded", false, func(c *Config) interface{} { return c.DownloadReminderMinutes }},
+	{"SECRET_REVEAL_AT", "SecretRevealAt", "Time at which all secret votes are revealed", false, func(c *Config) interface{} { return describeTime(c.SecretRevealAt) }},
+	{"", "ActivePhase", "Event phase whose settings profile is active", false, func(c *Config) interface{} { return c.ActivePhase }},
+	{"EVENT_TIMEZONE", "EventTimezone", "IANA timezone of the event", false, func(c *Config) interface{} { return c.EventTimezone }},
+	{"EVENT_END_AT", "EventEndAt", "End of the event, personal data is anonymized afterwards", false, func(c *Config) interface{} { return describeTime(c.EventEndAt) }},
+	{"ANONYMIZE_AFTER_DAYS", "AnonymizeAfterDays", "Days after the event end until personal data is anonymized", false, func(c *Config) interface{} { return c.AnonymizeAfterDays }},
+	{"WS_MAX_CONNECTIONS_PER_USER", "WSMaxConnectionsPerUser", "Simultaneous WebSocket connections per user (0 = unlimited)", false, func(c *Config) interface{} { return c.WSMaxConnectionsPerUser }},
+	{"WS_MAX_CONNECTIONS", "WSMaxConnections", "Total WebSocket connections (0 = unlimited)", false, func(c *Config) interface{} { return c.WSMaxConnections }},
+	{"WS_COMPRESSION_LEVEL", "WSCompressionLevel", "Compression level of WebSocket messages (0 = off, 1 = fastest, 9 = smallest)", false, func(c *Config) interface{} { return c.WSCompressionLevel }},
+	{"WS_REDIS_URL", "WSRedisURL", "Redis URL relaying WebSocket messages between backend instances (empty = single instance)", true, func(c *Config) interface{} { return c.WSRedisURL }},
+	{"WS_REDIS_CHANNEL", "WSRedisChannel", "Redis pub/sub channel of the WebSocket fan-out", false, func(c *Config) interface{} { return c.WSRedisChannel }},
+	{"METRICS_PROMETHEUS_TOKEN", "MetricsPrometheusToken", "Bearer token of the Prometheus scrape endpoint (empty = disabled)", true, func(c *Config) interface{} { return c.MetricsPrometheusToken }},
+	{"LAN_ALLOWED_CIDRS", "LANAllowedCIDRs", "CIDRs of the venue LAN, empty disables LAN-only mode", false, func(c *Config) interface{} { return c.LANAllowedCIDRs }},
+	{"LAN_ONLY_MODE", "LANOnlyMode", "Requests restricted to the LAN: writes or all", false, func(c *Config) interface{} { return c.LANOnlyMode }},
+	{"TRUSTED_PROXIES", "TrustedProxies", "Proxies whose X-Forwarded-For header is trusted", false, func(c *Config) interface{} { return c.TrustedProxies }},
+	{"WAREHOUSE_EXPORT_INTERVAL_MINUTES", "WarehouseExportIntervalMinutes", "Minutes between two Parquet exports to S3 (0 = disabled)", false, func(c *Config) interface{} { return c.WarehouseExportIntervalMinutes }},
+	{"WAREHOUSE_S3_ENDPOINT", "WarehouseS3Endpoint", "S3 or MinIO endpoint of the data warehouse export", false, func(c *Config) interface{} { return c.WarehouseS3Endpoint }},
+	{"WAREHOUSE_S3_REGION", "WarehouseS3Region", "S3 region of the data warehouse export", false, func(c *Config) interface{} { return c.WarehouseS3Region }},
+	{"WAREHOUSE_S3_BUCKET", "WarehouseS3Bucket", "Bucket the Parquet files are written to", false, func(c *Config) interface{} { return c.WarehouseS3Bucket }},
+	{"WAREHOUSE_S3_ACCESS_KEY", "WarehouseS3AccessKey", "Access key of the data warehouse bucket", false, func(c *Config) interface{} { return c.WarehouseS3AccessKey }},
+	{"WAREHOUSE_S3_SECRET_KEY", "WarehouseS3SecretKey", "Secret key of the data warehouse bucket", true, func(c *Config) interface{} { return c.WarehouseS3SecretKey }},
+	{"WAREHOUSE_S3_PREFIX", "WarehouseS3Prefix", "Key prefix of the exported Parquet files", false, func(c *Config) interface{} { return c.WarehouseS3Prefix }},
+	{"SMTP_HOST", "SMTPHost", "SMTP server for outgoing mail, empty disables mail", false, func(c *Config) interface{} { return c.SMTPHost }},
+	{"SMTP_PORT", "SMTPPort", "SMTP port (465 = implicit TLS, otherwise STARTTLS)", false, func(c *Config) interface{} { return c.SMTPPort }},
+	{"SMTP_USERNAME", "SMTPUsername", "SMTP login, empty sends without authentication", false, func(c *Config) interface{} { return c.SMTPUsername }},
+	{"SMTP_PASSWORD", "SMTPPassword", "SMTP password", true, func(c *Config) interface{} { return c.SMTPPassword }},
+	{"SMTP_FROM", "SMTPFrom", "Sender address of outgoing mail", false, func(c *Config) interface{} { return c.SMTPFrom }},
+	{"DIGEST_RECIPIENTS", "DigestRecipients", "E-mail addresses receiving the daily organizer digest", false, func(c *Config) interface{} { return c.DigestRecipients }},
+	{"DIGEST_TIME", "DigestTime", "Time of day (HH:MM, event timezone) the organizer digest is sent", false, func(c *Config) interface{} { return c.DigestTime }},
+}
+
+// describeTime formats an optional time, the zero time means not set
+func describeTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.Format(time.RFC3339)
+}
+
+// snapshotValues records the values right after loading, later differences are reported as overrides
+func (c *Config) snapshotValues() map[string]string {
+	values := make(map[string]string, len(entryDefs))
+	for _, def := range entryDefs {
+		values[def.name] = fmt.Sprint(def.value(c))
+	}
+	return values
+}
+
+// Describe returns the effective configuration with the source of each value, secrets are redacted
+func (c *Config) Describe() []Entry {
+	entries := make([]Entry, 0, len(entryDefs))
+	for _, def := range entryDefs {
+		value := def.value(c)
+		formatted := fmt.Sprint(value)
+
+		source := SourceDefault
+		if loaded, ok := c.loadedValues[def.name]; ok && loaded != formatted {
+			source = SourceOverride
+		} else if _, exists := os.LookupEnv(def.key); def.key != "" && exists {
+			source = SourceEnv
+		}
+
+		isSet := value != nil && formatted != "" && formatted != "[]" && formatted != "map[]"
+		if def.secret {
+			value = ""
+			if isSet {
+				value = redactedValue
+			}
+		}
+
+		entries = append(entries, Entry{
+			Key:         def.key,
+			Name:        def.name,
+			Description: def.description,
+			Value:       value,
+			Source:      source,
+			Secret:      def.secret,
+			IsSet:       isSet,
+		})
+	}
+	return entries
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// wizardQuestion asks for one value of the starter .env
+type wizardQuestion struct {
+	key      string
+	prompt   string
+	comment  string                                 // Written above the value in the .env file
+	fallback func(answers map[string]string) string // Default if the answer is empty
+	required bool
+}
+
+// wizardQuestions are asked in order, later defaults may depend on earlier answers
+var wizardQuestions = []wizardQuestion{
+	{
+		key:      "BACKEND_URL",
+		prompt:   "URL unter der der Server erreichbar ist",
+		comment:  "Public URL of the backend, used for the Steam callback and short links",
+		fallback: func(map[string]string) string { return "http://" + defaultHostname() + ":8080" },
+	},
+	{
+		key:      "FRONTEND_URL",
+		prompt:   "URL des Frontends (gleiche URL, wenn das Frontend eingebettet ist)",
+		comment:  "Public URL of the frontend, used for CORS and login redirects",
+		fallback: func(answers map[string]string) string { return answers["BACKEND_URL"] },
+	},
+	{
+		key:      "STEAM_API_KEY",
+		prompt:   "Steam Web API Key (https://steamcommunity.com/dev/apikey)",
+		comment:  "Steam Web API key for profiles and game libraries",
+		required: true,
+	},
+	{
+		key:      "ADMIN_STEAM_IDS",
+		prompt:   "Steam IDs der Admins, kommagetrennt (leer = Setup-Code im Log)",
+		comment:  "Comma-separated Steam IDs with admin privileges, empty prints a one-time setup code to the log",
+		fallback: func(map[string]string) string { return "" },
+	},
+	{
+		key:      "DB_PATH",
+		prompt:   "Pfad der SQLite-Datenbank",
+		comment:  "SQLite database path",
+		fallback: func(map[string]string) string { return "data/rate-your-mate.db" },
+	},
+	{
+		key:      "MDNS_ENABLED",
+		prompt:   "Server im LAN per mDNS ankündigen (true/false)",
+		comment:  "Advertise the backend on the LAN via mDNS",
+		fallback: func(map[string]string) string { return "true" },
+	},
+}
+
+// CreateEnv asks for the essential settings and writes a starter .env file
+// JWT_SECRET is generated, everything else keeps its built-in default
+func CreateEnv(path string, in io.Reader, out io.Writer) error {
+	reader := bufio.NewReader(in)
+
+	if _, err := os.Stat(path); err == nil {
+		fmt.Fprintf(out, "%s existiert bereits. Überschreiben? (y/N): ", path)
+		answer, err := readLine(reader)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+			return fmt.Errorf("%s already exists", path)
+		}
+	}
+
+	answers := make(map[string]string, len(wizardQuestions))
+	for _, q := range wizardQuestions {
+		fallback := ""
+		if q.fallback != nil {
+			fallback = q.fallback(answers)
+		}
+
+		for {
+			if fallback != "" {
+				fmt.Fprintf(out, "%s [%s]: ", q.prompt, fallback)
+			} else {
+				fmt.Fprintf(out, "%s: ", q.prompt)
+			}
+			answer, err := readLine(reader)
+			if err != nil {
+				return err
+			}
+			if answer == "" {
+				answer = fallback
+			}
+			if answer == "" && q.required {
+				fmt.Fprintln(out, "Dieser Wert wird benötigt.")
+				continue
+			}
+			answers[q.key] = answer
+			break
+		}
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString("# Rate your Mate - generated with --create-env\n")
+	b.WriteString("# All other settings keep their defaults, see .env.example for the full list\n")
+	for _, q := range wizardQuestions {
+		fmt.Fprintf(&b, "\n# %s\n%s=%s\n", q.comment, q.key, answers[q.key])
+	}
+	b.WriteString("\n# Secret used to sign login tokens (generated)\nJWT_SECRET=" + secret + "\n")
+
+	if err := os.WriteFile(path, []byte(b.String()), 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	fmt.Fprintf(out, "\n%s geschrieben. Starte den Server im selben Verzeichnis.\n", path)
+	return nil
+}
+
+// readLine reads one trimmed line, EOF on the last line without newline is not an error
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// generateSecret returns 32 random bytes, base64 encoded
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate JWT secret: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(buf), nil
+}
+
+// defaultHostname suggests the mDNS name of this machine, e.g. raspberrypi.local
+func defaultHostname() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return "localhost"
+	}
+	return hostname + ".local"
+}
@@ -0,0 +1,104 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+)
+
+// restoreSuffix marks a backup that replaces the SQLite database on the next start
+const restoreSuffix = ".restore"
+
+// sqliteHeader starts every SQLite database file
+var sqliteHeader = []byte("SQLite format 3\x00")
+
+// ErrBackupUnsupported is returned when the database is not SQLite
+var ErrBackupUnsupported = errors.New("backups are only supported for SQLite")
+
+// BackupSQLite writes a consistent snapshot of the running SQLite database to path
+// VACUUM INTO works while the database is in use and fails if path already exists
+func BackupSQLite(ctx context.Context, path string) error {
+	if dbType != DBTypeSQLite {
+		return ErrBackupUnsupported
+	}
+	if _, err := DB.ExecContext(ctx, `VACUUM INTO ?`, path); err != nil {
+		return fmt.Errorf("failed to back up database: %w", err)
+	}
+	return nil
+}
+
+// StageRestore copies a backup next to the SQLite database, it replaces the database on the next start
+// Restoring while running would pull the data away from open connections and running services
+func StageRestore(dbPath, backupPath string) error {
+	if dbType != DBTypeSQLite {
+		return ErrBackupUnsupported
+	}
+
+	src, err := os.Open(backupPath)
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer src.Close()
+
+	header := make([]byte, len(sqliteHeader))
+	if _, err := io.ReadFull(src, header); err != nil || !bytes.Equal(header, sqliteHeader) {
+		return fmt.Errorf("backup %s is not a SQLite database", backupPath)
+	}
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read backup: %w", err)
+	}
+
+	// Copy to a temporary file first, so a failed copy never gets restored
+	tmpPath := dbPath + restoreSuffix + ".tmp"
+	dst, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create restore file: %w", err)
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to copy backup: %w", err)
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write restore file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, dbPath+restoreSuffix); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to stage restore: %w", err)
+	}
+	return nil
+}
+
+// applyStagedRestore replaces the SQLite database with a staged backup before it is opened
+// The replaced database is kept as <db>.before-restore-<time>, so earlier safety copies are not overwritten
+func applyStagedRestore(dbPath string) error {
+	restorePath := dbPath + restoreSuffix
+	if _, err := os.Stat(restorePath); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	keptPath := dbPath + ".before-restore-" + time.Now().UTC().Format("20060102-150405")
+	if _, err := os.Stat(keptPath); err == nil {
+		return fmt.Errorf("failed to keep database before restore: %s already exists", keptPath)
+	}
+
+	// The WAL belongs to the replaced database and moves along with it
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		if err := os.Rename(dbPath+suffix, keptPath+suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to keep database before restore: %w", err)
+		}
+	}
+
+	if err := os.Rename(restorePath, dbPath); err != nil {
+		return fmt.Errorf("failed to restore database: %w", err)
+	}
+	log.Printf("Restored SQLite database from backup, the previous database was kept as %s", keptPath)
+	return nil
+}
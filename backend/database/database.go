@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -37,6 +38,15 @@ func IsMySQL() bool {
 	return dbType == DBTypeMySQL
 }
 
+// windowFunctions is set when the database server supports window functions
+var windowFunctions bool
+
+// SupportsWindowFunctions returns true if queries can use window functions like ROW_NUMBER() OVER
+// SQLite supports them since 3.25, MySQL since 8.0 and MariaDB since 10.2
+func SupportsWindowFunctions() bool {
+	return windowFunctions
+}
+
 // Config holds database configuration for initialization
 type Config struct {
 	// Type of database: "sqlite" or "mysql"
@@ -105,8 +115,14 @@ func Close() error {
 // If the function returns an error, the transaction is rolled back
 // If the function succeeds, the transaction is committed
 func WithTransaction(fn func(tx *sql.Tx) error) error {
-	return WithRetry(func() error {
-		tx, err := DB.Begin()
+	return WithTransactionContext(context.Background(), fn)
+}
+
+// WithTransactionContext executes a function within a transaction with retry and context support
+// The transaction is rolled back when ctx is canceled before the commit
+func WithTransactionContext(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return WithRetryContext(ctx, func() error {
+		tx, err := DB.BeginTx(ctx, nil)
 		if err != nil {
 			return fmt.Errorf("failed to begin transaction: %w", err)
 		}
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PoolStats are the connection pool statistics of the database
+type PoolStats struct {
+	MaxOpen      int    `json:"max_open"`
+	Open         int    `json:"open"`
+	InUse        int    `json:"in_use"`
+	Idle         int    `json:"idle"`
+	WaitCount    int64  `json:"wait_count"`
+	WaitDuration string `json:"wait_duration"`
+}
+
+// HealthStatus reports the reachability and state of the database
+type HealthStatus struct {
+	Driver           DBType    `json:"driver"`
+	Reachable        bool      `json:"reachable"`
+	LatencyMs        float64   `json:"latency_ms"`
+	Error            string    `json:"error,omitempty"`
+	MigrationVersion uint      `json:"migration_version"`
+	MigrationDirty   bool      `json:"migration_dirty"`
+	Pool             PoolStats `json:"pool"`
+}
+
+// Health pings the database and reads the migration version
+// Reachable is false if the database does not answer in time
+func Health(ctx context.Context) HealthStatus {
+	status := HealthStatus{Driver: dbType}
+	if DB == nil {
+		status.Error = "database not initialized"
+		return status
+	}
+
+	stats := DB.Stats()
+	status.Pool = PoolStats{
+		MaxOpen:      stats.MaxOpenConnections,
+		Open:         stats.OpenConnections,
+		InUse:        stats.InUse,
+		Idle:         stats.Idle,
+		WaitCount:    stats.WaitCount,
+		WaitDuration: stats.WaitDuration.String(),
+	}
+
+	start := time.Now()
+	if err := DB.PingContext(ctx); err != nil {
+		status.Error = fmt.Sprintf("ping failed: %v", err)
+		return status
+	}
+	status.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+	status.Reachable = true
+
+	// golang-migrate keeps the current version in schema_migrations
+	err := DB.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&status.MigrationVersion, &status.MigrationDirty)
+	if err != nil {
+		status.Error = fmt.Sprintf("failed to get migration version: %v", err)
+	}
+
+	return status
+}
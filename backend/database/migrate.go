@@ -2,12 +2,16 @@ package database
 
 import (
 	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/mysql"
 	"github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
 )
 
@@ -49,19 +53,7 @@ func runMySQLMigrationsV2() error {
 		return fmt.Errorf("failed to create migrate instance: %w", err)
 	}
 
-	// Run migrations
-	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-		return fmt.Errorf("MySQL migration failed: %w", err)
-	}
-
-	version, dirty, _ := m.Version()
-	if dirty {
-		log.Printf("Warning: MySQL migrations are in dirty state at version %d", version)
-	} else {
-		log.Printf("MySQL migrations completed (version: %d)", version)
-	}
-
-	return nil
+	return applyMigrations("MySQL", m, sourceDriver)
 }
 
 // runSQLiteMigrationsV2 runs SQLite migrations using golang-migrate
@@ -84,17 +76,95 @@ func runSQLiteMigrationsV2() error {
 		return fmt.Errorf("failed to create migrate instance: %w", err)
 	}
 
-	// Run migrations
+	return applyMigrations("SQLite", m, sourceDriver)
+}
+
+// applyMigrations runs all pending migrations and records which of them were applied
+// m.Up refuses to run on a dirty database (a migration failed halfway), which stops the start
+func applyMigrations(dbName string, m *migrate.Migrate, sourceDriver source.Driver) error {
+	before, _, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		before = 0
+	} else if err != nil {
+		return fmt.Errorf("failed to get %s migration version: %w", dbName, err)
+	}
+
 	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-		return fmt.Errorf("SQLite migration failed: %w", err)
+		return fmt.Errorf("%s migration failed: %w", dbName, err)
 	}
 
-	version, dirty, _ := m.Version()
-	if dirty {
-		log.Printf("Warning: SQLite migrations are in dirty state at version %d", version)
-	} else {
-		log.Printf("SQLite migrations completed (version: %d)", version)
+	version, _, err := m.Version()
+	if err != nil {
+		return fmt.Errorf("failed to get %s migration version: %w", dbName, err)
 	}
 
+	if err := recordMigrationHistory(sourceDriver, before, version); err != nil {
+		return err
+	}
+
+	log.Printf("%s migrations completed (version: %d)", dbName, version)
+	return nil
+}
+
+// recordMigrationHistory brings schema_migration_history in line with the current version
+// Migrations after before were applied in this run and get the current time, older ones missing
+// from the history ran before it existed and are recorded without a time. Rows above the current
+// version belong to migrations that were rolled back and are deleted.
+func recordMigrationHistory(sourceDriver source.Driver, before, version uint) error {
+	if _, err := DB.Exec(`DELETE FROM schema_migration_history WHERE version > ?`, version); err != nil {
+		return fmt.Errorf("failed to remove rolled back migrations from the history: %w", err)
+	}
+
+	rows, err := DB.Query(`SELECT version FROM schema_migration_history`)
+	if err != nil {
+		return fmt.Errorf("failed to get migration history: %w", err)
+	}
+	recorded := make(map[uint]bool)
+	for rows.Next() {
+		var v uint
+		if err := rows.Scan(&v); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan migration history: %w", err)
+		}
+		recorded[v] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to get migration history: %w", err)
+	}
+
+	now := time.Now().UTC()
+	applied, backfilled := 0, 0
+	v, err := sourceDriver.First()
+	for err == nil && v <= version {
+		if !recorded[v] {
+			r, name, readErr := sourceDriver.ReadUp(v)
+			if readErr != nil {
+				return fmt.Errorf("failed to read migration %d: %w", v, readErr)
+			}
+			r.Close()
+
+			var appliedAt interface{}
+			if v > before {
+				appliedAt = now
+				applied++
+			} else {
+				backfilled++
+			}
+			if _, err := DB.Exec(`
+				INSERT INTO schema_migration_history (version, name, applied_at)
+				VALUES (?, ?, ?)`, v, name, appliedAt); err != nil {
+				return fmt.Errorf("failed to record migration %d: %w", v, err)
+			}
+		}
+		v, err = sourceDriver.Next(v)
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	if applied > 0 || backfilled > 0 {
+		log.Printf("Recorded %d applied migrations in the migration history (%d from before the history without time)", applied+backfilled, backfilled)
+	}
 	return nil
 }
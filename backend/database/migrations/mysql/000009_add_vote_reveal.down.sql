@@ -0,0 +1,2 @@
+-- Remove is_revealed column from votes table
+ALTER TABLE votes DROP COLUMN is_revealed;
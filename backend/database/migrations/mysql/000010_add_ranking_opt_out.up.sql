@@ -0,0 +1,3 @@
+-- Add hide_from_ranking column to users table (MySQL)
+-- Users with this flag are hidden from the public ranking and leaderboard
+ALTER TABLE users ADD COLUMN hide_from_ranking TINYINT(1) DEFAULT 0;
@@ -0,0 +1,6 @@
+-- Remove vote invalidation audit trail (MySQL)
+DROP TABLE IF EXISTS vote_invalidation_log;
+
+ALTER TABLE votes DROP COLUMN invalidation_reason;
+ALTER TABLE votes DROP COLUMN invalidated_at;
+ALTER TABLE votes DROP COLUMN invalidated_by;
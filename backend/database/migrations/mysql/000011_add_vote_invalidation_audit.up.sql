@@ -0,0 +1,16 @@
+-- Record who invalidated a vote, when and why (MySQL)
+ALTER TABLE votes ADD COLUMN invalidated_by VARCHAR(50) DEFAULT NULL;
+ALTER TABLE votes ADD COLUMN invalidated_at DATETIME DEFAULT NULL;
+ALTER TABLE votes ADD COLUMN invalidation_reason VARCHAR(500) DEFAULT NULL;
+
+-- Audit trail of every invalidation toggle
+CREATE TABLE IF NOT EXISTS vote_invalidation_log (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    vote_id BIGINT UNSIGNED NOT NULL,
+    admin_steam_id VARCHAR(50) NOT NULL,
+    is_invalidated TINYINT(1) NOT NULL,
+    reason VARCHAR(500) DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_vote_invalidation_log_vote_id (vote_id),
+    FOREIGN KEY (vote_id) REFERENCES votes(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
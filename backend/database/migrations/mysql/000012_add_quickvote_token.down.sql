@@ -0,0 +1,3 @@
+-- Remove quick-vote token from users table (MySQL)
+DROP INDEX idx_users_quickvote_token_hash ON users;
+ALTER TABLE users DROP COLUMN quickvote_token_hash;
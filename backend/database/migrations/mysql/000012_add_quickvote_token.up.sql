@@ -0,0 +1,4 @@
+-- Add personal quick-vote token (SHA-256 hash) for hardware buttons like the Stream Deck (MySQL)
+ALTER TABLE users ADD COLUMN quickvote_token_hash CHAR(64) DEFAULT NULL;
+
+CREATE INDEX idx_users_quickvote_token_hash ON users(quickvote_token_hash);
@@ -0,0 +1,2 @@
+-- Remove anonymized_at column from users table (MySQL)
+ALTER TABLE users DROP COLUMN anonymized_at;
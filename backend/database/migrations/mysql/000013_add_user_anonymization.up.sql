@@ -0,0 +1,3 @@
+-- Add anonymized_at column to users table (MySQL)
+-- Set by the retention job once a user's personal data has been anonymized
+ALTER TABLE users ADD COLUMN anonymized_at DATETIME DEFAULT NULL;
@@ -0,0 +1,3 @@
+-- Remove country code and timezone from users table (MySQL)
+ALTER TABLE users DROP COLUMN timezone;
+ALTER TABLE users DROP COLUMN country_code;
@@ -0,0 +1,3 @@
+-- Add country code (from the Steam profile) and preferred timezone to users table (MySQL)
+ALTER TABLE users ADD COLUMN country_code VARCHAR(2) DEFAULT '';
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64) DEFAULT '';
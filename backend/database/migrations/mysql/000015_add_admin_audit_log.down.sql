@@ -0,0 +1,2 @@
+-- Remove admin audit log (MySQL)
+DROP TABLE IF EXISTS admin_audit_log;
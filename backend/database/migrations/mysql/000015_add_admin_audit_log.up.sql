@@ -0,0 +1,9 @@
+-- Audit trail of sensitive admin actions (e.g. viewing the real senders of secret votes) (MySQL)
+CREATE TABLE IF NOT EXISTS admin_audit_log (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    admin_steam_id VARCHAR(50) NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    details VARCHAR(500) DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_admin_audit_log_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+-- Remove event phases (MySQL)
+DROP TABLE IF EXISTS event_phases;
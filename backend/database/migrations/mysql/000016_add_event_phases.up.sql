@@ -0,0 +1,12 @@
+-- Event phases (e.g. Friday warm-up, Saturday tournament) with their own settings profile (MySQL)
+CREATE TABLE IF NOT EXISTS event_phases (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(100) NOT NULL,
+    starts_at DATETIME NOT NULL,
+    credit_interval_minutes INT NOT NULL,
+    negative_voting_disabled TINYINT(1) NOT NULL DEFAULT 0,
+    vote_visibility_mode VARCHAR(20) NOT NULL DEFAULT 'user_choice',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_event_phases_starts_at (starts_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
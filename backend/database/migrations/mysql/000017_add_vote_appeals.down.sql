@@ -0,0 +1,2 @@
+-- Remove vote appeals (MySQL)
+DROP TABLE IF EXISTS vote_appeals;
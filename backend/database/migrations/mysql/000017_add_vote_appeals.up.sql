@@ -0,0 +1,16 @@
+-- Appeals of vote recipients against negative votes, reviewed by admins (MySQL)
+CREATE TABLE IF NOT EXISTS vote_appeals (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    vote_id BIGINT UNSIGNED UNIQUE NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    reason VARCHAR(500) DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    resolved_by VARCHAR(50) DEFAULT NULL,
+    resolution_note VARCHAR(500) DEFAULT NULL,
+    resolved_at DATETIME DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_vote_appeals_status (status),
+    INDEX idx_vote_appeals_user_id (user_id),
+    FOREIGN KEY (vote_id) REFERENCES votes(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
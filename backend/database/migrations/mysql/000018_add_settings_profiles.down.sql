@@ -0,0 +1,2 @@
+-- Remove settings profiles (MySQL)
+DROP TABLE IF EXISTS settings_profiles;
@@ -0,0 +1,2 @@
+-- Remove achievements table (MySQL)
+DROP TABLE IF EXISTS achievements;
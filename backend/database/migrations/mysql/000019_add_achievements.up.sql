@@ -0,0 +1,13 @@
+-- Achievements managed at runtime by admins, built-in achievements are seeded on startup (MySQL)
+CREATE TABLE IF NOT EXISTS achievements (
+    id VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(255) DEFAULT '',
+    image_url VARCHAR(500) DEFAULT '',
+    is_positive TINYINT(1) NOT NULL DEFAULT 1,
+    is_builtin TINYINT(1) NOT NULL DEFAULT 0,
+    is_disabled TINYINT(1) NOT NULL DEFAULT 0,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,3 @@
+-- Remove reply_to_id column from chat_messages table (MySQL)
+ALTER TABLE chat_messages DROP FOREIGN KEY fk_chat_messages_reply_to;
+ALTER TABLE chat_messages DROP COLUMN reply_to_id;
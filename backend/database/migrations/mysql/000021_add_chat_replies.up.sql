@@ -0,0 +1,4 @@
+-- Add reply_to_id column to chat_messages table (MySQL)
+-- Replies keep their text when the parent message is deleted
+ALTER TABLE chat_messages ADD COLUMN reply_to_id BIGINT UNSIGNED DEFAULT NULL;
+ALTER TABLE chat_messages ADD CONSTRAINT fk_chat_messages_reply_to FOREIGN KEY (reply_to_id) REFERENCES chat_messages(id) ON DELETE SET NULL;
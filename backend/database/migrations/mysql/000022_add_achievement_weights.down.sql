@@ -0,0 +1,2 @@
+-- Remove weight column from achievements table (MySQL)
+ALTER TABLE achievements DROP COLUMN weight;
@@ -0,0 +1,3 @@
+-- Add weight column to achievements table (MySQL)
+-- Votes and placement bonus points of an achievement are multiplied by its weight in the ranking
+ALTER TABLE achievements ADD COLUMN weight INT NOT NULL DEFAULT 1;
@@ -0,0 +1,3 @@
+-- Remove chat reminders table and is_system column (MySQL)
+DROP TABLE IF EXISTS chat_reminders;
+ALTER TABLE chat_messages DROP COLUMN is_system;
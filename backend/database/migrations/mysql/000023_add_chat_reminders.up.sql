@@ -0,0 +1,16 @@
+-- System messages are shown with a system author instead of the admin who scheduled them (MySQL)
+ALTER TABLE chat_messages ADD COLUMN is_system TINYINT(1) DEFAULT 0;
+
+-- Chat reminders scheduled by admins and posted as system messages when due
+CREATE TABLE IF NOT EXISTS chat_reminders (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    message VARCHAR(500) NOT NULL,
+    send_at DATETIME NOT NULL,
+    created_by BIGINT UNSIGNED NOT NULL,
+    sent_at DATETIME DEFAULT NULL,
+    chat_message_id BIGINT UNSIGNED DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_chat_reminders_due (sent_at, send_at),
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (chat_message_id) REFERENCES chat_messages(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
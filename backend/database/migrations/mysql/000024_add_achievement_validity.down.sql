@@ -0,0 +1,3 @@
+-- Remove validity window from achievements table (MySQL)
+ALTER TABLE achievements DROP COLUMN valid_until;
+ALTER TABLE achievements DROP COLUMN valid_from;
@@ -0,0 +1,4 @@
+-- Add validity window to achievements table (MySQL)
+-- Seasonal achievements can only be voted between valid_from and valid_until, NULL means unbounded
+ALTER TABLE achievements ADD COLUMN valid_from DATETIME DEFAULT NULL;
+ALTER TABLE achievements ADD COLUMN valid_until DATETIME DEFAULT NULL;
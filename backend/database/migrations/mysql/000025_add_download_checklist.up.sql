@@ -0,0 +1,21 @@
+-- Games and updates players should download before the event starts, listed by admins (MySQL)
+CREATE TABLE IF NOT EXISTS download_requirements (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    app_id BIGINT UNSIGNED DEFAULT NULL,
+    name VARCHAR(100) NOT NULL,
+    version VARCHAR(100) DEFAULT '',
+    note VARCHAR(500) DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Players who confirmed that a requirement is downloaded
+CREATE TABLE IF NOT EXISTS download_confirmations (
+    requirement_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (requirement_id, user_id),
+    INDEX idx_download_confirmations_user_id (user_id),
+    FOREIGN KEY (requirement_id) REFERENCES download_requirements(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+-- Remove achievement suggestions table (MySQL)
+DROP TABLE IF EXISTS achievement_suggestions;
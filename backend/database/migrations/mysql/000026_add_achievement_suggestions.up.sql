@@ -0,0 +1,17 @@
+-- Achievement ideas submitted by players, approved or rejected by admins (MySQL)
+CREATE TABLE IF NOT EXISTS achievement_suggestions (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    user_id BIGINT UNSIGNED NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(255) DEFAULT '',
+    is_positive TINYINT(1) NOT NULL DEFAULT 1,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    achievement_id VARCHAR(50) DEFAULT NULL,
+    reviewed_by VARCHAR(50) DEFAULT NULL,
+    review_note VARCHAR(500) DEFAULT NULL,
+    reviewed_at DATETIME DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_achievement_suggestions_status (status),
+    INDEX idx_achievement_suggestions_user_id (user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
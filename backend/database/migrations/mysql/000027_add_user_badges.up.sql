@@ -0,0 +1,8 @@
+-- Meta-badges automatically awarded to players, badges are permanent once awarded (MySQL)
+CREATE TABLE IF NOT EXISTS user_badges (
+    user_id BIGINT UNSIGNED NOT NULL,
+    badge_id VARCHAR(50) NOT NULL,
+    awarded_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, badge_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
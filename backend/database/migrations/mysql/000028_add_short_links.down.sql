@@ -0,0 +1,2 @@
+-- Remove short links table (MySQL)
+DROP TABLE IF EXISTS short_links;
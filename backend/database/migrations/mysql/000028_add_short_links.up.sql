@@ -0,0 +1,9 @@
+-- Short links served under /go/:slug, e.g. for the welcome sheet (MySQL)
+CREATE TABLE IF NOT EXISTS short_links (
+    slug VARCHAR(50) PRIMARY KEY,
+    target_url VARCHAR(500) NOT NULL,
+    title VARCHAR(100) DEFAULT '',
+    hits INT UNSIGNED NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+-- Remove user roles table (MySQL)
+DROP TABLE IF EXISTS user_roles;
@@ -0,0 +1,9 @@
+-- Roles granted at runtime, e.g. the admin claimed with the first-run setup code (MySQL)
+-- Admins from ADMIN_STEAM_IDS are not stored here
+CREATE TABLE IF NOT EXISTS user_roles (
+    steam_id VARCHAR(50) NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    granted_by VARCHAR(50) DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (steam_id, role)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
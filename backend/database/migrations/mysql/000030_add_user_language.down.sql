@@ -0,0 +1,2 @@
+-- Remove preferred language from users table (MySQL)
+ALTER TABLE users DROP COLUMN language;
@@ -0,0 +1,2 @@
+-- Add preferred language for server-side translations to users table (MySQL)
+ALTER TABLE users ADD COLUMN language VARCHAR(10) DEFAULT '';
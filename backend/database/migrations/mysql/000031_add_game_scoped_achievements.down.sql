@@ -0,0 +1,4 @@
+-- Remove game scope from achievements and votes (MySQL)
+DROP INDEX idx_votes_app_id ON votes;
+ALTER TABLE votes DROP COLUMN app_id;
+ALTER TABLE achievements DROP COLUMN app_id;
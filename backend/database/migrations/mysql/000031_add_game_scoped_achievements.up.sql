@@ -0,0 +1,6 @@
+-- Add game scope to achievements and votes (MySQL)
+-- Game-scoped achievements can only be voted in the context of their Steam app, NULL means global
+ALTER TABLE achievements ADD COLUMN app_id INT DEFAULT NULL;
+-- Steam app the vote was cast in the context of, NULL means no game context
+ALTER TABLE votes ADD COLUMN app_id INT DEFAULT NULL;
+CREATE INDEX idx_votes_app_id ON votes(app_id);
@@ -0,0 +1,2 @@
+-- Remove reduced motion accessibility preference from users table (MySQL)
+ALTER TABLE users DROP COLUMN reduced_motion;
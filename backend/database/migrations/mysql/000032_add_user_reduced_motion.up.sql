@@ -0,0 +1,2 @@
+-- Add reduced motion accessibility preference to users table (MySQL)
+ALTER TABLE users ADD COLUMN reduced_motion TINYINT(1) DEFAULT 0;
@@ -0,0 +1,2 @@
+-- Remove scheduled timers table (MySQL)
+DROP TABLE IF EXISTS timers;
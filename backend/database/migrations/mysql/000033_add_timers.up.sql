@@ -0,0 +1,6 @@
+-- Scheduled timers like the countdown and the secret reveal, so they survive restarts (MySQL)
+CREATE TABLE IF NOT EXISTS timers (
+    name VARCHAR(50) PRIMARY KEY,
+    fire_at DATETIME NOT NULL,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
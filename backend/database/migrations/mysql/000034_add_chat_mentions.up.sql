@@ -0,0 +1,12 @@
+-- Users mentioned with @username in chat messages, unread until the user has seen them (MySQL)
+CREATE TABLE IF NOT EXISTS chat_mentions (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    chat_message_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    is_read TINYINT(1) DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_chat_mentions_message_user (chat_message_id, user_id),
+    INDEX idx_chat_mentions_user (user_id, is_read),
+    FOREIGN KEY (chat_message_id) REFERENCES chat_messages(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
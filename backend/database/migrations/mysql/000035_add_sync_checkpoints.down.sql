@@ -0,0 +1,2 @@
+-- Remove sync checkpoints table (MySQL)
+DROP TABLE IF EXISTS sync_checkpoints;
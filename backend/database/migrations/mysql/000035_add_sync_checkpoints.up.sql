@@ -0,0 +1,8 @@
+-- Position of running background syncs, so a restarted instance resumes where it left off (MySQL)
+CREATE TABLE IF NOT EXISTS sync_checkpoints (
+    name VARCHAR(50) PRIMARY KEY,
+    last_app_id INT NOT NULL DEFAULT 0,
+    processed INT NOT NULL DEFAULT 0,
+    total INT NOT NULL DEFAULT 0,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,3 @@
+-- Remove account reviews and IP log tables (MySQL)
+DROP TABLE IF EXISTS account_reviews;
+DROP TABLE IF EXISTS ip_log;
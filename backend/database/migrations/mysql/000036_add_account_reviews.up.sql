@@ -0,0 +1,25 @@
+-- IP addresses of Steam logins, including blocked ones, to recognize alt accounts of banned players (MySQL)
+CREATE TABLE IF NOT EXISTS ip_log (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    steam_id VARCHAR(20) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_ip_log_ip_address (ip_address),
+    INDEX idx_ip_log_steam_id (steam_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- New accounts flagged by the ban evasion heuristics, they can't log in until an admin approves them
+CREATE TABLE IF NOT EXISTS account_reviews (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    user_id BIGINT UNSIGNED NOT NULL,
+    steam_id VARCHAR(20) UNIQUE NOT NULL,
+    username VARCHAR(255) NOT NULL,
+    reasons VARCHAR(255) NOT NULL,
+    ip_address VARCHAR(45) DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    reviewed_by VARCHAR(20) DEFAULT NULL,
+    reviewed_at DATETIME DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_account_reviews_status (status),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,9 @@
+-- Words blocked by the chat filter, language is the preset a word was imported from (empty = added by hand) (MySQL)
+CREATE TABLE IF NOT EXISTS chat_blocked_words (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    word VARCHAR(100) NOT NULL,
+    language VARCHAR(10) NOT NULL DEFAULT '',
+    created_by VARCHAR(20) DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_chat_blocked_words_word (word)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+-- Remove chat mutes (MySQL)
+DROP TABLE IF EXISTS user_mutes;
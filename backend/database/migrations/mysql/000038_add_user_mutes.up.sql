@@ -0,0 +1,10 @@
+-- Chat mutes set by admins, the user can't chat (and optionally vote) until muted_until (MySQL)
+CREATE TABLE IF NOT EXISTS user_mutes (
+    user_id BIGINT UNSIGNED PRIMARY KEY,
+    muted_until DATETIME NOT NULL,
+    include_votes TINYINT(1) DEFAULT 0,
+    reason VARCHAR(500) DEFAULT '',
+    muted_by VARCHAR(20) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+-- Remove spectator accounts (MySQL)
+DROP TABLE IF EXISTS spectators;
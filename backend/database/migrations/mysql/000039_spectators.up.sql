@@ -0,0 +1,10 @@
+-- Read-only spectator accounts created by admins, they log in with name and passcode instead of Steam (MySQL)
+CREATE TABLE IF NOT EXISTS spectators (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(50) NOT NULL,
+    passcode_hash VARCHAR(100) NOT NULL,
+    created_by VARCHAR(20) NOT NULL,
+    last_login_at DATETIME DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_spectators_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
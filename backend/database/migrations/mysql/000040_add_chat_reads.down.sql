@@ -0,0 +1,2 @@
+-- Remove chat read state table (MySQL)
+DROP TABLE IF EXISTS chat_reads;
@@ -0,0 +1,7 @@
+-- Last chat message each user has read, newer messages of others count as unread (MySQL)
+CREATE TABLE IF NOT EXISTS chat_reads (
+    user_id BIGINT UNSIGNED PRIMARY KEY,
+    last_read_message_id BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,4 @@
+-- Remove game poll tables (MySQL)
+DROP TABLE IF EXISTS poll_votes;
+DROP TABLE IF EXISTS poll_options;
+DROP TABLE IF EXISTS polls;
@@ -0,0 +1,34 @@
+-- "What do we play next?" polls created by players from the multiplayer games list (MySQL)
+CREATE TABLE IF NOT EXISTS polls (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    question VARCHAR(200) NOT NULL,
+    created_by BIGINT UNSIGNED NOT NULL,
+    closes_at DATETIME DEFAULT NULL,
+    closed_at DATETIME DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_polls_created_at (created_at),
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Games to choose from, the name is kept in case the game leaves the games list
+CREATE TABLE IF NOT EXISTS poll_options (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    poll_id BIGINT UNSIGNED NOT NULL,
+    app_id BIGINT UNSIGNED NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    UNIQUE KEY uq_poll_options_poll_app (poll_id, app_id),
+    FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- One vote per player and poll, it can be changed while the poll is open
+CREATE TABLE IF NOT EXISTS poll_votes (
+    poll_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    option_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (poll_id, user_id),
+    INDEX idx_poll_votes_option_id (option_id),
+    FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (option_id) REFERENCES poll_options(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
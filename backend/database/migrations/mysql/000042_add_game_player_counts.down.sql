@@ -0,0 +1,2 @@
+-- Remove the maximum player count from game_cache (MySQL)
+ALTER TABLE game_cache DROP COLUMN max_players;
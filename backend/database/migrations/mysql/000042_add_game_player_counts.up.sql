@@ -0,0 +1,2 @@
+-- Add the maximum player count parsed from the Steam Store description to game_cache (0 = unknown) (MySQL)
+ALTER TABLE game_cache ADD COLUMN max_players INT DEFAULT 0;
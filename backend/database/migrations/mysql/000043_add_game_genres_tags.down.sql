@@ -0,0 +1,3 @@
+-- Remove genres and tags from game_cache (MySQL)
+ALTER TABLE game_cache DROP COLUMN tags;
+ALTER TABLE game_cache DROP COLUMN genres;
@@ -0,0 +1,3 @@
+-- Add Steam genres and the top user tags (JSON arrays like categories) to game_cache (MySQL)
+ALTER TABLE game_cache ADD COLUMN genres TEXT DEFAULT ('[]');
+ALTER TABLE game_cache ADD COLUMN tags TEXT DEFAULT ('[]');
@@ -0,0 +1,2 @@
+-- Remove game ratings (MySQL)
+DROP TABLE IF EXISTS game_ratings;
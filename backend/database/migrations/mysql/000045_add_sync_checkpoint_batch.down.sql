@@ -0,0 +1,2 @@
+-- Remove the batch from sync_checkpoints (MySQL)
+ALTER TABLE sync_checkpoints DROP COLUMN batch_id;
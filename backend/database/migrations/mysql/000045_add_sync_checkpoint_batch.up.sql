@@ -0,0 +1,2 @@
+-- Add the batch the checkpoint belongs to, a resumed sync continues the same batch (MySQL)
+ALTER TABLE sync_checkpoints ADD COLUMN batch_id VARCHAR(32) NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- Remove the game server connect strings (MySQL)
+DROP TABLE IF EXISTS game_servers;
@@ -0,0 +1,10 @@
+-- Server connect strings per game, set by any player so the others can join with one click (MySQL)
+CREATE TABLE IF NOT EXISTS game_servers (
+    app_id BIGINT UNSIGNED PRIMARY KEY,
+    address VARCHAR(100) NOT NULL,
+    note VARCHAR(200) NOT NULL DEFAULT '',
+    updated_by BIGINT UNSIGNED NOT NULL,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_game_servers_updated_by (updated_by),
+    FOREIGN KEY (updated_by) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
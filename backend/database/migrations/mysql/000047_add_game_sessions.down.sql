@@ -0,0 +1,2 @@
+-- Remove game sessions (MySQL)
+DROP TABLE IF EXISTS game_sessions;
@@ -0,0 +1,12 @@
+-- Play time of players per game during the party, recorded manually or inferred from the Steam presence (MySQL)
+CREATE TABLE IF NOT EXISTS game_sessions (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    user_id BIGINT UNSIGNED NOT NULL,
+    app_id BIGINT UNSIGNED NOT NULL,
+    source VARCHAR(20) NOT NULL DEFAULT 'manual', -- 'manual' or 'presence'
+    started_at DATETIME NOT NULL,
+    ended_at DATETIME NULL, -- NULL while the player is still playing
+    INDEX idx_game_sessions_user (user_id, ended_at),
+    INDEX idx_game_sessions_app (app_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
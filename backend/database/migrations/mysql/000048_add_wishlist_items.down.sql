@@ -0,0 +1,2 @@
+-- Remove cached wishlists (MySQL)
+DROP TABLE IF EXISTS wishlist_items;
@@ -0,0 +1,10 @@
+-- Cached Steam wishlists of the players, refreshed periodically for the group purchase overview (MySQL)
+CREATE TABLE IF NOT EXISTS wishlist_items (
+    user_id BIGINT UNSIGNED NOT NULL,
+    app_id BIGINT UNSIGNED NOT NULL,
+    priority INT NOT NULL DEFAULT 0,
+    added_at DATETIME NULL,
+    PRIMARY KEY (user_id, app_id),
+    INDEX idx_wishlist_items_app (app_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
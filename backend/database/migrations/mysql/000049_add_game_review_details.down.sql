@@ -0,0 +1,3 @@
+-- Remove the review wording and count from game_cache (MySQL)
+ALTER TABLE game_cache DROP COLUMN review_count;
+ALTER TABLE game_cache DROP COLUMN review_score_desc;
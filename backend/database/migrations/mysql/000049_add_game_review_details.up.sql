@@ -0,0 +1,3 @@
+-- Add the Steam review wording ("Overwhelmingly Positive") and the total number of reviews to game_cache (MySQL)
+ALTER TABLE game_cache ADD COLUMN review_score_desc VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE game_cache ADD COLUMN review_count INT NOT NULL DEFAULT 0;
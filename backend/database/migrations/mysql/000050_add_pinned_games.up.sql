@@ -0,0 +1,6 @@
+-- Pinned games managed by admins at runtime, PINNED_GAME_IDS only seeds the empty table (MySQL)
+CREATE TABLE IF NOT EXISTS pinned_games (
+    app_id BIGINT UNSIGNED PRIMARY KEY,
+    position INT NOT NULL DEFAULT 0, -- Order in the games list, lowest first
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
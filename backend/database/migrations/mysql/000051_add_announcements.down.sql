@@ -0,0 +1,2 @@
+-- Remove announcements (MySQL)
+DROP TABLE IF EXISTS announcements;
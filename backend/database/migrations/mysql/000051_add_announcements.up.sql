@@ -0,0 +1,12 @@
+-- Announcements broadcast by admins, active ones are shown to players who log in later (MySQL)
+CREATE TABLE IF NOT EXISTS announcements (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    title VARCHAR(100) NOT NULL,
+    body VARCHAR(1000) NOT NULL,
+    severity VARCHAR(16) NOT NULL DEFAULT 'info',
+    expires_at DATETIME DEFAULT NULL,
+    created_by BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_announcements_expires_at (expires_at),
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
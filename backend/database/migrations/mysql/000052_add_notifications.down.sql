@@ -0,0 +1,2 @@
+-- Remove personal notifications (MySQL)
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,2 @@
+-- Remove the migration history (MySQL)
+DROP TABLE IF EXISTS schema_migration_history;
@@ -0,0 +1,7 @@
+-- When each migration was applied, schema_migrations of golang-migrate only keeps the current version (MySQL)
+-- Migrations applied before this table existed are recorded at the next start
+CREATE TABLE IF NOT EXISTS schema_migration_history (
+    version BIGINT UNSIGNED PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    applied_at DATETIME NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+-- Remove deleted_at column from users table (MySQL)
+ALTER TABLE users DROP COLUMN deleted_at;
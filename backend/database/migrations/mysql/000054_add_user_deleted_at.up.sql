@@ -0,0 +1,3 @@
+-- Add deleted_at column to users table (MySQL)
+-- Set when an admin kicks or bans a user, their votes and chat messages stay attributed to a former player
+ALTER TABLE users ADD COLUMN deleted_at DATETIME DEFAULT NULL;
@@ -0,0 +1,3 @@
+-- Remove covering indexes for the leaderboard and the global ranking (MySQL)
+DROP INDEX idx_votes_received_since ON votes;
+DROP INDEX idx_votes_ranking ON votes;
@@ -0,0 +1,5 @@
+-- Covering indexes for the leaderboard and the global ranking (MySQL)
+-- Both aggregate the valid votes per achievement and recipient, these indexes answer them without reading the table
+CREATE INDEX idx_votes_ranking ON votes(is_invalidated, achievement_id, to_user_id, points, created_at, app_id);
+-- Net votes within a time range, e.g. since the start of the week
+CREATE INDEX idx_votes_received_since ON votes(is_invalidated, created_at, to_user_id, achievement_id, points);
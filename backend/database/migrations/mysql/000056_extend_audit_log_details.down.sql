@@ -0,0 +1,3 @@
+-- Revert admin_audit_log.details back to VARCHAR(500), longer entries are cut off
+UPDATE admin_audit_log SET details = LEFT(details, 500) WHERE CHAR_LENGTH(details) > 500;
+ALTER TABLE admin_audit_log MODIFY COLUMN details VARCHAR(500) DEFAULT '';
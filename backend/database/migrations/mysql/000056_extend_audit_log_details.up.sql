@@ -0,0 +1,2 @@
+-- Extend admin_audit_log.details from VARCHAR(500) to TEXT, SQL console queries are logged in full
+ALTER TABLE admin_audit_log MODIFY COLUMN details TEXT;
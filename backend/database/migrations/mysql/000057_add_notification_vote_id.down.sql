@@ -0,0 +1,5 @@
+-- Remove vote_id column from notifications table (MySQL)
+-- The copied vote payloads are gone, so vote notifications are deleted
+DELETE FROM notifications WHERE type = 'vote_received';
+ALTER TABLE notifications DROP FOREIGN KEY fk_notifications_vote;
+ALTER TABLE notifications DROP COLUMN vote_id;
@@ -0,0 +1,7 @@
+-- Vote notifications reference their vote instead of a copy of it (MySQL)
+-- Sender and visibility are read when the notification is loaded, so anonymization, reveals
+-- and later visibility changes apply to old notifications as well
+ALTER TABLE notifications ADD COLUMN vote_id BIGINT UNSIGNED DEFAULT NULL;
+UPDATE notifications SET vote_id = JSON_UNQUOTE(JSON_EXTRACT(payload, '$.vote_id')), payload = '{}' WHERE type = 'vote_received';
+DELETE FROM notifications WHERE type = 'vote_received' AND (vote_id IS NULL OR vote_id NOT IN (SELECT id FROM votes));
+ALTER TABLE notifications ADD CONSTRAINT fk_notifications_vote FOREIGN KEY (vote_id) REFERENCES votes(id) ON DELETE CASCADE;
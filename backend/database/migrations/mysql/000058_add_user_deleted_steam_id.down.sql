@@ -0,0 +1,3 @@
+-- Remove deleted_steam_id column from users table (MySQL)
+ALTER TABLE users DROP COLUMN deleted_steam_id;
+-- account_reviews keeps the longer steam_id and reviewed_by columns, anonymized IDs wouldn't fit otherwise
@@ -0,0 +1,8 @@
+-- Add deleted_steam_id column to users table (MySQL)
+-- Keeps the Steam ID of a removed user, so anonymization also finds their login IPs and ban entries
+-- Users removed before this migration have lost their Steam ID already
+ALTER TABLE users ADD COLUMN deleted_steam_id VARCHAR(50) DEFAULT NULL;
+
+-- Anonymized Steam IDs (anon_ + 32 hex characters) and FAKE_ admin IDs don't fit into VARCHAR(20)
+ALTER TABLE account_reviews MODIFY COLUMN steam_id VARCHAR(50) NOT NULL;
+ALTER TABLE account_reviews MODIFY COLUMN reviewed_by VARCHAR(50) DEFAULT NULL;
@@ -0,0 +1,3 @@
+-- Restore the NOT NULL applied_at column, unknown times are set to now (MySQL)
+UPDATE schema_migration_history SET applied_at = CURRENT_TIMESTAMP WHERE applied_at IS NULL;
+ALTER TABLE schema_migration_history MODIFY COLUMN applied_at DATETIME NOT NULL;
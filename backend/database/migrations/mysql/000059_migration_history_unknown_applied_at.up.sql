@@ -0,0 +1,10 @@
+-- applied_at is NULL for migrations that ran before the history existed, their time is unknown (MySQL)
+-- The first start with the history recorded all earlier migrations with its own start time,
+-- those made-up times are cleared (000053 created the table and really ran at that time)
+ALTER TABLE schema_migration_history MODIFY COLUMN applied_at DATETIME DEFAULT NULL;
+
+UPDATE schema_migration_history
+SET applied_at = NULL
+WHERE version < 53 AND applied_at = (
+    SELECT first_start FROM (SELECT MIN(applied_at) AS first_start FROM schema_migration_history) history
+);
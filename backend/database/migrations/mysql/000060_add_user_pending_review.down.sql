@@ -0,0 +1,2 @@
+-- Remove pending_review column from users table (MySQL)
+ALTER TABLE users DROP COLUMN pending_review;
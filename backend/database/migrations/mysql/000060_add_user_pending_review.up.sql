@@ -0,0 +1,6 @@
+-- Add pending_review column to users table (MySQL)
+-- Accounts flagged by the ban evasion heuristics stay out of rankings and vote targets until an admin approves them
+ALTER TABLE users ADD COLUMN pending_review TINYINT(1) DEFAULT 0;
+
+UPDATE users SET pending_review = 1
+WHERE id IN (SELECT user_id FROM account_reviews WHERE status != 'approved');
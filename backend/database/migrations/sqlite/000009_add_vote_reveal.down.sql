@@ -0,0 +1,2 @@
+-- Remove is_revealed column from votes table (requires SQLite 3.35.0+)
+ALTER TABLE votes DROP COLUMN is_revealed;
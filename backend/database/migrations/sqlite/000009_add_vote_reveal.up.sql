@@ -0,0 +1,3 @@
+-- Add is_revealed column to votes table
+-- is_secret keeps the original sender visibility, is_revealed is set by the scheduled reveal
+ALTER TABLE votes ADD COLUMN is_revealed INTEGER DEFAULT 0;
@@ -0,0 +1,2 @@
+-- Remove hide_from_ranking column from users table (requires SQLite 3.35.0+)
+ALTER TABLE users DROP COLUMN hide_from_ranking;
@@ -0,0 +1,3 @@
+-- Add hide_from_ranking column to users table (SQLite)
+-- Users with this flag are hidden from the public ranking and leaderboard
+ALTER TABLE users ADD COLUMN hide_from_ranking INTEGER DEFAULT 0;
@@ -0,0 +1,7 @@
+-- Remove vote invalidation audit trail (SQLite, requires SQLite 3.35.0+ for DROP COLUMN)
+DROP INDEX IF EXISTS idx_vote_invalidation_log_vote_id;
+DROP TABLE IF EXISTS vote_invalidation_log;
+
+ALTER TABLE votes DROP COLUMN invalidation_reason;
+ALTER TABLE votes DROP COLUMN invalidated_at;
+ALTER TABLE votes DROP COLUMN invalidated_by;
@@ -0,0 +1,16 @@
+-- Record who invalidated a vote, when and why (SQLite)
+ALTER TABLE votes ADD COLUMN invalidated_by TEXT DEFAULT NULL;
+ALTER TABLE votes ADD COLUMN invalidated_at DATETIME DEFAULT NULL;
+ALTER TABLE votes ADD COLUMN invalidation_reason TEXT DEFAULT NULL;
+
+-- Audit trail of every invalidation toggle
+CREATE TABLE IF NOT EXISTS vote_invalidation_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    vote_id INTEGER NOT NULL REFERENCES votes(id) ON DELETE CASCADE,
+    admin_steam_id TEXT NOT NULL,
+    is_invalidated INTEGER NOT NULL,
+    reason TEXT DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_vote_invalidation_log_vote_id ON vote_invalidation_log(vote_id);
@@ -0,0 +1,3 @@
+-- Remove quick-vote token from users table (SQLite, requires SQLite 3.35.0+)
+DROP INDEX IF EXISTS idx_users_quickvote_token_hash;
+ALTER TABLE users DROP COLUMN quickvote_token_hash;
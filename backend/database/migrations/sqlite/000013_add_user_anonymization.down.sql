@@ -0,0 +1,2 @@
+-- Remove anonymized_at column from users table (requires SQLite 3.35.0+)
+ALTER TABLE users DROP COLUMN anonymized_at;
@@ -0,0 +1,3 @@
+-- Remove country code and timezone from users table (requires SQLite 3.35.0+)
+ALTER TABLE users DROP COLUMN timezone;
+ALTER TABLE users DROP COLUMN country_code;
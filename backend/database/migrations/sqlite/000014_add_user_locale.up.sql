@@ -0,0 +1,3 @@
+-- Add country code (from the Steam profile) and preferred timezone to users table
+ALTER TABLE users ADD COLUMN country_code TEXT DEFAULT '';
+ALTER TABLE users ADD COLUMN timezone TEXT DEFAULT '';
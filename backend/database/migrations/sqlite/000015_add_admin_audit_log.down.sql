@@ -0,0 +1,3 @@
+-- Remove admin audit log
+DROP INDEX IF EXISTS idx_admin_audit_log_created_at;
+DROP TABLE IF EXISTS admin_audit_log;
@@ -0,0 +1,3 @@
+-- Remove event phases
+DROP INDEX IF EXISTS idx_event_phases_starts_at;
+DROP TABLE IF EXISTS event_phases;
@@ -0,0 +1,13 @@
+-- Event phases (e.g. Friday warm-up, Saturday tournament) with their own settings profile
+CREATE TABLE IF NOT EXISTS event_phases (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    starts_at DATETIME NOT NULL,
+    credit_interval_minutes INTEGER NOT NULL,
+    negative_voting_disabled INTEGER NOT NULL DEFAULT 0,
+    vote_visibility_mode TEXT NOT NULL DEFAULT 'user_choice',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_phases_starts_at ON event_phases(starts_at);
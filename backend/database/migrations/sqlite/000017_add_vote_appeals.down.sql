@@ -0,0 +1,4 @@
+-- Remove vote appeals
+DROP INDEX IF EXISTS idx_vote_appeals_user_id;
+DROP INDEX IF EXISTS idx_vote_appeals_status;
+DROP TABLE IF EXISTS vote_appeals;
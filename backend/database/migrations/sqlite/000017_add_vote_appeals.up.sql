@@ -0,0 +1,15 @@
+-- Appeals of vote recipients against negative votes, reviewed by admins
+CREATE TABLE IF NOT EXISTS vote_appeals (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    vote_id INTEGER NOT NULL UNIQUE REFERENCES votes(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending',
+    resolved_by TEXT DEFAULT NULL,
+    resolution_note TEXT DEFAULT NULL,
+    resolved_at DATETIME DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_vote_appeals_status ON vote_appeals(status);
+CREATE INDEX IF NOT EXISTS idx_vote_appeals_user_id ON vote_appeals(user_id);
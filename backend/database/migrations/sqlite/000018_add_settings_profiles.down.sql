@@ -0,0 +1,2 @@
+-- Remove settings profiles
+DROP TABLE IF EXISTS settings_profiles;
@@ -0,0 +1,8 @@
+-- Named settings presets (e.g. "ceremony mode", "quiet hours") admins can apply with one click
+CREATE TABLE IF NOT EXISTS settings_profiles (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT UNIQUE NOT NULL,
+    settings TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
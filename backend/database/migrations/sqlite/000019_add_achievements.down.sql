@@ -0,0 +1,2 @@
+-- Remove achievements table
+DROP TABLE IF EXISTS achievements;
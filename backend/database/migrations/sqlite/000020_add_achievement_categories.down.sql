@@ -0,0 +1,3 @@
+-- Remove category and tags columns from achievements table (requires SQLite 3.35.0+)
+ALTER TABLE achievements DROP COLUMN tags;
+ALTER TABLE achievements DROP COLUMN category;
@@ -0,0 +1,15 @@
+-- Add category and tags columns to achievements table (SQLite)
+-- Tags are stored as a comma separated list of lowercase slugs
+ALTER TABLE achievements ADD COLUMN category TEXT NOT NULL DEFAULT 'skill';
+ALTER TABLE achievements ADD COLUMN tags TEXT DEFAULT '';
+
+UPDATE achievements SET category = 'skill', tags = 'aim' WHERE id = 'pro-player';
+UPDATE achievements SET category = 'social', tags = 'team' WHERE id = 'teamplayer';
+UPDATE achievements SET category = 'skill', tags = 'aim,clutch' WHERE id = 'clutch-king';
+UPDATE achievements SET category = 'social', tags = 'team,support' WHERE id = 'support-hero';
+UPDATE achievements SET category = 'skill', tags = 'tactics' WHERE id = 'stratege';
+UPDATE achievements SET category = 'social', tags = 'fairplay' WHERE id = 'good-sport';
+UPDATE achievements SET category = 'negative', tags = 'tilt' WHERE id = 'rage-quitter';
+UPDATE achievements SET category = 'negative', tags = 'chat,tilt' WHERE id = 'toxic';
+UPDATE achievements SET category = 'negative', tags = 'team,aim' WHERE id = 'friendly-fire-expert';
+UPDATE achievements SET category = 'negative' WHERE is_builtin = 0 AND is_positive = 0;
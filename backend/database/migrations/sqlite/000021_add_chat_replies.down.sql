@@ -0,0 +1,2 @@
+-- Remove reply_to_id column from chat_messages table (requires SQLite 3.35.0+)
+ALTER TABLE chat_messages DROP COLUMN reply_to_id;
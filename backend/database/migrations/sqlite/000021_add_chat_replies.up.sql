@@ -0,0 +1,3 @@
+-- Add reply_to_id column to chat_messages table (SQLite)
+-- Replies keep their text when the parent message is deleted
+ALTER TABLE chat_messages ADD COLUMN reply_to_id INTEGER DEFAULT NULL REFERENCES chat_messages(id) ON DELETE SET NULL;
@@ -0,0 +1,2 @@
+-- Remove weight column from achievements table (requires SQLite 3.35.0+)
+ALTER TABLE achievements DROP COLUMN weight;
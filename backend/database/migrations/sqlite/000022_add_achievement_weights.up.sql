@@ -0,0 +1,3 @@
+-- Add weight column to achievements table (SQLite)
+-- Votes and placement bonus points of an achievement are multiplied by its weight in the ranking
+ALTER TABLE achievements ADD COLUMN weight INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,15 @@
+-- System messages are shown with a system author instead of the admin who scheduled them
+ALTER TABLE chat_messages ADD COLUMN is_system INTEGER DEFAULT 0;
+
+-- Chat reminders scheduled by admins and posted as system messages when due
+CREATE TABLE IF NOT EXISTS chat_reminders (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    message TEXT NOT NULL,
+    send_at DATETIME NOT NULL,
+    created_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    sent_at DATETIME DEFAULT NULL,
+    chat_message_id INTEGER DEFAULT NULL REFERENCES chat_messages(id) ON DELETE SET NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_reminders_due ON chat_reminders(sent_at, send_at);
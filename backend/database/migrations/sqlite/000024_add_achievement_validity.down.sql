@@ -0,0 +1,3 @@
+-- Remove validity window from achievements table (requires SQLite 3.35.0+)
+ALTER TABLE achievements DROP COLUMN valid_until;
+ALTER TABLE achievements DROP COLUMN valid_from;
@@ -0,0 +1,3 @@
+-- Remove download checklist tables
+DROP TABLE IF EXISTS download_confirmations;
+DROP TABLE IF EXISTS download_requirements;
@@ -0,0 +1,20 @@
+-- Games and updates players should download before the event starts, listed by admins
+CREATE TABLE IF NOT EXISTS download_requirements (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    app_id INTEGER DEFAULT NULL,
+    name TEXT NOT NULL,
+    version TEXT DEFAULT '',
+    note TEXT DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Players who confirmed that a requirement is downloaded
+CREATE TABLE IF NOT EXISTS download_confirmations (
+    requirement_id INTEGER NOT NULL REFERENCES download_requirements(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (requirement_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_download_confirmations_user_id ON download_confirmations(user_id);
@@ -0,0 +1,2 @@
+-- Remove achievement suggestions table
+DROP TABLE IF EXISTS achievement_suggestions;
@@ -0,0 +1,17 @@
+-- Achievement ideas submitted by players, approved or rejected by admins
+CREATE TABLE IF NOT EXISTS achievement_suggestions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT DEFAULT '',
+    is_positive INTEGER NOT NULL DEFAULT 1,
+    status TEXT NOT NULL DEFAULT 'pending',
+    achievement_id TEXT DEFAULT NULL,
+    reviewed_by TEXT DEFAULT NULL,
+    review_note TEXT DEFAULT NULL,
+    reviewed_at DATETIME DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_suggestions_status ON achievement_suggestions(status);
+CREATE INDEX IF NOT EXISTS idx_achievement_suggestions_user_id ON achievement_suggestions(user_id);
@@ -0,0 +1,2 @@
+-- Remove user badges table
+DROP TABLE IF EXISTS user_badges;
@@ -0,0 +1,7 @@
+-- Meta-badges automatically awarded to players, badges are permanent once awarded
+CREATE TABLE IF NOT EXISTS user_badges (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    badge_id TEXT NOT NULL,
+    awarded_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, badge_id)
+);
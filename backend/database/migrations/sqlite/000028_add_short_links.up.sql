@@ -0,0 +1,9 @@
+-- Short links served under /go/:slug, e.g. for the welcome sheet
+CREATE TABLE IF NOT EXISTS short_links (
+    slug TEXT PRIMARY KEY,
+    target_url TEXT NOT NULL,
+    title TEXT DEFAULT '',
+    hits INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
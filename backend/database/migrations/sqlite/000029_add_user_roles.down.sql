@@ -0,0 +1,2 @@
+-- Remove user roles table
+DROP TABLE IF EXISTS user_roles;
@@ -0,0 +1,9 @@
+-- Roles granted at runtime, e.g. the admin claimed with the first-run setup code
+-- Admins from ADMIN_STEAM_IDS are not stored here
+CREATE TABLE IF NOT EXISTS user_roles (
+    steam_id TEXT NOT NULL,
+    role TEXT NOT NULL,
+    granted_by TEXT DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (steam_id, role)
+);
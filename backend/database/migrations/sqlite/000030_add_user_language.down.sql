@@ -0,0 +1,2 @@
+-- Remove preferred language from users table (requires SQLite 3.35.0+)
+ALTER TABLE users DROP COLUMN language;
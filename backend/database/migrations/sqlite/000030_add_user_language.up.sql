@@ -0,0 +1,2 @@
+-- Add preferred language for server-side translations to users table
+ALTER TABLE users ADD COLUMN language TEXT DEFAULT '';
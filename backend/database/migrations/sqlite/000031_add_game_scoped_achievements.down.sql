@@ -0,0 +1,4 @@
+-- Remove game scope from achievements and votes (requires SQLite 3.35.0+)
+DROP INDEX IF EXISTS idx_votes_app_id;
+ALTER TABLE votes DROP COLUMN app_id;
+ALTER TABLE achievements DROP COLUMN app_id;
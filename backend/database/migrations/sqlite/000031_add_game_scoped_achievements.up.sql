@@ -0,0 +1,6 @@
+-- Add game scope to achievements and votes (SQLite)
+-- Game-scoped achievements can only be voted in the context of their Steam app, NULL means global
+ALTER TABLE achievements ADD COLUMN app_id INTEGER DEFAULT NULL;
+-- Steam app the vote was cast in the context of, NULL means no game context
+ALTER TABLE votes ADD COLUMN app_id INTEGER DEFAULT NULL;
+CREATE INDEX IF NOT EXISTS idx_votes_app_id ON votes(app_id);
@@ -0,0 +1,2 @@
+-- Remove reduced motion accessibility preference from users table (requires SQLite 3.35.0+)
+ALTER TABLE users DROP COLUMN reduced_motion;
@@ -0,0 +1,2 @@
+-- Add reduced motion accessibility preference to users table
+ALTER TABLE users ADD COLUMN reduced_motion INTEGER DEFAULT 0;
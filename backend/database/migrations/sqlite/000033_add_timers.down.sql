@@ -0,0 +1,2 @@
+-- Remove scheduled timers table
+DROP TABLE IF EXISTS timers;
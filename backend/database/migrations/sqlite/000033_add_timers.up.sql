@@ -0,0 +1,6 @@
+-- Scheduled timers like the countdown and the secret reveal, so they survive restarts
+CREATE TABLE IF NOT EXISTS timers (
+    name TEXT PRIMARY KEY,
+    fire_at DATETIME NOT NULL,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+-- Remove chat mentions table
+DROP TABLE IF EXISTS chat_mentions;
@@ -0,0 +1,11 @@
+-- Users mentioned with @username in chat messages, unread until the user has seen them
+CREATE TABLE IF NOT EXISTS chat_mentions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    chat_message_id INTEGER NOT NULL REFERENCES chat_messages(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    is_read INTEGER DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (chat_message_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_mentions_user ON chat_mentions(user_id, is_read);
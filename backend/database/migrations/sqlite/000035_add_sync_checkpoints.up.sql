@@ -0,0 +1,8 @@
+-- Position of running background syncs, so a restarted instance resumes where it left off
+CREATE TABLE IF NOT EXISTS sync_checkpoints (
+    name TEXT PRIMARY KEY,
+    last_app_id INTEGER NOT NULL DEFAULT 0,
+    processed INTEGER NOT NULL DEFAULT 0,
+    total INTEGER NOT NULL DEFAULT 0,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
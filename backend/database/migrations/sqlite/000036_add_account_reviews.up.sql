@@ -0,0 +1,26 @@
+-- IP addresses of Steam logins, including blocked ones, to recognize alt accounts of banned players
+CREATE TABLE IF NOT EXISTS ip_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    steam_id TEXT NOT NULL,
+    ip_address TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_ip_log_ip_address ON ip_log(ip_address);
+CREATE INDEX IF NOT EXISTS idx_ip_log_steam_id ON ip_log(steam_id);
+
+-- New accounts flagged by the ban evasion heuristics, they can't log in until an admin approves them
+CREATE TABLE IF NOT EXISTS account_reviews (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    steam_id TEXT NOT NULL UNIQUE,
+    username TEXT NOT NULL,
+    reasons TEXT NOT NULL,
+    ip_address TEXT DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending',
+    reviewed_by TEXT DEFAULT NULL,
+    reviewed_at DATETIME DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_reviews_status ON account_reviews(status);
@@ -0,0 +1,2 @@
+-- Remove the chat filter blocklist
+DROP TABLE IF EXISTS chat_blocked_words;
@@ -0,0 +1,8 @@
+-- Words blocked by the chat filter, language is the preset a word was imported from (empty = added by hand)
+CREATE TABLE IF NOT EXISTS chat_blocked_words (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    word TEXT NOT NULL UNIQUE,
+    language TEXT NOT NULL DEFAULT '',
+    created_by TEXT DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,9 @@
+-- Chat mutes set by admins, the user can't chat (and optionally vote) until muted_until
+CREATE TABLE IF NOT EXISTS user_mutes (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    muted_until DATETIME NOT NULL,
+    include_votes INTEGER DEFAULT 0,
+    reason TEXT DEFAULT '',
+    muted_by TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
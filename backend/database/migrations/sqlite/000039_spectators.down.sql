@@ -0,0 +1,2 @@
+-- Remove spectator accounts
+DROP TABLE IF EXISTS spectators;
@@ -0,0 +1,9 @@
+-- Read-only spectator accounts created by admins, they log in with name and passcode instead of Steam
+CREATE TABLE IF NOT EXISTS spectators (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE,
+    passcode_hash TEXT NOT NULL,
+    created_by TEXT NOT NULL,
+    last_login_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
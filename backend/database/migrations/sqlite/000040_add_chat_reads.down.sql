@@ -0,0 +1,2 @@
+-- Remove chat read state table
+DROP TABLE IF EXISTS chat_reads;
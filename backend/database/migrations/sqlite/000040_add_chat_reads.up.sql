@@ -0,0 +1,6 @@
+-- Last chat message each user has read, newer messages of others count as unread
+CREATE TABLE IF NOT EXISTS chat_reads (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    last_read_message_id INTEGER NOT NULL DEFAULT 0,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
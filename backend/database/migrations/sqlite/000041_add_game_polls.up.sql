@@ -0,0 +1,31 @@
+-- "What do we play next?" polls created by players from the multiplayer games list
+CREATE TABLE IF NOT EXISTS polls (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    question TEXT NOT NULL,
+    created_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    closes_at DATETIME DEFAULT NULL,
+    closed_at DATETIME DEFAULT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_polls_created_at ON polls(created_at DESC);
+
+-- Games to choose from, the name is kept in case the game leaves the games list
+CREATE TABLE IF NOT EXISTS poll_options (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    poll_id INTEGER NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+    app_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    UNIQUE (poll_id, app_id)
+);
+
+-- One vote per player and poll, it can be changed while the poll is open
+CREATE TABLE IF NOT EXISTS poll_votes (
+    poll_id INTEGER NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    option_id INTEGER NOT NULL REFERENCES poll_options(id) ON DELETE CASCADE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (poll_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_poll_votes_option_id ON poll_votes(option_id);
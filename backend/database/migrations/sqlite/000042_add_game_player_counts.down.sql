@@ -0,0 +1,2 @@
+-- Remove the maximum player count from game_cache (requires SQLite 3.35.0+)
+ALTER TABLE game_cache DROP COLUMN max_players;
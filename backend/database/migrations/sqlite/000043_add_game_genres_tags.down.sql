@@ -0,0 +1,3 @@
+-- Remove genres and tags from game_cache (requires SQLite 3.35.0+)
+ALTER TABLE game_cache DROP COLUMN tags;
+ALTER TABLE game_cache DROP COLUMN genres;
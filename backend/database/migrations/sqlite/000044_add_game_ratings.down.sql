@@ -0,0 +1,3 @@
+-- Remove game ratings
+DROP INDEX IF EXISTS idx_game_ratings_user;
+DROP TABLE IF EXISTS game_ratings;
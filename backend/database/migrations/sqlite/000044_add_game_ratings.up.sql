@@ -0,0 +1,11 @@
+-- Star ratings (1-5) of games by players, one rating per player and game
+CREATE TABLE IF NOT EXISTS game_ratings (
+    app_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rating INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (app_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_ratings_user ON game_ratings(user_id);
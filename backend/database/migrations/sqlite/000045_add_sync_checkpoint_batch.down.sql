@@ -0,0 +1,2 @@
+-- Remove the batch from sync_checkpoints (requires SQLite 3.35.0+)
+ALTER TABLE sync_checkpoints DROP COLUMN batch_id;
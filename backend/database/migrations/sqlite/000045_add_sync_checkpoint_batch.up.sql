@@ -0,0 +1,2 @@
+-- Add the batch the checkpoint belongs to, a resumed sync continues the same batch
+ALTER TABLE sync_checkpoints ADD COLUMN batch_id TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,8 @@
+-- Server connect strings per game, set by any player so the others can join with one click
+CREATE TABLE IF NOT EXISTS game_servers (
+    app_id INTEGER PRIMARY KEY,
+    address TEXT NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    updated_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,4 @@
+-- Remove game sessions
+DROP INDEX IF EXISTS idx_game_sessions_app;
+DROP INDEX IF EXISTS idx_game_sessions_user;
+DROP TABLE IF EXISTS game_sessions;
@@ -0,0 +1,12 @@
+-- Play time of players per game during the party, recorded manually or inferred from the Steam presence
+CREATE TABLE IF NOT EXISTS game_sessions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    app_id INTEGER NOT NULL,
+    source TEXT NOT NULL DEFAULT 'manual', -- 'manual' or 'presence'
+    started_at DATETIME NOT NULL,
+    ended_at DATETIME -- NULL while the player is still playing
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_sessions_user ON game_sessions(user_id, ended_at);
+CREATE INDEX IF NOT EXISTS idx_game_sessions_app ON game_sessions(app_id);
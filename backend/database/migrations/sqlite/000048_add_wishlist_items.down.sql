@@ -0,0 +1,3 @@
+-- Remove cached wishlists
+DROP INDEX IF EXISTS idx_wishlist_items_app;
+DROP TABLE IF EXISTS wishlist_items;
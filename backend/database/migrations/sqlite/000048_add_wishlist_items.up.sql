@@ -0,0 +1,10 @@
+-- Cached Steam wishlists of the players, refreshed periodically for the group purchase overview
+CREATE TABLE IF NOT EXISTS wishlist_items (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    app_id INTEGER NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 0,
+    added_at DATETIME,
+    PRIMARY KEY (user_id, app_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_wishlist_items_app ON wishlist_items(app_id);
@@ -0,0 +1,3 @@
+-- Add the Steam review wording ("Overwhelmingly Positive") and the total number of reviews to game_cache
+ALTER TABLE game_cache ADD COLUMN review_score_desc TEXT NOT NULL DEFAULT '';
+ALTER TABLE game_cache ADD COLUMN review_count INTEGER NOT NULL DEFAULT 0;
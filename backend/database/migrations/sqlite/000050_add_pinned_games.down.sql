@@ -0,0 +1,2 @@
+-- Remove pinned games
+DROP TABLE IF EXISTS pinned_games;
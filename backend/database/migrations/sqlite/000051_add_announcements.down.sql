@@ -0,0 +1,2 @@
+-- Remove announcements
+DROP TABLE IF EXISTS announcements;
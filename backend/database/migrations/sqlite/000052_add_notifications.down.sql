@@ -0,0 +1,2 @@
+-- Remove personal notifications
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,7 @@
+-- When each migration was applied, schema_migrations of golang-migrate only keeps the current version
+-- Migrations applied before this table existed are recorded at the next start
+CREATE TABLE IF NOT EXISTS schema_migration_history (
+    version INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    applied_at DATETIME NOT NULL
+);
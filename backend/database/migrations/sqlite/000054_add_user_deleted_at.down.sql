@@ -0,0 +1,2 @@
+-- Remove deleted_at column from users table (requires SQLite 3.35.0+)
+ALTER TABLE users DROP COLUMN deleted_at;
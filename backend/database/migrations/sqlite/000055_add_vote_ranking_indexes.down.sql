@@ -0,0 +1,3 @@
+-- Remove covering indexes for the leaderboard and the global ranking (SQLite)
+DROP INDEX IF EXISTS idx_votes_received_since;
+DROP INDEX IF EXISTS idx_votes_ranking;
@@ -0,0 +1,4 @@
+-- Remove vote_id column from notifications table (requires SQLite 3.35.0+)
+-- The copied vote payloads are gone, so vote notifications are deleted
+DELETE FROM notifications WHERE type = 'vote_received';
+ALTER TABLE notifications DROP COLUMN vote_id;
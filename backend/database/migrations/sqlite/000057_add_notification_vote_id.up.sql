@@ -0,0 +1,6 @@
+-- Vote notifications reference their vote instead of a copy of it (SQLite)
+-- Sender and visibility are read when the notification is loaded, so anonymization, reveals
+-- and later visibility changes apply to old notifications as well
+ALTER TABLE notifications ADD COLUMN vote_id INTEGER DEFAULT NULL REFERENCES votes(id) ON DELETE CASCADE;
+UPDATE notifications SET vote_id = json_extract(payload, '$.vote_id'), payload = '{}' WHERE type = 'vote_received';
+DELETE FROM notifications WHERE type = 'vote_received' AND (vote_id IS NULL OR vote_id NOT IN (SELECT id FROM votes));
@@ -0,0 +1,2 @@
+-- Remove deleted_steam_id column from users table (requires SQLite 3.35.0+)
+ALTER TABLE users DROP COLUMN deleted_steam_id;
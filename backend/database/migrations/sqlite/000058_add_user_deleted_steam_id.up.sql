@@ -0,0 +1,4 @@
+-- Add deleted_steam_id column to users table
+-- Keeps the Steam ID of a removed user, so anonymization also finds their login IPs and ban entries
+-- Users removed before this migration have lost their Steam ID already
+ALTER TABLE users ADD COLUMN deleted_steam_id TEXT DEFAULT NULL;
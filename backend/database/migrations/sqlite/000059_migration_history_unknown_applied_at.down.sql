@@ -0,0 +1,12 @@
+-- Restore the NOT NULL applied_at column, unknown times are set to now
+CREATE TABLE schema_migration_history_old (
+    version INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    applied_at DATETIME NOT NULL
+);
+
+INSERT INTO schema_migration_history_old (version, name, applied_at)
+SELECT version, name, COALESCE(applied_at, CURRENT_TIMESTAMP) FROM schema_migration_history;
+
+DROP TABLE schema_migration_history;
+ALTER TABLE schema_migration_history_old RENAME TO schema_migration_history;
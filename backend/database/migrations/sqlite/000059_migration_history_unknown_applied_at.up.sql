@@ -0,0 +1,16 @@
+-- applied_at is NULL for migrations that ran before the history existed, their time is unknown
+-- The first start with the history recorded all earlier migrations with its own start time,
+-- those made-up times are cleared (000053 created the table and really ran at that time)
+CREATE TABLE schema_migration_history_new (
+    version INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    applied_at DATETIME DEFAULT NULL
+);
+
+INSERT INTO schema_migration_history_new (version, name, applied_at)
+SELECT version, name,
+    CASE WHEN version < 53 AND applied_at = (SELECT MIN(applied_at) FROM schema_migration_history) THEN NULL ELSE applied_at END
+FROM schema_migration_history;
+
+DROP TABLE schema_migration_history;
+ALTER TABLE schema_migration_history_new RENAME TO schema_migration_history;
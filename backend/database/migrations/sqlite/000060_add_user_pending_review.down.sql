@@ -0,0 +1,2 @@
+-- Remove pending_review column from users table (requires SQLite 3.35.0+)
+ALTER TABLE users DROP COLUMN pending_review;
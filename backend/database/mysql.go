@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/go-sql-driver/mysql"
@@ -100,6 +101,12 @@ func initMySQL(cfg MySQLConfig) error {
 	// Set database type
 	dbType = DBTypeMySQL
 
+	var version string
+	if err := DB.QueryRow("SELECT VERSION()").Scan(&version); err != nil {
+		log.Printf("Warning: Could not read MySQL server version: %v", err)
+	}
+	windowFunctions = mysqlSupportsWindowFunctions(version)
+
 	// Log connection info (without password)
 	log.Printf("MySQL database initialized: %s@%s:%d/%s (TLS: %v)",
 		cfg.User, cfg.Host, cfg.Port, cfg.Database, cfg.TLSEnabled)
@@ -107,6 +114,19 @@ func initMySQL(cfg MySQLConfig) error {
 	return nil
 }
 
+// mysqlSupportsWindowFunctions checks a server version like "8.0.36" or "10.11.6-MariaDB"
+// An unknown version is treated as unsupported, queries then fall back to plain aggregates
+func mysqlSupportsWindowFunctions(version string) bool {
+	var major, minor int
+	if _, err := fmt.Sscanf(version, "%d.%d", &major, &minor); err != nil {
+		return false
+	}
+	if strings.Contains(strings.ToLower(version), "mariadb") {
+		return major > 10 || (major == 10 && minor >= 2)
+	}
+	return major >= 8
+}
+
 // ensureMySQLDatabaseExists connects without a database and creates it if necessary
 func ensureMySQLDatabaseExists(cfg MySQLConfig) error {
 	// Build MySQL DSN without database name
@@ -25,6 +25,11 @@ func initSQLite(dbPath string) error {
 		return fmt.Errorf("failed to create database directory: %w", err)
 	}
 
+	// A backup restored by an admin replaces the database before it is opened
+	if err := applyStagedRestore(dbPath); err != nil {
+		return err
+	}
+
 	// Open database connection with optimized settings for concurrent access
 	// _journal_mode=WAL enables Write-Ahead Logging for better concurrent writes
 	// _busy_timeout=10000 waits up to 10 seconds before returning SQLITE_BUSY
@@ -63,6 +68,8 @@ func initSQLite(dbPath string) error {
 
 	// Set database type
 	dbType = DBTypeSQLite
+	// The bundled SQLite is newer than 3.25
+	windowFunctions = true
 
 	log.Printf("SQLite database initialized: %s", dbPath)
 	return nil
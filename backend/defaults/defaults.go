@@ -0,0 +1,12 @@
+// Package defaults embeds the default configuration files into the binary
+// Files on disk (GAME_METADATA_PATH, I18N_PATH) take precedence, the embedded
+// copies are used when they are missing, e.g. for the single binary release
+package defaults
+
+import "embed"
+
+// FS contains game_metadata.json, the i18n/*.json translation files,
+// the chatfilter/*.txt blocklist presets and the mail/* e-mail templates
+//
+//go:embed game_metadata.json i18n/*.json chatfilter/*.txt mail/*
+var FS embed.FS
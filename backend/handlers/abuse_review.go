@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+)
+
+// AbuseReviewHandler lets admins review secret votes for harassment
+// Every access to the real senders is recorded in the admin audit trail
+type AbuseReviewHandler struct {
+	voteRepo  *repository.VoteRepository
+	auditRepo *repository.AuditRepository
+}
+
+// NewAbuseReviewHandler creates a new abuse review handler
+func NewAbuseReviewHandler(voteRepo *repository.VoteRepository, auditRepo *repository.AuditRepository) *AbuseReviewHandler {
+	return &AbuseReviewHandler{
+		voteRepo:  voteRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+// GetSecretVotes returns recent secret votes with their real senders and
+// per-sender counts of negative secret votes (admin only, requires elevation)
+// GET /api/v1/admin/secret-votes?limit=500&cursor=xxx
+func (h *AbuseReviewHandler) GetSecretVotes(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	page, ok := parsePage(c, 500)
+	if !ok {
+		return
+	}
+
+	// Write the audit entry first - no audit trail, no data
+	details := fmt.Sprintf("Viewed secret votes from %s", c.ClientIP())
+	if err := h.auditRepo.Log(ctx, claims.SteamID, models.AuditActionViewSecretVotes, details); err != nil {
+		log.Printf("Failed to write audit log for admin %s: %v", claims.SteamID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to write audit log",
+		})
+		return
+	}
+	log.Printf("Admin %s viewed the real senders of secret votes", claims.SteamID)
+
+	votes, err := h.voteRepo.GetSecretForAdmin(ctx, page)
+	if err != nil {
+		log.Printf("Failed to get secret votes: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load secret votes",
+		})
+		return
+	}
+
+	senders, err := h.voteRepo.GetNegativeSecretVoteCountsBySender(ctx)
+	if err != nil {
+		log.Printf("Failed to get secret vote counts: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load secret votes",
+		})
+		return
+	}
+
+	if votes == nil {
+		votes = []models.VoteWithDetails{}
+	}
+	if senders == nil {
+		senders = []repository.SenderSecretVoteCount{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"votes":       votes,
+		"next_cursor": repository.NextCursor(page, votes, voteCursorID),
+		"senders":     senders,
+	})
+}
+
+// GetAuditLog returns the most recent admin audit log entries
+// GET /api/v1/admin/audit-log
+func (h *AbuseReviewHandler) GetAuditLog(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	entries, err := h.auditRepo.GetRecent(ctx, 200)
+	if err != nil {
+		log.Printf("Failed to get audit log: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load audit log",
+		})
+		return
+	}
+
+	if entries == nil {
+		entries = []models.AdminAuditEntry{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+	})
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/services"
+	"github.com/guided-traffic/rate-your-mate/backend/websocket"
+)
+
+// AccountReviewHandler handles the admin review of new accounts flagged as possible ban evasion
+type AccountReviewHandler struct {
+	reviewRepo  *repository.AccountReviewRepository
+	gameService *services.GameService
+	wsHub       *websocket.Hub
+}
+
+// NewAccountReviewHandler creates a new account review handler
+func NewAccountReviewHandler(reviewRepo *repository.AccountReviewRepository, gameService *services.GameService, wsHub *websocket.Hub) *AccountReviewHandler {
+	return &AccountReviewHandler{
+		reviewRepo:  reviewRepo,
+		gameService: gameService,
+		wsHub:       wsHub,
+	}
+}
+
+// GetReviews returns flagged accounts, pending ones by default (admin only)
+// GET /api/v1/admin/account-reviews?status=pending
+func (h *AccountReviewHandler) GetReviews(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	status := c.DefaultQuery("status", models.AccountReviewPending)
+	switch status {
+	case "all":
+		status = ""
+	case models.AccountReviewPending, models.AccountReviewApproved, models.AccountReviewRejected:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "status must be 'pending', 'approved', 'rejected' or 'all'",
+		})
+		return
+	}
+
+	reviews, err := h.reviewRepo.GetByStatus(ctx, status, 200)
+	if err != nil {
+		log.Printf("Failed to get account reviews: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load account reviews",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reviews": reviews,
+	})
+}
+
+// Approve lets a flagged account log in (admin only)
+// POST /api/v1/admin/account-reviews/:id/approve
+func (h *AccountReviewHandler) Approve(c *gin.Context) {
+	h.resolve(c, models.AccountReviewApproved)
+}
+
+// Reject keeps a flagged account locked out (admin only)
+// POST /api/v1/admin/account-reviews/:id/reject
+func (h *AccountReviewHandler) Reject(c *gin.Context) {
+	h.resolve(c, models.AccountReviewRejected)
+}
+
+// resolve closes a pending review with the given status
+func (h *AccountReviewHandler) resolve(c *gin.Context, status string) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	reviewID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid review ID",
+		})
+		return
+	}
+
+	review, err := h.reviewRepo.GetByID(ctx, reviewID)
+	if err != nil {
+		log.Printf("Failed to get account review %d: %v", reviewID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to resolve account review",
+		})
+		return
+	}
+	if review == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Account review not found",
+		})
+		return
+	}
+
+	resolved, err := h.reviewRepo.Resolve(ctx, reviewID, status, claims.SteamID)
+	if err != nil {
+		log.Printf("Failed to resolve account review %d: %v", reviewID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to resolve account review",
+		})
+		return
+	}
+	if !resolved {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Account review has already been resolved",
+		})
+		return
+	}
+
+	log.Printf("Admin %s resolved account review %d of %s (%s) as %s", claims.SteamID, reviewID, review.Username, review.SteamID, status)
+
+	// The game library of held accounts is not synced at registration
+	if status == models.AccountReviewApproved && h.gameService != nil && h.wsHub != nil {
+		h.gameService.RegisterUserGames(review.SteamID, SyncProgressBroadcaster(h.wsHub))
+	}
+
+	review, err = h.reviewRepo.GetByID(ctx, reviewID)
+	if err != nil || review == nil {
+		log.Printf("Failed to reload account review %d: %v", reviewID, err)
+		c.JSON(http.StatusOK, gin.H{
+			"id":     reviewID,
+			"status": status,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
@@ -1,24 +1,212 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/config"
 	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/services"
+	"github.com/guided-traffic/rate-your-mate/backend/websocket"
 )
 
+// achievementIDPattern restricts achievement IDs to URL-safe slugs like "clutch-king"
+var achievementIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,49}$`)
+
+// achievementTagPattern restricts tags to short lowercase slugs like "clutch"
+var achievementTagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,29}$`)
+
+// maxAchievementTags limits the number of tags per achievement
+const maxAchievementTags = 10
+
 // AchievementHandler handles achievement-related endpoints
-type AchievementHandler struct{}
+type AchievementHandler struct {
+	achievementRepo *repository.AchievementRepository
+	voteRepo        *repository.VoteRepository
+	i18nService     *services.I18nService
+	wsHub           *websocket.Hub
+	cfg             *config.Config
+}
 
 // NewAchievementHandler creates a new achievement handler
-func NewAchievementHandler() *AchievementHandler {
-	return &AchievementHandler{}
+func NewAchievementHandler(achievementRepo *repository.AchievementRepository, voteRepo *repository.VoteRepository, i18nService *services.I18nService, wsHub *websocket.Hub, cfg *config.Config) *AchievementHandler {
+	return &AchievementHandler{
+		achievementRepo: achievementRepo,
+		voteRepo:        voteRepo,
+		i18nService:     i18nService,
+		wsHub:           wsHub,
+		cfg:             cfg,
+	}
+}
+
+// AchievementRequest represents the request body for creating or updating an achievement
+type AchievementRequest struct {
+	ID          string   `json:"id"` // Only used on create, immutable afterwards
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	ImageURL    string   `json:"image_url"`
+	IsPositive  bool     `json:"is_positive"`
+	Category    string   `json:"category"` // Optional, defaults to skill or negative on create and is kept on update
+	Tags        []string `json:"tags"`     // Optional, keeps the current tags on update if omitted
+	Weight      *int     `json:"weight"`   // Optional, defaults to 1 on create and is kept on update
+	IsDisabled  bool     `json:"is_disabled"`
+	ValidFrom   *string  `json:"valid_from"`  // Optional RFC3339 time, an empty string removes the bound, kept on update if omitted
+	ValidUntil  *string  `json:"valid_until"` // Same as valid_from, votes are rejected and the achievement is archived afterwards
+	SortOrder   *int     `json:"sort_order"`  // Optional, keeps the current position if omitted
+	AppID       *int     `json:"app_id"`      // Optional Steam app ID of a game-scoped achievement, 0 makes it global, kept on update if omitted
+
+	// Validity window and game scope parsed by validate
+	validFrom  *time.Time
+	validUntil *time.Time
+	appID      *int
+}
+
+// validate checks the editable fields of the request
+func (r *AchievementRequest) validate() string {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Description = strings.TrimSpace(r.Description)
+	r.ImageURL = strings.TrimSpace(r.ImageURL)
+
+	if r.Name == "" || len(r.Name) > 100 {
+		return "name must be between 1 and 100 characters"
+	}
+	if len(r.Description) > 255 {
+		return "description must be at most 255 characters"
+	}
+	if len(r.ImageURL) > 500 {
+		return "image_url must be at most 500 characters"
+	}
+
+	if r.Weight != nil && (*r.Weight < models.MinAchievementWeight || *r.Weight > models.MaxAchievementWeight) {
+		return "weight must be between 0 and 10"
+	}
+
+	var err error
+	if r.validFrom, err = parseValidityBound(r.ValidFrom); err != nil {
+		return "valid_from must be in RFC3339 format (e.g., 2024-12-31T18:00:00+01:00)"
+	}
+	if r.validUntil, err = parseValidityBound(r.ValidUntil); err != nil {
+		return "valid_until must be in RFC3339 format (e.g., 2025-01-01T06:00:00+01:00)"
+	}
+
+	if r.AppID != nil {
+		if *r.AppID < 0 {
+			return "app_id must be a Steam app ID or 0 for a global achievement"
+		}
+		if *r.AppID > 0 {
+			appID := *r.AppID
+			r.appID = &appID
+		}
+	}
+
+	r.Category = strings.ToLower(strings.TrimSpace(r.Category))
+	if r.Category != "" && !models.IsValidAchievementCategory(r.Category) {
+		return "category must be one of skill, social, meme or negative"
+	}
+
+	if r.Tags != nil {
+		if len(r.Tags) > maxAchievementTags {
+			return "at most 10 tags are allowed"
+		}
+		tags := make([]string, 0, len(r.Tags))
+		seen := make(map[string]bool)
+		for _, tag := range r.Tags {
+			tag = strings.ToLower(strings.TrimSpace(tag))
+			if !achievementTagPattern.MatchString(tag) {
+				return "tags must be 1-30 lowercase letters, digits or dashes"
+			}
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+		r.Tags = tags
+	}
+	return ""
 }
 
-// GetAll returns all available achievements
+// parseValidityBound parses an optional RFC3339 bound of the validity window, empty means unbounded
+func parseValidityBound(value *string) (*time.Time, error) {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(*value))
+	if err != nil {
+		return nil, err
+	}
+	t = t.UTC()
+	return &t, nil
+}
+
+// validateValidityWindow checks that a validity window does not end before it starts
+func validateValidityWindow(a *models.Achievement) string {
+	if a.ValidFrom != nil && a.ValidUntil != nil && !a.ValidUntil.After(*a.ValidFrom) {
+		return "valid_until must be after valid_from"
+	}
+	return ""
+}
+
+// defaultAchievementCategory picks the category for achievements created without one
+func defaultAchievementCategory(isPositive bool) string {
+	if isPositive {
+		return models.AchievementCategorySkill
+	}
+	return models.AchievementCategoryNegative
+}
+
+// broadcastAchievements sends the full achievement list to all connected clients
+func (h *AchievementHandler) broadcastAchievements() {
+	h.wsHub.BroadcastAchievementsUpdate(&websocket.AchievementsUpdatePayload{
+		Achievements: models.GetAllAchievements(),
+	})
+}
+
+// AchievementCategoryGroup lists the achievements of one category
+type AchievementCategoryGroup struct {
+	models.AchievementCategory
+	Achievements []models.Achievement `json:"achievements"`
+}
+
+// GetAll returns all achievements that can currently be voted, translated by the Accept-Language header
+// Optional query parameters: category, tag, app_id (voting context, adds the achievements scoped to that game)
 // GET /api/v1/achievements
 func (h *AchievementHandler) GetAll(c *gin.Context) {
-	achievements := models.GetAllAchievements()
+	category := c.Query("category")
+	if category != "" && !models.IsValidAchievementCategory(category) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid category",
+		})
+		return
+	}
+	tag := strings.ToLower(c.Query("tag"))
+	appID, ok := parseAppIDQuery(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid app ID",
+		})
+		return
+	}
+	language := h.i18nService.Negotiate(c.GetHeader("Accept-Language"))
+
+	achievements := make([]models.Achievement, 0)
+	for _, a := range h.i18nService.Achievements(language, models.GetEnabledAchievements()) {
+		if category != "" && a.Category != category {
+			continue
+		}
+		if !a.IsAvailableForGame(appID) {
+			continue
+		}
+		if tag != "" && !hasTag(a.Tags, tag) {
+			continue
+		}
+		achievements = append(achievements, a)
+	}
 
 	// Separate positive and negative achievements
 	positive := make([]models.Achievement, 0)
@@ -32,14 +220,52 @@ func (h *AchievementHandler) GetAll(c *gin.Context) {
 		}
 	}
 
+	// Group by category, keeping the category order and skipping empty ones
+	groups := make([]AchievementCategoryGroup, 0, len(models.AchievementCategories))
+	for _, cat := range models.AchievementCategories {
+		group := AchievementCategoryGroup{AchievementCategory: h.i18nService.Category(language, cat), Achievements: make([]models.Achievement, 0)}
+		for _, a := range achievements {
+			if a.Category == cat.ID {
+				group.Achievements = append(group.Achievements, a)
+			}
+		}
+		if len(group.Achievements) > 0 {
+			groups = append(groups, group)
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"achievements": achievements,
 		"positive":     positive,
 		"negative":     negative,
+		"categories":   groups,
 	})
 }
 
-// GetByID returns a single achievement by ID
+// parseAppIDQuery reads the optional app_id query parameter, 0 means no game context
+func parseAppIDQuery(c *gin.Context) (int, bool) {
+	value := c.Query("app_id")
+	if value == "" {
+		return 0, true
+	}
+	appID, err := strconv.Atoi(value)
+	if err != nil || appID <= 0 {
+		return 0, false
+	}
+	return appID, true
+}
+
+// hasTag checks if a tag list contains a tag
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// GetByID returns a single achievement by ID, translated by the Accept-Language header
 // GET /api/v1/achievements/:id
 func (h *AchievementHandler) GetByID(c *gin.Context) {
 	id := c.Param("id")
@@ -52,7 +278,264 @@ func (h *AchievementHandler) GetByID(c *gin.Context) {
 		return
 	}
 
+	language := h.i18nService.Negotiate(c.GetHeader("Accept-Language"))
+
+	c.JSON(http.StatusOK, gin.H{
+		"achievement": h.i18nService.Achievement(language, achievement),
+	})
+}
+
+// GetStats returns how often each achievement was received and how rare it is, translated by the Accept-Language header
+// GET /api/v1/achievements/stats
+func (h *AchievementHandler) GetStats(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	stats, totalPlayers, err := h.voteRepo.GetAchievementStats(ctx)
+	if err != nil {
+		log.Printf("Failed to get achievement stats: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load achievement stats",
+		})
+		return
+	}
+
+	language := h.i18nService.Negotiate(c.GetHeader("Accept-Language"))
+	for i := range stats {
+		stats[i].Achievement = h.i18nService.Achievement(language, stats[i].Achievement)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"stats":         stats,
+		"total_players": totalPlayers,
+	})
+}
+
+// GetAllForAdmin returns all achievements including disabled ones (admin only)
+// GET /api/v1/admin/achievements
+func (h *AchievementHandler) GetAllForAdmin(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"achievements": models.GetAllAchievements(),
+	})
+}
+
+// Create adds a custom achievement (admin only)
+// POST /api/v1/admin/achievements
+func (h *AchievementHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req AchievementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	req.ID = strings.TrimSpace(req.ID)
+	if !achievementIDPattern.MatchString(req.ID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "id must be 2-50 lowercase letters, digits or dashes",
+		})
+		return
+	}
+	if msg := req.validate(); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": msg,
+		})
+		return
+	}
+	if models.IsValidAchievement(req.ID) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Achievement already exists",
+		})
+		return
+	}
+
+	if req.Category == "" {
+		req.Category = defaultAchievementCategory(req.IsPositive)
+	}
+	if req.Tags == nil {
+		req.Tags = []string{}
+	}
+	weight := 1
+	if req.Weight != nil {
+		weight = *req.Weight
+	}
+
+	achievement := &models.Achievement{
+		ID:          req.ID,
+		Name:        req.Name,
+		Description: req.Description,
+		ImageURL:    req.ImageURL,
+		IsPositive:  req.IsPositive,
+		Category:    req.Category,
+		Tags:        req.Tags,
+		Weight:      weight,
+		IsDisabled:  req.IsDisabled,
+		ValidFrom:   req.validFrom,
+		ValidUntil:  req.validUntil,
+		AppID:       req.appID,
+	}
+	if msg := validateValidityWindow(achievement); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": msg,
+		})
+		return
+	}
+	if err := h.achievementRepo.Create(ctx, achievement); err != nil {
+		log.Printf("Failed to create achievement %s: %v", req.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create achievement",
+		})
+		return
+	}
+
+	log.Printf("Admin created achievement '%s' (%s)", achievement.Name, achievement.ID)
+	h.broadcastAchievements()
+
+	c.JSON(http.StatusCreated, gin.H{
+		"achievement": achievement,
+	})
+}
+
+// Update edits an achievement, built-ins included (admin only)
+// Setting is_disabled hides the achievement from voting while keeping its votes
+// PUT /api/v1/admin/achievements/:id
+func (h *AchievementHandler) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	existing, ok := models.GetAchievement(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Achievement not found",
+		})
+		return
+	}
+
+	var req AchievementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+	if msg := req.validate(); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": msg,
+		})
+		return
+	}
+
+	achievement := existing
+	achievement.Name = req.Name
+	achievement.Description = req.Description
+	achievement.ImageURL = req.ImageURL
+	achievement.IsPositive = req.IsPositive
+	achievement.IsDisabled = req.IsDisabled
+	if req.Category != "" {
+		achievement.Category = req.Category
+	}
+	if req.Tags != nil {
+		achievement.Tags = req.Tags
+	}
+	if req.Weight != nil {
+		achievement.Weight = *req.Weight
+	}
+	if req.ValidFrom != nil {
+		achievement.ValidFrom = req.validFrom
+	}
+	if req.ValidUntil != nil {
+		achievement.ValidUntil = req.validUntil
+	}
+	if req.AppID != nil {
+		achievement.AppID = req.appID
+	}
+	if msg := validateValidityWindow(&achievement); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": msg,
+		})
+		return
+	}
+	if req.SortOrder != nil {
+		achievement.SortOrder = *req.SortOrder
+	}
+
+	if err := h.achievementRepo.Update(ctx, &achievement); err != nil {
+		log.Printf("Failed to update achievement %s: %v", achievement.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update achievement",
+		})
+		return
+	}
+
+	log.Printf("Admin updated achievement '%s' (%s, weight: %d, disabled: %v)", achievement.Name, achievement.ID, achievement.Weight, achievement.IsDisabled)
+	h.broadcastAchievements()
+
 	c.JSON(http.StatusOK, gin.H{
 		"achievement": achievement,
 	})
 }
+
+// Delete removes a custom achievement that has not been voted yet (admin only)
+// Built-ins and achievements with votes can only be disabled
+// DELETE /api/v1/admin/achievements/:id
+func (h *AchievementHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	achievement, ok := models.GetAchievement(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Achievement not found",
+		})
+		return
+	}
+
+	if achievement.IsBuiltin {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Built-in achievements cannot be deleted, disable them instead",
+		})
+		return
+	}
+
+	votes, err := h.achievementRepo.CountVotes(ctx, achievement.ID)
+	if err != nil {
+		log.Printf("Failed to count votes of achievement %s: %v", achievement.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete achievement",
+		})
+		return
+	}
+	if votes > 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Achievement has votes and cannot be deleted, disable it instead",
+			"votes": votes,
+		})
+		return
+	}
+
+	if err := h.achievementRepo.Delete(ctx, achievement.ID); err != nil {
+		log.Printf("Failed to delete achievement %s: %v", achievement.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete achievement",
+		})
+		return
+	}
+
+	// Drop a daily limit of the deleted achievement, it would fail settings validation otherwise
+	if _, ok := h.cfg.AchievementDailyLimits[achievement.ID]; ok {
+		limits := make(map[string]int, len(h.cfg.AchievementDailyLimits))
+		for achievementID, limit := range h.cfg.AchievementDailyLimits {
+			if achievementID != achievement.ID {
+				limits[achievementID] = limit
+			}
+		}
+		h.cfg.AchievementDailyLimits = limits
+	}
+
+	log.Printf("Admin deleted achievement '%s' (%s)", achievement.Name, achievement.ID)
+	h.broadcastAchievements()
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Achievement deleted",
+	})
+}
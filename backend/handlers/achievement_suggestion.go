@@ -0,0 +1,404 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/websocket"
+)
+
+// AchievementSuggestionHandler handles achievement ideas of players and their moderation queue
+type AchievementSuggestionHandler struct {
+	suggestionRepo  *repository.AchievementSuggestionRepository
+	achievementRepo *repository.AchievementRepository
+	wsHub           *websocket.Hub
+}
+
+// NewAchievementSuggestionHandler creates a new achievement suggestion handler
+func NewAchievementSuggestionHandler(suggestionRepo *repository.AchievementSuggestionRepository, achievementRepo *repository.AchievementRepository, wsHub *websocket.Hub) *AchievementSuggestionHandler {
+	return &AchievementSuggestionHandler{
+		suggestionRepo:  suggestionRepo,
+		achievementRepo: achievementRepo,
+		wsHub:           wsHub,
+	}
+}
+
+// ApproveAchievementSuggestionRequest is the request body for approving a suggestion
+// All fields are optional, the suggested name, description and positivity are kept
+type ApproveAchievementSuggestionRequest struct {
+	ID       string   `json:"id"` // Defaults to a slug of the suggested name
+	ImageURL string   `json:"image_url"`
+	Category string   `json:"category"` // Defaults to skill or negative
+	Tags     []string `json:"tags"`
+	Weight   *int     `json:"weight"` // Defaults to 1
+	Note     string   `json:"note"`
+}
+
+// Create lets a player suggest a new achievement
+// POST /api/v1/achievement-suggestions
+func (h *AchievementSuggestionHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, ok := middleware.GetClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Not authenticated",
+		})
+		return
+	}
+
+	var req models.CreateAchievementSuggestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	description := strings.TrimSpace(req.Description)
+	if name == "" || len(name) > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "name must be between 1 and 100 characters",
+		})
+		return
+	}
+	if len(description) > 255 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "description must be at most 255 characters",
+		})
+		return
+	}
+
+	pending, err := h.suggestionRepo.CountPendingByUser(ctx, claims.UserID)
+	if err != nil {
+		log.Printf("Failed to count achievement suggestions of user %d: %v", claims.UserID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create suggestion",
+		})
+		return
+	}
+	if pending >= models.MaxPendingSuggestionsPerUser {
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error": fmt.Sprintf("You already have %d suggestions waiting for review", pending),
+		})
+		return
+	}
+
+	suggestion := &models.AchievementSuggestion{
+		UserID:      claims.UserID,
+		Username:    claims.Username,
+		Name:        name,
+		Description: description,
+		IsPositive:  req.IsPositive,
+	}
+	if err := h.suggestionRepo.Create(ctx, suggestion); err != nil {
+		log.Printf("Failed to create achievement suggestion: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create suggestion",
+		})
+		return
+	}
+
+	log.Printf("User %d suggested achievement '%s' (suggestion %d)", claims.UserID, name, suggestion.ID)
+
+	c.JSON(http.StatusCreated, suggestion)
+}
+
+// GetMine returns the suggestions of the current user
+// GET /api/v1/achievement-suggestions/mine
+func (h *AchievementSuggestionHandler) GetMine(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, ok := middleware.GetClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Not authenticated",
+		})
+		return
+	}
+
+	suggestions, err := h.suggestionRepo.GetByUser(ctx, claims.UserID)
+	if err != nil {
+		log.Printf("Failed to get achievement suggestions for user %d: %v", claims.UserID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load suggestions",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"suggestions": suggestions,
+	})
+}
+
+// GetAdminSuggestions returns the moderation queue, pending suggestions by default (admin only)
+// GET /api/v1/admin/achievement-suggestions?status=pending
+func (h *AchievementSuggestionHandler) GetAdminSuggestions(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	status := c.DefaultQuery("status", models.SuggestionStatusPending)
+	switch status {
+	case "all":
+		status = ""
+	case models.SuggestionStatusPending, models.SuggestionStatusApproved, models.SuggestionStatusRejected:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "status must be 'pending', 'approved', 'rejected' or 'all'",
+		})
+		return
+	}
+
+	suggestions, err := h.suggestionRepo.GetByStatus(ctx, status, 200)
+	if err != nil {
+		log.Printf("Failed to get achievement suggestions: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load suggestions",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"suggestions": suggestions,
+	})
+}
+
+// Approve creates an achievement from a pending suggestion and announces it (admin only)
+// POST /api/v1/admin/achievement-suggestions/:id/approve
+func (h *AchievementSuggestionHandler) Approve(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	suggestion, ok := h.loadPending(c)
+	if !ok {
+		return
+	}
+
+	// All fields are optional
+	var req ApproveAchievementSuggestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		req = ApproveAchievementSuggestionRequest{}
+	}
+
+	note := strings.TrimSpace(req.Note)
+	if len(note) > 500 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Note must be at most 500 characters",
+		})
+		return
+	}
+
+	id := strings.TrimSpace(req.ID)
+	if id == "" {
+		id = achievementSlug(suggestion.Name)
+	}
+	if !achievementIDPattern.MatchString(id) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "id must be 2-50 lowercase letters, digits or dashes",
+		})
+		return
+	}
+	if models.IsValidAchievement(id) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Achievement already exists, choose another id",
+			"id":    id,
+		})
+		return
+	}
+
+	// Reuse the admin achievement validation for the suggested fields and the overrides
+	achievementReq := AchievementRequest{
+		Name:        suggestion.Name,
+		Description: suggestion.Description,
+		ImageURL:    req.ImageURL,
+		IsPositive:  suggestion.IsPositive,
+		Category:    req.Category,
+		Tags:        req.Tags,
+		Weight:      req.Weight,
+	}
+	if msg := achievementReq.validate(); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": msg,
+		})
+		return
+	}
+	if achievementReq.Category == "" {
+		achievementReq.Category = defaultAchievementCategory(achievementReq.IsPositive)
+	}
+	if achievementReq.Tags == nil {
+		achievementReq.Tags = []string{}
+	}
+	weight := 1
+	if achievementReq.Weight != nil {
+		weight = *achievementReq.Weight
+	}
+
+	achievement := &models.Achievement{
+		ID:          id,
+		Name:        achievementReq.Name,
+		Description: achievementReq.Description,
+		ImageURL:    achievementReq.ImageURL,
+		IsPositive:  achievementReq.IsPositive,
+		Category:    achievementReq.Category,
+		Tags:        achievementReq.Tags,
+		Weight:      weight,
+	}
+
+	approved, err := h.suggestionRepo.Approve(ctx, suggestion.ID, achievement, claims.SteamID, note)
+	if err != nil {
+		log.Printf("Failed to approve achievement suggestion %d: %v", suggestion.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to approve suggestion",
+		})
+		return
+	}
+	if !approved {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Suggestion has already been reviewed",
+		})
+		return
+	}
+
+	if err := h.achievementRepo.Load(ctx); err != nil {
+		log.Printf("Failed to reload achievements: %v", err)
+	}
+
+	log.Printf("Admin %s approved achievement suggestion %d as '%s' (%s)", claims.SteamID, suggestion.ID, achievement.Name, achievement.ID)
+
+	h.wsHub.BroadcastAchievementsUpdate(&websocket.AchievementsUpdatePayload{
+		Achievements: models.GetAllAchievements(),
+	})
+	h.wsHub.BroadcastAchievementLive(&websocket.AchievementLivePayload{
+		Achievement:   achievement,
+		SuggestionID:  suggestion.ID,
+		SuggestedByID: suggestion.UserID,
+		SuggestedBy:   suggestion.Username,
+	})
+
+	h.respondReloaded(c, suggestion.ID, models.SuggestionStatusApproved)
+}
+
+// Reject closes a pending suggestion without creating an achievement (admin only)
+// POST /api/v1/admin/achievement-suggestions/:id/reject
+func (h *AchievementSuggestionHandler) Reject(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	suggestion, ok := h.loadPending(c)
+	if !ok {
+		return
+	}
+
+	// Note is optional
+	var req models.RejectAchievementSuggestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		req.Note = ""
+	}
+	note := strings.TrimSpace(req.Note)
+	if len(note) > 500 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Note must be at most 500 characters",
+		})
+		return
+	}
+
+	rejected, err := h.suggestionRepo.Reject(ctx, suggestion.ID, claims.SteamID, note)
+	if err != nil {
+		log.Printf("Failed to reject achievement suggestion %d: %v", suggestion.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to reject suggestion",
+		})
+		return
+	}
+	if !rejected {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Suggestion has already been reviewed",
+		})
+		return
+	}
+
+	log.Printf("Admin %s rejected achievement suggestion %d (note: %s)", claims.SteamID, suggestion.ID, note)
+
+	h.respondReloaded(c, suggestion.ID, models.SuggestionStatusRejected)
+}
+
+// loadPending loads the suggestion of the :id parameter and writes the error response if it is not pending
+func (h *AchievementSuggestionHandler) loadPending(c *gin.Context) (*models.AchievementSuggestion, bool) {
+	ctx := c.Request.Context()
+
+	suggestionID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid suggestion ID",
+		})
+		return nil, false
+	}
+
+	suggestion, err := h.suggestionRepo.GetByID(ctx, suggestionID)
+	if err != nil {
+		log.Printf("Failed to get achievement suggestion %d: %v", suggestionID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load suggestion",
+		})
+		return nil, false
+	}
+	if suggestion == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Suggestion not found",
+		})
+		return nil, false
+	}
+	if suggestion.Status != models.SuggestionStatusPending {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Suggestion has already been reviewed",
+		})
+		return nil, false
+	}
+	return suggestion, true
+}
+
+// respondReloaded responds with the reviewed suggestion, falling back to its ID and status if it cannot be reloaded
+func (h *AchievementSuggestionHandler) respondReloaded(c *gin.Context, suggestionID uint64, status string) {
+	ctx := c.Request.Context()
+
+	suggestion, err := h.suggestionRepo.GetByID(ctx, suggestionID)
+	if err != nil || suggestion == nil {
+		log.Printf("Failed to reload achievement suggestion %d: %v", suggestionID, err)
+		c.JSON(http.StatusOK, gin.H{
+			"id":     suggestionID,
+			"status": status,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, suggestion)
+}
+
+// achievementSlug derives an achievement ID like "clutch-king" from a name
+func achievementSlug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			dash = false
+		case b.Len() > 0 && !dash:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	slug := strings.TrimRight(b.String(), "-")
+	if len(slug) > 50 {
+		slug = strings.TrimRight(slug[:50], "-")
+	}
+	return slug
+}
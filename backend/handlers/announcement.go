@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/websocket"
+)
+
+// AnnouncementHandler handles admin announcements
+type AnnouncementHandler struct {
+	cfg              *config.Config
+	announcementRepo *repository.AnnouncementRepository
+	wsHub            *websocket.Hub
+}
+
+// NewAnnouncementHandler creates a new announcement handler
+func NewAnnouncementHandler(cfg *config.Config, announcementRepo *repository.AnnouncementRepository, wsHub *websocket.Hub) *AnnouncementHandler {
+	return &AnnouncementHandler{
+		cfg:              cfg,
+		announcementRepo: announcementRepo,
+		wsHub:            wsHub,
+	}
+}
+
+// GetActive returns the announcements that have not expired, for players who connect after the broadcast
+// GET /api/v1/announcements
+func (h *AnnouncementHandler) GetActive(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	announcements, err := h.announcementRepo.GetActive(ctx, time.Now())
+	if err != nil {
+		log.Printf("Failed to get announcements: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load announcements",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"announcements": announcements,
+	})
+}
+
+// Announce stores an announcement and broadcasts it to all clients (admin only)
+// POST /api/v1/admin/announce
+func (h *AnnouncementHandler) Announce(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, ok := middleware.GetClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req models.CreateAnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	title := strings.TrimSpace(req.Title)
+	body := strings.TrimSpace(req.Body)
+	if title == "" || body == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Title and body cannot be empty",
+		})
+		return
+	}
+
+	severity := req.Severity
+	switch severity {
+	case "":
+		severity = models.AnnouncementSeverityInfo
+	case models.AnnouncementSeverityInfo, models.AnnouncementSeverityWarning, models.AnnouncementSeverityCritical:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "severity must be info, warning or critical",
+		})
+		return
+	}
+
+	announcement := &models.Announcement{
+		Title:     title,
+		Body:      body,
+		Severity:  severity,
+		CreatedBy: claims.UserID,
+	}
+	if req.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, req.ExpiresAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "expires_at must be in RFC3339 format (e.g., 2024-12-31T19:45:00+01:00)",
+			})
+			return
+		}
+		if !expiresAt.After(time.Now()) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "expires_at must be in the future",
+			})
+			return
+		}
+		announcement.ExpiresAt = &expiresAt
+	}
+
+	if err := h.announcementRepo.Create(ctx, announcement); err != nil {
+		log.Printf("Failed to create announcement: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create announcement",
+		})
+		return
+	}
+
+	payload := &websocket.AnnouncementPayload{
+		ID:        announcement.ID,
+		Title:     announcement.Title,
+		Body:      announcement.Body,
+		Severity:  announcement.Severity,
+		CreatedAt: announcement.CreatedAt.In(h.cfg.EventLocation).Format(time.RFC3339),
+	}
+	if announcement.ExpiresAt != nil {
+		formatted := announcement.ExpiresAt.In(h.cfg.EventLocation).Format(time.RFC3339)
+		payload.ExpiresAt = &formatted
+	}
+	h.wsHub.BroadcastAnnouncement(payload)
+
+	log.Printf("Admin %s broadcast %s announcement %d", claims.Username, announcement.Severity, announcement.ID)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"announcement": announcement,
+	})
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/services"
+)
+
+// AnonymizationHandler handles the data retention endpoints for admins
+type AnonymizationHandler struct {
+	anonService *services.AnonymizationService
+}
+
+// NewAnonymizationHandler creates a new anonymization handler
+func NewAnonymizationHandler(anonService *services.AnonymizationService) *AnonymizationHandler {
+	return &AnonymizationHandler{
+		anonService: anonService,
+	}
+}
+
+// GetReport returns a dry-run report of the data the next anonymization would change
+// GET /api/v1/admin/anonymization
+func (h *AnonymizationHandler) GetReport(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	report, err := h.anonService.Report(ctx)
+	if err != nil {
+		log.Printf("Failed to create anonymization report: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create anonymization report",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// Run anonymizes all remaining users immediately, regardless of the retention schedule
+// POST /api/v1/admin/anonymization/run
+func (h *AnonymizationHandler) Run(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	log.Printf("Admin %s triggered anonymization of personal data", claims.SteamID)
+
+	report, err := h.anonService.Run(ctx)
+	if err != nil {
+		log.Printf("Failed to anonymize personal data: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to anonymize personal data",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,328 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/websocket"
+)
+
+// AppealHandler handles appeals of vote recipients against negative votes
+type AppealHandler struct {
+	appealRepo *repository.AppealRepository
+	voteRepo   *repository.VoteRepository
+	wsHub      *websocket.Hub
+	cfg        *config.Config
+}
+
+// NewAppealHandler creates a new appeal handler
+func NewAppealHandler(appealRepo *repository.AppealRepository, voteRepo *repository.VoteRepository, wsHub *websocket.Hub, cfg *config.Config) *AppealHandler {
+	return &AppealHandler{
+		appealRepo: appealRepo,
+		voteRepo:   voteRepo,
+		wsHub:      wsHub,
+		cfg:        cfg,
+	}
+}
+
+// Create lets the recipient of a negative vote flag it for admin review
+// POST /api/v1/votes/:id/appeal
+func (h *AppealHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, ok := middleware.GetClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Not authenticated",
+		})
+		return
+	}
+
+	voteID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid vote ID",
+		})
+		return
+	}
+
+	// Reason is optional
+	var req models.CreateAppealRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		req.Reason = ""
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if len(reason) > 500 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Reason must be at most 500 characters",
+		})
+		return
+	}
+
+	vote, err := h.voteRepo.GetByID(ctx, voteID)
+	if err != nil {
+		log.Printf("Failed to get vote: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get vote",
+		})
+		return
+	}
+	// Votes of other users are reported as missing, appeals must not reveal them
+	if vote == nil || vote.ToUser.ID != claims.UserID {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Vote not found",
+		})
+		return
+	}
+
+	if vote.Achievement.IsPositive {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Only negative votes can be appealed",
+		})
+		return
+	}
+	if vote.IsInvalidated {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Vote has already been invalidated",
+		})
+		return
+	}
+
+	existing, err := h.appealRepo.GetByVoteID(ctx, voteID)
+	if err != nil {
+		log.Printf("Failed to get vote appeal: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create appeal",
+		})
+		return
+	}
+	if existing != nil {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":  "Vote has already been appealed",
+			"appeal": existing,
+		})
+		return
+	}
+
+	appeal := &models.VoteAppeal{
+		VoteID: voteID,
+		UserID: claims.UserID,
+		Reason: reason,
+	}
+	if err := h.appealRepo.Create(ctx, appeal); err != nil {
+		log.Printf("Failed to create vote appeal: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create appeal",
+		})
+		return
+	}
+
+	log.Printf("User %d appealed vote %d (%s)", claims.UserID, voteID, vote.AchievementID)
+
+	h.wsHub.NotifyVoteAppeal(h.cfg.Admins(), h.appealPayload(appeal, vote))
+
+	vote.ApplyVisibilityMode(h.cfg.VoteVisibilityMode)
+	appeal.Vote = vote
+	c.JSON(http.StatusCreated, appeal)
+}
+
+// GetMine returns the appeals filed by the current user
+// GET /api/v1/votes/appeals
+func (h *AppealHandler) GetMine(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, ok := middleware.GetClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Not authenticated",
+		})
+		return
+	}
+
+	appeals, err := h.appealRepo.GetByUser(ctx, claims.UserID)
+	if err != nil {
+		log.Printf("Failed to get vote appeals for user %d: %v", claims.UserID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load appeals",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"appeals": h.withVotes(ctx, appeals, h.cfg.VoteVisibilityMode),
+	})
+}
+
+// GetAdminAppeals returns appeals for review, pending ones by default (admin only)
+// Senders of secret votes stay hidden, like in GET /api/v1/admin/votes
+// GET /api/v1/admin/appeals?status=pending
+func (h *AppealHandler) GetAdminAppeals(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	status := c.DefaultQuery("status", models.AppealStatusPending)
+	switch status {
+	case "all":
+		status = ""
+	case models.AppealStatusPending, models.AppealStatusUpheld, models.AppealStatusInvalidated:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "status must be 'pending', 'upheld', 'invalidated' or 'all'",
+		})
+		return
+	}
+
+	appeals, err := h.appealRepo.GetByStatus(ctx, status, 200)
+	if err != nil {
+		log.Printf("Failed to get vote appeals: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load appeals",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"appeals": h.withVotes(ctx, appeals, "user_choice"),
+	})
+}
+
+// Uphold closes an appeal and keeps the vote (admin only)
+// POST /api/v1/admin/appeals/:id/uphold
+func (h *AppealHandler) Uphold(c *gin.Context) {
+	h.resolve(c, models.AppealStatusUpheld)
+}
+
+// Invalidate closes an appeal and invalidates the vote (admin only)
+// The sender gets no credit back, the vote just stops counting towards the target's score
+// POST /api/v1/admin/appeals/:id/invalidate
+func (h *AppealHandler) Invalidate(c *gin.Context) {
+	h.resolve(c, models.AppealStatusInvalidated)
+}
+
+// resolve closes a pending appeal with the given status and notifies the appellant
+func (h *AppealHandler) resolve(c *gin.Context, status string) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	appealID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid appeal ID",
+		})
+		return
+	}
+
+	// Note is optional
+	var req models.ResolveAppealRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		req.Note = ""
+	}
+	note := strings.TrimSpace(req.Note)
+	if len(note) > 500 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Note must be at most 500 characters",
+		})
+		return
+	}
+
+	appeal, err := h.appealRepo.GetByID(ctx, appealID)
+	if err != nil {
+		log.Printf("Failed to get vote appeal %d: %v", appealID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to resolve appeal",
+		})
+		return
+	}
+	if appeal == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Appeal not found",
+		})
+		return
+	}
+
+	resolved, err := h.appealRepo.Resolve(ctx, appealID, status, claims.SteamID, note)
+	if err != nil {
+		log.Printf("Failed to resolve vote appeal %d: %v", appealID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to resolve appeal",
+		})
+		return
+	}
+	if !resolved {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Appeal has already been resolved",
+		})
+		return
+	}
+
+	log.Printf("Admin %s resolved appeal %d of vote %d as %s (note: %s)", claims.SteamID, appealID, appeal.VoteID, status, note)
+
+	if status == models.AppealStatusInvalidated {
+		h.wsHub.BroadcastVoteInvalidation(appeal.VoteID, true, note)
+	}
+
+	appeal, err = h.appealRepo.GetByID(ctx, appealID)
+	if err != nil || appeal == nil {
+		log.Printf("Failed to reload vote appeal %d: %v", appealID, err)
+		c.JSON(http.StatusOK, gin.H{
+			"id":     appealID,
+			"status": status,
+		})
+		return
+	}
+
+	vote, err := h.voteRepo.GetByID(ctx, appeal.VoteID)
+	if err != nil {
+		log.Printf("Failed to get vote %d: %v", appeal.VoteID, err)
+	}
+	if vote != nil {
+		h.wsHub.NotifyVoteAppealResolved(appeal.UserID, h.appealPayload(appeal, vote))
+		vote.ApplyVisibilityMode("user_choice")
+		appeal.Vote = vote
+	}
+
+	c.JSON(http.StatusOK, appeal)
+}
+
+// withVotes attaches the appealed votes, anonymized with the given visibility mode
+func (h *AppealHandler) withVotes(ctx context.Context, appeals []models.VoteAppeal, visibilityMode string) []models.VoteAppeal {
+	if appeals == nil {
+		return []models.VoteAppeal{}
+	}
+
+	for i := range appeals {
+		vote, err := h.voteRepo.GetByID(ctx, appeals[i].VoteID)
+		if err != nil {
+			log.Printf("Failed to get vote %d for appeal %d: %v", appeals[i].VoteID, appeals[i].ID, err)
+			continue
+		}
+		if vote != nil {
+			vote.ApplyVisibilityMode(visibilityMode)
+			appeals[i].Vote = vote
+		}
+	}
+	return appeals
+}
+
+// appealPayload builds the WebSocket payload of an appeal
+func (h *AppealHandler) appealPayload(appeal *models.VoteAppeal, vote *models.VoteWithDetails) *websocket.VoteAppealPayload {
+	return &websocket.VoteAppealPayload{
+		AppealID:        appeal.ID,
+		VoteID:          appeal.VoteID,
+		UserID:          appeal.UserID,
+		Username:        vote.ToUser.Username,
+		AchievementID:   vote.AchievementID,
+		AchievementName: vote.Achievement.Name,
+		Reason:          appeal.Reason,
+		Status:          appeal.Status,
+		ResolutionNote:  appeal.ResolutionNote,
+	}
+}
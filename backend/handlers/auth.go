@@ -9,6 +9,7 @@ import (
 	"github.com/guided-traffic/rate-your-mate/backend/auth"
 	"github.com/guided-traffic/rate-your-mate/backend/config"
 	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
 	"github.com/guided-traffic/rate-your-mate/backend/repository"
 	"github.com/guided-traffic/rate-your-mate/backend/services"
 	"github.com/guided-traffic/rate-your-mate/backend/websocket"
@@ -24,11 +25,14 @@ type AuthHandler struct {
 	creditService      *services.CreditService
 	gameService        *services.GameService
 	avatarCacheService *services.AvatarCacheService
+	reviewRepo         *repository.AccountReviewRepository
+	reviewService      *services.AccountReviewService
+	chatRepo           *repository.ChatRepository
 	wsHub              *websocket.Hub
 }
 
 // NewAuthHandler creates a new auth handler
-func NewAuthHandler(cfg *config.Config, userRepo *repository.UserRepository, creditService *services.CreditService, gameService *services.GameService, avatarCacheService *services.AvatarCacheService, wsHub *websocket.Hub) *AuthHandler {
+func NewAuthHandler(cfg *config.Config, userRepo *repository.UserRepository, creditService *services.CreditService, gameService *services.GameService, avatarCacheService *services.AvatarCacheService, reviewRepo *repository.AccountReviewRepository, reviewService *services.AccountReviewService, chatRepo *repository.ChatRepository, wsHub *websocket.Hub) *AuthHandler {
 	return &AuthHandler{
 		cfg:                cfg,
 		steamAuth:          auth.NewSteamAuth(cfg.BackendURL),
@@ -38,6 +42,9 @@ func NewAuthHandler(cfg *config.Config, userRepo *repository.UserRepository, cre
 		creditService:      creditService,
 		gameService:        gameService,
 		avatarCacheService: avatarCacheService,
+		reviewRepo:         reviewRepo,
+		reviewService:      reviewService,
+		chatRepo:           chatRepo,
 		wsHub:              wsHub,
 	}
 }
@@ -66,6 +73,8 @@ func (h *AuthHandler) SteamLogin(c *gin.Context) {
 // SteamCallback handles the Steam OpenID callback
 // GET /api/v1/auth/steam/callback
 func (h *AuthHandler) SteamCallback(c *gin.Context) {
+	ctx := c.Request.Context()
+
 	// Build the full callback URL from the request
 	fullURL := auth.BuildFullCallbackURL(c.Request)
 
@@ -79,8 +88,14 @@ func (h *AuthHandler) SteamCallback(c *gin.Context) {
 
 	log.Printf("Steam login successful for Steam ID: %s", steamID)
 
+	// Log the IP before the ban check, logins of banned players are needed to detect ban evasion
+	ipAddress := c.ClientIP()
+	if err := h.reviewRepo.LogIP(ctx, steamID, ipAddress); err != nil {
+		log.Printf("Failed to log login IP for %s: %v", steamID, err)
+	}
+
 	// Check if user is banned
-	banned, err := h.userRepo.IsBanned(steamID)
+	banned, err := h.userRepo.IsBanned(ctx, steamID)
 	if err != nil {
 		log.Printf("Failed to check ban status for %s: %v", steamID, err)
 		h.redirectWithError(c, "Failed to verify account status")
@@ -92,11 +107,25 @@ func (h *AuthHandler) SteamCallback(c *gin.Context) {
 		return
 	}
 
+	// Accounts flagged by the ban evasion heuristics wait for an admin decision
+	review, err := h.reviewRepo.GetBySteamID(ctx, steamID)
+	if err != nil {
+		log.Printf("Failed to check account review for %s: %v", steamID, err)
+		h.redirectWithError(c, "Failed to verify account status")
+		return
+	}
+	if review != nil && review.Status != models.AccountReviewApproved {
+		log.Printf("Account under review attempted to login: %s (%s)", steamID, review.Status)
+		h.redirectWithError(c, accountReviewError(review.Status))
+		return
+	}
+
 	// Fetch player profile from Steam API
-	var username, avatarURL, avatarSmall, profileURL string
+	var username, avatarURL, avatarSmall, profileURL, countryCode string
 	var originalAvatarURL string // Keep original URL for caching
+	var player *auth.SteamPlayer // nil if the profile could not be fetched
 	if h.steamAPI.IsConfigured() {
-		player, err := h.steamAPI.GetPlayerSummary(steamID)
+		player, err = h.steamAPI.GetPlayerSummary(steamID)
 		if err != nil {
 			log.Printf("Failed to fetch Steam profile for %s: %v", steamID, err)
 			// Continue with default values - we still have the Steam ID
@@ -105,6 +134,7 @@ func (h *AuthHandler) SteamCallback(c *gin.Context) {
 			username = player.PersonaName
 			originalAvatarURL = player.AvatarFull
 			profileURL = player.ProfileURL
+			countryCode = player.LocCountryCode
 
 			// Replace Steam default avatar with a generated one
 			if auth.IsDefaultAvatar(originalAvatarURL) {
@@ -136,7 +166,7 @@ func (h *AuthHandler) SteamCallback(c *gin.Context) {
 	}
 
 	// Create or update user in database
-	user, isNew, err := h.userRepo.FindOrCreate(steamID, username, avatarURL, avatarSmall, profileURL)
+	user, isNew, err := h.userRepo.FindOrCreate(ctx, steamID, username, avatarURL, avatarSmall, profileURL, countryCode)
 	if err != nil {
 		log.Printf("Failed to create/update user: %v", err)
 		h.redirectWithError(c, "Failed to create user account")
@@ -145,6 +175,24 @@ func (h *AuthHandler) SteamCallback(c *gin.Context) {
 
 	if isNew {
 		log.Printf("Created new user: %s (ID: %d)", username, user.ID)
+
+		review, err := h.reviewService.Check(ctx, user, ipAddress, player)
+		if err != nil {
+			// Don't lock out new players because of a failed check
+			log.Printf("Failed to check new account %s for review: %v", steamID, err)
+		}
+		if review != nil {
+			h.wsHub.NotifyAccountReview(h.cfg.Admins(), &websocket.AccountReviewPayload{
+				ReviewID: review.ID,
+				UserID:   user.ID,
+				SteamID:  steamID,
+				Username: username,
+				Reasons:  review.Reasons,
+			})
+			h.redirectWithError(c, accountReviewError(review.Status))
+			return
+		}
+
 		// Trigger incremental sync for new user's game library
 		h.triggerBackgroundSync(steamID)
 	} else {
@@ -177,6 +225,8 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 // Me returns the current authenticated user's information
 // GET /api/v1/auth/me
 func (h *AuthHandler) Me(c *gin.Context) {
+	ctx := c.Request.Context()
+
 	claims, ok := middleware.GetClaims(c)
 	if !ok {
 		c.JSON(http.StatusUnauthorized, gin.H{
@@ -186,7 +236,7 @@ func (h *AuthHandler) Me(c *gin.Context) {
 	}
 
 	// Load user from database
-	user, err := h.userRepo.GetByID(claims.UserID)
+	user, err := h.userRepo.GetByID(ctx, claims.UserID)
 	if err != nil {
 		log.Printf("Failed to load user %d: %v", claims.UserID, err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -203,7 +253,7 @@ func (h *AuthHandler) Me(c *gin.Context) {
 	}
 
 	// Calculate and update credits
-	credits, err := h.creditService.CalculateAndUpdateCredits(user)
+	credits, err := h.creditService.CalculateAndUpdateCredits(ctx, user)
 	if err != nil {
 		log.Printf("Failed to update credits for user %d: %v", user.ID, err)
 		// Continue with existing credits
@@ -213,6 +263,16 @@ func (h *AuthHandler) Me(c *gin.Context) {
 	// Calculate time until next credit
 	timeUntilNext := h.creditService.GetTimeUntilNextCredit(user)
 
+	// Unread chat messages and mentions for the chat tab badge
+	_, chatUnread, err := h.chatRepo.GetReadState(ctx, user.ID)
+	if err != nil {
+		log.Printf("Failed to get chat read state for user %d: %v", user.ID, err)
+	}
+	mentionsUnread, err := h.chatRepo.CountUnreadMentions(ctx, user.ID)
+	if err != nil {
+		log.Printf("Failed to count unread chat mentions for user %d: %v", user.ID, err)
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"user": gin.H{
 			"id":                     user.ID,
@@ -221,11 +281,18 @@ func (h *AuthHandler) Me(c *gin.Context) {
 			"avatar_url":             user.AvatarURL,
 			"avatar_small":           user.AvatarSmall,
 			"profile_url":            user.ProfileURL,
+			"country_code":           user.CountryCode,
+			"flag":                   models.CountryFlag(user.CountryCode),
+			"timezone":               user.Timezone,
 			"credits":                credits,
 			"seconds_until_credit":   int(timeUntilNext.Seconds()),
 			"credit_interval_seconds": h.cfg.CreditIntervalMinutes * 60,
 			"credit_max":             h.cfg.CreditMax,
 			"is_admin":               h.cfg.IsAdmin(user.SteamID),
+			"hide_from_ranking":      user.HideFromRanking,
+			"reduced_motion":         user.ReducedMotion,
+			"chat_unread_count":      chatUnread,
+			"mention_unread_count":   mentionsUnread,
 		},
 	})
 }
@@ -258,6 +325,14 @@ func (h *AuthHandler) redirectWithError(c *gin.Context, errorMsg string) {
 	c.Redirect(http.StatusTemporaryRedirect, redirectURL.String())
 }
 
+// accountReviewError returns the login error shown to accounts that are under review
+func accountReviewError(status string) string {
+	if status == models.AccountReviewRejected {
+		return "Dein Account wurde von einem Admin abgelehnt"
+	}
+	return "Dein Account muss erst von einem Admin freigegeben werden"
+}
+
 // triggerBackgroundSync registers a new user's games and triggers sync if needed
 func (h *AuthHandler) triggerBackgroundSync(steamID string) {
 	if h.gameService == nil || h.wsHub == nil {
@@ -268,22 +343,5 @@ func (h *AuthHandler) triggerBackgroundSync(steamID string) {
 	log.Printf("AuthHandler: Registering games for new user %s", steamID)
 
 	// Register user's games and trigger sync with WebSocket progress updates
-	h.gameService.RegisterUserGames(steamID, func(phase string, currentGame string, processed, total int) {
-		percentage := 0
-		if total > 0 {
-			percentage = (processed * 100) / total
-		}
-
-		if phase == "complete" {
-			h.wsHub.BroadcastGamesSyncComplete(processed)
-		} else {
-			h.wsHub.BroadcastGamesSyncProgress(&websocket.GamesSyncProgressPayload{
-				Phase:          phase,
-				CurrentGame:    currentGame,
-				ProcessedCount: processed,
-				TotalCount:     total,
-				Percentage:     percentage,
-			})
-		}
-	})
+	h.gameService.RegisterUserGames(steamID, SyncProgressBroadcaster(h.wsHub))
 }
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/services"
+)
+
+// BackupHandler handles the SQLite backup endpoints for admins
+// Backups are full copies of the database, downloads and restores are written to the admin audit trail
+type BackupHandler struct {
+	backupService *services.BackupService
+	auditRepo     *repository.AuditRepository
+}
+
+// NewBackupHandler creates a new backup handler
+func NewBackupHandler(backupService *services.BackupService, auditRepo *repository.AuditRepository) *BackupHandler {
+	return &BackupHandler{
+		backupService: backupService,
+		auditRepo:     auditRepo,
+	}
+}
+
+// GetBackups returns all backups in the backup directory, newest first
+// GET /api/v1/admin/backups
+func (h *BackupHandler) GetBackups(c *gin.Context) {
+	backups, err := h.backupService.List()
+	if err != nil {
+		log.Printf("Failed to list backups: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list backups",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"supported": !database.IsMySQL(),
+		"backups":   backups,
+	})
+}
+
+// CreateBackup writes a backup immediately, regardless of the schedule
+// POST /api/v1/admin/backups
+func (h *BackupHandler) CreateBackup(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	backup, err := h.backupService.Create(ctx)
+	if errors.Is(err, database.ErrBackupUnsupported) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Backups are only available for SQLite",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to create backup: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create backup",
+		})
+		return
+	}
+
+	log.Printf("Admin %s created backup %s", claims.SteamID, backup.Name)
+
+	c.JSON(http.StatusCreated, backup)
+}
+
+// DownloadBackup sends a backup as a file download
+// The backup contains all personal data it was taken with, even if it was anonymized since
+// GET /api/v1/admin/backups/:name
+func (h *BackupHandler) DownloadBackup(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	name := c.Param("name")
+	path, err := h.backupService.Path(name)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Backup not found",
+		})
+		return
+	}
+
+	// Write the audit entry first - no audit trail, no download
+	details := fmt.Sprintf("Downloaded backup %s from %s", name, c.ClientIP())
+	if err := h.auditRepo.Log(ctx, claims.SteamID, models.AuditActionDownloadBackup, details); err != nil {
+		log.Printf("Failed to write audit log for admin %s: %v", claims.SteamID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to write audit log",
+		})
+		return
+	}
+
+	log.Printf("Admin %s downloaded backup %s", claims.SteamID, name)
+
+	c.FileAttachment(path, name)
+}
+
+// RestoreBackup stages a backup that replaces the database when the backend is restarted
+// The audit entry is written to the current database, which is kept as <db>.before-restore-<time>
+// POST /api/v1/admin/backups/:name/restore
+func (h *BackupHandler) RestoreBackup(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	name := c.Param("name")
+	if database.IsMySQL() {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Backups are only available for SQLite",
+		})
+		return
+	}
+	if _, err := h.backupService.Path(name); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Backup not found",
+		})
+		return
+	}
+
+	// Write the audit entry first - no audit trail, no restore
+	details := fmt.Sprintf("Restored backup %s from %s", name, c.ClientIP())
+	if err := h.auditRepo.Log(ctx, claims.SteamID, models.AuditActionRestoreBackup, details); err != nil {
+		log.Printf("Failed to write audit log for admin %s: %v", claims.SteamID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to write audit log",
+		})
+		return
+	}
+
+	err := h.backupService.Restore(name)
+	switch {
+	case errors.Is(err, services.ErrBackupNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Backup not found",
+		})
+		return
+	case errors.Is(err, database.ErrBackupUnsupported):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Backups are only available for SQLite",
+		})
+		return
+	case err != nil:
+		log.Printf("Failed to restore backup %s: %v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to restore backup",
+		})
+		return
+	}
+
+	log.Printf("Admin %s restored backup %s, it is applied on the next start", claims.SteamID, name)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":          "Backup wird beim nächsten Neustart wiederhergestellt",
+		"restart_required": true,
+	})
+}
@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/websocket"
+)
+
+// BulkAdminHandler handles batch variants of admin operations for cleanups
+// Every operation can be previewed first ("preview": true) and writes a single
+// audit log entry with all affected IDs when it is executed
+type BulkAdminHandler struct {
+	cfg       *config.Config
+	userRepo  *repository.UserRepository
+	voteRepo  *repository.VoteRepository
+	auditRepo *repository.AuditRepository
+	wsHub     *websocket.Hub
+}
+
+// NewBulkAdminHandler creates a new bulk admin handler
+func NewBulkAdminHandler(cfg *config.Config, userRepo *repository.UserRepository, voteRepo *repository.VoteRepository, auditRepo *repository.AuditRepository, wsHub *websocket.Hub) *BulkAdminHandler {
+	return &BulkAdminHandler{
+		cfg:       cfg,
+		userRepo:  userRepo,
+		voteRepo:  voteRepo,
+		auditRepo: auditRepo,
+		wsHub:     wsHub,
+	}
+}
+
+// BulkUser is a user affected by a bulk operation
+type BulkUser struct {
+	ID         uint64 `json:"id"`
+	SteamID    string `json:"steam_id"`
+	Username   string `json:"username"`
+	Credits    int    `json:"credits"`
+	NewCredits *int   `json:"new_credits,omitempty"` // Only for bulk credits
+}
+
+// BanUsers bans several users at once (admin only)
+// POST /api/v1/admin/bulk/ban
+func (h *BulkAdminHandler) BanUsers(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	var req models.BulkBanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	reason := strings.TrimSpace(req.Reason)
+
+	users, notFound, ok := h.loadUsers(c, req.UserIDs)
+	if !ok {
+		return
+	}
+	for _, user := range users {
+		if user.SteamID == claims.SteamID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Du kannst dich nicht selbst bannen"})
+			return
+		}
+	}
+
+	if req.Preview {
+		c.JSON(http.StatusOK, gin.H{
+			"preview":   true,
+			"count":     len(users),
+			"users":     users,
+			"not_found": notFound,
+		})
+		return
+	}
+
+	// All users are banned in one transaction, a failure leaves everyone unbanned
+	if err := h.userRepo.BanMany(ctx, bulkIDs(users), reason, claims.SteamID); err != nil {
+		log.Printf("Error banning users in bulk: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to ban users"})
+		return
+	}
+
+	h.audit(c, models.AuditActionBulkBan, fmt.Sprintf("Banned users %s - Reason: %s", bulkUserIDs(users), reason))
+	log.Printf("Admin %s banned %d users in bulk - Reason: %s", claims.SteamID, len(users), reason)
+
+	for _, user := range users {
+		h.wsHub.BroadcastUserBanned(user.ID, user.Username)
+		h.wsHub.DisconnectUser(user.ID, websocket.CloseReasonBanned, "Du wurdest von einem Admin gebannt.")
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"preview":   false,
+		"count":     len(users),
+		"users":     users,
+		"not_found": notFound,
+	})
+}
+
+// InvalidateVotes invalidates all valid votes matching a filter (admin only)
+// POST /api/v1/admin/bulk/invalidate-votes
+func (h *BulkAdminHandler) InvalidateVotes(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	var req models.BulkInvalidateVotesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	if req.Filter.IsEmpty() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one filter criterion is required"})
+		return
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if len(reason) > 500 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Reason must be at most 500 characters"})
+		return
+	}
+
+	votes, err := h.voteRepo.GetValidByFilter(ctx, req.Filter, models.MaxBulkItems+1)
+	if err != nil {
+		log.Printf("Failed to get votes for bulk invalidation: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load votes"})
+		return
+	}
+	if len(votes) > models.MaxBulkItems {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("The filter matches more than %d votes, please narrow it down", models.MaxBulkItems),
+		})
+		return
+	}
+
+	if req.Preview {
+		c.JSON(http.StatusOK, gin.H{
+			"preview": true,
+			"count":   len(votes),
+			"votes":   votes,
+		})
+		return
+	}
+
+	voteIDs := make([]uint64, len(votes))
+	for i, v := range votes {
+		voteIDs[i] = v.ID
+	}
+	invalidated, err := h.voteRepo.InvalidateMany(ctx, voteIDs, claims.SteamID, reason)
+	if err != nil {
+		log.Printf("Failed to invalidate votes in bulk: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to invalidate votes"})
+		return
+	}
+
+	h.audit(c, models.AuditActionBulkInvalidateVotes, fmt.Sprintf("Invalidated votes %s - Reason: %s", joinIDs(invalidated), reason))
+	log.Printf("Admin %s invalidated %d votes in bulk (reason: %s)", claims.SteamID, len(invalidated), reason)
+
+	for _, voteID := range invalidated {
+		h.wsHub.BroadcastVoteInvalidation(voteID, true, reason)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"preview":  false,
+		"count":    len(invalidated),
+		"vote_ids": invalidated,
+	})
+}
+
+// GiveCredits gives credits to selected users, capped at CREDIT_MAX (admin only)
+// POST /api/v1/admin/bulk/credits
+func (h *BulkAdminHandler) GiveCredits(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	var req models.BulkGiveCreditsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	if req.Amount < 1 || req.Amount > h.cfg.CreditMax {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("amount must be between 1 and %d", h.cfg.CreditMax),
+		})
+		return
+	}
+
+	users, notFound, ok := h.loadUsers(c, req.UserIDs)
+	if !ok {
+		return
+	}
+	for i := range users {
+		newCredits := min(users[i].Credits+req.Amount, h.cfg.CreditMax)
+		users[i].NewCredits = &newCredits
+	}
+
+	if req.Preview {
+		c.JSON(http.StatusOK, gin.H{
+			"preview":   true,
+			"count":     len(users),
+			"users":     users,
+			"not_found": notFound,
+		})
+		return
+	}
+
+	// All users get the credits in one transaction, a failure leaves everyone unchanged
+	if err := h.userRepo.AddCreditsMany(ctx, bulkIDs(users), req.Amount, h.cfg.CreditMax); err != nil {
+		log.Printf("Error giving credits in bulk: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to give credits"})
+		return
+	}
+
+	h.audit(c, models.AuditActionBulkGiveCredits, fmt.Sprintf("Gave %d credits to users %s", req.Amount, bulkUserIDs(users)))
+	log.Printf("Admin %s gave %d credits to %d users in bulk", claims.SteamID, req.Amount, len(users))
+
+	for _, user := range users {
+		h.wsHub.NotifyCreditsGiven(user.ID, req.Amount)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"preview":   false,
+		"count":     len(users),
+		"users":     users,
+		"not_found": notFound,
+	})
+}
+
+// loadUsers validates the user IDs of a bulk request and loads the users
+// Writes the error response and returns false if the request is invalid
+func (h *BulkAdminHandler) loadUsers(c *gin.Context, userIDs []uint64) ([]BulkUser, []uint64, bool) {
+	ctx := c.Request.Context()
+
+	if len(userIDs) == 0 || len(userIDs) > models.MaxBulkItems {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("user_ids must contain between 1 and %d IDs", models.MaxBulkItems),
+		})
+		return nil, nil, false
+	}
+
+	users := []BulkUser{}
+	notFound := []uint64{}
+	seen := make(map[uint64]bool, len(userIDs))
+	for _, id := range userIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		user, err := h.userRepo.GetByID(ctx, id)
+		if err != nil {
+			log.Printf("Error getting user %d for bulk operation: %v", id, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get users"})
+			return nil, nil, false
+		}
+		if user == nil {
+			notFound = append(notFound, id)
+			continue
+		}
+		users = append(users, BulkUser{
+			ID:       user.ID,
+			SteamID:  user.SteamID,
+			Username: user.Username,
+			Credits:  user.Credits,
+		})
+	}
+
+	return users, notFound, true
+}
+
+// audit writes the single audit log entry of an executed bulk operation
+// The operation already happened, so a failure is only logged
+func (h *BulkAdminHandler) audit(c *gin.Context, action, details string) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+	if err := h.auditRepo.Log(ctx, claims.SteamID, action, details); err != nil {
+		log.Printf("Failed to write audit log for admin %s: %v", claims.SteamID, err)
+	}
+}
+
+// bulkIDs returns the IDs of the affected users
+func bulkIDs(users []BulkUser) []uint64 {
+	ids := make([]uint64, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	return ids
+}
+
+// bulkUserIDs formats the IDs of the affected users for the audit log
+func bulkUserIDs(users []BulkUser) string {
+	ids := bulkIDs(users)
+	return joinIDs(ids)
+}
+
+// joinIDs formats IDs as comma-separated list in brackets, e.g. [1,2,3]
+func joinIDs(ids []uint64) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = fmt.Sprint(id)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
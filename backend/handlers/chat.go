@@ -1,50 +1,95 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"log"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/config"
 	"github.com/guided-traffic/rate-your-mate/backend/middleware"
 	"github.com/guided-traffic/rate-your-mate/backend/models"
 	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/services"
 	"github.com/guided-traffic/rate-your-mate/backend/websocket"
 )
 
 // ChatHandler handles chat-related requests
 type ChatHandler struct {
-	chatRepo *repository.ChatRepository
-	userRepo *repository.UserRepository
-	wsHub    *websocket.Hub
+	cfg           *config.Config
+	chatRepo      *repository.ChatRepository
+	userRepo      *repository.UserRepository
+	filterService *services.ChatFilterService
+	muteRepo      *repository.MuteRepository
+	wsHub         *websocket.Hub
+	chatLimiter   *middleware.RateLimiter // Also applied to messages sent over the WebSocket
+
+	// Last chat message per user for the slow mode
+	lastMessageAt map[uint64]time.Time
+	mutex         sync.Mutex
 }
 
 // NewChatHandler creates a new chat handler
-func NewChatHandler(chatRepo *repository.ChatRepository, userRepo *repository.UserRepository, wsHub *websocket.Hub) *ChatHandler {
+func NewChatHandler(cfg *config.Config, chatRepo *repository.ChatRepository, userRepo *repository.UserRepository, filterService *services.ChatFilterService, muteRepo *repository.MuteRepository, wsHub *websocket.Hub, chatLimiter *middleware.RateLimiter) *ChatHandler {
 	return &ChatHandler{
-		chatRepo: chatRepo,
-		userRepo: userRepo,
-		wsHub:    wsHub,
+		cfg:           cfg,
+		chatRepo:      chatRepo,
+		userRepo:      userRepo,
+		filterService: filterService,
+		muteRepo:      muteRepo,
+		wsHub:         wsHub,
+		chatLimiter:   chatLimiter,
+		lastMessageAt: make(map[uint64]time.Time),
+	}
+}
+
+// allowMessage enforces the slow mode, admins are exempt
+// Returns the remaining cooldown if the user has to wait
+func (h *ChatHandler) allowMessage(userID uint64, steamID string) (time.Duration, bool) {
+	cooldown := time.Duration(h.cfg.ChatSlowModeSeconds) * time.Second
+	if cooldown <= 0 || h.cfg.IsAdmin(steamID) {
+		return 0, true
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if last, ok := h.lastMessageAt[userID]; ok {
+		if wait := cooldown - time.Since(last); wait > 0 {
+			return wait, false
+		}
 	}
+	h.lastMessageAt[userID] = time.Now()
+	return 0, true
 }
 
-// GetMessages returns recent chat messages
-// GET /api/v1/chat
+// GetMessages returns recent chat messages, older ones are loaded with the next_cursor of the previous page
+// GET /api/v1/chat?limit=50&cursor=xxx
 func (h *ChatHandler) GetMessages(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "50")
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 || limit > 100 {
-		limit = 50
+	ctx := c.Request.Context()
+
+	page, ok := parsePage(c, 50)
+	if !ok {
+		return
 	}
 
-	messages, err := h.chatRepo.GetRecent(limit)
+	messages, err := h.chatRepo.GetRecent(ctx, page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to get chat messages",
 		})
 		return
 	}
+	nextCursor := repository.NextCursor(page, messages, func(m models.ChatMessageWithUser) uint64 { return m.ID })
 
 	// Reverse order so oldest is first (for display)
 	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
@@ -52,13 +97,23 @@ func (h *ChatHandler) GetMessages(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"messages": messages,
+		"messages":    messages,
+		"next_cursor": nextCursor,
 	})
 }
 
+// chatError describes why a chat message could not be posted
+type chatError struct {
+	status     int
+	body       gin.H
+	retryAfter int // Seconds, sent as Retry-After header if set
+}
+
 // Create creates a new chat message
 // POST /api/v1/chat
 func (h *ChatHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+
 	// Get user from context (set by auth middleware)
 	claims, ok := middleware.GetClaims(c)
 	if !ok {
@@ -68,10 +123,6 @@ func (h *ChatHandler) Create(c *gin.Context) {
 		return
 	}
 
-	userID := claims.UserID
-	username := claims.Username
-	steamID := claims.SteamID
-
 	// Parse request
 	var req models.CreateChatMessageRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -81,20 +132,112 @@ func (h *ChatHandler) Create(c *gin.Context) {
 		return
 	}
 
+	fullMsg, cerr := h.postMessage(ctx, claims.UserID, claims.Username, claims.SteamID, req)
+	if cerr != nil {
+		if cerr.retryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(cerr.retryAfter))
+		}
+		c.JSON(cerr.status, cerr.body)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": fullMsg,
+	})
+}
+
+// SendFromWebSocket posts a chat message received as chat_send over the WebSocket connection
+// It applies the same checks as POST /chat including the chat rate limit
+func (h *ChatHandler) SendFromWebSocket(userID uint64, steamID, username string, payload *websocket.ChatSendPayload) *websocket.ChatSendResultPayload {
+	result := &websocket.ChatSendResultPayload{Ref: payload.Ref}
+
+	if q, allowed := h.chatLimiter.Allow(userID); !allowed {
+		result.Status = http.StatusTooManyRequests
+		result.Error = gin.H{
+			"error":       "Rate limit exceeded, slow down",
+			"retry_after": int(time.Until(q.ResetAt).Seconds()) + 1,
+		}
+		return result
+	}
+	if utf8.RuneCountInString(payload.Message) > 500 {
+		result.Status = http.StatusBadRequest
+		result.Error = gin.H{"error": "Message must be at most 500 characters"}
+		return result
+	}
+
+	fullMsg, cerr := h.postMessage(context.Background(), userID, username, steamID, models.CreateChatMessageRequest{
+		Message:   payload.Message,
+		ReplyToID: payload.ReplyToID,
+	})
+	if cerr != nil {
+		result.Status = cerr.status
+		result.Error = cerr.body
+		return result
+	}
+
+	result.OK = true
+	result.Status = http.StatusCreated
+	result.MessageID = fullMsg.ID
+	return result
+}
+
+// postMessage validates and stores a chat message, then broadcasts it and notifies mentioned users
+func (h *ChatHandler) postMessage(ctx context.Context, userID uint64, username, steamID string, req models.CreateChatMessageRequest) (*models.ChatMessageWithUser, *chatError) {
+	// Muted users can read but not write
+	mute, err := h.muteRepo.GetActive(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to check mute of user %d: %v", userID, err)
+		return nil, &chatError{status: http.StatusInternalServerError, body: gin.H{"error": "Failed to create chat message"}}
+	}
+	if mute != nil {
+		return nil, &chatError{status: http.StatusForbidden, body: mutedError(mute)}
+	}
+
 	// Sanitize message
 	message := strings.TrimSpace(req.Message)
 	if len(message) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Message cannot be empty",
-		})
-		return
+		return nil, &chatError{status: http.StatusBadRequest, body: gin.H{"error": "Message cannot be empty"}}
 	}
 	if len(message) > 500 {
 		message = message[:500]
 	}
 
+	// Word filter, admins may be exempt
+	if !h.cfg.ChatFilterAdminBypass || !h.cfg.IsAdmin(steamID) {
+		filtered, blocked := h.filterService.Filter(message)
+		if blocked && h.cfg.ChatFilterMode == models.ChatFilterReject {
+			return nil, &chatError{status: http.StatusBadRequest, body: gin.H{"error": "Message contains blocked words"}}
+		}
+		message = filtered
+	}
+
+	// Replies must reference an existing message
+	if req.ReplyToID != nil {
+		exists, err := h.chatRepo.Exists(ctx, *req.ReplyToID)
+		if err != nil {
+			return nil, &chatError{status: http.StatusInternalServerError, body: gin.H{"error": "Failed to check reply target"}}
+		}
+		if !exists {
+			return nil, &chatError{status: http.StatusBadRequest, body: gin.H{"error": "Message to reply to not found"}}
+		}
+	}
+
+	// Slow mode, checked last so rejected messages do not start a cooldown
+	if wait, ok := h.allowMessage(userID, steamID); !ok {
+		retryAfter := int(wait.Seconds()) + 1
+		return nil, &chatError{
+			status: http.StatusTooManyRequests,
+			body: gin.H{
+				"error":               "Slow mode is active, please wait before sending another message",
+				"slow_mode_seconds":   h.cfg.ChatSlowModeSeconds,
+				"retry_after_seconds": retryAfter,
+			},
+			retryAfter: retryAfter,
+		}
+	}
+
 	// Get user's current achievements
-	achievements, err := h.chatRepo.GetUserAchievementBadges(userID)
+	achievements, err := h.chatRepo.GetUserAchievementBadges(ctx, userID)
 	if err != nil {
 		achievements = []models.AchievementBadge{}
 	}
@@ -110,33 +253,28 @@ func (h *ChatHandler) Create(c *gin.Context) {
 		UserID:       userID,
 		Message:      message,
 		Achievements: string(achievementsJSON),
+		ReplyToID:    req.ReplyToID,
 	}
 
-	if err := h.chatRepo.Create(chatMsg); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to create chat message",
-		})
-		return
+	if err := h.chatRepo.Create(ctx, chatMsg); err != nil {
+		return nil, &chatError{status: http.StatusInternalServerError, body: gin.H{"error": "Failed to create chat message"}}
 	}
 
 	// Get the full message with user info
-	fullMsg, err := h.chatRepo.GetByID(chatMsg.ID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve chat message",
-		})
-		return
+	fullMsg, err := h.chatRepo.GetByID(ctx, chatMsg.ID)
+	if err != nil || fullMsg == nil {
+		return nil, &chatError{status: http.StatusInternalServerError, body: gin.H{"error": "Failed to retrieve chat message"}}
 	}
 
 	// Get user avatar info for WebSocket broadcast
-	user, _ := h.userRepo.GetByID(userID)
+	user, _ := h.userRepo.GetByID(ctx, userID)
 	avatarSmall := ""
 	if user != nil {
 		avatarSmall = user.AvatarSmall
 	}
 
 	// Broadcast to all connected clients
-	h.wsHub.BroadcastChatMessage(&websocket.ChatMessagePayload{
+	payload := &websocket.ChatMessagePayload{
 		ID:           fullMsg.ID,
 		UserID:       userID,
 		Username:     username,
@@ -145,9 +283,301 @@ func (h *ChatHandler) Create(c *gin.Context) {
 		Message:      fullMsg.Message,
 		Achievements: achievements,
 		CreatedAt:    fullMsg.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if fullMsg.ReplyTo != nil {
+		payload.ReplyTo = fullMsg.ReplyTo
+	}
+	h.wsHub.BroadcastChatMessage(payload)
+
+	h.notifyMentions(ctx, fullMsg, userID, username)
+
+	return fullMsg, nil
+}
+
+// Delete removes a chat message, authors may delete their own messages within
+// CHAT_DELETE_WINDOW_MINUTES, admins may always delete
+// DELETE /api/v1/chat/:id
+func (h *ChatHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, ok := middleware.GetClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid message ID",
+		})
+		return
+	}
+
+	msg, err := h.chatRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to get chat message %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get chat message",
+		})
+		return
+	}
+	if msg == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Message not found",
+		})
+		return
+	}
+
+	deletedBy := "admin"
+	if !h.cfg.IsAdmin(claims.SteamID) {
+		if msg.IsSystem || msg.User.ID != claims.UserID {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "You can only delete your own messages",
+			})
+			return
+		}
+		window := time.Duration(h.cfg.ChatDeleteWindowMinutes) * time.Minute
+		if window <= 0 || time.Since(msg.CreatedAt) > window {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":          "Message can no longer be deleted",
+				"window_minutes": h.cfg.ChatDeleteWindowMinutes,
+			})
+			return
+		}
+		deletedBy = "author"
+	}
+
+	if err := h.chatRepo.Delete(ctx, id); err != nil {
+		log.Printf("Failed to delete chat message %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete chat message",
+		})
+		return
+	}
+
+	log.Printf("Chat message %d deleted by %s %s", id, deletedBy, claims.Username)
+
+	h.wsHub.BroadcastChatMessageDeleted(&websocket.ChatMessageDeletedPayload{
+		ID:        id,
+		DeletedBy: deletedBy,
 	})
 
-	c.JSON(http.StatusCreated, gin.H{
-		"message": fullMsg,
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Chat message deleted",
+	})
+}
+
+// MarkRead stores the last chat message the current user has read
+// All connected devices of the user receive the new unread count
+// PUT /api/v1/chat/read
+func (h *ChatHandler) MarkRead(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	// The body is optional, without message ID the newest message is marked as read
+	var req models.ChatReadRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	messageID := req.MessageID
+	if messageID == 0 {
+		latestID, err := h.chatRepo.GetLatestID(ctx)
+		if err != nil {
+			log.Printf("Failed to get latest chat message: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to mark chat as read",
+			})
+			return
+		}
+		messageID = latestID
+	}
+
+	if err := h.chatRepo.MarkRead(ctx, userID, messageID); err != nil {
+		log.Printf("Failed to mark chat as read: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to mark chat as read",
+		})
+		return
+	}
+
+	lastReadID, unread, err := h.chatRepo.GetReadState(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to get chat read state: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to mark chat as read",
+		})
+		return
+	}
+
+	payload := &websocket.ChatUnreadPayload{
+		LastReadMessageID: lastReadID,
+		UnreadCount:       unread,
+	}
+	h.wsHub.NotifyChatUnread(userID, payload)
+
+	c.JSON(http.StatusOK, payload)
+}
+
+// notifyMentions stores the @username mentions of a message and notifies the mentioned users
+// The notification is sent even if the chat broadcast itself would not show a popup for them
+func (h *ChatHandler) notifyMentions(ctx context.Context, msg *models.ChatMessageWithUser, authorID uint64, authorName string) {
+	if !strings.Contains(msg.Message, "@") {
+		return
+	}
+
+	users, err := h.userRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("Failed to get users for chat mentions: %v", err)
+		return
+	}
+
+	mentioned := findMentionedUsers(msg.Message, users, authorID)
+	if len(mentioned) == 0 {
+		return
+	}
+
+	if err := h.chatRepo.CreateMentions(ctx, msg.ID, mentioned); err != nil {
+		log.Printf("Failed to create chat mentions: %v", err)
+		return
+	}
+
+	for _, userID := range mentioned {
+		unread, err := h.chatRepo.CountUnreadMentions(ctx, userID)
+		if err != nil {
+			log.Printf("Failed to count unread chat mentions: %v", err)
+		}
+		h.wsHub.NotifyChatMention(userID, &websocket.ChatMentionPayload{
+			MessageID:    msg.ID,
+			FromUserID:   authorID,
+			FromUsername: authorName,
+			Snippet:      models.NewChatReplySnippet(msg.Message),
+			UnreadCount:  unread,
+		})
+	}
+}
+
+// findMentionedUsers returns the IDs of the users mentioned with @username, case-insensitive
+// Usernames may contain spaces, the longest matching username wins
+func findMentionedUsers(message string, users []models.User, authorID uint64) []uint64 {
+	var mentioned []uint64
+	seen := make(map[uint64]bool)
+
+	for i := 0; i < len(message); i++ {
+		if message[i] != '@' {
+			continue
+		}
+		// Ignore e-mail addresses and the like
+		if i > 0 {
+			prev, _ := utf8.DecodeLastRuneInString(message[:i])
+			if !unicode.IsSpace(prev) && !unicode.IsPunct(prev) {
+				continue
+			}
+		}
+
+		rest := message[i+1:]
+		var best *models.User
+		for j := range users {
+			name := users[j].Username
+			if name == "" || len(name) > len(rest) || !strings.EqualFold(rest[:len(name)], name) {
+				continue
+			}
+			// The name must end at a word boundary
+			if next, _ := utf8.DecodeRuneInString(rest[len(name):]); len(rest) > len(name) &&
+				(unicode.IsLetter(next) || unicode.IsDigit(next) || next == '_') {
+				continue
+			}
+			if best == nil || len(name) > len(best.Username) {
+				best = &users[j]
+			}
+		}
+
+		if best != nil && best.ID != authorID && !seen[best.ID] {
+			seen[best.ID] = true
+			mentioned = append(mentioned, best.ID)
+		}
+	}
+
+	return mentioned
+}
+
+// GetMentions returns the chat messages the current user was mentioned in
+// GET /api/v1/chat/mentions
+func (h *ChatHandler) GetMentions(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 50
+	}
+
+	mentions, err := h.chatRepo.GetMentions(ctx, userID, limit)
+	if err != nil {
+		log.Printf("Failed to get chat mentions: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get chat mentions",
+		})
+		return
+	}
+
+	unread, err := h.chatRepo.CountUnreadMentions(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to count unread chat mentions: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get chat mentions",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ChatMentionsResponse{
+		Mentions:    mentions,
+		UnreadCount: unread,
+	})
+}
+
+// MarkMentionsRead marks all mentions of the current user as read
+// POST /api/v1/chat/mentions/read
+func (h *ChatHandler) MarkMentionsRead(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.chatRepo.MarkMentionsRead(ctx, userID); err != nil {
+		log.Printf("Failed to mark chat mentions as read: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to mark chat mentions as read",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"unread_count": 0,
 	})
 }
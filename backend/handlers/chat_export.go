@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// chatExportPageSize is the number of messages read from the database per page of the export
+const chatExportPageSize = 500
+
+// Export streams the full chat history as download for the party archive (admin only)
+// Query parameters: format=json (default) or text, from and until as RFC3339 to limit the date range
+// GET /api/v1/admin/chat/export
+func (h *ChatHandler) Export(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "text" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "format must be 'json' or 'text'",
+		})
+		return
+	}
+
+	from, ok := parseExportTime(c, "from")
+	if !ok {
+		return
+	}
+	until, ok := parseExportTime(c, "until")
+	if !ok {
+		return
+	}
+	if !from.IsZero() && !until.IsZero() && !until.After(from) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "until must be after from",
+		})
+		return
+	}
+
+	// Read the first page before sending headers so database errors still get a proper response
+	page, err := h.chatRepo.GetPage(ctx, 0, from, until, chatExportPageSize)
+	if err != nil {
+		log.Printf("Failed to export chat: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to export chat",
+		})
+		return
+	}
+
+	log.Printf("Admin %s exported the chat history (%s)", claims.SteamID, format)
+
+	filename := "chat-" + time.Now().In(h.cfg.EventLocation).Format("2006-01-02")
+	if format == "json" {
+		c.Header("Content-Type", "application/json; charset=utf-8")
+		filename += ".json"
+	} else {
+		c.Header("Content-Type", "text/plain; charset=utf-8")
+		filename += ".txt"
+	}
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+
+	w := bufio.NewWriter(c.Writer)
+	if format == "json" {
+		w.WriteString("[")
+	}
+
+	count := 0
+	for len(page) > 0 {
+		for i := range page {
+			if format == "json" {
+				if count > 0 {
+					w.WriteString(",")
+				}
+				data, _ := json.Marshal(&page[i])
+				w.WriteString("\n")
+				w.Write(data)
+			} else {
+				writeChatExportLine(w, &page[i], h.cfg.EventLocation)
+			}
+			count++
+		}
+		if err := w.Flush(); err != nil {
+			log.Printf("Chat export aborted after %d messages: %v", count, err)
+			return
+		}
+		c.Writer.Flush()
+
+		if len(page) < chatExportPageSize {
+			break
+		}
+		page, err = h.chatRepo.GetPage(ctx, page[len(page)-1].ID, from, until, chatExportPageSize)
+		if err != nil {
+			// Headers are already sent, the download ends incomplete
+			log.Printf("Chat export aborted after %d messages: %v", count, err)
+			return
+		}
+	}
+
+	if format == "json" {
+		w.WriteString("\n]\n")
+	}
+	w.Flush()
+}
+
+// parseExportTime parses an optional RFC3339 query parameter, zero if it is not set
+// Writes the error response and returns false if the value is invalid
+func parseExportTime(c *gin.Context, name string) (time.Time, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return time.Time{}, true
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("%s must be in RFC3339 format (e.g., 2024-12-31T18:00:00Z)", name),
+		})
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// writeChatExportLine writes a message as "[time] user: message" in the event timezone
+// Replies name the author of the parent message, following lines of a message are indented
+func writeChatExportLine(w *bufio.Writer, m *models.ChatMessageWithUser, loc *time.Location) {
+	author := m.User.Username
+	if m.ReplyTo != nil {
+		author += " (reply to " + m.ReplyTo.User.Username + ")"
+	}
+	message := strings.ReplaceAll(m.Message, "\n", "\n    ")
+	fmt.Fprintf(w, "[%s] %s: %s\n", m.CreatedAt.In(loc).Format("2006-01-02 15:04:05"), author, message)
+}
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/services"
+)
+
+// ChatFilterHandler handles the admin endpoints of the chat filter blocklist
+type ChatFilterHandler struct {
+	cfg           *config.Config
+	filterRepo    *repository.ChatFilterRepository
+	filterService *services.ChatFilterService
+}
+
+// NewChatFilterHandler creates a new chat filter handler
+func NewChatFilterHandler(cfg *config.Config, filterRepo *repository.ChatFilterRepository, filterService *services.ChatFilterService) *ChatFilterHandler {
+	return &ChatFilterHandler{
+		cfg:           cfg,
+		filterRepo:    filterRepo,
+		filterService: filterService,
+	}
+}
+
+// GetFilter returns the filter settings, the blocklist and the available presets (admin only)
+// GET /api/v1/admin/chat-filter
+func (h *ChatFilterHandler) GetFilter(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	words, err := h.filterRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("Failed to get blocked words: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load chat filter",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"mode":         h.cfg.ChatFilterMode,
+		"admin_bypass": h.cfg.ChatFilterAdminBypass,
+		"words":        words,
+		"presets":      h.filterService.Presets(),
+	})
+}
+
+// AddWord adds a word to the blocklist (admin only)
+// POST /api/v1/admin/chat-filter/words
+func (h *ChatFilterHandler) AddWord(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	var req models.AddChatBlockedWordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	word, err := services.NormalizeBlockedWord(req.Word)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	added, err := h.filterRepo.Add(ctx, []string{word}, "", claims.SteamID)
+	if err != nil {
+		log.Printf("Failed to add blocked word: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to add blocked word",
+		})
+		return
+	}
+	if added == 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Word is already blocked",
+		})
+		return
+	}
+
+	log.Printf("Admin %s added %q to the chat filter", claims.SteamID, word)
+	h.reload(c, http.StatusCreated, added)
+}
+
+// DeleteWord removes a word from the blocklist (admin only)
+// DELETE /api/v1/admin/chat-filter/words/:id
+func (h *ChatFilterHandler) DeleteWord(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid word ID",
+		})
+		return
+	}
+
+	deleted, err := h.filterRepo.Delete(ctx, id)
+	if err != nil {
+		log.Printf("Failed to delete blocked word %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete blocked word",
+		})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Blocked word not found",
+		})
+		return
+	}
+
+	log.Printf("Admin %s removed blocked word %d from the chat filter", claims.SteamID, id)
+	h.reload(c, http.StatusOK, 0)
+}
+
+// ImportPreset adds all words of a built-in language preset to the blocklist (admin only)
+// POST /api/v1/admin/chat-filter/presets/:language
+func (h *ChatFilterHandler) ImportPreset(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	language := c.Param("language")
+	if !slices.Contains(h.filterService.Presets(), language) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Preset not found",
+		})
+		return
+	}
+
+	words, err := h.filterService.Preset(language)
+	if err != nil {
+		log.Printf("Failed to read chat filter preset %s: %v", language, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to read preset",
+		})
+		return
+	}
+
+	added, err := h.filterRepo.Add(ctx, words, language, claims.SteamID)
+	if err != nil {
+		log.Printf("Failed to import chat filter preset %s: %v", language, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to import preset",
+		})
+		return
+	}
+
+	log.Printf("Admin %s imported the %s chat filter preset (%d new words)", claims.SteamID, language, added)
+	h.reload(c, http.StatusOK, added)
+}
+
+// reload applies a blocklist change and responds with the new blocklist
+func (h *ChatFilterHandler) reload(c *gin.Context, status int, added int) {
+	ctx := c.Request.Context()
+
+	if err := h.filterService.Load(ctx); err != nil {
+		log.Printf("Failed to reload chat filter: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to reload chat filter",
+		})
+		return
+	}
+
+	words, err := h.filterRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("Failed to get blocked words: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load chat filter",
+		})
+		return
+	}
+
+	c.JSON(status, gin.H{
+		"added": added,
+		"words": words,
+	})
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+)
+
+// ChatReminderHandler handles the admin endpoints for scheduled chat reminders
+type ChatReminderHandler struct {
+	reminderRepo *repository.ChatReminderRepository
+}
+
+// NewChatReminderHandler creates a new chat reminder handler
+func NewChatReminderHandler(reminderRepo *repository.ChatReminderRepository) *ChatReminderHandler {
+	return &ChatReminderHandler{
+		reminderRepo: reminderRepo,
+	}
+}
+
+// GetReminders returns all chat reminders, pending and sent
+// GET /api/v1/admin/reminders
+func (h *ChatReminderHandler) GetReminders(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	reminders, err := h.reminderRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("Failed to get chat reminders: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load reminders",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reminders": reminders,
+	})
+}
+
+// CreateReminder schedules a system chat message
+// POST /api/v1/admin/reminders
+func (h *ChatReminderHandler) CreateReminder(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, ok := middleware.GetClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req models.CreateChatReminderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	message := strings.TrimSpace(req.Message)
+	if message == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Message cannot be empty",
+		})
+		return
+	}
+
+	sendAt, err := time.Parse(time.RFC3339, req.SendAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "send_at must be in RFC3339 format (e.g., 2024-12-31T19:45:00+01:00)",
+		})
+		return
+	}
+	if sendAt.Before(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "send_at must be in the future",
+		})
+		return
+	}
+
+	reminder := &models.ChatReminder{
+		Message:   message,
+		SendAt:    sendAt.UTC(),
+		CreatedBy: claims.UserID,
+	}
+	if err := h.reminderRepo.Create(ctx, reminder); err != nil {
+		log.Printf("Failed to create chat reminder: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create reminder",
+		})
+		return
+	}
+
+	log.Printf("Admin %s scheduled chat reminder %d for %s", claims.Username, reminder.ID, reminder.SendAt.Format(time.RFC3339))
+
+	c.JSON(http.StatusCreated, gin.H{
+		"reminder": reminder,
+	})
+}
+
+// DeleteReminder cancels a pending chat reminder
+// DELETE /api/v1/admin/reminders/:id
+func (h *ChatReminderHandler) DeleteReminder(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid reminder ID",
+		})
+		return
+	}
+
+	reminder, err := h.reminderRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to get chat reminder %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete reminder",
+		})
+		return
+	}
+	if reminder == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Reminder not found",
+		})
+		return
+	}
+
+	deleted, err := h.reminderRepo.DeletePending(ctx, id)
+	if err != nil {
+		log.Printf("Failed to delete chat reminder %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete reminder",
+		})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Reminder has already been sent",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Reminder deleted",
+	})
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/services"
+)
+
+// DigestHandler handles the organizer digest endpoints for admins
+type DigestHandler struct {
+	digestService *services.OrganizerDigestService
+}
+
+// NewDigestHandler creates a new digest handler
+func NewDigestHandler(digestService *services.OrganizerDigestService) *DigestHandler {
+	return &DigestHandler{
+		digestService: digestService,
+	}
+}
+
+// Preview returns the content the next organizer digest would contain
+// GET /api/v1/admin/digest
+func (h *DigestHandler) Preview(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	digest, err := h.digestService.Build(ctx)
+	if err != nil {
+		log.Printf("Failed to build organizer digest: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to build organizer digest",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"enabled": h.digestService.Enabled(),
+		"digest":  digest,
+	})
+}
+
+// Send mails the organizer digest immediately, e.g. to test the SMTP settings
+// POST /api/v1/admin/digest/send
+func (h *DigestHandler) Send(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	if !h.digestService.Enabled() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": services.ErrDigestDisabled.Error(),
+		})
+		return
+	}
+
+	log.Printf("Admin %s triggered the organizer digest", claims.SteamID)
+
+	if err := h.digestService.Send(ctx); err != nil {
+		log.Printf("Failed to send organizer digest: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to send organizer digest",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tagesübersicht wurde versendet",
+	})
+}
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/services"
+)
+
+// DownloadHandler handles the download checklist endpoints
+type DownloadHandler struct {
+	downloadRepo    *repository.DownloadRepository
+	reminderService *services.DownloadReminderService
+}
+
+// NewDownloadHandler creates a new download handler
+func NewDownloadHandler(downloadRepo *repository.DownloadRepository, reminderService *services.DownloadReminderService) *DownloadHandler {
+	return &DownloadHandler{
+		downloadRepo:    downloadRepo,
+		reminderService: reminderService,
+	}
+}
+
+// GetDownloads returns the checklist with the readiness of all players and the own confirmation state
+// GET /api/v1/downloads
+func (h *DownloadHandler) GetDownloads(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, ok := middleware.GetClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	readiness, err := h.downloadRepo.GetReadiness(ctx, claims.UserID, false)
+	if err != nil {
+		log.Printf("Failed to get download readiness: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load downloads",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"downloads": readiness,
+	})
+}
+
+// MarkReady confirms that the current user pre-downloaded a requirement
+// POST /api/v1/downloads/:id/ready
+func (h *DownloadHandler) MarkReady(c *gin.Context) {
+	h.setReady(c, true)
+}
+
+// UnmarkReady revokes the current user's download confirmation
+// DELETE /api/v1/downloads/:id/ready
+func (h *DownloadHandler) UnmarkReady(c *gin.Context) {
+	h.setReady(c, false)
+}
+
+// setReady updates the current user's confirmation of a requirement
+func (h *DownloadHandler) setReady(c *gin.Context, ready bool) {
+	ctx := c.Request.Context()
+
+	claims, ok := middleware.GetClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	requirement, ok := h.loadRequirement(c)
+	if !ok {
+		return
+	}
+
+	if err := h.downloadRepo.SetReady(ctx, requirement.ID, claims.UserID, ready); err != nil {
+		log.Printf("Failed to update download confirmation of user %d for requirement %d: %v", claims.UserID, requirement.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update download status",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"requirement_id": requirement.ID,
+		"is_ready":       ready,
+	})
+}
+
+// GetAdminDownloads returns the checklist including the players that are still missing downloads
+// GET /api/v1/admin/downloads
+func (h *DownloadHandler) GetAdminDownloads(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, ok := middleware.GetClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	readiness, err := h.downloadRepo.GetReadiness(ctx, claims.UserID, true)
+	if err != nil {
+		log.Printf("Failed to get download readiness: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load downloads",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"downloads": readiness,
+	})
+}
+
+// CreateDownload adds a required download to the checklist
+// POST /api/v1/admin/downloads
+func (h *DownloadHandler) CreateDownload(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	requirement, ok := bindDownloadRequirement(c)
+	if !ok {
+		return
+	}
+
+	if err := h.downloadRepo.Create(ctx, requirement); err != nil {
+		log.Printf("Failed to create download requirement: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create download",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"download": requirement,
+	})
+}
+
+// UpdateDownload changes a required download
+// PUT /api/v1/admin/downloads/:id
+func (h *DownloadHandler) UpdateDownload(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	existing, ok := h.loadRequirement(c)
+	if !ok {
+		return
+	}
+
+	requirement, ok := bindDownloadRequirement(c)
+	if !ok {
+		return
+	}
+	requirement.ID = existing.ID
+	requirement.CreatedAt = existing.CreatedAt
+
+	if err := h.downloadRepo.Update(ctx, requirement); err != nil {
+		log.Printf("Failed to update download requirement %d: %v", existing.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update download",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"download": requirement,
+	})
+}
+
+// DeleteDownload removes a required download and all confirmations
+// DELETE /api/v1/admin/downloads/:id
+func (h *DownloadHandler) DeleteDownload(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	requirement, ok := h.loadRequirement(c)
+	if !ok {
+		return
+	}
+
+	if err := h.downloadRepo.Delete(ctx, requirement.ID); err != nil {
+		log.Printf("Failed to delete download requirement %d: %v", requirement.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete download",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Download deleted",
+	})
+}
+
+// RemindMissing immediately reminds all connected players with missing downloads
+// POST /api/v1/admin/downloads/remind
+func (h *DownloadHandler) RemindMissing(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	notified, err := h.reminderService.RemindAll(ctx)
+	if err != nil {
+		log.Printf("Failed to send download reminders: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to send reminders",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"notified": notified,
+	})
+}
+
+// loadRequirement parses the :id parameter and loads the requirement, writing the error response if needed
+func (h *DownloadHandler) loadRequirement(c *gin.Context) (*models.DownloadRequirement, bool) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid download ID",
+		})
+		return nil, false
+	}
+
+	requirement, err := h.downloadRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to get download requirement %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load download",
+		})
+		return nil, false
+	}
+	if requirement == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Download not found",
+		})
+		return nil, false
+	}
+	return requirement, true
+}
+
+// bindDownloadRequirement binds and validates a download requirement request
+func bindDownloadRequirement(c *gin.Context) (*models.DownloadRequirement, bool) {
+	var req models.DownloadRequirementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request: " + err.Error(),
+		})
+		return nil, false
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Name cannot be empty",
+		})
+		return nil, false
+	}
+	if req.AppID != nil && *req.AppID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "app_id must be a positive Steam app ID",
+		})
+		return nil, false
+	}
+
+	return &models.DownloadRequirement{
+		AppID:   req.AppID,
+		Name:    name,
+		Version: strings.TrimSpace(req.Version),
+		Note:    strings.TrimSpace(req.Note),
+	}, true
+}
@@ -1,6 +1,10 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"path/filepath"
 	"strconv"
@@ -10,6 +14,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/guided-traffic/rate-your-mate/backend/auth"
 	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
 	"github.com/guided-traffic/rate-your-mate/backend/repository"
 	"github.com/guided-traffic/rate-your-mate/backend/services"
 	"github.com/guided-traffic/rate-your-mate/backend/websocket"
@@ -18,24 +24,54 @@ import (
 const (
 	// Cooldown period for refreshing user games
 	userGamesRefreshCooldown = 5 * time.Minute
+	// Maximum number of players to compare in the common games list
+	maxCommonGamesUsers = 32
 )
 
+// SyncProgressBroadcaster reports game sync progress to all clients via WebSocket
+func SyncProgressBroadcaster(wsHub *websocket.Hub) services.SyncProgressCallback {
+	return func(phase string, currentGame string, processed, total int) {
+		percentage := 0
+		if total > 0 {
+			percentage = (processed * 100) / total
+		}
+
+		if phase == "complete" {
+			wsHub.BroadcastGamesSyncComplete(processed)
+		} else {
+			wsHub.BroadcastGamesSyncProgress(&websocket.GamesSyncProgressPayload{
+				Phase:          phase,
+				CurrentGame:    currentGame,
+				ProcessedCount: processed,
+				TotalCount:     total,
+				Percentage:     percentage,
+			})
+		}
+	}
+}
+
 // GameHandler handles game-related HTTP requests
 type GameHandler struct {
 	gameService       *services.GameService
+	gameNewsService   *services.GameNewsService
+	gameDealService   *services.GameDealService
 	imageCacheService *services.ImageCacheService
 	gameCacheRepo     *repository.GameCacheRepository
+	gameRatingRepo    *repository.GameRatingRepository
 	userRepo          *repository.UserRepository
 	cfg               *config.Config
 	wsHub             *websocket.Hub
 }
 
 // NewGameHandler creates a new game handler
-func NewGameHandler(gameService *services.GameService, imageCacheService *services.ImageCacheService, gameCacheRepo *repository.GameCacheRepository, userRepo *repository.UserRepository, cfg *config.Config, wsHub *websocket.Hub) *GameHandler {
+func NewGameHandler(gameService *services.GameService, gameNewsService *services.GameNewsService, gameDealService *services.GameDealService, imageCacheService *services.ImageCacheService, gameCacheRepo *repository.GameCacheRepository, gameRatingRepo *repository.GameRatingRepository, userRepo *repository.UserRepository, cfg *config.Config, wsHub *websocket.Hub) *GameHandler {
 	return &GameHandler{
 		gameService:       gameService,
+		gameNewsService:   gameNewsService,
+		gameDealService:   gameDealService,
 		imageCacheService: imageCacheService,
 		gameCacheRepo:     gameCacheRepo,
+		gameRatingRepo:    gameRatingRepo,
 		userRepo:          userRepo,
 		cfg:               cfg,
 		wsHub:             wsHub,
@@ -43,10 +79,32 @@ func NewGameHandler(gameService *services.GameService, imageCacheService *servic
 }
 
 // GetMultiplayerGames returns all multiplayer games owned by players
+// Optional query parameters: search, min_owners, max_price (cents), category, genre, free_only,
+// min_review (0-100), min_players, coop_only, sort (owners, price, review, playtime, rating)
+// and include_owners (adds username, avatar and playtime of every owner)
+// Responds with 304 Not Modified if If-None-Match contains the ETag of the current response
 // GET /api/v1/games
 func (h *GameHandler) GetMultiplayerGames(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	filter, ok := parseGameFilter(c)
+	if !ok {
+		return
+	}
+	includeOwners := false
+	if value := c.Query("include_owners"); value != "" {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "include_owners must be true or false",
+			})
+			return
+		}
+		includeOwners = b
+	}
+
 	// First, return cached data immediately
-	games, needsSync, err := h.gameService.GetMultiplayerGamesCached()
+	games, needsSync, err := h.gameService.GetMultiplayerGamesCached(ctx)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to fetch games",
@@ -57,6 +115,30 @@ func (h *GameHandler) GetMultiplayerGames(c *gin.Context) {
 	// Check current sync status
 	isSyncing, phase, currentGame, processed, total := h.gameService.GetSyncStatus()
 
+	// Polling clients skip the download while neither the list, the ratings nor the sync status changed
+	etag, err := h.gameService.GamesETag(ctx, games, c.Request.URL.RawQuery, needsSync, isSyncing, phase, currentGame, processed, total)
+	if err != nil {
+		log.Printf("Failed to compute games ETag: %v", err)
+	} else {
+		c.Header("ETag", etag)
+		c.Header("Cache-Control", "no-cache")
+		if etagMatches(c.GetHeader("If-None-Match"), etag) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+
+	games = h.gameService.FilterGames(ctx, games, filter)
+	if includeOwners {
+		if err := h.gameService.AddOwnerDetails(ctx, games); err != nil {
+			log.Printf("Failed to get game owner details: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to fetch games",
+			})
+			return
+		}
+	}
+
 	// Return response with sync status
 	c.JSON(http.StatusOK, gin.H{
 		"pinned_games": games.PinnedGames,
@@ -72,6 +154,84 @@ func (h *GameHandler) GetMultiplayerGames(c *gin.Context) {
 	})
 }
 
+// etagMatches checks whether an If-None-Match header contains the ETag, weak validators match as well
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// parseGameFilter reads the filter and sort query parameters of the games list
+// Writes the error response and returns false if a parameter is invalid
+func parseGameFilter(c *gin.Context) (models.GameFilter, bool) {
+	filter := models.GameFilter{
+		Search:        c.Query("search"),
+		Category:      strings.TrimSpace(c.Query("category")),
+		Genre:         strings.TrimSpace(c.Query("genre")),
+		MaxPriceCents: -1,
+		Sort:          c.Query("sort"),
+	}
+
+	invalid := func(message string) (models.GameFilter, bool) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": message,
+		})
+		return filter, false
+	}
+
+	if value := c.Query("min_owners"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return invalid("min_owners must be a non-negative number")
+		}
+		filter.MinOwners = n
+	}
+	if value := c.Query("max_price"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return invalid("max_price must be a non-negative price in cents")
+		}
+		filter.MaxPriceCents = n
+	}
+	if value := c.Query("free_only"); value != "" {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return invalid("free_only must be true or false")
+		}
+		filter.FreeOnly = b
+	}
+	if value := c.Query("min_review"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 || n > 100 {
+			return invalid("min_review must be between 0 and 100")
+		}
+		filter.MinReviewScore = n
+	}
+	if value := c.Query("min_players"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return invalid("min_players must be a non-negative number")
+		}
+		filter.MinPlayers = n
+	}
+	if value := c.Query("coop_only"); value != "" {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return invalid("coop_only must be true or false")
+		}
+		filter.CoopOnly = b
+	}
+	if filter.Sort != "" && !models.IsValidGameSort(filter.Sort) {
+		return invalid("sort must be one of: owners, price, review, playtime, rating")
+	}
+
+	return filter, true
+}
+
 // StartBackgroundSync triggers a background sync for game data
 // POST /api/v1/games/sync
 func (h *GameHandler) StartBackgroundSync(c *gin.Context) {
@@ -83,31 +243,14 @@ func (h *GameHandler) StartBackgroundSync(c *gin.Context) {
 	}
 
 	// Start sync with WebSocket progress updates
-	h.gameService.SyncGames(func(phase string, currentGame string, processed, total int) {
-		percentage := 0
-		if total > 0 {
-			percentage = (processed * 100) / total
-		}
-
-		if phase == "complete" {
-			h.wsHub.BroadcastGamesSyncComplete(processed)
-		} else {
-			h.wsHub.BroadcastGamesSyncProgress(&websocket.GamesSyncProgressPayload{
-				Phase:          phase,
-				CurrentGame:    currentGame,
-				ProcessedCount: processed,
-				TotalCount:     total,
-				Percentage:     percentage,
-			})
-		}
-	})
+	h.gameService.SyncGames(SyncProgressBroadcaster(h.wsHub))
 
 	c.JSON(http.StatusAccepted, gin.H{
 		"message": "Background sync started",
 	})
 }
 
-// GetSyncStatus returns the current sync status
+// GetSyncStatus returns the current sync status and the state of the Steam Store rate limiter
 // GET /api/v1/games/sync/status
 func (h *GameHandler) GetSyncStatus(c *gin.Context) {
 	isSyncing, phase, currentGame, processed, total := h.gameService.GetSyncStatus()
@@ -124,15 +267,253 @@ func (h *GameHandler) GetSyncStatus(c *gin.Context) {
 		"processed":    processed,
 		"total":        total,
 		"percentage":   percentage,
+		"rate_limit":   h.gameService.GetRateLimitStatus(),
+	})
+}
+
+// GetNews returns Steam news (patch notes, announcements) of the most commonly owned games
+// Optional query parameter: app_id
+// GET /api/v1/games/news
+func (h *GameHandler) GetNews(c *gin.Context) {
+	appID := 0
+	if appIDStr := c.Query("app_id"); appIDStr != "" {
+		id, err := strconv.Atoi(appIDStr)
+		if err != nil || id < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid app ID",
+			})
+			return
+		}
+		appID = id
+	}
+
+	news, updatedAt := h.gameNewsService.GetNews(appID)
+
+	var updatedAtStr *string
+	if !updatedAt.IsZero() {
+		formatted := updatedAt.In(h.cfg.EventLocation).Format(time.RFC3339)
+		updatedAtStr = &formatted
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"news":       news,
+		"enabled":    h.cfg.GameNewsEnabled,
+		"updated_at": updatedAtStr,
+	})
+}
+
+// GetCommonGames returns the multiplayer games every selected player owns, most combined playtime first
+// Query parameter user_ids is a comma-separated list of user IDs
+// GET /api/v1/games/common
+func (h *GameHandler) GetCommonGames(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var userIDs []uint64
+	seen := make(map[uint64]bool)
+	for _, part := range strings.Split(c.Query("user_ids"), ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(part, 10, 64)
+		if err != nil || id == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "user_ids must be a comma-separated list of user IDs",
+			})
+			return
+		}
+		if !seen[id] {
+			seen[id] = true
+			userIDs = append(userIDs, id)
+		}
+	}
+	if len(userIDs) == 0 || len(userIDs) > maxCommonGamesUsers {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Select between 1 and %d players", maxCommonGamesUsers),
+		})
+		return
+	}
+
+	for _, id := range userIDs {
+		user, err := h.userRepo.GetByID(ctx, id)
+		if err != nil {
+			log.Printf("Failed to get user %d: %v", id, err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to get common games",
+			})
+			return
+		}
+		if user == nil {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": fmt.Sprintf("User %d not found", id),
+			})
+			return
+		}
+	}
+
+	games, err := h.gameService.GetCommonGames(ctx, userIDs)
+	if err != nil {
+		log.Printf("Failed to get common games: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get common games",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_ids": userIDs,
+		"games":    games,
+	})
+}
+
+// GetRating returns the star ratings of a game and the rating of the requesting player
+// GET /api/v1/games/:appid/rating
+func (h *GameHandler) GetRating(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	appID, ok := h.ratedGameID(c)
+	if !ok {
+		return
+	}
+	userID, _ := middleware.GetUserID(c)
+
+	myRating, err := h.gameRatingRepo.GetUserRating(ctx, appID, userID)
+	if err != nil {
+		log.Printf("Failed to get rating of game %d: %v", appID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get rating",
+		})
+		return
+	}
+	h.respondRating(c, appID, myRating)
+}
+
+// RateGame stores the 1-5 star rating of the requesting player, an earlier rating is replaced
+// POST /api/v1/games/:appid/rating
+func (h *GameHandler) RateGame(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	appID, ok := h.ratedGameID(c)
+	if !ok {
+		return
+	}
+	userID, _ := middleware.GetUserID(c)
+
+	var req models.GameRatingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("rating must be between %d and %d", models.MinGameRating, models.MaxGameRating),
+		})
+		return
+	}
+
+	if err := h.gameRatingRepo.Upsert(ctx, appID, userID, req.Rating); err != nil {
+		log.Printf("Failed to rate game %d: %v", appID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to save rating",
+		})
+		return
+	}
+	h.respondRating(c, appID, req.Rating)
+}
+
+// DeleteRating removes the rating of the requesting player
+// DELETE /api/v1/games/:appid/rating
+func (h *GameHandler) DeleteRating(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	appID, ok := h.ratedGameID(c)
+	if !ok {
+		return
+	}
+	userID, _ := middleware.GetUserID(c)
+
+	if err := h.gameRatingRepo.Delete(ctx, appID, userID); err != nil {
+		log.Printf("Failed to delete rating of game %d: %v", appID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete rating",
+		})
+		return
+	}
+	h.respondRating(c, appID, 0)
+}
+
+// ratedGameID parses the :appid parameter, only games known to the game cache can be rated
+// Writes the error response and returns false if the game is unknown
+func (h *GameHandler) ratedGameID(c *gin.Context) (int, bool) {
+	ctx := c.Request.Context()
+
+	appID, err := strconv.Atoi(c.Param("appid"))
+	if err != nil || appID < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid app ID",
+		})
+		return 0, false
+	}
+
+	cached, err := h.gameCacheRepo.GetByAppID(ctx, appID)
+	if err != nil {
+		log.Printf("Failed to get game %d: %v", appID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get game",
+		})
+		return 0, false
+	}
+	if cached == nil || cached.FetchFailed {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game not found",
+		})
+		return 0, false
+	}
+	return appID, true
+}
+
+// respondRating writes the current star ratings of a game with the rating of the requesting player
+func (h *GameHandler) respondRating(c *gin.Context, appID, myRating int) {
+	ctx := c.Request.Context()
+
+	summary, err := h.gameRatingRepo.GetSummary(ctx, appID)
+	if err != nil {
+		log.Printf("Failed to get rating summary of game %d: %v", appID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get rating",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.GameRatingResponse{
+		AppID:             appID,
+		MyRating:          myRating,
+		GameRatingSummary: summary,
+	})
+}
+
+// GetDeals returns the active discounts and free weekends of pinned and commonly owned games
+// GET /api/v1/games/deals
+func (h *GameHandler) GetDeals(c *gin.Context) {
+	deals, updatedAt := h.gameDealService.GetDeals()
+
+	var updatedAtStr *string
+	if !updatedAt.IsZero() {
+		formatted := updatedAt.In(h.cfg.EventLocation).Format(time.RFC3339)
+		updatedAtStr = &formatted
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deals":      deals,
+		"enabled":    h.cfg.GameDealsEnabled,
+		"updated_at": updatedAtStr,
 	})
 }
 
 // RefreshGames invalidates the cache and returns fresh game data
 // POST /api/v1/games/refresh
 func (h *GameHandler) RefreshGames(c *gin.Context) {
+	ctx := c.Request.Context()
+
 	h.gameService.InvalidateCache()
 
-	games, err := h.gameService.GetMultiplayerGames()
+	games, err := h.gameService.GetMultiplayerGames(ctx)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to refresh games",
@@ -146,6 +527,8 @@ func (h *GameHandler) RefreshGames(c *gin.Context) {
 // InvalidateDBCache invalidates the database cache, forcing a re-fetch from Steam
 // POST /api/v1/admin/games/invalidate-cache
 func (h *GameHandler) InvalidateDBCache(c *gin.Context) {
+	ctx := c.Request.Context()
+
 	// Check admin permission
 	claims, exists := c.Get("claims")
 	if !exists {
@@ -160,7 +543,7 @@ func (h *GameHandler) InvalidateDBCache(c *gin.Context) {
 	}
 
 	// Invalidate DB cache
-	if err := h.gameCacheRepo.InvalidateAll(); err != nil {
+	if err := h.gameCacheRepo.InvalidateAll(ctx); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to invalidate cache",
 		})
@@ -194,6 +577,17 @@ func (h *GameHandler) ServeGameImage(c *gin.Context) {
 		return
 	}
 
+	// Cover images uploaded by admins take precedence, a versioned URL never changes its content
+	if overridePath, ok := h.imageCacheService.GetOverridePath(appID); ok {
+		if c.Query("v") != "" {
+			c.Header("Cache-Control", "public, max-age=31536000, immutable")
+		} else {
+			c.Header("Cache-Control", "public, max-age=300")
+		}
+		c.File(filepath.Clean(overridePath))
+		return
+	}
+
 	// Check if image exists locally
 	imagePath := h.imageCacheService.GetImagePath(appID)
 
@@ -211,9 +605,107 @@ func (h *GameHandler) ServeGameImage(c *gin.Context) {
 	c.File(filepath.Clean(imagePath))
 }
 
+// UploadImageOverride replaces the cover image of a game with an uploaded JPEG, PNG or WebP image (admin only)
+// Expects a multipart form with the file in the field "image"
+// PUT /api/v1/admin/games/:appid/image
+func (h *GameHandler) UploadImageOverride(c *gin.Context) {
+	claims, _ := middleware.GetClaims(c)
+
+	appID, err := strconv.Atoi(c.Param("appid"))
+	if err != nil || appID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid app ID"})
+		return
+	}
+
+	// Leave room for the multipart headers around the file
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, services.MaxGameImageOverrideBytes+64<<10)
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "image file is required (max 5 MB)"})
+		return
+	}
+	if fileHeader.Size > services.MaxGameImageOverrideBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Image is too large (max 5 MB)"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Printf("Failed to open uploaded image for game %d: %v", appID, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read image"})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		log.Printf("Failed to read uploaded image for game %d: %v", appID, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read image"})
+		return
+	}
+
+	if err := h.imageCacheService.SetOverride(appID, data); err != nil {
+		if errors.Is(err, services.ErrUnsupportedImage) {
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("Failed to save image override for game %d: %v", appID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save image"})
+		return
+	}
+
+	log.Printf("Admin %s uploaded a cover image for game %d", claims.SteamID, appID)
+	h.imagesChanged()
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":          "Titelbild gespeichert",
+		"header_image_url": h.imageCacheService.GetLocalImageURL(appID),
+	})
+}
+
+// DeleteImageOverride removes the uploaded cover image of a game, the Steam image is shown again (admin only)
+// DELETE /api/v1/admin/games/:appid/image
+func (h *GameHandler) DeleteImageOverride(c *gin.Context) {
+	claims, _ := middleware.GetClaims(c)
+
+	appID, err := strconv.Atoi(c.Param("appid"))
+	if err != nil || appID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid app ID"})
+		return
+	}
+
+	deleted, err := h.imageCacheService.DeleteOverride(appID)
+	if err != nil {
+		log.Printf("Failed to delete image override for game %d: %v", appID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete image"})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Game has no uploaded cover image"})
+		return
+	}
+
+	log.Printf("Admin %s removed the cover image of game %d", claims.SteamID, appID)
+	h.imagesChanged()
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":          "Titelbild entfernt",
+		"header_image_url": h.imageCacheService.GetLocalImageURL(appID),
+	})
+}
+
+// imagesChanged rebuilds the games list with the new image URLs and tells all clients to reload it
+func (h *GameHandler) imagesChanged() {
+	h.gameService.InvalidateCache()
+	h.wsHub.BroadcastGamesUpdated(&websocket.GamesUpdatedPayload{
+		PinnedGameIDs: h.gameService.GetPinnedGameIDs(),
+	})
+}
+
 // RefreshMyGames refreshes the current user's game library from Steam
 // POST /api/v1/games/refresh-my-games
 func (h *GameHandler) RefreshMyGames(c *gin.Context) {
+	ctx := c.Request.Context()
+
 	// Get user from JWT claims
 	claims, exists := c.Get("claims")
 	if !exists {
@@ -225,7 +717,7 @@ func (h *GameHandler) RefreshMyGames(c *gin.Context) {
 	steamID := jwtClaims.SteamID
 
 	// Get user from DB to check cooldown
-	user, err := h.userRepo.GetBySteamID(steamID)
+	user, err := h.userRepo.GetBySteamID(ctx, steamID)
 	if err != nil || user == nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user"})
 		return
@@ -246,14 +738,14 @@ func (h *GameHandler) RefreshMyGames(c *gin.Context) {
 	}
 
 	// Refresh user's games
-	gameCount, err := h.gameService.RefreshUserGames(steamID)
+	gameCount, err := h.gameService.RefreshUserGames(c.Request.Context(), steamID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh games"})
 		return
 	}
 
 	// Update last refresh timestamp
-	if err := h.userRepo.UpdateLastGamesRefresh(user.ID); err != nil {
+	if err := h.userRepo.UpdateLastGamesRefresh(ctx, user.ID); err != nil {
 		// Log but don't fail the request
 		c.JSON(http.StatusOK, gin.H{
 			"message":     "Games refreshed successfully",
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/services"
+	"github.com/guided-traffic/rate-your-mate/backend/websocket"
+)
+
+// GameServerHandler handles the servers players announce for games, e.g. "CS2 server up at 192.168.1.20"
+type GameServerHandler struct {
+	gameServerRepo *repository.GameServerRepository
+	gameService    *services.GameService
+	wsHub          *websocket.Hub
+}
+
+// NewGameServerHandler creates a new game server handler
+func NewGameServerHandler(gameServerRepo *repository.GameServerRepository, gameService *services.GameService, wsHub *websocket.Hub) *GameServerHandler {
+	return &GameServerHandler{
+		gameServerRepo: gameServerRepo,
+		gameService:    gameService,
+		wsHub:          wsHub,
+	}
+}
+
+// GetServers returns all announced game servers, most recently updated first
+// GET /api/v1/games/servers
+func (h *GameServerHandler) GetServers(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	servers, err := h.gameServerRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("Failed to get game servers: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get game servers",
+		})
+		return
+	}
+
+	games := multiplayerGameNames(ctx, h.gameService)
+	for i := range servers {
+		servers[i].GameName = games[servers[i].AppID]
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"servers": servers,
+	})
+}
+
+// SetServer announces the server of a game, any player may set or replace it
+// POST /api/v1/games/:appid/server
+func (h *GameServerHandler) SetServer(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, ok := middleware.GetClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	appID, name, ok := h.serverGame(c)
+	if !ok {
+		return
+	}
+
+	var req models.GameServerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "address is required",
+		})
+		return
+	}
+	address := strings.TrimSpace(req.Address)
+	if len(address) > models.MaxGameServerAddressLength || !isValidServerAddress(address) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "address must be a host or IP address with optional port (e.g., 192.168.1.20:27015)",
+		})
+		return
+	}
+	note := strings.TrimSpace(req.Note)
+	if utf8.RuneCountInString(note) > models.MaxGameServerNoteLength {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("note must be at most %d characters", models.MaxGameServerNoteLength),
+		})
+		return
+	}
+
+	if err := h.gameServerRepo.Upsert(ctx, appID, address, note, claims.UserID); err != nil {
+		log.Printf("Failed to save server of game %d: %v", appID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to save game server",
+		})
+		return
+	}
+
+	server, err := h.gameServerRepo.GetByAppID(ctx, appID)
+	if err != nil || server == nil {
+		log.Printf("Failed to reload server of game %d: %v", appID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get game server",
+		})
+		return
+	}
+	server.GameName = name
+
+	log.Printf("User %s announced %s server at %s", claims.Username, name, address)
+
+	h.wsHub.BroadcastGameServer(&websocket.GameServerPayload{
+		Event:  websocket.GameServerEventUp,
+		AppID:  appID,
+		Server: server,
+	})
+
+	c.JSON(http.StatusOK, server)
+}
+
+// DeleteServer removes the server of a game, any player may remove it once the server is down
+// DELETE /api/v1/games/:appid/server
+func (h *GameServerHandler) DeleteServer(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	appID, name, ok := h.serverGame(c)
+	if !ok {
+		return
+	}
+
+	if err := h.gameServerRepo.Delete(ctx, appID); err != nil {
+		log.Printf("Failed to delete server of game %d: %v", appID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete game server",
+		})
+		return
+	}
+
+	log.Printf("User %s removed the %s server", claims.Username, name)
+
+	h.wsHub.BroadcastGameServer(&websocket.GameServerPayload{
+		Event: websocket.GameServerEventDown,
+		AppID: appID,
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Server wurde entfernt",
+	})
+}
+
+// serverGame parses the :appid parameter, servers can only be announced for games of the multiplayer games list
+// Writes the error response and returns false if the game is unknown
+func (h *GameServerHandler) serverGame(c *gin.Context) (int, string, bool) {
+	ctx := c.Request.Context()
+
+	appID, err := strconv.Atoi(c.Param("appid"))
+	if err != nil || appID < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid app ID",
+		})
+		return 0, "", false
+	}
+
+	name, ok := multiplayerGameNames(ctx, h.gameService)[appID]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game is not in the multiplayer games list",
+		})
+		return 0, "", false
+	}
+	return appID, name, true
+}
+
+// multiplayerGameNames returns the names of the cached multiplayer games by app ID
+func multiplayerGameNames(ctx context.Context, gameService *services.GameService) map[int]string {
+	names := make(map[int]string)
+	games, _, err := gameService.GetMultiplayerGamesCached(ctx)
+	if err != nil || games == nil {
+		return names
+	}
+	for _, list := range [][]models.Game{games.PinnedGames, games.AllGames} {
+		for _, g := range list {
+			names[g.AppID] = g.Name
+		}
+	}
+	return names
+}
+
+// isValidServerAddress checks a host name or IPv4 address with optional port
+func isValidServerAddress(address string) bool {
+	host := address
+	if h, port, err := net.SplitHostPort(address); err == nil {
+		n, err := strconv.Atoi(port)
+		if err != nil || n < 1 || n > 65535 {
+			return false
+		}
+		host = h
+	}
+	if host == "" {
+		return false
+	}
+	for _, r := range host {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
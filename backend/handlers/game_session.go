@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/services"
+)
+
+// GameSessionHandler handles the games the players are in and the time spent per game
+type GameSessionHandler struct {
+	sessionService *services.GameSessionService
+	gameCacheRepo  *repository.GameCacheRepository
+}
+
+// NewGameSessionHandler creates a new game session handler
+func NewGameSessionHandler(sessionService *services.GameSessionService, gameCacheRepo *repository.GameCacheRepository) *GameSessionHandler {
+	return &GameSessionHandler{
+		sessionService: sessionService,
+		gameCacheRepo:  gameCacheRepo,
+	}
+}
+
+// GetActive returns the games that are currently played with their players
+// GET /api/v1/sessions
+func (h *GameSessionHandler) GetActive(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	games, err := h.sessionService.GetActiveGames(ctx)
+	if err != nil {
+		log.Printf("Failed to get active game sessions: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get game sessions",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"games": games,
+	})
+}
+
+// StartSession records that the player is now in a game, a running session is ended
+// POST /api/v1/sessions
+func (h *GameSessionHandler) StartSession(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, ok := middleware.GetClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req models.GameSessionStartRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.AppID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "app_id is required",
+		})
+		return
+	}
+
+	game, err := h.gameCacheRepo.GetByAppID(ctx, req.AppID)
+	if err != nil {
+		log.Printf("Failed to get game %d: %v", req.AppID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to start game session",
+		})
+		return
+	}
+	if game == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game not found",
+		})
+		return
+	}
+
+	if err := h.sessionService.StartSession(ctx, claims.UserID, req.AppID); err != nil {
+		log.Printf("Failed to start game session of user %d: %v", claims.UserID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to start game session",
+		})
+		return
+	}
+
+	log.Printf("User %s started playing %s (%d)", claims.Username, game.Name, req.AppID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Spielsitzung gestartet",
+	})
+}
+
+// EndSession records that the player left their game
+// DELETE /api/v1/sessions/me
+func (h *GameSessionHandler) EndSession(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, _ := middleware.GetUserID(c)
+
+	ended, err := h.sessionService.EndSession(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to end game session of user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to end game session",
+		})
+		return
+	}
+	if !ended {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "No running game session",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Spielsitzung beendet",
+	})
+}
+
+// GetStats returns the time spent per game and per player during the party
+// GET /api/v1/sessions/stats
+func (h *GameSessionHandler) GetStats(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	stats, err := h.sessionService.GetStats(ctx)
+	if err != nil {
+		log.Printf("Failed to get game session stats: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get game session stats",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+)
+
+// healthPingTimeout is the time the database gets to answer the health check
+const healthPingTimeout = 2 * time.Second
+
+// HealthHandler serves the health check for load balancers and monitoring
+type HealthHandler struct {
+	version   string
+	buildTime string
+	gitCommit string
+}
+
+// NewHealthHandler creates a new health handler with the build info of the binary
+func NewHealthHandler(version, buildTime, gitCommit string) *HealthHandler {
+	return &HealthHandler{
+		version:   version,
+		buildTime: buildTime,
+		gitCommit: gitCommit,
+	}
+}
+
+// Check reports the version and the database state, 503 if the database is unreachable
+// GET /health
+func (h *HealthHandler) Check(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), healthPingTimeout)
+	defer cancel()
+
+	db := database.Health(ctx)
+
+	status := "healthy"
+	code := http.StatusOK
+	if !db.Reachable {
+		status = "unhealthy"
+		code = http.StatusServiceUnavailable
+	}
+
+	c.JSON(code, gin.H{
+		"status":    status,
+		"version":   h.version,
+		"buildTime": h.buildTime,
+		"gitCommit": h.gitCommit,
+		"database":  db,
+	})
+}
+
+// Live reports that the process is running without checking the database
+// Used as liveness probe, so an unreachable database doesn't restart the backend
+// GET /health/live
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "alive",
+		"version": h.version,
+	})
+}
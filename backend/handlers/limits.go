@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/services"
+)
+
+// LimitsHandler describes the quotas of the current user for client developers
+type LimitsHandler struct {
+	cfg           *config.Config
+	userRepo      *repository.UserRepository
+	creditService *services.CreditService
+	voteLimiter   *middleware.RateLimiter
+	chatLimiter   *middleware.RateLimiter
+}
+
+// NewLimitsHandler creates a new limits handler
+func NewLimitsHandler(cfg *config.Config, userRepo *repository.UserRepository, creditService *services.CreditService, voteLimiter, chatLimiter *middleware.RateLimiter) *LimitsHandler {
+	return &LimitsHandler{
+		cfg:           cfg,
+		userRepo:      userRepo,
+		creditService: creditService,
+		voteLimiter:   voteLimiter,
+		chatLimiter:   chatLimiter,
+	}
+}
+
+// CreditQuota describes the credits of a user
+type CreditQuota struct {
+	Current          int  `json:"current"`
+	Max              int  `json:"max"`
+	IntervalSeconds  int  `json:"interval_seconds"`
+	SecondsUntilNext int  `json:"seconds_until_next"` // 0 at max credits, -1 while voting is paused
+	VotingPaused     bool `json:"voting_paused"`
+}
+
+// GetLimits returns the current rate limit quotas and credits of the user
+// GET /api/v1/limits
+func (h *LimitsHandler) GetLimits(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Not authenticated",
+		})
+		return
+	}
+
+	user, err := h.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to load user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load limits",
+		})
+		return
+	}
+	if user == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "User not found",
+		})
+		return
+	}
+
+	credits, err := h.creditService.CalculateAndUpdateCredits(ctx, user)
+	if err != nil {
+		log.Printf("Failed to update credits for user %d: %v", user.ID, err)
+		credits = user.Credits
+	}
+
+	secondsUntilNext := int(h.creditService.GetTimeUntilNextCredit(user).Seconds())
+	if h.cfg.VotingPaused {
+		secondsUntilNext = -1
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"votes": h.voteLimiter.Quota(userID),
+		"chat":  h.chatLimiter.Quota(userID),
+		"credits": CreditQuota{
+			Current:          credits,
+			Max:              h.cfg.CreditMax,
+			IntervalSeconds:  h.cfg.CreditIntervalMinutes * 60,
+			SecondsUntilNext: secondsUntilNext,
+			VotingPaused:     h.cfg.VotingPaused,
+		},
+		"quickvote_cooldown_seconds": h.cfg.QuickVoteCooldownSeconds,
+		"chat_slow_mode_seconds":     h.cfg.ChatSlowModeSeconds,
+	})
+}
@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/auth"
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/metrics"
+)
+
+// metricsStreamInterval is the time between two snapshots of the dashboard stream
+const metricsStreamInterval = 5 * time.Second
+
+// MetricsHandler serves the embedded metrics dashboard for deployments without Grafana
+type MetricsHandler struct {
+	cfg        *config.Config
+	jwtService *auth.JWTService
+	registry   *metrics.Registry
+}
+
+// NewMetricsHandler creates a new metrics handler
+func NewMetricsHandler(cfg *config.Config, jwtService *auth.JWTService, registry *metrics.Registry) *MetricsHandler {
+	return &MetricsHandler{
+		cfg:        cfg,
+		jwtService: jwtService,
+		registry:   registry,
+	}
+}
+
+// authorize checks the admin token from the query parameter
+// The page is opened directly in the browser and EventSource can't send headers
+func (h *MetricsHandler) authorize(c *gin.Context) bool {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Token required",
+		})
+		return false
+	}
+
+	claims, err := h.jwtService.ValidateToken(token)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid token",
+		})
+		return false
+	}
+
+	if !h.cfg.IsAdmin(claims.SteamID) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Admin access required",
+		})
+		return false
+	}
+
+	return true
+}
+
+// Dashboard serves a self-contained HTML page with live charts
+// GET /api/v1/admin/metrics-dashboard?token=xxx
+func (h *MetricsHandler) Dashboard(c *gin.Context) {
+	if !h.authorize(c) {
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Referrer-Policy", "no-referrer") // The URL contains the token
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(metricsDashboardHTML))
+}
+
+// Stream sends a metrics snapshot as server-sent event every few seconds
+// GET /api/v1/admin/metrics-dashboard/stream?token=xxx
+func (h *MetricsHandler) Stream(c *gin.Context) {
+	if !h.authorize(c) {
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Accel-Buffering", "no") // Disable proxy buffering (nginx)
+
+	ticker := time.NewTicker(metricsStreamInterval)
+	defer ticker.Stop()
+
+	c.SSEvent("snapshot", h.registry.Snapshot())
+	c.Writer.Flush()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-ticker.C:
+			c.SSEvent("snapshot", h.registry.Snapshot())
+			c.Writer.Flush()
+		}
+	}
+}
+
+// Prometheus serves all metrics in the Prometheus text format for scrapers
+// Disabled unless METRICS_PROMETHEUS_TOKEN is set, scrapers send it as bearer token
+// GET /api/v1/metrics
+func (h *MetricsHandler) Prometheus(c *gin.Context) {
+	if h.cfg.MetricsPrometheusToken == "" {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Prometheus metrics are disabled",
+		})
+		return
+	}
+
+	token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.cfg.MetricsPrometheusToken)) != 1 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid token",
+		})
+		return
+	}
+
+	c.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := h.registry.WritePrometheus(c.Writer); err != nil {
+		log.Printf("Failed to write Prometheus metrics: %v", err)
+	}
+}
+
+// metricsDashboardHTML is the dashboard page, charts are drawn on canvas without external scripts
+const metricsDashboardHTML = `<!DOCTYPE html>
+<html lang="de">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Rate your Mate - Metrics</title>
+<style>
+  body { margin: 0; padding: 24px; background: #111827; color: #e5e7eb; font-family: system-ui, sans-serif; }
+  h1 { margin: 0 0 4px; font-size: 20px; }
+  #status { margin: 0 0 24px; color: #9ca3af; font-size: 13px; }
+  .grid { display: grid; grid-template-columns: repeat(auto-fit, minmax(360px, 1fr)); gap: 16px; }
+  .card { background: #1f2937; border-radius: 8px; padding: 16px; }
+  .card h2 { margin: 0; font-size: 14px; font-weight: 500; color: #9ca3af; }
+  .card .value { margin: 4px 0 12px; font-size: 28px; font-weight: 600; }
+  canvas { width: 100%; height: 160px; display: block; }
+</style>
+</head>
+<body>
+<h1>Rate your Mate - Metrics</h1>
+<p id="status">Verbinde...</p>
+<div class="grid">
+  <div class="card"><h2>Votes / Minute</h2><div class="value" id="votes-value">-</div><canvas id="votes"></canvas></div>
+  <div class="card"><h2>Verbundene User</h2><div class="value" id="users-value">-</div><canvas id="users"></canvas></div>
+  <div class="card"><h2>Steam-Fehler / Minute</h2><div class="value" id="steam-value">-</div><canvas id="steam"></canvas></div>
+</div>
+<script>
+(function () {
+  var maxPoints = 120;
+  var charts = {
+    votes: { color: '#34d399', points: [] },
+    users: { color: '#60a5fa', points: [] },
+    steam: { color: '#f87171', points: [] }
+  };
+  var previous = null;
+
+  function draw(id) {
+    var chart = charts[id];
+    var canvas = document.getElementById(id);
+    var ratio = window.devicePixelRatio || 1;
+    canvas.width = canvas.clientWidth * ratio;
+    canvas.height = canvas.clientHeight * ratio;
+    var ctx = canvas.getContext('2d');
+    ctx.clearRect(0, 0, canvas.width, canvas.height);
+    if (chart.points.length < 2) return;
+
+    var max = Math.max.apply(null, chart.points.concat([1]));
+    var stepX = canvas.width / (maxPoints - 1);
+    var offset = maxPoints - chart.points.length;
+    ctx.strokeStyle = chart.color;
+    ctx.lineWidth = 2 * ratio;
+    ctx.beginPath();
+    chart.points.forEach(function (value, i) {
+      var x = (offset + i) * stepX;
+      var y = canvas.height - (value / max) * (canvas.height - 4 * ratio) - 2 * ratio;
+      if (i === 0) ctx.moveTo(x, y); else ctx.lineTo(x, y);
+    });
+    ctx.stroke();
+  }
+
+  function push(id, value, text) {
+    var chart = charts[id];
+    chart.points.push(value);
+    if (chart.points.length > maxPoints) chart.points.shift();
+    document.getElementById(id + '-value').textContent = text;
+    draw(id);
+  }
+
+  function perMinute(name, snapshot) {
+    var seconds = (new Date(snapshot.time) - new Date(previous.time)) / 1000;
+    if (seconds <= 0) return 0;
+    return ((snapshot.counters[name] || 0) - (previous.counters[name] || 0)) / seconds * 60;
+  }
+
+  var token = new URLSearchParams(window.location.search).get('token') || '';
+  var source = new EventSource('metrics-dashboard/stream?token=' + encodeURIComponent(token));
+  var status = document.getElementById('status');
+
+  source.addEventListener('snapshot', function (event) {
+    var snapshot = JSON.parse(event.data);
+    var users = snapshot.gauges.connected_users || 0;
+    push('users', users, String(users));
+    if (previous) {
+      var votes = perMinute('votes_cast_total', snapshot);
+      var steam = perMinute('steam_api_errors_total', snapshot);
+      push('votes', votes, votes.toFixed(1));
+      push('steam', steam, steam.toFixed(1));
+    }
+    previous = snapshot;
+    status.textContent = 'Aktualisiert: ' + new Date(snapshot.time).toLocaleTimeString() +
+      ' - Votes gesamt: ' + (snapshot.counters.votes_cast_total || 0) +
+      ' - Steam-Fehler gesamt: ' + (snapshot.counters.steam_api_errors_total || 0);
+  });
+  source.onerror = function () {
+    status.textContent = 'Verbindung unterbrochen, verbinde erneut...';
+  };
+  window.addEventListener('resize', function () { Object.keys(charts).forEach(draw); });
+})();
+</script>
+</body>
+</html>
+`
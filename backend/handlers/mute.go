@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/websocket"
+)
+
+// MuteHandler handles chat mutes, a milder moderation than kick or ban
+type MuteHandler struct {
+	muteRepo *repository.MuteRepository
+	userRepo *repository.UserRepository
+	wsHub    *websocket.Hub
+}
+
+// NewMuteHandler creates a new mute handler
+func NewMuteHandler(muteRepo *repository.MuteRepository, userRepo *repository.UserRepository, wsHub *websocket.Hub) *MuteHandler {
+	return &MuteHandler{
+		muteRepo: muteRepo,
+		userRepo: userRepo,
+		wsHub:    wsHub,
+	}
+}
+
+// GetMutedUsers returns all users that are currently muted (admin only)
+// GET /api/v1/admin/users/muted
+func (h *MuteHandler) GetMutedUsers(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	mutes, err := h.muteRepo.GetAllActive(ctx)
+	if err != nil {
+		log.Printf("Failed to get muted users: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get muted users",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"muted_users": mutes,
+	})
+}
+
+// MuteUser keeps a user from chatting (and optionally voting) for a while (admin only)
+// An existing mute of the user is replaced
+// POST /api/v1/admin/users/:id/mute
+func (h *MuteHandler) MuteUser(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req models.MuteUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	if req.DurationMinutes < 1 || req.DurationMinutes > models.MaxMuteMinutes {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("duration_minutes must be between 1 and %d", models.MaxMuteMinutes),
+		})
+		return
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if len(reason) > 500 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Reason must be at most 500 characters"})
+		return
+	}
+
+	user, err := h.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		log.Printf("Error getting user for mute: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user"})
+		return
+	}
+	if user == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	if user.SteamID == claims.SteamID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Du kannst dich nicht selbst stummschalten"})
+		return
+	}
+
+	mute := &models.UserMute{
+		UserID:       user.ID,
+		Username:     user.Username,
+		MutedUntil:   time.Now().UTC().Add(time.Duration(req.DurationMinutes) * time.Minute),
+		IncludeVotes: req.IncludeVotes,
+		Reason:       reason,
+		MutedBy:      claims.SteamID,
+	}
+	if err := h.muteRepo.Mute(ctx, mute); err != nil {
+		log.Printf("Error muting user %d: %v", user.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mute user"})
+		return
+	}
+
+	log.Printf("Admin %s muted user %s (%s) for %d minutes (votes: %v) - Reason: %s",
+		claims.SteamID, user.Username, user.SteamID, req.DurationMinutes, req.IncludeVotes, reason)
+
+	h.wsHub.NotifyUserMuted(user.ID, &websocket.UserMutedPayload{
+		Muted:        true,
+		MutedUntil:   mute.MutedUntil.Format(time.RFC3339),
+		IncludeVotes: mute.IncludeVotes,
+		Reason:       mute.Reason,
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Spieler wurde stummgeschaltet",
+		"mute":    mute,
+	})
+}
+
+// UnmuteUser lifts the mute of a user before it expires (admin only)
+// POST /api/v1/admin/users/:id/unmute
+func (h *MuteHandler) UnmuteUser(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	removed, err := h.muteRepo.Unmute(ctx, userID)
+	if err != nil {
+		log.Printf("Error unmuting user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unmute user"})
+		return
+	}
+	if !removed {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User is not muted"})
+		return
+	}
+
+	log.Printf("Admin %s unmuted user %d", claims.SteamID, userID)
+
+	h.wsHub.NotifyUserMuted(userID, &websocket.UserMutedPayload{Muted: false})
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Stummschaltung wurde aufgehoben",
+	})
+}
+
+// mutedError returns the 403 response body for a muted user
+func mutedError(mute *models.UserMute) gin.H {
+	return gin.H{
+		"error":       "You are muted",
+		"muted_until": mute.MutedUntil.Format(time.RFC3339),
+		"reason":      mute.Reason,
+	}
+}
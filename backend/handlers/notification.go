@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+)
+
+// NotificationHandler handles the personal notifications of the current user
+type NotificationHandler struct {
+	notificationRepo *repository.NotificationRepository
+	voteRepo         *repository.VoteRepository
+	cfg              *config.Config
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(notificationRepo *repository.NotificationRepository, voteRepo *repository.VoteRepository, cfg *config.Config) *NotificationHandler {
+	return &NotificationHandler{
+		notificationRepo: notificationRepo,
+		voteRepo:         voteRepo,
+		cfg:              cfg,
+	}
+}
+
+// GetNotifications returns the most recent notifications of the current user, oldest first
+// Clients load the unread ones after connecting to get the notifications they missed while offline
+// GET /api/v1/notifications?unread=true&limit=50
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 50
+	}
+	unreadOnly := c.Query("unread") == "true"
+
+	notifications, err := h.notificationRepo.GetByUser(ctx, userID, unreadOnly, limit)
+	if err != nil {
+		log.Printf("Failed to get notifications: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get notifications",
+		})
+		return
+	}
+
+	notifications, err = h.renderVotePayloads(ctx, notifications)
+	if err != nil {
+		log.Printf("Failed to load votes of notifications: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get notifications",
+		})
+		return
+	}
+
+	unread, err := h.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to count unread notifications: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get notifications",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NotificationsResponse{
+		Notifications: notifications,
+		UnreadCount:   unread,
+	})
+}
+
+// renderVotePayloads fills the payloads of vote_received notifications from their votes
+// The sender is anonymized by the current visibility mode, notifications of deleted votes are left out
+func (h *NotificationHandler) renderVotePayloads(ctx context.Context, notifications []models.Notification) ([]models.Notification, error) {
+	var voteIDs []uint64
+	for _, n := range notifications {
+		if n.VoteID != 0 {
+			voteIDs = append(voteIDs, n.VoteID)
+		}
+	}
+	votes, err := h.voteRepo.GetByIDs(ctx, voteIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := notifications[:0]
+	for _, n := range notifications {
+		if n.Type == models.NotificationTypeVoteReceived {
+			vote, ok := votes[n.VoteID]
+			if !ok {
+				continue
+			}
+			payload := newVotePayload(vote, h.cfg.VoteVisibilityMode)
+			payload.NotificationID = n.ID
+			data, err := json.Marshal(payload)
+			if err != nil {
+				return nil, err
+			}
+			n.Payload = data
+		}
+		rendered = append(rendered, n)
+	}
+	return rendered, nil
+}
+
+// MarkRead marks the given notifications of the current user as read, all of them without ids
+// POST /api/v1/notifications/read
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req models.MarkNotificationsReadRequest
+	// An empty body marks all notifications as read
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid request: " + err.Error(),
+			})
+			return
+		}
+	}
+	if len(req.IDs) > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Too many notification ids",
+		})
+		return
+	}
+
+	if err := h.notificationRepo.MarkRead(ctx, userID, req.IDs); err != nil {
+		log.Printf("Failed to mark notifications as read: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to mark notifications as read",
+		})
+		return
+	}
+
+	unread, err := h.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to count unread notifications: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to mark notifications as read",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"unread_count": unread,
+	})
+}
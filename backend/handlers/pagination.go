@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+)
+
+// parsePage reads the cursor and limit query parameters of a paginated listing
+// Writes a 400 response and returns false if one of them is invalid
+func parsePage(c *gin.Context, defaultLimit int) (repository.Page, bool) {
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "limit must be a number",
+			})
+			return repository.Page{}, false
+		}
+	}
+
+	page, err := repository.NewPage(c.Query("cursor"), limit, defaultLimit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid cursor",
+		})
+		return repository.Page{}, false
+	}
+	return page, true
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/services"
+)
+
+// PhaseHandler handles the admin endpoints for event phases
+type PhaseHandler struct {
+	phaseRepo    *repository.PhaseRepository
+	phaseService *services.PhaseService
+}
+
+// NewPhaseHandler creates a new phase handler
+func NewPhaseHandler(phaseRepo *repository.PhaseRepository, phaseService *services.PhaseService) *PhaseHandler {
+	return &PhaseHandler{
+		phaseRepo:    phaseRepo,
+		phaseService: phaseService,
+	}
+}
+
+// PhaseRequest represents the request body for creating or updating an event phase
+type PhaseRequest struct {
+	Name                   string `json:"name"`
+	StartsAt               string `json:"starts_at"` // RFC3339 formatted time
+	CreditIntervalMinutes  int    `json:"credit_interval_minutes"`
+	NegativeVotingDisabled bool   `json:"negative_voting_disabled"`
+	VoteVisibilityMode     string `json:"vote_visibility_mode"` // "user_choice", "all_secret", "all_public"
+}
+
+// toPhase validates the request and converts it into an event phase
+func (r *PhaseRequest) toPhase() (*models.EventPhase, string) {
+	name := strings.TrimSpace(r.Name)
+	if name == "" || len(name) > 100 {
+		return nil, "name must be between 1 and 100 characters"
+	}
+
+	startsAt, err := time.Parse(time.RFC3339, r.StartsAt)
+	if err != nil {
+		return nil, "starts_at must be in RFC3339 format (e.g., 2024-12-31T18:00:00Z)"
+	}
+
+	if r.CreditIntervalMinutes < 1 || r.CreditIntervalMinutes > 60 {
+		return nil, "credit_interval_minutes must be between 1 and 60"
+	}
+
+	validModes := map[string]bool{"user_choice": true, "all_secret": true, "all_public": true}
+	if !validModes[r.VoteVisibilityMode] {
+		return nil, "vote_visibility_mode must be 'user_choice', 'all_secret', or 'all_public'"
+	}
+
+	return &models.EventPhase{
+		Name:                   name,
+		StartsAt:               startsAt,
+		CreditIntervalMinutes:  r.CreditIntervalMinutes,
+		NegativeVotingDisabled: r.NegativeVotingDisabled,
+		VoteVisibilityMode:     r.VoteVisibilityMode,
+	}, ""
+}
+
+// reload makes the phase service pick up changed phases
+func (h *PhaseHandler) reload(ctx context.Context) {
+	if err := h.phaseService.Reload(ctx); err != nil {
+		log.Printf("Warning: Failed to reload event phases: %v", err)
+	}
+}
+
+// GetPhases returns all event phases and the currently active one
+// GET /api/v1/admin/phases
+func (h *PhaseHandler) GetPhases(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	phases, err := h.phaseRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("Failed to get event phases: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load event phases",
+		})
+		return
+	}
+	if phases == nil {
+		phases = []models.EventPhase{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"phases":          phases,
+		"active_phase_id": h.phaseService.ActivePhaseID(),
+	})
+}
+
+// CreatePhase creates a new event phase
+// POST /api/v1/admin/phases
+func (h *PhaseHandler) CreatePhase(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req PhaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	phase, msg := req.toPhase()
+	if phase == nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": msg,
+		})
+		return
+	}
+
+	if err := h.phaseRepo.Create(ctx, phase); err != nil {
+		log.Printf("Failed to create event phase: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create event phase",
+		})
+		return
+	}
+
+	log.Printf("Admin created event phase '%s' starting at %v", phase.Name, phase.StartsAt)
+	h.reload(ctx)
+
+	c.JSON(http.StatusCreated, phase)
+}
+
+// UpdatePhase updates an existing event phase
+// PUT /api/v1/admin/phases/:id
+func (h *PhaseHandler) UpdatePhase(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid phase ID",
+		})
+		return
+	}
+
+	var req PhaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	phase, msg := req.toPhase()
+	if phase == nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": msg,
+		})
+		return
+	}
+
+	existing, err := h.phaseRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to get event phase %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update event phase",
+		})
+		return
+	}
+	if existing == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Event phase not found",
+		})
+		return
+	}
+
+	phase.ID = existing.ID
+	phase.CreatedAt = existing.CreatedAt
+	if err := h.phaseRepo.Update(ctx, phase); err != nil {
+		log.Printf("Failed to update event phase %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update event phase",
+		})
+		return
+	}
+
+	log.Printf("Admin updated event phase '%s' starting at %v", phase.Name, phase.StartsAt)
+	h.reload(ctx)
+
+	c.JSON(http.StatusOK, phase)
+}
+
+// DeletePhase deletes an event phase
+// DELETE /api/v1/admin/phases/:id
+func (h *PhaseHandler) DeletePhase(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid phase ID",
+		})
+		return
+	}
+
+	existing, err := h.phaseRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to get event phase %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete event phase",
+		})
+		return
+	}
+	if existing == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Event phase not found",
+		})
+		return
+	}
+
+	if err := h.phaseRepo.Delete(ctx, id); err != nil {
+		log.Printf("Failed to delete event phase %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete event phase",
+		})
+		return
+	}
+
+	log.Printf("Admin deleted event phase '%s'", existing.Name)
+	h.reload(ctx)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Event phase deleted",
+	})
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/services"
+	"github.com/guided-traffic/rate-your-mate/backend/websocket"
+)
+
+// PinnedGameHandler handles the admin endpoints of the pinned games
+type PinnedGameHandler struct {
+	gameService *services.GameService
+	wsHub       *websocket.Hub
+}
+
+// NewPinnedGameHandler creates a new pinned game handler
+func NewPinnedGameHandler(gameService *services.GameService, wsHub *websocket.Hub) *PinnedGameHandler {
+	return &PinnedGameHandler{
+		gameService: gameService,
+		wsHub:       wsHub,
+	}
+}
+
+// GetPinnedGames returns the pinned games in their order (admin only)
+// GET /api/v1/admin/games/pinned
+func (h *PinnedGameHandler) GetPinnedGames(c *gin.Context) {
+	h.respond(c, http.StatusOK)
+}
+
+// PinGame pins a game at the end of the pinned games (admin only)
+// POST /api/v1/admin/games/pinned
+func (h *PinnedGameHandler) PinGame(c *gin.Context) {
+	claims, _ := middleware.GetClaims(c)
+
+	var req models.PinGameRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.AppID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "app_id is required",
+		})
+		return
+	}
+
+	err := h.gameService.PinGame(c.Request.Context(), req.AppID)
+	switch {
+	case errors.Is(err, services.ErrGameAlreadyPinned):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Game is already pinned",
+		})
+		return
+	case errors.Is(err, services.ErrPinnedGameNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game not found on the Steam store",
+		})
+		return
+	case err != nil:
+		log.Printf("Failed to pin game %d: %v", req.AppID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to pin game",
+		})
+		return
+	}
+
+	log.Printf("Admin %s pinned game %d", claims.SteamID, req.AppID)
+	h.broadcast()
+	h.respond(c, http.StatusCreated)
+}
+
+// UnpinGame removes a game from the pinned games (admin only)
+// DELETE /api/v1/admin/games/pinned/:appid
+func (h *PinnedGameHandler) UnpinGame(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	appID, err := strconv.Atoi(c.Param("appid"))
+	if err != nil || appID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid app ID",
+		})
+		return
+	}
+
+	err = h.gameService.UnpinGame(ctx, appID)
+	switch {
+	case errors.Is(err, services.ErrGameNotPinned):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game is not pinned",
+		})
+		return
+	case err != nil:
+		log.Printf("Failed to unpin game %d: %v", appID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to unpin game",
+		})
+		return
+	}
+
+	log.Printf("Admin %s unpinned game %d", claims.SteamID, appID)
+	h.broadcast()
+	h.respond(c, http.StatusOK)
+}
+
+// ReorderPinnedGames sets the order of the pinned games (admin only)
+// PUT /api/v1/admin/games/pinned
+func (h *PinnedGameHandler) ReorderPinnedGames(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	var req models.ReorderPinnedGamesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "app_ids is required",
+		})
+		return
+	}
+
+	err := h.gameService.ReorderPinnedGames(ctx, req.AppIDs)
+	switch {
+	case errors.Is(err, services.ErrPinnedGamesMismatch):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "app_ids must list every pinned game exactly once",
+		})
+		return
+	case err != nil:
+		log.Printf("Failed to reorder pinned games: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to reorder pinned games",
+		})
+		return
+	}
+
+	log.Printf("Admin %s reordered the pinned games", claims.SteamID)
+	h.broadcast()
+	h.respond(c, http.StatusOK)
+}
+
+// broadcast tells all clients to reload the games list
+func (h *PinnedGameHandler) broadcast() {
+	h.wsHub.BroadcastGamesUpdated(&websocket.GamesUpdatedPayload{
+		PinnedGameIDs: h.gameService.GetPinnedGameIDs(),
+	})
+}
+
+// respond sends the current pinned games
+func (h *PinnedGameHandler) respond(c *gin.Context, status int) {
+	ctx := c.Request.Context()
+
+	games, err := h.gameService.GetPinnedGames(ctx)
+	if err != nil {
+		log.Printf("Failed to get pinned games: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get pinned games",
+		})
+		return
+	}
+
+	c.JSON(status, gin.H{
+		"pinned_games": games,
+	})
+}
@@ -0,0 +1,398 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/services"
+	"github.com/guided-traffic/rate-your-mate/backend/websocket"
+)
+
+// PollHandler handles the "what do we play next?" polls
+type PollHandler struct {
+	pollRepo    *repository.PollRepository
+	gameService *services.GameService
+	wsHub       *websocket.Hub
+	cfg         *config.Config
+}
+
+// NewPollHandler creates a new poll handler
+func NewPollHandler(pollRepo *repository.PollRepository, gameService *services.GameService, wsHub *websocket.Hub, cfg *config.Config) *PollHandler {
+	return &PollHandler{
+		pollRepo:    pollRepo,
+		gameService: gameService,
+		wsHub:       wsHub,
+		cfg:         cfg,
+	}
+}
+
+// GetPolls returns the newest polls with their results
+// GET /api/v1/polls
+func (h *PollHandler) GetPolls(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, _ := middleware.GetUserID(c)
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	polls, err := h.pollRepo.GetRecent(ctx, userID, limit)
+	if err != nil {
+		log.Printf("Failed to get polls: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get polls",
+		})
+		return
+	}
+
+	games := h.gameIndex(ctx)
+	for i := range polls {
+		addGameDetails(&polls[i], games)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"polls": polls,
+	})
+}
+
+// GetPoll returns a single poll with its results
+// GET /api/v1/polls/:id
+func (h *PollHandler) GetPoll(c *gin.Context) {
+	poll, ok := h.loadPoll(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, poll)
+}
+
+// CreatePoll creates a poll from games of the multiplayer games list, any player may start one
+// POST /api/v1/polls
+func (h *PollHandler) CreatePoll(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, ok := middleware.GetClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req models.CreatePollRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	question := strings.TrimSpace(req.Question)
+	if question == "" {
+		question = models.DefaultPollQuestion
+	}
+	if utf8.RuneCountInString(question) > models.MaxPollQuestionLength {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("question must be at most %d characters", models.MaxPollQuestionLength),
+		})
+		return
+	}
+	if req.DurationMinutes > models.MaxPollDuration {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("duration_minutes must be between 0 and %d", models.MaxPollDuration),
+		})
+		return
+	}
+
+	// Options must be games of the multiplayer games list, duplicates are dropped
+	games := h.gameIndex(ctx)
+	if len(games) == 0 {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "The games list is not available yet",
+		})
+		return
+	}
+	options := []models.PollOption{}
+	seen := make(map[int]bool, len(req.AppIDs))
+	for _, appID := range req.AppIDs {
+		if seen[appID] {
+			continue
+		}
+		seen[appID] = true
+
+		game, ok := games[appID]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("Game %d is not in the multiplayer games list", appID),
+			})
+			return
+		}
+		options = append(options, models.PollOption{AppID: appID, Name: game.Name})
+	}
+	if len(options) < models.MinPollOptions || len(options) > models.MaxPollOptions {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("A poll needs between %d and %d games", models.MinPollOptions, models.MaxPollOptions),
+		})
+		return
+	}
+
+	open, err := h.pollRepo.CountOpenByUser(ctx, claims.UserID)
+	if err != nil {
+		log.Printf("Failed to count open polls of user %d: %v", claims.UserID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create poll",
+		})
+		return
+	}
+	if open >= models.MaxOpenPollsPerUser {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": fmt.Sprintf("You already have %d open polls, close one first", open),
+		})
+		return
+	}
+
+	poll := &models.Poll{
+		Question:  question,
+		CreatedBy: models.PublicUser{ID: claims.UserID},
+		Options:   options,
+	}
+	if req.DurationMinutes > 0 {
+		closesAt := time.Now().Add(time.Duration(req.DurationMinutes) * time.Minute)
+		poll.ClosesAt = &closesAt
+	}
+	if err := h.pollRepo.Create(ctx, poll); err != nil {
+		log.Printf("Failed to create poll: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create poll",
+		})
+		return
+	}
+
+	log.Printf("User %s created poll %d with %d games", claims.Username, poll.ID, len(options))
+
+	created, ok := h.reloadAndBroadcast(c, poll.ID, claims.UserID, websocket.PollEventCreated)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// Vote votes for a game of an open poll, an earlier vote of the player is replaced
+// POST /api/v1/polls/:id/vote
+func (h *PollHandler) Vote(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, _ := middleware.GetUserID(c)
+
+	var req models.PollVoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "option_id is required",
+		})
+		return
+	}
+
+	poll, ok := h.loadPoll(c)
+	if !ok {
+		return
+	}
+	if poll.IsClosed {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Die Abstimmung ist bereits beendet",
+		})
+		return
+	}
+	if !poll.HasOption(req.OptionID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Option does not belong to this poll",
+		})
+		return
+	}
+
+	if err := h.pollRepo.Vote(ctx, poll.ID, userID, req.OptionID); err != nil {
+		log.Printf("Failed to vote in poll %d: %v", poll.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to vote",
+		})
+		return
+	}
+
+	updated, ok := h.reloadAndBroadcast(c, poll.ID, userID, websocket.PollEventVoted)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// ClosePoll ends a poll before its time, only its creator and admins may close it
+// POST /api/v1/polls/:id/close
+func (h *PollHandler) ClosePoll(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	poll, ok := h.loadPoll(c)
+	if !ok {
+		return
+	}
+	if poll.CreatedBy.ID != claims.UserID && !h.cfg.IsAdmin(claims.SteamID) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Only the creator of the poll or an admin can close it",
+		})
+		return
+	}
+	if poll.IsClosed {
+		c.JSON(http.StatusOK, poll)
+		return
+	}
+
+	if err := h.pollRepo.Close(ctx, poll.ID); err != nil {
+		log.Printf("Failed to close poll %d: %v", poll.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to close poll",
+		})
+		return
+	}
+
+	log.Printf("User %s closed poll %d", claims.Username, poll.ID)
+
+	closed, ok := h.reloadAndBroadcast(c, poll.ID, claims.UserID, websocket.PollEventClosed)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, closed)
+}
+
+// DeletePoll removes a poll with all votes (admin only)
+// DELETE /api/v1/admin/polls/:id
+func (h *PollHandler) DeletePoll(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	poll, ok := h.loadPoll(c)
+	if !ok {
+		return
+	}
+
+	if err := h.pollRepo.Delete(ctx, poll.ID); err != nil {
+		log.Printf("Failed to delete poll %d: %v", poll.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete poll",
+		})
+		return
+	}
+
+	log.Printf("Admin %s deleted poll %d", claims.SteamID, poll.ID)
+
+	h.wsHub.BroadcastPollUpdate(&websocket.PollUpdatePayload{
+		Event:  websocket.PollEventDeleted,
+		PollID: poll.ID,
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Abstimmung wurde gelöscht",
+	})
+}
+
+// loadPoll loads the poll from the :id parameter with game details for the requesting player
+// Writes the error response and returns false if the poll cannot be loaded
+func (h *PollHandler) loadPoll(c *gin.Context) (*models.Poll, bool) {
+	ctx := c.Request.Context()
+
+	userID, _ := middleware.GetUserID(c)
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid poll ID",
+		})
+		return nil, false
+	}
+
+	poll, err := h.pollRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		log.Printf("Failed to get poll %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get poll",
+		})
+		return nil, false
+	}
+	if poll == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Poll not found",
+		})
+		return nil, false
+	}
+
+	addGameDetails(poll, h.gameIndex(ctx))
+	return poll, true
+}
+
+// reloadAndBroadcast reads the current results of a changed poll and sends them to all clients
+// Returns the poll as seen by the requesting player
+func (h *PollHandler) reloadAndBroadcast(c *gin.Context, pollID, userID uint64, event string) (*models.Poll, bool) {
+	ctx := c.Request.Context()
+
+	poll, err := h.pollRepo.GetByID(ctx, pollID, userID)
+	if err != nil || poll == nil {
+		log.Printf("Failed to reload poll %d: %v", pollID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get poll",
+		})
+		return nil, false
+	}
+	addGameDetails(poll, h.gameIndex(ctx))
+
+	// The vote of the requesting player is not part of the broadcast
+	shared := *poll
+	shared.MyOptionID = nil
+	h.wsHub.BroadcastPollUpdate(&websocket.PollUpdatePayload{
+		Event:  event,
+		PollID: poll.ID,
+		Poll:   &shared,
+	})
+
+	return poll, true
+}
+
+// gameIndex returns the cached multiplayer games by app ID, empty if the games list is not available
+func (h *PollHandler) gameIndex(ctx context.Context) map[int]models.Game {
+	index := make(map[int]models.Game)
+	games, _, err := h.gameService.GetMultiplayerGamesCached(ctx)
+	if err != nil || games == nil {
+		return index
+	}
+	for _, g := range games.AllGames {
+		index[g.AppID] = g
+	}
+	for _, g := range games.PinnedGames {
+		index[g.AppID] = g
+	}
+	return index
+}
+
+// addGameDetails fills the game details of the poll options from the games list
+func addGameDetails(poll *models.Poll, games map[int]models.Game) {
+	for i := range poll.Options {
+		game, ok := games[poll.Options[i].AppID]
+		if !ok {
+			continue
+		}
+		poll.Options[i].HeaderImageURL = game.HeaderImageURL
+		poll.Options[i].OwnerCount = game.OwnerCount
+		poll.Options[i].MaxPlayers = game.MaxPlayers
+	}
+}
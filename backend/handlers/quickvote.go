@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+)
+
+// QuickVoteHandler handles compact vote endpoints for hardware buttons (e.g. Stream Deck)
+// Requests are authenticated with a personal token instead of the JWT
+type QuickVoteHandler struct {
+	voteHandler *VoteHandler
+	userRepo    *repository.UserRepository
+	cfg         *config.Config
+
+	// Last quick vote per user for rate limiting
+	lastVoteAt map[uint64]time.Time
+	mutex      sync.Mutex
+}
+
+// NewQuickVoteHandler creates a new quick vote handler
+func NewQuickVoteHandler(voteHandler *VoteHandler, userRepo *repository.UserRepository, cfg *config.Config) *QuickVoteHandler {
+	return &QuickVoteHandler{
+		voteHandler: voteHandler,
+		userRepo:    userRepo,
+		cfg:         cfg,
+		lastVoteAt:  make(map[uint64]time.Time),
+	}
+}
+
+// QuickVoteTarget is a compact vote target for button displays
+type QuickVoteTarget struct {
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
+}
+
+// QuickVoteRequest is the request body for POST /quickvote
+type QuickVoteRequest struct {
+	Target      uint64 `json:"target" binding:"required"`
+	Achievement string `json:"achievement" binding:"required"`
+}
+
+// hashQuickVoteToken returns the hex encoded SHA-256 hash of a quick-vote token
+func hashQuickVoteToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// authenticate resolves the user for the personal token passed in the X-Quickvote-Token header
+// The token is not accepted as a query parameter, it would end up in access logs
+func (h *QuickVoteHandler) authenticate(c *gin.Context) (*models.User, bool) {
+	ctx := c.Request.Context()
+
+	token := c.GetHeader("X-Quickvote-Token")
+	if token == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token required"})
+		return nil, false
+	}
+
+	user, err := h.userRepo.GetByQuickVoteTokenHash(ctx, hashQuickVoteToken(token))
+	if err != nil {
+		log.Printf("Failed to look up quick-vote token: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify token"})
+		return nil, false
+	}
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		return nil, false
+	}
+
+	return user, true
+}
+
+// reserveCooldown starts the quick vote cooldown of the user, checked and started in one step so
+// parallel requests can't all pass. It returns the remaining wait if the cooldown is still running,
+// otherwise a release function that undoes the reservation when the vote is rejected
+func (h *QuickVoteHandler) reserveCooldown(userID uint64) (time.Duration, func()) {
+	cooldown := time.Duration(h.cfg.QuickVoteCooldownSeconds) * time.Second
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	last, hadLast := h.lastVoteAt[userID]
+	if hadLast {
+		if wait := cooldown - time.Since(last); wait > 0 {
+			return wait, nil
+		}
+	}
+
+	now := time.Now()
+	h.lastVoteAt[userID] = now
+
+	release := func() {
+		h.mutex.Lock()
+		defer h.mutex.Unlock()
+		// A later reservation is left alone
+		if !h.lastVoteAt[userID].Equal(now) {
+			return
+		}
+		if hadLast {
+			h.lastVoteAt[userID] = last
+		} else {
+			delete(h.lastVoteAt, userID)
+		}
+	}
+	return 0, release
+}
+
+// GetTargets returns a compact list of users the token owner can vote for
+// If ?after=<user id> is given, "next" contains the following target (wrapping around)
+// so a single button can cycle through all players
+// GET /api/v1/quickvote/targets
+func (h *QuickVoteHandler) GetTargets(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := h.authenticate(c)
+	if !ok {
+		return
+	}
+
+	users, err := h.userRepo.GetAll(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load users"})
+		return
+	}
+
+	targets := make([]QuickVoteTarget, 0, len(users))
+	for _, u := range users {
+		if u.ID != user.ID {
+			targets = append(targets, QuickVoteTarget{ID: u.ID, Name: u.Username})
+		}
+	}
+
+	response := gin.H{"targets": targets}
+
+	if afterStr := c.Query("after"); afterStr != "" && len(targets) > 0 {
+		after, err := strconv.ParseUint(afterStr, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid after parameter"})
+			return
+		}
+
+		next := targets[0]
+		for i, t := range targets {
+			if t.ID == after {
+				next = targets[(i+1)%len(targets)]
+				break
+			}
+		}
+		response["next"] = next
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Vote casts a one-point vote for the given target and achievement
+// POST /api/v1/quickvote
+func (h *QuickVoteHandler) Vote(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := h.authenticate(c)
+	if !ok {
+		return
+	}
+
+	var req QuickVoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	wait, release := h.reserveCooldown(user.ID)
+	if wait > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many quick votes, slow down"})
+		return
+	}
+
+	vote, credits, verr := h.voteHandler.castVote(ctx, user.ID, models.CreateVoteRequest{
+		ToUserID:      req.Target,
+		AchievementID: req.Achievement,
+		Points:        1,
+	})
+	if verr != nil {
+		// A rejected vote doesn't block the next try
+		release()
+		c.JSON(verr.status, verr.body)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"ok":          true,
+		"vote_id":     vote.ID,
+		"target":      vote.ToUser.Username,
+		"achievement": vote.Achievement.Name,
+		"credits":     credits,
+	})
+}
+
+// CreateToken generates (or rotates) the current user's personal quick-vote token
+// The plain token is only returned once
+// POST /api/v1/users/me/quickvote-token
+func (h *QuickVoteHandler) CreateToken(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		return
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		log.Printf("Failed to generate quick-vote token: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+	token := hex.EncodeToString(buf)
+
+	if err := h.userRepo.SetQuickVoteTokenHash(ctx, userID, hashQuickVoteToken(token)); err != nil {
+		log.Printf("Failed to store quick-vote token for user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"token": token,
+	})
+}
+
+// RevokeToken revokes the current user's personal quick-vote token
+// DELETE /api/v1/users/me/quickvote-token
+func (h *QuickVoteHandler) RevokeToken(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		return
+	}
+
+	if err := h.userRepo.SetQuickVoteTokenHash(ctx, userID, ""); err != nil {
+		log.Printf("Failed to revoke quick-vote token for user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Token revoked",
+	})
+}
@@ -1,63 +1,91 @@
 package handlers
 
 import (
+	"context"
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/auth"
 	"github.com/guided-traffic/rate-your-mate/backend/config"
 	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
 	"github.com/guided-traffic/rate-your-mate/backend/repository"
 	"github.com/guided-traffic/rate-your-mate/backend/websocket"
 )
 
+// elevationTokenTTL is how long an admin elevation token stays valid
+const elevationTokenTTL = 15 * time.Minute
+
 // SettingsHandler handles admin settings endpoints
 type SettingsHandler struct {
-	cfg      *config.Config
-	wsHub    *websocket.Hub
-	userRepo *repository.UserRepository
-	voteRepo *repository.VoteRepository
+	cfg         *config.Config
+	wsHub       *websocket.Hub
+	userRepo    *repository.UserRepository
+	voteRepo    *repository.VoteRepository
+	profileRepo *repository.SettingsProfileRepository
+	timerRepo   *repository.TimerRepository
+	jwtService  *auth.JWTService
 }
 
 // NewSettingsHandler creates a new settings handler
-func NewSettingsHandler(cfg *config.Config, wsHub *websocket.Hub, userRepo *repository.UserRepository, voteRepo *repository.VoteRepository) *SettingsHandler {
+func NewSettingsHandler(cfg *config.Config, wsHub *websocket.Hub, userRepo *repository.UserRepository, voteRepo *repository.VoteRepository, profileRepo *repository.SettingsProfileRepository, timerRepo *repository.TimerRepository, jwtService *auth.JWTService) *SettingsHandler {
 	return &SettingsHandler{
-		cfg:      cfg,
-		wsHub:    wsHub,
-		userRepo: userRepo,
-		voteRepo: voteRepo,
+		cfg:         cfg,
+		wsHub:       wsHub,
+		userRepo:    userRepo,
+		voteRepo:    voteRepo,
+		profileRepo: profileRepo,
+		timerRepo:   timerRepo,
+		jwtService:  jwtService,
 	}
 }
 
 // GetSettingsRequest represents the response for GET /settings
 type GetSettingsResponse struct {
-	CreditIntervalMinutes  int     `json:"credit_interval_minutes"`
-	CreditMax              int     `json:"credit_max"`
-	VotingPaused           bool    `json:"voting_paused"`
-	VoteVisibilityMode     string  `json:"vote_visibility_mode"` // "user_choice", "all_secret", "all_public"
-	MinVotesForRanking     int     `json:"min_votes_for_ranking"`
-	NegativeVotingDisabled bool    `json:"negative_voting_disabled"`
-	CountdownTarget        *string `json:"countdown_target,omitempty"` // RFC3339 formatted time, null if not set
+	CreditIntervalMinutes  int            `json:"credit_interval_minutes"`
+	CreditMax              int            `json:"credit_max"`
+	VotingPaused           bool           `json:"voting_paused"`
+	VoteVisibilityMode     string         `json:"vote_visibility_mode"` // "user_choice", "all_secret", "all_public"
+	MinVotesForRanking     int            `json:"min_votes_for_ranking"`
+	NegativeVotingDisabled bool           `json:"negative_voting_disabled"`
+	CountdownTarget        *string        `json:"countdown_target,omitempty"` // RFC3339 formatted time, null if not set
+	SecretRevealAt         *string        `json:"secret_reveal_at,omitempty"` // RFC3339 formatted time, null if not set
+	AchievementDailyLimits map[string]int `json:"achievement_daily_limits"`   // Max votes per voter and day per achievement ID
+	ActivePhase            string         `json:"active_phase,omitempty"`     // Name of the active event phase
+	ChatSlowModeSeconds    int            `json:"chat_slow_mode_seconds"`     // Minimum seconds between two chat messages of a user, 0 = off
+	StoreCountry           string         `json:"store_country"`              // Steam Store country code for prices (e.g., "de")
+	StoreLanguage          string         `json:"store_language"`             // Steam Store language (e.g., "english")
 }
 
 // UpdateSettingsRequest represents the request body for PUT /settings
 type UpdateSettingsRequest struct {
-	CreditIntervalMinutes  *int    `json:"credit_interval_minutes"`
-	CreditMax              *int    `json:"credit_max"`
-	VotingPaused           *bool   `json:"voting_paused"`
-	VoteVisibilityMode     *string `json:"vote_visibility_mode"` // "user_choice", "all_secret", "all_public"
-	MinVotesForRanking     *int    `json:"min_votes_for_ranking"`
-	NegativeVotingDisabled *bool   `json:"negative_voting_disabled"`
-	CountdownTarget        *string `json:"countdown_target"` // RFC3339 formatted time, empty string to clear
+	CreditIntervalMinutes  *int            `json:"credit_interval_minutes"`
+	CreditMax              *int            `json:"credit_max"`
+	VotingPaused           *bool           `json:"voting_paused"`
+	VoteVisibilityMode     *string         `json:"vote_visibility_mode"` // "user_choice", "all_secret", "all_public"
+	MinVotesForRanking     *int            `json:"min_votes_for_ranking"`
+	NegativeVotingDisabled *bool           `json:"negative_voting_disabled"`
+	CountdownTarget        *string         `json:"countdown_target"`         // RFC3339 formatted time, empty string to clear
+	SecretRevealAt         *string         `json:"secret_reveal_at"`         // RFC3339 formatted time, empty string to clear
+	AchievementDailyLimits *map[string]int `json:"achievement_daily_limits"` // Replaces all limits, empty object to clear
+	ChatSlowModeSeconds    *int            `json:"chat_slow_mode_seconds"`   // 0 turns slow mode off
+	StoreCountry           *string         `json:"store_country"`            // Two-letter country code, applies to games synced afterwards
+	StoreLanguage          *string         `json:"store_language"`           // Steam Store language, applies to games synced afterwards
 }
 
 // VotingStatusResponse represents the response for GET /voting-status
 type VotingStatusResponse struct {
-	VotingPaused           bool    `json:"voting_paused"`
-	NegativeVotingDisabled bool    `json:"negative_voting_disabled"`
-	CountdownTarget        *string `json:"countdown_target,omitempty"` // RFC3339 formatted time, null if not set
+	VotingPaused           bool           `json:"voting_paused"`
+	NegativeVotingDisabled bool           `json:"negative_voting_disabled"`
+	CountdownTarget        *string        `json:"countdown_target,omitempty"` // RFC3339 formatted time, null if not set
+	AchievementDailyLimits map[string]int `json:"achievement_daily_limits"`   // Max votes per voter and day per achievement ID
+	EventTimezone          string         `json:"event_timezone"`             // IANA timezone server timestamps are rendered in
 }
 
 // CountdownResponse represents the response for GET /countdown (public endpoint)
@@ -70,7 +98,7 @@ type CountdownResponse struct {
 func (h *SettingsHandler) GetCountdown(c *gin.Context) {
 	response := CountdownResponse{}
 	if !h.cfg.CountdownTarget.IsZero() {
-		formatted := h.cfg.CountdownTarget.Format(time.RFC3339)
+		formatted := h.cfg.CountdownTarget.In(h.cfg.EventLocation).Format(time.RFC3339)
 		response.CountdownTarget = &formatted
 	}
 	c.JSON(http.StatusOK, response)
@@ -82,9 +110,11 @@ func (h *SettingsHandler) GetVotingStatus(c *gin.Context) {
 	response := VotingStatusResponse{
 		VotingPaused:           h.cfg.VotingPaused,
 		NegativeVotingDisabled: h.cfg.NegativeVotingDisabled,
+		AchievementDailyLimits: h.cfg.AchievementDailyLimits,
+		EventTimezone:          h.cfg.EventLocation.String(),
 	}
 	if !h.cfg.CountdownTarget.IsZero() {
-		formatted := h.cfg.CountdownTarget.Format(time.RFC3339)
+		formatted := h.cfg.CountdownTarget.In(h.cfg.EventLocation).Format(time.RFC3339)
 		response.CountdownTarget = &formatted
 	}
 	c.JSON(http.StatusOK, response)
@@ -93,24 +123,22 @@ func (h *SettingsHandler) GetVotingStatus(c *gin.Context) {
 // GetSettings returns the current settings
 // GET /api/v1/admin/settings
 func (h *SettingsHandler) GetSettings(c *gin.Context) {
-	response := GetSettingsResponse{
-		CreditIntervalMinutes:  h.cfg.CreditIntervalMinutes,
-		CreditMax:              h.cfg.CreditMax,
-		VotingPaused:           h.cfg.VotingPaused,
-		VoteVisibilityMode:     h.cfg.VoteVisibilityMode,
-		MinVotesForRanking:     h.cfg.MinVotesForRanking,
-		NegativeVotingDisabled: h.cfg.NegativeVotingDisabled,
-	}
-	if !h.cfg.CountdownTarget.IsZero() {
-		formatted := h.cfg.CountdownTarget.Format(time.RFC3339)
-		response.CountdownTarget = &formatted
-	}
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, h.settingsResponse())
+}
+
+// GetConfig returns the effective configuration with the source of each value, secrets are redacted (admin only)
+// GET /api/v1/admin/config
+func (h *SettingsHandler) GetConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"config": h.cfg.Describe(),
+	})
 }
 
 // UpdateSettings updates the settings (admin only)
 // PUT /api/v1/admin/settings
 func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
+	ctx := c.Request.Context()
+
 	var req UpdateSettingsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -119,15 +147,31 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 		return
 	}
 
+	updated, err := h.applySettings(ctx, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Broadcast settings change to all connected clients
+	if updated {
+		h.broadcastSettings()
+	}
+
+	c.JSON(http.StatusOK, h.settingsResponse())
+}
+
+// applySettings validates and applies the given settings, fields that are nil stay unchanged
+// Returns whether anything was changed, the error message is meant for the admin
+func (h *SettingsHandler) applySettings(ctx context.Context, req *UpdateSettingsRequest) (bool, error) {
 	// Validate and update settings
 	updated := false
 
 	if req.CreditIntervalMinutes != nil {
 		if *req.CreditIntervalMinutes < 1 || *req.CreditIntervalMinutes > 60 {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "credit_interval_minutes must be between 1 and 60",
-			})
-			return
+			return updated, errors.New("credit_interval_minutes must be between 1 and 60")
 		}
 		h.cfg.CreditIntervalMinutes = *req.CreditIntervalMinutes
 		updated = true
@@ -136,10 +180,7 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 
 	if req.CreditMax != nil {
 		if *req.CreditMax < 1 || *req.CreditMax > 100 {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "credit_max must be between 1 and 100",
-			})
-			return
+			return updated, errors.New("credit_max must be between 1 and 100")
 		}
 		h.cfg.CreditMax = *req.CreditMax
 		updated = true
@@ -162,7 +203,7 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 			log.Printf("Admin resumed voting after %v pause", pauseDuration)
 
 			// Shift all users' last_credit_at forward by the pause duration
-			if err := h.userRepo.ShiftAllLastCreditAt(pauseDuration); err != nil {
+			if err := h.userRepo.ShiftAllLastCreditAt(ctx, pauseDuration); err != nil {
 				log.Printf("Warning: Failed to shift last_credit_at times: %v", err)
 			} else {
 				log.Printf("Shifted all users' last_credit_at forward by %v", pauseDuration)
@@ -178,10 +219,7 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 	if req.VoteVisibilityMode != nil {
 		validModes := map[string]bool{"user_choice": true, "all_secret": true, "all_public": true}
 		if !validModes[*req.VoteVisibilityMode] {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "vote_visibility_mode must be 'user_choice', 'all_secret', or 'all_public'",
-			})
-			return
+			return updated, errors.New("vote_visibility_mode must be 'user_choice', 'all_secret', or 'all_public'")
 		}
 		h.cfg.VoteVisibilityMode = *req.VoteVisibilityMode
 		updated = true
@@ -190,10 +228,7 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 
 	if req.MinVotesForRanking != nil {
 		if *req.MinVotesForRanking < 0 || *req.MinVotesForRanking > 1000 {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "min_votes_for_ranking must be between 0 and 1000",
-			})
-			return
+			return updated, errors.New("min_votes_for_ranking must be between 0 and 1000")
 		}
 		h.cfg.MinVotesForRanking = *req.MinVotesForRanking
 		updated = true
@@ -220,34 +255,119 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 			// Parse and set the countdown
 			parsedTime, err := time.Parse(time.RFC3339, *req.CountdownTarget)
 			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error": "countdown_target must be in RFC3339 format (e.g., 2024-12-31T18:00:00Z)",
-				})
-				return
+				return updated, errors.New("countdown_target must be in RFC3339 format (e.g., 2024-12-31T18:00:00Z)")
 			}
 			h.cfg.CountdownTarget = parsedTime
 			updated = true
 			log.Printf("Admin set countdown target to %v", parsedTime)
 		}
+		h.persistTimer(ctx, models.TimerCountdown, h.cfg.CountdownTarget)
 	}
 
-	// Broadcast settings change to all connected clients
-	if updated {
-		var countdownTarget *string
-		if !h.cfg.CountdownTarget.IsZero() {
-			formatted := h.cfg.CountdownTarget.Format(time.RFC3339)
-			countdownTarget = &formatted
+	if req.SecretRevealAt != nil {
+		if *req.SecretRevealAt == "" {
+			// Cancel the scheduled reveal
+			h.cfg.SecretRevealAt = time.Time{}
+			updated = true
+			log.Printf("Admin cleared secret reveal schedule")
+		} else {
+			parsedTime, err := time.Parse(time.RFC3339, *req.SecretRevealAt)
+			if err != nil {
+				return updated, errors.New("secret_reveal_at must be in RFC3339 format (e.g., 2024-12-31T18:00:00Z)")
+			}
+			h.cfg.SecretRevealAt = parsedTime
+			updated = true
+			log.Printf("Admin scheduled secret reveal at %v", parsedTime)
 		}
-		h.wsHub.BroadcastSettingsUpdate(&websocket.SettingsPayload{
-			CreditIntervalMinutes:  h.cfg.CreditIntervalMinutes,
-			CreditMax:              h.cfg.CreditMax,
-			VotingPaused:           h.cfg.VotingPaused,
-			VoteVisibilityMode:     h.cfg.VoteVisibilityMode,
-			NegativeVotingDisabled: h.cfg.NegativeVotingDisabled,
-			CountdownTarget:        countdownTarget,
-		})
+		h.persistTimer(ctx, models.TimerSecretReveal, h.cfg.SecretRevealAt)
+	}
+
+	if req.AchievementDailyLimits != nil {
+		limits := make(map[string]int, len(*req.AchievementDailyLimits))
+		for achievementID, limit := range *req.AchievementDailyLimits {
+			if !models.IsValidAchievement(achievementID) {
+				return updated, fmt.Errorf("achievement_daily_limits contains unknown achievement '%s'", achievementID)
+			}
+			if limit < 1 || limit > 100 {
+				return updated, errors.New("achievement_daily_limits values must be between 1 and 100")
+			}
+			limits[achievementID] = limit
+		}
+		// Replace the map instead of modifying it, it is read concurrently by vote requests
+		h.cfg.AchievementDailyLimits = limits
+		updated = true
+		log.Printf("Admin updated achievement_daily_limits to %v", limits)
+	}
+
+	if req.ChatSlowModeSeconds != nil {
+		if *req.ChatSlowModeSeconds < 0 || *req.ChatSlowModeSeconds > 3600 {
+			return updated, errors.New("chat_slow_mode_seconds must be between 0 and 3600")
+		}
+		h.cfg.ChatSlowModeSeconds = *req.ChatSlowModeSeconds
+		updated = true
+		log.Printf("Admin updated chat_slow_mode_seconds to %d", *req.ChatSlowModeSeconds)
+	}
+
+	// Cached prices keep their currency until the game is synced again
+	if req.StoreCountry != nil {
+		country := strings.ToLower(strings.TrimSpace(*req.StoreCountry))
+		if !config.IsValidStoreCountry(country) {
+			return updated, errors.New("store_country must be a two-letter country code")
+		}
+		h.cfg.StoreCountry = country
+		updated = true
+		log.Printf("Admin updated store_country to %s", country)
+	}
+
+	if req.StoreLanguage != nil {
+		language := strings.ToLower(strings.TrimSpace(*req.StoreLanguage))
+		if !config.IsValidStoreLanguage(language) {
+			return updated, fmt.Errorf("store_language '%s' is not supported by the Steam Store", language)
+		}
+		h.cfg.StoreLanguage = language
+		updated = true
+		log.Printf("Admin updated store_language to %s", language)
+	}
+
+	return updated, nil
+}
+
+// persistTimer stores a timer so it survives restarts, a zero time clears it
+// The timer stays active in memory even if it cannot be stored
+func (h *SettingsHandler) persistTimer(ctx context.Context, name string, fireAt time.Time) {
+	if err := h.timerRepo.Save(ctx, name, fireAt); err != nil {
+		log.Printf("Warning: Failed to persist timer %s: %v", name, err)
+	}
+}
+
+// broadcastSettings sends the current settings to all connected clients
+func (h *SettingsHandler) broadcastSettings() {
+	var countdownTarget *string
+	if !h.cfg.CountdownTarget.IsZero() {
+		formatted := h.cfg.CountdownTarget.In(h.cfg.EventLocation).Format(time.RFC3339)
+		countdownTarget = &formatted
 	}
+	var secretRevealAt *string
+	if !h.cfg.SecretRevealAt.IsZero() {
+		formatted := h.cfg.SecretRevealAt.In(h.cfg.EventLocation).Format(time.RFC3339)
+		secretRevealAt = &formatted
+	}
+	h.wsHub.BroadcastSettingsUpdate(&websocket.SettingsPayload{
+		CreditIntervalMinutes:  h.cfg.CreditIntervalMinutes,
+		CreditMax:              h.cfg.CreditMax,
+		VotingPaused:           h.cfg.VotingPaused,
+		VoteVisibilityMode:     h.cfg.VoteVisibilityMode,
+		NegativeVotingDisabled: h.cfg.NegativeVotingDisabled,
+		CountdownTarget:        countdownTarget,
+		SecretRevealAt:         secretRevealAt,
+		AchievementDailyLimits: h.cfg.AchievementDailyLimits,
+		ActivePhase:            h.cfg.ActivePhase,
+		ChatSlowModeSeconds:    h.cfg.ChatSlowModeSeconds,
+	})
+}
 
+// settingsResponse builds the admin view of the current settings
+func (h *SettingsHandler) settingsResponse() GetSettingsResponse {
 	response := GetSettingsResponse{
 		CreditIntervalMinutes:  h.cfg.CreditIntervalMinutes,
 		CreditMax:              h.cfg.CreditMax,
@@ -255,12 +375,21 @@ func (h *SettingsHandler) UpdateSettings(c *gin.Context) {
 		VoteVisibilityMode:     h.cfg.VoteVisibilityMode,
 		MinVotesForRanking:     h.cfg.MinVotesForRanking,
 		NegativeVotingDisabled: h.cfg.NegativeVotingDisabled,
+		AchievementDailyLimits: h.cfg.AchievementDailyLimits,
+		ActivePhase:            h.cfg.ActivePhase,
+		ChatSlowModeSeconds:    h.cfg.ChatSlowModeSeconds,
+		StoreCountry:           h.cfg.StoreCountry,
+		StoreLanguage:          h.cfg.StoreLanguage,
 	}
 	if !h.cfg.CountdownTarget.IsZero() {
-		formatted := h.cfg.CountdownTarget.Format(time.RFC3339)
+		formatted := h.cfg.CountdownTarget.In(h.cfg.EventLocation).Format(time.RFC3339)
 		response.CountdownTarget = &formatted
 	}
-	c.JSON(http.StatusOK, response)
+	if !h.cfg.SecretRevealAt.IsZero() {
+		formatted := h.cfg.SecretRevealAt.In(h.cfg.EventLocation).Format(time.RFC3339)
+		response.SecretRevealAt = &formatted
+	}
+	return response
 }
 
 // ResetAllCreditsResponse represents the response for POST /admin/credits/reset
@@ -278,7 +407,9 @@ type GiveEveryoneCreditResponse struct {
 // ResetAllCredits sets all users' credits to 0
 // POST /api/v1/admin/credits/reset
 func (h *SettingsHandler) ResetAllCredits(c *gin.Context) {
-	usersAffected, err := h.userRepo.ResetAllCredits()
+	ctx := c.Request.Context()
+
+	usersAffected, err := h.userRepo.ResetAllCredits(ctx)
 	if err != nil {
 		log.Printf("Error resetting all credits: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -301,7 +432,9 @@ func (h *SettingsHandler) ResetAllCredits(c *gin.Context) {
 // GiveEveryoneCredit gives each user 1 credit
 // POST /api/v1/admin/credits/give
 func (h *SettingsHandler) GiveEveryoneCredit(c *gin.Context) {
-	usersAffected, err := h.userRepo.GiveEveryoneCredit(h.cfg.CreditMax)
+	ctx := c.Request.Context()
+
+	usersAffected, err := h.userRepo.GiveEveryoneCredit(ctx, h.cfg.CreditMax)
 	if err != nil {
 		log.Printf("Error giving everyone credit: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -350,14 +483,21 @@ type VerifyAdminPasswordRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// errElevationDisabled is the message of guarded admin endpoints while no admin password is configured
+const errElevationDisabled = "ADMIN_PASSWORD is not set - the SQL console, secret votes, anonymization and backup downloads and restores are disabled"
+
 // VerifyAdminPassword checks if the provided password matches the admin password
+// On success a short-lived elevation token is returned for guarded admin endpoints
+// Without a configured admin password no elevation token is issued
 // POST /api/v1/admin/verify-password
 func (h *SettingsHandler) VerifyAdminPassword(c *gin.Context) {
-	// If no admin password is configured, always allow access
+	claims, _ := middleware.GetClaims(c)
+
 	if h.cfg.AdminPassword == "" {
-		c.JSON(http.StatusOK, gin.H{
-			"valid":             true,
+		c.JSON(http.StatusForbidden, gin.H{
+			"valid":             false,
 			"password_required": false,
+			"error":             errElevationDisabled,
 		})
 		return
 	}
@@ -370,12 +510,9 @@ func (h *SettingsHandler) VerifyAdminPassword(c *gin.Context) {
 		return
 	}
 
-	if req.Password == h.cfg.AdminPassword {
+	if subtle.ConstantTimeCompare([]byte(req.Password), []byte(h.cfg.AdminPassword)) == 1 {
 		log.Printf("Admin password verified successfully")
-		c.JSON(http.StatusOK, gin.H{
-			"valid":             true,
-			"password_required": true,
-		})
+		h.respondElevated(c, claims.SteamID)
 	} else {
 		log.Printf("Invalid admin password attempt")
 		c.JSON(http.StatusForbidden, gin.H{
@@ -386,6 +523,69 @@ func (h *SettingsHandler) VerifyAdminPassword(c *gin.Context) {
 	}
 }
 
+// respondElevated issues an elevation token and writes the successful verification response
+func (h *SettingsHandler) respondElevated(c *gin.Context, steamID string) {
+	token, expiresAt, err := h.jwtService.GenerateElevationToken(steamID, elevationTokenTTL)
+	if err != nil {
+		log.Printf("Failed to generate elevation token: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to generate elevation token",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"valid":                true,
+		"password_required":    true,
+		"elevation_token":      token,
+		"elevation_expires_at": expiresAt.In(h.cfg.EventLocation).Format(time.RFC3339),
+	})
+}
+
+// ElevationMiddleware requires a valid elevation token in the X-Admin-Elevation header
+// Guarded endpoints are refused entirely while no admin password is configured
+// Must be used after AdminMiddleware
+func (h *SettingsHandler) ElevationMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := middleware.GetClaims(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Not authenticated",
+			})
+			c.Abort()
+			return
+		}
+
+		if h.cfg.AdminPassword == "" {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": errElevationDisabled,
+			})
+			c.Abort()
+			return
+		}
+
+		token := c.GetHeader("X-Admin-Elevation")
+		if token == "" {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "Elevation token required",
+			})
+			c.Abort()
+			return
+		}
+
+		if err := h.jwtService.ValidateElevationToken(token, claims.SteamID); err != nil {
+			log.Printf("Rejected elevation token for %s: %v", claims.SteamID, err)
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "Invalid or expired elevation token",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
 // CheckAdminPasswordRequired checks if an admin password is configured
 // GET /api/v1/admin/password-required
 func (h *SettingsHandler) CheckAdminPasswordRequired(c *gin.Context) {
@@ -403,7 +603,9 @@ type DeleteAllVotesResponse struct {
 // DeleteAllVotes deletes all votes from the database
 // POST /api/v1/admin/votes/delete-all
 func (h *SettingsHandler) DeleteAllVotes(c *gin.Context) {
-	votesDeleted, err := h.voteRepo.DeleteAll()
+	ctx := c.Request.Context()
+
+	votesDeleted, err := h.voteRepo.DeleteAll(ctx)
 	if err != nil {
 		log.Printf("Error deleting all votes: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -433,10 +635,18 @@ type BanUserRequest struct {
 	Reason string `json:"reason"`
 }
 
-// GetAllUsersForAdmin returns all users for admin management
-// GET /api/v1/admin/users
+// GetAllUsersForAdmin returns the users for admin management in the order they registered
+// Further pages are loaded with the next_cursor of the previous page
+// GET /api/v1/admin/users?limit=500&cursor=xxx
 func (h *SettingsHandler) GetAllUsersForAdmin(c *gin.Context) {
-	users, err := h.userRepo.GetAllForAdmin()
+	ctx := c.Request.Context()
+
+	page, ok := parsePage(c, repository.MaxPageLimit)
+	if !ok {
+		return
+	}
+
+	users, err := h.userRepo.GetAllForAdmin(ctx, page)
 	if err != nil {
 		log.Printf("Error getting users for admin: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -445,15 +655,22 @@ func (h *SettingsHandler) GetAllUsersForAdmin(c *gin.Context) {
 		return
 	}
 
+	if users == nil {
+		users = []models.AdminUserInfo{}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"users": users,
+		"users":       users,
+		"next_cursor": repository.NextCursor(page, users, func(u models.AdminUserInfo) uint64 { return u.ID }),
 	})
 }
 
 // GetAllBannedUsers returns all banned users
 // GET /api/v1/admin/users/banned
 func (h *SettingsHandler) GetAllBannedUsers(c *gin.Context) {
-	users, err := h.userRepo.GetAllBannedUsers()
+	ctx := c.Request.Context()
+
+	users, err := h.userRepo.GetAllBannedUsers(ctx)
 	if err != nil {
 		log.Printf("Error getting banned users: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -467,9 +684,11 @@ func (h *SettingsHandler) GetAllBannedUsers(c *gin.Context) {
 	})
 }
 
-// KickUser removes a user and all their data
+// KickUser removes a user, their votes and chat messages stay attributed to a former player
 // POST /api/v1/admin/users/:id/kick
 func (h *SettingsHandler) KickUser(c *gin.Context) {
+	ctx := c.Request.Context()
+
 	claims, _ := middleware.GetClaims(c)
 
 	userID := c.Param("id")
@@ -481,7 +700,7 @@ func (h *SettingsHandler) KickUser(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userRepo.GetByID(id)
+	user, err := h.userRepo.GetByID(ctx, id)
 	if err != nil {
 		log.Printf("Error getting user for kick: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user"})
@@ -492,8 +711,8 @@ func (h *SettingsHandler) KickUser(c *gin.Context) {
 		return
 	}
 
-	// Delete the user (cascade will handle votes and chat messages)
-	if err := h.userRepo.DeleteByID(id); err != nil {
+	// Remove the user, their votes and chat messages are kept for a former player
+	if err := h.userRepo.SoftDeleteByID(ctx, id); err != nil {
 		log.Printf("Error kicking user %d: %v", id, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to kick user"})
 		return
@@ -503,6 +722,7 @@ func (h *SettingsHandler) KickUser(c *gin.Context) {
 
 	// Broadcast user kicked to all connected clients
 	h.wsHub.BroadcastUserKicked(user.ID, user.Username)
+	h.wsHub.DisconnectUser(user.ID, websocket.CloseReasonKicked, "Du wurdest von einem Admin entfernt.")
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":  "Spieler wurde gekickt",
@@ -513,6 +733,8 @@ func (h *SettingsHandler) KickUser(c *gin.Context) {
 // BanUser bans a user (removes them and prevents re-login)
 // POST /api/v1/admin/users/:id/ban
 func (h *SettingsHandler) BanUser(c *gin.Context) {
+	ctx := c.Request.Context()
+
 	claims, _ := middleware.GetClaims(c)
 
 	userID := c.Param("id")
@@ -530,7 +752,7 @@ func (h *SettingsHandler) BanUser(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userRepo.GetByID(id)
+	user, err := h.userRepo.GetByID(ctx, id)
 	if err != nil {
 		log.Printf("Error getting user for ban: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user"})
@@ -548,14 +770,14 @@ func (h *SettingsHandler) BanUser(c *gin.Context) {
 	}
 
 	// Add to ban list
-	if err := h.userRepo.BanUser(user.SteamID, user.Username, req.Reason, claims.SteamID); err != nil {
+	if err := h.userRepo.BanUser(ctx, user.SteamID, user.Username, req.Reason, claims.SteamID); err != nil {
 		log.Printf("Error banning user %d: %v", id, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to ban user"})
 		return
 	}
 
-	// Delete the user (cascade will handle votes and chat messages)
-	if err := h.userRepo.DeleteByID(id); err != nil {
+	// Remove the user, their votes and chat messages are kept for a former player
+	if err := h.userRepo.SoftDeleteByID(ctx, id); err != nil {
 		log.Printf("Error deleting banned user %d: %v", id, err)
 		// Don't return error - user is already banned
 	}
@@ -564,6 +786,7 @@ func (h *SettingsHandler) BanUser(c *gin.Context) {
 
 	// Broadcast user banned to all connected clients
 	h.wsHub.BroadcastUserBanned(user.ID, user.Username)
+	h.wsHub.DisconnectUser(user.ID, websocket.CloseReasonBanned, "Du wurdest von einem Admin gebannt.")
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":  "Spieler wurde gebannt",
@@ -574,12 +797,14 @@ func (h *SettingsHandler) BanUser(c *gin.Context) {
 // UnbanUser removes a user from the ban list
 // POST /api/v1/admin/users/unban/:steam_id
 func (h *SettingsHandler) UnbanUser(c *gin.Context) {
+	ctx := c.Request.Context()
+
 	claims, _ := middleware.GetClaims(c)
 
 	steamID := c.Param("steam_id")
 
 	// Check if user is actually banned
-	banned, err := h.userRepo.GetBannedUser(steamID)
+	banned, err := h.userRepo.GetBannedUser(ctx, steamID)
 	if err != nil {
 		log.Printf("Error getting banned user: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get ban info"})
@@ -591,7 +816,7 @@ func (h *SettingsHandler) UnbanUser(c *gin.Context) {
 	}
 
 	// Remove from ban list
-	if err := h.userRepo.UnbanUser(steamID); err != nil {
+	if err := h.userRepo.UnbanUser(ctx, steamID); err != nil {
 		log.Printf("Error unbanning user %s: %v", steamID, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unban user"})
 		return
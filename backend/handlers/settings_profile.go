@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// SaveProfileRequest represents the request body for POST /settings/profiles
+type SaveProfileRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// captureSettings snapshots the current settings in the shape of UpdateSettingsRequest
+// Countdown and secret reveal are one-off points in time and are not part of a profile
+func (h *SettingsHandler) captureSettings() UpdateSettingsRequest {
+	creditInterval := h.cfg.CreditIntervalMinutes
+	creditMax := h.cfg.CreditMax
+	votingPaused := h.cfg.VotingPaused
+	visibilityMode := h.cfg.VoteVisibilityMode
+	minVotes := h.cfg.MinVotesForRanking
+	negativeDisabled := h.cfg.NegativeVotingDisabled
+	slowMode := h.cfg.ChatSlowModeSeconds
+	dailyLimits := make(map[string]int, len(h.cfg.AchievementDailyLimits))
+	for achievementID, limit := range h.cfg.AchievementDailyLimits {
+		dailyLimits[achievementID] = limit
+	}
+
+	return UpdateSettingsRequest{
+		CreditIntervalMinutes:  &creditInterval,
+		CreditMax:              &creditMax,
+		VotingPaused:           &votingPaused,
+		VoteVisibilityMode:     &visibilityMode,
+		MinVotesForRanking:     &minVotes,
+		NegativeVotingDisabled: &negativeDisabled,
+		AchievementDailyLimits: &dailyLimits,
+		ChatSlowModeSeconds:    &slowMode,
+	}
+}
+
+// GetProfiles returns all saved settings profiles
+// GET /api/v1/admin/settings/profiles
+func (h *SettingsHandler) GetProfiles(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	profiles, err := h.profileRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("Failed to get settings profiles: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load settings profiles",
+		})
+		return
+	}
+	if profiles == nil {
+		profiles = []models.SettingsProfile{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"profiles": profiles,
+	})
+}
+
+// SaveProfile saves the current settings as a named profile, overwriting a profile with the same name
+// POST /api/v1/admin/settings/profiles
+func (h *SettingsHandler) SaveProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req SaveProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Name is required",
+		})
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "name must be between 1 and 100 characters",
+		})
+		return
+	}
+
+	settings, err := json.Marshal(h.captureSettings())
+	if err != nil {
+		log.Printf("Failed to marshal settings profile: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to save settings profile",
+		})
+		return
+	}
+
+	profile, err := h.profileRepo.Save(ctx, name, settings)
+	if err != nil {
+		log.Printf("Failed to save settings profile '%s': %v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to save settings profile",
+		})
+		return
+	}
+
+	log.Printf("Admin saved current settings as profile '%s'", name)
+	c.JSON(http.StatusOK, profile)
+}
+
+// ApplyProfile applies all settings of a saved profile and broadcasts the change
+// POST /api/v1/admin/settings/profiles/:id/apply
+func (h *SettingsHandler) ApplyProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid profile ID",
+		})
+		return
+	}
+
+	profile, err := h.profileRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to get settings profile %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load settings profile",
+		})
+		return
+	}
+	if profile == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Settings profile not found",
+		})
+		return
+	}
+
+	var req UpdateSettingsRequest
+	if err := json.Unmarshal(profile.Settings, &req); err != nil {
+		log.Printf("Failed to parse settings profile %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Settings profile is corrupt",
+		})
+		return
+	}
+
+	// Profiles are validated again, e.g. an achievement with a daily limit may no longer exist
+	updated, err := h.applySettings(ctx, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	log.Printf("Admin applied settings profile '%s'", profile.Name)
+	if updated {
+		h.broadcastSettings()
+	}
+
+	c.JSON(http.StatusOK, h.settingsResponse())
+}
+
+// DeleteProfile deletes a saved settings profile
+// DELETE /api/v1/admin/settings/profiles/:id
+func (h *SettingsHandler) DeleteProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid profile ID",
+		})
+		return
+	}
+
+	profile, err := h.profileRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to get settings profile %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete settings profile",
+		})
+		return
+	}
+	if profile == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Settings profile not found",
+		})
+		return
+	}
+
+	if err := h.profileRepo.Delete(ctx, id); err != nil {
+		log.Printf("Failed to delete settings profile %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete settings profile",
+		})
+		return
+	}
+
+	log.Printf("Admin deleted settings profile '%s'", profile.Name)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Settings profile deleted",
+	})
+}
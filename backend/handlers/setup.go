@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/services"
+)
+
+// SetupHandler handles the first-run admin bootstrap
+type SetupHandler struct {
+	setupService *services.SetupService
+}
+
+// NewSetupHandler creates a new setup handler
+func NewSetupHandler(setupService *services.SetupService) *SetupHandler {
+	return &SetupHandler{
+		setupService: setupService,
+	}
+}
+
+// ClaimAdminRequest represents the request body for POST /setup/claim-admin
+type ClaimAdminRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// GetStatus reports whether the instance still waits for its first admin (public)
+// GET /api/v1/setup/status
+func (h *SetupHandler) GetStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"setup_required": h.setupService.IsSetupRequired(),
+	})
+}
+
+// ClaimAdmin makes the current user admin with the one-time setup code from the server log
+// POST /api/v1/setup/claim-admin
+func (h *SetupHandler) ClaimAdmin(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, ok := middleware.GetClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Not authenticated",
+		})
+		return
+	}
+
+	var req ClaimAdminRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Setup code is required",
+		})
+		return
+	}
+
+	err := h.setupService.ClaimAdmin(ctx, claims.SteamID, req.Code)
+	switch {
+	case errors.Is(err, services.ErrSetupNotRequired):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Setup has already been completed",
+		})
+		return
+	case errors.Is(err, services.ErrInvalidSetupCode):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Invalid setup code",
+		})
+		return
+	case err != nil:
+		log.Printf("Failed to claim admin for %s: %v", claims.SteamID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to complete setup",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "You are now admin",
+		"is_admin": true,
+	})
+}
@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+)
+
+// shortLinkSlugPattern restricts slugs to short URL-safe names like "rules" or "discord"
+var shortLinkSlugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+// ShortLinkHandler handles the /go short links and their admin endpoints
+type ShortLinkHandler struct {
+	linkRepo *repository.ShortLinkRepository
+	cfg      *config.Config
+}
+
+// NewShortLinkHandler creates a new short link handler
+func NewShortLinkHandler(linkRepo *repository.ShortLinkRepository, cfg *config.Config) *ShortLinkHandler {
+	return &ShortLinkHandler{
+		linkRepo: linkRepo,
+		cfg:      cfg,
+	}
+}
+
+// withShortURL fills in the printable short URL of a link
+func (h *ShortLinkHandler) withShortURL(link *models.ShortLink) {
+	link.ShortURL = strings.TrimRight(h.cfg.BackendURL, "/") + "/go/" + link.Slug
+}
+
+// validTargetURL checks that a target is an absolute http(s) URL
+func validTargetURL(target string) bool {
+	u, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// Redirect sends the client to the target of a short link (public)
+// GET /go/:slug
+func (h *ShortLinkHandler) Redirect(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	slug := strings.ToLower(c.Param("slug"))
+
+	link, err := h.linkRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		log.Printf("Failed to get short link %s: %v", slug, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to resolve link",
+		})
+		return
+	}
+	if link == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Link not found",
+		})
+		return
+	}
+
+	if err := h.linkRepo.IncrementHits(ctx, slug); err != nil {
+		log.Printf("Failed to count hit of short link %s: %v", slug, err)
+	}
+
+	// Temporary redirect, admins can change the target during the event
+	c.Redirect(http.StatusFound, link.TargetURL)
+}
+
+// GetLinks returns all short links (admin only)
+// GET /api/v1/admin/links
+func (h *ShortLinkHandler) GetLinks(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	links, err := h.linkRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("Failed to get short links: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load links",
+		})
+		return
+	}
+
+	for i := range links {
+		h.withShortURL(&links[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"links": links,
+	})
+}
+
+// CreateLink adds a short link (admin only)
+// POST /api/v1/admin/links
+func (h *ShortLinkHandler) CreateLink(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.ShortLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	slug := strings.ToLower(strings.TrimSpace(req.Slug))
+	if !shortLinkSlugPattern.MatchString(slug) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "slug must be 1-50 lowercase letters, digits or dashes",
+		})
+		return
+	}
+
+	target := strings.TrimSpace(req.TargetURL)
+	if !validTargetURL(target) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "target_url must be an absolute http or https URL",
+		})
+		return
+	}
+
+	existing, err := h.linkRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		log.Printf("Failed to get short link %s: %v", slug, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create link",
+		})
+		return
+	}
+	if existing != nil {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Link already exists",
+		})
+		return
+	}
+
+	link := &models.ShortLink{
+		Slug:      slug,
+		TargetURL: target,
+		Title:     strings.TrimSpace(req.Title),
+	}
+	if err := h.linkRepo.Create(ctx, link); err != nil {
+		log.Printf("Failed to create short link %s: %v", slug, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create link",
+		})
+		return
+	}
+
+	log.Printf("Admin created short link /go/%s -> %s", link.Slug, link.TargetURL)
+	h.withShortURL(link)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"link": link,
+	})
+}
+
+// UpdateLink changes the target and title of a short link (admin only)
+// PUT /api/v1/admin/links/:slug
+func (h *ShortLinkHandler) UpdateLink(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	link, err := h.linkRepo.GetBySlug(ctx, c.Param("slug"))
+	if err != nil {
+		log.Printf("Failed to get short link %s: %v", c.Param("slug"), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update link",
+		})
+		return
+	}
+	if link == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Link not found",
+		})
+		return
+	}
+
+	var req models.ShortLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	target := strings.TrimSpace(req.TargetURL)
+	if !validTargetURL(target) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "target_url must be an absolute http or https URL",
+		})
+		return
+	}
+
+	link.TargetURL = target
+	link.Title = strings.TrimSpace(req.Title)
+	if err := h.linkRepo.Update(ctx, link); err != nil {
+		log.Printf("Failed to update short link %s: %v", link.Slug, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update link",
+		})
+		return
+	}
+
+	log.Printf("Admin updated short link /go/%s -> %s", link.Slug, link.TargetURL)
+	h.withShortURL(link)
+
+	c.JSON(http.StatusOK, gin.H{
+		"link": link,
+	})
+}
+
+// DeleteLink removes a short link (admin only)
+// DELETE /api/v1/admin/links/:slug
+func (h *ShortLinkHandler) DeleteLink(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	link, err := h.linkRepo.GetBySlug(ctx, c.Param("slug"))
+	if err != nil {
+		log.Printf("Failed to get short link %s: %v", c.Param("slug"), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete link",
+		})
+		return
+	}
+	if link == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Link not found",
+		})
+		return
+	}
+
+	if err := h.linkRepo.Delete(ctx, link.Slug); err != nil {
+		log.Printf("Failed to delete short link %s: %v", link.Slug, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to delete link",
+		})
+		return
+	}
+
+	log.Printf("Admin deleted short link /go/%s", link.Slug)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Link deleted",
+	})
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/auth"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// SpectatorRoutes are the read-only routes spectator accounts may access
+var SpectatorRoutes = []string{
+	"/api/v1/votes",
+	"/api/v1/ranking",
+	"/api/v1/chat",
+}
+
+// Login attempts allowed per IP address and spectator name, so passcodes can't be guessed
+const (
+	spectatorLoginAttempts = 10
+	spectatorLoginWindow   = 15 * time.Minute
+)
+
+// SpectatorHandler handles the read-only spectator accounts for people without Steam login
+type SpectatorHandler struct {
+	spectatorRepo *repository.SpectatorRepository
+	jwtService    *auth.JWTService
+	loginLimiter  *middleware.RateLimiter
+}
+
+// NewSpectatorHandler creates a new spectator handler
+func NewSpectatorHandler(spectatorRepo *repository.SpectatorRepository, jwtService *auth.JWTService) *SpectatorHandler {
+	return &SpectatorHandler{
+		spectatorRepo: spectatorRepo,
+		jwtService:    jwtService,
+		loginLimiter:  middleware.NewRateLimiter(func() int { return spectatorLoginAttempts }, spectatorLoginWindow),
+	}
+}
+
+// Login issues a spectator token for name and passcode
+// POST /api/v1/auth/spectator
+func (h *SpectatorHandler) Login(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.SpectatorLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if !h.loginLimiter.LimitRequest(c, c.ClientIP()+"|"+strings.ToLower(name)) {
+		log.Printf("Spectator login for %q from %s rate limited", name, c.ClientIP())
+		return
+	}
+
+	spectator, passcodeHash, err := h.spectatorRepo.GetByName(ctx, name)
+	if err != nil {
+		log.Printf("Failed to get spectator: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in"})
+		return
+	}
+	if spectator == nil || bcrypt.CompareHashAndPassword([]byte(passcodeHash), []byte(req.Passcode)) != nil {
+		log.Printf("Spectator login failed for %q from %s", req.Name, c.ClientIP())
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Name oder Passcode ist falsch"})
+		return
+	}
+
+	token, err := h.jwtService.GenerateSpectatorToken(spectator.ID, spectator.Name)
+	if err != nil {
+		log.Printf("Failed to generate spectator token: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in"})
+		return
+	}
+
+	if err := h.spectatorRepo.UpdateLastLogin(ctx, spectator.ID); err != nil {
+		log.Printf("Failed to update spectator login: %v", err)
+	}
+
+	log.Printf("Spectator %s logged in from %s", spectator.Name, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":     token,
+		"spectator": spectator,
+	})
+}
+
+// GetSpectators returns all spectator accounts (admin only)
+// GET /api/v1/admin/spectators
+func (h *SpectatorHandler) GetSpectators(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	spectators, err := h.spectatorRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("Failed to get spectators: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get spectators",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"spectators": spectators,
+	})
+}
+
+// CreateSpectator creates a spectator account with a passcode to share (admin only)
+// POST /api/v1/admin/spectators
+func (h *SpectatorHandler) CreateSpectator(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	var req models.CreateSpectatorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" || utf8.RuneCountInString(name) > models.MaxSpectatorNameLength {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Name must be between 1 and %d characters", models.MaxSpectatorNameLength),
+		})
+		return
+	}
+	if utf8.RuneCountInString(req.Passcode) < models.MinSpectatorPasscode || len(req.Passcode) > 72 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Passcode must be between %d and 72 characters", models.MinSpectatorPasscode),
+		})
+		return
+	}
+
+	passcodeHash, err := bcrypt.GenerateFromPassword([]byte(req.Passcode), bcrypt.DefaultCost)
+	if err != nil {
+		log.Printf("Failed to hash spectator passcode: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create spectator"})
+		return
+	}
+
+	spectator := &models.Spectator{
+		Name:      name,
+		CreatedBy: claims.SteamID,
+	}
+	created, err := h.spectatorRepo.Create(ctx, spectator, string(passcodeHash))
+	if err != nil {
+		log.Printf("Failed to create spectator: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create spectator"})
+		return
+	}
+	if !created {
+		c.JSON(http.StatusConflict, gin.H{"error": "A spectator with this name already exists"})
+		return
+	}
+
+	log.Printf("Admin %s created spectator account %s", claims.SteamID, spectator.Name)
+
+	c.JSON(http.StatusCreated, spectator)
+}
+
+// DeleteSpectator deletes a spectator account, its sessions end immediately (admin only)
+// DELETE /api/v1/admin/spectators/:id
+func (h *SpectatorHandler) DeleteSpectator(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	claims, _ := middleware.GetClaims(c)
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid spectator ID"})
+		return
+	}
+
+	deleted, err := h.spectatorRepo.Delete(ctx, id)
+	if err != nil {
+		log.Printf("Failed to delete spectator %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete spectator"})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Spectator not found"})
+		return
+	}
+
+	log.Printf("Admin %s deleted spectator account %d", claims.SteamID, id)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Zuschauer-Zugang wurde gelöscht",
+	})
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+)
+
+const (
+	// sqlConsoleMaxRows is the maximum number of rows returned by the SQL console
+	sqlConsoleMaxRows = 1000
+	// sqlConsoleTimeout is the maximum execution time of a console query
+	sqlConsoleTimeout = 5 * time.Second
+	// sqlConsoleMaxQueryLength is the maximum accepted query length
+	sqlConsoleMaxQueryLength = 4000
+)
+
+// forbiddenSQLKeywords are rejected anywhere in a console query (as whole words)
+var forbiddenSQLKeywords = regexp.MustCompile(`(?i)\b(INSERT|UPDATE|DELETE|REPLACE|UPSERT|MERGE|DROP|ALTER|CREATE|TRUNCATE|RENAME|GRANT|REVOKE|ATTACH|DETACH|PRAGMA|VACUUM|REINDEX|ANALYZE|LOAD_FILE|OUTFILE|DUMPFILE|LOCK|UNLOCK|CALL|EXEC|EXECUTE|SET|HANDLER|SLEEP|BENCHMARK)\b`)
+
+// SQLConsoleHandler handles the read-only SQL console for admins
+// Every query is written to the admin audit trail, it can read the senders of secret votes
+type SQLConsoleHandler struct {
+	consoleRepo *repository.SQLConsoleRepository
+	auditRepo   *repository.AuditRepository
+}
+
+// NewSQLConsoleHandler creates a new SQL console handler
+func NewSQLConsoleHandler(consoleRepo *repository.SQLConsoleRepository, auditRepo *repository.AuditRepository) *SQLConsoleHandler {
+	return &SQLConsoleHandler{
+		consoleRepo: consoleRepo,
+		auditRepo:   auditRepo,
+	}
+}
+
+// SQLConsoleRequest is the request body for POST /admin/sql
+type SQLConsoleRequest struct {
+	Query  string `json:"query" binding:"required"`
+	Format string `json:"format"` // "json" (default) or "csv"
+}
+
+// validateReadOnlyQuery checks that the query is a single SELECT statement without write keywords
+func validateReadOnlyQuery(query string) (string, error) {
+	query = strings.TrimSpace(query)
+	query = strings.TrimSpace(strings.TrimSuffix(query, ";"))
+
+	if query == "" {
+		return "", fmt.Errorf("query is empty")
+	}
+	if len(query) > sqlConsoleMaxQueryLength {
+		return "", fmt.Errorf("query must be at most %d characters", sqlConsoleMaxQueryLength)
+	}
+	if strings.Contains(query, ";") {
+		return "", fmt.Errorf("only a single statement is allowed")
+	}
+	if strings.Contains(query, "--") || strings.Contains(query, "/*") || strings.Contains(query, "#") {
+		return "", fmt.Errorf("comments are not allowed")
+	}
+
+	upper := strings.ToUpper(query)
+	if !strings.HasPrefix(upper, "SELECT") && !strings.HasPrefix(upper, "WITH") {
+		return "", fmt.Errorf("only SELECT queries are allowed")
+	}
+	if keyword := forbiddenSQLKeywords.FindString(query); keyword != "" {
+		return "", fmt.Errorf("keyword %s is not allowed", strings.ToUpper(keyword))
+	}
+
+	return query, nil
+}
+
+// Execute runs a read-only query and returns the result as JSON or CSV
+// POST /api/v1/admin/sql
+func (h *SQLConsoleHandler) Execute(c *gin.Context) {
+	claims, _ := middleware.GetClaims(c)
+
+	var req SQLConsoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Query is required",
+		})
+		return
+	}
+
+	query, err := validateReadOnlyQuery(req.Query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Write the audit entry first - no audit trail, no query
+	details := fmt.Sprintf("Ran SQL console query from %s: %s", c.ClientIP(), query)
+	if err := h.auditRepo.Log(c.Request.Context(), claims.SteamID, models.AuditActionSQLQuery, details); err != nil {
+		log.Printf("Failed to write audit log for admin %s: %v", claims.SteamID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to write audit log",
+		})
+		return
+	}
+	log.Printf("Admin %s executed SQL console query: %s", claims.SteamID, query)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), sqlConsoleTimeout)
+	defer cancel()
+
+	result, err := h.consoleRepo.QueryReadOnly(ctx, query, sqlConsoleMaxRows)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if req.Format == "csv" {
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		_ = w.Write(result.Columns)
+		for _, row := range result.Rows {
+			record := make([]string, len(row))
+			for i, v := range row {
+				if v != nil {
+					record[i] = fmt.Sprint(v)
+				}
+			}
+			_ = w.Write(record)
+		}
+		w.Flush()
+
+		c.Header("Content-Disposition", "attachment; filename=query.csv")
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
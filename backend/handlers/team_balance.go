@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/services"
+)
+
+// TeamBalanceHandler handles splitting players into balanced teams
+type TeamBalanceHandler struct {
+	teamBalanceService *services.TeamBalanceService
+	gameService        *services.GameService
+	userRepo           *repository.UserRepository
+}
+
+// NewTeamBalanceHandler creates a new team balance handler
+func NewTeamBalanceHandler(teamBalanceService *services.TeamBalanceService, gameService *services.GameService, userRepo *repository.UserRepository) *TeamBalanceHandler {
+	return &TeamBalanceHandler{
+		teamBalanceService: teamBalanceService,
+		gameService:        gameService,
+		userRepo:           userRepo,
+	}
+}
+
+// BalanceTeams splits the selected players into teams of similar skill for a game of the multiplayer games list
+// POST /api/v1/games/:appid/teams
+func (h *TeamBalanceHandler) BalanceTeams(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	appID, err := strconv.Atoi(c.Param("appid"))
+	if err != nil || appID < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid app ID",
+		})
+		return
+	}
+	gameName, ok := multiplayerGameNames(ctx, h.gameService)[appID]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Game is not in the multiplayer games list",
+		})
+		return
+	}
+
+	var req models.TeamBalanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "user_ids is required",
+		})
+		return
+	}
+	if req.TeamCount == 0 {
+		req.TeamCount = models.MinTeamCount
+	}
+	if req.TeamCount < models.MinTeamCount || req.TeamCount > models.MaxTeamCount {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("team_count must be between %d and %d", models.MinTeamCount, models.MaxTeamCount),
+		})
+		return
+	}
+
+	// Duplicates are dropped, every team needs at least one player
+	var users []models.PublicUser
+	seen := make(map[uint64]bool)
+	for _, id := range req.UserIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		user, err := h.userRepo.GetByID(ctx, id)
+		if err != nil {
+			log.Printf("Failed to get user %d: %v", id, err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to balance teams",
+			})
+			return
+		}
+		if user == nil {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": fmt.Sprintf("User %d not found", id),
+			})
+			return
+		}
+		users = append(users, user.ToPublic())
+	}
+	if len(users) < req.TeamCount || len(users) > models.MaxTeamBalanceUsers {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Select between %d and %d players", req.TeamCount, models.MaxTeamBalanceUsers),
+		})
+		return
+	}
+
+	balance, err := h.teamBalanceService.Balance(ctx, appID, gameName, users, req.TeamCount)
+	if err != nil {
+		log.Printf("Failed to balance teams for game %d: %v", appID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to balance teams",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, balance)
+}
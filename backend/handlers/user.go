@@ -1,35 +1,73 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
 	"github.com/guided-traffic/rate-your-mate/backend/repository"
 	"github.com/guided-traffic/rate-your-mate/backend/services"
+	"github.com/guided-traffic/rate-your-mate/backend/websocket"
 )
 
 // UserHandler handles user-related endpoints
 type UserHandler struct {
 	userRepo           *repository.UserRepository
+	badgeRepo          *repository.BadgeRepository
 	avatarCacheService *services.AvatarCacheService
+	i18nService        *services.I18nService
+	showcaseService    *services.SteamShowcaseService
+	wsHub              *websocket.Hub
 }
 
 // NewUserHandler creates a new user handler
-func NewUserHandler(userRepo *repository.UserRepository, avatarCacheService *services.AvatarCacheService) *UserHandler {
+func NewUserHandler(userRepo *repository.UserRepository, badgeRepo *repository.BadgeRepository, avatarCacheService *services.AvatarCacheService, i18nService *services.I18nService, showcaseService *services.SteamShowcaseService, wsHub *websocket.Hub) *UserHandler {
 	return &UserHandler{
 		userRepo:           userRepo,
+		badgeRepo:          badgeRepo,
 		avatarCacheService: avatarCacheService,
+		i18nService:        i18nService,
+		showcaseService:    showcaseService,
+		wsHub:              wsHub,
 	}
 }
 
+// language picks the language of server texts for the current request
+// The preferred language of the user wins over the Accept-Language header
+func (h *UserHandler) language(c *gin.Context) string {
+	ctx := c.Request.Context()
+
+	if userID, ok := middleware.GetUserID(c); ok {
+		user, err := h.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			log.Printf("Failed to get language of user %d: %v", userID, err)
+		} else if user != nil && user.Language != "" && h.i18nService.IsSupported(user.Language) {
+			return user.Language
+		}
+	}
+	return h.i18nService.Negotiate(c.GetHeader("Accept-Language"))
+}
+
+// translateBadges translates the awarded badges of a user
+func (h *UserHandler) translateBadges(language string, badges []models.UserBadge) []models.UserBadge {
+	for i := range badges {
+		badges[i].Badge = h.i18nService.Badge(language, badges[i].Badge)
+	}
+	return badges
+}
+
 // GetAll returns all registered users
 // GET /api/v1/users
 func (h *UserHandler) GetAll(c *gin.Context) {
-	users, err := h.userRepo.GetAll()
+	ctx := c.Request.Context()
+
+	users, err := h.userRepo.GetAll(ctx)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to load users",
@@ -47,6 +85,8 @@ func (h *UserHandler) GetAll(c *gin.Context) {
 			"avatar_url":   user.AvatarURL,
 			"avatar_small": user.AvatarSmall,
 			"profile_url":  user.ProfileURL,
+			"country_code": user.CountryCode,
+			"flag":         models.CountryFlag(user.CountryCode),
 		}
 	}
 
@@ -58,6 +98,8 @@ func (h *UserHandler) GetAll(c *gin.Context) {
 // GetByID returns a single user by ID
 // GET /api/v1/users/:id
 func (h *UserHandler) GetByID(c *gin.Context) {
+	ctx := c.Request.Context()
+
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 64)
 	if err != nil {
@@ -67,7 +109,7 @@ func (h *UserHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userRepo.GetByID(id)
+	user, err := h.userRepo.GetByID(ctx, id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to load user",
@@ -82,6 +124,13 @@ func (h *UserHandler) GetByID(c *gin.Context) {
 		return
 	}
 
+	badges, err := h.badgeRepo.GetByUser(ctx, user.ID)
+	if err != nil {
+		log.Printf("Failed to get badges of user %d: %v", user.ID, err)
+		badges = []models.UserBadge{}
+	}
+	badges = h.translateBadges(h.language(c), badges)
+
 	c.JSON(http.StatusOK, gin.H{
 		"user": gin.H{
 			"id":           user.ID,
@@ -90,13 +139,94 @@ func (h *UserHandler) GetByID(c *gin.Context) {
 			"avatar_url":   user.AvatarURL,
 			"avatar_small": user.AvatarSmall,
 			"profile_url":  user.ProfileURL,
+			"country_code": user.CountryCode,
+			"flag":         models.CountryFlag(user.CountryCode),
+			"badges":       badges,
 		},
 	})
 }
 
+// GetBadges returns all meta-badges with the ones earned by a user
+// GET /api/v1/users/:id/badges
+func (h *UserHandler) GetBadges(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	badges, err := h.badgeRepo.GetByUser(ctx, id)
+	if err != nil {
+		log.Printf("Failed to get badges of user %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load badges",
+		})
+		return
+	}
+
+	language := h.language(c)
+	available := make([]models.Badge, len(models.Badges))
+	for i, badge := range models.Badges {
+		available[i] = h.i18nService.Badge(language, badge)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"badges":    h.translateBadges(language, badges),
+		"available": available,
+	})
+}
+
+// GetSteamAchievements returns a user's real Steam achievements in the pinned games
+// GET /api/v1/users/:id/steam-achievements
+func (h *UserHandler) GetSteamAchievements(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	user, err := h.userRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to get user %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load user",
+		})
+		return
+	}
+	if user == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "User not found",
+		})
+		return
+	}
+
+	showcase, err := h.showcaseService.GetShowcase(ctx, user.SteamID, h.language(c))
+	if err != nil {
+		log.Printf("Failed to get Steam achievements of user %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load Steam achievements",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"steam_achievements": showcase,
+	})
+}
+
 // GetOthers returns all users except the current user (for voting)
 // GET /api/v1/users/others
 func (h *UserHandler) GetOthers(c *gin.Context) {
+	ctx := c.Request.Context()
+
 	currentUserID, ok := middleware.GetUserID(c)
 	if !ok {
 		c.JSON(http.StatusUnauthorized, gin.H{
@@ -105,7 +235,7 @@ func (h *UserHandler) GetOthers(c *gin.Context) {
 		return
 	}
 
-	users, err := h.userRepo.GetAll()
+	users, err := h.userRepo.GetAll(ctx)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to load users",
@@ -124,6 +254,8 @@ func (h *UserHandler) GetOthers(c *gin.Context) {
 				"avatar_url":   user.AvatarURL,
 				"avatar_small": user.AvatarSmall,
 				"profile_url":  user.ProfileURL,
+				"country_code": user.CountryCode,
+				"flag":         models.CountryFlag(user.CountryCode),
 			})
 		}
 	}
@@ -133,6 +265,193 @@ func (h *UserHandler) GetOthers(c *gin.Context) {
 	})
 }
 
+// UpdatePrivacyRequest represents the request body for PUT /users/me/privacy
+type UpdatePrivacyRequest struct {
+	HideFromRanking *bool `json:"hide_from_ranking" binding:"required"`
+}
+
+// UpdatePrivacy lets the current user opt out of (or back into) the public ranking
+// Hidden users still receive votes and can still see their own rank via /ranking/me
+// PUT /api/v1/users/me/privacy
+func (h *UserHandler) UpdatePrivacy(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Not authenticated",
+		})
+		return
+	}
+
+	var req UpdatePrivacyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	if err := h.userRepo.UpdateHideFromRanking(ctx, userID, *req.HideFromRanking); err != nil {
+		log.Printf("Failed to update privacy for user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update privacy settings",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"hide_from_ranking": *req.HideFromRanking,
+	})
+}
+
+// UpdateTimezoneRequest represents the request body for PUT /users/me/timezone
+type UpdateTimezoneRequest struct {
+	Timezone *string `json:"timezone" binding:"required"` // IANA timezone name, empty string = event timezone
+}
+
+// UpdateTimezone sets the current user's preferred timezone
+// PUT /api/v1/users/me/timezone
+func (h *UserHandler) UpdateTimezone(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Not authenticated",
+		})
+		return
+	}
+
+	var req UpdateTimezoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	timezone := strings.TrimSpace(*req.Timezone)
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil || timezone == "Local" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "timezone must be a valid IANA timezone name (e.g. Europe/Berlin)",
+			})
+			return
+		}
+	}
+
+	if err := h.userRepo.UpdateTimezone(ctx, userID, timezone); err != nil {
+		log.Printf("Failed to update timezone for user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update timezone",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"timezone": timezone,
+	})
+}
+
+// UpdateAccessibilityRequest represents the request body for PUT /users/me/accessibility
+type UpdateAccessibilityRequest struct {
+	ReducedMotion *bool `json:"reduced_motion" binding:"required"` // Effects without confetti, flashing and sounds
+}
+
+// UpdateAccessibility sets the current user's accessibility preferences for live effects
+// PUT /api/v1/users/me/accessibility
+func (h *UserHandler) UpdateAccessibility(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Not authenticated",
+		})
+		return
+	}
+
+	var req UpdateAccessibilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	if err := h.userRepo.UpdateReducedMotion(ctx, userID, *req.ReducedMotion); err != nil {
+		log.Printf("Failed to update accessibility preferences for user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update accessibility preferences",
+		})
+		return
+	}
+
+	// Following effects are sent with the new preference
+	h.wsHub.SetReducedMotion(userID, *req.ReducedMotion)
+
+	c.JSON(http.StatusOK, gin.H{
+		"reduced_motion": *req.ReducedMotion,
+	})
+}
+
+// UpdateLanguageRequest represents the request body for PUT /users/me/language
+type UpdateLanguageRequest struct {
+	Language *string `json:"language" binding:"required"` // Language code like "en", empty string = Accept-Language header
+}
+
+// UpdateLanguage sets the current user's preferred language of server texts
+// PUT /api/v1/users/me/language
+func (h *UserHandler) UpdateLanguage(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Not authenticated",
+		})
+		return
+	}
+
+	var req UpdateLanguageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+
+	language := strings.ToLower(strings.TrimSpace(*req.Language))
+	if language != "" && !h.i18nService.IsSupported(language) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "language must be one of " + strings.Join(h.i18nService.Languages(), ", "),
+		})
+		return
+	}
+
+	if err := h.userRepo.UpdateLanguage(ctx, userID, language); err != nil {
+		log.Printf("Failed to update language for user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update language",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"language": language,
+	})
+}
+
+// GetLanguages returns the languages server texts are available in
+// GET /api/v1/languages
+func (h *UserHandler) GetLanguages(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"languages": h.i18nService.Languages(),
+		"default":   services.SourceLanguage,
+	})
+}
+
 // ServeAvatar serves a cached avatar image
 // GET /api/v1/avatars/:filename
 func (h *UserHandler) ServeAvatar(c *gin.Context) {
@@ -1,12 +1,21 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"math/rand/v2"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/metrics"
 	"github.com/guided-traffic/rate-your-mate/backend/middleware"
 	"github.com/guided-traffic/rate-your-mate/backend/models"
 	"github.com/guided-traffic/rate-your-mate/backend/repository"
@@ -16,34 +25,53 @@ import (
 
 // VoteHandler handles vote-related endpoints
 type VoteHandler struct {
-	voteRepo      *repository.VoteRepository
-	userRepo      *repository.UserRepository
-	creditService *services.CreditService
-	wsHub         *websocket.Hub
-	cfg           *config.Config
+	voteRepo         *repository.VoteRepository
+	userRepo         *repository.UserRepository
+	muteRepo         *repository.MuteRepository
+	notificationRepo *repository.NotificationRepository
+	creditService    *services.CreditService
+	badgeService     *services.BadgeService
+	wsHub            *websocket.Hub
+	cfg              *config.Config
+
+	// Recently requested historical rankings (GET /ranking?at=), keyed by the minute
+	rankingAtCache map[int64]*rankingSnapshot
+	rankingAtMutex sync.Mutex
+}
+
+// Limits of the historical ranking cache
+const (
+	rankingAtCacheSize = 20              // Cached timestamps
+	rankingAtCacheTTL  = 5 * time.Minute // Invalidations change past standings, too
+)
+
+// rankingSnapshot is a cached historical ranking
+type rankingSnapshot struct {
+	rankings   []repository.PlayerRanking
+	totalVotes int
+	expiresAt  time.Time
 }
 
 // NewVoteHandler creates a new vote handler
-func NewVoteHandler(voteRepo *repository.VoteRepository, userRepo *repository.UserRepository, creditService *services.CreditService, wsHub *websocket.Hub, cfg *config.Config) *VoteHandler {
+func NewVoteHandler(voteRepo *repository.VoteRepository, userRepo *repository.UserRepository, muteRepo *repository.MuteRepository, notificationRepo *repository.NotificationRepository, creditService *services.CreditService, badgeService *services.BadgeService, wsHub *websocket.Hub, cfg *config.Config) *VoteHandler {
 	return &VoteHandler{
-		voteRepo:      voteRepo,
-		userRepo:      userRepo,
-		creditService: creditService,
-		wsHub:         wsHub,
-		cfg:           cfg,
+		voteRepo:         voteRepo,
+		userRepo:         userRepo,
+		muteRepo:         muteRepo,
+		notificationRepo: notificationRepo,
+		creditService:    creditService,
+		badgeService:     badgeService,
+		wsHub:            wsHub,
+		cfg:              cfg,
+
+		rankingAtCache: make(map[int64]*rankingSnapshot),
 	}
 }
 
 // Create creates a new vote
 // POST /api/v1/votes
 func (h *VoteHandler) Create(c *gin.Context) {
-	// Check if voting is paused
-	if h.cfg.VotingPaused {
-		c.JSON(http.StatusForbidden, gin.H{
-			"error": "Voting is currently paused by admin",
-		})
-		return
-	}
+	ctx := c.Request.Context()
 
 	// Get current user
 	fromUserID, ok := middleware.GetUserID(c)
@@ -63,21 +91,94 @@ func (h *VoteHandler) Create(c *gin.Context) {
 		return
 	}
 
+	voteDetails, credits, verr := h.castVote(ctx, fromUserID, req)
+	if verr != nil {
+		c.JSON(verr.status, verr.body)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"vote":    voteDetails,
+		"credits": credits,
+	})
+}
+
+// voteError describes why a vote could not be cast
+type voteError struct {
+	status int
+	body   gin.H
+}
+
+// castVote validates and stores a vote from the given user, handles credits and broadcasts it
+// Returns the created vote and the sender's remaining credits
+func (h *VoteHandler) castVote(ctx context.Context, fromUserID uint64, req models.CreateVoteRequest) (*models.VoteWithDetails, int, *voteError) {
+	// Check if voting is paused
+	if h.cfg.VotingPaused {
+		return nil, 0, &voteError{http.StatusForbidden, gin.H{"error": "Voting is currently paused by admin"}}
+	}
+
+	// Admins can extend a chat mute to voting
+	mute, err := h.muteRepo.GetActive(ctx, fromUserID)
+	if err != nil {
+		log.Printf("Failed to check mute of user %d: %v", fromUserID, err)
+		return nil, 0, &voteError{http.StatusInternalServerError, gin.H{"error": "Failed to process vote"}}
+	}
+	if mute != nil && mute.IncludeVotes {
+		return nil, 0, &voteError{http.StatusForbidden, mutedError(mute)}
+	}
+
 	// Validate achievement early to check if negative voting is disabled
 	if !models.IsValidAchievement(req.AchievementID) {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid achievement ID",
-		})
-		return
+		return nil, 0, &voteError{http.StatusBadRequest, gin.H{"error": "Invalid achievement ID"}}
 	}
 
-	// Check if negative voting is disabled
 	achievement, _ := models.GetAchievement(req.AchievementID)
+	if achievement.IsDisabled {
+		return nil, 0, &voteError{http.StatusBadRequest, gin.H{"error": "Achievement is disabled"}}
+	}
+
+	// Seasonal achievements can only be voted inside their validity window
+	now := time.Now()
+	if !achievement.HasStartedAt(now) {
+		return nil, 0, &voteError{http.StatusBadRequest, gin.H{"error": "Achievement is not available yet"}}
+	}
+	if achievement.IsArchivedAt(now) {
+		return nil, 0, &voteError{http.StatusBadRequest, gin.H{"error": "Achievement is archived"}}
+	}
+
+	// Game-scoped achievements can only be voted in the context of their game
+	if req.AppID != nil && *req.AppID <= 0 {
+		return nil, 0, &voteError{http.StatusBadRequest, gin.H{"error": "Invalid app ID"}}
+	}
+	appID := 0
+	if req.AppID != nil {
+		appID = *req.AppID
+	}
+	if !achievement.IsAvailableForGame(appID) {
+		return nil, 0, &voteError{http.StatusBadRequest, gin.H{"error": "Achievement can only be voted in the context of its game"}}
+	}
+
+	// Check if negative voting is disabled
 	if h.cfg.NegativeVotingDisabled && !achievement.IsPositive {
-		c.JSON(http.StatusForbidden, gin.H{
-			"error": "Negative voting is currently disabled by admin",
-		})
-		return
+		return nil, 0, &voteError{http.StatusForbidden, gin.H{"error": "Negative voting is currently disabled by admin"}}
+	}
+
+	// Check the daily limit for this achievement
+	if limit, ok := h.cfg.AchievementDailyLimits[req.AchievementID]; ok {
+		dayStart, resetsAt := h.dailyLimitWindow()
+
+		count, err := h.voteRepo.CountByVoterSince(ctx, fromUserID, req.AchievementID, dayStart)
+		if err != nil {
+			log.Printf("Failed to check daily vote limit: %v", err)
+			return nil, 0, &voteError{http.StatusInternalServerError, gin.H{"error": "Failed to process vote"}}
+		}
+		if count >= limit {
+			return nil, 0, &voteError{http.StatusTooManyRequests, gin.H{
+				"error":     fmt.Sprintf("Daily limit of %d %q votes reached, resets at %s", limit, achievement.Name, resetsAt.Format("15:04")),
+				"limit":     limit,
+				"resets_at": resetsAt.Format(time.RFC3339),
+			}}
+		}
 	}
 
 	// Default to 1 point if not specified
@@ -88,77 +189,64 @@ func (h *VoteHandler) Create(c *gin.Context) {
 
 	// Validate points (1-3)
 	if points < 1 || points > 3 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Points must be between 1 and 3",
-		})
-		return
+		return nil, 0, &voteError{http.StatusBadRequest, gin.H{"error": "Points must be between 1 and 3"}}
+	}
+
+	// Validate comment length (max 160 characters)
+	var comment *string
+	if req.Comment != nil && len(*req.Comment) > 0 {
+		if len(*req.Comment) > 160 {
+			return nil, 0, &voteError{http.StatusBadRequest, gin.H{"error": "Comment must be at most 160 characters"}}
+		}
+		comment = req.Comment
 	}
 
 	// Can't vote for yourself
 	if fromUserID == req.ToUserID {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Cannot vote for yourself",
-		})
-		return
+		return nil, 0, &voteError{http.StatusBadRequest, gin.H{"error": "Cannot vote for yourself"}}
 	}
 
 	// Check if target user exists
-	toUser, err := h.userRepo.GetByID(req.ToUserID)
+	toUser, err := h.userRepo.GetByID(ctx, req.ToUserID)
 	if err != nil {
 		log.Printf("Failed to check target user: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to process vote",
-		})
-		return
+		return nil, 0, &voteError{http.StatusInternalServerError, gin.H{"error": "Failed to process vote"}}
 	}
-	if toUser == nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Target user not found",
-		})
-		return
+	// Accounts waiting for an admin review are hidden from other players
+	if toUser == nil || toUser.PendingReview {
+		return nil, 0, &voteError{http.StatusBadRequest, gin.H{"error": "Target user not found"}}
 	}
 
 	// Check and update credits for current user
-	fromUser, err := h.userRepo.GetByID(fromUserID)
+	fromUser, err := h.userRepo.GetByID(ctx, fromUserID)
 	if err != nil {
 		log.Printf("Failed to load current user: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to process vote",
-		})
-		return
+		return nil, 0, &voteError{http.StatusInternalServerError, gin.H{"error": "Failed to process vote"}}
+	}
+	// Users removed by an admin keep their token until it expires
+	if fromUser == nil {
+		return nil, 0, &voteError{http.StatusUnauthorized, gin.H{"error": "User not found"}}
 	}
 
 	// Calculate current credits
-	_, err = h.creditService.CalculateAndUpdateCredits(fromUser)
+	credits, err := h.creditService.CalculateAndUpdateCredits(ctx, fromUser)
 	if err != nil {
 		log.Printf("Failed to calculate credits: %v", err)
 	}
+	fromUser.Credits = credits
 
-	// Reload user to get updated credits
-	fromUser, _ = h.userRepo.GetByID(fromUserID)
+	// Repeated negative votes on the same target may cost more than the points
+	cost := points * h.creditService.VoteCostMultiplier(fromUserID, req.ToUserID, achievement)
 
 	// Check if user has enough credits for the requested points
-	if !h.creditService.CanAffordVoteWithPoints(fromUser, points) {
-		c.JSON(http.StatusPaymentRequired, gin.H{
-			"error":   "Insufficient credits",
-			"credits": fromUser.Credits,
-		})
-		return
-	}
-
-	// Deduct credits based on points
-	if err := h.creditService.DeductVoteCostWithPoints(fromUserID, points); err != nil {
-		log.Printf("Failed to deduct credits: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to process vote",
-		})
-		return
+	if !h.creditService.CanAffordVoteWithPoints(fromUser, cost) {
+		return nil, 0, &voteError{http.StatusPaymentRequired, gin.H{"error": "Insufficient credits", "credits": credits, "cost": cost}}
 	}
 
 	// Get the current king before creating votes (only for positive achievements)
 	var previousKingID uint64
 	if achievement.IsPositive {
-		champsBefore, _ := h.voteRepo.GetChampions()
+		champsBefore, _ := h.voteRepo.GetChampions(ctx, h.cfg.RankingTieBreakers)
 		if champsBefore != nil && champsBefore.King != nil {
 			previousKingID = champsBefore.King.User.ID
 		}
@@ -172,18 +260,6 @@ func (h *VoteHandler) Create(c *gin.Context) {
 		isSecret = *req.IsSecret
 	}
 
-	// Validate comment length (max 160 characters)
-	var comment *string
-	if req.Comment != nil && len(*req.Comment) > 0 {
-		if len(*req.Comment) > 160 {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Comment must be at most 160 characters",
-			})
-			return
-		}
-		comment = req.Comment
-	}
-
 	// Create a single vote with points value
 	vote := &models.Vote{
 		FromUserID:    fromUserID,
@@ -192,69 +268,42 @@ func (h *VoteHandler) Create(c *gin.Context) {
 		Points:        points,
 		IsSecret:      isSecret,
 		Comment:       comment,
+		AppID:         req.AppID,
 	}
 
-	if err := h.voteRepo.Create(vote); err != nil {
+	// Credits are deducted in the same transaction, a failed vote never costs credits
+	// The 402 response reports the credits the transaction saw, a concurrent vote may have spent some
+	credits, err = h.voteRepo.CreateWithCost(ctx, vote, cost)
+	if errors.Is(err, repository.ErrInsufficientCredits) {
+		return nil, 0, &voteError{http.StatusPaymentRequired, gin.H{"error": "Insufficient credits", "credits": credits, "cost": cost}}
+	}
+	if err != nil {
 		log.Printf("Failed to create vote: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to create vote",
-		})
-		return
+		return nil, 0, &voteError{http.StatusInternalServerError, gin.H{"error": "Failed to create vote"}}
 	}
+	h.creditService.RecordVote(fromUserID, req.ToUserID, achievement)
+	metrics.VotesCast.Inc()
 
 	// Get full vote details for response
-	voteDetails, err := h.voteRepo.GetByID(vote.ID)
+	voteDetails, err := h.voteRepo.GetByID(ctx, vote.ID)
 	if err != nil {
 		log.Printf("Failed to get vote details: %v", err)
 	}
 
 	// Broadcast vote to all WebSocket clients (once, with points info)
 	if voteDetails != nil && h.wsHub != nil {
-		achievement, _ := models.GetAchievement(voteDetails.AchievementID)
-
-		// Determine if sender should be anonymized based on visibility mode
-		shouldAnonymize := false
-		switch h.cfg.VoteVisibilityMode {
-		case "all_secret":
-			shouldAnonymize = true
-		case "all_public":
-			shouldAnonymize = false
-		default: // "user_choice"
-			shouldAnonymize = isSecret
-		}
-
-		// Prepare payload - anonymize sender if needed
-		fromUserID := voteDetails.FromUser.ID
-		fromUsername := voteDetails.FromUser.Username
-		fromAvatar := voteDetails.FromUser.AvatarSmall
-		if shouldAnonymize {
-			fromUserID = 0
-			fromUsername = "Anonym"
-			fromAvatar = ""
-		}
+		// Sender is anonymized based on the visibility mode
+		payload := newVotePayload(voteDetails, h.cfg.VoteVisibilityMode)
 
-		payload := &websocket.VotePayload{
-			VoteID:        voteDetails.ID,
-			FromUserID:    fromUserID,
-			FromUsername:  fromUsername,
-			FromAvatar:    fromAvatar,
-			ToUserID:      voteDetails.ToUser.ID,
-			ToUsername:    voteDetails.ToUser.Username,
-			ToAvatar:      voteDetails.ToUser.AvatarSmall,
-			AchievementID: voteDetails.AchievementID,
-			Achievement:   achievement.Name,
-			IsPositive:    achievement.IsPositive,
-			IsSecret:      shouldAnonymize,
-			CreatedAt:     voteDetails.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-			Points:        points,
-		}
-
-		// Broadcast to all clients - frontend decides who shows notification popup
+		// Broadcast to all clients for the live feed and ranking
 		h.wsHub.BroadcastVote(payload)
 
+		// The recipient gets a personal notification, stored in case they are offline
+		h.notifyVoteReceived(ctx, payload)
+
 		// Check if the king has changed (only for positive achievements)
 		if achievement.IsPositive {
-			champsAfter, _ := h.voteRepo.GetChampions()
+			champsAfter, _ := h.voteRepo.GetChampions(ctx, h.cfg.RankingTieBreakers)
 			if champsAfter != nil && champsAfter.King != nil {
 				newKingID := champsAfter.King.User.ID
 				// If king changed, broadcast the new king notification
@@ -269,19 +318,130 @@ func (h *VoteHandler) Create(c *gin.Context) {
 		}
 	}
 
-	// Return updated credits
-	fromUser, _ = h.userRepo.GetByID(fromUserID)
+	// Check if the target is on a streak (only for positive achievements)
+	if achievement.IsPositive {
+		h.evaluateStreak(ctx, vote, toUser)
+	}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"vote":    voteDetails,
-		"credits": fromUser.Credits,
-	})
+	// Sender and target may have reached a badge threshold
+	h.badgeService.Evaluate(ctx, fromUserID, toUser.ID)
+
+	// Credits left after the vote, as seen by its transaction
+	return voteDetails, credits, nil
+}
+
+// notifyVoteReceived stores the vote_received notification of the recipient and sends it to their connections
+// Recipients who are offline load the unread notification when they connect again
+// Only the vote ID is stored, the sender is shown as the visibility mode allows when the notification is read
+func (h *VoteHandler) notifyVoteReceived(ctx context.Context, vote *websocket.VotePayload) {
+	payload := *vote
+
+	notification := &models.Notification{
+		UserID: payload.ToUserID,
+		Type:   models.NotificationTypeVoteReceived,
+		VoteID: payload.VoteID,
+	}
+	if err := h.notificationRepo.Create(ctx, notification); err != nil {
+		// Still notify the connected clients, only the offline queue is lost
+		log.Printf("Failed to store vote notification for user %d: %v", payload.ToUserID, err)
+	} else {
+		payload.NotificationID = notification.ID
+	}
+
+	h.wsHub.NotifyVoteReceived(payload.ToUserID, &payload)
+}
+
+// newVotePayload builds the WebSocket payload of a vote, the sender is anonymized as the visibility mode requires
+func newVotePayload(vote *models.VoteWithDetails, visibilityMode string) *websocket.VotePayload {
+	shown := *vote
+	shown.ApplyVisibilityMode(visibilityMode)
+
+	achievement, _ := models.GetAchievement(shown.AchievementID)
+	return &websocket.VotePayload{
+		VoteID:        shown.ID,
+		FromUserID:    shown.FromUser.ID,
+		FromUsername:  shown.FromUser.Username,
+		FromAvatar:    shown.FromUser.AvatarSmall,
+		ToUserID:      shown.ToUser.ID,
+		ToUsername:    shown.ToUser.Username,
+		ToAvatar:      shown.ToUser.AvatarSmall,
+		AchievementID: shown.AchievementID,
+		Achievement:   achievement.Name,
+		IsPositive:    achievement.IsPositive,
+		IsSecret:      shown.FromUser.ID == 0,
+		CreatedAt:     shown.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Points:        shown.Points,
+	}
+}
+
+// dailyLimitWindow returns the start of the current day and the time the daily limits reset
+// Days start at midnight in the event timezone
+func (h *VoteHandler) dailyLimitWindow() (time.Time, time.Time) {
+	now := time.Now().In(h.cfg.EventLocation)
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return dayStart, dayStart.AddDate(0, 0, 1)
+}
+
+// evaluateStreak checks whether the vote completes a streak: the same positive achievement
+// from StreakThreshold different users within StreakWindowMinutes. The target gets bonus
+// credits and an "on fire" broadcast is sent once the threshold is crossed.
+func (h *VoteHandler) evaluateStreak(ctx context.Context, vote *models.Vote, toUser *models.User) {
+	if h.cfg.StreakThreshold < 2 || h.cfg.StreakWindowMinutes <= 0 {
+		return
+	}
+
+	since := time.Now().Add(-time.Duration(h.cfg.StreakWindowMinutes) * time.Minute)
+
+	before, err := h.voteRepo.CountDistinctVotersSince(ctx, vote.ToUserID, vote.AchievementID, since, vote.ID)
+	if err != nil {
+		log.Printf("Failed to evaluate streak: %v", err)
+		return
+	}
+	after, err := h.voteRepo.CountDistinctVotersSince(ctx, vote.ToUserID, vote.AchievementID, since, 0)
+	if err != nil {
+		log.Printf("Failed to evaluate streak: %v", err)
+		return
+	}
+
+	// Only trigger when this vote crosses the threshold
+	if before >= h.cfg.StreakThreshold || after < h.cfg.StreakThreshold {
+		return
+	}
+
+	if h.cfg.StreakBonusCredits > 0 {
+		if err := h.creditService.GrantBonusCredits(ctx, toUser.ID, h.cfg.StreakBonusCredits); err != nil {
+			log.Printf("Failed to grant streak bonus to user %d: %v", toUser.ID, err)
+		}
+	}
+
+	log.Printf("User %s is on fire: %d voters for %s within %d minutes", toUser.Username, after, vote.AchievementID, h.cfg.StreakWindowMinutes)
+
+	if h.wsHub != nil {
+		achievement, _ := models.GetAchievement(vote.AchievementID)
+		h.wsHub.BroadcastOnFire(&websocket.OnFirePayload{
+			UserID:          toUser.ID,
+			Username:        toUser.Username,
+			Avatar:          toUser.AvatarSmall,
+			AchievementID:   vote.AchievementID,
+			AchievementName: achievement.Name,
+			VoterCount:      after,
+			WindowMinutes:   h.cfg.StreakWindowMinutes,
+			BonusCredits:    h.cfg.StreakBonusCredits,
+		})
+	}
 }
 
-// GetTimeline returns recent votes for the timeline
-// GET /api/v1/votes
+// GetTimeline returns recent votes for the timeline, older ones are loaded with the next_cursor of the previous page
+// GET /api/v1/votes?limit=100&cursor=xxx
 func (h *VoteHandler) GetTimeline(c *gin.Context) {
-	votes, err := h.voteRepo.GetRecent(100)
+	ctx := c.Request.Context()
+
+	page, ok := parsePage(c, 100)
+	if !ok {
+		return
+	}
+
+	votes, err := h.voteRepo.GetRecent(ctx, page)
 	if err != nil {
 		log.Printf("Failed to get timeline: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -300,14 +460,361 @@ func (h *VoteHandler) GetTimeline(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"votes": votes,
+		"votes":       votes,
+		"next_cursor": repository.NextCursor(page, votes, voteCursorID),
+	})
+}
+
+// voteCursorID returns the ID of a vote for the cursors of vote listings
+func voteCursorID(v models.VoteWithDetails) uint64 {
+	return v.ID
+}
+
+// GetMine returns the votes cast by the current user, including secret ones
+// Supports pagination via ?limit= (default 50) and ?cursor= (next_cursor of the previous page)
+// GET /api/v1/votes/mine
+func (h *VoteHandler) GetMine(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Not authenticated",
+		})
+		return
+	}
+
+	page, ok := parsePage(c, 50)
+	if !ok {
+		return
+	}
+
+	votes, err := h.voteRepo.GetGivenByUser(ctx, userID, page)
+	if err != nil {
+		log.Printf("Failed to get votes given by user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load votes",
+		})
+		return
+	}
+
+	total, err := h.voteRepo.CountGivenByUser(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to count votes given by user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load votes",
+		})
+		return
+	}
+
+	if votes == nil {
+		votes = []models.VoteWithDetails{}
+	}
+
+	// No visibility mode here - the user is the author of all these votes
+	c.JSON(http.StatusOK, gin.H{
+		"votes":       votes,
+		"total":       total,
+		"limit":       page.Limit,
+		"next_cursor": repository.NextCursor(page, votes, voteCursorID),
 	})
 }
 
+// votePromptRecentWindow is how long a (user, achievement) pair is not suggested again after voting for it
+const votePromptRecentWindow = 6 * time.Hour
+
+// VotePrompt is a suggested vote for the requesting user
+type VotePrompt struct {
+	User          models.PublicUser  `json:"user"`
+	Achievement   models.Achievement `json:"achievement"`
+	VotesReceived int                `json:"votes_received"`
+}
+
+// GetPrompt suggests a random (user, achievement) pair the requester has not voted for recently
+// Players with fewer received votes are more likely to be suggested
+// GET /api/v1/votes/prompt
+func (h *VoteHandler) GetPrompt(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Not authenticated",
+		})
+		return
+	}
+
+	users, err := h.userRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("Failed to get users for vote prompt: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load vote prompt",
+		})
+		return
+	}
+
+	voted, err := h.voteRepo.GetVotedPairsSince(ctx, userID, time.Now().Add(-votePromptRecentWindow))
+	if err != nil {
+		log.Printf("Failed to get recent votes for vote prompt: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load vote prompt",
+		})
+		return
+	}
+
+	counts, err := h.voteRepo.GetReceivedVoteCounts(ctx)
+	if err != nil {
+		log.Printf("Failed to get vote counts for vote prompt: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load vote prompt",
+		})
+		return
+	}
+
+	// Sort achievements so the result only depends on the random source
+	// Game-scoped achievements need a game context and are left out,
+	// negative achievements too while negative voting is disabled
+	enabled := models.GetEnabledAchievements()
+	achievementIDs := make([]string, 0, len(enabled))
+	for _, a := range enabled {
+		if a.AppID != nil {
+			continue
+		}
+		if h.cfg.NegativeVotingDisabled && !a.IsPositive {
+			continue
+		}
+		achievementIDs = append(achievementIDs, a.ID)
+	}
+	sort.Strings(achievementIDs)
+
+	type candidate struct {
+		user         models.User
+		achievements []string
+		weight       float64
+	}
+
+	var candidates []candidate
+	var totalWeight float64
+	for _, u := range users {
+		if u.ID == userID {
+			continue
+		}
+
+		var open []string
+		for _, id := range achievementIDs {
+			if !voted[repository.VotePair{ToUserID: u.ID, AchievementID: id}] {
+				open = append(open, id)
+			}
+		}
+		if len(open) == 0 {
+			continue
+		}
+
+		// Under-voted players get a higher weight
+		weight := 1.0 / float64(counts[u.ID]+1)
+		candidates = append(candidates, candidate{user: u, achievements: open, weight: weight})
+		totalWeight += weight
+	}
+
+	if len(candidates) == 0 {
+		c.JSON(http.StatusOK, gin.H{
+			"prompt": nil,
+		})
+		return
+	}
+
+	// Weighted random pick of the player, uniform pick of the achievement
+	picked := candidates[len(candidates)-1]
+	r := rand.Float64() * totalWeight
+	for _, cand := range candidates {
+		if r < cand.weight {
+			picked = cand
+			break
+		}
+		r -= cand.weight
+	}
+	achievement, _ := models.GetAchievement(picked.achievements[rand.IntN(len(picked.achievements))])
+
+	c.JSON(http.StatusOK, gin.H{
+		"prompt": VotePrompt{
+			User:          picked.user.ToPublic(),
+			Achievement:   achievement,
+			VotesReceived: counts[picked.user.ID],
+		},
+	})
+}
+
+// Reasons why an achievement can't be voted right now
+const (
+	ConstraintDisabled         = "disabled"
+	ConstraintNotStarted       = "not_started"
+	ConstraintArchived         = "archived"
+	ConstraintNegativeDisabled = "negative_disabled"
+	ConstraintDailyLimit       = "daily_limit"
+)
+
+// VoteConstraints tells the voting UI which votes would currently be rejected
+type VoteConstraints struct {
+	VotingPaused           bool                    `json:"voting_paused"`
+	NegativeVotingDisabled bool                    `json:"negative_voting_disabled"`
+	Credits                int                     `json:"credits"`
+	MaxPoints              int                     `json:"max_points"`                // Highest affordable points of a regular vote, 0 = no vote affordable
+	SecondsUntilNextCredit int                     `json:"seconds_until_next_credit"` // -1 while voting is paused
+	Achievements           []AchievementConstraint `json:"achievements"`              // Only achievements that can't be voted
+	Targets                []TargetConstraint      `json:"targets"`                   // Only target and achievement combinations that cost extra
+}
+
+// AchievementConstraint describes why an achievement can't be voted right now
+type AchievementConstraint struct {
+	AchievementID string     `json:"achievement_id"`
+	Reason        string     `json:"reason"`
+	Limit         int        `json:"limit,omitempty"`        // Daily limit
+	AvailableAt   *time.Time `json:"available_at,omitempty"` // When the achievement can be voted again, nil if unknown
+}
+
+// TargetConstraint is a target and achievement combination on cooldown after repeated negative votes
+type TargetConstraint struct {
+	UserID         uint64    `json:"user_id"`
+	AchievementID  string    `json:"achievement_id"`
+	CostMultiplier int       `json:"cost_multiplier"`
+	MaxPoints      int       `json:"max_points"` // 0 = unaffordable
+	ResetsAt       time.Time `json:"resets_at"`  // The cost is back to normal after this time
+}
+
+// GetConstraints returns which achievements and targets the current user can't vote right now
+// The checks match the ones of POST /api/v1/votes, so the UI can grey out options before submit
+// GET /api/v1/votes/constraints
+func (h *VoteHandler) GetConstraints(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Not authenticated",
+		})
+		return
+	}
+
+	user, err := h.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to load user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load vote constraints",
+		})
+		return
+	}
+	if user == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "User not found",
+		})
+		return
+	}
+
+	credits, err := h.creditService.CalculateAndUpdateCredits(ctx, user)
+	if err != nil {
+		log.Printf("Failed to update credits for user %d: %v", user.ID, err)
+		credits = user.Credits
+	}
+
+	secondsUntilNext := int(h.creditService.GetTimeUntilNextCredit(user).Seconds())
+	if h.cfg.VotingPaused {
+		secondsUntilNext = -1
+	}
+
+	dayStart, resetsAt := h.dailyLimitWindow()
+	counts := map[string]int{}
+	if len(h.cfg.AchievementDailyLimits) > 0 {
+		counts, err = h.voteRepo.CountByVoterPerAchievementSince(ctx, userID, dayStart)
+		if err != nil {
+			log.Printf("Failed to count votes of user %d: %v", userID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to load vote constraints",
+			})
+			return
+		}
+	}
+
+	now := time.Now()
+	achievements := []AchievementConstraint{}
+	for _, a := range models.GetAllAchievements() {
+		constraint := AchievementConstraint{AchievementID: a.ID}
+		limit, hasLimit := h.cfg.AchievementDailyLimits[a.ID]
+
+		switch {
+		case a.IsDisabled:
+			constraint.Reason = ConstraintDisabled
+		case !a.HasStartedAt(now):
+			constraint.Reason = ConstraintNotStarted
+			constraint.AvailableAt = a.ValidFrom
+		case a.IsArchivedAt(now):
+			constraint.Reason = ConstraintArchived
+		case h.cfg.NegativeVotingDisabled && !a.IsPositive:
+			constraint.Reason = ConstraintNegativeDisabled
+		case hasLimit && counts[a.ID] >= limit:
+			constraint.Reason = ConstraintDailyLimit
+			constraint.Limit = limit
+			constraint.AvailableAt = &resetsAt
+		default:
+			continue
+		}
+		achievements = append(achievements, constraint)
+	}
+
+	targets := []TargetConstraint{}
+	for _, e := range h.creditService.RepeatVoteEscalations(userID) {
+		targets = append(targets, TargetConstraint{
+			UserID:         e.ToUserID,
+			AchievementID:  e.AchievementID,
+			CostMultiplier: e.Multiplier,
+			MaxPoints:      maxAffordablePoints(credits, e.Multiplier),
+			ResetsAt:       e.ResetsAt,
+		})
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].UserID != targets[j].UserID {
+			return targets[i].UserID < targets[j].UserID
+		}
+		return targets[i].AchievementID < targets[j].AchievementID
+	})
+
+	c.JSON(http.StatusOK, VoteConstraints{
+		VotingPaused:           h.cfg.VotingPaused,
+		NegativeVotingDisabled: h.cfg.NegativeVotingDisabled,
+		Credits:                credits,
+		MaxPoints:              maxAffordablePoints(credits, 1),
+		SecondsUntilNextCredit: secondsUntilNext,
+		Achievements:           achievements,
+		Targets:                targets,
+	})
+}
+
+// maxAffordablePoints returns the highest points (1-3) a vote can have with the given credits and cost multiplier
+func maxAffordablePoints(credits, multiplier int) int {
+	return min(credits/multiplier, 3)
+}
+
 // GetLeaderboard returns the leaderboard (top 3 per achievement)
+// Optional query parameters: category, app_id (per-game leaderboard of the votes cast in the context of that game)
 // GET /api/v1/leaderboard
 func (h *VoteHandler) GetLeaderboard(c *gin.Context) {
-	leaderboard, err := h.voteRepo.GetLeaderboard(3)
+	ctx := c.Request.Context()
+
+	category := c.Query("category")
+	if category != "" && !models.IsValidAchievementCategory(category) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid category",
+		})
+		return
+	}
+	appID, ok := parseAppIDQuery(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid app ID",
+		})
+		return
+	}
+
+	leaderboard, err := h.voteRepo.GetLeaderboard(ctx, 3, category, appID)
 	if err != nil {
 		log.Printf("Failed to get leaderboard: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -324,7 +831,9 @@ func (h *VoteHandler) GetLeaderboard(c *gin.Context) {
 // GetChampions returns the king (winner) and brother of the king (loser)
 // GET /api/v1/champions
 func (h *VoteHandler) GetChampions(c *gin.Context) {
-	champions, err := h.voteRepo.GetChampions()
+	ctx := c.Request.Context()
+
+	champions, err := h.voteRepo.GetChampions(ctx, h.cfg.RankingTieBreakers)
 	if err != nil {
 		log.Printf("Failed to get champions: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -344,12 +853,22 @@ type GlobalRankingResponse struct {
 	TotalVotes         int                        `json:"total_votes"`
 	MinVotesForRanking int                        `json:"min_votes_for_ranking"`
 	RankingActive      bool                       `json:"ranking_active"`
+	TieBreakers        []models.TieBreakRule      `json:"tie_breakers"` // Rules ordering players with the same score
+	At                 *time.Time                 `json:"at,omitempty"` // Only set for historical rankings
 }
 
 // GetGlobalRanking returns the global ranking based on net votes
+// With ?at=<RFC3339> the ranking is recalculated from the votes created before that time
 // GET /api/v1/ranking
 func (h *VoteHandler) GetGlobalRanking(c *gin.Context) {
-	rankings, err := h.voteRepo.GetGlobalRanking()
+	ctx := c.Request.Context()
+
+	if atParam := c.Query("at"); atParam != "" {
+		h.getGlobalRankingAt(c, atParam)
+		return
+	}
+
+	rankings, err := h.voteRepo.GetGlobalRanking(ctx, h.cfg.RankingTieBreakers)
 	if err != nil {
 		log.Printf("Failed to get global ranking: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -358,7 +877,7 @@ func (h *VoteHandler) GetGlobalRanking(c *gin.Context) {
 		return
 	}
 
-	totalVotes, err := h.voteRepo.GetTotalVoteCount()
+	totalVotes, err := h.voteRepo.GetTotalVoteCount(ctx)
 	if err != nil {
 		log.Printf("Failed to get total vote count: %v", err)
 		totalVotes = 0
@@ -369,12 +888,103 @@ func (h *VoteHandler) GetGlobalRanking(c *gin.Context) {
 		TotalVotes:         totalVotes,
 		MinVotesForRanking: h.cfg.MinVotesForRanking,
 		RankingActive:      totalVotes >= h.cfg.MinVotesForRanking,
+		TieBreakers:        models.DescribeTieBreaks(h.cfg.RankingTieBreakers),
+	})
+}
+
+// getGlobalRankingAt returns the ranking as it was at the given time, e.g. for the closing ceremony
+// The time is truncated to the minute and the results of the last requested minutes are cached
+func (h *VoteHandler) getGlobalRankingAt(c *gin.Context, atParam string) {
+	ctx := c.Request.Context()
+
+	at, err := time.Parse(time.RFC3339, atParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid at parameter, expected RFC3339 (e.g. 2024-05-18T00:00:00+02:00)",
+		})
+		return
+	}
+	at = at.UTC().Truncate(time.Minute)
+	if at.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "at must not be in the future",
+		})
+		return
+	}
+
+	snapshot, err := h.rankingAt(ctx, at)
+	if err != nil {
+		log.Printf("Failed to get ranking at %s: %v", at.Format(time.RFC3339), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load ranking",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, GlobalRankingResponse{
+		Rankings:           snapshot.rankings,
+		TotalVotes:         snapshot.totalVotes,
+		MinVotesForRanking: h.cfg.MinVotesForRanking,
+		RankingActive:      snapshot.totalVotes >= h.cfg.MinVotesForRanking,
+		TieBreakers:        models.DescribeTieBreaks(h.cfg.RankingTieBreakers),
+		At:                 &at,
 	})
 }
 
+// rankingAt returns the cached historical ranking or recalculates it
+// When the cache is full, expired entries and then the entry expiring first are dropped
+func (h *VoteHandler) rankingAt(ctx context.Context, at time.Time) (*rankingSnapshot, error) {
+	key := at.Unix()
+	now := time.Now()
+
+	h.rankingAtMutex.Lock()
+	cached := h.rankingAtCache[key]
+	h.rankingAtMutex.Unlock()
+	if cached != nil && now.Before(cached.expiresAt) {
+		return cached, nil
+	}
+
+	rankings, err := h.voteRepo.GetGlobalRankingAt(ctx, at, h.cfg.RankingTieBreakers)
+	if err != nil {
+		return nil, err
+	}
+	totalVotes, err := h.voteRepo.GetVoteCountUntil(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &rankingSnapshot{
+		rankings:   rankings,
+		totalVotes: totalVotes,
+		expiresAt:  now.Add(rankingAtCacheTTL),
+	}
+
+	h.rankingAtMutex.Lock()
+	defer h.rankingAtMutex.Unlock()
+	for k, s := range h.rankingAtCache {
+		if !now.Before(s.expiresAt) {
+			delete(h.rankingAtCache, k)
+		}
+	}
+	for len(h.rankingAtCache) >= rankingAtCacheSize {
+		var oldest *rankingSnapshot
+		var oldestKey int64
+		for k, s := range h.rankingAtCache {
+			if oldest == nil || s.expiresAt.Before(oldest.expiresAt) {
+				oldest, oldestKey = s, k
+			}
+		}
+		delete(h.rankingAtCache, oldestKey)
+	}
+	h.rankingAtCache[key] = snapshot
+
+	return snapshot, nil
+}
+
 // GetMyRanking returns the current user's rank
 // GET /api/v1/ranking/me
 func (h *VoteHandler) GetMyRanking(c *gin.Context) {
+	ctx := c.Request.Context()
+
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
 		c.JSON(http.StatusUnauthorized, gin.H{
@@ -383,7 +993,7 @@ func (h *VoteHandler) GetMyRanking(c *gin.Context) {
 		return
 	}
 
-	totalVotes, err := h.voteRepo.GetTotalVoteCount()
+	totalVotes, err := h.voteRepo.GetTotalVoteCount(ctx)
 	if err != nil {
 		log.Printf("Failed to get total vote count: %v", err)
 		totalVotes = 0
@@ -398,11 +1008,12 @@ func (h *VoteHandler) GetMyRanking(c *gin.Context) {
 			"total_votes":        totalVotes,
 			"min_votes_for_ranking": h.cfg.MinVotesForRanking,
 			"ranking_active":     false,
+			"tie_breakers":       models.DescribeTieBreaks(h.cfg.RankingTieBreakers),
 		})
 		return
 	}
 
-	ranking, err := h.voteRepo.GetUserRank(userID)
+	ranking, err := h.voteRepo.GetUserRank(ctx, userID, h.cfg.RankingTieBreakers)
 	if err != nil {
 		log.Printf("Failed to get user rank: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -416,12 +1027,15 @@ func (h *VoteHandler) GetMyRanking(c *gin.Context) {
 		"total_votes":        totalVotes,
 		"min_votes_for_ranking": h.cfg.MinVotesForRanking,
 		"ranking_active":     true,
+		"tie_breakers":       models.DescribeTieBreaks(h.cfg.RankingTieBreakers),
 	})
 }
 
 // ToggleInvalidation toggles the is_invalidated flag of a vote (admin only)
 // PUT /api/v1/votes/:id/invalidate
 func (h *VoteHandler) ToggleInvalidation(c *gin.Context) {
+	ctx := c.Request.Context()
+
 	// Get the vote ID from URL parameter
 	voteIDStr := c.Param("id")
 	voteID, err := strconv.ParseUint(voteIDStr, 10, 64)
@@ -449,7 +1063,7 @@ func (h *VoteHandler) ToggleInvalidation(c *gin.Context) {
 	}
 
 	// Check if vote exists
-	vote, err := h.voteRepo.GetByID(voteID)
+	vote, err := h.voteRepo.GetByID(ctx, voteID)
 	if err != nil {
 		log.Printf("Failed to get vote: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -464,8 +1078,21 @@ func (h *VoteHandler) ToggleInvalidation(c *gin.Context) {
 		return
 	}
 
+	// Reason is optional
+	var req models.ToggleInvalidationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		req.Reason = ""
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if len(reason) > 500 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Reason must be at most 500 characters",
+		})
+		return
+	}
+
 	// Toggle invalidation
-	newState, err := h.voteRepo.ToggleInvalidation(voteID)
+	newState, err := h.voteRepo.ToggleInvalidation(ctx, voteID, claims.SteamID, reason)
 	if err != nil {
 		log.Printf("Failed to toggle vote invalidation: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -476,11 +1103,82 @@ func (h *VoteHandler) ToggleInvalidation(c *gin.Context) {
 
 	// Broadcast vote invalidation update via WebSocket
 	if h.wsHub != nil {
-		h.wsHub.BroadcastVoteInvalidation(voteID, newState)
+		h.wsHub.BroadcastVoteInvalidation(voteID, newState, reason)
 	}
 
+	log.Printf("Admin %s set invalidation of vote %d to %v (reason: %s)", claims.SteamID, voteID, newState, reason)
+
 	c.JSON(http.StatusOK, gin.H{
 		"vote_id":        voteID,
 		"is_invalidated": newState,
+		"reason":         reason,
+	})
+}
+
+// GetAdminVotes returns recent votes with invalidation details (admin only)
+// Senders of secret votes stay hidden, they are only available via the audited
+// GET /api/v1/admin/secret-votes
+// GET /api/v1/admin/votes
+func (h *VoteHandler) GetAdminVotes(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	page, ok := parsePage(c, 200)
+	if !ok {
+		return
+	}
+
+	votes, err := h.voteRepo.GetRecentForAdmin(ctx, page)
+	if err != nil {
+		log.Printf("Failed to get admin votes: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load votes",
+		})
+		return
+	}
+
+	if votes == nil {
+		votes = []models.VoteWithDetails{}
+	}
+
+	// "user_choice" anonymizes exactly the secret, not yet revealed votes
+	for i := range votes {
+		votes[i].ApplyVisibilityMode("user_choice")
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"votes":       votes,
+		"next_cursor": repository.NextCursor(page, votes, voteCursorID),
+	})
+}
+
+// GetInvalidationLog returns the invalidation audit trail of a vote (admin only)
+// GET /api/v1/admin/votes/:id/invalidations
+func (h *VoteHandler) GetInvalidationLog(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	voteID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid vote ID",
+		})
+		return
+	}
+
+	entries, err := h.voteRepo.GetInvalidationLog(ctx, voteID)
+	if err != nil {
+		log.Printf("Failed to get invalidation log for vote %d: %v", voteID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load invalidation log",
+		})
+		return
+	}
+
+	if entries == nil {
+		entries = []models.VoteInvalidationLogEntry{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"vote_id": voteID,
+		"entries": entries,
 	})
 }
@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/guided-traffic/rate-your-mate/backend/auth"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
 	"github.com/guided-traffic/rate-your-mate/backend/websocket"
 )
 
@@ -12,20 +15,25 @@ import (
 type WebSocketHandler struct {
 	hub        *websocket.Hub
 	jwtService *auth.JWTService
+	userRepo   *repository.UserRepository
 }
 
 // NewWebSocketHandler creates a new WebSocket handler
-func NewWebSocketHandler(hub *websocket.Hub, jwtService *auth.JWTService) *WebSocketHandler {
+func NewWebSocketHandler(hub *websocket.Hub, jwtService *auth.JWTService, userRepo *repository.UserRepository) *WebSocketHandler {
 	return &WebSocketHandler{
 		hub:        hub,
 		jwtService: jwtService,
+		userRepo:   userRepo,
 	}
 }
 
 // HandleConnection handles WebSocket connection requests
 // The token is passed as a query parameter since WebSocket doesn't support headers easily
-// GET /api/v1/ws?token=xxx
+// Reconnecting clients pass the epoch and seq of the last broadcast they received to get the ones they missed
+// GET /api/v1/ws?token=xxx&epoch=xxx&last_seq=123
 func (h *WebSocketHandler) HandleConnection(c *gin.Context) {
+	ctx := c.Request.Context()
+
 	// Get token from query parameter
 	token := c.Query("token")
 	if token == "" {
@@ -44,14 +52,65 @@ func (h *WebSocketHandler) HandleConnection(c *gin.Context) {
 		return
 	}
 
+	// Spectators follow along via the read-only REST endpoints
+	if claims.IsSpectator() {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Spectators can't connect to the WebSocket",
+		})
+		return
+	}
+
+	// Tokens of kicked and banned users stay valid, they must not reconnect
+	user, err := h.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil {
+		log.Printf("Failed to get user %d for WebSocket connection: %v", claims.UserID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to check user",
+		})
+		return
+	}
+	banned, err := h.userRepo.IsBanned(ctx, claims.SteamID)
+	if err != nil {
+		log.Printf("Failed to check ban of user %d for WebSocket connection: %v", claims.UserID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to check user",
+		})
+		return
+	}
+	if user == nil || banned {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "User was removed or banned",
+		})
+		return
+	}
+
+	var resume *websocket.Resume
+	if epoch := c.Query("epoch"); epoch != "" {
+		lastSeq, err := strconv.ParseUint(c.Query("last_seq"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid last_seq",
+			})
+			return
+		}
+		resume = &websocket.Resume{Epoch: epoch, LastSeq: lastSeq}
+	}
+
 	// Upgrade to WebSocket
-	websocket.ServeWs(h.hub, c.Writer, c.Request, claims.UserID, claims.SteamID, claims.Username)
+	websocket.ServeWs(h.hub, c.Writer, c.Request, claims.UserID, claims.SteamID, claims.Username, resume)
 }
 
-// GetStatus returns WebSocket hub status
+// GetStatus returns the aggregate WebSocket hub status: connection counts and message throughput
 // GET /api/v1/ws/status
 func (h *WebSocketHandler) GetStatus(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"connected_users": h.hub.GetConnectedUserCount(),
-	})
+	status := h.hub.Status()
+	// Who is connected and since when is only shown to admins
+	status.ConnectionsPerUser = nil
+	c.JSON(http.StatusOK, status)
+}
+
+// GetAdminStatus returns the WebSocket hub status including the connections of every user
+// GET /api/v1/admin/ws/status
+func (h *WebSocketHandler) GetAdminStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, h.hub.Status())
 }
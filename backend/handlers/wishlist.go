@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/middleware"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/services"
+)
+
+// WishlistHandler handles the aggregated Steam wishlists of the players
+type WishlistHandler struct {
+	wishlistService *services.WishlistService
+	userRepo        *repository.UserRepository
+}
+
+// NewWishlistHandler creates a new wishlist handler
+func NewWishlistHandler(wishlistService *services.WishlistService, userRepo *repository.UserRepository) *WishlistHandler {
+	return &WishlistHandler{
+		wishlistService: wishlistService,
+		userRepo:        userRepo,
+	}
+}
+
+// GetWishlists returns the games wishlisted by several players with their current price
+// Query parameter min_users sets how many players must have wishlisted a game (default 2)
+// GET /api/v1/wishlists
+func (h *WishlistHandler) GetWishlists(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	minUsers, err := strconv.Atoi(c.DefaultQuery("min_users", "2"))
+	if err != nil || minUsers < 1 {
+		minUsers = 2
+	}
+
+	games, updatedAt, err := h.wishlistService.GetGames(ctx, minUsers)
+	if err != nil {
+		log.Printf("Failed to get wishlists: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get wishlists",
+		})
+		return
+	}
+
+	response := gin.H{
+		"games":   games,
+		"enabled": h.wishlistService.IsEnabled(),
+	}
+	if !updatedAt.IsZero() {
+		response["updated_at"] = updatedAt
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// RefreshMyWishlist fetches the wishlist of the requesting player from Steam
+// POST /api/v1/wishlists/refresh
+func (h *WishlistHandler) RefreshMyWishlist(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if !h.wishlistService.IsEnabled() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Wishlists are disabled",
+		})
+		return
+	}
+
+	userID, _ := middleware.GetUserID(c)
+	user, err := h.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to get user",
+		})
+		return
+	}
+
+	count, err := h.wishlistService.RefreshUser(ctx, user)
+	if errors.Is(err, services.ErrWishlistRefreshCooldown) {
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error": "Wishlist was refreshed recently, try again in a few minutes",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to refresh wishlist of user %d: %v", userID, err)
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": "Failed to fetch wishlist from Steam",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Wunschliste aktualisiert",
+		"game_count": count,
+	})
+}
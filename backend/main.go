@@ -1,8 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -10,9 +16,11 @@ import (
 	"github.com/guided-traffic/rate-your-mate/backend/config"
 	"github.com/guided-traffic/rate-your-mate/backend/database"
 	"github.com/guided-traffic/rate-your-mate/backend/handlers"
+	"github.com/guided-traffic/rate-your-mate/backend/metrics"
 	"github.com/guided-traffic/rate-your-mate/backend/middleware"
 	"github.com/guided-traffic/rate-your-mate/backend/repository"
 	"github.com/guided-traffic/rate-your-mate/backend/services"
+	"github.com/guided-traffic/rate-your-mate/backend/web"
 	"github.com/guided-traffic/rate-your-mate/backend/websocket"
 )
 
@@ -27,6 +35,14 @@ var (
 var cfg *config.Config
 
 func main() {
+	// Generate a starter .env for venue deployments of the single binary
+	if len(os.Args) > 1 && os.Args[1] == "--create-env" {
+		if err := config.CreateEnv(".env", os.Stdin, os.Stdout); err != nil {
+			log.Fatalf("Failed to create .env: %v", err)
+		}
+		return
+	}
+
 	// Load configuration
 	cfg = config.Load()
 	log.Printf("Configuration loaded - Frontend: %s, Backend: %s", cfg.FrontendURL, cfg.BackendURL)
@@ -63,78 +79,279 @@ func main() {
 	defer database.Close()
 
 	// Initialize WebSocket hub
-	wsHub := websocket.NewHub()
+	wsHub := websocket.NewHub(cfg.WSMaxConnectionsPerUser, cfg.WSMaxConnections, cfg.WSCompressionLevel)
+	if cfg.WSRedisURL != "" {
+		fanout, err := websocket.NewRedisFanout(cfg.WSRedisURL, cfg.WSRedisChannel)
+		if err != nil {
+			log.Fatalf("Failed to configure WebSocket fan-out: %v", err)
+		}
+		defer fanout.Close()
+		fanoutCtx, stopFanout := context.WithCancel(context.Background())
+		defer stopFanout()
+		wsHub.UseFanout(fanoutCtx, fanout)
+	}
 	go wsHub.Run()
 	log.Println("WebSocket hub started")
 
+	// Register hub gauges for the metrics dashboard
+	metrics.Default.Gauge(metrics.GaugeConnectedUsers, func() float64 { return float64(wsHub.GetConnectedUserCount()) })
+	metrics.Default.Gauge(metrics.GaugeConnections, func() float64 { return float64(wsHub.GetConnectionCount()) })
+
 	// Initialize repositories
 	userRepo := repository.NewUserRepository()
 	voteRepo := repository.NewVoteRepository()
 	chatRepo := repository.NewChatRepository()
 	gameCacheRepo := repository.NewGameCacheRepository()
 	gameOwnerRepo := repository.NewGameOwnerRepository()
+	sqlConsoleRepo := repository.NewSQLConsoleRepository()
+	anonRepo := repository.NewAnonymizationRepository()
+	auditRepo := repository.NewAuditRepository()
+	phaseRepo := repository.NewPhaseRepository()
+	appealRepo := repository.NewAppealRepository()
+	settingsProfileRepo := repository.NewSettingsProfileRepository()
+	achievementRepo := repository.NewAchievementRepository()
+	chatReminderRepo := repository.NewChatReminderRepository()
+	announcementRepo := repository.NewAnnouncementRepository()
+	notificationRepo := repository.NewNotificationRepository()
+	downloadRepo := repository.NewDownloadRepository()
+	accountReviewRepo := repository.NewAccountReviewRepository()
+	chatFilterRepo := repository.NewChatFilterRepository()
+	muteRepo := repository.NewMuteRepository()
+	suggestionRepo := repository.NewAchievementSuggestionRepository()
+	spectatorRepo := repository.NewSpectatorRepository()
+	badgeRepo := repository.NewBadgeRepository()
+	shortLinkRepo := repository.NewShortLinkRepository()
+	roleRepo := repository.NewRoleRepository()
+	timerRepo := repository.NewTimerRepository()
+	syncCheckpointRepo := repository.NewSyncCheckpointRepository()
+	warehouseRepo := repository.NewWarehouseRepository()
+	pollRepo := repository.NewPollRepository()
+	gameRatingRepo := repository.NewGameRatingRepository()
+	gameServerRepo := repository.NewGameServerRepository()
+	gameSessionRepo := repository.NewGameSessionRepository()
+	wishlistRepo := repository.NewWishlistRepository()
+	pinnedGameRepo := repository.NewPinnedGameRepository()
+
+	// Effects honor the stored reduced motion preferences
+	if reducedMotionUserIDs, err := userRepo.GetReducedMotionUserIDs(context.Background()); err != nil {
+		log.Printf("Warning: Failed to load reduced motion preferences: %v", err)
+	} else {
+		wsHub.LoadReducedMotion(reducedMotionUserIDs)
+	}
+
+	// Load achievements, built-ins are seeded on first start
+	if err := achievementRepo.SeedBuiltins(context.Background()); err != nil {
+		log.Fatalf("Failed to seed achievements: %v", err)
+	}
+	if err := achievementRepo.Load(context.Background()); err != nil {
+		log.Fatalf("Failed to load achievements: %v", err)
+	}
+
+	// Load admins granted at runtime, prints a setup code if there is no admin at all
+	setupService := services.NewSetupService(cfg, roleRepo)
+	if err := setupService.Init(context.Background()); err != nil {
+		log.Fatalf("Failed to initialize admin setup: %v", err)
+	}
 
 	// Initialize services
 	creditService := services.NewCreditService(cfg, userRepo)
+	badgeService := services.NewBadgeService(badgeRepo, userRepo, wsHub)
 	imageCacheService := services.NewImageCacheService()
 	avatarCacheService := services.NewAvatarCacheService(cfg.BackendURL)
 	gameMetadataService := services.NewGameMetadataService(cfg.GameMetadataPath)
-	gameService := services.NewGameService(cfg, userRepo, gameCacheRepo, gameOwnerRepo, imageCacheService, gameMetadataService)
-	countdownService := services.NewCountdownService(cfg, wsHub, userRepo)
+	i18nService := services.NewI18nService(cfg.I18nPath)
+	gameService := services.NewGameService(cfg, userRepo, gameCacheRepo, gameOwnerRepo, imageCacheService, gameMetadataService, timerRepo, syncCheckpointRepo, gameRatingRepo, pinnedGameRepo)
+	showcaseService := services.NewSteamShowcaseService(cfg, steamAPIClient, gameCacheRepo, gameService)
+	gameNewsService := services.NewGameNewsService(cfg, wsHub, gameService)
+	gameDealService := services.NewGameDealService(cfg, wsHub, gameService)
+	gameCacheRefreshService := services.NewGameCacheRefreshService(cfg, gameService)
+	teamBalanceService := services.NewTeamBalanceService(cfg, voteRepo, gameOwnerRepo)
+	gameSessionService := services.NewGameSessionService(cfg, wsHub, gameSessionRepo, userRepo, gameCacheRepo, steamAPIClient, gameService)
+	wishlistService := services.NewWishlistService(cfg, steamAPIClient, wishlistRepo, userRepo, gameCacheRepo, gameService, imageCacheService, handlers.SyncProgressBroadcaster(wsHub))
+	countdownService := services.NewCountdownService(cfg, wsHub, userRepo, timerRepo)
+	revealService := services.NewRevealService(cfg, wsHub, voteRepo, timerRepo)
+	anonService := services.NewAnonymizationService(cfg, anonRepo, avatarCacheService)
+	accountReviewService := services.NewAccountReviewService(cfg, steamAPIClient, accountReviewRepo)
+	chatFilterService := services.NewChatFilterService(cfg, chatFilterRepo)
+	if err := chatFilterService.Load(context.Background()); err != nil {
+		log.Printf("Warning: Failed to load chat filter: %v", err)
+	}
+	phaseService := services.NewPhaseService(cfg, wsHub, phaseRepo)
+	chatReminderService := services.NewChatReminderService(wsHub, chatReminderRepo, chatRepo)
+	downloadReminderService := services.NewDownloadReminderService(cfg, wsHub, downloadRepo)
+	warehouseExportService := services.NewWarehouseExportService(cfg, warehouseRepo, voteRepo)
+	backupService := services.NewBackupService(cfg)
+	mailerService := services.NewMailerService(cfg)
+	digestService := services.NewOrganizerDigestService(cfg, mailerService, userRepo, voteRepo, appealRepo, accountReviewRepo, suggestionRepo)
+
+	// Restore persisted timers, those that expired while the server was down fire right away
+	countdownService.Restore(context.Background())
+	revealService.Restore(context.Background())
 
 	// Start countdown watcher
 	countdownService.Start()
 	defer countdownService.Stop()
 
+	// Start secret vote reveal watcher
+	revealService.Start()
+	defer revealService.Stop()
+
+	// Start data retention watcher
+	anonService.Start()
+	defer anonService.Stop()
+
+	// Start event phase watcher
+	phaseService.Start(context.Background())
+	defer phaseService.Stop()
+
+	// Start scheduled chat reminder watcher
+	chatReminderService.Start()
+	defer chatReminderService.Stop()
+
+	// Start Steam news fetcher for commonly owned games
+	gameNewsService.Start()
+	defer gameNewsService.Stop()
+
+	// Start deal watcher for pinned and commonly owned games
+	gameDealService.Start()
+	defer gameDealService.Stop()
+
+	// Start background refresh of stale game data
+	gameCacheRefreshService.Start()
+	defer gameCacheRefreshService.Stop()
+
+	// Start game session tracking from the Steam playing status
+	gameSessionService.Start()
+	defer gameSessionService.Stop()
+
+	// Start periodic wishlist refresh
+	wishlistService.Start()
+	defer wishlistService.Stop()
+
+	// Start download checklist reminder watcher
+	downloadReminderService.Start()
+	defer downloadReminderService.Stop()
+
+	// Start data warehouse export (disabled without WAREHOUSE_EXPORT_INTERVAL_MINUTES)
+	warehouseExportService.Start()
+	defer warehouseExportService.Stop()
+
+	// Start scheduled SQLite backups (disabled with BACKUP_INTERVAL_MINUTES=0 and for MySQL)
+	backupService.Start()
+	defer backupService.Stop()
+
+	// Start organizer digest e-mail (disabled without SMTP_HOST and DIGEST_RECIPIENTS)
+	digestService.Start()
+	defer digestService.Stop()
+
+	// Advertise the backend on the LAN via mDNS (optional)
+	mdnsService := services.NewMDNSService(cfg)
+	if err := mdnsService.Start(); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	defer mdnsService.Stop()
+
+	// Load the pinned games managed by admins, PINNED_GAME_IDS seeds them on the first start
+	if err := gameService.LoadPinnedGames(context.Background()); err != nil {
+		log.Printf("Warning: Failed to load pinned games: %v", err)
+	}
+
 	// Prefetch pinned games in background at startup
 	gameService.PrefetchPinnedGames()
 
+	// Restore the Steam rate limit pause and resume a game sync interrupted by a restart
+	gameService.Restore(context.Background(), handlers.SyncProgressBroadcaster(wsHub))
+
 	// Initialize handlers
-	authHandler := handlers.NewAuthHandler(cfg, userRepo, creditService, gameService, avatarCacheService, wsHub)
-	userHandler := handlers.NewUserHandler(userRepo, avatarCacheService)
-	achievementHandler := handlers.NewAchievementHandler()
-	voteHandler := handlers.NewVoteHandler(voteRepo, userRepo, creditService, wsHub, cfg)
-	wsHandler := handlers.NewWebSocketHandler(wsHub, authHandler.GetJWTService())
-	settingsHandler := handlers.NewSettingsHandler(cfg, wsHub, userRepo, voteRepo)
-	chatHandler := handlers.NewChatHandler(chatRepo, userRepo, wsHub)
-	gameHandler := handlers.NewGameHandler(gameService, imageCacheService, gameCacheRepo, userRepo, cfg, wsHub)
+	authHandler := handlers.NewAuthHandler(cfg, userRepo, creditService, gameService, avatarCacheService, accountReviewRepo, accountReviewService, chatRepo, wsHub)
+	userHandler := handlers.NewUserHandler(userRepo, badgeRepo, avatarCacheService, i18nService, showcaseService, wsHub)
+	achievementHandler := handlers.NewAchievementHandler(achievementRepo, voteRepo, i18nService, wsHub, cfg)
+	suggestionHandler := handlers.NewAchievementSuggestionHandler(suggestionRepo, achievementRepo, wsHub)
+	voteHandler := handlers.NewVoteHandler(voteRepo, userRepo, muteRepo, notificationRepo, creditService, badgeService, wsHub, cfg)
+	quickVoteHandler := handlers.NewQuickVoteHandler(voteHandler, userRepo, cfg)
+	wsHandler := handlers.NewWebSocketHandler(wsHub, authHandler.GetJWTService(), userRepo)
+	settingsHandler := handlers.NewSettingsHandler(cfg, wsHub, userRepo, voteRepo, settingsProfileRepo, timerRepo, authHandler.GetJWTService())
+	chatLimiter := middleware.NewRateLimiter(func() int { return cfg.ChatRateLimitPerMinute }, time.Minute)
+	chatHandler := handlers.NewChatHandler(cfg, chatRepo, userRepo, chatFilterService, muteRepo, wsHub, chatLimiter)
+	wsHub.SetChatSendHandler(chatHandler.SendFromWebSocket)
+	voteLimiter := middleware.NewRateLimiter(func() int { return cfg.VoteRateLimitPerMinute }, time.Minute)
+	limitsHandler := handlers.NewLimitsHandler(cfg, userRepo, creditService, voteLimiter, chatLimiter)
+	sqlConsoleHandler := handlers.NewSQLConsoleHandler(sqlConsoleRepo, auditRepo)
+	anonymizationHandler := handlers.NewAnonymizationHandler(anonService)
+	abuseReviewHandler := handlers.NewAbuseReviewHandler(voteRepo, auditRepo)
+	phaseHandler := handlers.NewPhaseHandler(phaseRepo, phaseService)
+	chatReminderHandler := handlers.NewChatReminderHandler(chatReminderRepo)
+	announcementHandler := handlers.NewAnnouncementHandler(cfg, announcementRepo, wsHub)
+	notificationHandler := handlers.NewNotificationHandler(notificationRepo, voteRepo, cfg)
+	downloadHandler := handlers.NewDownloadHandler(downloadRepo, downloadReminderService)
+	appealHandler := handlers.NewAppealHandler(appealRepo, voteRepo, wsHub, cfg)
+	accountReviewHandler := handlers.NewAccountReviewHandler(accountReviewRepo, gameService, wsHub)
+	chatFilterHandler := handlers.NewChatFilterHandler(cfg, chatFilterRepo, chatFilterService)
+	muteHandler := handlers.NewMuteHandler(muteRepo, userRepo, wsHub)
+	digestHandler := handlers.NewDigestHandler(digestService)
+	backupHandler := handlers.NewBackupHandler(backupService, auditRepo)
+	spectatorHandler := handlers.NewSpectatorHandler(spectatorRepo, authHandler.GetJWTService())
+	bulkAdminHandler := handlers.NewBulkAdminHandler(cfg, userRepo, voteRepo, auditRepo, wsHub)
+	shortLinkHandler := handlers.NewShortLinkHandler(shortLinkRepo, cfg)
+	setupHandler := handlers.NewSetupHandler(setupService)
+	gameHandler := handlers.NewGameHandler(gameService, gameNewsService, gameDealService, imageCacheService, gameCacheRepo, gameRatingRepo, userRepo, cfg, wsHub)
+	pollHandler := handlers.NewPollHandler(pollRepo, gameService, wsHub, cfg)
+	gameServerHandler := handlers.NewGameServerHandler(gameServerRepo, gameService, wsHub)
+	gameSessionHandler := handlers.NewGameSessionHandler(gameSessionService, gameCacheRepo)
+	teamBalanceHandler := handlers.NewTeamBalanceHandler(teamBalanceService, gameService, userRepo)
+	wishlistHandler := handlers.NewWishlistHandler(wishlistService, userRepo)
+	pinnedGameHandler := handlers.NewPinnedGameHandler(gameService, wsHub)
+	metricsHandler := handlers.NewMetricsHandler(cfg, authHandler.GetJWTService(), metrics.Default)
 
 	r := gin.New()
+	// Only trust X-Forwarded-For from configured proxies, the LAN allowlist and the login IP log rely on the client IP
+	// Gin trusts every proxy by default, without TRUSTED_PROXIES the connection address is used
+	trustedProxies := cfg.TrustedProxies
+	if len(trustedProxies) == 0 {
+		trustedProxies = nil
+	}
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
 	r.Use(gin.Recovery())
 	r.Use(gin.LoggerWithConfig(gin.LoggerConfig{
-		SkipPaths: []string{"/health"},
+		SkipPaths: []string{"/health", "/health/live"},
 	}))
 
 	// CORS configuration
 	corsConfig := cors.DefaultConfig()
 	corsConfig.AllowOrigins = []string{cfg.FrontendURL}
 	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Authorization"}
+	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Authorization", "X-Admin-Elevation", "If-None-Match"}
+	corsConfig.ExposeHeaders = []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After", "ETag"}
 	corsConfig.AllowCredentials = true
 	r.Use(cors.New(corsConfig))
 
-	// Health check endpoint with version info
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":    "healthy",
-			"version":   Version,
-			"buildTime": BuildTime,
-			"gitCommit": GitCommit,
-		})
-	})
+	// Health check endpoint with version info and database state
+	healthHandler := handlers.NewHealthHandler(Version, BuildTime, GitCommit)
+	r.GET("/health", healthHandler.Check)
+	r.GET("/health/live", healthHandler.Live)
+
+	// Short links for the welcome sheet (public, outside the API so URLs stay short)
+	r.GET("/go/:slug", shortLinkHandler.Redirect)
 
 	// API routes
 	api := r.Group("/api/v1")
+	// Outside the venue LAN only public read-only endpoints and the spectator login stay available (if LAN_ALLOWED_CIDRS is set)
+	api.Use(middleware.LANOnlyMiddleware(cfg,
+		"/api/v1/auth/spectator",
+		"/api/v1/health",
+		"/api/v1/achievements",
+		"/api/v1/achievements/:id",
+		"/api/v1/achievements/stats",
+		"/api/v1/languages",
+		"/api/v1/games/images/:filename",
+		"/api/v1/avatars/:filename",
+		"/api/v1/countdown",
+	))
 	{
 		// Health check endpoint (also available at /health for backwards compatibility)
-		api.GET("/health", func(c *gin.Context) {
-			c.JSON(http.StatusOK, gin.H{
-				"status":    "healthy",
-				"version":   Version,
-				"buildTime": BuildTime,
-				"gitCommit": GitCommit,
-			})
-		})
+		api.GET("/health", healthHandler.Check)
 
 		// Auth endpoints (public)
 		auth := api.Group("/auth")
@@ -142,11 +359,14 @@ func main() {
 			auth.GET("/steam", authHandler.SteamLogin)
 			auth.GET("/steam/callback", authHandler.SteamCallback)
 			auth.POST("/logout", authHandler.Logout)
+			auth.POST("/spectator", spectatorHandler.Login)
 		}
 
 		// Achievements (public)
 		api.GET("/achievements", achievementHandler.GetAll)
+		api.GET("/achievements/stats", achievementHandler.GetStats)
 		api.GET("/achievements/:id", achievementHandler.GetByID)
+		api.GET("/languages", userHandler.GetLanguages)
 
 		// Game images (public - allows caching by browsers/CDNs)
 		api.GET("/games/images/:filename", gameHandler.ServeGameImage)
@@ -157,31 +377,77 @@ func main() {
 		// Public countdown endpoint (for login page)
 		api.GET("/countdown", settingsHandler.GetCountdown)
 
+		// First-run setup status (for login page)
+		api.GET("/setup/status", setupHandler.GetStatus)
+
 		// WebSocket endpoint (token passed as query param, validates internally)
 		api.GET("/ws", wsHandler.HandleConnection)
 
+		// Embedded metrics dashboard (admin token passed as query param, validates internally)
+		api.GET("/admin/metrics-dashboard", metricsHandler.Dashboard)
+		api.GET("/admin/metrics-dashboard/stream", metricsHandler.Stream)
+		// Prometheus scrape endpoint (bearer token from the config, disabled without one)
+		api.GET("/metrics", metricsHandler.Prometheus)
+
+		// Quick vote endpoints for hardware buttons (personal token, validates internally)
+		api.GET("/quickvote/targets", quickVoteHandler.GetTargets)
+		api.POST("/quickvote", quickVoteHandler.Vote)
+
 		// Protected routes
 		protected := api.Group("")
-		protected.Use(middleware.AuthMiddleware(authHandler.GetJWTService()))
+		// Spectator accounts can only read the timeline, ranking and chat
+		protected.Use(middleware.AuthMiddleware(authHandler.GetJWTService(), spectatorRepo.Exists, handlers.SpectatorRoutes...))
 		{
 			// Auth
 			protected.GET("/auth/me", authHandler.Me)
 
-			// WebSocket status (requires authentication)
+			// First-run admin bootstrap with the setup code from the server log
+			protected.POST("/setup/claim-admin", setupHandler.ClaimAdmin)
+
+			// WebSocket status (requires authentication, connections per user only for admins)
 			protected.GET("/ws/status", wsHandler.GetStatus)
 
 			// Users
 			protected.GET("/users", userHandler.GetAll)
 			protected.GET("/users/others", userHandler.GetOthers)
 			protected.GET("/users/:id", userHandler.GetByID)
+			protected.GET("/users/:id/badges", userHandler.GetBadges)
+			protected.GET("/users/:id/steam-achievements", userHandler.GetSteamAchievements)
+			protected.PUT("/users/me/privacy", userHandler.UpdatePrivacy)
+			protected.PUT("/users/me/timezone", userHandler.UpdateTimezone)
+			protected.PUT("/users/me/language", userHandler.UpdateLanguage)
+			protected.PUT("/users/me/accessibility", userHandler.UpdateAccessibility)
+			protected.POST("/users/me/quickvote-token", quickVoteHandler.CreateToken)
+			protected.DELETE("/users/me/quickvote-token", quickVoteHandler.RevokeToken)
 
 			// Votes
-			protected.POST("/votes", voteHandler.Create)
+			protected.POST("/votes", voteLimiter.Middleware(), voteHandler.Create)
 			protected.GET("/votes", voteHandler.GetTimeline)
+			protected.GET("/votes/prompt", voteHandler.GetPrompt)
+			protected.GET("/votes/constraints", voteHandler.GetConstraints)
+			protected.GET("/votes/mine", voteHandler.GetMine)
+			protected.GET("/votes/appeals", appealHandler.GetMine)
+			protected.POST("/votes/:id/appeal", appealHandler.Create)
+
+			// Achievement suggestions
+			protected.POST("/achievement-suggestions", suggestionHandler.Create)
+			protected.GET("/achievement-suggestions/mine", suggestionHandler.GetMine)
 
 			// Chat
 			protected.GET("/chat", chatHandler.GetMessages)
-			protected.POST("/chat", chatHandler.Create)
+			protected.POST("/chat", chatLimiter.Middleware(), chatHandler.Create)
+			protected.DELETE("/chat/:id", chatHandler.Delete)
+			protected.PUT("/chat/read", chatHandler.MarkRead)
+			protected.GET("/chat/mentions", chatHandler.GetMentions)
+			protected.POST("/chat/mentions/read", chatHandler.MarkMentionsRead)
+			protected.GET("/limits", limitsHandler.GetLimits)
+
+			// Announcements of admins, for players who log in after the broadcast
+			protected.GET("/announcements", announcementHandler.GetActive)
+
+			// Personal notifications, unread ones are loaded after connecting to catch up on missed ones
+			protected.GET("/notifications", notificationHandler.GetNotifications)
+			protected.POST("/notifications/read", notificationHandler.MarkRead)
 
 			// Voting status (for authenticated users)
 			protected.GET("/voting-status", settingsHandler.GetVotingStatus)
@@ -200,6 +466,42 @@ func main() {
 			protected.POST("/games/refresh-my-games", gameHandler.RefreshMyGames)
 			protected.POST("/games/sync", gameHandler.StartBackgroundSync)
 			protected.GET("/games/sync/status", gameHandler.GetSyncStatus)
+			protected.GET("/games/news", gameHandler.GetNews)
+			protected.GET("/games/deals", gameHandler.GetDeals)
+			protected.GET("/games/common", gameHandler.GetCommonGames)
+			protected.GET("/games/:appid/rating", gameHandler.GetRating)
+			protected.POST("/games/:appid/rating", gameHandler.RateGame)
+			protected.DELETE("/games/:appid/rating", gameHandler.DeleteRating)
+
+			// Game servers
+			protected.GET("/games/servers", gameServerHandler.GetServers)
+			protected.POST("/games/:appid/server", gameServerHandler.SetServer)
+			protected.DELETE("/games/:appid/server", gameServerHandler.DeleteServer)
+
+			// Team balancer
+			protected.POST("/games/:appid/teams", teamBalanceHandler.BalanceTeams)
+
+			// Wishlists
+			protected.GET("/wishlists", wishlistHandler.GetWishlists)
+			protected.POST("/wishlists/refresh", wishlistHandler.RefreshMyWishlist)
+
+			// Game sessions
+			protected.GET("/sessions", gameSessionHandler.GetActive)
+			protected.POST("/sessions", gameSessionHandler.StartSession)
+			protected.DELETE("/sessions/me", gameSessionHandler.EndSession)
+			protected.GET("/sessions/stats", gameSessionHandler.GetStats)
+
+			// Game polls
+			protected.GET("/polls", pollHandler.GetPolls)
+			protected.POST("/polls", pollHandler.CreatePoll)
+			protected.GET("/polls/:id", pollHandler.GetPoll)
+			protected.POST("/polls/:id/vote", pollHandler.Vote)
+			protected.POST("/polls/:id/close", pollHandler.ClosePoll)
+
+			// Download checklist routes
+			protected.GET("/downloads", downloadHandler.GetDownloads)
+			protected.POST("/downloads/:id/ready", downloadHandler.MarkReady)
+			protected.DELETE("/downloads/:id/ready", downloadHandler.UnmarkReady)
 
 			// Admin routes (require admin privileges)
 			admin := protected.Group("/admin")
@@ -209,24 +511,133 @@ func main() {
 				admin.POST("/verify-password", settingsHandler.VerifyAdminPassword)
 				admin.GET("/settings", settingsHandler.GetSettings)
 				admin.PUT("/settings", settingsHandler.UpdateSettings)
+				admin.GET("/config", settingsHandler.GetConfig)
+				admin.GET("/ws/status", wsHandler.GetAdminStatus)
+				admin.GET("/settings/profiles", settingsHandler.GetProfiles)
+				admin.POST("/settings/profiles", settingsHandler.SaveProfile)
+				admin.POST("/settings/profiles/:id/apply", settingsHandler.ApplyProfile)
+				admin.DELETE("/settings/profiles/:id", settingsHandler.DeleteProfile)
+				admin.GET("/phases", phaseHandler.GetPhases)
+				admin.POST("/phases", phaseHandler.CreatePhase)
+				admin.PUT("/phases/:id", phaseHandler.UpdatePhase)
+				admin.DELETE("/phases/:id", phaseHandler.DeletePhase)
+				admin.GET("/reminders", chatReminderHandler.GetReminders)
+				admin.POST("/reminders", chatReminderHandler.CreateReminder)
+				admin.DELETE("/reminders/:id", chatReminderHandler.DeleteReminder)
+				admin.POST("/announce", announcementHandler.Announce)
+				admin.GET("/chat/export", chatHandler.Export)
+				admin.GET("/chat-filter", chatFilterHandler.GetFilter)
+				admin.POST("/chat-filter/words", chatFilterHandler.AddWord)
+				admin.DELETE("/chat-filter/words/:id", chatFilterHandler.DeleteWord)
+				admin.POST("/chat-filter/presets/:language", chatFilterHandler.ImportPreset)
+				admin.GET("/downloads", downloadHandler.GetAdminDownloads)
+				admin.POST("/downloads", downloadHandler.CreateDownload)
+				admin.POST("/downloads/remind", downloadHandler.RemindMissing)
+				admin.PUT("/downloads/:id", downloadHandler.UpdateDownload)
+				admin.DELETE("/downloads/:id", downloadHandler.DeleteDownload)
+				admin.GET("/links", shortLinkHandler.GetLinks)
+				admin.POST("/links", shortLinkHandler.CreateLink)
+				admin.PUT("/links/:slug", shortLinkHandler.UpdateLink)
+				admin.DELETE("/links/:slug", shortLinkHandler.DeleteLink)
 				admin.POST("/credits/reset", settingsHandler.ResetAllCredits)
 				admin.POST("/credits/give", settingsHandler.GiveEveryoneCredit)
 				admin.POST("/votes/delete-all", settingsHandler.DeleteAllVotes)
 				admin.POST("/games/invalidate-cache", gameHandler.InvalidateDBCache)
+				admin.GET("/games/pinned", pinnedGameHandler.GetPinnedGames)
+				admin.POST("/games/pinned", pinnedGameHandler.PinGame)
+				admin.PUT("/games/pinned", pinnedGameHandler.ReorderPinnedGames)
+				admin.DELETE("/games/pinned/:appid", pinnedGameHandler.UnpinGame)
+				admin.PUT("/games/:appid/image", gameHandler.UploadImageOverride)
+				admin.DELETE("/games/:appid/image", gameHandler.DeleteImageOverride)
+				admin.DELETE("/polls/:id", pollHandler.DeletePoll)
 				// Vote management
+				admin.GET("/votes", voteHandler.GetAdminVotes)
 				admin.PUT("/votes/:id/invalidate", voteHandler.ToggleInvalidation)
+				admin.GET("/votes/:id/invalidations", voteHandler.GetInvalidationLog)
+				admin.GET("/audit-log", abuseReviewHandler.GetAuditLog)
+				admin.GET("/achievements", achievementHandler.GetAllForAdmin)
+				admin.POST("/achievements", achievementHandler.Create)
+				admin.PUT("/achievements/:id", achievementHandler.Update)
+				admin.DELETE("/achievements/:id", achievementHandler.Delete)
+				admin.GET("/achievement-suggestions", suggestionHandler.GetAdminSuggestions)
+				admin.POST("/achievement-suggestions/:id/approve", suggestionHandler.Approve)
+				admin.POST("/achievement-suggestions/:id/reject", suggestionHandler.Reject)
+				admin.GET("/appeals", appealHandler.GetAdminAppeals)
+				admin.POST("/appeals/:id/uphold", appealHandler.Uphold)
+				admin.POST("/appeals/:id/invalidate", appealHandler.Invalidate)
+				admin.GET("/account-reviews", accountReviewHandler.GetReviews)
+				admin.POST("/account-reviews/:id/approve", accountReviewHandler.Approve)
+				admin.POST("/account-reviews/:id/reject", accountReviewHandler.Reject)
 				// User management
 				admin.GET("/users", settingsHandler.GetAllUsersForAdmin)
 				admin.GET("/users/banned", settingsHandler.GetAllBannedUsers)
+				admin.GET("/users/muted", muteHandler.GetMutedUsers)
 				admin.POST("/users/:id/kick", settingsHandler.KickUser)
 				admin.POST("/users/:id/ban", settingsHandler.BanUser)
+				admin.POST("/users/:id/mute", muteHandler.MuteUser)
+				admin.POST("/users/:id/unmute", muteHandler.UnmuteUser)
 				admin.POST("/users/unban/:steam_id", settingsHandler.UnbanUser)
+				admin.GET("/spectators", spectatorHandler.GetSpectators)
+				admin.POST("/spectators", spectatorHandler.CreateSpectator)
+				admin.DELETE("/spectators/:id", spectatorHandler.DeleteSpectator)
+				// Bulk operations (preview with "preview": true, one audit log entry per run)
+				admin.POST("/bulk/ban", bulkAdminHandler.BanUsers)
+				admin.POST("/bulk/invalidate-votes", bulkAdminHandler.InvalidateVotes)
+				admin.POST("/bulk/credits", bulkAdminHandler.GiveCredits)
+
+				// Data retention
+				admin.GET("/anonymization", anonymizationHandler.GetReport)
+
+				// Organizer digest
+				admin.GET("/digest", digestHandler.Preview)
+				admin.POST("/digest/send", digestHandler.Send)
+
+				// SQLite backups
+				admin.GET("/backups", backupHandler.GetBackups)
+				admin.POST("/backups", backupHandler.CreateBackup)
+
+				// Elevated admin routes (require elevation token from verify-password)
+				elevated := admin.Group("")
+				elevated.Use(settingsHandler.ElevationMiddleware())
+				{
+					elevated.POST("/sql", sqlConsoleHandler.Execute)
+					elevated.POST("/anonymization/run", anonymizationHandler.Run)
+					elevated.GET("/secret-votes", abuseReviewHandler.GetSecretVotes)
+					elevated.GET("/backups/:name", backupHandler.DownloadBackup)
+					elevated.POST("/backups/:name/restore", backupHandler.RestoreBackup)
+				}
 			}
 		}
 	}
 
-	log.Printf("Server starting on port %s", cfg.Port)
-	if err := r.Run(":" + cfg.Port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	// Frontend bundle and achievement icons of the single binary release
+	if web.HasFiles() {
+		r.NoRoute(web.Handler())
+		log.Printf("Serving embedded files (frontend: %v)", web.HasFrontend())
+	}
+
+	// Stop on SIGINT/SIGTERM, so the deferred Stop calls above end running work cleanly
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{
+		Addr:    ":" + cfg.Port,
+		Handler: r,
+	}
+	go func() {
+		log.Printf("Server starting on port %s", cfg.Port)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("Shutting down server...")
+	gameService.Stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Warning: Server shutdown: %v", err)
 	}
 }
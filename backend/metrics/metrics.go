@@ -0,0 +1,151 @@
+// Package metrics is a small in-process registry of counters and gauges
+// It feeds the embedded admin metrics dashboard, no external monitoring stack is needed
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Counter is a monotonically increasing value, safe for concurrent use
+type Counter struct {
+	value atomic.Int64
+}
+
+// Inc increments the counter by one
+func (c *Counter) Inc() {
+	c.value.Add(1)
+}
+
+// Add increments the counter by n, e.g. a duration to sum up
+func (c *Counter) Add(n int64) {
+	c.value.Add(n)
+}
+
+// Value returns the current value
+func (c *Counter) Value() int64 {
+	return c.value.Load()
+}
+
+// Registry holds named counters and gauges
+type Registry struct {
+	mu       sync.RWMutex
+	counters map[string]*Counter
+	gauges   map[string]func() float64 // Read when a snapshot is taken
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		counters: make(map[string]*Counter),
+		gauges:   make(map[string]func() float64),
+	}
+}
+
+// Counter returns the counter with the given name, it is created on first use
+func (r *Registry) Counter(name string) *Counter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.counters[name]
+	if !ok {
+		c = &Counter{}
+		r.counters[name] = c
+	}
+	return c
+}
+
+// Gauge registers a function that reports the current value of a gauge, e.g. connected users
+func (r *Registry) Gauge(name string, value func() float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.gauges[name] = value
+}
+
+// Snapshot contains the values of all metrics at a point in time
+type Snapshot struct {
+	Time     time.Time          `json:"time"`
+	Counters map[string]int64   `json:"counters"`
+	Gauges   map[string]float64 `json:"gauges"`
+}
+
+// Snapshot reads all metrics
+func (r *Registry) Snapshot() Snapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s := Snapshot{
+		Time:     time.Now(),
+		Counters: make(map[string]int64, len(r.counters)),
+		Gauges:   make(map[string]float64, len(r.gauges)),
+	}
+	for name, c := range r.counters {
+		s.Counters[name] = c.Value()
+	}
+	for name, value := range r.gauges {
+		s.Gauges[name] = value()
+	}
+	return s
+}
+
+// Names returns the sorted names of all counters and gauges
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.counters)+len(r.gauges))
+	for name := range r.counters {
+		names = append(names, name)
+	}
+	for name := range r.gauges {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WritePrometheus writes all metrics in the Prometheus text exposition format
+func (r *Registry) WritePrometheus(w io.Writer) error {
+	s := r.Snapshot()
+	for _, name := range r.Names() {
+		var err error
+		if value, ok := s.Counters[name]; ok {
+			_, err = fmt.Fprintf(w, "# TYPE %s counter\n%s %d\n", name, name, value)
+		} else if value, ok := s.Gauges[name]; ok {
+			_, err = fmt.Fprintf(w, "# TYPE %s gauge\n%s %g\n", name, name, value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Default is the registry of the application
+var Default = NewRegistry()
+
+// Metrics recorded by the application
+var (
+	VotesCast      = Default.Counter("votes_cast_total")       // Votes created, including quick votes
+	SteamAPIErrors = Default.Counter("steam_api_errors_total") // Failed Steam Web and Store API requests
+
+	WebSocketBroadcasts = Default.Counter("websocket_broadcasts_total")       // Broadcasts sent to all clients, including relayed ones
+	WebSocketMessages   = Default.Counter("websocket_messages_total")         // Messages queued for a client
+	WebSocketDropped    = Default.Counter("websocket_dropped_messages_total") // Messages dropped because a client's send buffer was full
+
+	// Sums of the query durations, divided by the totals they give the average time of the hot paths
+	RankingCalculations       = Default.Counter("ranking_calculations_total")              // Global rankings calculated, twice per positive vote
+	RankingDurationMicros     = Default.Counter("ranking_duration_microseconds_total")     // Time spent calculating global rankings
+	LeaderboardQueries        = Default.Counter("leaderboard_queries_total")               // Achievement leaderboards read
+	LeaderboardDurationMicros = Default.Counter("leaderboard_duration_microseconds_total") // Time spent reading achievement leaderboards
+)
+
+// Gauges registered by the application
+const (
+	GaugeConnectedUsers = "connected_users"
+	GaugeConnections    = "websocket_connections"
+)
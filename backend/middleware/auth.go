@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"context"
+	"log"
 	"net/http"
 	"strings"
 
@@ -14,7 +16,14 @@ const (
 )
 
 // AuthMiddleware creates a middleware that validates JWT tokens
-func AuthMiddleware(jwtService *auth.JWTService) gin.HandlerFunc {
+// Spectator tokens are only accepted for the given read-only routes and as long as
+// spectatorExists reports that the account has not been deleted
+func AuthMiddleware(jwtService *auth.JWTService, spectatorExists func(ctx context.Context, id uint64) (bool, error), spectatorRoutes ...string) gin.HandlerFunc {
+	spectatorAllowed := make(map[string]bool, len(spectatorRoutes))
+	for _, route := range spectatorRoutes {
+		spectatorAllowed[route] = true
+	}
+
 	return func(c *gin.Context) {
 		// Get the Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -45,6 +54,31 @@ func AuthMiddleware(jwtService *auth.JWTService) gin.HandlerFunc {
 			return
 		}
 
+		// Spectators can only read the timeline, ranking and chat
+		if claims.IsSpectator() {
+			if c.Request.Method != http.MethodGet || !spectatorAllowed[c.FullPath()] {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"error":     "Spectators have read-only access",
+					"spectator": true,
+				})
+				return
+			}
+			exists, err := spectatorExists(c.Request.Context(), claims.SpectatorID)
+			if err != nil {
+				log.Printf("Failed to check spectator %d: %v", claims.SpectatorID, err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+					"error": "Failed to check spectator account",
+				})
+				return
+			}
+			if !exists {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+					"error": "Invalid or expired token",
+				})
+				return
+			}
+		}
+
 		// Store claims in context for handlers to use
 		c.Set(ContextKeyClaims, claims)
 		c.Next()
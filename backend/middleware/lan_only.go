@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+)
+
+// lanOnlyPage is shown to browsers outside the venue LAN
+const lanOnlyPage = `<!DOCTYPE html>
+<html lang="de">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Rate your Mate</title>
+<style>
+body { margin: 0; min-height: 100vh; display: flex; align-items: center; justify-content: center;
+       background: #14161c; color: #e8e8e8; font-family: system-ui, sans-serif; text-align: center; }
+h1 { font-size: 2rem; margin-bottom: 0.5rem; }
+p { color: #a0a4ad; max-width: 28rem; }
+</style>
+</head>
+<body>
+<div>
+<h1>Komm zur Party, um abzustimmen! 🎮</h1>
+<p>Abstimmen geht nur direkt vor Ort im LAN-Party-Netzwerk. Schnapp dir deinen Rechner und komm vorbei!</p>
+</div>
+</body>
+</html>
+`
+
+// LANOnlyMiddleware restricts requests to the configured venue LAN (LAN_ALLOWED_CIDRS)
+// In "writes" mode only state-changing requests are restricted, in "all" mode every request
+// except the given public read-only routes (e.g. overlays, images) is restricted
+// In "writes" mode public routes are allowed with any method, e.g. the spectator login
+func LANOnlyMiddleware(cfg *config.Config, publicRoutes ...string) gin.HandlerFunc {
+	public := make(map[string]bool, len(publicRoutes))
+	for _, route := range publicRoutes {
+		public[route] = true
+	}
+
+	return func(c *gin.Context) {
+		// LAN-only mode is disabled without allowlist
+		if len(cfg.LANAllowedNets) == 0 {
+			c.Next()
+			return
+		}
+
+		readOnly := c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead || c.Request.Method == http.MethodOptions
+		if readOnly && (cfg.LANOnlyMode == "writes" || public[c.FullPath()]) || cfg.LANOnlyMode == "writes" && public[c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		if ip := net.ParseIP(c.ClientIP()); ip != nil && cfg.IsLANAddress(ip) {
+			c.Next()
+			return
+		}
+
+		log.Printf("LAN-only: Blocked %s %s from %s", c.Request.Method, c.Request.URL.Path, c.ClientIP())
+
+		if strings.Contains(c.GetHeader("Accept"), "text/html") {
+			c.Data(http.StatusForbidden, "text/html; charset=utf-8", []byte(lanOnlyPage))
+			c.Abort()
+			return
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error":    "Komm zur Party, um abzustimmen! Diese Aktion ist nur im LAN-Party-Netzwerk möglich.",
+			"lan_only": true,
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitQuota describes the current rate limit usage of a user
+type RateLimitQuota struct {
+	Limit         int       `json:"limit"` // 0 = unlimited
+	Remaining     int       `json:"remaining"`
+	ResetAt       time.Time `json:"reset_at"`
+	WindowSeconds int       `json:"window_seconds"`
+}
+
+// rateWindow counts the requests of one user or key in the current window
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// RateLimiter limits requests per user (or any other key) in fixed time windows
+// The limit is read on every request so runtime changes apply immediately
+type RateLimiter struct {
+	limit  func() int
+	window time.Duration
+
+	mu      sync.Mutex
+	windows map[string]*rateWindow
+}
+
+// NewRateLimiter creates a new rate limiter, a limit of 0 disables it
+func NewRateLimiter(limit func() int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:   limit,
+		window:  window,
+		windows: make(map[string]*rateWindow),
+	}
+}
+
+// Quota returns the current usage of a user without counting a request
+func (l *RateLimiter) Quota(userID uint64) RateLimitQuota {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.quota(userKey(userID), time.Now())
+}
+
+// userKey is the key the requests of a user are counted under
+func userKey(userID uint64) string {
+	return strconv.FormatUint(userID, 10)
+}
+
+// quota builds the usage of a key, the caller must hold mu
+func (l *RateLimiter) quota(key string, now time.Time) RateLimitQuota {
+	limit := l.limit()
+	q := RateLimitQuota{
+		Limit:         limit,
+		Remaining:     limit,
+		ResetAt:       now.Add(l.window),
+		WindowSeconds: int(l.window.Seconds()),
+	}
+	if w, ok := l.windows[key]; ok && now.Sub(w.start) < l.window {
+		q.Remaining = limit - w.count
+		if q.Remaining < 0 {
+			q.Remaining = 0
+		}
+		q.ResetAt = w.start.Add(l.window)
+	}
+	return q
+}
+
+// Allow counts a request that does not pass the middleware, e.g. a WebSocket message
+// A limit of 0 always allows the request
+func (l *RateLimiter) Allow(userID uint64) (RateLimitQuota, bool) {
+	if l.limit() <= 0 {
+		return l.Quota(userID), true
+	}
+	return l.allow(userKey(userID))
+}
+
+// allow counts a request under key and reports whether it is within the limit
+func (l *RateLimiter) allow(key string) (RateLimitQuota, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+
+	// Drop expired windows so the map only holds recently active keys
+	for k, w := range l.windows {
+		if now.Sub(w.start) >= l.window {
+			delete(l.windows, k)
+		}
+	}
+
+	q := l.quota(key, now)
+	if q.Remaining <= 0 {
+		return q, false
+	}
+
+	w, ok := l.windows[key]
+	if !ok {
+		w = &rateWindow{start: now}
+		l.windows[key] = w
+	}
+	w.count++
+
+	return l.quota(key, now), true
+}
+
+// Middleware rejects requests over the limit with 429 and sets the X-RateLimit headers
+// Must be used after AuthMiddleware, unauthenticated requests are not limited
+func (l *RateLimiter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := GetUserID(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		if l.LimitRequest(c, userKey(userID)) {
+			c.Next()
+		}
+	}
+}
+
+// LimitRequest counts a request under key and sets the X-RateLimit headers
+// Over the limit it aborts the request with 429 and returns false, a limit of 0 always allows it
+// Used by handlers that limit unauthenticated requests, e.g. by IP address
+func (l *RateLimiter) LimitRequest(c *gin.Context, key string) bool {
+	if l.limit() <= 0 {
+		return true
+	}
+
+	q, allowed := l.allow(key)
+	c.Header("X-RateLimit-Limit", strconv.Itoa(q.Limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(q.Remaining))
+	c.Header("X-RateLimit-Reset", strconv.FormatInt(q.ResetAt.Unix(), 10))
+
+	if !allowed {
+		retryAfter := int(time.Until(q.ResetAt).Seconds()) + 1
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+			"error":       "Rate limit exceeded, slow down",
+			"retry_after": retryAfter,
+		})
+		return false
+	}
+	return true
+}
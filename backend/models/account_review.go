@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Account review states
+const (
+	AccountReviewPending  = "pending"  // The account can't log in until an admin decides
+	AccountReviewApproved = "approved" // The account was let in
+	AccountReviewRejected = "rejected" // The account stays locked out
+)
+
+// Signals of the ban evasion heuristics
+const (
+	AccountSignalYoungAccount   = "young_account"   // Steam account younger than ACCOUNT_REVIEW_MIN_AGE_DAYS
+	AccountSignalNoGames        = "no_games"        // No owned games visible
+	AccountSignalPrivateProfile = "private_profile" // Steam profile is not public
+	AccountSignalBannedIP       = "banned_ip"       // Same IP as a login of a banned player
+)
+
+// AccountReview is a new account flagged by the ban evasion heuristics
+type AccountReview struct {
+	ID         uint64     `json:"id"`
+	UserID     uint64     `json:"user_id"`
+	SteamID    string     `json:"steam_id"`
+	Username   string     `json:"username"`
+	Reasons    []string   `json:"reasons"` // Matched signals, e.g. young_account
+	IPAddress  string     `json:"ip_address,omitempty"`
+	Status     string     `json:"status"`
+	ReviewedBy string     `json:"reviewed_by,omitempty"` // Steam ID of the admin
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
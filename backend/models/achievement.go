@@ -1,101 +1,258 @@
 package models
 
-// Achievement represents a predefined achievement that users can vote for
+import (
+	"sync"
+	"time"
+)
+
+// Achievement categories
+const (
+	AchievementCategorySkill    = "skill"
+	AchievementCategorySocial   = "social"
+	AchievementCategoryMeme     = "meme"
+	AchievementCategoryNegative = "negative"
+)
+
+// AchievementCategory groups achievements for display and leaderboard filtering
+type AchievementCategory struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// AchievementCategories lists all categories in display order
+var AchievementCategories = []AchievementCategory{
+	{ID: AchievementCategorySkill, Name: "Skill"},
+	{ID: AchievementCategorySocial, Name: "Sozial"},
+	{ID: AchievementCategoryMeme, Name: "Meme"},
+	{ID: AchievementCategoryNegative, Name: "Negativ"},
+}
+
+// Achievement weight bounds, a weight of 0 excludes an achievement from the ranking
+const (
+	MinAchievementWeight = 0
+	MaxAchievementWeight = 10
+)
+
+// IsValidAchievementCategory checks if a category ID is valid
+func IsValidAchievementCategory(id string) bool {
+	for _, category := range AchievementCategories {
+		if category.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Achievement represents an achievement that users can vote for
 type Achievement struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	ImageURL    string `json:"image_url"`
-	IsPositive  bool   `json:"is_positive"`
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	ImageURL    string     `json:"image_url"`
+	IsPositive  bool       `json:"is_positive"`
+	Category    string     `json:"category"`
+	Tags        []string   `json:"tags"`
+	Weight      int        `json:"weight"` // Multiplier for votes and placement bonus in the ranking
+	IsBuiltin   bool       `json:"is_builtin"`
+	IsDisabled  bool       `json:"is_disabled"` // Disabled achievements keep their votes but cannot be voted anymore
+	ValidFrom   *time.Time `json:"valid_from"`  // Seasonal achievements can only be voted from this time on
+	ValidUntil  *time.Time `json:"valid_until"` // and are archived afterwards, nil means unbounded
+	AppID       *int       `json:"app_id"`      // Game-scoped achievements can only be voted in the context of this Steam app, nil means global
+	SortOrder   int        `json:"sort_order"`
+}
+
+// IsAvailableForGame checks if an achievement can be voted in the context of a Steam app (0 = no game context)
+// Global achievements are available everywhere, game-scoped ones only for their app
+func (a Achievement) IsAvailableForGame(appID int) bool {
+	return a.AppID == nil || *a.AppID == appID
+}
+
+// HasStartedAt checks if the validity window of an achievement has begun at t
+func (a Achievement) HasStartedAt(t time.Time) bool {
+	return a.ValidFrom == nil || !t.Before(*a.ValidFrom)
+}
+
+// IsArchivedAt checks if the validity window of an achievement has ended at t
+func (a Achievement) IsArchivedAt(t time.Time) bool {
+	return a.ValidUntil != nil && !t.Before(*a.ValidUntil)
+}
+
+// IsVotableAt checks if an achievement is enabled and inside its validity window at t
+func (a Achievement) IsVotableAt(t time.Time) bool {
+	return !a.IsDisabled && a.HasStartedAt(t) && !a.IsArchivedAt(t)
 }
 
-// Predefined achievements
-var Achievements = map[string]Achievement{
+// BuiltinAchievements are seeded into the achievements table on startup
+// Admins can edit and disable them, but not delete them
+var BuiltinAchievements = []Achievement{
 	// Positive achievements
-	"pro-player": {
+	{
 		ID:          "pro-player",
 		Name:        "Pro Player",
 		Description: "Zeigt herausragende Fähigkeiten, für seine Verhältnisse.",
 		ImageURL:    "/icons/achievements/trophy.svg",
 		IsPositive:  true,
+		Category:    AchievementCategorySkill,
+		Tags:        []string{"aim"},
+		Weight:      1,
+		IsBuiltin:   true,
 	},
-	"teamplayer": {
+	{
 		ID:          "teamplayer",
 		Name:        "Teamplayer",
 		Description: "Stirbt freiwillig zuerst, damit du looten kannst.",
 		ImageURL:    "/icons/achievements/three-friends.svg",
 		IsPositive:  true,
+		Category:    AchievementCategorySocial,
+		Tags:        []string{"team"},
+		Weight:      1,
+		IsBuiltin:   true,
 	},
-	"clutch-king": {
+	{
 		ID:          "clutch-king",
 		Name:        "Clutch King",
 		Description: "1v5? Kein Problem. Wo ist die Herausforderung?",
 		ImageURL:    "/icons/achievements/muscle-up.svg",
 		IsPositive:  true,
+		Category:    AchievementCategorySkill,
+		Tags:        []string{"aim", "clutch"},
+		Weight:      1,
+		IsBuiltin:   true,
 	},
-	"support-hero": {
+	{
 		ID:          "support-hero",
 		Name:        "Support Hero",
 		Description: "Flasht die Gegner, nicht das eigene Team. Ein Wunder!",
 		ImageURL:    "/icons/achievements/shaking-hands.svg",
 		IsPositive:  true,
+		Category:    AchievementCategorySocial,
+		Tags:        []string{"team", "support"},
+		Weight:      1,
+		IsBuiltin:   true,
 	},
-	"stratege": {
+	{
 		ID:          "stratege",
 		Name:        "Stratege",
 		Description: "Seine Taktik: 'Vertraut mir, Jungs!' alle sterben",
 		ImageURL:    "/icons/achievements/chess-king.svg",
 		IsPositive:  true,
+		Category:    AchievementCategorySkill,
+		Tags:        []string{"tactics"},
+		Weight:      1,
+		IsBuiltin:   true,
 	},
-	"good-sport": {
+	{
 		ID:          "good-sport",
 		Name:        "Gute Manieren",
 		Description: "Der einzige der nach dem Match noch Freunde hat.",
 		ImageURL:    "/icons/achievements/bow-tie-ribbon.svg",
 		IsPositive:  true,
+		Category:    AchievementCategorySocial,
+		Tags:        []string{"fairplay"},
+		Weight:      1,
+		IsBuiltin:   true,
 	},
 
 	// Negative achievements
-	"rage-quitter": {
+	{
 		ID:          "rage-quitter",
 		Name:        "Rage Quitter",
 		Description: "'Das Spiel ist eh buggy' – 0.3 Sekunden nach dem Tod.",
 		ImageURL:    "/icons/achievements/enrage.svg",
 		IsPositive:  false,
+		Category:    AchievementCategoryNegative,
+		Tags:        []string{"tilt"},
+		Weight:      1,
+		IsBuiltin:   true,
 	},
-	"toxic": {
+	{
 		ID:          "toxic",
 		Name:        "Toxic",
 		Description: "Caps Lock ist sein Standardmodus.",
 		ImageURL:    "/icons/achievements/death-juice.svg",
 		IsPositive:  false,
+		Category:    AchievementCategoryNegative,
+		Tags:        []string{"chat", "tilt"},
+		Weight:      1,
+		IsBuiltin:   true,
 	},
-	"friendly-fire-expert": {
+	{
 		ID:          "friendly-fire-expert",
 		Name:        "Friendly Fire Expert",
 		Description: "Sein Team fürchtet ihn mehr als die Gegner.",
 		ImageURL:    "/icons/achievements/backstab.svg",
 		IsPositive:  false,
+		Category:    AchievementCategoryNegative,
+		Tags:        []string{"team", "aim"},
+		Weight:      1,
+		IsBuiltin:   true,
 	},
 }
 
-// GetAllAchievements returns all achievements as a slice
+// Runtime achievement registry, loaded from the achievements table
+// Starts with the built-ins so lookups work before the database has been read
+var (
+	achievementsMu   sync.RWMutex
+	achievements     = BuiltinAchievements
+	achievementsByID = indexAchievements(BuiltinAchievements)
+)
+
+// indexAchievements builds the ID lookup of an achievement list
+func indexAchievements(list []Achievement) map[string]Achievement {
+	byID := make(map[string]Achievement, len(list))
+	for _, a := range list {
+		byID[a.ID] = a
+	}
+	return byID
+}
+
+// SetAchievements replaces the runtime achievement registry (list is expected in display order)
+func SetAchievements(list []Achievement) {
+	byID := indexAchievements(list)
+
+	achievementsMu.Lock()
+	achievements = list
+	achievementsByID = byID
+	achievementsMu.Unlock()
+}
+
+// GetAllAchievements returns all achievements in display order, including disabled ones
 func GetAllAchievements() []Achievement {
-	achievements := make([]Achievement, 0, len(Achievements))
-	for _, a := range Achievements {
-		achievements = append(achievements, a)
+	achievementsMu.RLock()
+	defer achievementsMu.RUnlock()
+
+	result := make([]Achievement, len(achievements))
+	copy(result, achievements)
+	return result
+}
+
+// GetEnabledAchievements returns all achievements that can currently be voted, in display order
+// Disabled achievements and seasonal achievements outside their validity window are left out
+func GetEnabledAchievements() []Achievement {
+	achievementsMu.RLock()
+	defer achievementsMu.RUnlock()
+
+	now := time.Now()
+	result := make([]Achievement, 0, len(achievements))
+	for _, a := range achievements {
+		if a.IsVotableAt(now) {
+			result = append(result, a)
+		}
 	}
-	return achievements
+	return result
 }
 
-// GetAchievement returns an achievement by ID
+// GetAchievement returns an achievement by ID, including disabled ones
 func GetAchievement(id string) (Achievement, bool) {
-	a, ok := Achievements[id]
+	achievementsMu.RLock()
+	defer achievementsMu.RUnlock()
+
+	a, ok := achievementsByID[id]
 	return a, ok
 }
 
 // IsValidAchievement checks if an achievement ID is valid
 func IsValidAchievement(id string) bool {
-	_, ok := Achievements[id]
+	_, ok := GetAchievement(id)
 	return ok
 }
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Achievement suggestion states
+const (
+	SuggestionStatusPending  = "pending"
+	SuggestionStatusApproved = "approved" // An achievement was created from the suggestion
+	SuggestionStatusRejected = "rejected"
+)
+
+// MaxPendingSuggestionsPerUser limits how many suggestions a player can have waiting for review
+const MaxPendingSuggestionsPerUser = 5
+
+// AchievementSuggestion is an achievement idea submitted by a player
+type AchievementSuggestion struct {
+	ID            uint64     `json:"id"`
+	UserID        uint64     `json:"user_id"`
+	Username      string     `json:"username"`
+	Name          string     `json:"name"`
+	Description   string     `json:"description"`
+	IsPositive    bool       `json:"is_positive"`
+	Status        string     `json:"status"`
+	AchievementID string     `json:"achievement_id,omitempty"` // Set once approved
+	ReviewedBy    string     `json:"reviewed_by,omitempty"`    // Steam ID of the admin
+	ReviewNote    string     `json:"review_note,omitempty"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// CreateAchievementSuggestionRequest is the request body for suggesting an achievement
+type CreateAchievementSuggestionRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	IsPositive  bool   `json:"is_positive"`
+}
+
+// RejectAchievementSuggestionRequest is the request body for rejecting a suggestion
+type RejectAchievementSuggestionRequest struct {
+	Note string `json:"note"` // optional free-text note, max 500 characters
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Severities of an announcement
+const (
+	AnnouncementSeverityInfo     = "info"
+	AnnouncementSeverityWarning  = "warning"
+	AnnouncementSeverityCritical = "critical"
+)
+
+// Announcement is a message broadcast by an admin to all players, shown until it expires
+type Announcement struct {
+	ID        uint64     `json:"id"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Severity  string     `json:"severity"`   // info, warning or critical
+	ExpiresAt *time.Time `json:"expires_at"` // nil = shown until the event ends
+	CreatedBy uint64     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// CreateAnnouncementRequest is the request body for broadcasting an announcement
+type CreateAnnouncementRequest struct {
+	Title     string `json:"title" binding:"required,min=1,max=100"`
+	Body      string `json:"body" binding:"required,min=1,max=1000"`
+	Severity  string `json:"severity"`   // info (default), warning or critical
+	ExpiresAt string `json:"expires_at"` // RFC3339 formatted time (optional)
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Vote appeal states
+const (
+	AppealStatusPending     = "pending"
+	AppealStatusUpheld      = "upheld"      // The vote stays valid
+	AppealStatusInvalidated = "invalidated" // The vote was invalidated
+)
+
+// VoteAppeal is a request of a vote recipient to review a negative vote
+type VoteAppeal struct {
+	ID             uint64           `json:"id"`
+	VoteID         uint64           `json:"vote_id"`
+	UserID         uint64           `json:"user_id"`
+	Reason         string           `json:"reason"`
+	Status         string           `json:"status"`
+	ResolvedBy     string           `json:"resolved_by,omitempty"` // Steam ID of the admin
+	ResolutionNote string           `json:"resolution_note,omitempty"`
+	ResolvedAt     *time.Time       `json:"resolved_at,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	Vote           *VoteWithDetails `json:"vote,omitempty"`
+}
+
+// CreateAppealRequest is the request body for appealing a vote
+type CreateAppealRequest struct {
+	Reason string `json:"reason"` // optional free-text reason, max 500 characters
+}
+
+// ResolveAppealRequest is the request body for resolving an appeal
+type ResolveAppealRequest struct {
+	Note string `json:"note"` // optional free-text note, max 500 characters
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Admin audit log actions
+const (
+	AuditActionViewSecretVotes     = "view_secret_votes"
+	AuditActionBulkBan             = "bulk_ban"
+	AuditActionBulkInvalidateVotes = "bulk_invalidate_votes"
+	AuditActionBulkGiveCredits     = "bulk_give_credits"
+	AuditActionSQLQuery            = "sql_query"
+	AuditActionDownloadBackup      = "download_backup"
+	AuditActionRestoreBackup       = "restore_backup"
+)
+
+// AdminAuditEntry is a single entry of the admin audit trail
+type AdminAuditEntry struct {
+	ID           uint64    `json:"id"`
+	AdminSteamID string    `json:"admin_steam_id"`
+	Action       string    `json:"action"`
+	Details      string    `json:"details"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+// Badge statistics a badge threshold can be based on
+const (
+	BadgeStatVotesGiven            = "votes_given"
+	BadgeStatVotesReceived         = "votes_received"
+	BadgeStatNegativeVotesReceived = "negative_votes_received"
+	BadgeStatAchievementsLed       = "achievements_led" // Positive achievements the user holds 1st place in
+)
+
+// Badge is a meta-badge that is automatically awarded once a statistic reaches its threshold
+type Badge struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+	Stat        string `json:"stat"`
+	Threshold   int    `json:"threshold"`
+}
+
+// Badges lists all meta-badges in display order
+var Badges = []Badge{
+	{
+		ID:          "first-vote",
+		Name:        "Erste Stimme",
+		Description: "Hat zum ersten Mal abgestimmt.",
+		Icon:        "🗳️",
+		Stat:        BadgeStatVotesGiven,
+		Threshold:   1,
+	},
+	{
+		ID:          "crowd-favorite",
+		Name:        "Publikumsliebling",
+		Description: "Hat 50 Stimmen erhalten.",
+		Icon:        "⭐",
+		Stat:        BadgeStatVotesReceived,
+		Threshold:   50,
+	},
+	{
+		ID:          "triple-crown",
+		Name:        "Triple Crown",
+		Description: "Führt in 3 Achievements.",
+		Icon:        "👑",
+		Stat:        BadgeStatAchievementsLed,
+		Threshold:   3,
+	},
+	{
+		ID:          "thick-skin",
+		Name:        "Dickes Fell",
+		Description: "Hat 10 negative Stimmen überlebt.",
+		Icon:        "🛡️",
+		Stat:        BadgeStatNegativeVotesReceived,
+		Threshold:   10,
+	},
+}
+
+// GetBadge returns a badge by ID
+func GetBadge(id string) (Badge, bool) {
+	for _, badge := range Badges {
+		if badge.ID == id {
+			return badge, true
+		}
+	}
+	return Badge{}, false
+}
+
+// UserBadge is a badge awarded to a user
+type UserBadge struct {
+	Badge
+	AwardedAt time.Time `json:"awarded_at"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// MaxBulkItems limits the users or votes changed by one bulk operation
+const MaxBulkItems = 500
+
+// BulkBanRequest is the request body for banning several users at once
+type BulkBanRequest struct {
+	UserIDs []uint64 `json:"user_ids"`
+	Reason  string   `json:"reason"`  // optional, applies to all bans
+	Preview bool     `json:"preview"` // only list the affected users
+}
+
+// BulkInvalidateVotesRequest is the request body for invalidating all valid votes matching a filter
+type BulkInvalidateVotesRequest struct {
+	Filter  VoteFilter `json:"filter"`
+	Reason  string     `json:"reason"`  // optional, max 500 characters
+	Preview bool       `json:"preview"` // only list the affected votes
+}
+
+// VoteFilter selects votes for bulk operations, all set criteria must match
+type VoteFilter struct {
+	FromUserID    uint64     `json:"from_user_id,omitempty"`
+	ToUserID      uint64     `json:"to_user_id,omitempty"`
+	AchievementID string     `json:"achievement_id,omitempty"`
+	Since         *time.Time `json:"since,omitempty"`
+	Until         *time.Time `json:"until,omitempty"`
+}
+
+// IsEmpty checks if no criterion is set, an empty filter would match every vote
+func (f VoteFilter) IsEmpty() bool {
+	return f.FromUserID == 0 && f.ToUserID == 0 && f.AchievementID == "" && f.Since == nil && f.Until == nil
+}
+
+// BulkGiveCreditsRequest is the request body for giving credits to selected users
+type BulkGiveCreditsRequest struct {
+	UserIDs []uint64 `json:"user_ids"`
+	Amount  int      `json:"amount"`
+	Preview bool     `json:"preview"` // only list the affected users and their new credits
+}
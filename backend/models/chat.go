@@ -8,15 +8,43 @@ type ChatMessage struct {
 	UserID       uint64    `json:"user_id"`
 	Message      string    `json:"message"`
 	Achievements string    `json:"achievements"` // JSON array of achievement IDs at time of message
+	ReplyToID    *uint64   `json:"reply_to_id"`  // Parent message, nil for top-level messages
+	IsSystem     bool      `json:"is_system"`    // Posted by the system, e.g. a scheduled reminder
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+// SystemChatUser is shown as the author of system messages
+var SystemChatUser = PublicUser{
+	Username: "System",
+}
+
+// ChatReplySnippetLength is the maximum number of characters quoted from a parent message
+const ChatReplySnippetLength = 100
+
+// ChatReplyPreview quotes the parent of a reply
+type ChatReplyPreview struct {
+	ID      uint64     `json:"id"`
+	User    PublicUser `json:"user"`
+	Snippet string     `json:"snippet"`
+}
+
+// NewChatReplySnippet shortens a parent message to ChatReplySnippetLength characters
+func NewChatReplySnippet(message string) string {
+	runes := []rune(message)
+	if len(runes) <= ChatReplySnippetLength {
+		return message
+	}
+	return string(runes[:ChatReplySnippetLength]) + "…"
+}
+
 // ChatMessageWithUser includes user information for display
 type ChatMessageWithUser struct {
 	ID           uint64           `json:"id"`
 	User         PublicUser       `json:"user"`
 	Message      string           `json:"message"`
 	Achievements []AchievementBadge `json:"achievements"` // Achievement badges at time of message
+	ReplyTo      *ChatReplyPreview  `json:"reply_to,omitempty"`
+	IsSystem     bool             `json:"is_system"`
 	CreatedAt    time.Time        `json:"created_at"`
 }
 
@@ -31,5 +59,17 @@ type AchievementBadge struct {
 
 // CreateChatMessageRequest is the request body for creating a chat message
 type CreateChatMessageRequest struct {
-	Message string `json:"message" binding:"required,min=1,max=500"`
+	Message   string  `json:"message" binding:"required,min=1,max=500"`
+	ReplyToID *uint64 `json:"reply_to_id"` // Optional parent message to reply to
+}
+
+// ChatReadRequest is the request body for marking the chat as read
+type ChatReadRequest struct {
+	MessageID uint64 `json:"message_id"` // Last message read, 0 or missing for the newest message
+}
+
+// ChatMentionsResponse lists the chat messages a user was mentioned in
+type ChatMentionsResponse struct {
+	Mentions    []ChatMessageWithUser `json:"mentions"` // Newest first
+	UnreadCount int                   `json:"unread_count"`
 }
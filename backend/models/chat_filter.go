@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Chat filter modes
+const (
+	ChatFilterOff    = "off"    // Messages are not filtered
+	ChatFilterMask   = "mask"   // Blocked words are replaced with asterisks
+	ChatFilterReject = "reject" // Messages with blocked words are refused
+)
+
+// ChatBlockedWord is a word of the chat filter blocklist
+// A trailing * matches every word starting with the rest, e.g. "idiot*"
+type ChatBlockedWord struct {
+	ID        uint64    `json:"id"`
+	Word      string    `json:"word"`
+	Language  string    `json:"language"`             // Preset the word was imported from, empty if added by hand
+	CreatedBy string    `json:"created_by,omitempty"` // Steam ID of the admin
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AddChatBlockedWordRequest is the request body for adding a word to the blocklist
+type AddChatBlockedWordRequest struct {
+	Word string `json:"word" binding:"required"`
+}
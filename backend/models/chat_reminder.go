@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// ChatReminder is a chat message scheduled by an admin, posted as a system message at SendAt
+type ChatReminder struct {
+	ID            uint64     `json:"id"`
+	Message       string     `json:"message"`
+	SendAt        time.Time  `json:"send_at"`
+	CreatedBy     uint64     `json:"created_by"`
+	SentAt        *time.Time `json:"sent_at"`         // nil while the reminder is pending
+	ChatMessageID *uint64    `json:"chat_message_id"` // Posted message, nil while pending
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// CreateChatReminderRequest is the request body for scheduling a chat reminder
+type CreateChatReminderRequest struct {
+	Message string `json:"message" binding:"required,min=1,max=500"`
+	SendAt  string `json:"send_at" binding:"required"` // RFC3339 formatted time
+}
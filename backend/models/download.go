@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// DownloadRequirement is a game or update players should download before the event starts
+type DownloadRequirement struct {
+	ID        uint64    `json:"id"`
+	AppID     *int      `json:"app_id"` // Steam app ID, nil for non-Steam downloads (mods, maps, ...)
+	Name      string    `json:"name"`
+	Version   string    `json:"version"` // Required version or update, e.g. "Patch 1.2.3"
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DownloadReadiness is a requirement with the download progress of all players
+type DownloadReadiness struct {
+	DownloadRequirement
+	ReadyCount   int          `json:"ready_count"`
+	PlayerCount  int          `json:"player_count"`
+	Percentage   int          `json:"percentage"`
+	IsReady      bool         `json:"is_ready"`                // Whether the requesting player confirmed the download
+	MissingUsers []PublicUser `json:"missing_users,omitempty"` // Admin view only
+}
+
+// DownloadRequirementRequest is the request body for creating or updating a download requirement
+type DownloadRequirementRequest struct {
+	AppID   *int   `json:"app_id"`
+	Name    string `json:"name" binding:"required,min=1,max=100"`
+	Version string `json:"version" binding:"max=100"`
+	Note    string `json:"note" binding:"max=500"`
+}
@@ -1,13 +1,18 @@
 package models
 
+import "time"
+
 // Game represents a Steam game with multiplayer information
 type Game struct {
 	AppID           int      `json:"app_id"`
 	Name            string   `json:"name"`
 	HeaderImageURL  string   `json:"header_image_url"`  // 460x215
 	CapsuleImageURL string   `json:"capsule_image_url"` // 231x87
+	LaunchURL       string   `json:"launch_url"`        // steam://run/<appid>, starts the game in the Steam client
 	PlaytimeForever int      `json:"playtime_forever"`  // Total playtime in minutes
 	Categories      []string `json:"categories"`        // e.g., "Multi-player", "Co-op", etc.
+	Genres          []string `json:"genres,omitempty"`  // Steam genres, e.g., "Action", "Racing"
+	Tags            []string `json:"tags,omitempty"`    // Top user tags, most votes first (e.g., "Shooter", "Party")
 	OwnerCount      int      `json:"owner_count"`       // Number of players who own this game
 	Owners          []string `json:"owners"`            // Steam IDs of owners
 	IsPinned        bool     `json:"is_pinned"`         // Whether this game is pinned/featured
@@ -18,9 +23,56 @@ type Game struct {
 	DiscountPercent int    `json:"discount_percent"`  // Discount percentage (0-100)
 	PriceFormatted  string `json:"price_formatted"`   // Formatted price string (e.g., "59,99€" or "Free")
 	// Review information
-	ReviewScore int `json:"review_score"` // Percentage of positive reviews (0-100), -1 if not enough reviews
-	// Custom metadata (manually curated)
-	MaxPlayers int `json:"max_players,omitempty"` // Maximum number of players, 0 if unknown
+	ReviewScore     int    `json:"review_score"`                // Percentage of positive reviews (0-100), -1 if not enough reviews
+	ReviewScoreDesc string `json:"review_score_desc,omitempty"` // Steam review wording, e.g. "Overwhelmingly Positive"
+	ReviewCount     int    `json:"review_count"`                // Total number of reviews
+	// Player counts, curated metadata takes precedence over the store description
+	MaxPlayers int      `json:"max_players,omitempty"` // Maximum number of players, 0 if unknown
+	CoopModes  []string `json:"coop_modes,omitempty"`  // Supported co-op modes (online, lan, local)
+	// Star ratings of the players (1-5)
+	CommunityRating      float64 `json:"community_rating,omitempty"` // Average, one decimal
+	CommunityRatingCount int     `json:"community_rating_count"`
+	// Owner profiles, only filled on request (include_owners)
+	OwnerDetails []GameOwnerInfo `json:"owner_details,omitempty"`
+}
+
+// GameOwnerInfo is a registered player owning a game, most playtime first
+type GameOwnerInfo struct {
+	UserID          uint64 `json:"user_id"`
+	SteamID         string `json:"steam_id"`
+	Username        string `json:"username"`
+	AvatarSmall     string `json:"avatar_small"`
+	PlaytimeForever int    `json:"playtime_forever"` // Total playtime in minutes
+}
+
+// Limits of game star ratings
+const (
+	MinGameRating = 1
+	MaxGameRating = 5
+)
+
+// GameRatingSummary is the average and number of star ratings of a game
+type GameRatingSummary struct {
+	Average float64 `json:"average"` // One decimal, 0 without ratings
+	Count   int     `json:"count"`
+}
+
+// GameRatingRequest is the request body for rating a game
+type GameRatingRequest struct {
+	Rating int `json:"rating" binding:"required,min=1,max=5"`
+}
+
+// GameRatingResponse is the rating of the requesting player next to the ratings of all players
+type GameRatingResponse struct {
+	AppID    int `json:"app_id"`
+	MyRating int `json:"my_rating"` // 0 if not rated
+	GameRatingSummary
+}
+
+// CommonGame is a multiplayer game owned by every player of a selection
+type CommonGame struct {
+	Game
+	CombinedPlaytime int `json:"combined_playtime"` // Sum of the playtime of the selected players in minutes
 }
 
 // GameOwnership represents a player's ownership of a game
@@ -32,10 +84,60 @@ type GameOwnership struct {
 	IconURL         string `json:"icon_url"`
 }
 
+// PinnedGame is a game pinned by admins to the top of the games list
+type PinnedGame struct {
+	AppID          int    `json:"app_id"`
+	Name           string `json:"name"` // Empty while the store data is not cached
+	HeaderImageURL string `json:"header_image_url"`
+}
+
+// PinGameRequest pins a game at the end of the pinned games
+type PinGameRequest struct {
+	AppID int `json:"app_id" binding:"required"`
+}
+
+// ReorderPinnedGamesRequest sets the order of the pinned games, every pinned game must be listed once
+type ReorderPinnedGamesRequest struct {
+	AppIDs []int `json:"app_ids" binding:"required"`
+}
+
 // GamesResponse represents the API response for games
 type GamesResponse struct {
 	PinnedGames []Game `json:"pinned_games"`
 	AllGames    []Game `json:"all_games"`
+	ContentHash string `json:"-"` // Hash of the cached list, set when the in-memory cache is rebuilt
+}
+
+// Sort keys for the games list
+const (
+	GameSortOwners   = "owners"   // Most owners first (default)
+	GameSortPrice    = "price"    // Cheapest first
+	GameSortReview   = "review"   // Best reviews first
+	GameSortPlaytime = "playtime" // Most played first
+	GameSortRating   = "rating"   // Best star rating of the players first
+)
+
+// GameFilter narrows down and sorts the games list, zero values disable a filter
+type GameFilter struct {
+	Search         string // Case-insensitive part of the game name
+	MinOwners      int
+	MaxPriceCents  int // -1 = no price limit
+	Category       string
+	Genre          string // Matches genres and user tags, case-insensitive
+	FreeOnly       bool
+	MinReviewScore int // Games without enough reviews are excluded when set
+	MinPlayers     int // Games with unknown player count are excluded when set
+	CoopOnly       bool
+	Sort           string
+}
+
+// IsValidGameSort checks if a sort key is supported
+func IsValidGameSort(sort string) bool {
+	switch sort {
+	case GameSortOwners, GameSortPrice, GameSortReview, GameSortPlaytime, GameSortRating:
+		return true
+	}
+	return false
 }
 
 // MultiplayerCategories defines which Steam categories indicate multiplayer capability
@@ -66,3 +168,84 @@ func (g *Game) HasMultiplayerCategory() bool {
 	}
 	return false
 }
+
+// Co-op modes derived from the Steam categories
+const (
+	CoopModeOnline = "online"
+	CoopModeLAN    = "lan"
+	CoopModeLocal  = "local"
+)
+
+// coopCategories maps Steam co-op categories to co-op modes
+var coopCategories = map[string]string{
+	"Online Co-op":              CoopModeOnline,
+	"LAN Co-op":                 CoopModeLAN,
+	"Shared/Split Screen Co-op": CoopModeLocal,
+}
+
+// CoopModesFromCategories returns the co-op modes of a game in the order online, lan, local
+func CoopModesFromCategories(categories []string) []string {
+	found := make(map[string]bool)
+	for _, cat := range categories {
+		if mode, ok := coopCategories[cat]; ok {
+			found[mode] = true
+		}
+	}
+
+	var modes []string
+	for _, mode := range []string{CoopModeOnline, CoopModeLAN, CoopModeLocal} {
+		if found[mode] {
+			modes = append(modes, mode)
+		}
+	}
+	return modes
+}
+
+// IsCoop checks if a game supports co-op, including games with only the generic "Co-op" category
+func (g *Game) IsCoop() bool {
+	if len(g.CoopModes) > 0 {
+		return true
+	}
+	for _, cat := range g.Categories {
+		if cat == "Co-op" {
+			return true
+		}
+	}
+	return false
+}
+
+// Kinds of game deals
+const (
+	GameDealDiscount = "discount" // Reduced price
+	GameDealFree     = "free"     // Free weekend or free to keep (100% discount)
+)
+
+// GameDeal is a discount or free weekend of a pinned or commonly owned game
+type GameDeal struct {
+	AppID           int       `json:"app_id"`
+	Name            string    `json:"name"`
+	HeaderImageURL  string    `json:"header_image_url"`
+	OwnerCount      int       `json:"owner_count"`
+	IsPinned        bool      `json:"is_pinned"`
+	Kind            string    `json:"kind"`
+	DiscountPercent int       `json:"discount_percent"`
+	PriceCents      int       `json:"price_cents"`
+	OriginalCents   int       `json:"original_cents"`
+	PriceFormatted  string    `json:"price_formatted"`
+	DetectedAt      time.Time `json:"detected_at"`
+}
+
+// GameNewsItem is a Steam news post (patch notes, announcement) of a commonly owned game
+type GameNewsItem struct {
+	GID          string    `json:"gid"`
+	AppID        int       `json:"app_id"`
+	GameName     string    `json:"game_name"`
+	OwnerCount   int       `json:"owner_count"` // Number of players who own the game
+	Title        string    `json:"title"`
+	URL          string    `json:"url"`
+	Author       string    `json:"author"`
+	Contents     string    `json:"contents"` // Shortened by Steam, may contain BBCode
+	FeedLabel    string    `json:"feed_label"`
+	IsPatchNotes bool      `json:"is_patch_notes"` // Tagged as patch notes, likely means an update download
+	Date         time.Time `json:"date"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Limits of game server connect strings
+const (
+	MaxGameServerAddressLength = 100
+	MaxGameServerNoteLength    = 200
+)
+
+// GameServer is a running server of a game the players can join, e.g. the CS2 server of the LAN party
+type GameServer struct {
+	AppID     int        `json:"app_id"`
+	GameName  string     `json:"game_name"`
+	Address   string     `json:"address"` // host or host:port
+	Note      string     `json:"note,omitempty"`
+	JoinURL   string     `json:"join_url"`   // steam://connect/<address>
+	LaunchURL string     `json:"launch_url"` // steam://run/<appid>, for games without server join support
+	UpdatedBy PublicUser `json:"updated_by"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// GameServerRequest is the request body for announcing a game server
+type GameServerRequest struct {
+	Address string `json:"address" binding:"required"`
+	Note    string `json:"note"`
+}
+
+// SteamLaunchURL returns the Steam client URL starting a game
+func SteamLaunchURL(appID int) string {
+	return fmt.Sprintf("steam://run/%d", appID)
+}
+
+// SteamConnectURL returns the Steam client URL joining a game server
+func SteamConnectURL(address string) string {
+	return "steam://connect/" + address
+}
@@ -0,0 +1,80 @@
+package models
+
+import "time"
+
+// Sources of game sessions
+const (
+	GameSessionSourceManual   = "manual"   // Started by the player
+	GameSessionSourcePresence = "presence" // Inferred from the "currently playing" status of the Steam profile
+)
+
+// GameSession is a time span a player spent in a game
+type GameSession struct {
+	ID        uint64     `json:"id"`
+	User      PublicUser `json:"user"`
+	AppID     int        `json:"app_id"`
+	Source    string     `json:"source"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"` // nil while the player is still playing
+}
+
+// Minutes returns the length of the session, running sessions count up to now
+func (s *GameSession) Minutes(now time.Time) int {
+	end := now
+	if s.EndedAt != nil {
+		end = *s.EndedAt
+	}
+	if end.Before(s.StartedAt) {
+		return 0
+	}
+	return int(end.Sub(s.StartedAt).Minutes())
+}
+
+// GameSessionStartRequest is the request body for starting a game session
+type GameSessionStartRequest struct {
+	AppID int `json:"app_id" binding:"required"`
+}
+
+// ActivePlayer is a player currently in a game
+type ActivePlayer struct {
+	PublicUser
+	Source string    `json:"source"`
+	Since  time.Time `json:"since"`
+}
+
+// ActiveGame is a game that is currently played with its players
+type ActiveGame struct {
+	AppID     int            `json:"app_id"`
+	GameName  string         `json:"game_name"`
+	LaunchURL string         `json:"launch_url"`
+	Players   []ActivePlayer `json:"players"`
+}
+
+// GameSessionGameStats is the time spent in a game by all players
+type GameSessionGameStats struct {
+	AppID         int    `json:"app_id"`
+	GameName      string `json:"game_name"`
+	Minutes       int    `json:"minutes"`        // Time at least one player was in the game
+	PlayerMinutes int    `json:"player_minutes"` // Sum of the time of all players
+	PlayerCount   int    `json:"player_count"`   // Players who played the game
+}
+
+// GameSessionPlayerGame is the time a player spent in a game
+type GameSessionPlayerGame struct {
+	AppID    int    `json:"app_id"`
+	GameName string `json:"game_name"`
+	Minutes  int    `json:"minutes"`
+}
+
+// GameSessionPlayerStats is the time a player spent in games, most played game first
+type GameSessionPlayerStats struct {
+	User         PublicUser              `json:"user"`
+	TotalMinutes int                     `json:"total_minutes"`
+	Games        []GameSessionPlayerGame `json:"games"`
+}
+
+// GameSessionStats summarizes all game sessions of the party
+type GameSessionStats struct {
+	Games   []GameSessionGameStats   `json:"games"`   // Most played first
+	Players []GameSessionPlayerStats `json:"players"` // Most playtime first
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Types of personal notifications, named like the WebSocket message that delivers them live
+const (
+	NotificationTypeVoteReceived = "vote_received"
+)
+
+// Notification is a personal notification, stored so players who were offline still get it
+type Notification struct {
+	ID        uint64          `json:"id"`
+	UserID    uint64          `json:"user_id"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"` // Payload of the WebSocket message
+	VoteID    uint64          `json:"-"`       // Vote of a vote_received notification, its payload is built when it is read
+	IsRead    bool            `json:"is_read"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// NotificationsResponse is the response of the notification list
+type NotificationsResponse struct {
+	Notifications []Notification `json:"notifications"`
+	UnreadCount   int            `json:"unread_count"`
+}
+
+// MarkNotificationsReadRequest is the request body for marking notifications as read
+type MarkNotificationsReadRequest struct {
+	IDs []uint64 `json:"ids"` // Empty marks all notifications of the user as read
+}
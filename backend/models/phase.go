@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// EventPhase is a section of a multi-day event (e.g. "Saturday tournament")
+// with its own settings profile that is applied when the phase starts
+type EventPhase struct {
+	ID                     uint64    `json:"id"`
+	Name                   string    `json:"name"`
+	StartsAt               time.Time `json:"starts_at"`
+	CreditIntervalMinutes  int       `json:"credit_interval_minutes"`
+	NegativeVotingDisabled bool      `json:"negative_voting_disabled"`
+	VoteVisibilityMode     string    `json:"vote_visibility_mode"` // "user_choice", "all_secret", "all_public"
+	CreatedAt              time.Time `json:"created_at"`
+	UpdatedAt              time.Time `json:"updated_at"`
+}
@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+// Limits of game polls
+const (
+	MinPollOptions        = 2
+	MaxPollOptions        = 10
+	MaxPollQuestionLength = 200
+	MaxOpenPollsPerUser   = 2    // Open polls a player may have at the same time
+	MaxPollDuration       = 1440 // Minutes, longest automatic end of a poll
+)
+
+// DefaultPollQuestion is used when a poll is created without question
+const DefaultPollQuestion = "Was spielen wir als Nächstes?"
+
+// Poll is a "what do we play next?" poll with the current results
+type Poll struct {
+	ID         uint64       `json:"id"`
+	Question   string       `json:"question"`
+	CreatedBy  PublicUser   `json:"created_by"`
+	ClosesAt   *time.Time   `json:"closes_at"` // Automatic end, nil until closed manually
+	ClosedAt   *time.Time   `json:"closed_at"` // Set when the poll was closed manually
+	IsClosed   bool         `json:"is_closed"`
+	TotalVotes int          `json:"total_votes"`
+	Options    []PollOption `json:"options"`
+	MyOptionID *uint64      `json:"my_option_id,omitempty"` // Option the requesting player voted for
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// PollOption is a game of a poll with its votes
+// Game details come from the games list and stay empty if the game is no longer listed
+type PollOption struct {
+	ID             uint64 `json:"id"`
+	AppID          int    `json:"app_id"`
+	Name           string `json:"name"`
+	HeaderImageURL string `json:"header_image_url,omitempty"`
+	OwnerCount     int    `json:"owner_count"`
+	MaxPlayers     int    `json:"max_players,omitempty"`
+	Votes          int    `json:"votes"`
+	IsWinner       bool   `json:"is_winner"` // Most votes, also set for every option of a tie
+}
+
+// Evaluate sets whether the poll is closed at the given time, the total votes and the winners
+func (p *Poll) Evaluate(now time.Time) {
+	p.IsClosed = p.ClosedAt != nil || (p.ClosesAt != nil && !now.Before(*p.ClosesAt))
+
+	p.TotalVotes = 0
+	maxVotes := 0
+	for _, o := range p.Options {
+		p.TotalVotes += o.Votes
+		maxVotes = max(maxVotes, o.Votes)
+	}
+	for i := range p.Options {
+		p.Options[i].IsWinner = maxVotes > 0 && p.Options[i].Votes == maxVotes
+	}
+}
+
+// HasOption checks whether an option belongs to the poll
+func (p *Poll) HasOption(optionID uint64) bool {
+	for _, o := range p.Options {
+		if o.ID == optionID {
+			return true
+		}
+	}
+	return false
+}
+
+// CreatePollRequest is the request body for creating a poll
+type CreatePollRequest struct {
+	Question        string `json:"question"`                         // Optional, DefaultPollQuestion if empty
+	AppIDs          []int  `json:"app_ids" binding:"required"`       // Games from the multiplayer games list
+	DurationMinutes int    `json:"duration_minutes" binding:"min=0"` // 0 = open until closed manually
+}
+
+// PollVoteRequest is the request body for voting in a poll
+type PollVoteRequest struct {
+	OptionID uint64 `json:"option_id" binding:"required"`
+}
@@ -0,0 +1,36 @@
+package models
+
+// Ranking tie-break rules, applied in the configured order to players with the same total score
+const (
+	TieBreakEarliest       = "earliest"        // Reached the score first
+	TieBreakFewestNegative = "fewest_negative" // Fewer weighted negative points received
+	TieBreakHeadToHead     = "head_to_head"    // More weighted net points received from the other tied players
+)
+
+// TieBreakRule describes a tie-break rule in the ranking response
+type TieBreakRule struct {
+	Rule        string `json:"rule"`
+	Description string `json:"description"`
+}
+
+// tieBreakDescriptions explain the rules to players
+var tieBreakDescriptions = map[string]string{
+	TieBreakEarliest:       "Wer die Punktzahl zuerst erreicht hat (letzte wertende Stimme früher)",
+	TieBreakFewestNegative: "Wer weniger negative Stimmen erhalten hat",
+	TieBreakHeadToHead:     "Wer im direkten Vergleich mehr Stimmen von den anderen Punktgleichen erhalten hat",
+}
+
+// IsValidTieBreak checks if a tie-break rule name is known
+func IsValidTieBreak(rule string) bool {
+	_, ok := tieBreakDescriptions[rule]
+	return ok
+}
+
+// DescribeTieBreaks returns the descriptions of the given rules in order
+func DescribeTieBreaks(rules []string) []TieBreakRule {
+	result := make([]TieBreakRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, TieBreakRule{Rule: rule, Description: tieBreakDescriptions[rule]})
+	}
+	return result
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Roles that can be granted at runtime
+const (
+	RoleAdmin = "admin"
+)
+
+// UserRole is a role granted to a Steam account
+type UserRole struct {
+	SteamID   string    `json:"steam_id"`
+	Role      string    `json:"role"`
+	GrantedBy string    `json:"granted_by"` // Steam ID of the granting admin, "setup" for the first-run setup code
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SettingsProfile is a named snapshot of the admin settings that can be applied in one go
+type SettingsProfile struct {
+	ID        uint64          `json:"id"`
+	Name      string          `json:"name"`
+	Settings  json.RawMessage `json:"settings"` // Same shape as the body of PUT /api/v1/admin/settings
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// ShortLink maps a memorable slug to an external URL, served under /go/:slug
+type ShortLink struct {
+	Slug      string    `json:"slug"`
+	TargetURL string    `json:"target_url"`
+	Title     string    `json:"title"`
+	Hits      int       `json:"hits"` // Number of redirects served
+	ShortURL  string    `json:"short_url"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ShortLinkRequest is the request body for creating or updating a short link
+type ShortLinkRequest struct {
+	Slug      string `json:"slug"` // Only used on create, immutable afterwards
+	TargetURL string `json:"target_url" binding:"required,max=500"`
+	Title     string `json:"title" binding:"max=100"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Limits of spectator accounts
+const (
+	MaxSpectatorNameLength = 50
+	MinSpectatorPasscode   = 6
+)
+
+// Spectator is a read-only account for people following the event without Steam login,
+// e.g. parents or partners. Several people may share one account and its passcode.
+type Spectator struct {
+	ID          uint64     `json:"id"`
+	Name        string     `json:"name"`
+	CreatedBy   string     `json:"created_by"` // Steam ID of the admin
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// CreateSpectatorRequest is the request body for creating a spectator account
+type CreateSpectatorRequest struct {
+	Name     string `json:"name"`
+	Passcode string `json:"passcode"`
+}
+
+// SpectatorLoginRequest is the request body for the spectator login
+type SpectatorLoginRequest struct {
+	Name     string `json:"name"`
+	Passcode string `json:"passcode"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// SteamAchievementUnlock is a recently unlocked Steam achievement
+type SteamAchievementUnlock struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	UnlockedAt  time.Time `json:"unlocked_at"`
+}
+
+// SteamGameProgress is a player's Steam achievement progress in a pinned game
+type SteamGameProgress struct {
+	AppID             int                      `json:"app_id"`
+	Name              string                   `json:"name"`
+	Unlocked          int                      `json:"unlocked"`
+	Total             int                      `json:"total"`
+	CompletionPercent float64                  `json:"completion_percent"`
+	PlaytimeMinutes   int                      `json:"playtime_minutes"`
+	RecentUnlocks     []SteamAchievementUnlock `json:"recent_unlocks"` // Newest first
+}
+
+// SteamShowcase lists the Steam achievements of a player for the pinned games
+type SteamShowcase struct {
+	Games     []SteamGameProgress `json:"games"`
+	IsPrivate bool                `json:"is_private"` // Game details of the Steam profile are not public
+	FetchedAt time.Time           `json:"fetched_at"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// SyncCheckpointSteamGames is the checkpoint of the Steam game data sync
+const SyncCheckpointSteamGames = "steam_games"
+
+// SyncCheckpoint is the position of a running background sync
+// Games are synced in app ID order, everything up to LastAppID is done
+type SyncCheckpoint struct {
+	Name      string
+	BatchID   string
+	LastAppID int
+	Processed int
+	Total     int
+}
+
+// SteamRateLimitStatus is the state of the Steam Store rate limiter, reported with the sync status
+type SteamRateLimitStatus struct {
+	Paused            bool       `json:"paused"`
+	PausedUntil       *time.Time `json:"paused_until,omitempty"`
+	Strikes           int        `json:"strikes"`             // 429 responses in a row, each doubles the next pause
+	RequestsPerMinute int        `json:"requests_per_minute"` // Current rate, lowered after 429 responses
+}
@@ -0,0 +1,38 @@
+package models
+
+// Limits of the team balancer
+const (
+	MinTeamCount        = 2
+	MaxTeamCount        = 8
+	MaxTeamBalanceUsers = 64
+)
+
+// TeamBalanceRequest is the request body for splitting players into teams
+type TeamBalanceRequest struct {
+	UserIDs   []uint64 `json:"user_ids" binding:"required"`
+	TeamCount int      `json:"team_count"` // 0 = two teams
+}
+
+// TeamPlayer is a player of a balanced team with the skill signals the split was based on
+type TeamPlayer struct {
+	PublicUser
+	Skill           float64 `json:"skill"`            // 0-100, combination of the signals below
+	PositiveVotes   int     `json:"positive_votes"`   // Valid votes received for positive achievements
+	Rank            int     `json:"rank"`             // Global rank, 0 if the player is not in the public ranking
+	PlaytimeMinutes int     `json:"playtime_minutes"` // Playtime in the game, 0 if not owned or hidden
+}
+
+// BalancedTeam is one team of a split
+type BalancedTeam struct {
+	Number  int          `json:"number"` // 1-based
+	Skill   float64      `json:"skill"`  // Sum of the skill of the players
+	Players []TeamPlayer `json:"players"`
+}
+
+// TeamBalance is a split of players into teams of similar skill for a game
+type TeamBalance struct {
+	AppID    int            `json:"app_id"`
+	GameName string         `json:"game_name"`
+	Teams    []BalancedTeam `json:"teams"`
+	Spread   float64        `json:"spread"` // Skill difference between the strongest and the weakest team
+}
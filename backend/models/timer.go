@@ -0,0 +1,8 @@
+package models
+
+// Names of the persisted timers
+const (
+	TimerCountdown      = "countdown"        // Lifts the voting pause when it expires
+	TimerSecretReveal   = "secret_reveal"    // Reveals all secret votes when it expires
+	TimerSteamRateLimit = "steam_rate_limit" // Lifts the Steam Store API pause after a 429 response
+)
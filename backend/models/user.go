@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // User represents a registered player
 type User struct {
@@ -13,10 +16,20 @@ type User struct {
 	Credits            int        `json:"credits"`
 	LastCreditAt       time.Time  `json:"last_credit_at"`
 	LastGamesRefreshAt *time.Time `json:"last_games_refresh_at"`
+	HideFromRanking    bool       `json:"hide_from_ranking"` // Opted out of the public ranking and leaderboard
+	CountryCode        string     `json:"country_code"`      // ISO 3166-1 alpha-2 code from the Steam profile (may be empty)
+	Timezone           string     `json:"timezone"`          // Preferred IANA timezone (empty = event timezone)
+	Language           string     `json:"language"`          // Preferred language of server texts (empty = Accept-Language header)
+	ReducedMotion      bool       `json:"reduced_motion"`    // Prefers effects without confetti, flashing and sounds
+	PendingReview      bool       `json:"-"`                 // Flagged at registration, hidden from other players until an admin approves the account
 	CreatedAt          time.Time  `json:"created_at"`
 	UpdatedAt          time.Time  `json:"updated_at"`
 }
 
+// FormerPlayerName replaces the username of kicked and banned users
+// Their votes and chat messages are kept and shown as sent by a former player
+const FormerPlayerName = "Ehemaliger Spieler"
+
 // PublicUser represents the public-facing user data (no sensitive info)
 type PublicUser struct {
 	ID          uint64 `json:"id"`
@@ -25,6 +38,8 @@ type PublicUser struct {
 	AvatarURL   string `json:"avatar_url"`
 	AvatarSmall string `json:"avatar_small"`
 	ProfileURL  string `json:"profile_url"`
+	CountryCode string `json:"country_code,omitempty"`
+	Flag        string `json:"flag,omitempty"` // Emoji flag derived from the country code
 }
 
 // ToPublic converts a User to PublicUser
@@ -36,7 +51,27 @@ func (u *User) ToPublic() PublicUser {
 		AvatarURL:   u.AvatarURL,
 		AvatarSmall: u.AvatarSmall,
 		ProfileURL:  u.ProfileURL,
+		CountryCode: u.CountryCode,
+		Flag:        CountryFlag(u.CountryCode),
+	}
+}
+
+// CountryFlag returns the emoji flag for an ISO 3166-1 alpha-2 country code
+// Returns an empty string for missing or invalid codes
+func CountryFlag(countryCode string) string {
+	if len(countryCode) != 2 {
+		return ""
+	}
+
+	// A flag is the pair of regional indicator symbols matching the two letters
+	flag := make([]rune, 0, 2)
+	for _, c := range strings.ToUpper(countryCode) {
+		if c < 'A' || c > 'Z' {
+			return ""
+		}
+		flag = append(flag, 0x1F1E6+(c-'A'))
 	}
+	return string(flag)
 }
 
 // BannedUser represents a banned player
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// MaxMuteMinutes is the longest chat mute an admin can set (7 days)
+const MaxMuteMinutes = 7 * 24 * 60
+
+// UserMute is a chat mute set by an admin
+type UserMute struct {
+	UserID       uint64    `json:"user_id"`
+	Username     string    `json:"username"`
+	MutedUntil   time.Time `json:"muted_until"`
+	IncludeVotes bool      `json:"include_votes"` // The user can't vote either
+	Reason       string    `json:"reason"`
+	MutedBy      string    `json:"muted_by"` // Steam ID of the admin
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// MuteUserRequest is the request body for muting a user
+type MuteUserRequest struct {
+	DurationMinutes int    `json:"duration_minutes" binding:"required"`
+	Reason          string `json:"reason"`
+	IncludeVotes    bool   `json:"include_votes"`
+}
@@ -11,7 +11,9 @@ type Vote struct {
 	Points        int       `json:"points"`
 	IsSecret      bool      `json:"is_secret"`
 	IsInvalidated bool      `json:"is_invalidated"`
+	IsRevealed    bool      `json:"is_revealed"`
 	Comment       *string   `json:"comment,omitempty"`
+	AppID         *int      `json:"app_id,omitempty"` // Steam app the vote was cast in the context of
 	CreatedAt     time.Time `json:"created_at"`
 }
 
@@ -25,8 +27,34 @@ type VoteWithDetails struct {
 	Points        int         `json:"points"`
 	IsSecret      bool        `json:"is_secret"`
 	IsInvalidated bool        `json:"is_invalidated"`
+	IsRevealed    bool        `json:"is_revealed"` // True once the scheduled reveal has uncovered the sender
 	Comment       *string     `json:"comment,omitempty"`
+	AppID         *int        `json:"app_id,omitempty"`
 	CreatedAt     time.Time   `json:"created_at"`
+	// Invalidation details, only populated for admin views
+	Invalidation *VoteInvalidation `json:"invalidation,omitempty"`
+}
+
+// VoteInvalidation records who invalidated a vote, when and why
+type VoteInvalidation struct {
+	InvalidatedBy string    `json:"invalidated_by"` // Steam ID of the admin
+	InvalidatedAt time.Time `json:"invalidated_at"`
+	Reason        string    `json:"reason"`
+}
+
+// VoteInvalidationLogEntry is a single entry of the invalidation audit trail
+type VoteInvalidationLogEntry struct {
+	ID            uint64    `json:"id"`
+	VoteID        uint64    `json:"vote_id"`
+	AdminSteamID  string    `json:"admin_steam_id"`
+	IsInvalidated bool      `json:"is_invalidated"`
+	Reason        string    `json:"reason"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ToggleInvalidationRequest is the request body for toggling a vote's invalidation
+type ToggleInvalidationRequest struct {
+	Reason string `json:"reason"` // optional free-text reason, max 500 characters
 }
 
 // CreateVoteRequest is the request body for creating a vote
@@ -36,6 +64,7 @@ type CreateVoteRequest struct {
 	Points        int     `json:"points"`    // 1-3 points, defaults to 1 if not provided
 	IsSecret      *bool   `json:"is_secret"` // nil = use default (negative=secret, positive=open)
 	Comment       *string `json:"comment"`   // optional comment, max 160 characters
+	AppID         *int    `json:"app_id"`    // optional Steam app the vote is cast in the context of, required for game-scoped achievements
 }
 
 // AnonymousUser returns an anonymous PublicUser for secret votes
@@ -52,7 +81,12 @@ func AnonymousUser() PublicUser {
 
 // ApplyVisibilityMode applies the visibility mode to a vote
 // visibilityMode can be: "user_choice", "all_secret", "all_public"
+// Revealed votes are never anonymized, regardless of the visibility mode
 func (v *VoteWithDetails) ApplyVisibilityMode(visibilityMode string) {
+	if v.IsRevealed {
+		return
+	}
+
 	shouldAnonymize := false
 
 	switch visibilityMode {
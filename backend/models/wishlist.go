@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// WishlistItem is a game on the cached Steam wishlist of a player
+type WishlistItem struct {
+	AppID    int
+	Priority int        // Position set by the player, 0 = not ranked
+	AddedAt  *time.Time // nil if Steam did not report it
+}
+
+// WishlistGame is a game wishlisted by players with its current price, for group purchases
+type WishlistGame struct {
+	AppID           int          `json:"app_id"`
+	Name            string       `json:"name"`
+	HeaderImageURL  string       `json:"header_image_url"`
+	IsFree          bool         `json:"is_free"`
+	PriceCents      int          `json:"price_cents"`
+	OriginalCents   int          `json:"original_cents"`
+	DiscountPercent int          `json:"discount_percent"`
+	PriceFormatted  string       `json:"price_formatted"` // Empty until the store details were synced
+	WishlistCount   int          `json:"wishlist_count"`
+	Users           []PublicUser `json:"users"`
+}
@@ -0,0 +1,104 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Thrift compact protocol types
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes Parquet metadata with the Thrift compact protocol
+// The top-level struct is implicit, it is closed by the last structEnd
+type thriftWriter struct {
+	buf    bytes.Buffer
+	lastID int16   // ID of the previous field in the current struct
+	stack  []int16 // lastID of the enclosing structs
+}
+
+// fieldHeader writes a field header, small ID deltas are packed into the type byte
+func (w *thriftWriter) fieldHeader(id int16, fieldType byte) {
+	delta := id - w.lastID
+	if delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | fieldType)
+	} else {
+		w.buf.WriteByte(fieldType)
+		w.varint(int32(id))
+	}
+	w.lastID = id
+}
+
+// varint writes a zigzag encoded integer
+func (w *thriftWriter) varint(v int32) {
+	w.varint64(int64(v))
+}
+
+// varint64 writes a zigzag encoded 64-bit integer
+func (w *thriftWriter) varint64(v int64) {
+	var scratch [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(scratch[:], uint64((v<<1)^(v>>63)))
+	w.buf.Write(scratch[:n])
+}
+
+// binary writes a length-prefixed string
+func (w *thriftWriter) binary(s string) {
+	var scratch [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(scratch[:], uint64(len(s)))
+	w.buf.Write(scratch[:n])
+	w.buf.WriteString(s)
+}
+
+func (w *thriftWriter) i32Field(id int16, v int32) {
+	w.fieldHeader(id, thriftI32)
+	w.varint(v)
+}
+
+func (w *thriftWriter) i64Field(id int16, v int64) {
+	w.fieldHeader(id, thriftI64)
+	w.varint64(v)
+}
+
+func (w *thriftWriter) binaryField(id int16, s string) {
+	w.fieldHeader(id, thriftBinary)
+	w.binary(s)
+}
+
+// listField writes the header of a list field, the elements follow
+func (w *thriftWriter) listField(id int16, elemType byte, size int) {
+	w.fieldHeader(id, thriftList)
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elemType)
+		return
+	}
+	w.buf.WriteByte(0xF0 | elemType)
+	var scratch [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(scratch[:], uint64(size))
+	w.buf.Write(scratch[:n])
+}
+
+// structField starts a struct field, it must be closed with structEnd
+func (w *thriftWriter) structField(id int16) {
+	w.fieldHeader(id, thriftStruct)
+	w.structBegin()
+}
+
+// structBegin starts a struct, e.g. a list element
+func (w *thriftWriter) structBegin() {
+	w.stack = append(w.stack, w.lastID)
+	w.lastID = 0
+}
+
+// structEnd writes the stop byte of the current struct
+func (w *thriftWriter) structEnd() {
+	w.buf.WriteByte(0)
+	if len(w.stack) > 0 {
+		w.lastID = w.stack[len(w.stack)-1]
+		w.stack = w.stack[:len(w.stack)-1]
+	}
+}
@@ -0,0 +1,311 @@
+// Package parquet writes small, uncompressed Parquet files for data exports
+// Only flat schemas with PLAIN encoded columns are supported, which DuckDB, pandas & co. read fine
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ColumnType is the type of a column
+type ColumnType int
+
+// Supported column types, the Go value of a row field is given in brackets
+const (
+	Int64     ColumnType = iota // int64
+	Double                      // float64
+	Bool                        // bool
+	String                      // string
+	Timestamp                   // time.Time, stored as milliseconds since the epoch in UTC
+)
+
+// Column describes a column of the file
+type Column struct {
+	Name     string
+	Type     ColumnType
+	Optional bool // Row fields may be nil
+}
+
+// Parquet physical types, converted types and enums (see parquet.thrift)
+const (
+	physicalBoolean   = 0
+	physicalInt64     = 2
+	physicalDouble    = 5
+	physicalByteArray = 6
+
+	convertedUTF8            = 0
+	convertedTimestampMillis = 9
+
+	repetitionRequired = 0
+	repetitionOptional = 1
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	pageTypeData = 0
+)
+
+var magic = []byte("PAR1")
+
+// Encode writes the rows as a Parquet file with a single row group
+// Each row holds one value per column in column order
+func Encode(columns []Column, rows [][]interface{}) ([]byte, error) {
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return nil, fmt.Errorf("row %d has %d values, expected %d", i, len(row), len(columns))
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.Write(magic)
+
+	var chunks []columnChunk
+	var totalSize int64
+	if len(rows) > 0 {
+		for i, column := range columns {
+			chunk, err := writeColumn(&buf, column, i, rows)
+			if err != nil {
+				return nil, err
+			}
+			chunks = append(chunks, chunk)
+			totalSize += chunk.size
+		}
+	}
+
+	meta := fileMetadata(columns, chunks, int64(len(rows)), totalSize)
+	buf.Write(meta)
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(meta)))
+	buf.Write(length[:])
+	buf.Write(magic)
+
+	return buf.Bytes(), nil
+}
+
+// columnChunk describes a written column for the file metadata
+type columnChunk struct {
+	column    Column
+	offset    int64
+	size      int64
+	numValues int64
+}
+
+// writeColumn writes one data page with all values of a column
+func writeColumn(buf *bytes.Buffer, column Column, index int, rows [][]interface{}) (columnChunk, error) {
+	var page bytes.Buffer
+
+	// Definition levels tell readers which values of optional columns are null
+	if column.Optional {
+		levels := make([]bool, len(rows))
+		for i, row := range rows {
+			levels[i] = row[index] != nil
+		}
+		encoded := encodeDefinitionLevels(levels)
+		var length [4]byte
+		binary.LittleEndian.PutUint32(length[:], uint32(len(encoded)))
+		page.Write(length[:])
+		page.Write(encoded)
+	}
+
+	var bits []bool
+	for i, row := range rows {
+		value := row[index]
+		if value == nil {
+			if !column.Optional {
+				return columnChunk{}, fmt.Errorf("row %d: column %s is not optional", i, column.Name)
+			}
+			continue
+		}
+		if err := writeValue(&page, &bits, column, value); err != nil {
+			return columnChunk{}, fmt.Errorf("row %d: %w", i, err)
+		}
+	}
+	if column.Type == Bool {
+		page.Write(packBits(bits))
+	}
+
+	header := pageHeader(page.Len(), len(rows))
+	chunk := columnChunk{
+		column:    column,
+		offset:    int64(buf.Len()),
+		size:      int64(len(header) + page.Len()),
+		numValues: int64(len(rows)),
+	}
+	buf.Write(header)
+	buf.Write(page.Bytes())
+
+	return chunk, nil
+}
+
+// writeValue appends a PLAIN encoded value, booleans are collected and bit-packed at the end
+func writeValue(page *bytes.Buffer, bits *[]bool, column Column, value interface{}) error {
+	var scratch [8]byte
+	switch column.Type {
+	case Int64:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("column %s expects int64, got %T", column.Name, value)
+		}
+		binary.LittleEndian.PutUint64(scratch[:], uint64(v))
+		page.Write(scratch[:])
+	case Timestamp:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("column %s expects time.Time, got %T", column.Name, value)
+		}
+		binary.LittleEndian.PutUint64(scratch[:], uint64(v.UnixMilli()))
+		page.Write(scratch[:])
+	case Double:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("column %s expects float64, got %T", column.Name, value)
+		}
+		binary.LittleEndian.PutUint64(scratch[:], math.Float64bits(v))
+		page.Write(scratch[:])
+	case String:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("column %s expects string, got %T", column.Name, value)
+		}
+		binary.LittleEndian.PutUint32(scratch[:4], uint32(len(v)))
+		page.Write(scratch[:4])
+		page.WriteString(v)
+	case Bool:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("column %s expects bool, got %T", column.Name, value)
+		}
+		*bits = append(*bits, v)
+	default:
+		return fmt.Errorf("column %s has unknown type %d", column.Name, column.Type)
+	}
+	return nil
+}
+
+// packBits packs booleans LSB first as required by the PLAIN encoding
+func packBits(bits []bool) []byte {
+	packed := make([]byte, (len(bits)+7)/8)
+	for i, bit := range bits {
+		if bit {
+			packed[i/8] |= 1 << (i % 8)
+		}
+	}
+	return packed
+}
+
+// encodeDefinitionLevels encodes levels of bit width 1 as RLE runs
+func encodeDefinitionLevels(levels []bool) []byte {
+	var out []byte
+	for i := 0; i < len(levels); {
+		run := 1
+		for i+run < len(levels) && levels[i+run] == levels[i] {
+			run++
+		}
+		out = binary.AppendUvarint(out, uint64(run)<<1)
+		if levels[i] {
+			out = append(out, 1)
+		} else {
+			out = append(out, 0)
+		}
+		i += run
+	}
+	return out
+}
+
+// physicalType maps a column type to its Parquet physical type
+func physicalType(t ColumnType) int32 {
+	switch t {
+	case Double:
+		return physicalDouble
+	case Bool:
+		return physicalBoolean
+	case String:
+		return physicalByteArray
+	default:
+		return physicalInt64
+	}
+}
+
+// pageHeader encodes the PageHeader of an uncompressed data page
+func pageHeader(size, numValues int) []byte {
+	w := &thriftWriter{}
+	w.i32Field(1, pageTypeData)
+	w.i32Field(2, int32(size))
+	w.i32Field(3, int32(size))
+	w.structField(5)
+	w.i32Field(1, int32(numValues))
+	w.i32Field(2, encodingPlain)
+	w.i32Field(3, encodingRLE)
+	w.i32Field(4, encodingRLE)
+	w.structEnd()
+	w.structEnd()
+	return w.buf.Bytes()
+}
+
+// fileMetadata encodes the FileMetaData footer
+func fileMetadata(columns []Column, chunks []columnChunk, numRows, totalSize int64) []byte {
+	w := &thriftWriter{}
+	w.i32Field(1, 1)
+
+	// Schema: a root element followed by one element per column
+	w.listField(2, thriftStruct, len(columns)+1)
+	w.structBegin()
+	w.binaryField(4, "schema")
+	w.i32Field(5, int32(len(columns)))
+	w.structEnd()
+	for _, column := range columns {
+		w.structBegin()
+		w.i32Field(1, physicalType(column.Type))
+		if column.Optional {
+			w.i32Field(3, repetitionOptional)
+		} else {
+			w.i32Field(3, repetitionRequired)
+		}
+		w.binaryField(4, column.Name)
+		switch column.Type {
+		case String:
+			w.i32Field(6, convertedUTF8)
+		case Timestamp:
+			w.i32Field(6, convertedTimestampMillis)
+		}
+		w.structEnd()
+	}
+
+	w.i64Field(3, numRows)
+
+	if len(chunks) == 0 {
+		w.listField(4, thriftStruct, 0)
+	} else {
+		w.listField(4, thriftStruct, 1)
+		w.structBegin()
+		w.listField(1, thriftStruct, len(chunks))
+		for _, chunk := range chunks {
+			w.structBegin()
+			w.i64Field(2, chunk.offset)
+			w.structField(3)
+			w.i32Field(1, physicalType(chunk.column.Type))
+			w.listField(2, thriftI32, 2)
+			w.varint(encodingPlain)
+			w.varint(encodingRLE)
+			w.listField(3, thriftBinary, 1)
+			w.binary(chunk.column.Name)
+			w.i32Field(4, 0) // Uncompressed
+			w.i64Field(5, chunk.numValues)
+			w.i64Field(6, chunk.size)
+			w.i64Field(7, chunk.size)
+			w.i64Field(9, chunk.offset)
+			w.structEnd()
+			w.structEnd()
+		}
+		w.i64Field(2, totalSize)
+		w.i64Field(3, numRows)
+		w.structEnd()
+	}
+
+	w.binaryField(6, "rate-your-mate")
+	w.structEnd()
+	return w.buf.Bytes()
+}
@@ -0,0 +1,396 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// thriftReader decodes the Thrift compact protocol generically for the tests
+// Structs become maps from field ID to value, lists become slices
+type thriftReader struct {
+	t    *testing.T
+	data []byte
+	pos  int
+}
+
+func (r *thriftReader) byte() byte {
+	if r.pos >= len(r.data) {
+		r.t.Fatalf("thrift: unexpected end of data at %d", r.pos)
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		r.t.Fatalf("thrift: invalid varint at %d", r.pos)
+	}
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) zigzag() int64 {
+	v := r.uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(fieldType byte) interface{} {
+	switch fieldType {
+	case thriftI32:
+		return int32(r.zigzag())
+	case thriftI64:
+		return r.zigzag()
+	case thriftBinary:
+		n := int(r.uvarint())
+		s := string(r.data[r.pos : r.pos+n])
+		r.pos += n
+		return s
+	case thriftList:
+		header := r.byte()
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := make([]interface{}, size)
+		for i := range list {
+			list[i] = r.value(header & 0x0F)
+		}
+		return list
+	case thriftStruct:
+		return r.structValue()
+	}
+	r.t.Fatalf("thrift: unsupported type %d at %d", fieldType, r.pos)
+	return nil
+}
+
+func (r *thriftReader) structValue() map[int16]interface{} {
+	fields := make(map[int16]interface{})
+	var lastID int16
+	for {
+		header := r.byte()
+		if header == 0 {
+			return fields
+		}
+		id := lastID + int16(header>>4)
+		if header>>4 == 0 {
+			id = int16(r.zigzag())
+		}
+		fields[id] = r.value(header & 0x0F)
+		lastID = id
+	}
+}
+
+// decodedColumn is a column read back from an encoded file
+type decodedColumn struct {
+	name       string
+	physical   int32
+	repetition int32
+	converted  interface{} // nil if the schema element has no converted type
+	values     []interface{}
+}
+
+// decode reads the footer and all column chunks of an encoded file
+func decode(t *testing.T, data []byte) (numRows int64, columns []decodedColumn) {
+	t.Helper()
+
+	if !bytes.HasPrefix(data, magic) || !bytes.HasSuffix(data, magic) {
+		t.Fatalf("file does not start and end with %q", magic)
+	}
+	footerLength := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footerStart := len(data) - 8 - footerLength
+	if footerStart < len(magic) {
+		t.Fatalf("footer length %d exceeds the file", footerLength)
+	}
+
+	footer := &thriftReader{t: t, data: data[:len(data)-8], pos: footerStart}
+	meta := footer.structValue()
+	if footer.pos != len(data)-8 {
+		t.Fatalf("footer ends at %d, expected %d", footer.pos, len(data)-8)
+	}
+	if meta[1] != int32(1) {
+		t.Errorf("version = %v, want 1", meta[1])
+	}
+	if meta[6] != "rate-your-mate" {
+		t.Errorf("created_by = %v, want rate-your-mate", meta[6])
+	}
+	numRows = meta[3].(int64)
+
+	schema := meta[2].([]interface{})
+	root := schema[0].(map[int16]interface{})
+	if root[5] != int32(len(schema)-1) {
+		t.Errorf("root num_children = %v, want %d", root[5], len(schema)-1)
+	}
+	for _, element := range schema[1:] {
+		e := element.(map[int16]interface{})
+		columns = append(columns, decodedColumn{
+			name:       e[4].(string),
+			physical:   e[1].(int32),
+			repetition: e[3].(int32),
+			converted:  e[6],
+		})
+	}
+
+	rowGroups := meta[4].([]interface{})
+	if numRows == 0 {
+		if len(rowGroups) != 0 {
+			t.Errorf("empty file has %d row groups, want 0", len(rowGroups))
+		}
+		return numRows, columns
+	}
+	if len(rowGroups) != 1 {
+		t.Fatalf("file has %d row groups, want 1", len(rowGroups))
+	}
+	rowGroup := rowGroups[0].(map[int16]interface{})
+	if rowGroup[3] != numRows {
+		t.Errorf("row group num_rows = %v, want %d", rowGroup[3], numRows)
+	}
+
+	chunks := rowGroup[1].([]interface{})
+	if len(chunks) != len(columns) {
+		t.Fatalf("row group has %d column chunks, want %d", len(chunks), len(columns))
+	}
+	var totalSize int64
+	for i, chunk := range chunks {
+		columnMeta := chunk.(map[int16]interface{})[3].(map[int16]interface{})
+		if path := columnMeta[3].([]interface{}); len(path) != 1 || path[0] != columns[i].name {
+			t.Errorf("column %d path = %v, want [%s]", i, path, columns[i].name)
+		}
+		if columnMeta[1] != columns[i].physical {
+			t.Errorf("column %s chunk type = %v, schema type %d", columns[i].name, columnMeta[1], columns[i].physical)
+		}
+		if columnMeta[4] != int32(0) {
+			t.Errorf("column %s codec = %v, want uncompressed", columns[i].name, columnMeta[4])
+		}
+		size := columnMeta[6].(int64)
+		totalSize += size
+		columns[i].values = decodePage(t, data, columns[i], columnMeta[9].(int64), size, numRows)
+	}
+	if rowGroup[2] != totalSize {
+		t.Errorf("row group total_byte_size = %v, want %d", rowGroup[2], totalSize)
+	}
+
+	return numRows, columns
+}
+
+// decodePage reads the data page of a column chunk, nulls are returned as nil
+func decodePage(t *testing.T, data []byte, column decodedColumn, offset, size, numRows int64) []interface{} {
+	t.Helper()
+
+	r := &thriftReader{t: t, data: data[:offset+size], pos: int(offset)}
+	header := r.structValue()
+	if header[1] != int32(pageTypeData) {
+		t.Fatalf("column %s page type = %v, want data page", column.name, header[1])
+	}
+	pageSize := int(header[2].(int32))
+	if header[3] != header[2] {
+		t.Errorf("column %s compressed size %v != uncompressed size %v", column.name, header[3], header[2])
+	}
+	if int64(r.pos+pageSize) != offset+size {
+		t.Fatalf("column %s page ends at %d, chunk at %d", column.name, r.pos+pageSize, offset+size)
+	}
+	dataPage := header[5].(map[int16]interface{})
+	if dataPage[1] != int32(numRows) {
+		t.Errorf("column %s num_values = %v, want %d", column.name, dataPage[1], numRows)
+	}
+
+	page := data[r.pos : r.pos+pageSize]
+	defined := make([]bool, numRows)
+	for i := range defined {
+		defined[i] = true
+	}
+	if column.repetition == repetitionOptional {
+		length := int(binary.LittleEndian.Uint32(page))
+		levels := page[4 : 4+length]
+		page = page[4+length:]
+		row := 0
+		for len(levels) > 0 {
+			run, n := binary.Uvarint(levels)
+			if n <= 0 || run&1 != 0 {
+				t.Fatalf("column %s: expected an RLE run in the definition levels", column.name)
+			}
+			for j := 0; j < int(run>>1); j++ {
+				defined[row] = levels[n] == 1
+				row++
+			}
+			levels = levels[n+1:]
+		}
+		if row != int(numRows) {
+			t.Fatalf("column %s has %d definition levels, want %d", column.name, row, numRows)
+		}
+	}
+
+	values := make([]interface{}, numRows)
+	bit := 0
+	for i := range values {
+		if !defined[i] {
+			continue
+		}
+		switch column.physical {
+		case physicalInt64:
+			values[i] = int64(binary.LittleEndian.Uint64(page))
+			page = page[8:]
+		case physicalDouble:
+			values[i] = math.Float64frombits(binary.LittleEndian.Uint64(page))
+			page = page[8:]
+		case physicalByteArray:
+			n := int(binary.LittleEndian.Uint32(page))
+			values[i] = string(page[4 : 4+n])
+			page = page[4+n:]
+		case physicalBoolean:
+			values[i] = page[bit/8]&(1<<(bit%8)) != 0
+			bit++
+		}
+	}
+	if column.physical == physicalBoolean {
+		page = page[(bit+7)/8:]
+	}
+	if len(page) != 0 {
+		t.Errorf("column %s has %d unread bytes", column.name, len(page))
+	}
+	return values
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	columns := []Column{
+		{Name: "id", Type: Int64},
+		{Name: "achievement", Type: String},
+		{Name: "comment", Type: String, Optional: true},
+		{Name: "is_secret", Type: Bool},
+		{Name: "score", Type: Double, Optional: true},
+		{Name: "created_at", Type: Timestamp},
+	}
+	at := time.Date(2024, 5, 1, 18, 30, 0, 0, time.UTC)
+
+	// 20 rows, so booleans span several bytes and definition levels have several runs
+	var rows [][]interface{}
+	for i := 0; i < 20; i++ {
+		var comment, score interface{}
+		if i%3 != 0 {
+			comment = fmt.Sprintf("Kommentar %d ✓", i)
+		}
+		if i < 5 || i > 15 {
+			score = float64(i) / 4
+		}
+		rows = append(rows, []interface{}{
+			int64(i) - 3, "pro-player", comment, i%2 == 0 || i == 9, score, at.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	data, err := Encode(columns, rows)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	numRows, decoded := decode(t, data)
+	if numRows != int64(len(rows)) {
+		t.Fatalf("num_rows = %d, want %d", numRows, len(rows))
+	}
+	if len(decoded) != len(columns) {
+		t.Fatalf("schema has %d columns, want %d", len(decoded), len(columns))
+	}
+
+	wantSchema := []struct {
+		physical   int32
+		repetition int32
+		converted  interface{}
+	}{
+		{physicalInt64, repetitionRequired, nil},
+		{physicalByteArray, repetitionRequired, int32(convertedUTF8)},
+		{physicalByteArray, repetitionOptional, int32(convertedUTF8)},
+		{physicalBoolean, repetitionRequired, nil},
+		{physicalDouble, repetitionOptional, nil},
+		{physicalInt64, repetitionRequired, int32(convertedTimestampMillis)},
+	}
+	for i, want := range wantSchema {
+		got := decoded[i]
+		if got.name != columns[i].Name || got.physical != want.physical || got.repetition != want.repetition || got.converted != want.converted {
+			t.Errorf("schema of column %d = %s/%d/%d/%v, want %s/%d/%d/%v", i,
+				got.name, got.physical, got.repetition, got.converted,
+				columns[i].Name, want.physical, want.repetition, want.converted)
+		}
+	}
+
+	for i, column := range decoded {
+		for j, row := range rows {
+			want := row[i]
+			if ts, ok := want.(time.Time); ok {
+				want = ts.UnixMilli()
+			}
+			if !reflect.DeepEqual(column.values[j], want) {
+				t.Errorf("column %s row %d = %#v, want %#v", column.name, j, column.values[j], want)
+			}
+		}
+	}
+}
+
+func TestEncodeEmpty(t *testing.T) {
+	columns := []Column{{Name: "id", Type: Int64}, {Name: "name", Type: String, Optional: true}}
+
+	data, err := Encode(columns, nil)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	numRows, decoded := decode(t, data)
+	if numRows != 0 {
+		t.Errorf("num_rows = %d, want 0", numRows)
+	}
+	if len(decoded) != 2 || decoded[0].name != "id" || decoded[1].name != "name" {
+		t.Errorf("schema = %+v, want id and name", decoded)
+	}
+}
+
+// Many columns and a long schema list use the long forms of field and list headers
+func TestEncodeManyColumns(t *testing.T) {
+	var columns []Column
+	row := []interface{}{}
+	for i := 0; i < 20; i++ {
+		columns = append(columns, Column{Name: fmt.Sprintf("c%d", i), Type: Int64})
+		row = append(row, int64(i*1000))
+	}
+
+	data, err := Encode(columns, [][]interface{}{row})
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	_, decoded := decode(t, data)
+	if len(decoded) != len(columns) {
+		t.Fatalf("schema has %d columns, want %d", len(decoded), len(columns))
+	}
+	for i, column := range decoded {
+		if column.values[0] != int64(i*1000) {
+			t.Errorf("column %s = %v, want %d", column.name, column.values[0], i*1000)
+		}
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []Column
+		rows    [][]interface{}
+		want    string
+	}{
+		{"row length", []Column{{Name: "id", Type: Int64}}, [][]interface{}{{int64(1), "x"}}, "row 0 has 2 values"},
+		{"wrong type", []Column{{Name: "id", Type: Int64}}, [][]interface{}{{1}}, "expects int64, got int"},
+		{"null in required column", []Column{{Name: "name", Type: String}}, [][]interface{}{{"a"}, {nil}}, "row 1: column name is not optional"},
+		{"wrong timestamp", []Column{{Name: "at", Type: Timestamp}}, [][]interface{}{{"2024-05-01"}}, "expects time.Time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Encode(tt.columns, tt.rows)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Encode error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
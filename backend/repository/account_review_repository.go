@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// AccountReviewRepository handles the login IP log and the review queue of flagged accounts
+type AccountReviewRepository struct{}
+
+// NewAccountReviewRepository creates a new account review repository
+func NewAccountReviewRepository() *AccountReviewRepository {
+	return &AccountReviewRepository{}
+}
+
+// accountReviewColumns are the columns read by scanAccountReview
+const accountReviewColumns = `id, user_id, steam_id, username, reasons, ip_address, status, reviewed_by, reviewed_at, created_at`
+
+// scanAccountReview scans a row selected with accountReviewColumns
+func scanAccountReview(s rowScanner) (*models.AccountReview, error) {
+	var r models.AccountReview
+	var reasons string
+	var ipAddress, reviewedBy sql.NullString
+	if err := s.Scan(&r.ID, &r.UserID, &r.SteamID, &r.Username, &reasons, &ipAddress,
+		&r.Status, &reviewedBy, &r.ReviewedAt, &r.CreatedAt); err != nil {
+		return nil, err
+	}
+	r.Reasons = strings.Split(reasons, ",")
+	r.IPAddress = ipAddress.String
+	r.ReviewedBy = reviewedBy.String
+	return &r, nil
+}
+
+// LogIP records the IP address of a Steam login (with retry for SQLITE_BUSY)
+func (r *AccountReviewRepository) LogIP(ctx context.Context, steamID, ipAddress string) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `
+			INSERT INTO ip_log (steam_id, ip_address, created_at)
+			VALUES (?, ?, ?)`,
+			steamID, ipAddress, time.Now().UTC(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to log login IP: %w", err)
+		}
+		return nil
+	})
+}
+
+// IsBannedIP checks if a banned player has logged in from the IP address
+func (r *AccountReviewRepository) IsBannedIP(ctx context.Context, ipAddress string) (bool, error) {
+	var count int
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM ip_log l
+		JOIN banned_users b ON b.steam_id = l.steam_id
+		WHERE l.ip_address = ?`, ipAddress,
+	).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check banned IP: %w", err)
+	}
+	return count > 0, nil
+}
+
+// Create adds a flagged account to the review queue and hides the account from other players
+// until it is approved (with retry for SQLITE_BUSY)
+func (r *AccountReviewRepository) Create(ctx context.Context, review *models.AccountReview) error {
+	return database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		now := time.Now().UTC()
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO account_reviews (user_id, steam_id, username, reasons, ip_address, status, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			review.UserID, review.SteamID, review.Username, strings.Join(review.Reasons, ","),
+			review.IPAddress, models.AccountReviewPending, now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create account review: %w", err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get last insert id: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE users SET pending_review = 1 WHERE id = ?`, review.UserID); err != nil {
+			return fmt.Errorf("failed to hide flagged account: %w", err)
+		}
+
+		review.ID = uint64(id)
+		review.Status = models.AccountReviewPending
+		review.CreatedAt = now
+		return nil
+	})
+}
+
+// GetByID returns a review, nil if it does not exist
+func (r *AccountReviewRepository) GetByID(ctx context.Context, id uint64) (*models.AccountReview, error) {
+	review, err := scanAccountReview(database.DB.QueryRowContext(ctx, `SELECT `+accountReviewColumns+` FROM account_reviews WHERE id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account review: %w", err)
+	}
+	return review, nil
+}
+
+// GetBySteamID returns the review of an account, nil if the account was never flagged
+func (r *AccountReviewRepository) GetBySteamID(ctx context.Context, steamID string) (*models.AccountReview, error) {
+	review, err := scanAccountReview(database.DB.QueryRowContext(ctx, `SELECT `+accountReviewColumns+` FROM account_reviews WHERE steam_id = ?`, steamID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account review: %w", err)
+	}
+	return review, nil
+}
+
+// GetByStatus returns reviews with the given status (empty = all), oldest first
+func (r *AccountReviewRepository) GetByStatus(ctx context.Context, status string, limit int) ([]models.AccountReview, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT `+accountReviewColumns+`
+		FROM account_reviews
+		WHERE (? = '' OR status = ?)
+		ORDER BY created_at, id
+		LIMIT ?`, status, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []models.AccountReview{}
+	for rows.Next() {
+		review, err := scanAccountReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan account review: %w", err)
+		}
+		reviews = append(reviews, *review)
+	}
+
+	return reviews, nil
+}
+
+// GetCreatedSince returns the accounts flagged since the given time, oldest first
+func (r *AccountReviewRepository) GetCreatedSince(ctx context.Context, since time.Time) ([]models.AccountReview, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT `+accountReviewColumns+`
+		FROM account_reviews
+		WHERE created_at >= ?
+		ORDER BY created_at, id`, since.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []models.AccountReview{}
+	for rows.Next() {
+		review, err := scanAccountReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan account review: %w", err)
+		}
+		reviews = append(reviews, *review)
+	}
+
+	return reviews, nil
+}
+
+// Resolve approves or rejects a pending review (with retry for SQLITE_BUSY)
+// Approved accounts become visible to other players, rejected ones stay hidden
+// Returns false if the review was already resolved
+func (r *AccountReviewRepository) Resolve(ctx context.Context, id uint64, status, adminSteamID string) (bool, error) {
+	var resolved bool
+	err := database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE account_reviews
+			SET status = ?, reviewed_by = ?, reviewed_at = ?
+			WHERE id = ? AND status = ?`,
+			status, adminSteamID, time.Now().UTC(), id, models.AccountReviewPending,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to resolve account review: %w", err)
+		}
+		changed, _ := result.RowsAffected()
+		resolved = changed > 0
+
+		if resolved && status == models.AccountReviewApproved {
+			if _, err := tx.ExecContext(ctx, `
+				UPDATE users SET pending_review = 0
+				WHERE id = (SELECT user_id FROM account_reviews WHERE id = ?)`, id,
+			); err != nil {
+				return fmt.Errorf("failed to show approved account: %w", err)
+			}
+		}
+		return nil
+	})
+	return resolved, err
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// visibleToPlayers reports whether the user shows up in the player list and the global ranking
+func visibleToPlayers(t *testing.T, ctx context.Context, userID uint64) (inList, inRanking bool) {
+	t.Helper()
+
+	users, err := NewUserRepository().GetAll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, u := range users {
+		if u.ID == userID {
+			inList = true
+		}
+	}
+
+	rankings, err := NewVoteRepository().GetGlobalRanking(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range rankings {
+		if r.User.ID == userID {
+			inRanking = true
+		}
+	}
+	return inList, inRanking
+}
+
+func TestFlaggedAccountHiddenUntilApproved(t *testing.T) {
+	initTestDB(t)
+	ctx := context.Background()
+	userRepo := NewUserRepository()
+	reviewRepo := NewAccountReviewRepository()
+
+	user := &models.User{SteamID: "76561198000000002", Username: "Newcomer", LastCreditAt: time.Now()}
+	if err := userRepo.Create(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+	if inList, inRanking := visibleToPlayers(t, ctx, user.ID); !inList || !inRanking {
+		t.Fatalf("new user visible in list = %v, ranking = %v, want both", inList, inRanking)
+	}
+
+	review := &models.AccountReview{
+		UserID:    user.ID,
+		SteamID:   user.SteamID,
+		Username:  user.Username,
+		Reasons:   []string{models.AccountSignalBannedIP},
+		IPAddress: "192.168.1.23",
+	}
+	if err := reviewRepo.Create(ctx, review); err != nil {
+		t.Fatal(err)
+	}
+	if inList, inRanking := visibleToPlayers(t, ctx, user.ID); inList || inRanking {
+		t.Fatalf("flagged user visible in list = %v, ranking = %v, want neither", inList, inRanking)
+	}
+	flagged, err := userRepo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flagged == nil || !flagged.PendingReview {
+		t.Fatal("flagged user is not marked as pending review")
+	}
+
+	if _, err := reviewRepo.Resolve(ctx, review.ID, models.AccountReviewApproved, "76561198000000099"); err != nil {
+		t.Fatal(err)
+	}
+	if inList, inRanking := visibleToPlayers(t, ctx, user.ID); !inList || !inRanking {
+		t.Fatalf("approved user visible in list = %v, ranking = %v, want both", inList, inRanking)
+	}
+}
+
+func TestRejectedAccountStaysHidden(t *testing.T) {
+	initTestDB(t)
+	ctx := context.Background()
+	reviewRepo := NewAccountReviewRepository()
+
+	user := &models.User{SteamID: "76561198000000003", Username: "Alt", LastCreditAt: time.Now()}
+	if err := NewUserRepository().Create(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+	review := &models.AccountReview{UserID: user.ID, SteamID: user.SteamID, Username: user.Username, Reasons: []string{models.AccountSignalBannedIP}}
+	if err := reviewRepo.Create(ctx, review); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reviewRepo.Resolve(ctx, review.ID, models.AccountReviewRejected, "76561198000000099"); err != nil {
+		t.Fatal(err)
+	}
+
+	if inList, inRanking := visibleToPlayers(t, ctx, user.ID); inList || inRanking {
+		t.Fatalf("rejected user visible in list = %v, ranking = %v, want neither", inList, inRanking)
+	}
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// AchievementRepository handles achievement database operations
+// Every write reloads the runtime registry in the models package
+type AchievementRepository struct{}
+
+// NewAchievementRepository creates a new achievement repository
+func NewAchievementRepository() *AchievementRepository {
+	return &AchievementRepository{}
+}
+
+// SeedBuiltins inserts built-in achievements that are missing from the table
+// Existing rows are left alone so admin edits survive restarts
+func (r *AchievementRepository) SeedBuiltins(ctx context.Context) error {
+	return database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		for i, a := range models.BuiltinAchievements {
+			var exists int
+			err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM achievements WHERE id = ?`, a.ID).Scan(&exists)
+			if err != nil {
+				return fmt.Errorf("failed to check achievement %s: %w", a.ID, err)
+			}
+			if exists > 0 {
+				continue
+			}
+
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO achievements (id, name, description, image_url, is_positive, category, tags, weight, is_builtin, is_disabled, sort_order)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, 1, 0, ?)`,
+				a.ID, a.Name, a.Description, a.ImageURL, a.IsPositive, a.Category, joinTags(a.Tags), a.Weight, i,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to seed achievement %s: %w", a.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Load reads all achievements into the runtime registry
+func (r *AchievementRepository) Load(ctx context.Context) error {
+	achievements, err := r.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	models.SetAchievements(achievements)
+	return nil
+}
+
+// GetAll returns all achievements in display order
+func (r *AchievementRepository) GetAll(ctx context.Context) ([]models.Achievement, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT id, name, description, image_url, is_positive, category, COALESCE(tags, ''), weight, is_builtin, is_disabled, valid_from, valid_until, app_id, sort_order
+		FROM achievements
+		ORDER BY sort_order, id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get achievements: %w", err)
+	}
+	defer rows.Close()
+
+	var achievements []models.Achievement
+	for rows.Next() {
+		var a models.Achievement
+		var tags string
+		if err := rows.Scan(&a.ID, &a.Name, &a.Description, &a.ImageURL, &a.IsPositive, &a.Category, &tags, &a.Weight, &a.IsBuiltin, &a.IsDisabled, &a.ValidFrom, &a.ValidUntil, &a.AppID, &a.SortOrder); err != nil {
+			return nil, fmt.Errorf("failed to scan achievement: %w", err)
+		}
+		a.Tags = splitTags(tags)
+		achievements = append(achievements, a)
+	}
+
+	return achievements, nil
+}
+
+// Create inserts a new custom achievement at the end of the display order and reloads the registry
+func (r *AchievementRepository) Create(ctx context.Context, a *models.Achievement) error {
+	err := database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		return insertAchievement(ctx, tx, a)
+	})
+	if err != nil {
+		return err
+	}
+	return r.Load(ctx)
+}
+
+// insertAchievement inserts a custom achievement at the end of the display order
+// The registry is not reloaded, callers reload it after the transaction committed
+func insertAchievement(ctx context.Context, tx *sql.Tx, a *models.Achievement) error {
+	var maxOrder int
+	if err := tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(sort_order), -1) FROM achievements`).Scan(&maxOrder); err != nil {
+		return fmt.Errorf("failed to get achievement order: %w", err)
+	}
+
+	now := time.Now().UTC()
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO achievements (id, name, description, image_url, is_positive, category, tags, weight, is_builtin, is_disabled, valid_from, valid_until, app_id, sort_order, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, 0, ?, ?, ?, ?, ?, ?, ?)`,
+		a.ID, a.Name, a.Description, a.ImageURL, a.IsPositive, a.Category, joinTags(a.Tags), a.Weight, a.IsDisabled, a.ValidFrom, a.ValidUntil, a.AppID, maxOrder+1, now, now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create achievement: %w", err)
+	}
+	a.IsBuiltin = false
+	a.SortOrder = maxOrder + 1
+	return nil
+}
+
+// Update overwrites the editable fields of an achievement and reloads the registry
+func (r *AchievementRepository) Update(ctx context.Context, a *models.Achievement) error {
+	err := database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `
+			UPDATE achievements
+			SET name = ?, description = ?, image_url = ?, is_positive = ?, category = ?, tags = ?, weight = ?, is_disabled = ?, valid_from = ?, valid_until = ?, app_id = ?, sort_order = ?, updated_at = ?
+			WHERE id = ?`,
+			a.Name, a.Description, a.ImageURL, a.IsPositive, a.Category, joinTags(a.Tags), a.Weight, a.IsDisabled, a.ValidFrom, a.ValidUntil, a.AppID, a.SortOrder, time.Now().UTC(), a.ID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update achievement: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return r.Load(ctx)
+}
+
+// Delete removes an achievement and reloads the registry
+func (r *AchievementRepository) Delete(ctx context.Context, id string) error {
+	err := database.WithRetryContext(ctx, func() error {
+		if _, err := database.DB.ExecContext(ctx, `DELETE FROM achievements WHERE id = ?`, id); err != nil {
+			return fmt.Errorf("failed to delete achievement: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return r.Load(ctx)
+}
+
+// CountVotes returns how many votes (including invalidated ones) reference an achievement
+func (r *AchievementRepository) CountVotes(ctx context.Context, id string) (int, error) {
+	var count int
+	err := database.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM votes WHERE achievement_id = ?`, id).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count achievement votes: %w", err)
+	}
+	return count, nil
+}
+
+// joinTags stores tags as a comma separated list
+func joinTags(tags []string) string {
+	return strings.Join(tags, ",")
+}
+
+// splitTags parses a comma separated tag list, always returning a non-nil slice
+func splitTags(tags string) []string {
+	result := []string{}
+	for _, tag := range strings.Split(tags, ",") {
+		if tag != "" {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// AchievementSuggestionRepository handles achievement suggestion database operations
+type AchievementSuggestionRepository struct{}
+
+// NewAchievementSuggestionRepository creates a new achievement suggestion repository
+func NewAchievementSuggestionRepository() *AchievementSuggestionRepository {
+	return &AchievementSuggestionRepository{}
+}
+
+const suggestionQuery = `
+	SELECT s.id, s.user_id, COALESCE(u.username, ''), s.name, s.description, s.is_positive, s.status,
+		s.achievement_id, s.reviewed_by, s.review_note, s.reviewed_at, s.created_at
+	FROM achievement_suggestions s
+	LEFT JOIN users u ON u.id = s.user_id`
+
+// scanSuggestion scans a row selected with suggestionQuery
+func scanSuggestion(s rowScanner) (*models.AchievementSuggestion, error) {
+	var a models.AchievementSuggestion
+	var achievementID, reviewedBy, reviewNote sql.NullString
+	if err := s.Scan(&a.ID, &a.UserID, &a.Username, &a.Name, &a.Description, &a.IsPositive, &a.Status,
+		&achievementID, &reviewedBy, &reviewNote, &a.ReviewedAt, &a.CreatedAt); err != nil {
+		return nil, err
+	}
+	a.AchievementID = achievementID.String
+	a.ReviewedBy = reviewedBy.String
+	a.ReviewNote = reviewNote.String
+	return &a, nil
+}
+
+// Create stores a new pending suggestion (with retry for SQLITE_BUSY)
+func (r *AchievementSuggestionRepository) Create(ctx context.Context, suggestion *models.AchievementSuggestion) error {
+	return database.WithRetryContext(ctx, func() error {
+		now := time.Now().UTC()
+		result, err := database.DB.ExecContext(ctx, `
+			INSERT INTO achievement_suggestions (user_id, name, description, is_positive, status, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			suggestion.UserID, suggestion.Name, suggestion.Description, suggestion.IsPositive, models.SuggestionStatusPending, now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create achievement suggestion: %w", err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get last insert id: %w", err)
+		}
+
+		suggestion.ID = uint64(id)
+		suggestion.Status = models.SuggestionStatusPending
+		suggestion.CreatedAt = now
+		return nil
+	})
+}
+
+// GetByID returns a suggestion by ID
+func (r *AchievementSuggestionRepository) GetByID(ctx context.Context, id uint64) (*models.AchievementSuggestion, error) {
+	suggestion, err := scanSuggestion(database.DB.QueryRowContext(ctx, suggestionQuery+` WHERE s.id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get achievement suggestion: %w", err)
+	}
+	return suggestion, nil
+}
+
+// GetByUser returns all suggestions of a user, newest first
+func (r *AchievementSuggestionRepository) GetByUser(ctx context.Context, userID uint64) ([]models.AchievementSuggestion, error) {
+	return r.query(ctx, suggestionQuery+` WHERE s.user_id = ? ORDER BY s.created_at DESC, s.id DESC`, userID)
+}
+
+// GetByStatus returns suggestions with the given status (empty = all), oldest first so admins work through the queue in order
+func (r *AchievementSuggestionRepository) GetByStatus(ctx context.Context, status string, limit int) ([]models.AchievementSuggestion, error) {
+	return r.query(ctx, suggestionQuery+`
+		WHERE (? = '' OR s.status = ?)
+		ORDER BY s.created_at, s.id
+		LIMIT ?`, status, status, limit)
+}
+
+// CountPendingByUser returns how many suggestions of a user wait for review
+func (r *AchievementSuggestionRepository) CountPendingByUser(ctx context.Context, userID uint64) (int, error) {
+	var count int
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM achievement_suggestions WHERE user_id = ? AND status = ?`,
+		userID, models.SuggestionStatusPending,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pending achievement suggestions: %w", err)
+	}
+	return count, nil
+}
+
+// query runs a suggestion query and scans all rows
+func (r *AchievementSuggestionRepository) query(ctx context.Context, query string, args ...interface{}) ([]models.AchievementSuggestion, error) {
+	rows, err := database.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get achievement suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	suggestions := []models.AchievementSuggestion{}
+	for rows.Next() {
+		suggestion, err := scanSuggestion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan achievement suggestion: %w", err)
+		}
+		suggestions = append(suggestions, *suggestion)
+	}
+
+	return suggestions, nil
+}
+
+// Approve creates the achievement and closes the pending suggestion in one transaction
+// The achievement registry is not reloaded, callers reload it with AchievementRepository.Load
+// Returns false if the suggestion was already reviewed
+func (r *AchievementSuggestionRepository) Approve(ctx context.Context, suggestionID uint64, a *models.Achievement, adminSteamID, note string) (bool, error) {
+	approved := false
+	err := database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		approved = false
+		result, err := tx.ExecContext(ctx, `
+			UPDATE achievement_suggestions
+			SET status = ?, achievement_id = ?, reviewed_by = ?, review_note = ?, reviewed_at = CURRENT_TIMESTAMP
+			WHERE id = ? AND status = ?`,
+			models.SuggestionStatusApproved, a.ID, adminSteamID, note, suggestionID, models.SuggestionStatusPending,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to approve achievement suggestion: %w", err)
+		}
+		if changed, _ := result.RowsAffected(); changed == 0 {
+			return nil
+		}
+
+		if err := insertAchievement(ctx, tx, a); err != nil {
+			return err
+		}
+		approved = true
+		return nil
+	})
+	return approved, err
+}
+
+// Reject closes a pending suggestion without creating an achievement
+// Returns false if the suggestion was already reviewed
+func (r *AchievementSuggestionRepository) Reject(ctx context.Context, suggestionID uint64, adminSteamID, note string) (bool, error) {
+	rejected := false
+	err := database.WithRetryContext(ctx, func() error {
+		result, err := database.DB.ExecContext(ctx, `
+			UPDATE achievement_suggestions
+			SET status = ?, reviewed_by = ?, review_note = ?, reviewed_at = CURRENT_TIMESTAMP
+			WHERE id = ? AND status = ?`,
+			models.SuggestionStatusRejected, adminSteamID, note, suggestionID, models.SuggestionStatusPending,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to reject achievement suggestion: %w", err)
+		}
+		changed, _ := result.RowsAffected()
+		rejected = changed > 0
+		return nil
+	})
+	return rejected, err
+}
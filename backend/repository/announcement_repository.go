@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// AnnouncementRepository handles admin announcement database operations
+type AnnouncementRepository struct{}
+
+// NewAnnouncementRepository creates a new announcement repository
+func NewAnnouncementRepository() *AnnouncementRepository {
+	return &AnnouncementRepository{}
+}
+
+// GetActive returns the announcements that have not expired yet, newest first
+func (r *AnnouncementRepository) GetActive(ctx context.Context, now time.Time) ([]models.Announcement, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT id, title, body, severity, expires_at, created_by, created_at
+		FROM announcements
+		WHERE expires_at IS NULL OR expires_at > ?
+		ORDER BY created_at DESC, id DESC`, now.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get announcements: %w", err)
+	}
+	defer rows.Close()
+
+	announcements := []models.Announcement{}
+	for rows.Next() {
+		var a models.Announcement
+		if err := rows.Scan(&a.ID, &a.Title, &a.Body, &a.Severity, &a.ExpiresAt, &a.CreatedBy, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan announcement: %w", err)
+		}
+		announcements = append(announcements, a)
+	}
+	return announcements, rows.Err()
+}
+
+// Create stores a new announcement (with retry for SQLITE_BUSY)
+func (r *AnnouncementRepository) Create(ctx context.Context, a *models.Announcement) error {
+	return database.WithRetryContext(ctx, func() error {
+		now := time.Now().UTC()
+		var expiresAt *time.Time
+		if a.ExpiresAt != nil {
+			utc := a.ExpiresAt.UTC()
+			expiresAt = &utc
+		}
+
+		result, err := database.DB.ExecContext(ctx, `
+			INSERT INTO announcements (title, body, severity, expires_at, created_by, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			a.Title, a.Body, a.Severity, expiresAt, a.CreatedBy, now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create announcement: %w", err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get last insert id: %w", err)
+		}
+
+		a.ID = uint64(id)
+		a.ExpiresAt = expiresAt
+		a.CreatedAt = now
+		return nil
+	})
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+)
+
+// AnonymizationCandidate is a user whose personal data has not been anonymized yet
+type AnonymizationCandidate struct {
+	ID       uint64 `json:"id"`
+	SteamID  string `json:"steam_id"`
+	Username string `json:"username"`
+}
+
+// AnonymizationStats counts the personal data affected by an anonymization run
+type AnonymizationStats struct {
+	Users         int `json:"users"`
+	ChatMessages  int `json:"chat_messages"`
+	VoteComments  int `json:"vote_comments"`
+	GameOwnerRows int `json:"game_owner_rows"`
+}
+
+// AnonymizationRepository handles the anonymization of personal data after an event
+type AnonymizationRepository struct{}
+
+// NewAnonymizationRepository creates a new anonymization repository
+func NewAnonymizationRepository() *AnonymizationRepository {
+	return &AnonymizationRepository{}
+}
+
+// GetCandidates returns all users that have not been anonymized yet
+// Removed users are returned with the Steam ID they had before they were removed
+func (r *AnonymizationRepository) GetCandidates(ctx context.Context) ([]AnonymizationCandidate, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT id, COALESCE(deleted_steam_id, steam_id), username
+		FROM users
+		WHERE anonymized_at IS NULL
+		ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get anonymization candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []AnonymizationCandidate
+	for rows.Next() {
+		var c AnonymizationCandidate
+		if err := rows.Scan(&c.ID, &c.SteamID, &c.Username); err != nil {
+			return nil, fmt.Errorf("failed to scan anonymization candidate: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+
+	return candidates, nil
+}
+
+// GetStats counts the personal data of all users that have not been anonymized yet
+func (r *AnonymizationRepository) GetStats(ctx context.Context) (*AnonymizationStats, error) {
+	var stats AnonymizationStats
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM users WHERE anonymized_at IS NULL),
+			(SELECT COUNT(*) FROM chat_messages cm
+				JOIN users u ON cm.user_id = u.id
+				WHERE u.anonymized_at IS NULL AND cm.message != '' AND cm.is_system = 0),
+			(SELECT COUNT(*) FROM votes v
+				JOIN users u ON v.from_user_id = u.id
+				WHERE u.anonymized_at IS NULL AND v.comment IS NOT NULL),
+			(SELECT COUNT(*) FROM game_owners o
+				JOIN users u ON o.steam_id = u.steam_id
+				WHERE u.anonymized_at IS NULL)`,
+	).Scan(&stats.Users, &stats.ChatMessages, &stats.VoteComments, &stats.GameOwnerRows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get anonymization stats: %w", err)
+	}
+	return &stats, nil
+}
+
+// AnonymizeUser replaces the personal data of a user while keeping votes and aggregates intact
+// The Steam ID is replaced by anonSteamID everywhere it is referenced, chat messages,
+// vote comments, appeal reasons and the user's own ban entries are scrubbed, the user's game library
+// and login IPs are removed. steamID is the Steam ID before the user was removed (see GetCandidates).
+func (r *AnonymizationRepository) AnonymizeUser(ctx context.Context, userID uint64, steamID, anonSteamID string) error {
+	return database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		now := time.Now().UTC()
+
+		// Removed users keep their deleted_<id> placeholder, a player who joined again may hold the same anonSteamID
+		_, err := tx.ExecContext(ctx, `
+			UPDATE users
+			SET steam_id = CASE WHEN deleted_at IS NULL THEN ? ELSE steam_id END, deleted_steam_id = NULL,
+				username = ?, avatar_url = '', avatar_small = '', profile_url = '',
+				country_code = '', timezone = '', language = '', reduced_motion = 0, quickvote_token_hash = NULL, anonymized_at = ?, updated_at = ?
+			WHERE id = ?`,
+			anonSteamID, fmt.Sprintf("Anonym %d", userID), now, now, userID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to anonymize user: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE chat_messages SET message = '' WHERE user_id = ? AND is_system = 0`, userID); err != nil {
+			return fmt.Errorf("failed to scrub chat messages: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE votes SET comment = NULL WHERE from_user_id = ?`, userID); err != nil {
+			return fmt.Errorf("failed to scrub vote comments: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE vote_appeals SET reason = '' WHERE user_id = ?`, userID); err != nil {
+			return fmt.Errorf("failed to scrub vote appeals: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE vote_appeals SET resolved_by = ? WHERE resolved_by = ?`, anonSteamID, steamID); err != nil {
+			return fmt.Errorf("failed to anonymize vote appeal resolutions: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE achievement_suggestions SET reviewed_by = ? WHERE reviewed_by = ?`, anonSteamID, steamID); err != nil {
+			return fmt.Errorf("failed to anonymize achievement suggestion reviews: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE votes SET invalidated_by = ? WHERE invalidated_by = ?`, anonSteamID, steamID); err != nil {
+			return fmt.Errorf("failed to anonymize vote invalidations: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE vote_invalidation_log SET admin_steam_id = ? WHERE admin_steam_id = ?`, anonSteamID, steamID); err != nil {
+			return fmt.Errorf("failed to anonymize invalidation log: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE admin_audit_log SET admin_steam_id = ? WHERE admin_steam_id = ?`, anonSteamID, steamID); err != nil {
+			return fmt.Errorf("failed to anonymize admin audit log: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE user_roles SET steam_id = ? WHERE steam_id = ?`, anonSteamID, steamID); err != nil {
+			return fmt.Errorf("failed to anonymize user roles: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE user_roles SET granted_by = ? WHERE granted_by = ?`, anonSteamID, steamID); err != nil {
+			return fmt.Errorf("failed to anonymize role grants: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE banned_users SET banned_by = ? WHERE banned_by = ?`, anonSteamID, steamID); err != nil {
+			return fmt.Errorf("failed to anonymize bans: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE banned_users SET steam_id = ?, username = ?, reason = '' WHERE steam_id = ?`,
+			anonSteamID, fmt.Sprintf("Anonym %d", userID), steamID); err != nil {
+			return fmt.Errorf("failed to anonymize ban entries: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE user_mutes SET muted_by = ? WHERE muted_by = ?`, anonSteamID, steamID); err != nil {
+			return fmt.Errorf("failed to anonymize mutes: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE spectators SET created_by = ? WHERE created_by = ?`, anonSteamID, steamID); err != nil {
+			return fmt.Errorf("failed to anonymize spectator accounts: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE chat_blocked_words SET created_by = ? WHERE created_by = ?`, anonSteamID, steamID); err != nil {
+			return fmt.Errorf("failed to anonymize chat blocklist entries: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM notifications WHERE user_id = ?`, userID); err != nil {
+			return fmt.Errorf("failed to delete notifications: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM game_owners WHERE steam_id = ?`, steamID); err != nil {
+			return fmt.Errorf("failed to delete game ownership: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM ip_log WHERE steam_id = ?`, steamID); err != nil {
+			return fmt.Errorf("failed to delete login IPs: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE account_reviews SET steam_id = ?, username = ?, ip_address = NULL WHERE user_id = ?`,
+			anonSteamID, fmt.Sprintf("Anonym %d", userID), userID); err != nil {
+			return fmt.Errorf("failed to anonymize account reviews: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE account_reviews SET reviewed_by = ? WHERE reviewed_by = ?`, anonSteamID, steamID); err != nil {
+			return fmt.Errorf("failed to anonymize account review decisions: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+func TestAnonymizeUserRemovedAndBanned(t *testing.T) {
+	initTestDB(t)
+	ctx := context.Background()
+	userRepo := NewUserRepository()
+	anonRepo := NewAnonymizationRepository()
+
+	const steamID = "76561198000000001"
+	user := &models.User{SteamID: steamID, Username: "Cheater", LastCreditAt: time.Now()}
+	if err := userRepo.Create(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewAccountReviewRepository().LogIP(ctx, steamID, "192.168.1.23"); err != nil {
+		t.Fatal(err)
+	}
+	// Banning removes the user and replaces their Steam ID with a placeholder
+	if err := userRepo.BanMany(ctx, []uint64{user.ID}, "Aimbot", "76561198000000099"); err != nil {
+		t.Fatal(err)
+	}
+
+	candidates, err := anonRepo.GetCandidates(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var candidate *AnonymizationCandidate
+	for i := range candidates {
+		if candidates[i].ID == user.ID {
+			candidate = &candidates[i]
+		}
+	}
+	if candidate == nil {
+		t.Fatalf("user %d is no anonymization candidate", user.ID)
+	}
+	if candidate.SteamID != steamID {
+		t.Fatalf("candidate Steam ID = %s, want %s", candidate.SteamID, steamID)
+	}
+
+	if err := anonRepo.AnonymizeUser(ctx, candidate.ID, candidate.SteamID, "anon_test"); err != nil {
+		t.Fatal(err)
+	}
+
+	var ipRows, banRows int
+	if err := database.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM ip_log WHERE steam_id = ?`, steamID).Scan(&ipRows); err != nil {
+		t.Fatal(err)
+	}
+	if ipRows != 0 {
+		t.Errorf("%d login IPs left for the original Steam ID", ipRows)
+	}
+	if err := database.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM banned_users WHERE steam_id = ?`, steamID).Scan(&banRows); err != nil {
+		t.Fatal(err)
+	}
+	if banRows != 0 {
+		t.Errorf("%d ban entries left for the original Steam ID", banRows)
+	}
+
+	var bannedUsername, reason string
+	err = database.DB.QueryRowContext(ctx, `SELECT username, reason FROM banned_users WHERE steam_id = ?`, "anon_test").Scan(&bannedUsername, &reason)
+	if err != nil {
+		t.Fatalf("anonymized ban entry not found: %v", err)
+	}
+	if want := fmt.Sprintf("Anonym %d", user.ID); bannedUsername != want || reason != "" {
+		t.Errorf("ban entry = (%q, %q), want (%q, \"\")", bannedUsername, reason, want)
+	}
+
+	var userSteamID string
+	var deletedSteamID sql.NullString
+	err = database.DB.QueryRowContext(ctx, `SELECT steam_id, deleted_steam_id FROM users WHERE id = ?`, user.ID).Scan(&userSteamID, &deletedSteamID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := fmt.Sprintf("deleted_%d", user.ID); userSteamID != want {
+		t.Errorf("steam_id = %s, want %s", userSteamID, want)
+	}
+	if deletedSteamID.Valid {
+		t.Errorf("deleted_steam_id = %s, want NULL", deletedSteamID.String)
+	}
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// AppealRepository handles vote appeal database operations
+type AppealRepository struct{}
+
+// NewAppealRepository creates a new appeal repository
+func NewAppealRepository() *AppealRepository {
+	return &AppealRepository{}
+}
+
+const appealColumns = `id, vote_id, user_id, reason, status, resolved_by, resolution_note, resolved_at, created_at`
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanAppeal scans a row selected with appealColumns
+func scanAppeal(s rowScanner) (*models.VoteAppeal, error) {
+	var a models.VoteAppeal
+	var resolvedBy, resolutionNote sql.NullString
+	if err := s.Scan(&a.ID, &a.VoteID, &a.UserID, &a.Reason, &a.Status,
+		&resolvedBy, &resolutionNote, &a.ResolvedAt, &a.CreatedAt); err != nil {
+		return nil, err
+	}
+	a.ResolvedBy = resolvedBy.String
+	a.ResolutionNote = resolutionNote.String
+	return &a, nil
+}
+
+// Create stores a new pending appeal (with retry for SQLITE_BUSY)
+func (r *AppealRepository) Create(ctx context.Context, appeal *models.VoteAppeal) error {
+	return database.WithRetryContext(ctx, func() error {
+		now := time.Now().UTC()
+		result, err := database.DB.ExecContext(ctx, `
+			INSERT INTO vote_appeals (vote_id, user_id, reason, status, created_at)
+			VALUES (?, ?, ?, ?, ?)`,
+			appeal.VoteID, appeal.UserID, appeal.Reason, models.AppealStatusPending, now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create vote appeal: %w", err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get last insert id: %w", err)
+		}
+
+		appeal.ID = uint64(id)
+		appeal.Status = models.AppealStatusPending
+		appeal.CreatedAt = now
+		return nil
+	})
+}
+
+// GetByID returns an appeal by ID
+func (r *AppealRepository) GetByID(ctx context.Context, id uint64) (*models.VoteAppeal, error) {
+	appeal, err := scanAppeal(database.DB.QueryRowContext(ctx, `SELECT `+appealColumns+` FROM vote_appeals WHERE id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vote appeal: %w", err)
+	}
+	return appeal, nil
+}
+
+// GetByVoteID returns the appeal of a vote, nil if the vote was not appealed
+func (r *AppealRepository) GetByVoteID(ctx context.Context, voteID uint64) (*models.VoteAppeal, error) {
+	appeal, err := scanAppeal(database.DB.QueryRowContext(ctx, `SELECT `+appealColumns+` FROM vote_appeals WHERE vote_id = ?`, voteID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vote appeal: %w", err)
+	}
+	return appeal, nil
+}
+
+// GetByUser returns all appeals filed by a user, newest first
+func (r *AppealRepository) GetByUser(ctx context.Context, userID uint64) ([]models.VoteAppeal, error) {
+	return r.query(ctx, `SELECT `+appealColumns+` FROM vote_appeals WHERE user_id = ? ORDER BY created_at DESC, id DESC`, userID)
+}
+
+// GetByStatus returns appeals with the given status (empty = all), oldest first so admins work through the queue in order
+func (r *AppealRepository) GetByStatus(ctx context.Context, status string, limit int) ([]models.VoteAppeal, error) {
+	return r.query(ctx, `
+		SELECT `+appealColumns+`
+		FROM vote_appeals
+		WHERE (? = '' OR status = ?)
+		ORDER BY created_at, id
+		LIMIT ?`, status, status, limit)
+}
+
+// GetCreatedSince returns the appeals submitted since the given time, oldest first
+func (r *AppealRepository) GetCreatedSince(ctx context.Context, since time.Time) ([]models.VoteAppeal, error) {
+	return r.query(ctx, `SELECT `+appealColumns+` FROM vote_appeals WHERE created_at >= ? ORDER BY created_at, id`, since.UTC())
+}
+
+// query runs an appeal query and scans all rows
+func (r *AppealRepository) query(ctx context.Context, query string, args ...interface{}) ([]models.VoteAppeal, error) {
+	rows, err := database.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vote appeals: %w", err)
+	}
+	defer rows.Close()
+
+	var appeals []models.VoteAppeal
+	for rows.Next() {
+		appeal, err := scanAppeal(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan vote appeal: %w", err)
+		}
+		appeals = append(appeals, *appeal)
+	}
+
+	return appeals, nil
+}
+
+// Resolve closes a pending appeal with the given status
+// Invalidating the appeal also invalidates the vote and records it in the invalidation audit trail
+// Returns false if the appeal was already resolved
+func (r *AppealRepository) Resolve(ctx context.Context, appealID uint64, status, adminSteamID, note string) (bool, error) {
+	resolved := false
+	err := database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		var voteID uint64
+		var current string
+		err := tx.QueryRowContext(ctx, `SELECT vote_id, status FROM vote_appeals WHERE id = ?`, appealID).Scan(&voteID, &current)
+		if err != nil {
+			return fmt.Errorf("failed to get vote appeal: %w", err)
+		}
+		if current != models.AppealStatusPending {
+			return nil
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE vote_appeals
+			SET status = ?, resolved_by = ?, resolution_note = ?, resolved_at = CURRENT_TIMESTAMP
+			WHERE id = ?`, status, adminSteamID, note, appealID)
+		if err != nil {
+			return fmt.Errorf("failed to resolve vote appeal: %w", err)
+		}
+
+		if status == models.AppealStatusInvalidated {
+			result, err := tx.ExecContext(ctx, `
+				UPDATE votes
+				SET is_invalidated = 1, invalidated_by = ?, invalidated_at = CURRENT_TIMESTAMP, invalidation_reason = ?
+				WHERE id = ? AND is_invalidated = 0`, adminSteamID, note, voteID)
+			if err != nil {
+				return fmt.Errorf("failed to invalidate vote: %w", err)
+			}
+
+			// Only log actual state changes, the vote may have been invalidated in the meantime
+			if changed, _ := result.RowsAffected(); changed > 0 {
+				_, err = tx.ExecContext(ctx, `
+					INSERT INTO vote_invalidation_log (vote_id, admin_steam_id, is_invalidated, reason)
+					VALUES (?, ?, ?, ?)`, voteID, adminSteamID, true, note)
+				if err != nil {
+					return fmt.Errorf("failed to write invalidation log: %w", err)
+				}
+			}
+		}
+
+		resolved = true
+		return nil
+	})
+
+	return resolved, err
+}
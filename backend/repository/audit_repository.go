@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// AuditRepository handles the admin audit trail
+type AuditRepository struct{}
+
+// NewAuditRepository creates a new audit repository
+func NewAuditRepository() *AuditRepository {
+	return &AuditRepository{}
+}
+
+// Log records an admin action (with retry for SQLITE_BUSY)
+func (r *AuditRepository) Log(ctx context.Context, adminSteamID, action, details string) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `
+			INSERT INTO admin_audit_log (admin_steam_id, action, details)
+			VALUES (?, ?, ?)`,
+			adminSteamID, action, details,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to write audit log: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetRecent returns the most recent audit log entries, newest first
+func (r *AuditRepository) GetRecent(ctx context.Context, limit int) ([]models.AdminAuditEntry, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT id, admin_steam_id, action, details, created_at
+		FROM admin_audit_log
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit log: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.AdminAuditEntry
+	for rows.Next() {
+		var e models.AdminAuditEntry
+		if err := rows.Scan(&e.ID, &e.AdminSteamID, &e.Action, &e.Details, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan audit log entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
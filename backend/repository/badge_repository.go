@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// BadgeRepository handles meta-badge database operations
+type BadgeRepository struct{}
+
+// NewBadgeRepository creates a new badge repository
+func NewBadgeRepository() *BadgeRepository {
+	return &BadgeRepository{}
+}
+
+// GetByUser returns the badges awarded to a user in badge display order
+// Awarded badges that are no longer defined are skipped
+func (r *BadgeRepository) GetByUser(ctx context.Context, userID uint64) ([]models.UserBadge, error) {
+	rows, err := database.DB.QueryContext(ctx, `SELECT badge_id, awarded_at FROM user_badges WHERE user_id = ?`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user badges: %w", err)
+	}
+	defer rows.Close()
+
+	awarded := make(map[string]time.Time)
+	for rows.Next() {
+		var badgeID string
+		var awardedAt time.Time
+		if err := rows.Scan(&badgeID, &awardedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan user badge: %w", err)
+		}
+		awarded[badgeID] = awardedAt
+	}
+
+	badges := []models.UserBadge{}
+	for _, badge := range models.Badges {
+		if awardedAt, ok := awarded[badge.ID]; ok {
+			badges = append(badges, models.UserBadge{Badge: badge, AwardedAt: awardedAt})
+		}
+	}
+	return badges, nil
+}
+
+// Award grants a badge to a user (with retry for SQLITE_BUSY)
+// Returns false if the user already had the badge
+func (r *BadgeRepository) Award(ctx context.Context, userID uint64, badgeID string) (bool, error) {
+	awarded := false
+	err := database.WithRetryContext(ctx, func() error {
+		query := `INSERT IGNORE INTO user_badges (user_id, badge_id, awarded_at) VALUES (?, ?, ?)`
+		if database.IsSQLite() {
+			query = `INSERT OR IGNORE INTO user_badges (user_id, badge_id, awarded_at) VALUES (?, ?, ?)`
+		}
+
+		result, err := database.DB.ExecContext(ctx, query, userID, badgeID, time.Now().UTC())
+		if err != nil {
+			return fmt.Errorf("failed to award badge: %w", err)
+		}
+		changed, _ := result.RowsAffected()
+		awarded = changed > 0
+		return nil
+	})
+	return awarded, err
+}
+
+// GetStats returns the badge statistics of a user, keyed by the models.BadgeStat constants
+// Invalidated votes do not count
+func (r *BadgeRepository) GetStats(ctx context.Context, userID uint64) (map[string]int, error) {
+	var given, received int
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM votes WHERE from_user_id = ? AND is_invalidated = 0),
+			(SELECT COUNT(*) FROM votes WHERE to_user_id = ? AND is_invalidated = 0)`,
+		userID, userID,
+	).Scan(&given, &received)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get badge stats: %w", err)
+	}
+
+	stats := map[string]int{
+		models.BadgeStatVotesGiven:    given,
+		models.BadgeStatVotesReceived: received,
+	}
+
+	// Achievement positivity lives in the registry, so received votes are split in Go
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT achievement_id, COUNT(*)
+		FROM votes
+		WHERE to_user_id = ? AND is_invalidated = 0
+		GROUP BY achievement_id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get received votes per achievement: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var achievementID string
+		var count int
+		if err := rows.Scan(&achievementID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan received votes: %w", err)
+		}
+		if achievement, ok := models.GetAchievement(achievementID); ok && !achievement.IsPositive {
+			stats[models.BadgeStatNegativeVotesReceived] += count
+		}
+	}
+
+	led, err := r.countAchievementsLed(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	stats[models.BadgeStatAchievementsLed] = led
+
+	return stats, nil
+}
+
+// countAchievementsLed counts the positive achievements a user holds 1st place in
+// Ties are broken like in the ranking: the first vote wins
+func (r *BadgeRepository) countAchievementsLed(ctx context.Context, userID uint64) (int, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT
+			v.achievement_id,
+			v.to_user_id,
+			SUM(v.points) as vote_count,
+			MIN(v.created_at) as first_vote
+		FROM votes v
+		WHERE v.is_invalidated = 0
+		GROUP BY v.achievement_id, v.to_user_id
+		ORDER BY v.achievement_id, vote_count DESC, first_vote ASC`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get achievement leaders: %w", err)
+	}
+	defer rows.Close()
+
+	led := 0
+	currentAchievement := ""
+	for rows.Next() {
+		var achievementID string
+		var leaderID uint64
+		var voteCount int
+		var firstVote interface{}
+		if err := rows.Scan(&achievementID, &leaderID, &voteCount, &firstVote); err != nil {
+			return 0, fmt.Errorf("failed to scan achievement leader: %w", err)
+		}
+
+		// Only the first row of each achievement is its leader
+		if achievementID == currentAchievement {
+			continue
+		}
+		currentAchievement = achievementID
+
+		if leaderID != userID {
+			continue
+		}
+		if achievement, ok := models.GetAchievement(achievementID); ok && achievement.IsPositive {
+			led++
+		}
+	}
+
+	return led, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// ChatFilterRepository handles the blocklist of the chat filter
+type ChatFilterRepository struct{}
+
+// NewChatFilterRepository creates a new chat filter repository
+func NewChatFilterRepository() *ChatFilterRepository {
+	return &ChatFilterRepository{}
+}
+
+// GetAll returns all blocked words in alphabetical order
+func (r *ChatFilterRepository) GetAll(ctx context.Context) ([]models.ChatBlockedWord, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT id, word, language, created_by, created_at
+		FROM chat_blocked_words
+		ORDER BY word`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked words: %w", err)
+	}
+	defer rows.Close()
+
+	words := []models.ChatBlockedWord{}
+	for rows.Next() {
+		var w models.ChatBlockedWord
+		var createdBy sql.NullString
+		if err := rows.Scan(&w.ID, &w.Word, &w.Language, &createdBy, &w.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan blocked word: %w", err)
+		}
+		w.CreatedBy = createdBy.String
+		words = append(words, w)
+	}
+
+	return words, nil
+}
+
+// Add adds words to the blocklist, words that are already blocked are skipped
+// Returns the number of added words (with retry for SQLITE_BUSY)
+func (r *ChatFilterRepository) Add(ctx context.Context, words []string, language, createdBy string) (int, error) {
+	insert := `INSERT IGNORE INTO chat_blocked_words (word, language, created_by, created_at) VALUES (?, ?, ?, ?)`
+	if database.IsSQLite() {
+		insert = `INSERT OR IGNORE INTO chat_blocked_words (word, language, created_by, created_at) VALUES (?, ?, ?, ?)`
+	}
+
+	var added int
+	err := database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		added = 0
+		now := time.Now().UTC()
+		for _, word := range words {
+			result, err := tx.ExecContext(ctx, insert, word, language, createdBy, now)
+			if err != nil {
+				return fmt.Errorf("failed to add blocked word: %w", err)
+			}
+			changed, _ := result.RowsAffected()
+			added += int(changed)
+		}
+		return nil
+	})
+	return added, err
+}
+
+// Delete removes a word from the blocklist (with retry for SQLITE_BUSY)
+// Returns false if the word does not exist
+func (r *ChatFilterRepository) Delete(ctx context.Context, id uint64) (bool, error) {
+	var deleted bool
+	err := database.WithRetryContext(ctx, func() error {
+		result, err := database.DB.ExecContext(ctx, `DELETE FROM chat_blocked_words WHERE id = ?`, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete blocked word: %w", err)
+		}
+		changed, _ := result.RowsAffected()
+		deleted = changed > 0
+		return nil
+	})
+	return deleted, err
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// ChatReminderRepository handles scheduled chat reminder database operations
+type ChatReminderRepository struct{}
+
+// NewChatReminderRepository creates a new chat reminder repository
+func NewChatReminderRepository() *ChatReminderRepository {
+	return &ChatReminderRepository{}
+}
+
+const chatReminderColumns = `id, message, send_at, created_by, sent_at, chat_message_id, created_at`
+
+// scanChatReminder scans a row selected with chatReminderColumns
+func scanChatReminder(s rowScanner) (*models.ChatReminder, error) {
+	var rem models.ChatReminder
+	var chatMessageID sql.NullInt64
+	if err := s.Scan(&rem.ID, &rem.Message, &rem.SendAt, &rem.CreatedBy,
+		&rem.SentAt, &chatMessageID, &rem.CreatedAt); err != nil {
+		return nil, err
+	}
+	if chatMessageID.Valid {
+		id := uint64(chatMessageID.Int64)
+		rem.ChatMessageID = &id
+	}
+	return &rem, nil
+}
+
+// queryChatReminders runs a query selecting chatReminderColumns and scans all rows
+func queryChatReminders(ctx context.Context, query string, args ...interface{}) ([]models.ChatReminder, error) {
+	rows, err := database.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reminders := []models.ChatReminder{}
+	for rows.Next() {
+		rem, err := scanChatReminder(rows)
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, *rem)
+	}
+	return reminders, rows.Err()
+}
+
+// GetAll returns all reminders, pending and sent, ordered by send time
+func (r *ChatReminderRepository) GetAll(ctx context.Context) ([]models.ChatReminder, error) {
+	reminders, err := queryChatReminders(ctx, `SELECT `+chatReminderColumns+` FROM chat_reminders ORDER BY send_at, id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat reminders: %w", err)
+	}
+	return reminders, nil
+}
+
+// GetDue returns pending reminders whose send time has been reached
+func (r *ChatReminderRepository) GetDue(ctx context.Context, now time.Time) ([]models.ChatReminder, error) {
+	reminders, err := queryChatReminders(ctx, `
+		SELECT `+chatReminderColumns+`
+		FROM chat_reminders
+		WHERE sent_at IS NULL AND send_at <= ?
+		ORDER BY send_at, id`, now.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due chat reminders: %w", err)
+	}
+	return reminders, nil
+}
+
+// GetByID returns a reminder by ID
+func (r *ChatReminderRepository) GetByID(ctx context.Context, id uint64) (*models.ChatReminder, error) {
+	rem, err := scanChatReminder(database.DB.QueryRowContext(ctx, `SELECT `+chatReminderColumns+` FROM chat_reminders WHERE id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat reminder: %w", err)
+	}
+	return rem, nil
+}
+
+// Create schedules a new reminder (with retry for SQLITE_BUSY)
+func (r *ChatReminderRepository) Create(ctx context.Context, rem *models.ChatReminder) error {
+	return database.WithRetryContext(ctx, func() error {
+		now := time.Now().UTC()
+		result, err := database.DB.ExecContext(ctx, `
+			INSERT INTO chat_reminders (message, send_at, created_by, created_at)
+			VALUES (?, ?, ?, ?)`,
+			rem.Message, rem.SendAt.UTC(), rem.CreatedBy, now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create chat reminder: %w", err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get last insert id: %w", err)
+		}
+
+		rem.ID = uint64(id)
+		rem.CreatedAt = now
+		return nil
+	})
+}
+
+// DeletePending removes a reminder that has not been sent yet
+// Returns false if the reminder does not exist or was already sent
+func (r *ChatReminderRepository) DeletePending(ctx context.Context, id uint64) (bool, error) {
+	var deleted bool
+	err := database.WithRetryContext(ctx, func() error {
+		result, err := database.DB.ExecContext(ctx, `DELETE FROM chat_reminders WHERE id = ? AND sent_at IS NULL`, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete chat reminder: %w", err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+		deleted = affected > 0
+		return nil
+	})
+	return deleted, err
+}
+
+// Deliver posts a reminder as system chat message and marks it as sent in one transaction,
+// so a reminder is never posted twice. Returns the ID of the posted chat message,
+// 0 if the reminder was already sent or deleted in the meantime
+func (r *ChatReminderRepository) Deliver(ctx context.Context, rem *models.ChatReminder) (uint64, error) {
+	var messageID uint64
+	err := database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		now := time.Now().UTC()
+		result, err := tx.ExecContext(ctx, `UPDATE chat_reminders SET sent_at = ? WHERE id = ? AND sent_at IS NULL`, now, rem.ID)
+		if err != nil {
+			return fmt.Errorf("failed to mark chat reminder as sent: %w", err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+		if affected == 0 {
+			return nil
+		}
+
+		result, err = tx.ExecContext(ctx, `
+			INSERT INTO chat_messages (user_id, message, achievements, is_system, created_at)
+			VALUES (?, ?, '[]', 1, ?)`,
+			rem.CreatedBy, rem.Message, now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to post chat reminder: %w", err)
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get last insert id: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE chat_reminders SET chat_message_id = ? WHERE id = ?`, id, rem.ID); err != nil {
+			return fmt.Errorf("failed to link chat reminder message: %w", err)
+		}
+
+		messageID = uint64(id)
+		return nil
+	})
+	return messageID, err
+}
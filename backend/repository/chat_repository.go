@@ -1,8 +1,11 @@
 package repository
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/guided-traffic/rate-your-mate/backend/database"
 	"github.com/guided-traffic/rate-your-mate/backend/models"
@@ -16,13 +19,76 @@ func NewChatRepository() *ChatRepository {
 	return &ChatRepository{}
 }
 
+// chatMessageQuery selects chat messages with their author and the quoted parent of replies
+const chatMessageQuery = `
+	SELECT
+		cm.id, cm.message, cm.achievements, COALESCE(cm.is_system, 0), cm.created_at,
+		u.id, u.steam_id, u.username, u.avatar_url, u.avatar_small, u.profile_url, u.country_code,
+		pm.id, COALESCE(pm.message, ''), COALESCE(pm.is_system, 0),
+		pu.id, COALESCE(pu.steam_id, ''), COALESCE(pu.username, ''), COALESCE(pu.avatar_url, ''),
+		COALESCE(pu.avatar_small, ''), COALESCE(pu.profile_url, ''), COALESCE(pu.country_code, '')
+	FROM chat_messages cm
+	JOIN users u ON cm.user_id = u.id
+	LEFT JOIN chat_messages pm ON cm.reply_to_id = pm.id
+	LEFT JOIN users pu ON pm.user_id = pu.id`
+
+// scanChatMessage scans a row selected with chatMessageQuery
+func scanChatMessage(s rowScanner) (*models.ChatMessageWithUser, error) {
+	var m models.ChatMessageWithUser
+	var achievementsJSON string
+	var parentID, parentUserID sql.NullInt64
+	var parentMessage string
+	var parentIsSystem bool
+	var parentUser models.PublicUser
+	err := s.Scan(
+		&m.ID, &m.Message, &achievementsJSON, &m.IsSystem, &m.CreatedAt,
+		&m.User.ID, &m.User.SteamID, &m.User.Username, &m.User.AvatarURL, &m.User.AvatarSmall, &m.User.ProfileURL, &m.User.CountryCode,
+		&parentID, &parentMessage, &parentIsSystem,
+		&parentUserID, &parentUser.SteamID, &parentUser.Username, &parentUser.AvatarURL,
+		&parentUser.AvatarSmall, &parentUser.ProfileURL, &parentUser.CountryCode,
+	)
+	if err != nil {
+		return nil, err
+	}
+	m.User.Flag = models.CountryFlag(m.User.CountryCode)
+	if m.IsSystem {
+		m.User = models.SystemChatUser
+	}
+
+	// Parse achievements JSON
+	if achievementsJSON != "" && achievementsJSON != "[]" {
+		if err := json.Unmarshal([]byte(achievementsJSON), &m.Achievements); err != nil {
+			// If parsing fails, just leave it empty
+			m.Achievements = []models.AchievementBadge{}
+		}
+	} else {
+		m.Achievements = []models.AchievementBadge{}
+	}
+
+	// Quote the parent message of replies
+	if parentID.Valid {
+		parentUser.ID = uint64(parentUserID.Int64)
+		parentUser.Flag = models.CountryFlag(parentUser.CountryCode)
+		if parentIsSystem {
+			parentUser = models.SystemChatUser
+		}
+		m.ReplyTo = &models.ChatReplyPreview{
+			ID:      uint64(parentID.Int64),
+			User:    parentUser,
+			Snippet: models.NewChatReplySnippet(parentMessage),
+		}
+	}
+
+	return &m, nil
+}
+
 // Create creates a new chat message with the user's current achievements (with retry for SQLITE_BUSY)
-func (r *ChatRepository) Create(msg *models.ChatMessage) error {
-	return database.WithRetry(func() error {
-		result, err := database.DB.Exec(`
-			INSERT INTO chat_messages (user_id, message, achievements)
-			VALUES (?, ?, ?)`,
-			msg.UserID, msg.Message, msg.Achievements,
+func (r *ChatRepository) Create(ctx context.Context, msg *models.ChatMessage) error {
+	return database.WithRetryContext(ctx, func() error {
+		result, err := database.DB.ExecContext(ctx, `
+			INSERT INTO chat_messages (user_id, message, achievements, reply_to_id, is_system)
+			VALUES (?, ?, ?, ?, ?)`,
+			msg.UserID, msg.Message, msg.Achievements, msg.ReplyToID, msg.IsSystem,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to create chat message: %w", err)
@@ -38,16 +104,12 @@ func (r *ChatRepository) Create(msg *models.ChatMessage) error {
 	})
 }
 
-// GetRecent returns the most recent chat messages
-func (r *ChatRepository) GetRecent(limit int) ([]models.ChatMessageWithUser, error) {
-	rows, err := database.DB.Query(`
-		SELECT
-			cm.id, cm.message, cm.achievements, cm.created_at,
-			u.id, u.steam_id, u.username, u.avatar_url, u.avatar_small, u.profile_url
-		FROM chat_messages cm
-		JOIN users u ON cm.user_id = u.id
-		ORDER BY cm.created_at DESC
-		LIMIT ?`, limit)
+// GetRecent returns a page of the most recent chat messages, newest first
+func (r *ChatRepository) GetRecent(ctx context.Context, page Page) ([]models.ChatMessageWithUser, error) {
+	rows, err := database.DB.QueryContext(ctx, chatMessageQuery+`
+		WHERE (? = 0 OR cm.id < ?)
+		ORDER BY cm.id DESC
+		LIMIT ?`, page.Cursor, page.Cursor, page.Limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get recent chat messages: %w", err)
 	}
@@ -55,70 +117,82 @@ func (r *ChatRepository) GetRecent(limit int) ([]models.ChatMessageWithUser, err
 
 	var messages []models.ChatMessageWithUser
 	for rows.Next() {
-		var m models.ChatMessageWithUser
-		var achievementsJSON string
-		err := rows.Scan(
-			&m.ID, &m.Message, &achievementsJSON, &m.CreatedAt,
-			&m.User.ID, &m.User.SteamID, &m.User.Username, &m.User.AvatarURL, &m.User.AvatarSmall, &m.User.ProfileURL,
-		)
+		m, err := scanChatMessage(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan chat message row: %w", err)
 		}
+		messages = append(messages, *m)
+	}
 
-		// Parse achievements JSON
-		if achievementsJSON != "" && achievementsJSON != "[]" {
-			if err := json.Unmarshal([]byte(achievementsJSON), &m.Achievements); err != nil {
-				// If parsing fails, just leave it empty
-				m.Achievements = []models.AchievementBadge{}
-			}
-		} else {
-			m.Achievements = []models.AchievementBadge{}
-		}
+	return messages, nil
+}
+
+// GetPage returns up to limit chat messages with an ID above afterID in chronological order
+// Only messages created in [from, until) are returned, zero times leave the range open
+func (r *ChatRepository) GetPage(ctx context.Context, afterID uint64, from, until time.Time, limit int) ([]models.ChatMessageWithUser, error) {
+	rows, err := database.DB.QueryContext(ctx, chatMessageQuery+`
+		WHERE cm.id > ?
+			AND (? OR cm.created_at >= ?)
+			AND (? OR cm.created_at < ?)
+		ORDER BY cm.id
+		LIMIT ?`, afterID, from.IsZero(), from.UTC(), until.IsZero(), until.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat messages: %w", err)
+	}
+	defer rows.Close()
 
-		messages = append(messages, m)
+	messages := []models.ChatMessageWithUser{}
+	for rows.Next() {
+		m, err := scanChatMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan chat message row: %w", err)
+		}
+		messages = append(messages, *m)
 	}
 
 	return messages, nil
 }
 
-// GetByID returns a chat message by ID with full details
-func (r *ChatRepository) GetByID(id uint64) (*models.ChatMessageWithUser, error) {
-	var m models.ChatMessageWithUser
-	var achievementsJSON string
-	err := database.DB.QueryRow(`
-		SELECT
-			cm.id, cm.message, cm.achievements, cm.created_at,
-			u.id, u.steam_id, u.username, u.avatar_url, u.avatar_small, u.profile_url
-		FROM chat_messages cm
-		JOIN users u ON cm.user_id = u.id
-		WHERE cm.id = ?`, id,
-	).Scan(
-		&m.ID, &m.Message, &achievementsJSON, &m.CreatedAt,
-		&m.User.ID, &m.User.SteamID, &m.User.Username, &m.User.AvatarURL, &m.User.AvatarSmall, &m.User.ProfileURL,
-	)
-
+// GetByID returns a chat message by ID with full details, nil if it does not exist
+func (r *ChatRepository) GetByID(ctx context.Context, id uint64) (*models.ChatMessageWithUser, error) {
+	m, err := scanChatMessage(database.DB.QueryRowContext(ctx, chatMessageQuery+`
+		WHERE cm.id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chat message: %w", err)
 	}
+	return m, nil
+}
 
-	// Parse achievements JSON
-	if achievementsJSON != "" && achievementsJSON != "[]" {
-		if err := json.Unmarshal([]byte(achievementsJSON), &m.Achievements); err != nil {
-			m.Achievements = []models.AchievementBadge{}
-		}
-	} else {
-		m.Achievements = []models.AchievementBadge{}
+// Exists checks if a chat message exists, used to validate reply targets
+func (r *ChatRepository) Exists(ctx context.Context, id uint64) (bool, error) {
+	var count int
+	err := database.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM chat_messages WHERE id = ?`, id).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check chat message: %w", err)
 	}
+	return count > 0, nil
+}
 
-	return &m, nil
+// Delete removes a chat message, replies keep their text but lose the quote
+func (r *ChatRepository) Delete(ctx context.Context, id uint64) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `DELETE FROM chat_messages WHERE id = ?`, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete chat message: %w", err)
+		}
+		return nil
+	})
 }
 
-// GetUserAchievementBadges returns the current achievement badges for a user (aggregated votes received)
-func (r *ChatRepository) GetUserAchievementBadges(userID uint64) ([]models.AchievementBadge, error) {
-	rows, err := database.DB.Query(`
+// GetUserAchievementBadges returns the current achievement badges for a user (aggregated valid votes received)
+func (r *ChatRepository) GetUserAchievementBadges(ctx context.Context, userID uint64) ([]models.AchievementBadge, error) {
+	rows, err := database.DB.QueryContext(ctx, `
 		SELECT achievement_id, COUNT(*) as count
 		FROM votes
-		WHERE to_user_id = ?
+		WHERE to_user_id = ? AND is_invalidated = 0
 		GROUP BY achievement_id
 		ORDER BY count DESC`, userID)
 	if err != nil {
@@ -148,3 +222,130 @@ func (r *ChatRepository) GetUserAchievementBadges(userID uint64) ([]models.Achie
 
 	return badges, nil
 }
+
+// CreateMentions records the users mentioned in a chat message (with retry for SQLITE_BUSY)
+// Existing mentions are ignored, so a retry after a partial insert is safe
+func (r *ChatRepository) CreateMentions(ctx context.Context, messageID uint64, userIDs []uint64) error {
+	query := `INSERT IGNORE INTO chat_mentions (chat_message_id, user_id) VALUES (?, ?)`
+	if database.IsSQLite() {
+		query = `INSERT OR IGNORE INTO chat_mentions (chat_message_id, user_id) VALUES (?, ?)`
+	}
+
+	return database.WithRetryContext(ctx, func() error {
+		for _, userID := range userIDs {
+			_, err := database.DB.ExecContext(ctx, query, messageID, userID)
+			if err != nil {
+				return fmt.Errorf("failed to create chat mention: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// MarkRead stores the last chat message the user has read (with retry for SQLITE_BUSY)
+// The read position only moves forward, so an older device cannot mark messages unread again
+func (r *ChatRepository) MarkRead(ctx context.Context, userID, messageID uint64) error {
+	return database.WithRetryContext(ctx, func() error {
+		now := time.Now().UTC()
+		var err error
+		if database.IsSQLite() {
+			_, err = database.DB.ExecContext(ctx, `
+				INSERT INTO chat_reads (user_id, last_read_message_id, updated_at)
+				VALUES (?, ?, ?)
+				ON CONFLICT(user_id) DO UPDATE SET
+					last_read_message_id = MAX(last_read_message_id, excluded.last_read_message_id),
+					updated_at = excluded.updated_at`,
+				userID, messageID, now,
+			)
+		} else {
+			_, err = database.DB.ExecContext(ctx, `
+				INSERT INTO chat_reads (user_id, last_read_message_id, updated_at)
+				VALUES (?, ?, ?)
+				ON DUPLICATE KEY UPDATE
+					last_read_message_id = GREATEST(last_read_message_id, VALUES(last_read_message_id)),
+					updated_at = VALUES(updated_at)`,
+				userID, messageID, now,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to mark chat as read: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetLatestID returns the ID of the newest chat message, 0 if the chat is empty
+func (r *ChatRepository) GetLatestID(ctx context.Context) (uint64, error) {
+	var id uint64
+	err := database.DB.QueryRowContext(ctx, `SELECT COALESCE(MAX(id), 0) FROM chat_messages`).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest chat message: %w", err)
+	}
+	return id, nil
+}
+
+// GetReadState returns the last chat message the user has read and the number of
+// newer messages written by others
+func (r *ChatRepository) GetReadState(ctx context.Context, userID uint64) (uint64, int, error) {
+	var lastReadID uint64
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT last_read_message_id FROM chat_reads WHERE user_id = ?`, userID).Scan(&lastReadID)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, 0, fmt.Errorf("failed to get chat read state: %w", err)
+	}
+
+	var unread int
+	err = database.DB.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM chat_messages WHERE id > ? AND user_id != ?`, lastReadID, userID).Scan(&unread)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count unread chat messages: %w", err)
+	}
+	return lastReadID, unread, nil
+}
+
+// GetMentions returns the most recent chat messages mentioning the user, newest first
+func (r *ChatRepository) GetMentions(ctx context.Context, userID uint64, limit int) ([]models.ChatMessageWithUser, error) {
+	rows, err := database.DB.QueryContext(ctx, chatMessageQuery+`
+		JOIN chat_mentions mn ON mn.chat_message_id = cm.id
+		WHERE mn.user_id = ?
+		ORDER BY cm.created_at DESC
+		LIMIT ?`, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat mentions: %w", err)
+	}
+	defer rows.Close()
+
+	messages := []models.ChatMessageWithUser{}
+	for rows.Next() {
+		m, err := scanChatMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan chat mention row: %w", err)
+		}
+		messages = append(messages, *m)
+	}
+
+	return messages, nil
+}
+
+// CountUnreadMentions returns the number of unread mentions of the user
+func (r *ChatRepository) CountUnreadMentions(ctx context.Context, userID uint64) (int, error) {
+	var count int
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM chat_mentions WHERE user_id = ? AND is_read = 0`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread chat mentions: %w", err)
+	}
+	return count, nil
+}
+
+// MarkMentionsRead marks all mentions of the user as read (with retry for SQLITE_BUSY)
+func (r *ChatRepository) MarkMentionsRead(ctx context.Context, userID uint64) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `
+			UPDATE chat_mentions SET is_read = 1 WHERE user_id = ? AND is_read = 0`, userID)
+		if err != nil {
+			return fmt.Errorf("failed to mark chat mentions as read: %w", err)
+		}
+		return nil
+	})
+}
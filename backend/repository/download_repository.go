@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// DownloadRepository handles the download checklist database operations
+type DownloadRepository struct{}
+
+// NewDownloadRepository creates a new download repository
+func NewDownloadRepository() *DownloadRepository {
+	return &DownloadRepository{}
+}
+
+const downloadRequirementColumns = `id, app_id, name, version, note, created_at, updated_at`
+
+// scanDownloadRequirement scans a row selected with downloadRequirementColumns
+func scanDownloadRequirement(s rowScanner) (*models.DownloadRequirement, error) {
+	var req models.DownloadRequirement
+	var appID sql.NullInt64
+	if err := s.Scan(&req.ID, &appID, &req.Name, &req.Version, &req.Note, &req.CreatedAt, &req.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if appID.Valid {
+		id := int(appID.Int64)
+		req.AppID = &id
+	}
+	return &req, nil
+}
+
+// GetAll returns all download requirements in creation order
+func (r *DownloadRepository) GetAll(ctx context.Context) ([]models.DownloadRequirement, error) {
+	rows, err := database.DB.QueryContext(ctx, `SELECT `+downloadRequirementColumns+` FROM download_requirements ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get download requirements: %w", err)
+	}
+	defer rows.Close()
+
+	requirements := []models.DownloadRequirement{}
+	for rows.Next() {
+		req, err := scanDownloadRequirement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan download requirement: %w", err)
+		}
+		requirements = append(requirements, *req)
+	}
+	return requirements, nil
+}
+
+// GetByID returns a download requirement by ID
+func (r *DownloadRepository) GetByID(ctx context.Context, id uint64) (*models.DownloadRequirement, error) {
+	req, err := scanDownloadRequirement(database.DB.QueryRowContext(ctx, `SELECT `+downloadRequirementColumns+` FROM download_requirements WHERE id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get download requirement: %w", err)
+	}
+	return req, nil
+}
+
+// Create adds a download requirement (with retry for SQLITE_BUSY)
+func (r *DownloadRepository) Create(ctx context.Context, req *models.DownloadRequirement) error {
+	return database.WithRetryContext(ctx, func() error {
+		now := time.Now().UTC()
+		result, err := database.DB.ExecContext(ctx, `
+			INSERT INTO download_requirements (app_id, name, version, note, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			req.AppID, req.Name, req.Version, req.Note, now, now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create download requirement: %w", err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get last insert id: %w", err)
+		}
+
+		req.ID = uint64(id)
+		req.CreatedAt = now
+		req.UpdatedAt = now
+		return nil
+	})
+}
+
+// Update overwrites a download requirement (with retry for SQLITE_BUSY)
+// Existing confirmations are kept, admins delete and recreate a requirement when a new version is needed
+func (r *DownloadRepository) Update(ctx context.Context, req *models.DownloadRequirement) error {
+	return database.WithRetryContext(ctx, func() error {
+		now := time.Now().UTC()
+		_, err := database.DB.ExecContext(ctx, `
+			UPDATE download_requirements
+			SET app_id = ?, name = ?, version = ?, note = ?, updated_at = ?
+			WHERE id = ?`,
+			req.AppID, req.Name, req.Version, req.Note, now, req.ID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update download requirement: %w", err)
+		}
+		req.UpdatedAt = now
+		return nil
+	})
+}
+
+// Delete removes a download requirement and its confirmations
+func (r *DownloadRepository) Delete(ctx context.Context, id uint64) error {
+	return database.WithRetryContext(ctx, func() error {
+		if _, err := database.DB.ExecContext(ctx, `DELETE FROM download_requirements WHERE id = ?`, id); err != nil {
+			return fmt.Errorf("failed to delete download requirement: %w", err)
+		}
+		return nil
+	})
+}
+
+// SetReady confirms or revokes a player's download of a requirement
+func (r *DownloadRepository) SetReady(ctx context.Context, requirementID, userID uint64, ready bool) error {
+	return database.WithRetryContext(ctx, func() error {
+		var err error
+		if ready {
+			var exists int
+			err = database.DB.QueryRowContext(ctx, `
+				SELECT COUNT(*) FROM download_confirmations WHERE requirement_id = ? AND user_id = ?`,
+				requirementID, userID,
+			).Scan(&exists)
+			if err == nil && exists == 0 {
+				_, err = database.DB.ExecContext(ctx, `
+					INSERT INTO download_confirmations (requirement_id, user_id, created_at)
+					VALUES (?, ?, ?)`,
+					requirementID, userID, time.Now().UTC(),
+				)
+			}
+		} else {
+			_, err = database.DB.ExecContext(ctx, `
+				DELETE FROM download_confirmations WHERE requirement_id = ? AND user_id = ?`,
+				requirementID, userID,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update download confirmation: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetReadiness returns all requirements with the download progress of all players
+// Players are all users that are neither banned nor anonymized. isReady is set for userID,
+// the players that did not confirm a download are only included with includeMissing
+func (r *DownloadRepository) GetReadiness(ctx context.Context, userID uint64, includeMissing bool) ([]models.DownloadReadiness, error) {
+	requirements, err := r.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := r.getPlayers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := database.DB.QueryContext(ctx, `SELECT requirement_id, user_id FROM download_confirmations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get download confirmations: %w", err)
+	}
+	defer rows.Close()
+
+	confirmed := make(map[uint64]map[uint64]bool)
+	for rows.Next() {
+		var requirementID, confirmedBy uint64
+		if err := rows.Scan(&requirementID, &confirmedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan download confirmation: %w", err)
+		}
+		if confirmed[requirementID] == nil {
+			confirmed[requirementID] = make(map[uint64]bool)
+		}
+		confirmed[requirementID][confirmedBy] = true
+	}
+
+	result := make([]models.DownloadReadiness, 0, len(requirements))
+	for _, req := range requirements {
+		readiness := models.DownloadReadiness{
+			DownloadRequirement: req,
+			PlayerCount:         len(players),
+			IsReady:             confirmed[req.ID][userID],
+		}
+		if includeMissing {
+			readiness.MissingUsers = []models.PublicUser{}
+		}
+
+		// Count only current players, confirmations of banned users do not count
+		for _, player := range players {
+			if confirmed[req.ID][player.ID] {
+				readiness.ReadyCount++
+			} else if includeMissing {
+				readiness.MissingUsers = append(readiness.MissingUsers, player)
+			}
+		}
+		if readiness.PlayerCount > 0 {
+			readiness.Percentage = readiness.ReadyCount * 100 / readiness.PlayerCount
+		}
+
+		result = append(result, readiness)
+	}
+
+	return result, nil
+}
+
+// getPlayers returns all users that are neither banned, deleted nor anonymized
+func (r *DownloadRepository) getPlayers(ctx context.Context) ([]models.PublicUser, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT u.id, u.steam_id, u.username, u.avatar_url, u.avatar_small, u.profile_url, u.country_code
+		FROM users u
+		WHERE u.anonymized_at IS NULL AND u.deleted_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM banned_users b WHERE b.steam_id = u.steam_id)
+		ORDER BY u.username`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get players: %w", err)
+	}
+	defer rows.Close()
+
+	var players []models.PublicUser
+	for rows.Next() {
+		var user models.PublicUser
+		if err := rows.Scan(&user.ID, &user.SteamID, &user.Username, &user.AvatarURL, &user.AvatarSmall, &user.ProfileURL, &user.CountryCode); err != nil {
+			return nil, fmt.Errorf("failed to scan player row: %w", err)
+		}
+		user.Flag = models.CountryFlag(user.CountryCode)
+		players = append(players, user)
+	}
+	return players, nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -19,8 +20,13 @@ type GameCache struct {
 	OriginalCents   int       `json:"original_cents"`
 	DiscountPercent int       `json:"discount_percent"`
 	PriceFormatted  string    `json:"price_formatted"`
-	ReviewScore     int       `json:"review_score"` // Percentage of positive reviews (0-100), -1 if not enough reviews
-	FetchFailed     bool      `json:"fetch_failed"` // True if game was not found (e.g., removed from Steam Store)
+	ReviewScore     int       `json:"review_score"`      // Percentage of positive reviews (0-100), -1 if not enough reviews
+	ReviewScoreDesc string    `json:"review_score_desc"` // Steam review wording, e.g. "Overwhelmingly Positive"
+	ReviewCount     int       `json:"review_count"`      // Total number of reviews
+	MaxPlayers      int       `json:"max_players"`       // Parsed from the store description, 0 if unknown
+	Genres          string    `json:"genres"`            // JSON array stored as string
+	Tags            string    `json:"tags"`              // JSON array of the top user tags stored as string
+	FetchFailed     bool      `json:"fetch_failed"`      // True if game was not found (e.g., removed from Steam Store)
 	FetchedAt       time.Time `json:"fetched_at"`
 }
 
@@ -33,12 +39,12 @@ func NewGameCacheRepository() *GameCacheRepository {
 }
 
 // GetByAppID finds a cached game by App ID
-func (r *GameCacheRepository) GetByAppID(appID int) (*GameCache, error) {
+func (r *GameCacheRepository) GetByAppID(ctx context.Context, appID int) (*GameCache, error) {
 	cache := &GameCache{}
-	err := database.DB.QueryRow(`
-		SELECT app_id, name, categories, is_free, price_cents, original_cents, discount_percent, price_formatted, review_score, fetch_failed, fetched_at
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT app_id, name, categories, is_free, price_cents, original_cents, discount_percent, price_formatted, review_score, review_score_desc, review_count, max_players, genres, tags, fetch_failed, fetched_at
 		FROM game_cache WHERE app_id = ?`, appID,
-	).Scan(&cache.AppID, &cache.Name, &cache.Categories, &cache.IsFree, &cache.PriceCents, &cache.OriginalCents, &cache.DiscountPercent, &cache.PriceFormatted, &cache.ReviewScore, &cache.FetchFailed, &cache.FetchedAt)
+	).Scan(&cache.AppID, &cache.Name, &cache.Categories, &cache.IsFree, &cache.PriceCents, &cache.OriginalCents, &cache.DiscountPercent, &cache.PriceFormatted, &cache.ReviewScore, &cache.ReviewScoreDesc, &cache.ReviewCount, &cache.MaxPlayers, &cache.Genres, &cache.Tags, &cache.FetchFailed, &cache.FetchedAt)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -51,9 +57,9 @@ func (r *GameCacheRepository) GetByAppID(appID int) (*GameCache, error) {
 }
 
 // GetAll returns all cached games
-func (r *GameCacheRepository) GetAll() ([]GameCache, error) {
-	rows, err := database.DB.Query(`
-		SELECT app_id, name, categories, is_free, price_cents, original_cents, discount_percent, price_formatted, review_score, fetch_failed, fetched_at
+func (r *GameCacheRepository) GetAll(ctx context.Context) ([]GameCache, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT app_id, name, categories, is_free, price_cents, original_cents, discount_percent, price_formatted, review_score, review_score_desc, review_count, max_players, genres, tags, fetch_failed, fetched_at
 		FROM game_cache ORDER BY name`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all game cache: %w", err)
@@ -63,7 +69,7 @@ func (r *GameCacheRepository) GetAll() ([]GameCache, error) {
 	var games []GameCache
 	for rows.Next() {
 		var game GameCache
-		err := rows.Scan(&game.AppID, &game.Name, &game.Categories, &game.IsFree, &game.PriceCents, &game.OriginalCents, &game.DiscountPercent, &game.PriceFormatted, &game.ReviewScore, &game.FetchFailed, &game.FetchedAt)
+		err := rows.Scan(&game.AppID, &game.Name, &game.Categories, &game.IsFree, &game.PriceCents, &game.OriginalCents, &game.DiscountPercent, &game.PriceFormatted, &game.ReviewScore, &game.ReviewScoreDesc, &game.ReviewCount, &game.MaxPlayers, &game.Genres, &game.Tags, &game.FetchFailed, &game.FetchedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan game cache row: %w", err)
 		}
@@ -74,10 +80,10 @@ func (r *GameCacheRepository) GetAll() ([]GameCache, error) {
 }
 
 // GetStaleGames returns all games that need to be refreshed (older than maxAge)
-func (r *GameCacheRepository) GetStaleGames(maxAge time.Duration) ([]GameCache, error) {
+func (r *GameCacheRepository) GetStaleGames(ctx context.Context, maxAge time.Duration) ([]GameCache, error) {
 	cutoff := time.Now().Add(-maxAge)
-	rows, err := database.DB.Query(`
-		SELECT app_id, name, categories, is_free, price_cents, original_cents, discount_percent, price_formatted, review_score, fetch_failed, fetched_at
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT app_id, name, categories, is_free, price_cents, original_cents, discount_percent, price_formatted, review_score, review_score_desc, review_count, max_players, genres, tags, fetch_failed, fetched_at
 		FROM game_cache
 		WHERE fetched_at < ?
 		ORDER BY fetched_at ASC`, cutoff)
@@ -89,7 +95,7 @@ func (r *GameCacheRepository) GetStaleGames(maxAge time.Duration) ([]GameCache,
 	var games []GameCache
 	for rows.Next() {
 		var game GameCache
-		err := rows.Scan(&game.AppID, &game.Name, &game.Categories, &game.IsFree, &game.PriceCents, &game.OriginalCents, &game.DiscountPercent, &game.PriceFormatted, &game.ReviewScore, &game.FetchFailed, &game.FetchedAt)
+		err := rows.Scan(&game.AppID, &game.Name, &game.Categories, &game.IsFree, &game.PriceCents, &game.OriginalCents, &game.DiscountPercent, &game.PriceFormatted, &game.ReviewScore, &game.ReviewScoreDesc, &game.ReviewCount, &game.MaxPlayers, &game.Genres, &game.Tags, &game.FetchFailed, &game.FetchedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan game cache row: %w", err)
 		}
@@ -103,12 +109,12 @@ func (r *GameCacheRepository) GetStaleGames(maxAge time.Duration) ([]GameCache,
 // - Never fetched (fetched_at is NULL or epoch)
 // - Stale (fetched_at older than maxAge)
 // - Failed fetches that are ready for retry (older than retryDelay)
-func (r *GameCacheRepository) GetGamesNeedingSync(maxAge, retryDelay time.Duration) ([]GameCache, error) {
+func (r *GameCacheRepository) GetGamesNeedingSync(ctx context.Context, maxAge, retryDelay time.Duration) ([]GameCache, error) {
 	staleCutoff := time.Now().Add(-maxAge)
 	retryCutoff := time.Now().Add(-retryDelay)
 
-	rows, err := database.DB.Query(`
-		SELECT app_id, name, categories, is_free, price_cents, original_cents, discount_percent, price_formatted, review_score, fetch_failed, fetched_at
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT app_id, name, categories, is_free, price_cents, original_cents, discount_percent, price_formatted, review_score, review_score_desc, review_count, max_players, genres, tags, fetch_failed, fetched_at
 		FROM game_cache
 		WHERE
 			fetched_at < ?
@@ -122,7 +128,7 @@ func (r *GameCacheRepository) GetGamesNeedingSync(maxAge, retryDelay time.Durati
 	var games []GameCache
 	for rows.Next() {
 		var game GameCache
-		err := rows.Scan(&game.AppID, &game.Name, &game.Categories, &game.IsFree, &game.PriceCents, &game.OriginalCents, &game.DiscountPercent, &game.PriceFormatted, &game.ReviewScore, &game.FetchFailed, &game.FetchedAt)
+		err := rows.Scan(&game.AppID, &game.Name, &game.Categories, &game.IsFree, &game.PriceCents, &game.OriginalCents, &game.DiscountPercent, &game.PriceFormatted, &game.ReviewScore, &game.ReviewScoreDesc, &game.ReviewCount, &game.MaxPlayers, &game.Genres, &game.Tags, &game.FetchFailed, &game.FetchedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan game cache row: %w", err)
 		}
@@ -134,9 +140,9 @@ func (r *GameCacheRepository) GetGamesNeedingSync(maxAge, retryDelay time.Durati
 
 // InsertIfNotExists adds a game to the cache only if it doesn't already exist
 // This is used when a new user joins - we record their games without overwriting existing data
-func (r *GameCacheRepository) InsertIfNotExists(appID int, name string) error {
+func (r *GameCacheRepository) InsertIfNotExists(ctx context.Context, appID int, name string) error {
 	if database.IsSQLite() {
-		_, err := database.DB.Exec(`
+		_, err := database.DB.ExecContext(ctx, `
 			INSERT OR IGNORE INTO game_cache (app_id, name, categories, review_score, fetched_at)
 			VALUES (?, ?, '[]', -1, '1970-01-01 00:00:00')`,
 			appID, name,
@@ -146,7 +152,7 @@ func (r *GameCacheRepository) InsertIfNotExists(appID int, name string) error {
 		}
 	} else {
 		// MySQL/MariaDB - INSERT IGNORE
-		_, err := database.DB.Exec(`
+		_, err := database.DB.ExecContext(ctx, `
 			INSERT IGNORE INTO game_cache (app_id, name, categories, review_score, fetched_at)
 			VALUES (?, ?, '[]', -1, '1970-01-01 00:00:00')`,
 			appID, name,
@@ -159,12 +165,12 @@ func (r *GameCacheRepository) InsertIfNotExists(appID int, name string) error {
 }
 
 // CountGamesNeedingSync returns the count of games that need to be synced
-func (r *GameCacheRepository) CountGamesNeedingSync(maxAge, retryDelay time.Duration) (int, error) {
+func (r *GameCacheRepository) CountGamesNeedingSync(ctx context.Context, maxAge, retryDelay time.Duration) (int, error) {
 	staleCutoff := time.Now().Add(-maxAge)
 	retryCutoff := time.Now().Add(-retryDelay)
 
 	var count int
-	err := database.DB.QueryRow(`
+	err := database.DB.QueryRowContext(ctx, `
 		SELECT COUNT(*) FROM game_cache
 		WHERE
 			fetched_at < ?
@@ -175,23 +181,28 @@ func (r *GameCacheRepository) CountGamesNeedingSync(maxAge, retryDelay time.Dura
 	return count, nil
 }
 
-// GamePriceInfo contains price and review information for caching
+// GamePriceInfo contains price, review, player count and genre information for caching
 type GamePriceInfo struct {
 	IsFree          bool
 	PriceCents      int
 	OriginalCents   int
 	DiscountPercent int
 	PriceFormatted  string
-	ReviewScore     int // Percentage of positive reviews (0-100), -1 if not enough reviews
+	ReviewScore     int    // Percentage of positive reviews (0-100), -1 if not enough reviews
+	ReviewScoreDesc string // Steam review wording, e.g. "Overwhelmingly Positive"
+	ReviewCount     int    // Total number of reviews
+	MaxPlayers      int    // Parsed from the store description, 0 if unknown
+	Genres          []string
+	Tags            []string // Top user tags, most votes first
 }
 
 // Upsert creates or updates a cached game
-func (r *GameCacheRepository) Upsert(appID int, name string, categories []string, price *GamePriceInfo) error {
-	return r.UpsertWithStatus(appID, name, categories, price, false)
+func (r *GameCacheRepository) Upsert(ctx context.Context, appID int, name string, categories []string, price *GamePriceInfo) error {
+	return r.UpsertWithStatus(ctx, appID, name, categories, price, false)
 }
 
 // UpsertWithStatus creates or updates a cached game with fetch status
-func (r *GameCacheRepository) UpsertWithStatus(appID int, name string, categories []string, price *GamePriceInfo, fetchFailed bool) error {
+func (r *GameCacheRepository) UpsertWithStatus(ctx context.Context, appID int, name string, categories []string, price *GamePriceInfo, fetchFailed bool) error {
 	categoriesJSON, err := json.Marshal(categories)
 	if err != nil {
 		return fmt.Errorf("failed to marshal categories: %w", err)
@@ -201,12 +212,20 @@ func (r *GameCacheRepository) UpsertWithStatus(appID int, name string, categorie
 	if price == nil {
 		price = &GamePriceInfo{ReviewScore: -1}
 	}
+	genresJSON, err := json.Marshal(nonNilStrings(price.Genres))
+	if err != nil {
+		return fmt.Errorf("failed to marshal genres: %w", err)
+	}
+	tagsJSON, err := json.Marshal(nonNilStrings(price.Tags))
+	if err != nil {
+		return fmt.Errorf("failed to marshal tags: %w", err)
+	}
 
 	// Use database-specific upsert syntax
 	if database.IsSQLite() {
-		_, err = database.DB.Exec(`
-			INSERT INTO game_cache (app_id, name, categories, is_free, price_cents, original_cents, discount_percent, price_formatted, review_score, fetch_failed, fetched_at)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		_, err = database.DB.ExecContext(ctx, `
+			INSERT INTO game_cache (app_id, name, categories, is_free, price_cents, original_cents, discount_percent, price_formatted, review_score, review_score_desc, review_count, max_players, genres, tags, fetch_failed, fetched_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
 			ON CONFLICT(app_id) DO UPDATE SET
 				name = excluded.name,
 				categories = excluded.categories,
@@ -216,15 +235,20 @@ func (r *GameCacheRepository) UpsertWithStatus(appID int, name string, categorie
 				discount_percent = excluded.discount_percent,
 				price_formatted = excluded.price_formatted,
 				review_score = excluded.review_score,
+				review_score_desc = excluded.review_score_desc,
+				review_count = excluded.review_count,
+				max_players = excluded.max_players,
+				genres = excluded.genres,
+				tags = excluded.tags,
 				fetch_failed = excluded.fetch_failed,
 				fetched_at = CURRENT_TIMESTAMP`,
-			appID, name, string(categoriesJSON), price.IsFree, price.PriceCents, price.OriginalCents, price.DiscountPercent, price.PriceFormatted, price.ReviewScore, fetchFailed,
+			appID, name, string(categoriesJSON), price.IsFree, price.PriceCents, price.OriginalCents, price.DiscountPercent, price.PriceFormatted, price.ReviewScore, price.ReviewScoreDesc, price.ReviewCount, price.MaxPlayers, string(genresJSON), string(tagsJSON), fetchFailed,
 		)
 	} else {
 		// MySQL/MariaDB syntax
-		_, err = database.DB.Exec(`
-			INSERT INTO game_cache (app_id, name, categories, is_free, price_cents, original_cents, discount_percent, price_formatted, review_score, fetch_failed, fetched_at)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		_, err = database.DB.ExecContext(ctx, `
+			INSERT INTO game_cache (app_id, name, categories, is_free, price_cents, original_cents, discount_percent, price_formatted, review_score, review_score_desc, review_count, max_players, genres, tags, fetch_failed, fetched_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
 			ON DUPLICATE KEY UPDATE
 				name = VALUES(name),
 				categories = VALUES(categories),
@@ -234,9 +258,14 @@ func (r *GameCacheRepository) UpsertWithStatus(appID int, name string, categorie
 				discount_percent = VALUES(discount_percent),
 				price_formatted = VALUES(price_formatted),
 				review_score = VALUES(review_score),
+				review_score_desc = VALUES(review_score_desc),
+				review_count = VALUES(review_count),
+				max_players = VALUES(max_players),
+				genres = VALUES(genres),
+				tags = VALUES(tags),
 				fetch_failed = VALUES(fetch_failed),
 				fetched_at = CURRENT_TIMESTAMP`,
-			appID, name, string(categoriesJSON), price.IsFree, price.PriceCents, price.OriginalCents, price.DiscountPercent, price.PriceFormatted, price.ReviewScore, fetchFailed,
+			appID, name, string(categoriesJSON), price.IsFree, price.PriceCents, price.OriginalCents, price.DiscountPercent, price.PriceFormatted, price.ReviewScore, price.ReviewScoreDesc, price.ReviewCount, price.MaxPlayers, string(genresJSON), string(tagsJSON), fetchFailed,
 		)
 	}
 	if err != nil {
@@ -255,14 +284,55 @@ func (c *GameCache) GetCategories() []string {
 	return categories
 }
 
+// GetGenres parses the genres JSON and returns a string slice
+func (c *GameCache) GetGenres() []string {
+	var genres []string
+	if c.Genres != "" {
+		json.Unmarshal([]byte(c.Genres), &genres)
+	}
+	return genres
+}
+
+// GetTags parses the tags JSON and returns a string slice
+func (c *GameCache) GetTags() []string {
+	var tags []string
+	if c.Tags != "" {
+		json.Unmarshal([]byte(c.Tags), &tags)
+	}
+	return tags
+}
+
+// nonNilStrings returns an empty slice for nil so it is stored as [] instead of null
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
 // IsStale checks if the cache entry is older than the given duration
 func (c *GameCache) IsStale(maxAge time.Duration) bool {
 	return time.Since(c.FetchedAt) > maxAge
 }
 
+// UpdatePrice updates only the price of a cached game, fetched_at is kept so the full refresh stays due
+func (r *GameCacheRepository) UpdatePrice(ctx context.Context, appID int, price *GamePriceInfo) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `
+			UPDATE game_cache SET is_free = ?, price_cents = ?, original_cents = ?, discount_percent = ?, price_formatted = ?
+			WHERE app_id = ?`,
+			price.IsFree, price.PriceCents, price.OriginalCents, price.DiscountPercent, price.PriceFormatted, appID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update game price: %w", err)
+		}
+		return nil
+	})
+}
+
 // Delete removes a cached game by App ID
-func (r *GameCacheRepository) Delete(appID int) error {
-	_, err := database.DB.Exec(`DELETE FROM game_cache WHERE app_id = ?`, appID)
+func (r *GameCacheRepository) Delete(ctx context.Context, appID int) error {
+	_, err := database.DB.ExecContext(ctx, `DELETE FROM game_cache WHERE app_id = ?`, appID)
 	if err != nil {
 		return fmt.Errorf("failed to delete game cache: %w", err)
 	}
@@ -270,8 +340,8 @@ func (r *GameCacheRepository) Delete(appID int) error {
 }
 
 // DeleteAll removes all cached games
-func (r *GameCacheRepository) DeleteAll() error {
-	_, err := database.DB.Exec(`DELETE FROM game_cache`)
+func (r *GameCacheRepository) DeleteAll(ctx context.Context) error {
+	_, err := database.DB.ExecContext(ctx, `DELETE FROM game_cache`)
 	if err != nil {
 		return fmt.Errorf("failed to delete all game cache: %w", err)
 	}
@@ -279,8 +349,8 @@ func (r *GameCacheRepository) DeleteAll() error {
 }
 
 // InvalidateAll marks all cached games as stale by resetting fetched_at to epoch
-func (r *GameCacheRepository) InvalidateAll() error {
-	_, err := database.DB.Exec(`UPDATE game_cache SET fetched_at = '1970-01-01 00:00:00'`)
+func (r *GameCacheRepository) InvalidateAll(ctx context.Context) error {
+	_, err := database.DB.ExecContext(ctx, `UPDATE game_cache SET fetched_at = '1970-01-01 00:00:00'`)
 	if err != nil {
 		return fmt.Errorf("failed to invalidate game cache: %w", err)
 	}
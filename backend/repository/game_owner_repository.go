@@ -1,11 +1,14 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
 )
 
 // GameOwner represents a game ownership entry in the database
@@ -26,8 +29,8 @@ func NewGameOwnerRepository() *GameOwnerRepository {
 }
 
 // GetOwnersByAppID returns all owners of a specific game
-func (r *GameOwnerRepository) GetOwnersByAppID(appID int) ([]GameOwner, error) {
-	rows, err := database.DB.Query(`
+func (r *GameOwnerRepository) GetOwnersByAppID(ctx context.Context, appID int) ([]GameOwner, error) {
+	rows, err := database.DB.QueryContext(ctx, `
 		SELECT app_id, steam_id, playtime_forever, created_at, updated_at
 		FROM game_owners
 		WHERE app_id = ?
@@ -51,8 +54,8 @@ func (r *GameOwnerRepository) GetOwnersByAppID(appID int) ([]GameOwner, error) {
 }
 
 // GetSteamIDsByAppID returns just the steam IDs of owners for a specific game
-func (r *GameOwnerRepository) GetSteamIDsByAppID(appID int) ([]string, error) {
-	rows, err := database.DB.Query(`
+func (r *GameOwnerRepository) GetSteamIDsByAppID(ctx context.Context, appID int) ([]string, error) {
+	rows, err := database.DB.QueryContext(ctx, `
 		SELECT steam_id
 		FROM game_owners
 		WHERE app_id = ?
@@ -76,9 +79,9 @@ func (r *GameOwnerRepository) GetSteamIDsByAppID(appID int) ([]string, error) {
 }
 
 // GetOwnerCountByAppID returns the number of owners for a specific game
-func (r *GameOwnerRepository) GetOwnerCountByAppID(appID int) (int, error) {
+func (r *GameOwnerRepository) GetOwnerCountByAppID(ctx context.Context, appID int) (int, error) {
 	var count int
-	err := database.DB.QueryRow(`
+	err := database.DB.QueryRowContext(ctx, `
 		SELECT COUNT(*) FROM game_owners WHERE app_id = ?`, appID).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count game owners: %w", err)
@@ -87,8 +90,8 @@ func (r *GameOwnerRepository) GetOwnerCountByAppID(appID int) (int, error) {
 }
 
 // GetGamesByUserSteamID returns all games owned by a specific user
-func (r *GameOwnerRepository) GetGamesByUserSteamID(steamID string) ([]GameOwner, error) {
-	rows, err := database.DB.Query(`
+func (r *GameOwnerRepository) GetGamesByUserSteamID(ctx context.Context, steamID string) ([]GameOwner, error) {
+	rows, err := database.DB.QueryContext(ctx, `
 		SELECT app_id, steam_id, playtime_forever, created_at, updated_at
 		FROM game_owners
 		WHERE steam_id = ?
@@ -112,8 +115,8 @@ func (r *GameOwnerRepository) GetGamesByUserSteamID(steamID string) ([]GameOwner
 }
 
 // GetAllOwnersGroupedByAppID returns a map of appID -> []steamID for all games
-func (r *GameOwnerRepository) GetAllOwnersGroupedByAppID() (map[int][]string, error) {
-	rows, err := database.DB.Query(`
+func (r *GameOwnerRepository) GetAllOwnersGroupedByAppID(ctx context.Context) (map[int][]string, error) {
+	rows, err := database.DB.QueryContext(ctx, `
 		SELECT app_id, steam_id
 		FROM game_owners
 		ORDER BY app_id, playtime_forever DESC`)
@@ -136,10 +139,98 @@ func (r *GameOwnerRepository) GetAllOwnersGroupedByAppID() (map[int][]string, er
 	return result, nil
 }
 
+// GetOwnerDetailsGroupedByAppID returns a map of appID -> registered owners with their profile and playtime
+// Owners are sorted by playtime, most played first
+func (r *GameOwnerRepository) GetOwnerDetailsGroupedByAppID(ctx context.Context) (map[int][]models.GameOwnerInfo, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT g.app_id, u.id, u.steam_id, u.username, u.avatar_small, g.playtime_forever
+		FROM game_owners g
+		JOIN users u ON u.steam_id = g.steam_id
+		ORDER BY g.app_id, g.playtime_forever DESC, u.username`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner details grouped by app id: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[int][]models.GameOwnerInfo)
+	for rows.Next() {
+		var appID int
+		var owner models.GameOwnerInfo
+		err := rows.Scan(&appID, &owner.UserID, &owner.SteamID, &owner.Username, &owner.AvatarSmall, &owner.PlaytimeForever)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan owner details row: %w", err)
+		}
+		result[appID] = append(result[appID], owner)
+	}
+
+	return result, nil
+}
+
+// GetMaxPlaytimeByAppID returns a map of appID -> highest playtime of any owner in minutes
+func (r *GameOwnerRepository) GetMaxPlaytimeByAppID(ctx context.Context) (map[int]int, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT app_id, MAX(playtime_forever)
+		FROM game_owners
+		GROUP BY app_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get max playtime by app id: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[int]int)
+	for rows.Next() {
+		var appID, playtime int
+		if err := rows.Scan(&appID, &playtime); err != nil {
+			return nil, fmt.Errorf("failed to scan playtime row: %w", err)
+		}
+		result[appID] = playtime
+	}
+
+	return result, nil
+}
+
+// GetCommonPlaytimeByAppID returns a map of appID -> combined playtime in minutes
+// for all games owned by every one of the given users
+func (r *GameOwnerRepository) GetCommonPlaytimeByAppID(ctx context.Context, userIDs []uint64) (map[int]int, error) {
+	result := make(map[int]int)
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(userIDs)), ", ")
+	args := make([]interface{}, 0, len(userIDs)+1)
+	for _, id := range userIDs {
+		args = append(args, id)
+	}
+	args = append(args, len(userIDs))
+
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT g.app_id, SUM(g.playtime_forever)
+		FROM game_owners g
+		JOIN users u ON u.steam_id = g.steam_id
+		WHERE u.id IN (`+placeholders+`)
+		GROUP BY g.app_id
+		HAVING COUNT(DISTINCT u.id) = ?`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get common games: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var appID, playtime int
+		if err := rows.Scan(&appID, &playtime); err != nil {
+			return nil, fmt.Errorf("failed to scan common game row: %w", err)
+		}
+		result[appID] = playtime
+	}
+
+	return result, nil
+}
+
 // Upsert creates or updates a game ownership entry
-func (r *GameOwnerRepository) Upsert(appID int, steamID string, playtimeForever int) error {
+func (r *GameOwnerRepository) Upsert(ctx context.Context, appID int, steamID string, playtimeForever int) error {
 	if database.IsSQLite() {
-		_, err := database.DB.Exec(`
+		_, err := database.DB.ExecContext(ctx, `
 			INSERT INTO game_owners (app_id, steam_id, playtime_forever, created_at, updated_at)
 			VALUES (?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
 			ON CONFLICT(app_id, steam_id) DO UPDATE SET
@@ -152,7 +243,7 @@ func (r *GameOwnerRepository) Upsert(appID int, steamID string, playtimeForever
 		}
 	} else {
 		// MySQL/MariaDB syntax
-		_, err := database.DB.Exec(`
+		_, err := database.DB.ExecContext(ctx, `
 			INSERT INTO game_owners (app_id, steam_id, playtime_forever, created_at, updated_at)
 			VALUES (?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
 			ON DUPLICATE KEY UPDATE
@@ -168,7 +259,7 @@ func (r *GameOwnerRepository) Upsert(appID int, steamID string, playtimeForever
 }
 
 // UpsertBatch upserts multiple game ownerships for a user efficiently
-func (r *GameOwnerRepository) UpsertBatch(steamID string, games []struct {
+func (r *GameOwnerRepository) UpsertBatch(ctx context.Context, steamID string, games []struct {
 	AppID           int
 	PlaytimeForever int
 }) error {
@@ -177,7 +268,7 @@ func (r *GameOwnerRepository) UpsertBatch(steamID string, games []struct {
 	}
 
 	// Use a transaction for better performance
-	tx, err := database.DB.Begin()
+	tx, err := database.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -185,14 +276,14 @@ func (r *GameOwnerRepository) UpsertBatch(steamID string, games []struct {
 
 	var stmt *sql.Stmt
 	if database.IsSQLite() {
-		stmt, err = tx.Prepare(`
+		stmt, err = tx.PrepareContext(ctx, `
 			INSERT INTO game_owners (app_id, steam_id, playtime_forever, created_at, updated_at)
 			VALUES (?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
 			ON CONFLICT(app_id, steam_id) DO UPDATE SET
 				playtime_forever = excluded.playtime_forever,
 				updated_at = CURRENT_TIMESTAMP`)
 	} else {
-		stmt, err = tx.Prepare(`
+		stmt, err = tx.PrepareContext(ctx, `
 			INSERT INTO game_owners (app_id, steam_id, playtime_forever, created_at, updated_at)
 			VALUES (?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
 			ON DUPLICATE KEY UPDATE
@@ -205,7 +296,7 @@ func (r *GameOwnerRepository) UpsertBatch(steamID string, games []struct {
 	defer stmt.Close()
 
 	for _, game := range games {
-		_, err = stmt.Exec(game.AppID, steamID, game.PlaytimeForever)
+		_, err = stmt.ExecContext(ctx, game.AppID, steamID, game.PlaytimeForever)
 		if err != nil {
 			return fmt.Errorf("failed to upsert game owner %d for %s: %w", game.AppID, steamID, err)
 		}
@@ -219,8 +310,8 @@ func (r *GameOwnerRepository) UpsertBatch(steamID string, games []struct {
 }
 
 // DeleteByUserSteamID removes all game ownerships for a specific user
-func (r *GameOwnerRepository) DeleteByUserSteamID(steamID string) error {
-	_, err := database.DB.Exec(`DELETE FROM game_owners WHERE steam_id = ?`, steamID)
+func (r *GameOwnerRepository) DeleteByUserSteamID(ctx context.Context, steamID string) error {
+	_, err := database.DB.ExecContext(ctx, `DELETE FROM game_owners WHERE steam_id = ?`, steamID)
 	if err != nil {
 		return fmt.Errorf("failed to delete game owners by steam id: %w", err)
 	}
@@ -228,8 +319,8 @@ func (r *GameOwnerRepository) DeleteByUserSteamID(steamID string) error {
 }
 
 // DeleteByAppID removes all ownership entries for a specific game
-func (r *GameOwnerRepository) DeleteByAppID(appID int) error {
-	_, err := database.DB.Exec(`DELETE FROM game_owners WHERE app_id = ?`, appID)
+func (r *GameOwnerRepository) DeleteByAppID(ctx context.Context, appID int) error {
+	_, err := database.DB.ExecContext(ctx, `DELETE FROM game_owners WHERE app_id = ?`, appID)
 	if err != nil {
 		return fmt.Errorf("failed to delete game owners by app id: %w", err)
 	}
@@ -237,8 +328,8 @@ func (r *GameOwnerRepository) DeleteByAppID(appID int) error {
 }
 
 // DeleteAll removes all game ownership entries
-func (r *GameOwnerRepository) DeleteAll() error {
-	_, err := database.DB.Exec(`DELETE FROM game_owners`)
+func (r *GameOwnerRepository) DeleteAll(ctx context.Context) error {
+	_, err := database.DB.ExecContext(ctx, `DELETE FROM game_owners`)
 	if err != nil {
 		return fmt.Errorf("failed to delete all game owners: %w", err)
 	}
@@ -246,9 +337,9 @@ func (r *GameOwnerRepository) DeleteAll() error {
 }
 
 // Exists checks if a specific ownership entry exists
-func (r *GameOwnerRepository) Exists(appID int, steamID string) (bool, error) {
+func (r *GameOwnerRepository) Exists(ctx context.Context, appID int, steamID string) (bool, error) {
 	var count int
-	err := database.DB.QueryRow(`
+	err := database.DB.QueryRowContext(ctx, `
 		SELECT COUNT(*) FROM game_owners WHERE app_id = ? AND steam_id = ?`, appID, steamID).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("failed to check game owner existence: %w", err)
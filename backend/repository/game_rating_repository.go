@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// GameRatingRepository handles the star ratings of games by players
+type GameRatingRepository struct{}
+
+// NewGameRatingRepository creates a new game rating repository
+func NewGameRatingRepository() *GameRatingRepository {
+	return &GameRatingRepository{}
+}
+
+// Upsert stores the rating of a player for a game, an earlier rating is replaced (with retry for SQLITE_BUSY)
+func (r *GameRatingRepository) Upsert(ctx context.Context, appID int, userID uint64, rating int) error {
+	return database.WithRetryContext(ctx, func() error {
+		now := time.Now().UTC()
+		var err error
+		if database.IsSQLite() {
+			_, err = database.DB.ExecContext(ctx, `
+				INSERT INTO game_ratings (app_id, user_id, rating, created_at, updated_at)
+				VALUES (?, ?, ?, ?, ?)
+				ON CONFLICT(app_id, user_id) DO UPDATE SET
+					rating = excluded.rating,
+					updated_at = excluded.updated_at`,
+				appID, userID, rating, now, now,
+			)
+		} else {
+			_, err = database.DB.ExecContext(ctx, `
+				INSERT INTO game_ratings (app_id, user_id, rating, created_at, updated_at)
+				VALUES (?, ?, ?, ?, ?)
+				ON DUPLICATE KEY UPDATE
+					rating = VALUES(rating),
+					updated_at = VALUES(updated_at)`,
+				appID, userID, rating, now, now,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to save game rating: %w", err)
+		}
+		return nil
+	})
+}
+
+// Delete removes the rating of a player for a game (with retry for SQLITE_BUSY)
+func (r *GameRatingRepository) Delete(ctx context.Context, appID int, userID uint64) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `DELETE FROM game_ratings WHERE app_id = ? AND user_id = ?`, appID, userID)
+		if err != nil {
+			return fmt.Errorf("failed to delete game rating: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetUserRating returns the rating of a player for a game, 0 if the player has not rated it
+func (r *GameRatingRepository) GetUserRating(ctx context.Context, appID int, userID uint64) (int, error) {
+	var rating int
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT rating FROM game_ratings WHERE app_id = ? AND user_id = ?`, appID, userID,
+	).Scan(&rating)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get game rating: %w", err)
+	}
+	return rating, nil
+}
+
+// GetSummary returns the average and number of ratings of a game
+func (r *GameRatingRepository) GetSummary(ctx context.Context, appID int) (models.GameRatingSummary, error) {
+	var summary models.GameRatingSummary
+	var avg sql.NullFloat64
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT AVG(rating), COUNT(*) FROM game_ratings WHERE app_id = ?`, appID,
+	).Scan(&avg, &summary.Count)
+	if err != nil {
+		return summary, fmt.Errorf("failed to get game rating summary: %w", err)
+	}
+	summary.Average = roundRating(avg.Float64)
+	return summary, nil
+}
+
+// GetSummaries returns the average and number of ratings of all rated games by app ID
+func (r *GameRatingRepository) GetSummaries(ctx context.Context) (map[int]models.GameRatingSummary, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT app_id, AVG(rating), COUNT(*)
+		FROM game_ratings
+		GROUP BY app_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game rating summaries: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[int]models.GameRatingSummary)
+	for rows.Next() {
+		var appID int
+		var avg float64
+		var summary models.GameRatingSummary
+		if err := rows.Scan(&appID, &avg, &summary.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan game rating summary: %w", err)
+		}
+		summary.Average = roundRating(avg)
+		result[appID] = summary
+	}
+	return result, nil
+}
+
+// roundRating rounds an average rating to one decimal
+func roundRating(avg float64) float64 {
+	return math.Round(avg*10) / 10
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// GameServerRepository handles the announced servers of games
+type GameServerRepository struct{}
+
+// NewGameServerRepository creates a new game server repository
+func NewGameServerRepository() *GameServerRepository {
+	return &GameServerRepository{}
+}
+
+// gameServerQuery selects game servers with the player who announced them
+const gameServerQuery = `
+	SELECT s.app_id, s.address, s.note, s.updated_at,
+		u.id, u.steam_id, u.username, u.avatar_url, u.avatar_small, u.profile_url, u.country_code
+	FROM game_servers s
+	JOIN users u ON s.updated_by = u.id`
+
+// scanGameServer scans a row selected with gameServerQuery, the game name is left to the caller
+func scanGameServer(s rowScanner) (*models.GameServer, error) {
+	var server models.GameServer
+	err := s.Scan(
+		&server.AppID, &server.Address, &server.Note, &server.UpdatedAt,
+		&server.UpdatedBy.ID, &server.UpdatedBy.SteamID, &server.UpdatedBy.Username, &server.UpdatedBy.AvatarURL,
+		&server.UpdatedBy.AvatarSmall, &server.UpdatedBy.ProfileURL, &server.UpdatedBy.CountryCode,
+	)
+	if err != nil {
+		return nil, err
+	}
+	server.UpdatedBy.Flag = models.CountryFlag(server.UpdatedBy.CountryCode)
+	server.JoinURL = models.SteamConnectURL(server.Address)
+	server.LaunchURL = models.SteamLaunchURL(server.AppID)
+	return &server, nil
+}
+
+// Upsert stores the server of a game, an earlier server of the game is replaced (with retry for SQLITE_BUSY)
+func (r *GameServerRepository) Upsert(ctx context.Context, appID int, address, note string, userID uint64) error {
+	return database.WithRetryContext(ctx, func() error {
+		now := time.Now().UTC()
+		var err error
+		if database.IsSQLite() {
+			_, err = database.DB.ExecContext(ctx, `
+				INSERT INTO game_servers (app_id, address, note, updated_by, updated_at)
+				VALUES (?, ?, ?, ?, ?)
+				ON CONFLICT(app_id) DO UPDATE SET
+					address = excluded.address,
+					note = excluded.note,
+					updated_by = excluded.updated_by,
+					updated_at = excluded.updated_at`,
+				appID, address, note, userID, now,
+			)
+		} else {
+			_, err = database.DB.ExecContext(ctx, `
+				INSERT INTO game_servers (app_id, address, note, updated_by, updated_at)
+				VALUES (?, ?, ?, ?, ?)
+				ON DUPLICATE KEY UPDATE
+					address = VALUES(address),
+					note = VALUES(note),
+					updated_by = VALUES(updated_by),
+					updated_at = VALUES(updated_at)`,
+				appID, address, note, userID, now,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to save game server: %w", err)
+		}
+		return nil
+	})
+}
+
+// Delete removes the server of a game (with retry for SQLITE_BUSY)
+func (r *GameServerRepository) Delete(ctx context.Context, appID int) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `DELETE FROM game_servers WHERE app_id = ?`, appID)
+		if err != nil {
+			return fmt.Errorf("failed to delete game server: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetByAppID returns the server of a game, nil if none was announced
+func (r *GameServerRepository) GetByAppID(ctx context.Context, appID int) (*models.GameServer, error) {
+	server, err := scanGameServer(database.DB.QueryRowContext(ctx, gameServerQuery+` WHERE s.app_id = ?`, appID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game server: %w", err)
+	}
+	return server, nil
+}
+
+// GetAll returns all announced servers, most recently updated first
+func (r *GameServerRepository) GetAll(ctx context.Context) ([]models.GameServer, error) {
+	rows, err := database.DB.QueryContext(ctx, gameServerQuery+` ORDER BY s.updated_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game servers: %w", err)
+	}
+	defer rows.Close()
+
+	servers := []models.GameServer{}
+	for rows.Next() {
+		server, err := scanGameServer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan game server: %w", err)
+		}
+		servers = append(servers, *server)
+	}
+	return servers, nil
+}
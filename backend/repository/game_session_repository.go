@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// GameSessionRepository handles the time players spend in games
+type GameSessionRepository struct{}
+
+// NewGameSessionRepository creates a new game session repository
+func NewGameSessionRepository() *GameSessionRepository {
+	return &GameSessionRepository{}
+}
+
+// gameSessionQuery selects game sessions with their player
+const gameSessionQuery = `
+	SELECT s.id, s.app_id, s.source, s.started_at, s.ended_at,
+		u.id, u.steam_id, u.username, u.avatar_url, u.avatar_small, u.profile_url, u.country_code
+	FROM game_sessions s
+	JOIN users u ON s.user_id = u.id`
+
+// Start ends the running session of a player and starts a session in the given game
+func (r *GameSessionRepository) Start(ctx context.Context, userID uint64, appID int, source string) error {
+	return database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		now := time.Now().UTC()
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE game_sessions SET ended_at = ? WHERE user_id = ? AND ended_at IS NULL`,
+			now, userID,
+		); err != nil {
+			return fmt.Errorf("failed to end running game session: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO game_sessions (user_id, app_id, source, started_at) VALUES (?, ?, ?, ?)`,
+			userID, appID, source, now,
+		); err != nil {
+			return fmt.Errorf("failed to start game session: %w", err)
+		}
+		return nil
+	})
+}
+
+// End ends the running session of a player, returns false if the player was not in a game (with retry for SQLITE_BUSY)
+func (r *GameSessionRepository) End(ctx context.Context, userID uint64) (bool, error) {
+	var ended bool
+	err := database.WithRetryContext(ctx, func() error {
+		result, err := database.DB.ExecContext(ctx, `
+			UPDATE game_sessions SET ended_at = ? WHERE user_id = ? AND ended_at IS NULL`,
+			time.Now().UTC(), userID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to end game session: %w", err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get ended game sessions: %w", err)
+		}
+		ended = affected > 0
+		return nil
+	})
+	return ended, err
+}
+
+// GetActive returns the running sessions of all players, longest running first
+func (r *GameSessionRepository) GetActive(ctx context.Context) ([]models.GameSession, error) {
+	return r.query(ctx, gameSessionQuery+` WHERE s.ended_at IS NULL ORDER BY s.started_at`)
+}
+
+// GetAll returns all sessions of the party in the order they were started
+func (r *GameSessionRepository) GetAll(ctx context.Context) ([]models.GameSession, error) {
+	return r.query(ctx, gameSessionQuery+` ORDER BY s.started_at`)
+}
+
+// query runs a query selecting gameSessionQuery columns
+func (r *GameSessionRepository) query(ctx context.Context, query string) ([]models.GameSession, error) {
+	rows, err := database.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []models.GameSession{}
+	for rows.Next() {
+		var s models.GameSession
+		err := rows.Scan(
+			&s.ID, &s.AppID, &s.Source, &s.StartedAt, &s.EndedAt,
+			&s.User.ID, &s.User.SteamID, &s.User.Username, &s.User.AvatarURL,
+			&s.User.AvatarSmall, &s.User.ProfileURL, &s.User.CountryCode,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan game session: %w", err)
+		}
+		s.User.Flag = models.CountryFlag(s.User.CountryCode)
+		sessions = append(sessions, s)
+	}
+	return sessions, nil
+}
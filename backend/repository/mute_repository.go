@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// MuteRepository handles chat mutes set by admins
+type MuteRepository struct{}
+
+// NewMuteRepository creates a new mute repository
+func NewMuteRepository() *MuteRepository {
+	return &MuteRepository{}
+}
+
+// Mute stores a mute, an existing mute of the user is replaced (with retry for SQLITE_BUSY)
+func (r *MuteRepository) Mute(ctx context.Context, mute *models.UserMute) error {
+	return database.WithRetryContext(ctx, func() error {
+		now := time.Now().UTC()
+		var err error
+		if database.IsSQLite() {
+			_, err = database.DB.ExecContext(ctx, `
+				INSERT INTO user_mutes (user_id, muted_until, include_votes, reason, muted_by, created_at)
+				VALUES (?, ?, ?, ?, ?, ?)
+				ON CONFLICT(user_id) DO UPDATE SET
+					muted_until = excluded.muted_until,
+					include_votes = excluded.include_votes,
+					reason = excluded.reason,
+					muted_by = excluded.muted_by,
+					created_at = excluded.created_at`,
+				mute.UserID, mute.MutedUntil.UTC(), mute.IncludeVotes, mute.Reason, mute.MutedBy, now,
+			)
+		} else {
+			_, err = database.DB.ExecContext(ctx, `
+				INSERT INTO user_mutes (user_id, muted_until, include_votes, reason, muted_by, created_at)
+				VALUES (?, ?, ?, ?, ?, ?)
+				ON DUPLICATE KEY UPDATE
+					muted_until = VALUES(muted_until),
+					include_votes = VALUES(include_votes),
+					reason = VALUES(reason),
+					muted_by = VALUES(muted_by),
+					created_at = VALUES(created_at)`,
+				mute.UserID, mute.MutedUntil.UTC(), mute.IncludeVotes, mute.Reason, mute.MutedBy, now,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to mute user: %w", err)
+		}
+		mute.CreatedAt = now
+		return nil
+	})
+}
+
+// GetActive returns the mute of a user, nil if the user is not muted or the mute has expired
+func (r *MuteRepository) GetActive(ctx context.Context, userID uint64) (*models.UserMute, error) {
+	var m models.UserMute
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT m.user_id, u.username, m.muted_until, m.include_votes, COALESCE(m.reason, ''), m.muted_by, m.created_at
+		FROM user_mutes m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.user_id = ? AND m.muted_until > ?`,
+		userID, time.Now().UTC(),
+	).Scan(&m.UserID, &m.Username, &m.MutedUntil, &m.IncludeVotes, &m.Reason, &m.MutedBy, &m.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mute: %w", err)
+	}
+	return &m, nil
+}
+
+// GetAllActive returns all mutes that have not expired yet, ending soonest first
+func (r *MuteRepository) GetAllActive(ctx context.Context) ([]models.UserMute, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT m.user_id, u.username, m.muted_until, m.include_votes, COALESCE(m.reason, ''), m.muted_by, m.created_at
+		FROM user_mutes m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.muted_until > ?
+		ORDER BY m.muted_until`,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mutes: %w", err)
+	}
+	defer rows.Close()
+
+	mutes := []models.UserMute{}
+	for rows.Next() {
+		var m models.UserMute
+		if err := rows.Scan(&m.UserID, &m.Username, &m.MutedUntil, &m.IncludeVotes, &m.Reason, &m.MutedBy, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan mute: %w", err)
+		}
+		mutes = append(mutes, m)
+	}
+
+	return mutes, nil
+}
+
+// Unmute removes the mute of a user (with retry for SQLITE_BUSY)
+// Returns false if the user was not muted
+func (r *MuteRepository) Unmute(ctx context.Context, userID uint64) (bool, error) {
+	var removed bool
+	err := database.WithRetryContext(ctx, func() error {
+		result, err := database.DB.ExecContext(ctx, `DELETE FROM user_mutes WHERE user_id = ? AND muted_until > ?`, userID, time.Now().UTC())
+		if err != nil {
+			return fmt.Errorf("failed to unmute user: %w", err)
+		}
+		changed, _ := result.RowsAffected()
+		removed = changed > 0
+		return nil
+	})
+	return removed, err
+}
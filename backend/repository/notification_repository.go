@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// NotificationRepository handles personal notification database operations
+type NotificationRepository struct{}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository() *NotificationRepository {
+	return &NotificationRepository{}
+}
+
+// Create stores a new unread notification (with retry for SQLITE_BUSY)
+// Vote notifications only store the vote ID, an empty payload is stored as {}
+func (r *NotificationRepository) Create(ctx context.Context, n *models.Notification) error {
+	payload := string(n.Payload)
+	if payload == "" {
+		payload = "{}"
+	}
+	var voteID interface{}
+	if n.VoteID != 0 {
+		voteID = n.VoteID
+	}
+
+	return database.WithRetryContext(ctx, func() error {
+		now := time.Now().UTC()
+		result, err := database.DB.ExecContext(ctx, `
+			INSERT INTO notifications (user_id, type, payload, vote_id, is_read, created_at)
+			VALUES (?, ?, ?, ?, 0, ?)`,
+			n.UserID, n.Type, payload, voteID, now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create notification: %w", err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get last insert id: %w", err)
+		}
+
+		n.ID = uint64(id)
+		n.IsRead = false
+		n.CreatedAt = now
+		return nil
+	})
+}
+
+// GetByUser returns the most recent notifications of the user, oldest first so they are shown in order
+func (r *NotificationRepository) GetByUser(ctx context.Context, userID uint64, unreadOnly bool, limit int) ([]models.Notification, error) {
+	query := `
+		SELECT id, user_id, type, payload, vote_id, is_read, created_at
+		FROM notifications
+		WHERE user_id = ?`
+	if unreadOnly {
+		query += ` AND is_read = 0`
+	}
+	query += ` ORDER BY id DESC LIMIT ?`
+
+	rows, err := database.DB.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []models.Notification{}
+	for rows.Next() {
+		var n models.Notification
+		var payload string
+		var voteID sql.NullInt64
+		if err := rows.Scan(&n.ID, &n.UserID, &n.Type, &payload, &voteID, &n.IsRead, &n.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		n.Payload = []byte(payload)
+		n.VoteID = uint64(voteID.Int64)
+		notifications = append(notifications, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	// Newest were selected first to apply the limit
+	for i, j := 0, len(notifications)-1; i < j; i, j = i+1, j-1 {
+		notifications[i], notifications[j] = notifications[j], notifications[i]
+	}
+	return notifications, nil
+}
+
+// CountUnread returns the number of unread notifications of the user
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID uint64) (int, error) {
+	var count int
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM notifications WHERE user_id = ? AND is_read = 0`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkRead marks notifications of the user as read, all of them if ids is empty (with retry for SQLITE_BUSY)
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID uint64, ids []uint64) error {
+	query := `UPDATE notifications SET is_read = 1 WHERE user_id = ? AND is_read = 0`
+	args := []interface{}{userID}
+	if len(ids) > 0 {
+		query += ` AND id IN (` + strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ") + `)`
+		for _, id := range ids {
+			args = append(args, id)
+		}
+	}
+
+	return database.WithRetryContext(ctx, func() error {
+		if _, err := database.DB.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to mark notifications as read: %w", err)
+		}
+		return nil
+	})
+}
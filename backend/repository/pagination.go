@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+)
+
+// MaxPageLimit is the largest page a listing returns
+const MaxPageLimit = 500
+
+// ErrInvalidCursor is returned for cursors that were not created by EncodeCursor
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Page selects one page of a listing with keyset pagination
+// The cursor is the ID of the last row of the previous page, 0 starts at the beginning.
+// Listings define their order: newest first listings continue below the cursor, others above it.
+type Page struct {
+	Cursor uint64
+	Limit  int
+}
+
+// NewPage decodes a cursor from a query parameter and clamps the limit to 1..MaxPageLimit
+// An empty cursor starts at the beginning, a limit below 1 falls back to defaultLimit
+func NewPage(cursor string, limit, defaultLimit int) (Page, error) {
+	id, err := DecodeCursor(cursor)
+	if err != nil {
+		return Page{}, err
+	}
+	return Page{Cursor: id, Limit: ClampLimit(limit, defaultLimit)}, nil
+}
+
+// ClampLimit limits a requested page size to 1..MaxPageLimit, using defaultLimit below 1
+func ClampLimit(limit, defaultLimit int) int {
+	if limit < 1 {
+		limit = defaultLimit
+	}
+	return min(max(limit, 1), MaxPageLimit)
+}
+
+// EncodeCursor turns the ID of the last row of a page into an opaque cursor for the next page
+func EncodeCursor(id uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(id, 10)))
+}
+
+// DecodeCursor returns the ID of a cursor, 0 for an empty cursor
+func DecodeCursor(cursor string) (uint64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	id, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil || id == 0 {
+		return 0, ErrInvalidCursor
+	}
+	return id, nil
+}
+
+// NextCursor returns the cursor of the page after rows, id returns the ID of a row
+// A page with fewer than limit rows is the last one, its next cursor is empty
+func NextCursor[T any](page Page, rows []T, id func(T) uint64) string {
+	if len(rows) == 0 || len(rows) < page.Limit {
+		return ""
+	}
+	return EncodeCursor(id(rows[len(rows)-1]))
+}
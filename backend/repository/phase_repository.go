@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// PhaseRepository handles event phase database operations
+type PhaseRepository struct{}
+
+// NewPhaseRepository creates a new phase repository
+func NewPhaseRepository() *PhaseRepository {
+	return &PhaseRepository{}
+}
+
+// GetAll returns all event phases ordered by start time
+func (r *PhaseRepository) GetAll(ctx context.Context) ([]models.EventPhase, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT id, name, starts_at, credit_interval_minutes, negative_voting_disabled, vote_visibility_mode, created_at, updated_at
+		FROM event_phases
+		ORDER BY starts_at, id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event phases: %w", err)
+	}
+	defer rows.Close()
+
+	var phases []models.EventPhase
+	for rows.Next() {
+		var p models.EventPhase
+		if err := rows.Scan(&p.ID, &p.Name, &p.StartsAt, &p.CreditIntervalMinutes, &p.NegativeVotingDisabled,
+			&p.VoteVisibilityMode, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event phase: %w", err)
+		}
+		phases = append(phases, p)
+	}
+
+	return phases, nil
+}
+
+// GetByID finds an event phase by ID
+func (r *PhaseRepository) GetByID(ctx context.Context, id uint64) (*models.EventPhase, error) {
+	var p models.EventPhase
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT id, name, starts_at, credit_interval_minutes, negative_voting_disabled, vote_visibility_mode, created_at, updated_at
+		FROM event_phases
+		WHERE id = ?`, id,
+	).Scan(&p.ID, &p.Name, &p.StartsAt, &p.CreditIntervalMinutes, &p.NegativeVotingDisabled,
+		&p.VoteVisibilityMode, &p.CreatedAt, &p.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event phase: %w", err)
+	}
+	return &p, nil
+}
+
+// Create inserts a new event phase (with retry for SQLITE_BUSY)
+func (r *PhaseRepository) Create(ctx context.Context, phase *models.EventPhase) error {
+	return database.WithRetryContext(ctx, func() error {
+		now := time.Now().UTC()
+		result, err := database.DB.ExecContext(ctx, `
+			INSERT INTO event_phases (name, starts_at, credit_interval_minutes, negative_voting_disabled, vote_visibility_mode, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			phase.Name, phase.StartsAt.UTC(), phase.CreditIntervalMinutes, phase.NegativeVotingDisabled,
+			phase.VoteVisibilityMode, now, now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create event phase: %w", err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get event phase ID: %w", err)
+		}
+		phase.ID = uint64(id)
+		phase.CreatedAt = now
+		phase.UpdatedAt = now
+		return nil
+	})
+}
+
+// Update overwrites an existing event phase (with retry for SQLITE_BUSY)
+func (r *PhaseRepository) Update(ctx context.Context, phase *models.EventPhase) error {
+	return database.WithRetryContext(ctx, func() error {
+		now := time.Now().UTC()
+		_, err := database.DB.ExecContext(ctx, `
+			UPDATE event_phases
+			SET name = ?, starts_at = ?, credit_interval_minutes = ?, negative_voting_disabled = ?, vote_visibility_mode = ?, updated_at = ?
+			WHERE id = ?`,
+			phase.Name, phase.StartsAt.UTC(), phase.CreditIntervalMinutes, phase.NegativeVotingDisabled,
+			phase.VoteVisibilityMode, now, phase.ID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update event phase: %w", err)
+		}
+		phase.UpdatedAt = now
+		return nil
+	})
+}
+
+// Delete removes an event phase (with retry for SQLITE_BUSY)
+func (r *PhaseRepository) Delete(ctx context.Context, id uint64) error {
+	return database.WithRetryContext(ctx, func() error {
+		if _, err := database.DB.ExecContext(ctx, `DELETE FROM event_phases WHERE id = ?`, id); err != nil {
+			return fmt.Errorf("failed to delete event phase: %w", err)
+		}
+		return nil
+	})
+}
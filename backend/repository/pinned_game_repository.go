@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+)
+
+// PinnedGameRepository handles the pinned games managed by admins
+type PinnedGameRepository struct{}
+
+// NewPinnedGameRepository creates a new pinned game repository
+func NewPinnedGameRepository() *PinnedGameRepository {
+	return &PinnedGameRepository{}
+}
+
+// GetAll returns the app IDs of the pinned games in their order
+func (r *PinnedGameRepository) GetAll(ctx context.Context) ([]int, error) {
+	rows, err := database.DB.QueryContext(ctx, `SELECT app_id FROM pinned_games ORDER BY position, app_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pinned games: %w", err)
+	}
+	defer rows.Close()
+
+	appIDs := []int{}
+	for rows.Next() {
+		var appID int
+		if err := rows.Scan(&appID); err != nil {
+			return nil, fmt.Errorf("failed to scan pinned game: %w", err)
+		}
+		appIDs = append(appIDs, appID)
+	}
+
+	return appIDs, nil
+}
+
+// Replace stores the pinned games in the given order, games missing in the list are unpinned
+func (r *PinnedGameRepository) Replace(ctx context.Context, appIDs []int) error {
+	return database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM pinned_games`); err != nil {
+			return fmt.Errorf("failed to clear pinned games: %w", err)
+		}
+		for position, appID := range appIDs {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO pinned_games (app_id, position) VALUES (?, ?)`, appID, position); err != nil {
+				return fmt.Errorf("failed to insert pinned game: %w", err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// PollRepository handles the "what do we play next?" polls
+type PollRepository struct{}
+
+// NewPollRepository creates a new poll repository
+func NewPollRepository() *PollRepository {
+	return &PollRepository{}
+}
+
+// pollQuery selects polls with their creator
+const pollQuery = `
+	SELECT p.id, p.question, p.closes_at, p.closed_at, p.created_at,
+		u.id, u.steam_id, u.username, u.avatar_url, u.avatar_small, u.profile_url, u.country_code
+	FROM polls p
+	JOIN users u ON p.created_by = u.id`
+
+// scanPoll scans a row selected with pollQuery
+func scanPoll(s rowScanner) (*models.Poll, error) {
+	var p models.Poll
+	err := s.Scan(
+		&p.ID, &p.Question, &p.ClosesAt, &p.ClosedAt, &p.CreatedAt,
+		&p.CreatedBy.ID, &p.CreatedBy.SteamID, &p.CreatedBy.Username, &p.CreatedBy.AvatarURL,
+		&p.CreatedBy.AvatarSmall, &p.CreatedBy.ProfileURL, &p.CreatedBy.CountryCode,
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.CreatedBy.Flag = models.CountryFlag(p.CreatedBy.CountryCode)
+	return &p, nil
+}
+
+// Create stores a poll with its options in a transaction
+// The IDs of the poll and its options are set on success
+func (r *PollRepository) Create(ctx context.Context, poll *models.Poll) error {
+	return database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		poll.CreatedAt = time.Now().UTC()
+		var closesAt interface{}
+		if poll.ClosesAt != nil {
+			closesAt = poll.ClosesAt.UTC()
+		}
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO polls (question, created_by, closes_at, created_at)
+			VALUES (?, ?, ?, ?)`,
+			poll.Question, poll.CreatedBy.ID, closesAt, poll.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create poll: %w", err)
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get poll ID: %w", err)
+		}
+		poll.ID = uint64(id)
+
+		for i := range poll.Options {
+			result, err := tx.ExecContext(ctx, `
+				INSERT INTO poll_options (poll_id, app_id, name) VALUES (?, ?, ?)`,
+				poll.ID, poll.Options[i].AppID, poll.Options[i].Name,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create poll option: %w", err)
+			}
+			optionID, err := result.LastInsertId()
+			if err != nil {
+				return fmt.Errorf("failed to get poll option ID: %w", err)
+			}
+			poll.Options[i].ID = uint64(optionID)
+		}
+		return nil
+	})
+}
+
+// GetByID returns a poll with its results, nil if it does not exist
+// userID is the requesting player whose vote is returned as MyOptionID
+func (r *PollRepository) GetByID(ctx context.Context, id, userID uint64) (*models.Poll, error) {
+	poll, err := scanPoll(database.DB.QueryRowContext(ctx, pollQuery+`
+		WHERE p.id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	if err := r.loadResults(ctx, poll, userID); err != nil {
+		return nil, err
+	}
+	return poll, nil
+}
+
+// GetRecent returns the newest polls with their results
+func (r *PollRepository) GetRecent(ctx context.Context, userID uint64, limit int) ([]models.Poll, error) {
+	rows, err := database.DB.QueryContext(ctx, pollQuery+`
+		ORDER BY p.created_at DESC, p.id DESC
+		LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get polls: %w", err)
+	}
+
+	polls := []models.Poll{}
+	for rows.Next() {
+		poll, err := scanPoll(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan poll: %w", err)
+		}
+		polls = append(polls, *poll)
+	}
+	rows.Close()
+
+	for i := range polls {
+		if err := r.loadResults(ctx, &polls[i], userID); err != nil {
+			return nil, err
+		}
+	}
+	return polls, nil
+}
+
+// loadResults reads the options with their votes and the vote of the requesting player
+func (r *PollRepository) loadResults(ctx context.Context, poll *models.Poll, userID uint64) error {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT o.id, o.app_id, o.name, COUNT(v.user_id)
+		FROM poll_options o
+		LEFT JOIN poll_votes v ON v.option_id = o.id
+		WHERE o.poll_id = ?
+		GROUP BY o.id, o.app_id, o.name
+		ORDER BY o.id`, poll.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get poll options: %w", err)
+	}
+	defer rows.Close()
+
+	poll.Options = []models.PollOption{}
+	for rows.Next() {
+		var o models.PollOption
+		if err := rows.Scan(&o.ID, &o.AppID, &o.Name, &o.Votes); err != nil {
+			return fmt.Errorf("failed to scan poll option: %w", err)
+		}
+		poll.Options = append(poll.Options, o)
+	}
+
+	var optionID uint64
+	err = database.DB.QueryRowContext(ctx, `
+		SELECT option_id FROM poll_votes WHERE poll_id = ? AND user_id = ?`, poll.ID, userID).Scan(&optionID)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to get poll vote: %w", err)
+	}
+	if err == nil {
+		poll.MyOptionID = &optionID
+	}
+
+	poll.Evaluate(time.Now())
+	return nil
+}
+
+// CountOpenByUser returns the number of open polls created by a player
+func (r *PollRepository) CountOpenByUser(ctx context.Context, userID uint64) (int, error) {
+	var count int
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM polls
+		WHERE created_by = ? AND closed_at IS NULL AND (closes_at IS NULL OR closes_at > ?)`,
+		userID, time.Now().UTC(),
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count open polls: %w", err)
+	}
+	return count, nil
+}
+
+// Vote stores the vote of a player, an earlier vote in the same poll is replaced (with retry for SQLITE_BUSY)
+func (r *PollRepository) Vote(ctx context.Context, pollID, userID, optionID uint64) error {
+	return database.WithRetryContext(ctx, func() error {
+		now := time.Now().UTC()
+		var err error
+		if database.IsSQLite() {
+			_, err = database.DB.ExecContext(ctx, `
+				INSERT INTO poll_votes (poll_id, user_id, option_id, created_at)
+				VALUES (?, ?, ?, ?)
+				ON CONFLICT(poll_id, user_id) DO UPDATE SET
+					option_id = excluded.option_id,
+					created_at = excluded.created_at`,
+				pollID, userID, optionID, now,
+			)
+		} else {
+			_, err = database.DB.ExecContext(ctx, `
+				INSERT INTO poll_votes (poll_id, user_id, option_id, created_at)
+				VALUES (?, ?, ?, ?)
+				ON DUPLICATE KEY UPDATE
+					option_id = VALUES(option_id),
+					created_at = VALUES(created_at)`,
+				pollID, userID, optionID, now,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to vote in poll: %w", err)
+		}
+		return nil
+	})
+}
+
+// Close ends a poll manually (with retry for SQLITE_BUSY)
+func (r *PollRepository) Close(ctx context.Context, id uint64) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `
+			UPDATE polls SET closed_at = ? WHERE id = ? AND closed_at IS NULL`, time.Now().UTC(), id)
+		if err != nil {
+			return fmt.Errorf("failed to close poll: %w", err)
+		}
+		return nil
+	})
+}
+
+// Delete removes a poll with its options and votes (with retry for SQLITE_BUSY)
+func (r *PollRepository) Delete(ctx context.Context, id uint64) error {
+	return database.WithRetryContext(ctx, func() error {
+		if _, err := database.DB.ExecContext(ctx, `DELETE FROM polls WHERE id = ?`, id); err != nil {
+			return fmt.Errorf("failed to delete poll: %w", err)
+		}
+		return nil
+	})
+}
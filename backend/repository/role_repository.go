@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+)
+
+// RoleRepository handles role database operations
+type RoleRepository struct{}
+
+// NewRoleRepository creates a new role repository
+func NewRoleRepository() *RoleRepository {
+	return &RoleRepository{}
+}
+
+// GetSteamIDsByRole returns the Steam IDs holding a role
+func (r *RoleRepository) GetSteamIDsByRole(ctx context.Context, role string) ([]string, error) {
+	rows, err := database.DB.QueryContext(ctx, `SELECT steam_id FROM user_roles WHERE role = ? ORDER BY created_at`, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user roles: %w", err)
+	}
+	defer rows.Close()
+
+	steamIDs := []string{}
+	for rows.Next() {
+		var steamID string
+		if err := rows.Scan(&steamID); err != nil {
+			return nil, fmt.Errorf("failed to scan user role: %w", err)
+		}
+		steamIDs = append(steamIDs, steamID)
+	}
+	return steamIDs, rows.Err()
+}
+
+// Grant gives a role to a Steam account (with retry for SQLITE_BUSY)
+// Granting a role the account already holds is a no-op
+func (r *RoleRepository) Grant(ctx context.Context, steamID, role, grantedBy string) error {
+	return database.WithRetryContext(ctx, func() error {
+		query := `INSERT IGNORE INTO user_roles (steam_id, role, granted_by, created_at) VALUES (?, ?, ?, ?)`
+		if database.IsSQLite() {
+			query = `INSERT OR IGNORE INTO user_roles (steam_id, role, granted_by, created_at) VALUES (?, ?, ?, ?)`
+		}
+
+		if _, err := database.DB.ExecContext(ctx, query, steamID, role, grantedBy, time.Now().UTC()); err != nil {
+			return fmt.Errorf("failed to grant role: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// SettingsProfileRepository handles settings profile database operations
+type SettingsProfileRepository struct{}
+
+// NewSettingsProfileRepository creates a new settings profile repository
+func NewSettingsProfileRepository() *SettingsProfileRepository {
+	return &SettingsProfileRepository{}
+}
+
+// GetAll returns all settings profiles ordered by name
+func (r *SettingsProfileRepository) GetAll(ctx context.Context) ([]models.SettingsProfile, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT id, name, settings, created_at, updated_at
+		FROM settings_profiles
+		ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settings profiles: %w", err)
+	}
+	defer rows.Close()
+
+	var profiles []models.SettingsProfile
+	for rows.Next() {
+		var p models.SettingsProfile
+		var settings string
+		if err := rows.Scan(&p.ID, &p.Name, &settings, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan settings profile: %w", err)
+		}
+		p.Settings = []byte(settings)
+		profiles = append(profiles, p)
+	}
+
+	return profiles, nil
+}
+
+// GetByID finds a settings profile by ID
+func (r *SettingsProfileRepository) GetByID(ctx context.Context, id uint64) (*models.SettingsProfile, error) {
+	var p models.SettingsProfile
+	var settings string
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT id, name, settings, created_at, updated_at
+		FROM settings_profiles
+		WHERE id = ?`, id,
+	).Scan(&p.ID, &p.Name, &settings, &p.CreatedAt, &p.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settings profile: %w", err)
+	}
+	p.Settings = []byte(settings)
+	return &p, nil
+}
+
+// Save stores the settings under the given name, overwriting an existing profile with the same name
+func (r *SettingsProfileRepository) Save(ctx context.Context, name string, settings []byte) (*models.SettingsProfile, error) {
+	var profile *models.SettingsProfile
+	err := database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		now := time.Now().UTC()
+
+		var id uint64
+		err := tx.QueryRowContext(ctx, `SELECT id FROM settings_profiles WHERE name = ?`, name).Scan(&id)
+		switch {
+		case err == sql.ErrNoRows:
+			result, err := tx.ExecContext(ctx, `
+				INSERT INTO settings_profiles (name, settings, created_at, updated_at)
+				VALUES (?, ?, ?, ?)`, name, string(settings), now, now)
+			if err != nil {
+				return fmt.Errorf("failed to create settings profile: %w", err)
+			}
+			insertID, err := result.LastInsertId()
+			if err != nil {
+				return fmt.Errorf("failed to get last insert id: %w", err)
+			}
+			id = uint64(insertID)
+		case err != nil:
+			return fmt.Errorf("failed to get settings profile: %w", err)
+		default:
+			_, err = tx.ExecContext(ctx, `
+				UPDATE settings_profiles SET settings = ?, updated_at = ? WHERE id = ?`,
+				string(settings), now, id)
+			if err != nil {
+				return fmt.Errorf("failed to update settings profile: %w", err)
+			}
+		}
+
+		profile = &models.SettingsProfile{ID: id, Name: name, Settings: settings, UpdatedAt: now}
+		return tx.QueryRowContext(ctx, `SELECT created_at FROM settings_profiles WHERE id = ?`, id).Scan(&profile.CreatedAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// Delete removes a settings profile (with retry for SQLITE_BUSY)
+func (r *SettingsProfileRepository) Delete(ctx context.Context, id uint64) error {
+	return database.WithRetryContext(ctx, func() error {
+		if _, err := database.DB.ExecContext(ctx, `DELETE FROM settings_profiles WHERE id = ?`, id); err != nil {
+			return fmt.Errorf("failed to delete settings profile: %w", err)
+		}
+		return nil
+	})
+}
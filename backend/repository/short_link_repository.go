@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// ShortLinkRepository handles short link database operations
+type ShortLinkRepository struct{}
+
+// NewShortLinkRepository creates a new short link repository
+func NewShortLinkRepository() *ShortLinkRepository {
+	return &ShortLinkRepository{}
+}
+
+const shortLinkColumns = `slug, target_url, title, hits, created_at, updated_at`
+
+// scanShortLink scans a row selected with shortLinkColumns
+func scanShortLink(s rowScanner) (*models.ShortLink, error) {
+	var link models.ShortLink
+	if err := s.Scan(&link.Slug, &link.TargetURL, &link.Title, &link.Hits, &link.CreatedAt, &link.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// GetAll returns all short links ordered by slug
+func (r *ShortLinkRepository) GetAll(ctx context.Context) ([]models.ShortLink, error) {
+	rows, err := database.DB.QueryContext(ctx, `SELECT `+shortLinkColumns+` FROM short_links ORDER BY slug`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get short links: %w", err)
+	}
+	defer rows.Close()
+
+	links := []models.ShortLink{}
+	for rows.Next() {
+		link, err := scanShortLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan short link: %w", err)
+		}
+		links = append(links, *link)
+	}
+	return links, rows.Err()
+}
+
+// GetBySlug returns a short link by slug
+func (r *ShortLinkRepository) GetBySlug(ctx context.Context, slug string) (*models.ShortLink, error) {
+	link, err := scanShortLink(database.DB.QueryRowContext(ctx, `SELECT `+shortLinkColumns+` FROM short_links WHERE slug = ?`, slug))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get short link: %w", err)
+	}
+	return link, nil
+}
+
+// Create stores a new short link (with retry for SQLITE_BUSY)
+func (r *ShortLinkRepository) Create(ctx context.Context, link *models.ShortLink) error {
+	return database.WithRetryContext(ctx, func() error {
+		now := time.Now().UTC()
+		_, err := database.DB.ExecContext(ctx, `
+			INSERT INTO short_links (slug, target_url, title, hits, created_at, updated_at)
+			VALUES (?, ?, ?, 0, ?, ?)`,
+			link.Slug, link.TargetURL, link.Title, now, now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create short link: %w", err)
+		}
+		link.Hits = 0
+		link.CreatedAt = now
+		link.UpdatedAt = now
+		return nil
+	})
+}
+
+// Update changes the target and title of a short link (with retry for SQLITE_BUSY)
+func (r *ShortLinkRepository) Update(ctx context.Context, link *models.ShortLink) error {
+	return database.WithRetryContext(ctx, func() error {
+		now := time.Now().UTC()
+		_, err := database.DB.ExecContext(ctx, `
+			UPDATE short_links SET target_url = ?, title = ?, updated_at = ? WHERE slug = ?`,
+			link.TargetURL, link.Title, now, link.Slug,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update short link: %w", err)
+		}
+		link.UpdatedAt = now
+		return nil
+	})
+}
+
+// Delete removes a short link (with retry for SQLITE_BUSY)
+func (r *ShortLinkRepository) Delete(ctx context.Context, slug string) error {
+	return database.WithRetryContext(ctx, func() error {
+		if _, err := database.DB.ExecContext(ctx, `DELETE FROM short_links WHERE slug = ?`, slug); err != nil {
+			return fmt.Errorf("failed to delete short link: %w", err)
+		}
+		return nil
+	})
+}
+
+// IncrementHits counts a served redirect (with retry for SQLITE_BUSY)
+func (r *ShortLinkRepository) IncrementHits(ctx context.Context, slug string) error {
+	return database.WithRetryContext(ctx, func() error {
+		if _, err := database.DB.ExecContext(ctx, `UPDATE short_links SET hits = hits + 1 WHERE slug = ?`, slug); err != nil {
+			return fmt.Errorf("failed to count short link hit: %w", err)
+		}
+		return nil
+	})
+}
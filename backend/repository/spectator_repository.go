@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// SpectatorRepository handles the read-only spectator accounts
+type SpectatorRepository struct{}
+
+// NewSpectatorRepository creates a new spectator repository
+func NewSpectatorRepository() *SpectatorRepository {
+	return &SpectatorRepository{}
+}
+
+// GetAll returns all spectator accounts in alphabetical order
+func (r *SpectatorRepository) GetAll(ctx context.Context) ([]models.Spectator, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT id, name, created_by, last_login_at, created_at
+		FROM spectators
+		ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spectators: %w", err)
+	}
+	defer rows.Close()
+
+	spectators := []models.Spectator{}
+	for rows.Next() {
+		var s models.Spectator
+		if err := rows.Scan(&s.ID, &s.Name, &s.CreatedBy, &s.LastLoginAt, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan spectator: %w", err)
+		}
+		spectators = append(spectators, s)
+	}
+
+	return spectators, nil
+}
+
+// GetByName returns a spectator account and its passcode hash, nil if the name is unknown
+func (r *SpectatorRepository) GetByName(ctx context.Context, name string) (*models.Spectator, string, error) {
+	var s models.Spectator
+	var passcodeHash string
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT id, name, created_by, last_login_at, created_at, passcode_hash
+		FROM spectators
+		WHERE name = ?`, name,
+	).Scan(&s.ID, &s.Name, &s.CreatedBy, &s.LastLoginAt, &s.CreatedAt, &passcodeHash)
+	if err == sql.ErrNoRows {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get spectator: %w", err)
+	}
+	return &s, passcodeHash, nil
+}
+
+// Exists checks whether a spectator account with the given ID exists
+func (r *SpectatorRepository) Exists(ctx context.Context, id uint64) (bool, error) {
+	var count int
+	err := database.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM spectators WHERE id = ?`, id).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check spectator: %w", err)
+	}
+	return count > 0, nil
+}
+
+// Create stores a new spectator account (with retry for SQLITE_BUSY)
+// Returns false if the name is already taken
+func (r *SpectatorRepository) Create(ctx context.Context, spectator *models.Spectator, passcodeHash string) (bool, error) {
+	insert := `INSERT IGNORE INTO spectators (name, passcode_hash, created_by, created_at) VALUES (?, ?, ?, ?)`
+	if database.IsSQLite() {
+		insert = `INSERT OR IGNORE INTO spectators (name, passcode_hash, created_by, created_at) VALUES (?, ?, ?, ?)`
+	}
+
+	var created bool
+	err := database.WithRetryContext(ctx, func() error {
+		spectator.CreatedAt = time.Now().UTC()
+		result, err := database.DB.ExecContext(ctx, insert, spectator.Name, passcodeHash, spectator.CreatedBy, spectator.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create spectator: %w", err)
+		}
+		changed, _ := result.RowsAffected()
+		created = changed > 0
+		if !created {
+			return nil
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get spectator ID: %w", err)
+		}
+		spectator.ID = uint64(id)
+		return nil
+	})
+	return created, err
+}
+
+// UpdateLastLogin records a successful spectator login (with retry for SQLITE_BUSY)
+func (r *SpectatorRepository) UpdateLastLogin(ctx context.Context, id uint64) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `UPDATE spectators SET last_login_at = ? WHERE id = ?`, time.Now().UTC(), id)
+		if err != nil {
+			return fmt.Errorf("failed to update spectator login: %w", err)
+		}
+		return nil
+	})
+}
+
+// Delete removes a spectator account (with retry for SQLITE_BUSY)
+// Returns false if the account does not exist
+func (r *SpectatorRepository) Delete(ctx context.Context, id uint64) (bool, error) {
+	var deleted bool
+	err := database.WithRetryContext(ctx, func() error {
+		result, err := database.DB.ExecContext(ctx, `DELETE FROM spectators WHERE id = ?`, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete spectator: %w", err)
+		}
+		changed, _ := result.RowsAffected()
+		deleted = changed > 0
+		return nil
+	})
+	return deleted, err
+}
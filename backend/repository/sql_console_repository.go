@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+)
+
+// SQLConsoleResult contains the result of an ad-hoc read-only query
+type SQLConsoleResult struct {
+	Columns   []string        `json:"columns"`
+	Rows      [][]interface{} `json:"rows"`
+	RowCount  int             `json:"row_count"`
+	Truncated bool            `json:"truncated"` // True if more rows were available than maxRows
+	Duration  string          `json:"duration"`
+}
+
+// SQLConsoleRepository executes ad-hoc read-only queries for the admin SQL console
+type SQLConsoleRepository struct{}
+
+// NewSQLConsoleRepository creates a new SQL console repository
+func NewSQLConsoleRepository() *SQLConsoleRepository {
+	return &SQLConsoleRepository{}
+}
+
+// QueryReadOnly runs a query with the database in read-only mode and returns at most maxRows rows
+// The caller is responsible for validating that the query is a single SELECT statement
+func (r *SQLConsoleRepository) QueryReadOnly(ctx context.Context, query string, maxRows int) (*SQLConsoleResult, error) {
+	start := time.Now()
+
+	// Use a dedicated connection so the read-only mode doesn't leak into the pool
+	conn, err := database.DB.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if database.IsSQLite() {
+		// SQLite ignores read-only transactions, query_only rejects all writes on this connection
+		if _, err := conn.ExecContext(ctx, `PRAGMA query_only = ON`); err != nil {
+			return nil, fmt.Errorf("failed to enable read-only mode: %w", err)
+		}
+		defer conn.ExecContext(context.Background(), `PRAGMA query_only = OFF`)
+	}
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin read-only transaction: %w", err)
+	}
+	// Never commit - nothing should have been written anyway
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns: %w", err)
+	}
+
+	result := &SQLConsoleResult{
+		Columns: columns,
+		Rows:    [][]interface{}{},
+	}
+
+	for rows.Next() {
+		if len(result.Rows) >= maxRows {
+			result.Truncated = true
+			break
+		}
+
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		// Byte slices would be base64 encoded in JSON, return them as text instead
+		for i, v := range values {
+			if b, ok := v.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+
+		result.Rows = append(result.Rows, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	result.RowCount = len(result.Rows)
+	result.Duration = time.Since(start).String()
+	return result, nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// SyncCheckpointRepository persists the position of background syncs so they survive restarts
+type SyncCheckpointRepository struct{}
+
+// NewSyncCheckpointRepository creates a new sync checkpoint repository
+func NewSyncCheckpointRepository() *SyncCheckpointRepository {
+	return &SyncCheckpointRepository{}
+}
+
+// Get returns a checkpoint, nil if the sync is not running
+func (r *SyncCheckpointRepository) Get(ctx context.Context, name string) (*models.SyncCheckpoint, error) {
+	cp := &models.SyncCheckpoint{Name: name}
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT batch_id, last_app_id, processed, total FROM sync_checkpoints WHERE name = ?`, name,
+	).Scan(&cp.BatchID, &cp.LastAppID, &cp.Processed, &cp.Total)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync checkpoint %s: %w", name, err)
+	}
+	return cp, nil
+}
+
+// Save stores a checkpoint, an existing checkpoint with the same name is replaced
+func (r *SyncCheckpointRepository) Save(ctx context.Context, cp *models.SyncCheckpoint) error {
+	return database.WithRetryContext(ctx, func() error {
+		var err error
+		if database.IsSQLite() {
+			_, err = database.DB.ExecContext(ctx, `
+				INSERT INTO sync_checkpoints (name, batch_id, last_app_id, processed, total, updated_at)
+				VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+				ON CONFLICT(name) DO UPDATE SET
+					batch_id = excluded.batch_id,
+					last_app_id = excluded.last_app_id,
+					processed = excluded.processed,
+					total = excluded.total,
+					updated_at = CURRENT_TIMESTAMP`,
+				cp.Name, cp.BatchID, cp.LastAppID, cp.Processed, cp.Total,
+			)
+		} else {
+			_, err = database.DB.ExecContext(ctx, `
+				INSERT INTO sync_checkpoints (name, batch_id, last_app_id, processed, total)
+				VALUES (?, ?, ?, ?, ?)
+				ON DUPLICATE KEY UPDATE
+					batch_id = VALUES(batch_id),
+					last_app_id = VALUES(last_app_id),
+					processed = VALUES(processed),
+					total = VALUES(total)`,
+				cp.Name, cp.BatchID, cp.LastAppID, cp.Processed, cp.Total,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to save sync checkpoint %s: %w", cp.Name, err)
+		}
+		return nil
+	})
+}
+
+// Clear removes a checkpoint once the sync has finished
+func (r *SyncCheckpointRepository) Clear(ctx context.Context, name string) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `DELETE FROM sync_checkpoints WHERE name = ?`, name)
+		if err != nil {
+			return fmt.Errorf("failed to clear sync checkpoint %s: %w", name, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+)
+
+// initTestDB opens a migrated SQLite database in a temporary directory, closed when the test ends
+func initTestDB(tb testing.TB) {
+	tb.Helper()
+	if err := database.InitSQLite(filepath.Join(tb.TempDir(), "test.db")); err != nil {
+		tb.Fatalf("failed to init database: %v", err)
+	}
+	tb.Cleanup(func() { database.Close() })
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+)
+
+// TimerRepository persists scheduled timers so they survive restarts
+type TimerRepository struct{}
+
+// NewTimerRepository creates a new timer repository
+func NewTimerRepository() *TimerRepository {
+	return &TimerRepository{}
+}
+
+// Get returns the fire time of a timer, zero if it is not set
+func (r *TimerRepository) Get(ctx context.Context, name string) (time.Time, error) {
+	var fireAt time.Time
+	err := database.DB.QueryRowContext(ctx, `SELECT fire_at FROM timers WHERE name = ?`, name).Scan(&fireAt)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get timer %s: %w", name, err)
+	}
+	return fireAt, nil
+}
+
+// Set schedules a timer, an existing timer with the same name is replaced
+func (r *TimerRepository) Set(ctx context.Context, name string, fireAt time.Time) error {
+	return database.WithRetryContext(ctx, func() error {
+		var err error
+		if database.IsSQLite() {
+			_, err = database.DB.ExecContext(ctx, `
+				INSERT INTO timers (name, fire_at, updated_at)
+				VALUES (?, ?, CURRENT_TIMESTAMP)
+				ON CONFLICT(name) DO UPDATE SET
+					fire_at = excluded.fire_at,
+					updated_at = CURRENT_TIMESTAMP`,
+				name, fireAt.UTC(),
+			)
+		} else {
+			_, err = database.DB.ExecContext(ctx, `
+				INSERT INTO timers (name, fire_at)
+				VALUES (?, ?)
+				ON DUPLICATE KEY UPDATE
+					fire_at = VALUES(fire_at)`,
+				name, fireAt.UTC(),
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to set timer %s: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// Clear removes a timer, clearing a timer that is not set is not an error
+func (r *TimerRepository) Clear(ctx context.Context, name string) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `DELETE FROM timers WHERE name = ?`, name)
+		if err != nil {
+			return fmt.Errorf("failed to clear timer %s: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// Save sets the timer to fireAt or clears it if fireAt is zero
+func (r *TimerRepository) Save(ctx context.Context, name string, fireAt time.Time) error {
+	if fireAt.IsZero() {
+		return r.Clear(ctx, name)
+	}
+	return r.Set(ctx, name, fireAt)
+}
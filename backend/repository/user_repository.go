@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,6 +11,9 @@ import (
 	"github.com/guided-traffic/rate-your-mate/backend/models"
 )
 
+// ErrInsufficientCredits is returned when a user has fewer credits than needed
+var ErrInsufficientCredits = errors.New("insufficient credits")
+
 // UserRepository handles user database operations
 type UserRepository struct{}
 
@@ -18,12 +23,12 @@ func NewUserRepository() *UserRepository {
 }
 
 // Create creates a new user in the database (with retry for SQLITE_BUSY)
-func (r *UserRepository) Create(user *models.User) error {
-	return database.WithRetry(func() error {
-		result, err := database.DB.Exec(`
-			INSERT INTO users (steam_id, username, avatar_url, avatar_small, profile_url, credits, last_credit_at)
-			VALUES (?, ?, ?, ?, ?, ?, ?)`,
-			user.SteamID, user.Username, user.AvatarURL, user.AvatarSmall, user.ProfileURL, user.Credits, user.LastCreditAt,
+func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
+	return database.WithRetryContext(ctx, func() error {
+		result, err := database.DB.ExecContext(ctx, `
+			INSERT INTO users (steam_id, username, avatar_url, avatar_small, profile_url, country_code, timezone, credits, last_credit_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			user.SteamID, user.Username, user.AvatarURL, user.AvatarSmall, user.ProfileURL, user.CountryCode, user.Timezone, user.Credits, user.LastCreditAt,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to create user: %w", err)
@@ -40,13 +45,13 @@ func (r *UserRepository) Create(user *models.User) error {
 }
 
 // GetByID finds a user by ID
-func (r *UserRepository) GetByID(id uint64) (*models.User, error) {
+func (r *UserRepository) GetByID(ctx context.Context, id uint64) (*models.User, error) {
 	user := &models.User{}
-	err := database.DB.QueryRow(`
-		SELECT id, steam_id, username, avatar_url, avatar_small, profile_url, credits, last_credit_at, last_games_refresh_at, created_at, updated_at
-		FROM users WHERE id = ?`, id,
-	).Scan(&user.ID, &user.SteamID, &user.Username, &user.AvatarURL, &user.AvatarSmall, &user.ProfileURL,
-		&user.Credits, &user.LastCreditAt, &user.LastGamesRefreshAt, &user.CreatedAt, &user.UpdatedAt)
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT id, steam_id, username, avatar_url, avatar_small, profile_url, country_code, timezone, language, credits, last_credit_at, last_games_refresh_at, hide_from_ranking, reduced_motion, pending_review, created_at, updated_at
+		FROM users WHERE id = ? AND deleted_at IS NULL`, id,
+	).Scan(&user.ID, &user.SteamID, &user.Username, &user.AvatarURL, &user.AvatarSmall, &user.ProfileURL, &user.CountryCode, &user.Timezone, &user.Language,
+		&user.Credits, &user.LastCreditAt, &user.LastGamesRefreshAt, &user.HideFromRanking, &user.ReducedMotion, &user.PendingReview, &user.CreatedAt, &user.UpdatedAt)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -59,13 +64,13 @@ func (r *UserRepository) GetByID(id uint64) (*models.User, error) {
 }
 
 // GetBySteamID finds a user by Steam ID
-func (r *UserRepository) GetBySteamID(steamID string) (*models.User, error) {
+func (r *UserRepository) GetBySteamID(ctx context.Context, steamID string) (*models.User, error) {
 	user := &models.User{}
-	err := database.DB.QueryRow(`
-		SELECT id, steam_id, username, avatar_url, avatar_small, profile_url, credits, last_credit_at, last_games_refresh_at, created_at, updated_at
-		FROM users WHERE steam_id = ?`, steamID,
-	).Scan(&user.ID, &user.SteamID, &user.Username, &user.AvatarURL, &user.AvatarSmall, &user.ProfileURL,
-		&user.Credits, &user.LastCreditAt, &user.LastGamesRefreshAt, &user.CreatedAt, &user.UpdatedAt)
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT id, steam_id, username, avatar_url, avatar_small, profile_url, country_code, timezone, language, credits, last_credit_at, last_games_refresh_at, hide_from_ranking, reduced_motion, pending_review, created_at, updated_at
+		FROM users WHERE steam_id = ? AND deleted_at IS NULL`, steamID,
+	).Scan(&user.ID, &user.SteamID, &user.Username, &user.AvatarURL, &user.AvatarSmall, &user.ProfileURL, &user.CountryCode, &user.Timezone, &user.Language,
+		&user.Credits, &user.LastCreditAt, &user.LastGamesRefreshAt, &user.HideFromRanking, &user.ReducedMotion, &user.PendingReview, &user.CreatedAt, &user.UpdatedAt)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -77,11 +82,11 @@ func (r *UserRepository) GetBySteamID(steamID string) (*models.User, error) {
 	return user, nil
 }
 
-// GetAll returns all users
-func (r *UserRepository) GetAll() ([]models.User, error) {
-	rows, err := database.DB.Query(`
-		SELECT id, steam_id, username, avatar_url, avatar_small, profile_url, credits, last_credit_at, last_games_refresh_at, created_at, updated_at
-		FROM users ORDER BY username`)
+// GetAll returns all users, except accounts waiting for an admin review
+func (r *UserRepository) GetAll(ctx context.Context) ([]models.User, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT id, steam_id, username, avatar_url, avatar_small, profile_url, country_code, timezone, language, credits, last_credit_at, last_games_refresh_at, hide_from_ranking, reduced_motion, created_at, updated_at
+		FROM users WHERE deleted_at IS NULL AND pending_review = 0 ORDER BY username`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all users: %w", err)
 	}
@@ -90,8 +95,32 @@ func (r *UserRepository) GetAll() ([]models.User, error) {
 	var users []models.User
 	for rows.Next() {
 		var user models.User
-		err := rows.Scan(&user.ID, &user.SteamID, &user.Username, &user.AvatarURL, &user.AvatarSmall, &user.ProfileURL,
-			&user.Credits, &user.LastCreditAt, &user.LastGamesRefreshAt, &user.CreatedAt, &user.UpdatedAt)
+		err := rows.Scan(&user.ID, &user.SteamID, &user.Username, &user.AvatarURL, &user.AvatarSmall, &user.ProfileURL, &user.CountryCode, &user.Timezone, &user.Language,
+			&user.Credits, &user.LastCreditAt, &user.LastGamesRefreshAt, &user.HideFromRanking, &user.ReducedMotion, &user.CreatedAt, &user.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user row: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// GetCreatedSince returns the users registered since the given time, oldest first
+func (r *UserRepository) GetCreatedSince(ctx context.Context, since time.Time) ([]models.User, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT id, steam_id, username, avatar_url, avatar_small, profile_url, country_code, timezone, language, credits, last_credit_at, last_games_refresh_at, hide_from_ranking, reduced_motion, created_at, updated_at
+		FROM users WHERE created_at >= ? AND deleted_at IS NULL ORDER BY created_at, id`, since.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get new users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		var user models.User
+		err := rows.Scan(&user.ID, &user.SteamID, &user.Username, &user.AvatarURL, &user.AvatarSmall, &user.ProfileURL, &user.CountryCode, &user.Timezone, &user.Language,
+			&user.Credits, &user.LastCreditAt, &user.LastGamesRefreshAt, &user.HideFromRanking, &user.ReducedMotion, &user.CreatedAt, &user.UpdatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user row: %w", err)
 		}
@@ -102,13 +131,13 @@ func (r *UserRepository) GetAll() ([]models.User, error) {
 }
 
 // Update updates a user's profile information (with retry for SQLITE_BUSY)
-func (r *UserRepository) Update(user *models.User) error {
-	return database.WithRetry(func() error {
-		_, err := database.DB.Exec(`
+func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `
 			UPDATE users
-			SET username = ?, avatar_url = ?, avatar_small = ?, profile_url = ?, updated_at = CURRENT_TIMESTAMP
+			SET username = ?, avatar_url = ?, avatar_small = ?, profile_url = ?, country_code = ?, updated_at = CURRENT_TIMESTAMP
 			WHERE id = ?`,
-			user.Username, user.AvatarURL, user.AvatarSmall, user.ProfileURL, user.ID,
+			user.Username, user.AvatarURL, user.AvatarSmall, user.ProfileURL, user.CountryCode, user.ID,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to update user: %w", err)
@@ -118,9 +147,9 @@ func (r *UserRepository) Update(user *models.User) error {
 }
 
 // UpdateCredits updates a user's credits (with retry for SQLITE_BUSY)
-func (r *UserRepository) UpdateCredits(userID uint64, credits int, lastCreditAt time.Time) error {
-	return database.WithRetry(func() error {
-		_, err := database.DB.Exec(`
+func (r *UserRepository) UpdateCredits(ctx context.Context, userID uint64, credits int, lastCreditAt time.Time) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `
 			UPDATE users
 			SET credits = ?, last_credit_at = ?, updated_at = CURRENT_TIMESTAMP
 			WHERE id = ?`,
@@ -134,9 +163,9 @@ func (r *UserRepository) UpdateCredits(userID uint64, credits int, lastCreditAt
 }
 
 // UpdateLastGamesRefresh updates the last games refresh timestamp for a user
-func (r *UserRepository) UpdateLastGamesRefresh(userID uint64) error {
-	return database.WithRetry(func() error {
-		_, err := database.DB.Exec(`
+func (r *UserRepository) UpdateLastGamesRefresh(ctx context.Context, userID uint64) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `
 			UPDATE users
 			SET last_games_refresh_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
 			WHERE id = ?`,
@@ -149,17 +178,141 @@ func (r *UserRepository) UpdateLastGamesRefresh(userID uint64) error {
 	})
 }
 
+// UpdateHideFromRanking sets whether a user is hidden from the public ranking
+func (r *UserRepository) UpdateHideFromRanking(ctx context.Context, userID uint64, hide bool) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `
+			UPDATE users
+			SET hide_from_ranking = ?, updated_at = CURRENT_TIMESTAMP
+			WHERE id = ?`,
+			hide, userID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update ranking visibility: %w", err)
+		}
+		return nil
+	})
+}
+
+// UpdateTimezone sets a user's preferred timezone (IANA name, empty string = event timezone)
+func (r *UserRepository) UpdateTimezone(ctx context.Context, userID uint64, timezone string) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `
+			UPDATE users
+			SET timezone = ?, updated_at = CURRENT_TIMESTAMP
+			WHERE id = ?`,
+			timezone, userID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update timezone: %w", err)
+		}
+		return nil
+	})
+}
+
+// UpdateLanguage sets a user's preferred language (empty string = Accept-Language header)
+func (r *UserRepository) UpdateLanguage(ctx context.Context, userID uint64, language string) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `
+			UPDATE users
+			SET language = ?, updated_at = CURRENT_TIMESTAMP
+			WHERE id = ?`,
+			language, userID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update language: %w", err)
+		}
+		return nil
+	})
+}
+
+// UpdateReducedMotion sets a user's reduced motion accessibility preference
+func (r *UserRepository) UpdateReducedMotion(ctx context.Context, userID uint64, reducedMotion bool) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `
+			UPDATE users
+			SET reduced_motion = ?, updated_at = CURRENT_TIMESTAMP
+			WHERE id = ?`,
+			reducedMotion, userID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update reduced motion: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetReducedMotionUserIDs returns the IDs of all users that prefer reduced motion
+func (r *UserRepository) GetReducedMotionUserIDs(ctx context.Context) ([]uint64, error) {
+	rows, err := database.DB.QueryContext(ctx, `SELECT id FROM users WHERE reduced_motion = 1 AND deleted_at IS NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reduced motion users: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uint64
+	for rows.Next() {
+		var id uint64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan user id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// SetQuickVoteTokenHash stores the hash of a user's personal quick-vote token (empty string revokes it)
+func (r *UserRepository) SetQuickVoteTokenHash(ctx context.Context, userID uint64, tokenHash string) error {
+	var value interface{}
+	if tokenHash != "" {
+		value = tokenHash
+	}
+
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `
+			UPDATE users
+			SET quickvote_token_hash = ?, updated_at = CURRENT_TIMESTAMP
+			WHERE id = ?`,
+			value, userID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update quick-vote token: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetByQuickVoteTokenHash finds a user by the hash of their personal quick-vote token
+func (r *UserRepository) GetByQuickVoteTokenHash(ctx context.Context, tokenHash string) (*models.User, error) {
+	user := &models.User{}
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT id, steam_id, username, avatar_url, avatar_small, profile_url, country_code, timezone, language, credits, last_credit_at, last_games_refresh_at, hide_from_ranking, reduced_motion, created_at, updated_at
+		FROM users WHERE quickvote_token_hash = ?`, tokenHash,
+	).Scan(&user.ID, &user.SteamID, &user.Username, &user.AvatarURL, &user.AvatarSmall, &user.ProfileURL, &user.CountryCode, &user.Timezone, &user.Language,
+		&user.Credits, &user.LastCreditAt, &user.LastGamesRefreshAt, &user.HideFromRanking, &user.ReducedMotion, &user.CreatedAt, &user.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by quick-vote token: %w", err)
+	}
+
+	return user, nil
+}
+
 // DeductCredit deducts one credit from a user (atomic operation)
-func (r *UserRepository) DeductCredit(userID uint64) error {
-	return r.DeductCredits(userID, 1)
+func (r *UserRepository) DeductCredit(ctx context.Context, userID uint64) error {
+	return r.DeductCredits(ctx, userID, 1)
 }
 
 // DeductCredits deducts a specified amount of credits from a user (atomic operation with retry)
-func (r *UserRepository) DeductCredits(userID uint64, amount int) error {
+func (r *UserRepository) DeductCredits(ctx context.Context, userID uint64, amount int) error {
 	var rowsAffected int64
 
-	err := database.WithRetry(func() error {
-		result, err := database.DB.Exec(`
+	err := database.WithRetryContext(ctx, func() error {
+		result, err := database.DB.ExecContext(ctx, `
 			UPDATE users
 			SET credits = credits - ?, updated_at = CURRENT_TIMESTAMP
 			WHERE id = ? AND credits >= ?`,
@@ -181,18 +334,18 @@ func (r *UserRepository) DeductCredits(userID uint64, amount int) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("insufficient credits")
+		return ErrInsufficientCredits
 	}
 
 	return nil
 }
 
 // ResetAllCredits sets all users' credits to 0 and resets the time until next credit (with retry for SQLITE_BUSY)
-func (r *UserRepository) ResetAllCredits() (int64, error) {
+func (r *UserRepository) ResetAllCredits(ctx context.Context) (int64, error) {
 	var rowsAffected int64
 
-	err := database.WithRetry(func() error {
-		result, err := database.DB.Exec(`
+	err := database.WithRetryContext(ctx, func() error {
+		result, err := database.DB.ExecContext(ctx, `
 			UPDATE users
 			SET credits = 0, last_credit_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP`)
 		if err != nil {
@@ -210,11 +363,11 @@ func (r *UserRepository) ResetAllCredits() (int64, error) {
 }
 
 // GiveEveryoneCredit gives each user 1 credit (respecting max credits, with retry for SQLITE_BUSY)
-func (r *UserRepository) GiveEveryoneCredit(maxCredits int) (int64, error) {
+func (r *UserRepository) GiveEveryoneCredit(ctx context.Context, maxCredits int) (int64, error) {
 	var rowsAffected int64
 
-	err := database.WithRetry(func() error {
-		result, err := database.DB.Exec(`
+	err := database.WithRetryContext(ctx, func() error {
+		result, err := database.DB.ExecContext(ctx, `
 			UPDATE users
 			SET credits = MIN(credits + 1, ?), updated_at = CURRENT_TIMESTAMP
 			WHERE credits < ?`,
@@ -233,16 +386,49 @@ func (r *UserRepository) GiveEveryoneCredit(maxCredits int) (int64, error) {
 	return rowsAffected, err
 }
 
+// AddCredits gives a user additional credits, capped at maxCredits (with retry for SQLITE_BUSY)
+func (r *UserRepository) AddCredits(ctx context.Context, userID uint64, amount int, maxCredits int) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `
+			UPDATE users
+			SET credits = CASE WHEN credits + ? > ? THEN ? ELSE credits + ? END, updated_at = CURRENT_TIMESTAMP
+			WHERE id = ?`,
+			amount, maxCredits, maxCredits, amount, userID)
+		if err != nil {
+			return fmt.Errorf("failed to add credits: %w", err)
+		}
+		return nil
+	})
+}
+
+// AddCreditsMany adds credits to several users in one transaction, capped at maxCredits
+// Either all users get the credits or none
+func (r *UserRepository) AddCreditsMany(ctx context.Context, userIDs []uint64, amount int, maxCredits int) error {
+	return database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		for _, userID := range userIDs {
+			_, err := tx.ExecContext(ctx, `
+				UPDATE users
+				SET credits = CASE WHEN credits + ? > ? THEN ? ELSE credits + ? END, updated_at = CURRENT_TIMESTAMP
+				WHERE id = ?`,
+				amount, maxCredits, maxCredits, amount, userID)
+			if err != nil {
+				return fmt.Errorf("failed to add credits: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
 // ShiftAllLastCreditAt shifts all users' last_credit_at forward by the given duration
 // This is used when voting is resumed after a pause to prevent users from accumulating
 // credit time during the pause
-func (r *UserRepository) ShiftAllLastCreditAt(duration time.Duration) error {
+func (r *UserRepository) ShiftAllLastCreditAt(ctx context.Context, duration time.Duration) error {
 	// Add the duration (in seconds) to all last_credit_at timestamps
 	// We calculate the new timestamp in Go and update directly
 	newTime := time.Now()
 
 	// Get all users and update their last_credit_at by adding the pause duration
-	rows, err := database.DB.Query(`SELECT id, last_credit_at FROM users`)
+	rows, err := database.DB.QueryContext(ctx, `SELECT id, last_credit_at FROM users`)
 	if err != nil {
 		return fmt.Errorf("failed to query users: %w", err)
 	}
@@ -265,7 +451,7 @@ func (r *UserRepository) ShiftAllLastCreditAt(duration time.Duration) error {
 		}
 
 		// Update this user
-		_, err := database.DB.Exec(`
+		_, err := database.DB.ExecContext(ctx, `
 			UPDATE users
 			SET last_credit_at = ?, updated_at = CURRENT_TIMESTAMP
 			WHERE id = ?`,
@@ -280,9 +466,9 @@ func (r *UserRepository) ShiftAllLastCreditAt(duration time.Duration) error {
 
 // FindOrCreate finds a user by Steam ID or creates a new one
 // Always updates profile data (username, avatar) on each login to reflect Steam profile changes
-func (r *UserRepository) FindOrCreate(steamID, username, avatarURL, avatarSmall, profileURL string) (*models.User, bool, error) {
+func (r *UserRepository) FindOrCreate(ctx context.Context, steamID, username, avatarURL, avatarSmall, profileURL, countryCode string) (*models.User, bool, error) {
 	// Try to find existing user
-	user, err := r.GetBySteamID(steamID)
+	user, err := r.GetBySteamID(ctx, steamID)
 	if err != nil {
 		return nil, false, err
 	}
@@ -290,12 +476,13 @@ func (r *UserRepository) FindOrCreate(steamID, username, avatarURL, avatarSmall,
 	if user != nil {
 		// Always update profile data on login to catch Steam profile changes
 		// This ensures users who set a custom avatar after using default get their new avatar
-		if user.Username != username || user.AvatarURL != avatarURL || user.AvatarSmall != avatarSmall || user.ProfileURL != profileURL {
+		if user.Username != username || user.AvatarURL != avatarURL || user.AvatarSmall != avatarSmall || user.ProfileURL != profileURL || user.CountryCode != countryCode {
 			user.Username = username
 			user.AvatarURL = avatarURL
 			user.AvatarSmall = avatarSmall
 			user.ProfileURL = profileURL
-			if err := r.Update(user); err != nil {
+			user.CountryCode = countryCode
+			if err := r.Update(ctx, user); err != nil {
 				return nil, false, err
 			}
 		}
@@ -309,32 +496,116 @@ func (r *UserRepository) FindOrCreate(steamID, username, avatarURL, avatarSmall,
 		AvatarURL:    avatarURL,
 		AvatarSmall:  avatarSmall,
 		ProfileURL:   profileURL,
+		CountryCode:  countryCode,
 		Credits:      0,
 		LastCreditAt: time.Now(),
 	}
 
-	if err := r.Create(user); err != nil {
+	if err := r.Create(ctx, user); err != nil {
 		return nil, false, err
 	}
 
 	return user, true, nil // true = new user created
 }
 
-// DeleteByID deletes a user by ID and returns the number of rows affected
-func (r *UserRepository) DeleteByID(id uint64) error {
-	return database.WithRetry(func() error {
-		_, err := database.DB.Exec(`DELETE FROM users WHERE id = ?`, id)
+// SoftDeleteByID removes a kicked or banned user while keeping their history intact
+// The user row stays with a placeholder name so votes and chat messages keep pointing to a former player.
+// The Steam ID is released so a kicked player can log in again with a fresh account.
+func (r *UserRepository) SoftDeleteByID(ctx context.Context, id uint64) error {
+	return database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		var steamID string
+		err := tx.QueryRowContext(ctx, `SELECT steam_id FROM users WHERE id = ? AND deleted_at IS NULL`, id).Scan(&steamID)
+		if err == sql.ErrNoRows {
+			return nil
+		}
 		if err != nil {
-			return fmt.Errorf("failed to delete user: %w", err)
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		return softDeleteUser(ctx, tx, id, steamID)
+	})
+}
+
+// BanMany bans several users and removes them like SoftDeleteByID in one transaction
+// Either all users are banned or none, users that are already deleted are skipped
+func (r *UserRepository) BanMany(ctx context.Context, userIDs []uint64, reason, bannedBy string) error {
+	return database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		for _, id := range userIDs {
+			var steamID, username string
+			err := tx.QueryRowContext(ctx, `SELECT steam_id, username FROM users WHERE id = ? AND deleted_at IS NULL`, id).Scan(&steamID, &username)
+			if err == sql.ErrNoRows {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get user: %w", err)
+			}
+
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO banned_users (steam_id, username, reason, banned_by)
+				VALUES (?, ?, ?, ?)`,
+				steamID, username, reason, bannedBy,
+			); err != nil {
+				return fmt.Errorf("failed to ban user: %w", err)
+			}
+
+			if err := softDeleteUser(ctx, tx, id, steamID); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
 }
 
+// softDeleteUser replaces the user with a former player and drops their active state (caller runs the transaction)
+// The Steam ID is kept in deleted_steam_id for the ban evasion check and the later anonymization
+func softDeleteUser(ctx context.Context, tx *sql.Tx, id uint64, steamID string) error {
+	now := time.Now().UTC()
+	_, err := tx.ExecContext(ctx, `
+		UPDATE users
+		SET steam_id = ?, deleted_steam_id = ?, username = ?, avatar_url = '', avatar_small = '', profile_url = '', country_code = '',
+			credits = 0, reduced_motion = 0, quickvote_token_hash = NULL, deleted_at = ?, updated_at = ?
+		WHERE id = ?`,
+		fmt.Sprintf("deleted_%d", id), steamID, models.FormerPlayerName, now, now, id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	// The state of active players is dropped, the history is kept
+	if _, err := tx.ExecContext(ctx, `DELETE FROM game_owners WHERE steam_id = ?`, steamID); err != nil {
+		return fmt.Errorf("failed to delete game ownership: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM game_sessions WHERE user_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete game sessions: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM wishlist_items WHERE user_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete wishlist: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM download_confirmations WHERE user_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete download confirmations: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM chat_reads WHERE user_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete chat read state: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_mutes WHERE user_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete mute: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM notifications WHERE user_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete notifications: %w", err)
+	}
+
+	return nil
+}
+
 // DeleteBySteamID deletes a user by Steam ID
-func (r *UserRepository) DeleteBySteamID(steamID string) error {
-	return database.WithRetry(func() error {
-		_, err := database.DB.Exec(`DELETE FROM users WHERE steam_id = ?`, steamID)
+func (r *UserRepository) DeleteBySteamID(ctx context.Context, steamID string) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `DELETE FROM users WHERE steam_id = ?`, steamID)
 		if err != nil {
 			return fmt.Errorf("failed to delete user: %w", err)
 		}
@@ -342,11 +613,13 @@ func (r *UserRepository) DeleteBySteamID(steamID string) error {
 	})
 }
 
-// GetAllForAdmin returns all users with admin-relevant info
-func (r *UserRepository) GetAllForAdmin() ([]models.AdminUserInfo, error) {
-	rows, err := database.DB.Query(`
+// GetAllForAdmin returns a page of users with admin-relevant info, in the order they registered
+func (r *UserRepository) GetAllForAdmin(ctx context.Context, page Page) ([]models.AdminUserInfo, error) {
+	rows, err := database.DB.QueryContext(ctx, `
 		SELECT id, steam_id, username, avatar_small, created_at
-		FROM users ORDER BY username`)
+		FROM users WHERE deleted_at IS NULL AND id > ?
+		ORDER BY id
+		LIMIT ?`, page.Cursor, page.Limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all users: %w", err)
 	}
@@ -366,9 +639,9 @@ func (r *UserRepository) GetAllForAdmin() ([]models.AdminUserInfo, error) {
 }
 
 // IsBanned checks if a Steam ID is banned
-func (r *UserRepository) IsBanned(steamID string) (bool, error) {
+func (r *UserRepository) IsBanned(ctx context.Context, steamID string) (bool, error) {
 	var count int
-	err := database.DB.QueryRow(`SELECT COUNT(*) FROM banned_users WHERE steam_id = ?`, steamID).Scan(&count)
+	err := database.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM banned_users WHERE steam_id = ?`, steamID).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("failed to check ban status: %w", err)
 	}
@@ -376,9 +649,9 @@ func (r *UserRepository) IsBanned(steamID string) (bool, error) {
 }
 
 // GetBannedUser returns the ban info for a Steam ID
-func (r *UserRepository) GetBannedUser(steamID string) (*models.BannedUser, error) {
+func (r *UserRepository) GetBannedUser(ctx context.Context, steamID string) (*models.BannedUser, error) {
 	var ban models.BannedUser
-	err := database.DB.QueryRow(`
+	err := database.DB.QueryRowContext(ctx, `
 		SELECT id, steam_id, username, reason, banned_by, banned_at
 		FROM banned_users WHERE steam_id = ?`, steamID,
 	).Scan(&ban.ID, &ban.SteamID, &ban.Username, &ban.Reason, &ban.BannedBy, &ban.BannedAt)
@@ -393,9 +666,9 @@ func (r *UserRepository) GetBannedUser(steamID string) (*models.BannedUser, erro
 }
 
 // BanUser adds a user to the ban list
-func (r *UserRepository) BanUser(steamID, username, reason, bannedBy string) error {
-	return database.WithRetry(func() error {
-		_, err := database.DB.Exec(`
+func (r *UserRepository) BanUser(ctx context.Context, steamID, username, reason, bannedBy string) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `
 			INSERT INTO banned_users (steam_id, username, reason, banned_by)
 			VALUES (?, ?, ?, ?)`,
 			steamID, username, reason, bannedBy,
@@ -408,9 +681,9 @@ func (r *UserRepository) BanUser(steamID, username, reason, bannedBy string) err
 }
 
 // UnbanUser removes a user from the ban list
-func (r *UserRepository) UnbanUser(steamID string) error {
-	return database.WithRetry(func() error {
-		_, err := database.DB.Exec(`DELETE FROM banned_users WHERE steam_id = ?`, steamID)
+func (r *UserRepository) UnbanUser(ctx context.Context, steamID string) error {
+	return database.WithRetryContext(ctx, func() error {
+		_, err := database.DB.ExecContext(ctx, `DELETE FROM banned_users WHERE steam_id = ?`, steamID)
 		if err != nil {
 			return fmt.Errorf("failed to unban user: %w", err)
 		}
@@ -419,8 +692,8 @@ func (r *UserRepository) UnbanUser(steamID string) error {
 }
 
 // GetAllBannedUsers returns all banned users
-func (r *UserRepository) GetAllBannedUsers() ([]models.BannedUser, error) {
-	rows, err := database.DB.Query(`
+func (r *UserRepository) GetAllBannedUsers(ctx context.Context) ([]models.BannedUser, error) {
+	rows, err := database.DB.QueryContext(ctx, `
 		SELECT id, steam_id, username, reason, banned_by, banned_at
 		FROM banned_users ORDER BY banned_at DESC`)
 	if err != nil {
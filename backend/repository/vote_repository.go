@@ -1,10 +1,15 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/metrics"
 	"github.com/guided-traffic/rate-your-mate/backend/models"
 )
 
@@ -17,12 +22,12 @@ func NewVoteRepository() *VoteRepository {
 }
 
 // Create creates a new vote (with retry for SQLITE_BUSY)
-func (r *VoteRepository) Create(vote *models.Vote) error {
-	return database.WithRetry(func() error {
-		result, err := database.DB.Exec(`
-			INSERT INTO votes (from_user_id, to_user_id, achievement_id, points, is_secret, comment)
-			VALUES (?, ?, ?, ?, ?, ?)`,
-			vote.FromUserID, vote.ToUserID, vote.AchievementID, vote.Points, vote.IsSecret, vote.Comment,
+func (r *VoteRepository) Create(ctx context.Context, vote *models.Vote) error {
+	return database.WithRetryContext(ctx, func() error {
+		result, err := database.DB.ExecContext(ctx, `
+			INSERT INTO votes (from_user_id, to_user_id, achievement_id, points, is_secret, comment, app_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			vote.FromUserID, vote.ToUserID, vote.AchievementID, vote.Points, vote.IsSecret, vote.Comment, vote.AppID,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to create vote: %w", err)
@@ -38,18 +43,63 @@ func (r *VoteRepository) Create(vote *models.Vote) error {
 	})
 }
 
-// GetRecent returns the most recent votes for the timeline
-func (r *VoteRepository) GetRecent(limit int) ([]models.VoteWithDetails, error) {
-	rows, err := database.DB.Query(`
+// CreateWithCost deducts the cost from the sender's credits and creates the vote in one transaction
+// Returns the sender's credits as seen by the transaction: the remaining credits, or the current
+// credits together with ErrInsufficientCredits (without any change) if the sender can't pay for the vote
+func (r *VoteRepository) CreateWithCost(ctx context.Context, vote *models.Vote, cost int) (int, error) {
+	var credits int
+	err := database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE users
+			SET credits = credits - ?, updated_at = CURRENT_TIMESTAMP
+			WHERE id = ? AND credits >= ? AND deleted_at IS NULL`,
+			cost, vote.FromUserID, cost,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to deduct credits: %w", err)
+		}
+		changed, _ := result.RowsAffected()
+
+		if err := tx.QueryRowContext(ctx, `SELECT credits FROM users WHERE id = ?`, vote.FromUserID).Scan(&credits); err != nil {
+			return fmt.Errorf("failed to get credits: %w", err)
+		}
+		if changed == 0 {
+			return ErrInsufficientCredits
+		}
+
+		result, err = tx.ExecContext(ctx, `
+			INSERT INTO votes (from_user_id, to_user_id, achievement_id, points, is_secret, comment, app_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			vote.FromUserID, vote.ToUserID, vote.AchievementID, vote.Points, vote.IsSecret, vote.Comment, vote.AppID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create vote: %w", err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get last insert id: %w", err)
+		}
+
+		vote.ID = uint64(id)
+		return nil
+	})
+	return credits, err
+}
+
+// GetRecent returns a page of the timeline, newest votes first
+func (r *VoteRepository) GetRecent(ctx context.Context, page Page) ([]models.VoteWithDetails, error) {
+	rows, err := database.DB.QueryContext(ctx, `
 		SELECT
-			v.id, v.achievement_id, v.points, v.is_secret, v.is_invalidated, v.comment, v.created_at,
-			fu.id, fu.steam_id, fu.username, fu.avatar_url, fu.avatar_small, fu.profile_url,
-			tu.id, tu.steam_id, tu.username, tu.avatar_url, tu.avatar_small, tu.profile_url
+			v.id, v.achievement_id, v.points, v.is_secret, v.is_invalidated, v.is_revealed, v.comment, v.app_id, v.created_at,
+			fu.id, fu.steam_id, fu.username, fu.avatar_url, fu.avatar_small, fu.profile_url, fu.country_code,
+			tu.id, tu.steam_id, tu.username, tu.avatar_url, tu.avatar_small, tu.profile_url, tu.country_code
 		FROM votes v
 		JOIN users fu ON v.from_user_id = fu.id
 		JOIN users tu ON v.to_user_id = tu.id
-		ORDER BY v.created_at DESC
-		LIMIT ?`, limit)
+		WHERE (? = 0 OR v.id < ?)
+		ORDER BY v.id DESC
+		LIMIT ?`, page.Cursor, page.Cursor, page.Limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get recent votes: %w", err)
 	}
@@ -59,13 +109,15 @@ func (r *VoteRepository) GetRecent(limit int) ([]models.VoteWithDetails, error)
 	for rows.Next() {
 		var v models.VoteWithDetails
 		err := rows.Scan(
-			&v.ID, &v.AchievementID, &v.Points, &v.IsSecret, &v.IsInvalidated, &v.Comment, &v.CreatedAt,
-			&v.FromUser.ID, &v.FromUser.SteamID, &v.FromUser.Username, &v.FromUser.AvatarURL, &v.FromUser.AvatarSmall, &v.FromUser.ProfileURL,
-			&v.ToUser.ID, &v.ToUser.SteamID, &v.ToUser.Username, &v.ToUser.AvatarURL, &v.ToUser.AvatarSmall, &v.ToUser.ProfileURL,
+			&v.ID, &v.AchievementID, &v.Points, &v.IsSecret, &v.IsInvalidated, &v.IsRevealed, &v.Comment, &v.AppID, &v.CreatedAt,
+			&v.FromUser.ID, &v.FromUser.SteamID, &v.FromUser.Username, &v.FromUser.AvatarURL, &v.FromUser.AvatarSmall, &v.FromUser.ProfileURL, &v.FromUser.CountryCode,
+			&v.ToUser.ID, &v.ToUser.SteamID, &v.ToUser.Username, &v.ToUser.AvatarURL, &v.ToUser.AvatarSmall, &v.ToUser.ProfileURL, &v.ToUser.CountryCode,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan vote row: %w", err)
 		}
+		v.FromUser.Flag = models.CountryFlag(v.FromUser.CountryCode)
+		v.ToUser.Flag = models.CountryFlag(v.ToUser.CountryCode)
 
 		// Add achievement details
 		if achievement, ok := models.GetAchievement(v.AchievementID); ok {
@@ -78,43 +130,230 @@ func (r *VoteRepository) GetRecent(limit int) ([]models.VoteWithDetails, error)
 	return votes, nil
 }
 
-// GetByID returns a vote by ID with full details
-func (r *VoteRepository) GetByID(id uint64) (*models.VoteWithDetails, error) {
+// voteDetailsQuery selects votes with full details, scanned by scanVoteDetails
+const voteDetailsQuery = `
+	SELECT
+		v.id, v.achievement_id, v.points, v.is_secret, v.is_invalidated, v.is_revealed, v.comment, v.app_id, v.created_at,
+		fu.id, fu.steam_id, fu.username, fu.avatar_url, fu.avatar_small, fu.profile_url, fu.country_code,
+		tu.id, tu.steam_id, tu.username, tu.avatar_url, tu.avatar_small, tu.profile_url, tu.country_code
+	FROM votes v
+	JOIN users fu ON v.from_user_id = fu.id
+	JOIN users tu ON v.to_user_id = tu.id`
+
+// scanVoteDetails scans a row of voteDetailsQuery and adds flags and achievement details
+func scanVoteDetails(scan func(dest ...interface{}) error) (*models.VoteWithDetails, error) {
 	var v models.VoteWithDetails
-	err := database.DB.QueryRow(`
-		SELECT
-			v.id, v.achievement_id, v.points, v.is_secret, v.is_invalidated, v.comment, v.created_at,
-			fu.id, fu.steam_id, fu.username, fu.avatar_url, fu.avatar_small, fu.profile_url,
-			tu.id, tu.steam_id, tu.username, tu.avatar_url, tu.avatar_small, tu.profile_url
-		FROM votes v
-		JOIN users fu ON v.from_user_id = fu.id
-		JOIN users tu ON v.to_user_id = tu.id
-		WHERE v.id = ?`, id,
-	).Scan(
-		&v.ID, &v.AchievementID, &v.Points, &v.IsSecret, &v.IsInvalidated, &v.Comment, &v.CreatedAt,
-		&v.FromUser.ID, &v.FromUser.SteamID, &v.FromUser.Username, &v.FromUser.AvatarURL, &v.FromUser.AvatarSmall, &v.FromUser.ProfileURL,
-		&v.ToUser.ID, &v.ToUser.SteamID, &v.ToUser.Username, &v.ToUser.AvatarURL, &v.ToUser.AvatarSmall, &v.ToUser.ProfileURL,
+	err := scan(
+		&v.ID, &v.AchievementID, &v.Points, &v.IsSecret, &v.IsInvalidated, &v.IsRevealed, &v.Comment, &v.AppID, &v.CreatedAt,
+		&v.FromUser.ID, &v.FromUser.SteamID, &v.FromUser.Username, &v.FromUser.AvatarURL, &v.FromUser.AvatarSmall, &v.FromUser.ProfileURL, &v.FromUser.CountryCode,
+		&v.ToUser.ID, &v.ToUser.SteamID, &v.ToUser.Username, &v.ToUser.AvatarURL, &v.ToUser.AvatarSmall, &v.ToUser.ProfileURL, &v.ToUser.CountryCode,
 	)
+	if err != nil {
+		return nil, err
+	}
+	v.FromUser.Flag = models.CountryFlag(v.FromUser.CountryCode)
+	v.ToUser.Flag = models.CountryFlag(v.ToUser.CountryCode)
+
+	// Add achievement details
+	if achievement, ok := models.GetAchievement(v.AchievementID); ok {
+		v.Achievement = achievement
+	}
 
+	return &v, nil
+}
+
+// GetByID returns a vote by ID with full details
+func (r *VoteRepository) GetByID(ctx context.Context, id uint64) (*models.VoteWithDetails, error) {
+	v, err := scanVoteDetails(database.DB.QueryRowContext(ctx, voteDetailsQuery+` WHERE v.id = ?`, id).Scan)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vote by id: %w", err)
 	}
+	return v, nil
+}
 
-	// Add achievement details
-	if achievement, ok := models.GetAchievement(v.AchievementID); ok {
-		v.Achievement = achievement
+// GetByIDs returns the votes with the given IDs with full details, keyed by ID
+// Unknown IDs are left out
+func (r *VoteRepository) GetByIDs(ctx context.Context, ids []uint64) (map[uint64]*models.VoteWithDetails, error) {
+	votes := make(map[uint64]*models.VoteWithDetails, len(ids))
+	if len(ids) == 0 {
+		return votes, nil
 	}
 
-	return &v, nil
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	rows, err := database.DB.QueryContext(ctx,
+		voteDetailsQuery+` WHERE v.id IN (`+strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")+`)`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get votes by id: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		v, err := scanVoteDetails(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan vote row: %w", err)
+		}
+		votes[v.ID] = v
+	}
+	return votes, rows.Err()
+}
+
+// CountDistinctVotersSince returns how many different users gave the target the achievement since the given time
+// Invalidated votes and the vote with excludeVoteID (0 = none) are not counted
+func (r *VoteRepository) CountDistinctVotersSince(ctx context.Context, toUserID uint64, achievementID string, since time.Time, excludeVoteID uint64) (int, error) {
+	var count int
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT from_user_id)
+		FROM votes
+		WHERE to_user_id = ? AND achievement_id = ? AND created_at >= ? AND is_invalidated = 0 AND id != ?`,
+		toUserID, achievementID, since.UTC(), excludeVoteID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count distinct voters: %w", err)
+	}
+	return count, nil
+}
+
+// CountByVoterSince returns how many votes the user has given for the achievement since the given time
+// Invalidated votes are counted as well, they were still cast
+func (r *VoteRepository) CountByVoterSince(ctx context.Context, fromUserID uint64, achievementID string, since time.Time) (int, error) {
+	var count int
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM votes
+		WHERE from_user_id = ? AND achievement_id = ? AND created_at >= ?`,
+		fromUserID, achievementID, since.UTC(),
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count votes by voter: %w", err)
+	}
+	return count, nil
+}
+
+// CountByVoterPerAchievementSince returns how many votes the user has given per achievement since the given time
+// Invalidated votes are counted as well, achievements without votes are not included
+func (r *VoteRepository) CountByVoterPerAchievementSince(ctx context.Context, fromUserID uint64, since time.Time) (map[string]int, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT achievement_id, COUNT(*)
+		FROM votes
+		WHERE from_user_id = ? AND created_at >= ?
+		GROUP BY achievement_id`,
+		fromUserID, since.UTC(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count votes by voter: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var achievementID string
+		var count int
+		if err := rows.Scan(&achievementID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan vote count: %w", err)
+		}
+		counts[achievementID] = count
+	}
+
+	return counts, nil
+}
+
+// VotePair identifies a (target user, achievement) combination
+type VotePair struct {
+	ToUserID      uint64
+	AchievementID string
+}
+
+// GetVotedPairsSince returns the (user, achievement) pairs the given user has voted for since the given time
+func (r *VoteRepository) GetVotedPairsSince(ctx context.Context, fromUserID uint64, since time.Time) (map[VotePair]bool, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT DISTINCT to_user_id, achievement_id
+		FROM votes
+		WHERE from_user_id = ? AND created_at >= ?`,
+		fromUserID, since.UTC(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get voted pairs: %w", err)
+	}
+	defer rows.Close()
+
+	pairs := make(map[VotePair]bool)
+	for rows.Next() {
+		var p VotePair
+		if err := rows.Scan(&p.ToUserID, &p.AchievementID); err != nil {
+			return nil, fmt.Errorf("failed to scan voted pair: %w", err)
+		}
+		pairs[p] = true
+	}
+
+	return pairs, nil
+}
+
+// GetReceivedVoteCounts returns the number of valid votes each user has received
+// Users without votes are not included
+func (r *VoteRepository) GetReceivedVoteCounts(ctx context.Context) (map[uint64]int, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT to_user_id, COUNT(*)
+		FROM votes
+		WHERE is_invalidated = 0
+		GROUP BY to_user_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get received vote counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[uint64]int)
+	for rows.Next() {
+		var userID uint64
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan vote count: %w", err)
+		}
+		counts[userID] = count
+	}
+
+	return counts, nil
+}
+
+// GetReceivedPositiveVoteCounts returns the number of valid votes for positive achievements each user has received
+// Users without such votes are not included
+func (r *VoteRepository) GetReceivedPositiveVoteCounts(ctx context.Context) (map[uint64]int, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT to_user_id, achievement_id, COUNT(*)
+		FROM votes
+		WHERE is_invalidated = 0
+		GROUP BY to_user_id, achievement_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get received positive vote counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[uint64]int)
+	for rows.Next() {
+		var userID uint64
+		var achievementID string
+		var count int
+		if err := rows.Scan(&userID, &achievementID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan vote count: %w", err)
+		}
+		if achievement, ok := models.GetAchievement(achievementID); ok && achievement.IsPositive {
+			counts[userID] += count
+		}
+	}
+
+	return counts, nil
 }
 
 // LeaderboardEntry represents a user's position on the leaderboard for an achievement
 type LeaderboardEntry struct {
 	User       models.PublicUser `json:"user"`
 	VoteCount  int               `json:"vote_count"`
+	Points     int               `json:"points"` // Vote count multiplied by the achievement weight
 	Rank       int               `json:"rank"`
 }
 
@@ -122,21 +361,66 @@ type LeaderboardEntry struct {
 type AchievementLeaderboard struct {
 	Achievement models.Achievement `json:"achievement"`
 	Leaders     []LeaderboardEntry `json:"leaders"`
+	IsArchived  bool               `json:"is_archived"` // Validity window of a seasonal achievement has ended
 }
 
 // GetLeaderboard returns the top N users per achievement
-func (r *VoteRepository) GetLeaderboard(topN int) ([]AchievementLeaderboard, error) {
-	// Get all achievements and their top voters (sum of points), excluding invalidated votes
-	rows, err := database.DB.Query(`
-		SELECT
-			v.achievement_id,
-			u.id, u.steam_id, u.username, u.avatar_url, u.avatar_small, u.profile_url,
-			SUM(v.points) as vote_count
+// An empty category includes achievements of all categories
+// An appID other than 0 only counts votes cast in the context of that game and
+// leaves out achievements scoped to other games
+// Users with the same points are ordered by their first vote, like the placement bonus of the ranking
+func (r *VoteRepository) GetLeaderboard(ctx context.Context, topN int, category string, appID int) ([]AchievementLeaderboard, error) {
+	start := time.Now()
+	defer func() {
+		metrics.LeaderboardQueries.Inc()
+		metrics.LeaderboardDurationMicros.Add(time.Since(start).Microseconds())
+	}()
+
+	gameFilter := ""
+	args := []interface{}{}
+	if appID != 0 {
+		gameFilter = " AND v.app_id = ?"
+		args = append(args, appID)
+	}
+
+	// Sum of points per achievement and recipient, excluding invalidated votes (covered by idx_votes_ranking)
+	totals := `
+		SELECT v.achievement_id, v.to_user_id, SUM(v.points) AS vote_count, MIN(v.created_at) AS first_vote
 		FROM votes v
 		JOIN users u ON v.to_user_id = u.id
-		WHERE v.is_invalidated = 0
-		GROUP BY v.achievement_id, v.to_user_id
-		ORDER BY v.achievement_id, vote_count DESC`)
+		WHERE v.is_invalidated = 0 AND u.hide_from_ranking = 0 AND u.deleted_at IS NULL AND u.pending_review = 0` + gameFilter + `
+		GROUP BY v.achievement_id, v.to_user_id`
+
+	var query string
+	if database.SupportsWindowFunctions() {
+		// Only the top N per achievement leave the database
+		query = `
+			SELECT
+				t.achievement_id,
+				u.id, u.steam_id, u.username, u.avatar_url, u.avatar_small, u.profile_url, u.country_code,
+				t.vote_count
+			FROM (
+				SELECT achievement_id, to_user_id, vote_count,
+					ROW_NUMBER() OVER (PARTITION BY achievement_id ORDER BY vote_count DESC, first_vote ASC) AS position
+				FROM (` + totals + `) totals
+			) t
+			JOIN users u ON t.to_user_id = u.id
+			WHERE t.position <= ?
+			ORDER BY t.achievement_id, t.position`
+		args = append(args, topN)
+	} else {
+		// Older MySQL servers return every recipient, the top N are kept below
+		query = `
+			SELECT
+				t.achievement_id,
+				u.id, u.steam_id, u.username, u.avatar_url, u.avatar_small, u.profile_url, u.country_code,
+				t.vote_count
+			FROM (` + totals + `) t
+			JOIN users u ON t.to_user_id = u.id
+			ORDER BY t.achievement_id, t.vote_count DESC, t.first_vote ASC`
+	}
+
+	rows, err := database.DB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
 	}
@@ -151,30 +435,48 @@ func (r *VoteRepository) GetLeaderboard(topN int) ([]AchievementLeaderboard, err
 
 		err := rows.Scan(
 			&achievementID,
-			&user.ID, &user.SteamID, &user.Username, &user.AvatarURL, &user.AvatarSmall, &user.ProfileURL,
+			&user.ID, &user.SteamID, &user.Username, &user.AvatarURL, &user.AvatarSmall, &user.ProfileURL, &user.CountryCode,
 			&voteCount,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan leaderboard row: %w", err)
 		}
+		user.Flag = models.CountryFlag(user.CountryCode)
 
 		// Only keep top N per achievement
 		if len(achievementMap[achievementID]) < topN {
+			weight := 1
+			if achievement, ok := models.GetAchievement(achievementID); ok {
+				weight = achievement.Weight
+			}
 			entry := LeaderboardEntry{
 				User:      user,
 				VoteCount: voteCount,
+				Points:    voteCount * weight,
 				Rank:      len(achievementMap[achievementID]) + 1,
 			}
 			achievementMap[achievementID] = append(achievementMap[achievementID], entry)
 		}
 	}
 
-	// Build result with all achievements (even those with no votes)
-	var result []AchievementLeaderboard
+	// Build result with all achievements (even those with no votes),
+	// seasonal achievements only show up once their validity window has begun
+	now := time.Now()
+	result := make([]AchievementLeaderboard, 0)
 	for _, achievement := range models.GetAllAchievements() {
+		if category != "" && achievement.Category != category {
+			continue
+		}
+		if !achievement.HasStartedAt(now) {
+			continue
+		}
+		if appID != 0 && !achievement.IsAvailableForGame(appID) {
+			continue
+		}
 		lb := AchievementLeaderboard{
 			Achievement: achievement,
 			Leaders:     achievementMap[achievement.ID],
+			IsArchived:  achievement.IsArchivedAt(now),
 		}
 		if lb.Leaders == nil {
 			lb.Leaders = []LeaderboardEntry{}
@@ -185,13 +487,86 @@ func (r *VoteRepository) GetLeaderboard(topN int) ([]AchievementLeaderboard, err
 	return result, nil
 }
 
+// AchievementStats describes how often an achievement has been received
+type AchievementStats struct {
+	Achievement      models.Achievement `json:"achievement"`
+	VoteCount        int                `json:"vote_count"`        // Valid votes, regardless of points
+	RecipientCount   int                `json:"recipient_count"`   // Distinct players who received it
+	RecipientPercent float64            `json:"recipient_percent"` // Share of all players who received it
+	RarityPercentile int                `json:"rarity_percentile"` // Share of achievements received by more players, 100 = rarest
+}
+
+// GetAchievementStats returns vote and recipient counts of all achievements with their rarity
+// Seasonal achievements only show up once their validity window has begun
+func (r *VoteRepository) GetAchievementStats(ctx context.Context) ([]AchievementStats, int, error) {
+	var totalPlayers int
+	if err := database.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE deleted_at IS NULL AND pending_review = 0`).Scan(&totalPlayers); err != nil {
+		return nil, 0, fmt.Errorf("failed to count players: %w", err)
+	}
+
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT achievement_id, COUNT(*), COUNT(DISTINCT to_user_id)
+		FROM votes
+		WHERE is_invalidated = 0
+		GROUP BY achievement_id`)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get achievement stats: %w", err)
+	}
+	defer rows.Close()
+
+	type counts struct{ votes, recipients int }
+	byAchievement := make(map[string]counts)
+	for rows.Next() {
+		var achievementID string
+		var c counts
+		if err := rows.Scan(&achievementID, &c.votes, &c.recipients); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan achievement stats: %w", err)
+		}
+		byAchievement[achievementID] = c
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to get achievement stats: %w", err)
+	}
+
+	now := time.Now()
+	stats := make([]AchievementStats, 0)
+	for _, achievement := range models.GetAllAchievements() {
+		if !achievement.HasStartedAt(now) {
+			continue
+		}
+		c := byAchievement[achievement.ID]
+		s := AchievementStats{
+			Achievement:    achievement,
+			VoteCount:      c.votes,
+			RecipientCount: c.recipients,
+		}
+		if totalPlayers > 0 {
+			// Rounded to one decimal place
+			s.RecipientPercent = float64(c.recipients*1000/totalPlayers) / 10
+		}
+		stats = append(stats, s)
+	}
+
+	for i := range stats {
+		moreCommon := 0
+		for _, other := range stats {
+			if other.RecipientCount > stats[i].RecipientCount {
+				moreCommon++
+			}
+		}
+		stats[i].RarityPercentile = moreCommon * 100 / len(stats)
+	}
+
+	return stats, totalPlayers, nil
+}
+
 // GetVotesForUser returns all votes received by a user
-func (r *VoteRepository) GetVotesForUser(userID uint64) ([]models.VoteWithDetails, error) {
-	rows, err := database.DB.Query(`
+func (r *VoteRepository) GetVotesForUser(ctx context.Context, userID uint64) ([]models.VoteWithDetails, error) {
+	rows, err := database.DB.QueryContext(ctx, `
 		SELECT
 			v.id, v.achievement_id, v.points, v.is_secret, v.created_at,
-			fu.id, fu.steam_id, fu.username, fu.avatar_url, fu.avatar_small, fu.profile_url,
-			tu.id, tu.steam_id, tu.username, tu.avatar_url, tu.avatar_small, tu.profile_url
+			fu.id, fu.steam_id, fu.username, fu.avatar_url, fu.avatar_small, fu.profile_url, fu.country_code,
+			tu.id, tu.steam_id, tu.username, tu.avatar_url, tu.avatar_small, tu.profile_url, tu.country_code
 		FROM votes v
 		JOIN users fu ON v.from_user_id = fu.id
 		JOIN users tu ON v.to_user_id = tu.id
@@ -207,12 +582,14 @@ func (r *VoteRepository) GetVotesForUser(userID uint64) ([]models.VoteWithDetail
 		var v models.VoteWithDetails
 		err := rows.Scan(
 			&v.ID, &v.AchievementID, &v.Points, &v.IsSecret, &v.CreatedAt,
-			&v.FromUser.ID, &v.FromUser.SteamID, &v.FromUser.Username, &v.FromUser.AvatarURL, &v.FromUser.AvatarSmall, &v.FromUser.ProfileURL,
-			&v.ToUser.ID, &v.ToUser.SteamID, &v.ToUser.Username, &v.ToUser.AvatarURL, &v.ToUser.AvatarSmall, &v.ToUser.ProfileURL,
+			&v.FromUser.ID, &v.FromUser.SteamID, &v.FromUser.Username, &v.FromUser.AvatarURL, &v.FromUser.AvatarSmall, &v.FromUser.ProfileURL, &v.FromUser.CountryCode,
+			&v.ToUser.ID, &v.ToUser.SteamID, &v.ToUser.Username, &v.ToUser.AvatarURL, &v.ToUser.AvatarSmall, &v.ToUser.ProfileURL, &v.ToUser.CountryCode,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan vote row: %w", err)
 		}
+		v.FromUser.Flag = models.CountryFlag(v.FromUser.CountryCode)
+		v.ToUser.Flag = models.CountryFlag(v.ToUser.CountryCode)
 
 		if achievement, ok := models.GetAchievement(v.AchievementID); ok {
 			v.Achievement = achievement
@@ -244,11 +621,12 @@ type ChampionsResult struct {
 // 1. Net votes (positive - negative)
 // 2. Bonus points from holding top 3 positions in positive achievements (1st: +5, 2nd: +3, 3rd: +2)
 // Tie-breaking for achievement positions: first vote wins (earlier created_at)
-func (r *VoteRepository) GetChampions() (*ChampionsResult, error) {
+// Tie-breaking for the total score: the given rules, see GetGlobalRanking
+func (r *VoteRepository) GetChampions(ctx context.Context, tieBreakers []string) (*ChampionsResult, error) {
 	result := &ChampionsResult{}
 
 	// Get global rankings (already includes bonus points)
-	rankings, err := r.GetGlobalRanking()
+	rankings, err := r.GetGlobalRanking(ctx, tieBreakers)
 	if err != nil {
 		return nil, err
 	}
@@ -266,6 +644,8 @@ func (r *VoteRepository) GetChampions() (*ChampionsResult, error) {
 				AvatarURL:   p.User.AvatarURL,
 				AvatarSmall: p.User.AvatarSmall,
 				ProfileURL:  p.User.ProfileURL,
+				CountryCode: p.User.CountryCode,
+				Flag:        p.User.Flag,
 			},
 			TotalScore:  p.TotalScore,
 			NetVotes:    p.NetVotes,
@@ -287,22 +667,37 @@ func (r *VoteRepository) GetChampions() (*ChampionsResult, error) {
 }
 
 // ToggleInvalidation toggles the is_invalidated flag of a vote
-func (r *VoteRepository) ToggleInvalidation(voteID uint64) (bool, error) {
+// The admin and reason are stored on the vote and appended to the invalidation audit trail
+func (r *VoteRepository) ToggleInvalidation(ctx context.Context, voteID uint64, adminSteamID, reason string) (bool, error) {
 	var newState bool
-	err := database.WithRetry(func() error {
-		// Toggle is_invalidated: if 0 -> 1, if 1 -> 0
-		_, err := database.DB.Exec(`
-			UPDATE votes
-			SET is_invalidated = CASE WHEN is_invalidated = 0 THEN 1 ELSE 0 END
-			WHERE id = ?`, voteID)
+	err := database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		var current bool
+		err := tx.QueryRowContext(ctx, `SELECT is_invalidated FROM votes WHERE id = ?`, voteID).Scan(&current)
+		if err != nil {
+			return fmt.Errorf("failed to get invalidation state: %w", err)
+		}
+		newState = !current
+
+		if newState {
+			_, err = tx.ExecContext(ctx, `
+				UPDATE votes
+				SET is_invalidated = 1, invalidated_by = ?, invalidated_at = CURRENT_TIMESTAMP, invalidation_reason = ?
+				WHERE id = ?`, adminSteamID, reason, voteID)
+		} else {
+			_, err = tx.ExecContext(ctx, `
+				UPDATE votes
+				SET is_invalidated = 0, invalidated_by = NULL, invalidated_at = NULL, invalidation_reason = NULL
+				WHERE id = ?`, voteID)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to toggle vote invalidation: %w", err)
 		}
 
-		// Get the new state
-		err = database.DB.QueryRow(`SELECT is_invalidated FROM votes WHERE id = ?`, voteID).Scan(&newState)
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO vote_invalidation_log (vote_id, admin_steam_id, is_invalidated, reason)
+			VALUES (?, ?, ?, ?)`, voteID, adminSteamID, newState, reason)
 		if err != nil {
-			return fmt.Errorf("failed to get new invalidation state: %w", err)
+			return fmt.Errorf("failed to write invalidation log: %w", err)
 		}
 
 		return nil
@@ -311,15 +706,316 @@ func (r *VoteRepository) ToggleInvalidation(voteID uint64) (bool, error) {
 	return newState, err
 }
 
-// DeleteAll deletes all votes from the database (admin only)
-func (r *VoteRepository) DeleteAll() (int64, error) {
+// GetValidByFilter returns up to limit votes matching the filter that are not invalidated, oldest first
+// Filtering by sender skips unrevealed secret votes, their senders are only visible in the audited secret vote review
+func (r *VoteRepository) GetValidByFilter(ctx context.Context, filter models.VoteFilter, limit int) ([]models.Vote, error) {
+	since, until := time.Time{}, time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+	if filter.Since != nil {
+		since = *filter.Since
+	}
+	if filter.Until != nil {
+		until = *filter.Until
+	}
+
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT id, from_user_id, to_user_id, achievement_id, points, is_secret, is_invalidated, is_revealed, created_at
+		FROM votes
+		WHERE is_invalidated = 0
+			AND (? = 0 OR (from_user_id = ? AND (is_secret = 0 OR is_revealed = 1)))
+			AND (? = 0 OR to_user_id = ?)
+			AND (? = '' OR achievement_id = ?)
+			AND created_at >= ? AND created_at <= ?
+		ORDER BY created_at, id
+		LIMIT ?`,
+		filter.FromUserID, filter.FromUserID,
+		filter.ToUserID, filter.ToUserID,
+		filter.AchievementID, filter.AchievementID,
+		since.UTC(), until.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get votes by filter: %w", err)
+	}
+	defer rows.Close()
+
+	votes := []models.Vote{}
+	for rows.Next() {
+		var v models.Vote
+		if err := rows.Scan(&v.ID, &v.FromUserID, &v.ToUserID, &v.AchievementID, &v.Points,
+			&v.IsSecret, &v.IsInvalidated, &v.IsRevealed, &v.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan vote: %w", err)
+		}
+		// Hide the sender of secret votes
+		if v.IsSecret && !v.IsRevealed {
+			v.FromUserID = 0
+		}
+		votes = append(votes, v)
+	}
+
+	return votes, nil
+}
+
+// InvalidateMany invalidates the given votes in one transaction and appends them to the invalidation audit trail
+// Votes that are already invalidated are skipped, returns the IDs of the invalidated votes
+func (r *VoteRepository) InvalidateMany(ctx context.Context, voteIDs []uint64, adminSteamID, reason string) ([]uint64, error) {
+	var invalidated []uint64
+	err := database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		invalidated = invalidated[:0]
+		for _, voteID := range voteIDs {
+			result, err := tx.ExecContext(ctx, `
+				UPDATE votes
+				SET is_invalidated = 1, invalidated_by = ?, invalidated_at = CURRENT_TIMESTAMP, invalidation_reason = ?
+				WHERE id = ? AND is_invalidated = 0`, adminSteamID, reason, voteID)
+			if err != nil {
+				return fmt.Errorf("failed to invalidate vote: %w", err)
+			}
+			if changed, _ := result.RowsAffected(); changed == 0 {
+				continue
+			}
+
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO vote_invalidation_log (vote_id, admin_steam_id, is_invalidated, reason)
+				VALUES (?, ?, ?, ?)`, voteID, adminSteamID, true, reason)
+			if err != nil {
+				return fmt.Errorf("failed to write invalidation log: %w", err)
+			}
+			invalidated = append(invalidated, voteID)
+		}
+		return nil
+	})
+
+	return invalidated, err
+}
+
+// GetRecentForAdmin returns a page of the most recent votes including invalidation details
+func (r *VoteRepository) GetRecentForAdmin(ctx context.Context, page Page) ([]models.VoteWithDetails, error) {
+	return r.getForAdmin(ctx, false, page)
+}
+
+// GetSecretForAdmin returns a page of the most recent secret votes with their real senders and invalidation details
+func (r *VoteRepository) GetSecretForAdmin(ctx context.Context, page Page) ([]models.VoteWithDetails, error) {
+	return r.getForAdmin(ctx, true, page)
+}
+
+// getForAdmin returns a page of the most recent votes (optionally only secret ones) including invalidation details, newest first
+func (r *VoteRepository) getForAdmin(ctx context.Context, secretOnly bool, page Page) ([]models.VoteWithDetails, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT
+			v.id, v.achievement_id, v.points, v.is_secret, v.is_invalidated, v.is_revealed, v.comment, v.app_id, v.created_at,
+			v.invalidated_by, v.invalidated_at, v.invalidation_reason,
+			fu.id, fu.steam_id, fu.username, fu.avatar_url, fu.avatar_small, fu.profile_url, fu.country_code,
+			tu.id, tu.steam_id, tu.username, tu.avatar_url, tu.avatar_small, tu.profile_url, tu.country_code
+		FROM votes v
+		JOIN users fu ON v.from_user_id = fu.id
+		JOIN users tu ON v.to_user_id = tu.id
+		WHERE (? = 0 OR v.is_secret = 1)
+			AND (? = 0 OR v.id < ?)
+		ORDER BY v.id DESC
+		LIMIT ?`, secretOnly, page.Cursor, page.Cursor, page.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent votes: %w", err)
+	}
+	defer rows.Close()
+
+	var votes []models.VoteWithDetails
+	for rows.Next() {
+		var v models.VoteWithDetails
+		var invalidatedBy, invalidationReason sql.NullString
+		var invalidatedAt *time.Time
+		err := rows.Scan(
+			&v.ID, &v.AchievementID, &v.Points, &v.IsSecret, &v.IsInvalidated, &v.IsRevealed, &v.Comment, &v.AppID, &v.CreatedAt,
+			&invalidatedBy, &invalidatedAt, &invalidationReason,
+			&v.FromUser.ID, &v.FromUser.SteamID, &v.FromUser.Username, &v.FromUser.AvatarURL, &v.FromUser.AvatarSmall, &v.FromUser.ProfileURL, &v.FromUser.CountryCode,
+			&v.ToUser.ID, &v.ToUser.SteamID, &v.ToUser.Username, &v.ToUser.AvatarURL, &v.ToUser.AvatarSmall, &v.ToUser.ProfileURL, &v.ToUser.CountryCode,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan vote row: %w", err)
+		}
+		v.FromUser.Flag = models.CountryFlag(v.FromUser.CountryCode)
+		v.ToUser.Flag = models.CountryFlag(v.ToUser.CountryCode)
+
+		if achievement, ok := models.GetAchievement(v.AchievementID); ok {
+			v.Achievement = achievement
+		}
+
+		if v.IsInvalidated && invalidatedBy.Valid {
+			v.Invalidation = &models.VoteInvalidation{
+				InvalidatedBy: invalidatedBy.String,
+				Reason:        invalidationReason.String,
+			}
+			if invalidatedAt != nil {
+				v.Invalidation.InvalidatedAt = *invalidatedAt
+			}
+		}
+
+		votes = append(votes, v)
+	}
+
+	return votes, nil
+}
+
+// GetGivenByUser returns a page of the votes cast by a user, newest first
+func (r *VoteRepository) GetGivenByUser(ctx context.Context, fromUserID uint64, page Page) ([]models.VoteWithDetails, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT
+			v.id, v.achievement_id, v.points, v.is_secret, v.is_invalidated, v.is_revealed, v.comment, v.app_id, v.created_at,
+			fu.id, fu.steam_id, fu.username, fu.avatar_url, fu.avatar_small, fu.profile_url, fu.country_code,
+			tu.id, tu.steam_id, tu.username, tu.avatar_url, tu.avatar_small, tu.profile_url, tu.country_code
+		FROM votes v
+		JOIN users fu ON v.from_user_id = fu.id
+		JOIN users tu ON v.to_user_id = tu.id
+		WHERE v.from_user_id = ?
+			AND (? = 0 OR v.id < ?)
+		ORDER BY v.id DESC
+		LIMIT ?`, fromUserID, page.Cursor, page.Cursor, page.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get votes given by user: %w", err)
+	}
+	defer rows.Close()
+
+	var votes []models.VoteWithDetails
+	for rows.Next() {
+		var v models.VoteWithDetails
+		err := rows.Scan(
+			&v.ID, &v.AchievementID, &v.Points, &v.IsSecret, &v.IsInvalidated, &v.IsRevealed, &v.Comment, &v.AppID, &v.CreatedAt,
+			&v.FromUser.ID, &v.FromUser.SteamID, &v.FromUser.Username, &v.FromUser.AvatarURL, &v.FromUser.AvatarSmall, &v.FromUser.ProfileURL, &v.FromUser.CountryCode,
+			&v.ToUser.ID, &v.ToUser.SteamID, &v.ToUser.Username, &v.ToUser.AvatarURL, &v.ToUser.AvatarSmall, &v.ToUser.ProfileURL, &v.ToUser.CountryCode,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan vote row: %w", err)
+		}
+		v.FromUser.Flag = models.CountryFlag(v.FromUser.CountryCode)
+		v.ToUser.Flag = models.CountryFlag(v.ToUser.CountryCode)
+
+		if achievement, ok := models.GetAchievement(v.AchievementID); ok {
+			v.Achievement = achievement
+		}
+
+		votes = append(votes, v)
+	}
+
+	return votes, nil
+}
+
+// CountGivenByUser returns the total number of votes cast by a user
+func (r *VoteRepository) CountGivenByUser(ctx context.Context, fromUserID uint64) (int, error) {
+	var count int
+	err := database.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM votes WHERE from_user_id = ?`, fromUserID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count votes given by user: %w", err)
+	}
+	return count, nil
+}
+
+// SenderSecretVoteCount counts the negative secret votes cast by one sender
+type SenderSecretVoteCount struct {
+	User                models.PublicUser `json:"user"`
+	NegativeSecretVotes int               `json:"negative_secret_votes"`
+	DistinctTargets     int               `json:"distinct_targets"`
+}
+
+// GetNegativeSecretVoteCountsBySender returns per-sender counts of negative secret votes, most active first
+func (r *VoteRepository) GetNegativeSecretVoteCountsBySender(ctx context.Context) ([]SenderSecretVoteCount, error) {
+	// Achievement polarity lives in code, pass the negative IDs as parameters
+	var negativeIDs []interface{}
+	for _, a := range models.GetAllAchievements() {
+		if !a.IsPositive {
+			negativeIDs = append(negativeIDs, a.ID)
+		}
+	}
+	if len(negativeIDs) == 0 {
+		return []SenderSecretVoteCount{}, nil
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(negativeIDs)), ", ")
+
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT
+			u.id, u.steam_id, u.username, u.avatar_url, u.avatar_small, u.profile_url, u.country_code,
+			COUNT(*) as vote_count, COUNT(DISTINCT v.to_user_id)
+		FROM votes v
+		JOIN users u ON v.from_user_id = u.id
+		WHERE v.is_secret = 1 AND v.achievement_id IN (`+placeholders+`)
+		GROUP BY u.id, u.steam_id, u.username, u.avatar_url, u.avatar_small, u.profile_url, u.country_code
+		ORDER BY vote_count DESC`, negativeIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret vote counts: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []SenderSecretVoteCount
+	for rows.Next() {
+		var c SenderSecretVoteCount
+		err := rows.Scan(
+			&c.User.ID, &c.User.SteamID, &c.User.Username, &c.User.AvatarURL, &c.User.AvatarSmall, &c.User.ProfileURL, &c.User.CountryCode,
+			&c.NegativeSecretVotes, &c.DistinctTargets,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan secret vote count: %w", err)
+		}
+		c.User.Flag = models.CountryFlag(c.User.CountryCode)
+		counts = append(counts, c)
+	}
+
+	return counts, nil
+}
+
+// GetInvalidationLog returns the invalidation audit trail for a vote, oldest first
+func (r *VoteRepository) GetInvalidationLog(ctx context.Context, voteID uint64) ([]models.VoteInvalidationLogEntry, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT id, vote_id, admin_steam_id, is_invalidated, reason, created_at
+		FROM vote_invalidation_log
+		WHERE vote_id = ?
+		ORDER BY created_at ASC, id ASC`, voteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invalidation log: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.VoteInvalidationLogEntry
+	for rows.Next() {
+		var e models.VoteInvalidationLogEntry
+		if err := rows.Scan(&e.ID, &e.VoteID, &e.AdminSteamID, &e.IsInvalidated, &e.Reason, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan invalidation log row: %w", err)
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+// RevealAll marks all existing votes as revealed so that their senders are shown
+// The original is_secret flag is kept untouched
+func (r *VoteRepository) RevealAll(ctx context.Context) (int64, error) {
+	var rowsAffected int64
+	err := database.WithRetryContext(ctx, func() error {
+		result, err := database.DB.ExecContext(ctx, `UPDATE votes SET is_revealed = 1 WHERE is_revealed = 0`)
+		if err != nil {
+			return fmt.Errorf("failed to reveal votes: %w", err)
+		}
+
+		rowsAffected, err = result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		return nil
+	})
+
+	return rowsAffected, err
+}
+
+// DeleteAll deletes all votes and their notifications from the database (admin only)
+func (r *VoteRepository) DeleteAll(ctx context.Context) (int64, error) {
 	var rowsAffected int64
-	err := database.WithRetry(func() error {
-		result, err := database.DB.Exec(`DELETE FROM votes`)
+	err := database.WithRetryContext(ctx, func() error {
+		result, err := database.DB.ExecContext(ctx, `DELETE FROM votes`)
 		if err != nil {
 			return fmt.Errorf("failed to delete all votes: %w", err)
 		}
 
+		// Unread notifications of deleted votes must not pop up later
+		if _, err := database.DB.ExecContext(ctx, `DELETE FROM notifications WHERE type = ?`, models.NotificationTypeVoteReceived); err != nil {
+			return fmt.Errorf("failed to delete vote notifications: %w", err)
+		}
+
 		rowsAffected, err = result.RowsAffected()
 		if err != nil {
 			return fmt.Errorf("failed to get rows affected: %w", err)
@@ -335,9 +1031,10 @@ func (r *VoteRepository) DeleteAll() (int64, error) {
 type PlayerRanking struct {
 	User        models.PublicUser `json:"user"`
 	TotalScore  int               `json:"total_score"`  // net votes + bonus points
-	NetVotes    int               `json:"net_votes"`    // positive votes - negative votes
+	NetVotes    int               `json:"net_votes"`    // weighted positive votes - weighted negative votes
 	BonusPoints int               `json:"bonus_points"` // bonus from achievement placements
 	Rank        int               `json:"rank"`
+	TieBreak    string            `json:"tie_break,omitempty"` // Rule that placed the player below the previous one with the same score
 }
 
 // GlobalRankingResult contains the global ranking data
@@ -348,36 +1045,54 @@ type GlobalRankingResult struct {
 }
 
 // GetTotalVoteCount returns the total number of valid votes in the database
-func (r *VoteRepository) GetTotalVoteCount() (int, error) {
+func (r *VoteRepository) GetTotalVoteCount(ctx context.Context) (int, error) {
 	var count int
-	err := database.DB.QueryRow(`SELECT COUNT(*) FROM votes WHERE is_invalidated = 0`).Scan(&count)
+	err := database.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM votes WHERE is_invalidated = 0`).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get total vote count: %w", err)
 	}
 	return count, nil
 }
 
-// getAchievementBonusPoints calculates bonus points for each user based on their achievement positions
-// Only positive achievements count for bonus: 1st place = 5, 2nd = 3, 3rd = 2 points
-func (r *VoteRepository) getAchievementBonusPoints() (map[uint64]int, error) {
-	rows, err := database.DB.Query(`
+// GetVoteCountUntil returns the number of valid votes created before the given time
+func (r *VoteRepository) GetVoteCountUntil(ctx context.Context, until time.Time) (int, error) {
+	var count int
+	err := database.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM votes WHERE is_invalidated = 0 AND created_at < ?`, until.UTC()).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get vote count: %w", err)
+	}
+	return count, nil
+}
+
+// getRankingPoints calculates the weighted net votes and the bonus points of each user
+// from a single pass over the valid votes created before until (zero = all)
+// Net votes: positive achievements add, negative achievements subtract their weighted points
+// Bonus points: only positive achievements count, 1st place = 5, 2nd = 3, 3rd = 2 points,
+// multiplied by the achievement weight
+// Like on the leaderboard, users who opted out of the public ranking, were deleted or
+// wait for an account review take no placement, so they neither receive a bonus nor push others down
+func (r *VoteRepository) getRankingPoints(ctx context.Context, until time.Time) (netVotes, bonusPoints map[uint64]int, err error) {
+	// Covered by idx_votes_ranking, the votes table itself is not read
+	rows, err := database.DB.QueryContext(ctx, `
 		SELECT
 			v.achievement_id,
 			v.to_user_id,
 			SUM(v.points) as vote_count,
-			MIN(v.created_at) as first_vote
+			MIN(v.created_at) as first_vote,
+			MAX(CASE WHEN u.hide_from_ranking = 0 AND u.deleted_at IS NULL AND u.pending_review = 0 THEN 1 ELSE 0 END) as placeable
 		FROM votes v
-		WHERE v.achievement_id IN ('pro-player', 'teamplayer', 'clutch-king', 'support-hero', 'stratege', 'good-sport')
-			AND v.is_invalidated = 0
+		JOIN users u ON v.to_user_id = u.id
+		WHERE v.is_invalidated = 0 AND (? OR v.created_at < ?)
 		GROUP BY v.achievement_id, v.to_user_id
 		ORDER BY v.achievement_id, vote_count DESC, first_vote ASC
-	`)
+	`, until.IsZero(), until.UTC())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get achievement rankings: %w", err)
+		return nil, nil, fmt.Errorf("failed to get achievement rankings: %w", err)
 	}
 	defer rows.Close()
 
-	bonusPoints := make(map[uint64]int)
+	netVotes = make(map[uint64]int)
+	bonusPoints = make(map[uint64]int)
 	currentAchievement := ""
 	positionInAchievement := 0
 
@@ -386,10 +1101,11 @@ func (r *VoteRepository) getAchievementBonusPoints() (map[uint64]int, error) {
 		var userID uint64
 		var voteCount int
 		var firstVote interface{}
+		var placeable bool
 
-		err := rows.Scan(&achievementID, &userID, &voteCount, &firstVote)
+		err := rows.Scan(&achievementID, &userID, &voteCount, &firstVote, &placeable)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan achievement ranking row: %w", err)
+			return nil, nil, fmt.Errorf("failed to scan achievement ranking row: %w", err)
 		}
 
 		if achievementID != currentAchievement {
@@ -397,51 +1113,110 @@ func (r *VoteRepository) getAchievementBonusPoints() (map[uint64]int, error) {
 			positionInAchievement = 0
 		}
 
+		achievement, ok := models.GetAchievement(achievementID)
+		if !ok {
+			continue
+		}
+		if !achievement.IsPositive {
+			netVotes[userID] -= voteCount * achievement.Weight
+			continue
+		}
+		netVotes[userID] += voteCount * achievement.Weight
+
+		if !placeable {
+			continue
+		}
 		positionInAchievement++
 
 		switch positionInAchievement {
 		case 1:
-			bonusPoints[userID] += 5
+			bonusPoints[userID] += 5 * achievement.Weight
 		case 2:
-			bonusPoints[userID] += 3
+			bonusPoints[userID] += 3 * achievement.Weight
 		case 3:
-			bonusPoints[userID] += 2
+			bonusPoints[userID] += 2 * achievement.Weight
 		}
 	}
 
-	return bonusPoints, nil
+	return netVotes, bonusPoints, rows.Err()
+}
+
+// GetWeightedNetVotesSince returns the weighted net points each user received since the given time
+// Positive achievements add, negative achievements subtract their weighted points
+func (r *VoteRepository) GetWeightedNetVotesSince(ctx context.Context, since time.Time) (map[uint64]int, error) {
+	// Covered by idx_votes_received_since
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT to_user_id, achievement_id, SUM(points)
+		FROM votes
+		WHERE is_invalidated = 0 AND created_at >= ?
+		GROUP BY to_user_id, achievement_id`, since.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get net votes: %w", err)
+	}
+	defer rows.Close()
+
+	netVotes := make(map[uint64]int)
+	for rows.Next() {
+		var userID uint64
+		var achievementID string
+		var points int
+		if err := rows.Scan(&userID, &achievementID, &points); err != nil {
+			return nil, fmt.Errorf("failed to scan net votes row: %w", err)
+		}
+
+		achievement, ok := models.GetAchievement(achievementID)
+		if !ok {
+			continue
+		}
+		if achievement.IsPositive {
+			netVotes[userID] += points * achievement.Weight
+		} else {
+			netVotes[userID] -= points * achievement.Weight
+		}
+	}
+
+	return netVotes, nil
 }
 
 // GetGlobalRanking calculates the global ranking based on total score (net votes + bonus points)
-// Users with the same total score share the same rank
-func (r *VoteRepository) GetGlobalRanking() ([]PlayerRanking, error) {
-	// Step 1: Get bonus points from achievement positions
-	bonusPoints, err := r.getAchievementBonusPoints()
+// Users with the same total score are ordered by the tie-break rules (models.TieBreak*),
+// users who are still tied share the same rank and are listed by username
+// Users who opted out of the public ranking are not included
+func (r *VoteRepository) GetGlobalRanking(ctx context.Context, tieBreakers []string) ([]PlayerRanking, error) {
+	return r.getRanking(ctx, false, time.Time{}, tieBreakers)
+}
+
+// GetGlobalRankingAt recalculates the global ranking as it was at the given time
+// Only votes created and users registered before that time count; votes invalidated
+// later are still excluded, so the result shows the corrected standings of that moment
+func (r *VoteRepository) GetGlobalRankingAt(ctx context.Context, at time.Time, tieBreakers []string) ([]PlayerRanking, error) {
+	return r.getRanking(ctx, false, at, tieBreakers)
+}
+
+// getRanking calculates the ranking from the votes created before until (zero = all),
+// optionally including users who opted out of the public ranking
+func (r *VoteRepository) getRanking(ctx context.Context, includeHidden bool, until time.Time, tieBreakers []string) ([]PlayerRanking, error) {
+	start := time.Now()
+	defer func() {
+		metrics.RankingCalculations.Inc()
+		metrics.RankingDurationMicros.Add(time.Since(start).Microseconds())
+	}()
+
+	// Step 1: Weighted net votes and bonus points from achievement positions (excluding invalidated votes)
+	netVotesByUser, bonusPoints, err := r.getRankingPoints(ctx, until)
 	if err != nil {
 		return nil, err
 	}
 
-	// Step 2: Calculate net votes per user (excluding invalidated votes)
-	rows, err := database.DB.Query(`
-		SELECT
-			u.id, u.steam_id, u.username, u.avatar_url, u.avatar_small, u.profile_url,
-			COALESCE(SUM(CASE
-				WHEN v.achievement_id IN ('pro-player', 'teamplayer', 'clutch-king', 'support-hero', 'stratege', 'good-sport')
-					AND v.is_invalidated = 0
-				THEN v.points
-				ELSE 0
-			END), 0) -
-			COALESCE(SUM(CASE
-				WHEN v.achievement_id IN ('rage-quitter', 'toxic', 'friendly-fire-expert')
-					AND v.is_invalidated = 0
-				THEN v.points
-				ELSE 0
-			END), 0) as net_votes
+	// Step 2: Combine both for all rankable users
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT u.id, u.steam_id, u.username, u.avatar_url, u.avatar_small, u.profile_url, u.country_code
 		FROM users u
-		LEFT JOIN votes v ON v.to_user_id = u.id
-		WHERE NOT EXISTS (SELECT 1 FROM banned_users b WHERE b.steam_id = u.steam_id)
-		GROUP BY u.id
-	`)
+		WHERE u.deleted_at IS NULL AND u.pending_review = 0
+			AND NOT EXISTS (SELECT 1 FROM banned_users b WHERE b.steam_id = u.steam_id)
+			AND (? OR u.hide_from_ranking = 0)
+			AND (? OR u.created_at < ?)
+	`, includeHidden, until.IsZero(), until.UTC())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get global ranking: %w", err)
 	}
@@ -450,16 +1225,16 @@ func (r *VoteRepository) GetGlobalRanking() ([]PlayerRanking, error) {
 	var rankings []PlayerRanking
 	for rows.Next() {
 		var user models.PublicUser
-		var netVotes int
 
 		err := rows.Scan(
-			&user.ID, &user.SteamID, &user.Username, &user.AvatarURL, &user.AvatarSmall, &user.ProfileURL,
-			&netVotes,
+			&user.ID, &user.SteamID, &user.Username, &user.AvatarURL, &user.AvatarSmall, &user.ProfileURL, &user.CountryCode,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan ranking row: %w", err)
 		}
+		user.Flag = models.CountryFlag(user.CountryCode)
 
+		netVotes := netVotesByUser[user.ID]
 		bonus := bonusPoints[user.ID]
 		rankings = append(rankings, PlayerRanking{
 			User:        user,
@@ -470,19 +1245,35 @@ func (r *VoteRepository) GetGlobalRanking() ([]PlayerRanking, error) {
 	}
 
 	// Sort by total score descending, then by username
-	for i := 0; i < len(rankings); i++ {
-		for j := i + 1; j < len(rankings); j++ {
-			if rankings[j].TotalScore > rankings[i].TotalScore ||
-				(rankings[j].TotalScore == rankings[i].TotalScore && rankings[j].User.Username < rankings[i].User.Username) {
-				rankings[i], rankings[j] = rankings[j], rankings[i]
+	sort.SliceStable(rankings, func(i, j int) bool {
+		if rankings[i].TotalScore != rankings[j].TotalScore {
+			return rankings[i].TotalScore > rankings[j].TotalScore
+		}
+		return rankings[i].User.Username < rankings[j].User.Username
+	})
+
+	// Order players with the same total score by the tie-break rules
+	var stats *tieBreakStats
+	for start := 0; start < len(rankings); {
+		end := start + 1
+		for end < len(rankings) && rankings[end].TotalScore == rankings[start].TotalScore {
+			end++
+		}
+		if end-start > 1 && len(tieBreakers) > 0 {
+			if stats == nil {
+				if stats, err = r.getTieBreakStats(ctx, until); err != nil {
+					return nil, err
+				}
 			}
+			stats.breakTies(rankings[start:end], tieBreakers)
 		}
+		start = end
 	}
 
-	// Assign ranks - users with the same total score share the same rank
+	// Assign ranks - users with the same total score share the same rank unless a tie-break rule decided
 	currentRank := 1
 	for i := range rankings {
-		if i > 0 && rankings[i].TotalScore < rankings[i-1].TotalScore {
+		if i > 0 && (rankings[i].TotalScore < rankings[i-1].TotalScore || rankings[i].TieBreak != "") {
 			currentRank = i + 1
 		}
 		rankings[i].Rank = currentRank
@@ -491,9 +1282,101 @@ func (r *VoteRepository) GetGlobalRanking() ([]PlayerRanking, error) {
 	return rankings, nil
 }
 
+// tieBreakStats holds the per-player values compared by the tie-break rules
+type tieBreakStats struct {
+	lastScoredAt   map[uint64]time.Time // Time of the last valid vote received
+	negativePoints map[uint64]int       // Weighted negative points received
+	pairPoints     map[[2]uint64]int    // Weighted net points per [from, to] pair
+}
+
+// getTieBreakStats reads the values of the tie-break rules from the valid votes created before until (zero = all)
+func (r *VoteRepository) getTieBreakStats(ctx context.Context, until time.Time) (*tieBreakStats, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT from_user_id, to_user_id, achievement_id, points, created_at
+		FROM votes
+		WHERE is_invalidated = 0 AND (? OR created_at < ?)`, until.IsZero(), until.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tie-break votes: %w", err)
+	}
+	defer rows.Close()
+
+	stats := &tieBreakStats{
+		lastScoredAt:   make(map[uint64]time.Time),
+		negativePoints: make(map[uint64]int),
+		pairPoints:     make(map[[2]uint64]int),
+	}
+	for rows.Next() {
+		var fromUserID, toUserID uint64
+		var achievementID string
+		var points int
+		var createdAt time.Time
+		if err := rows.Scan(&fromUserID, &toUserID, &achievementID, &points, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan tie-break vote: %w", err)
+		}
+
+		achievement, ok := models.GetAchievement(achievementID)
+		if !ok || achievement.Weight == 0 {
+			continue
+		}
+		weighted := points * achievement.Weight
+		if !achievement.IsPositive {
+			stats.negativePoints[toUserID] += weighted
+			weighted = -weighted
+		}
+		stats.pairPoints[[2]uint64{fromUserID, toUserID}] += weighted
+		if createdAt.After(stats.lastScoredAt[toUserID]) {
+			stats.lastScoredAt[toUserID] = createdAt
+		}
+	}
+
+	return stats, rows.Err()
+}
+
+// breakTies orders players with the same total score by the rules and marks the deciding rule
+// The players must already be sorted by username, which stays the order of players who are still tied
+func (s *tieBreakStats) breakTies(tied []PlayerRanking, rules []string) {
+	// Head-to-head counts the points received from the other players of the tie
+	headToHead := make(map[uint64]int, len(tied))
+	for _, to := range tied {
+		for _, from := range tied {
+			if from.User.ID != to.User.ID {
+				headToHead[to.User.ID] += s.pairPoints[[2]uint64{from.User.ID, to.User.ID}]
+			}
+		}
+	}
+
+	// compare returns the first rule that ranks a above b (negative) or below b (positive)
+	compare := func(a, b *PlayerRanking) (int, string) {
+		for _, rule := range rules {
+			var c int
+			switch rule {
+			case models.TieBreakEarliest:
+				c = s.lastScoredAt[a.User.ID].Compare(s.lastScoredAt[b.User.ID])
+			case models.TieBreakFewestNegative:
+				c = s.negativePoints[a.User.ID] - s.negativePoints[b.User.ID]
+			case models.TieBreakHeadToHead:
+				c = headToHead[b.User.ID] - headToHead[a.User.ID]
+			}
+			if c != 0 {
+				return c, rule
+			}
+		}
+		return 0, ""
+	}
+
+	sort.SliceStable(tied, func(i, j int) bool {
+		c, _ := compare(&tied[i], &tied[j])
+		return c < 0
+	})
+	for i := 1; i < len(tied); i++ {
+		_, tied[i].TieBreak = compare(&tied[i-1], &tied[i])
+	}
+}
+
 // GetUserRank returns the rank for a specific user
-func (r *VoteRepository) GetUserRank(userID uint64) (*PlayerRanking, error) {
-	rankings, err := r.GetGlobalRanking()
+// Users who opted out of the public ranking still get their own rank
+func (r *VoteRepository) GetUserRank(ctx context.Context, userID uint64, tieBreakers []string) (*PlayerRanking, error) {
+	rankings, err := r.getRanking(ctx, true, time.Time{}, tieBreakers)
 	if err != nil {
 		return nil, err
 	}
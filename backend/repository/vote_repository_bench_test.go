@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// Size of the seeded party: a large LAN party late on the last day
+const (
+	benchUsers = 300
+	benchVotes = 30000
+)
+
+// seedBenchDB creates a migrated SQLite database with the built-in achievements, users and votes
+func seedBenchDB(b *testing.B) {
+	b.Helper()
+	ctx := context.Background()
+
+	initTestDB(b)
+
+	achievementRepo := NewAchievementRepository()
+	if err := achievementRepo.SeedBuiltins(ctx); err != nil {
+		b.Fatal(err)
+	}
+	if err := achievementRepo.Load(ctx); err != nil {
+		b.Fatal(err)
+	}
+	achievements := models.GetAllAchievements()
+
+	// Fixed seed, so every run measures the same data
+	rng := rand.New(rand.NewSource(1))
+	start := time.Date(2024, 12, 31, 18, 0, 0, 0, time.UTC)
+
+	err := database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		for i := 1; i <= benchUsers; i++ {
+			hidden := i%50 == 0
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO users (steam_id, username, avatar_url, avatar_small, profile_url, hide_from_ranking)
+				VALUES (?, ?, '', '', '', ?)`,
+				fmt.Sprintf("7656119800000%04d", i), fmt.Sprintf("Player %d", i), hidden,
+			); err != nil {
+				return fmt.Errorf("failed to seed user: %w", err)
+			}
+		}
+
+		stmt, err := tx.PrepareContext(ctx, `
+			INSERT INTO votes (from_user_id, to_user_id, achievement_id, points, is_secret, is_invalidated, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`)
+		if err != nil {
+			return fmt.Errorf("failed to prepare vote insert: %w", err)
+		}
+		defer stmt.Close()
+
+		for i := 0; i < benchVotes; i++ {
+			from := rng.Intn(benchUsers) + 1
+			to := rng.Intn(benchUsers-1) + 1
+			if to >= from {
+				to++
+			}
+			achievement := achievements[rng.Intn(len(achievements))]
+			createdAt := start.Add(time.Duration(i) * 10 * time.Second)
+			if _, err := stmt.ExecContext(ctx, from, to, achievement.ID, rng.Intn(3)+1,
+				!achievement.IsPositive, i%100 == 0, createdAt); err != nil {
+				return fmt.Errorf("failed to seed vote: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+}
+
+func BenchmarkGetLeaderboard(b *testing.B) {
+	seedBenchDB(b)
+	ctx := context.Background()
+	repo := NewVoteRepository()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.GetLeaderboard(ctx, 3, "", 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetGlobalRanking(b *testing.B) {
+	seedBenchDB(b)
+	ctx := context.Background()
+	repo := NewVoteRepository()
+	tieBreakers := []string{models.TieBreakEarliest, models.TieBreakFewestNegative, models.TieBreakHeadToHead}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.GetGlobalRanking(ctx, tieBreakers); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
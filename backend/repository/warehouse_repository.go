@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+)
+
+// WarehouseRepository reads flat rows for the data warehouse export
+type WarehouseRepository struct{}
+
+// NewWarehouseRepository creates a new warehouse repository
+func NewWarehouseRepository() *WarehouseRepository {
+	return &WarehouseRepository{}
+}
+
+// WarehouseVote is a vote row of the export
+type WarehouseVote struct {
+	ID            uint64
+	FromUserID    *uint64 // nil for secret votes that are not revealed yet
+	ToUserID      uint64
+	AchievementID string
+	Points        int
+	IsSecret      bool
+	IsRevealed    bool
+	IsInvalidated bool
+	AppID         *int
+	CreatedAt     time.Time
+}
+
+// WarehouseChatMessage is a chat message row of the export
+type WarehouseChatMessage struct {
+	ID        uint64
+	UserID    uint64
+	Message   string
+	ReplyToID *uint64
+	IsSystem  bool
+	CreatedAt time.Time
+}
+
+// GetVotes returns all votes including invalidated ones, oldest first
+func (r *WarehouseRepository) GetVotes(ctx context.Context) ([]WarehouseVote, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT id, from_user_id, to_user_id, achievement_id, points, is_secret, is_revealed, is_invalidated, app_id, created_at
+		FROM votes
+		ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get votes for export: %w", err)
+	}
+	defer rows.Close()
+
+	var votes []WarehouseVote
+	for rows.Next() {
+		var v WarehouseVote
+		var fromUserID uint64
+		err := rows.Scan(&v.ID, &fromUserID, &v.ToUserID, &v.AchievementID, &v.Points,
+			&v.IsSecret, &v.IsRevealed, &v.IsInvalidated, &v.AppID, &v.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan vote row: %w", err)
+		}
+		// Secret votes stay secret until the reveal
+		if !v.IsSecret || v.IsRevealed {
+			v.FromUserID = &fromUserID
+		}
+		votes = append(votes, v)
+	}
+
+	return votes, rows.Err()
+}
+
+// GetChatMessages returns all chat messages, oldest first
+func (r *WarehouseRepository) GetChatMessages(ctx context.Context) ([]WarehouseChatMessage, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT id, user_id, message, reply_to_id, COALESCE(is_system, 0), created_at
+		FROM chat_messages
+		ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat messages for export: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []WarehouseChatMessage
+	for rows.Next() {
+		var m WarehouseChatMessage
+		if err := rows.Scan(&m.ID, &m.UserID, &m.Message, &m.ReplyToID, &m.IsSystem, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan chat message row: %w", err)
+		}
+		messages = append(messages, m)
+	}
+
+	return messages, rows.Err()
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// WishlistRepository handles the cached Steam wishlists of the players
+type WishlistRepository struct{}
+
+// NewWishlistRepository creates a new wishlist repository
+func NewWishlistRepository() *WishlistRepository {
+	return &WishlistRepository{}
+}
+
+// ReplaceForUser replaces the cached wishlist of a player
+func (r *WishlistRepository) ReplaceForUser(ctx context.Context, userID uint64, items []models.WishlistItem) error {
+	return database.WithTransactionContext(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM wishlist_items WHERE user_id = ?`, userID); err != nil {
+			return fmt.Errorf("failed to clear wishlist: %w", err)
+		}
+		for _, item := range items {
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO wishlist_items (user_id, app_id, priority, added_at) VALUES (?, ?, ?, ?)`,
+				userID, item.AppID, item.Priority, item.AddedAt,
+			); err != nil {
+				return fmt.Errorf("failed to insert wishlist item: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetAggregated returns all wishlisted games with the players who wishlisted them and the cached store prices
+// Games are ordered by app ID, their players by the position on their wishlist
+func (r *WishlistRepository) GetAggregated(ctx context.Context) ([]models.WishlistGame, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT w.app_id, COALESCE(g.name, ''), COALESCE(g.is_free, 0), COALESCE(g.price_cents, 0),
+			COALESCE(g.original_cents, 0), COALESCE(g.discount_percent, 0), COALESCE(g.price_formatted, ''),
+			u.id, u.steam_id, u.username, u.avatar_url, u.avatar_small, u.profile_url, u.country_code
+		FROM wishlist_items w
+		JOIN users u ON w.user_id = u.id
+		LEFT JOIN game_cache g ON g.app_id = w.app_id
+		ORDER BY w.app_id, w.priority = 0, w.priority, u.username`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wishlists: %w", err)
+	}
+	defer rows.Close()
+
+	var games []models.WishlistGame
+	for rows.Next() {
+		var game models.WishlistGame
+		var user models.PublicUser
+		if err := rows.Scan(
+			&game.AppID, &game.Name, &game.IsFree, &game.PriceCents,
+			&game.OriginalCents, &game.DiscountPercent, &game.PriceFormatted,
+			&user.ID, &user.SteamID, &user.Username, &user.AvatarURL, &user.AvatarSmall, &user.ProfileURL, &user.CountryCode,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan wishlist row: %w", err)
+		}
+		user.Flag = models.CountryFlag(user.CountryCode)
+
+		if n := len(games); n == 0 || games[n-1].AppID != game.AppID {
+			games = append(games, game)
+		}
+		last := &games[len(games)-1]
+		last.Users = append(last.Users, user)
+		last.WishlistCount++
+	}
+
+	return games, nil
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/auth"
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+)
+
+// steamVisibilityPublic is the communityvisibilitystate of a public Steam profile
+const steamVisibilityPublic = 3
+
+// AccountReviewService flags new accounts that look like ban evasion for admin review
+type AccountReviewService struct {
+	cfg        *config.Config
+	steamAPI   *auth.SteamAPIClient
+	reviewRepo *repository.AccountReviewRepository
+}
+
+// NewAccountReviewService creates a new account review service
+func NewAccountReviewService(cfg *config.Config, steamAPI *auth.SteamAPIClient, reviewRepo *repository.AccountReviewRepository) *AccountReviewService {
+	return &AccountReviewService{
+		cfg:        cfg,
+		steamAPI:   steamAPI,
+		reviewRepo: reviewRepo,
+	}
+}
+
+// Check applies the heuristics to a new account and creates a pending review if it is suspicious
+// player is nil if the Steam profile could not be fetched
+// Returns nil if the account may log in
+func (s *AccountReviewService) Check(ctx context.Context, user *models.User, ipAddress string, player *auth.SteamPlayer) (*models.AccountReview, error) {
+	if s.cfg.AccountReviewMinSignals <= 0 {
+		return nil, nil
+	}
+
+	signals, err := s.signals(ctx, user.SteamID, ipAddress, player)
+	if err != nil {
+		return nil, err
+	}
+
+	// A login from the IP of a banned player is enough on its own
+	if len(signals) < s.cfg.AccountReviewMinSignals && !slices.Contains(signals, models.AccountSignalBannedIP) {
+		if len(signals) > 0 {
+			log.Printf("AccountReview: %s (%s) let in with signals %v", user.Username, user.SteamID, signals)
+		}
+		return nil, nil
+	}
+
+	review := &models.AccountReview{
+		UserID:    user.ID,
+		SteamID:   user.SteamID,
+		Username:  user.Username,
+		Reasons:   signals,
+		IPAddress: ipAddress,
+	}
+	if err := s.reviewRepo.Create(ctx, review); err != nil {
+		return nil, err
+	}
+
+	log.Printf("AccountReview: %s (%s) held for admin review, signals %v", user.Username, user.SteamID, signals)
+	return review, nil
+}
+
+// signals collects the suspicious signals of an account
+func (s *AccountReviewService) signals(ctx context.Context, steamID, ipAddress string, player *auth.SteamPlayer) ([]string, error) {
+	var signals []string
+
+	if player != nil {
+		// Creation date and games are only visible on public profiles
+		if player.CommunityVisibilityState != steamVisibilityPublic {
+			signals = append(signals, models.AccountSignalPrivateProfile)
+		} else {
+			if player.TimeCreated > 0 {
+				age := time.Since(time.Unix(player.TimeCreated, 0))
+				if age < time.Duration(s.cfg.AccountReviewMinAgeDays)*24*time.Hour {
+					signals = append(signals, models.AccountSignalYoungAccount)
+				}
+			}
+
+			playtimes, err := s.steamAPI.GetOwnedGamePlaytimes(steamID)
+			switch {
+			case errors.Is(err, auth.ErrSteamProfilePrivate):
+				signals = append(signals, models.AccountSignalPrivateProfile)
+			case err != nil:
+				log.Printf("AccountReview: Failed to get games of %s, skipping game check: %v", steamID, err)
+			case len(playtimes) == 0:
+				signals = append(signals, models.AccountSignalNoGames)
+			}
+		}
+	}
+
+	if ipAddress != "" {
+		banned, err := s.reviewRepo.IsBannedIP(ctx, ipAddress)
+		if err != nil {
+			return nil, err
+		}
+		if banned {
+			signals = append(signals, models.AccountSignalBannedIP)
+		}
+	}
+
+	return signals, nil
+}
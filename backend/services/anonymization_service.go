@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+)
+
+// AnonymizationReport describes what an anonymization run changes (or changed)
+type AnonymizationReport struct {
+	DryRun      bool                                `json:"dry_run"`
+	Scheduled   bool                                `json:"scheduled"`
+	DueAt       *time.Time                          `json:"due_at"`
+	Due         bool                                `json:"due"`
+	Users       []repository.AnonymizationCandidate `json:"users"`
+	Stats       repository.AnonymizationStats       `json:"stats"`
+	AvatarFiles int                                 `json:"avatar_files"`
+	GeneratedAt time.Time                           `json:"generated_at"`
+}
+
+// AnonymizationService anonymizes personal data a configurable time after the event has ended
+// Steam IDs are replaced by a keyed hash, cached avatars are deleted and chat messages and
+// vote comments are scrubbed - votes and aggregate statistics are kept
+type AnonymizationService struct {
+	cfg         *config.Config
+	anonRepo    *repository.AnonymizationRepository
+	avatarCache *AvatarCacheService
+	ticker      *time.Ticker
+	done        chan bool
+}
+
+// NewAnonymizationService creates a new anonymization service
+func NewAnonymizationService(cfg *config.Config, anonRepo *repository.AnonymizationRepository, avatarCache *AvatarCacheService) *AnonymizationService {
+	return &AnonymizationService{
+		cfg:         cfg,
+		anonRepo:    anonRepo,
+		avatarCache: avatarCache,
+		done:        make(chan bool),
+	}
+}
+
+// Start begins the retention watcher
+func (s *AnonymizationService) Start() {
+	// Retention is measured in days, checking every hour is plenty
+	s.ticker = time.NewTicker(1 * time.Hour)
+	go s.watch()
+	log.Println("Anonymization service started")
+}
+
+// Stop stops the retention watcher
+func (s *AnonymizationService) Stop() {
+	if s.ticker != nil {
+		s.ticker.Stop()
+	}
+	s.done <- true
+	log.Println("Anonymization service stopped")
+}
+
+// watch continuously checks if the retention period has passed
+func (s *AnonymizationService) watch() {
+	// Check once right away so a restart after the due date does not wait an hour
+	s.checkRetention(context.Background())
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ticker.C:
+			s.checkRetention(context.Background())
+		}
+	}
+}
+
+// dueAt returns the time at which personal data is anonymized (zero if not scheduled)
+func (s *AnonymizationService) dueAt() time.Time {
+	if s.cfg.EventEndAt.IsZero() || s.cfg.AnonymizeAfterDays < 0 {
+		return time.Time{}
+	}
+	return s.cfg.EventEndAt.AddDate(0, 0, s.cfg.AnonymizeAfterDays)
+}
+
+// checkRetention logs a dry-run report and anonymizes all remaining users once the retention period has passed
+func (s *AnonymizationService) checkRetention(ctx context.Context) {
+	dueAt := s.dueAt()
+	if dueAt.IsZero() || time.Now().Before(dueAt) {
+		return
+	}
+
+	report, err := s.Report(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to create anonymization report: %v", err)
+		return
+	}
+	if report.Stats.Users == 0 {
+		return
+	}
+
+	log.Printf("Retention period ended at %v - anonymizing %d users (%d chat messages, %d vote comments, %d game library entries, %d avatar files)",
+		dueAt, report.Stats.Users, report.Stats.ChatMessages, report.Stats.VoteComments, report.Stats.GameOwnerRows, report.AvatarFiles)
+
+	if _, err := s.Run(ctx); err != nil {
+		log.Printf("Warning: Anonymization failed: %v", err)
+	}
+}
+
+// Report returns a dry-run report of the data the next anonymization run would change
+func (s *AnonymizationService) Report(ctx context.Context) (*AnonymizationReport, error) {
+	candidates, err := s.anonRepo.GetCandidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if candidates == nil {
+		candidates = []repository.AnonymizationCandidate{}
+	}
+
+	stats, err := s.anonRepo.GetStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	avatarFiles := 0
+	for _, c := range candidates {
+		avatarFiles += s.avatarCache.CountAvatars(c.SteamID)
+	}
+
+	report := &AnonymizationReport{
+		DryRun:      true,
+		Users:       candidates,
+		Stats:       *stats,
+		AvatarFiles: avatarFiles,
+		GeneratedAt: time.Now().In(s.cfg.EventLocation),
+	}
+	if dueAt := s.dueAt(); !dueAt.IsZero() {
+		dueAt = dueAt.In(s.cfg.EventLocation)
+		report.Scheduled = true
+		report.DueAt = &dueAt
+		report.Due = !time.Now().Before(dueAt)
+	}
+
+	return report, nil
+}
+
+// Run anonymizes all users that have not been anonymized yet
+// Users that fail are logged and retried on the next run
+func (s *AnonymizationService) Run(ctx context.Context) (*AnonymizationReport, error) {
+	report, err := s.Report(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.DryRun = false
+
+	avatarFiles := 0
+	anonymized := make([]repository.AnonymizationCandidate, 0, len(report.Users))
+	for _, c := range report.Users {
+		if err := s.anonRepo.AnonymizeUser(ctx, c.ID, c.SteamID, s.hashSteamID(c.SteamID)); err != nil {
+			log.Printf("Warning: Failed to anonymize user %d: %v", c.ID, err)
+			continue
+		}
+		avatarFiles += s.avatarCache.DeleteAvatars(c.SteamID)
+		anonymized = append(anonymized, c)
+	}
+
+	report.Users = anonymized
+	report.Stats.Users = len(anonymized)
+	report.AvatarFiles = avatarFiles
+	log.Printf("Anonymized %d users, deleted %d avatar files", len(anonymized), avatarFiles)
+
+	return report, nil
+}
+
+// hashSteamID replaces a Steam ID with a keyed hash
+// The JWT secret is used as key so the small Steam ID space cannot be brute-forced
+func (s *AnonymizationService) hashSteamID(steamID string) string {
+	mac := hmac.New(sha256.New, []byte(s.cfg.JWTSecret))
+	mac.Write([]byte(steamID))
+	// anon_ + 32 hex characters fits into the 50 character steam_id columns
+	return "anon_" + hex.EncodeToString(mac.Sum(nil)[:16])
+}
@@ -182,3 +182,32 @@ func (s *AvatarCacheService) CleanupOldAvatars(steamID string, currentFilename s
 		}
 	}
 }
+
+// CountAvatars returns the number of cached avatar files of a user
+func (s *AvatarCacheService) CountAvatars(steamID string) int {
+	matches, err := filepath.Glob(filepath.Join(s.baseDir, steamID+"_*"))
+	if err != nil {
+		return 0
+	}
+	return len(matches)
+}
+
+// DeleteAvatars removes all cached avatar files of a user
+// Returns the number of deleted files
+func (s *AvatarCacheService) DeleteAvatars(steamID string) int {
+	matches, err := filepath.Glob(filepath.Join(s.baseDir, steamID+"_*"))
+	if err != nil {
+		log.Printf("Failed to find avatars for user %s: %v", steamID, err)
+		return 0
+	}
+
+	deleted := 0
+	for _, match := range matches {
+		if err := os.Remove(match); err != nil {
+			log.Printf("Failed to remove avatar %s: %v", match, err)
+			continue
+		}
+		deleted++
+	}
+	return deleted
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/database"
+)
+
+// backupTimeFormat is the timestamp in backup file names, sortable and safe for file systems
+const backupTimeFormat = "20060102-150405"
+
+// backupNamePattern matches the file names of backups, only these can be downloaded and restored
+var backupNamePattern = regexp.MustCompile(`^rate-your-mate-\d{8}-\d{6}\.db$`)
+
+// ErrBackupNotFound is returned for unknown or invalid backup names
+var ErrBackupNotFound = errors.New("backup not found")
+
+// BackupInfo describes a backup snapshot in the backup directory
+type BackupInfo struct {
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BackupService periodically writes snapshots of the SQLite database to the backup directory
+// and deletes the oldest ones beyond the retention
+// Anonymization does not touch existing backups, they keep the personal data until they are pruned.
+// A restored backup is anonymized again on the next start if the retention period has already ended.
+type BackupService struct {
+	cfg    *config.Config
+	mu     sync.Mutex // Serializes backups and restores
+	ticker *time.Ticker
+	done   chan bool
+}
+
+// NewBackupService creates a new backup service
+func NewBackupService(cfg *config.Config) *BackupService {
+	return &BackupService{
+		cfg:  cfg,
+		done: make(chan bool),
+	}
+}
+
+// Start begins the scheduled backups, they are disabled without interval and for MySQL
+func (s *BackupService) Start() {
+	if s.cfg.BackupIntervalMinutes <= 0 || database.IsMySQL() {
+		log.Println("Scheduled backups disabled")
+		return
+	}
+
+	interval := time.Duration(s.cfg.BackupIntervalMinutes) * time.Minute
+	s.ticker = time.NewTicker(interval)
+	go s.watch()
+	log.Printf("Backup service started (every %v to %s, keeping %d)", interval, s.cfg.BackupDir, s.cfg.BackupRetention)
+}
+
+// Stop stops the scheduled backups
+func (s *BackupService) Stop() {
+	if s.ticker == nil {
+		return
+	}
+	s.ticker.Stop()
+	s.done <- true
+	log.Println("Backup service stopped")
+}
+
+// watch backs up on every tick, the first backup is written one interval after the start
+func (s *BackupService) watch() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ticker.C:
+			if _, err := s.Create(context.Background()); err != nil {
+				log.Printf("Warning: Scheduled backup failed: %v", err)
+			}
+		}
+	}
+}
+
+// Create writes a new backup and deletes the oldest ones beyond the retention
+func (s *BackupService) Create(ctx context.Context) (*BackupInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.cfg.BackupDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	name := "rate-your-mate-" + time.Now().UTC().Format(backupTimeFormat) + ".db"
+	path := filepath.Join(s.cfg.BackupDir, name)
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("backup %s already exists", name)
+	}
+	if err := database.BackupSQLite(ctx, path); err != nil {
+		// VACUUM INTO may leave a partial file behind
+		os.Remove(path)
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	log.Printf("Database backup written: %s (%d bytes)", name, info.Size())
+
+	s.prune()
+	return &BackupInfo{Name: name, Size: info.Size(), CreatedAt: info.ModTime().UTC()}, nil
+}
+
+// List returns all backups, newest first
+func (s *BackupService) List() ([]BackupInfo, error) {
+	entries, err := os.ReadDir(s.cfg.BackupDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []BackupInfo{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	backups := []BackupInfo{}
+	for _, entry := range entries {
+		if entry.IsDir() || !backupNamePattern.MatchString(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, BackupInfo{Name: entry.Name(), Size: info.Size(), CreatedAt: info.ModTime().UTC()})
+	}
+
+	// Names contain the UTC timestamp, so they sort by age
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Name > backups[j].Name
+	})
+	return backups, nil
+}
+
+// Path returns the file path of a backup
+func (s *BackupService) Path(name string) (string, error) {
+	if !backupNamePattern.MatchString(name) {
+		return "", ErrBackupNotFound
+	}
+	path := filepath.Join(s.cfg.BackupDir, name)
+	if _, err := os.Stat(path); err != nil {
+		return "", ErrBackupNotFound
+	}
+	return path, nil
+}
+
+// Restore stages a backup that replaces the database on the next start of the backend
+// The replaced database is kept next to it, so a restore can be undone by hand
+func (s *BackupService) Restore(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path, err := s.Path(name)
+	if err != nil {
+		return err
+	}
+
+	if err := database.StageRestore(s.cfg.DBPath, path); err != nil {
+		return err
+	}
+	log.Printf("Backup %s staged for restore on the next start", name)
+	return nil
+}
+
+// prune deletes the oldest backups beyond the retention (caller holds the lock)
+func (s *BackupService) prune() {
+	if s.cfg.BackupRetention <= 0 {
+		return
+	}
+
+	backups, err := s.List()
+	if err != nil {
+		log.Printf("Warning: Failed to list backups for cleanup: %v", err)
+		return
+	}
+	for _, b := range backups[min(s.cfg.BackupRetention, len(backups)):] {
+		if err := os.Remove(filepath.Join(s.cfg.BackupDir, b.Name)); err != nil {
+			log.Printf("Warning: Failed to delete old backup %s: %v", b.Name, err)
+			continue
+		}
+		log.Printf("Deleted old backup %s", b.Name)
+	}
+}
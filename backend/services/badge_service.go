@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/websocket"
+)
+
+// BadgeService awards meta-badges once a user's statistics reach their thresholds
+// Badges are permanent, they are not revoked when votes are invalidated or deleted
+type BadgeService struct {
+	badgeRepo *repository.BadgeRepository
+	userRepo  *repository.UserRepository
+	wsHub     *websocket.Hub
+}
+
+// NewBadgeService creates a new badge service
+func NewBadgeService(badgeRepo *repository.BadgeRepository, userRepo *repository.UserRepository, wsHub *websocket.Hub) *BadgeService {
+	return &BadgeService{
+		badgeRepo: badgeRepo,
+		userRepo:  userRepo,
+		wsHub:     wsHub,
+	}
+}
+
+// Evaluate checks the badge thresholds of the given users and announces newly awarded badges
+func (s *BadgeService) Evaluate(ctx context.Context, userIDs ...uint64) {
+	for _, userID := range userIDs {
+		s.evaluateUser(ctx, userID)
+	}
+}
+
+// evaluateUser awards all badges whose threshold the user has reached
+func (s *BadgeService) evaluateUser(ctx context.Context, userID uint64) {
+	stats, err := s.badgeRepo.GetStats(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to get badge stats of user %d: %v", userID, err)
+		return
+	}
+
+	for _, badge := range models.Badges {
+		if stats[badge.Stat] < badge.Threshold {
+			continue
+		}
+
+		awarded, err := s.badgeRepo.Award(ctx, userID, badge.ID)
+		if err != nil {
+			log.Printf("Failed to award badge %s to user %d: %v", badge.ID, userID, err)
+			continue
+		}
+		if !awarded {
+			continue
+		}
+
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil || user == nil {
+			log.Printf("Failed to load user %d for badge announcement: %v", userID, err)
+			continue
+		}
+
+		log.Printf("User %s earned badge %s", user.Username, badge.ID)
+
+		if s.wsHub != nil {
+			s.wsHub.BroadcastBadgeAwarded(&websocket.BadgeAwardedPayload{
+				UserID:   user.ID,
+				Username: user.Username,
+				Avatar:   user.AvatarSmall,
+				Badge:    badge,
+			})
+		}
+	}
+}
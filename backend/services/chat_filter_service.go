@@ -0,0 +1,159 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/defaults"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+)
+
+// chatFilterWordPattern finds the words of a chat message
+var chatFilterWordPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// chatFilterEntryPattern validates blocklist entries: one word, optionally with a trailing *
+var chatFilterEntryPattern = regexp.MustCompile(`^[\p{L}\p{N}]{2,100}\*?$`)
+
+// ChatFilterService masks or rejects chat messages containing blocked words
+// The blocklist is kept in memory and reloaded whenever admins change it
+type ChatFilterService struct {
+	cfg  *config.Config
+	repo *repository.ChatFilterRepository
+
+	mu       sync.RWMutex
+	words    map[string]bool // Exact matches, lower case
+	prefixes []string        // Entries with a trailing *, lower case without the *
+}
+
+// NewChatFilterService creates a new chat filter service
+func NewChatFilterService(cfg *config.Config, repo *repository.ChatFilterRepository) *ChatFilterService {
+	return &ChatFilterService{
+		cfg:   cfg,
+		repo:  repo,
+		words: make(map[string]bool),
+	}
+}
+
+// Load reads the blocklist from the database
+func (s *ChatFilterService) Load(ctx context.Context) error {
+	entries, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	words := make(map[string]bool, len(entries))
+	var prefixes []string
+	for _, e := range entries {
+		if prefix, ok := strings.CutSuffix(e.Word, "*"); ok {
+			prefixes = append(prefixes, prefix)
+		} else {
+			words[e.Word] = true
+		}
+	}
+
+	s.mu.Lock()
+	s.words = words
+	s.prefixes = prefixes
+	s.mu.Unlock()
+
+	log.Printf("ChatFilter: Loaded %d blocked words (mode: %s)", len(entries), s.cfg.ChatFilterMode)
+	return nil
+}
+
+// Filter checks a message against the blocklist
+// Returns the message with blocked words masked and whether a blocked word was found
+func (s *ChatFilterService) Filter(message string) (string, bool) {
+	if s.cfg.ChatFilterMode == models.ChatFilterOff {
+		return message, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.words) == 0 && len(s.prefixes) == 0 {
+		return message, false
+	}
+
+	found := false
+	masked := chatFilterWordPattern.ReplaceAllStringFunc(message, func(word string) string {
+		if !s.isBlocked(strings.ToLower(word)) {
+			return word
+		}
+		found = true
+		return strings.Repeat("*", utf8.RuneCountInString(word))
+	})
+	return masked, found
+}
+
+// isBlocked checks a lower case word, the caller must hold mu
+func (s *ChatFilterService) isBlocked(word string) bool {
+	if s.words[word] {
+		return true
+	}
+	for _, prefix := range s.prefixes {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeBlockedWord lower-cases a blocklist entry and checks that it is a single word
+func NormalizeBlockedWord(word string) (string, error) {
+	word = strings.ToLower(strings.TrimSpace(word))
+	if !chatFilterEntryPattern.MatchString(word) {
+		return "", errors.New("blocked words must be a single word of 2-100 letters or digits, optionally ending with *")
+	}
+	return word, nil
+}
+
+// Presets returns the languages of the built-in blocklist presets
+func (s *ChatFilterService) Presets() []string {
+	files, err := fs.Glob(defaults.FS, "chatfilter/*.txt")
+	if err != nil {
+		return []string{}
+	}
+
+	languages := make([]string, 0, len(files))
+	for _, file := range files {
+		languages = append(languages, strings.TrimSuffix(path.Base(file), ".txt"))
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Preset returns the words of a built-in blocklist preset, nil if there is no preset for the language
+func (s *ChatFilterService) Preset(language string) ([]string, error) {
+	data, err := fs.ReadFile(defaults.FS, "chatfilter/"+language+".txt")
+	if err != nil {
+		return nil, nil
+	}
+
+	var words []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		word, err := NormalizeBlockedWord(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid entry %q in %s preset: %w", line, language, err)
+		}
+		words = append(words, word)
+	}
+	return words, scanner.Err()
+}
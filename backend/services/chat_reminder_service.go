@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/websocket"
+)
+
+// ChatReminderService posts scheduled chat reminders as system messages when they are due
+type ChatReminderService struct {
+	wsHub        *websocket.Hub
+	reminderRepo *repository.ChatReminderRepository
+	chatRepo     *repository.ChatRepository
+	ticker       *time.Ticker
+	done         chan bool
+}
+
+// NewChatReminderService creates a new chat reminder service
+func NewChatReminderService(wsHub *websocket.Hub, reminderRepo *repository.ChatReminderRepository, chatRepo *repository.ChatRepository) *ChatReminderService {
+	return &ChatReminderService{
+		wsHub:        wsHub,
+		reminderRepo: reminderRepo,
+		chatRepo:     chatRepo,
+		done:         make(chan bool),
+	}
+}
+
+// Start begins the reminder watcher
+func (s *ChatReminderService) Start() {
+	// Reminders are scheduled to the minute, checking every few seconds is precise enough
+	s.ticker = time.NewTicker(5 * time.Second)
+	go s.watch()
+	log.Println("Chat reminder service started")
+}
+
+// Stop stops the reminder watcher
+func (s *ChatReminderService) Stop() {
+	if s.ticker != nil {
+		s.ticker.Stop()
+	}
+	s.done <- true
+	log.Println("Chat reminder service stopped")
+}
+
+// watch continuously checks for due reminders
+func (s *ChatReminderService) watch() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ticker.C:
+			s.deliverDue(context.Background())
+		}
+	}
+}
+
+// deliverDue posts all due reminders and broadcasts them like normal chat messages
+func (s *ChatReminderService) deliverDue(ctx context.Context) {
+	reminders, err := s.reminderRepo.GetDue(ctx, time.Now())
+	if err != nil {
+		log.Printf("Warning: Failed to get due chat reminders: %v", err)
+		return
+	}
+
+	for i := range reminders {
+		reminder := &reminders[i]
+
+		messageID, err := s.reminderRepo.Deliver(ctx, reminder)
+		if err != nil {
+			// Keep the reminder pending so the next tick retries
+			log.Printf("Warning: Failed to deliver chat reminder %d: %v", reminder.ID, err)
+			continue
+		}
+		if messageID == 0 {
+			continue
+		}
+
+		msg, err := s.chatRepo.GetByID(ctx, messageID)
+		if err != nil || msg == nil {
+			log.Printf("Warning: Failed to load chat reminder message %d: %v", messageID, err)
+			continue
+		}
+
+		log.Printf("Chat reminder %d posted as message %d", reminder.ID, messageID)
+		s.wsHub.BroadcastChatMessage(&websocket.ChatMessagePayload{
+			ID:           msg.ID,
+			Username:     msg.User.Username,
+			Message:      msg.Message,
+			Achievements: msg.Achievements,
+			IsSystem:     true,
+			CreatedAt:    msg.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+}
@@ -1,33 +1,58 @@
 package services
 
 import (
+	"context"
 	"log"
 	"time"
 
 	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
 	"github.com/guided-traffic/rate-your-mate/backend/repository"
 	"github.com/guided-traffic/rate-your-mate/backend/websocket"
 )
 
 // CountdownService handles countdown expiration and automatic voting pause lift
 type CountdownService struct {
-	cfg      *config.Config
-	wsHub    *websocket.Hub
-	userRepo *repository.UserRepository
-	ticker   *time.Ticker
-	done     chan bool
+	cfg       *config.Config
+	wsHub     *websocket.Hub
+	userRepo  *repository.UserRepository
+	timerRepo *repository.TimerRepository
+	ticker    *time.Ticker
+	done      chan bool
 }
 
 // NewCountdownService creates a new countdown service
-func NewCountdownService(cfg *config.Config, wsHub *websocket.Hub, userRepo *repository.UserRepository) *CountdownService {
+func NewCountdownService(cfg *config.Config, wsHub *websocket.Hub, userRepo *repository.UserRepository, timerRepo *repository.TimerRepository) *CountdownService {
 	return &CountdownService{
-		cfg:      cfg,
-		wsHub:    wsHub,
-		userRepo: userRepo,
-		done:     make(chan bool),
+		cfg:       cfg,
+		wsHub:     wsHub,
+		userRepo:  userRepo,
+		timerRepo: timerRepo,
+		done:      make(chan bool),
 	}
 }
 
+// Restore loads the persisted countdown, it takes precedence over COUNTDOWN_TARGET
+// A countdown that expired while the server was down fires immediately
+func (s *CountdownService) Restore(ctx context.Context) {
+	target, err := s.timerRepo.Get(ctx, models.TimerCountdown)
+	if err != nil {
+		log.Printf("Warning: Failed to restore countdown: %v", err)
+		return
+	}
+	if target.IsZero() {
+		return
+	}
+
+	s.cfg.CountdownTarget = target
+	if time.Now().After(target) {
+		log.Printf("Countdown expired at %v while the server was down - firing it now", target)
+		s.checkCountdown(ctx)
+		return
+	}
+	log.Printf("Restored countdown to %v", target)
+}
+
 // Start begins the countdown watcher
 func (s *CountdownService) Start() {
 	// Check every second for countdown expiration
@@ -52,13 +77,13 @@ func (s *CountdownService) watch() {
 		case <-s.done:
 			return
 		case <-s.ticker.C:
-			s.checkCountdown()
+			s.checkCountdown(context.Background())
 		}
 	}
 }
 
 // checkCountdown checks if the countdown has expired and lifts voting pause
-func (s *CountdownService) checkCountdown() {
+func (s *CountdownService) checkCountdown(ctx context.Context) {
 	// Skip if no countdown is set
 	if s.cfg.CountdownTarget.IsZero() {
 		return
@@ -79,7 +104,7 @@ func (s *CountdownService) checkCountdown() {
 				log.Printf("Automatically resumed voting after %v pause (countdown expired)", pauseDuration)
 
 				// Shift all users' last_credit_at forward by the pause duration
-				if err := s.userRepo.ShiftAllLastCreditAt(pauseDuration); err != nil {
+				if err := s.userRepo.ShiftAllLastCreditAt(ctx, pauseDuration); err != nil {
 					log.Printf("Warning: Failed to shift last_credit_at times: %v", err)
 				} else {
 					log.Printf("Shifted all users' last_credit_at forward by %v", pauseDuration)
@@ -97,11 +122,15 @@ func (s *CountdownService) checkCountdown() {
 				VoteVisibilityMode:     s.cfg.VoteVisibilityMode,
 				NegativeVotingDisabled: s.cfg.NegativeVotingDisabled,
 				CountdownTarget:        nil, // Countdown has expired
+				ChatSlowModeSeconds:    s.cfg.ChatSlowModeSeconds,
 			})
 		}
 
 		// Clear the countdown target
 		s.cfg.CountdownTarget = time.Time{}
+		if err := s.timerRepo.Clear(ctx, models.TimerCountdown); err != nil {
+			log.Printf("Warning: Failed to clear persisted countdown: %v", err)
+		}
 		log.Println("Countdown target cleared")
 	}
 }
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+	"sync"
 	"time"
 
 	"github.com/guided-traffic/rate-your-mate/backend/config"
@@ -12,20 +14,32 @@ import (
 type CreditService struct {
 	cfg      *config.Config
 	userRepo *repository.UserRepository
+
+	// Recent negative votes per sender, target and achievement for the repeat vote escalation
+	repeatMu    sync.Mutex
+	repeatVotes map[repeatVoteKey][]time.Time
+}
+
+// repeatVoteKey identifies the votes of one sender for the same achievement on the same target
+type repeatVoteKey struct {
+	fromUserID    uint64
+	toUserID      uint64
+	achievementID string
 }
 
 // NewCreditService creates a new credit service
 func NewCreditService(cfg *config.Config, userRepo *repository.UserRepository) *CreditService {
 	return &CreditService{
-		cfg:      cfg,
-		userRepo: userRepo,
+		cfg:         cfg,
+		userRepo:    userRepo,
+		repeatVotes: make(map[repeatVoteKey][]time.Time),
 	}
 }
 
 // CalculateAndUpdateCredits calculates new credits based on time elapsed and updates the user
 // Returns the updated credit count
 // Note: When voting is paused, no new credits are generated
-func (s *CreditService) CalculateAndUpdateCredits(user *models.User) (int, error) {
+func (s *CreditService) CalculateAndUpdateCredits(ctx context.Context, user *models.User) (int, error) {
 	// If voting is paused, don't generate new credits
 	if s.cfg.VotingPaused {
 		return user.Credits, nil
@@ -64,7 +78,7 @@ func (s *CreditService) CalculateAndUpdateCredits(user *models.User) (int, error
 		}
 
 		// Update in database
-		if err := s.userRepo.UpdateCredits(user.ID, totalCredits, newLastCreditAt); err != nil {
+		if err := s.userRepo.UpdateCredits(ctx, user.ID, totalCredits, newLastCreditAt); err != nil {
 			return user.Credits, err
 		}
 
@@ -110,11 +124,106 @@ func (s *CreditService) CanAffordVoteWithPoints(user *models.User, points int) b
 }
 
 // DeductVoteCost deducts the cost of a vote from the user's credits
-func (s *CreditService) DeductVoteCost(userID uint64) error {
-	return s.userRepo.DeductCredit(userID)
+func (s *CreditService) DeductVoteCost(ctx context.Context, userID uint64) error {
+	return s.userRepo.DeductCredit(ctx, userID)
+}
+
+// GrantBonusCredits gives a user bonus credits (e.g. for a vote streak), respecting the credit maximum
+func (s *CreditService) GrantBonusCredits(ctx context.Context, userID uint64, amount int) error {
+	return s.userRepo.AddCredits(ctx, userID, amount, s.cfg.CreditMax)
 }
 
-// DeductVoteCostWithPoints deducts multiple credits for a vote with points
-func (s *CreditService) DeductVoteCostWithPoints(userID uint64, points int) error {
-	return s.userRepo.DeductCredits(userID, points)
+// VoteCostMultiplier returns the credit multiplier of a vote
+// Repeating a negative achievement on the same target within RepeatVoteEscalationMinutes
+// costs progressively more (2x, 3x, ...) up to RepeatVoteEscalationMax, other votes cost 1x
+func (s *CreditService) VoteCostMultiplier(fromUserID, toUserID uint64, achievement models.Achievement) int {
+	if s.cfg.RepeatVoteEscalationMinutes <= 0 || achievement.IsPositive {
+		return 1
+	}
+
+	key := repeatVoteKey{fromUserID: fromUserID, toUserID: toUserID, achievementID: achievement.ID}
+
+	s.repeatMu.Lock()
+	defer s.repeatMu.Unlock()
+
+	s.pruneRepeatVotes(time.Now())
+	multiplier := len(s.repeatVotes[key]) + 1
+	if s.cfg.RepeatVoteEscalationMax > 0 && multiplier > s.cfg.RepeatVoteEscalationMax {
+		multiplier = s.cfg.RepeatVoteEscalationMax
+	}
+	return multiplier
+}
+
+// RecordVote counts a cast vote for the repeat vote escalation
+func (s *CreditService) RecordVote(fromUserID, toUserID uint64, achievement models.Achievement) {
+	if s.cfg.RepeatVoteEscalationMinutes <= 0 || achievement.IsPositive {
+		return
+	}
+
+	key := repeatVoteKey{fromUserID: fromUserID, toUserID: toUserID, achievementID: achievement.ID}
+
+	s.repeatMu.Lock()
+	defer s.repeatMu.Unlock()
+
+	s.repeatVotes[key] = append(s.repeatVotes[key], time.Now())
+}
+
+// RepeatVoteEscalation is a sender, target and achievement combination that currently costs extra
+type RepeatVoteEscalation struct {
+	ToUserID      uint64    `json:"to_user_id"`
+	AchievementID string    `json:"achievement_id"`
+	Multiplier    int       `json:"multiplier"` // Multiplier of the next vote
+	ResetsAt      time.Time `json:"resets_at"`  // The multiplier is back to 1x after this time
+}
+
+// RepeatVoteEscalations returns the escalated combinations of a sender, their next vote costs more than the points
+func (s *CreditService) RepeatVoteEscalations(fromUserID uint64) []RepeatVoteEscalation {
+	if s.cfg.RepeatVoteEscalationMinutes <= 0 {
+		return nil
+	}
+	window := time.Duration(s.cfg.RepeatVoteEscalationMinutes) * time.Minute
+
+	s.repeatMu.Lock()
+	defer s.repeatMu.Unlock()
+
+	s.pruneRepeatVotes(time.Now())
+
+	var escalations []RepeatVoteEscalation
+	for key, times := range s.repeatVotes {
+		if key.fromUserID != fromUserID {
+			continue
+		}
+		multiplier := len(times) + 1
+		if s.cfg.RepeatVoteEscalationMax > 0 && multiplier > s.cfg.RepeatVoteEscalationMax {
+			multiplier = s.cfg.RepeatVoteEscalationMax
+		}
+		if multiplier <= 1 {
+			continue
+		}
+		escalations = append(escalations, RepeatVoteEscalation{
+			ToUserID:      key.toUserID,
+			AchievementID: key.achievementID,
+			Multiplier:    multiplier,
+			ResetsAt:      times[len(times)-1].Add(window),
+		})
+	}
+	return escalations
+}
+
+// pruneRepeatVotes drops votes that left the escalation window, the caller must hold repeatMu
+func (s *CreditService) pruneRepeatVotes(now time.Time) {
+	cutoff := now.Add(-time.Duration(s.cfg.RepeatVoteEscalationMinutes) * time.Minute)
+	for key, times := range s.repeatVotes {
+		kept := times[:0]
+		for _, t := range times {
+			if t.After(cutoff) {
+				kept = append(kept, t)
+			}
+		}
+		if len(kept) == 0 {
+			delete(s.repeatVotes, key)
+		} else {
+			s.repeatVotes[key] = kept
+		}
+	}
 }
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/websocket"
+)
+
+// DownloadReminderService reminds players with missing downloads before the event starts
+// Reminders are sent DownloadReminderMinutes before the countdown target
+type DownloadReminderService struct {
+	cfg          *config.Config
+	wsHub        *websocket.Hub
+	downloadRepo *repository.DownloadRepository
+	ticker       *time.Ticker
+	done         chan bool
+
+	mu   sync.Mutex
+	sent map[time.Time]map[int]bool // Reminder offsets already sent per countdown target
+}
+
+// NewDownloadReminderService creates a new download reminder service
+func NewDownloadReminderService(cfg *config.Config, wsHub *websocket.Hub, downloadRepo *repository.DownloadRepository) *DownloadReminderService {
+	return &DownloadReminderService{
+		cfg:          cfg,
+		wsHub:        wsHub,
+		downloadRepo: downloadRepo,
+		done:         make(chan bool),
+		sent:         make(map[time.Time]map[int]bool),
+	}
+}
+
+// Start begins the reminder watcher
+func (s *DownloadReminderService) Start() {
+	s.ticker = time.NewTicker(30 * time.Second)
+	go s.watch()
+	log.Println("Download reminder service started")
+}
+
+// Stop stops the reminder watcher
+func (s *DownloadReminderService) Stop() {
+	if s.ticker != nil {
+		s.ticker.Stop()
+	}
+	s.done <- true
+	log.Println("Download reminder service stopped")
+}
+
+// watch continuously checks whether a reminder is due
+func (s *DownloadReminderService) watch() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ticker.C:
+			s.checkReminders(context.Background())
+		}
+	}
+}
+
+// checkReminders sends each configured reminder once per countdown target
+// Reminders whose time passed while the server was down are sent once, but never after the event started
+func (s *DownloadReminderService) checkReminders(ctx context.Context) {
+	target := s.cfg.CountdownTarget
+	now := time.Now()
+	if target.IsZero() || !now.Before(target) {
+		return
+	}
+
+	s.mu.Lock()
+	if s.sent[target] == nil {
+		s.sent[target] = make(map[int]bool)
+	}
+	due := false
+	for _, minutes := range s.cfg.DownloadReminderMinutes {
+		if s.sent[target][minutes] {
+			continue
+		}
+		if !now.Before(target.Add(-time.Duration(minutes) * time.Minute)) {
+			s.sent[target][minutes] = true
+			due = true
+		}
+	}
+	s.mu.Unlock()
+
+	if !due {
+		return
+	}
+
+	notified, err := s.RemindAll(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to send download reminders: %v", err)
+		return
+	}
+	log.Printf("Download reminder sent to %d players with missing downloads", notified)
+}
+
+// RemindAll notifies every player with unconfirmed downloads and returns how many were notified
+// Only connected players receive the reminder, the others see the checklist on their next visit
+func (s *DownloadReminderService) RemindAll(ctx context.Context) (int, error) {
+	readiness, err := s.downloadRepo.GetReadiness(ctx, 0, true)
+	if err != nil {
+		return 0, err
+	}
+
+	missing := make(map[uint64][]models.DownloadRequirement)
+	for _, r := range readiness {
+		for _, user := range r.MissingUsers {
+			missing[user.ID] = append(missing[user.ID], r.DownloadRequirement)
+		}
+	}
+
+	var eventStartsAt *string
+	if !s.cfg.CountdownTarget.IsZero() {
+		formatted := s.cfg.CountdownTarget.In(s.cfg.EventLocation).Format(time.RFC3339)
+		eventStartsAt = &formatted
+	}
+
+	notified := 0
+	for userID, requirements := range missing {
+		if !s.wsHub.IsUserConnected(userID) {
+			continue
+		}
+		s.wsHub.NotifyDownloadReminder(userID, &websocket.DownloadReminderPayload{
+			Missing:       requirements,
+			EventStartsAt: eventStartsAt,
+		})
+		notified++
+	}
+	return notified, nil
+}
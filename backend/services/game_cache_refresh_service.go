@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+)
+
+// GameCacheRefreshService refreshes the oldest stale game cache entries in small batches,
+// so the games list stays current without a full sync during the party
+type GameCacheRefreshService struct {
+	cfg         *config.Config
+	gameService *GameService
+	ticker      *time.Ticker
+	done        chan bool
+}
+
+// NewGameCacheRefreshService creates a new game cache refresh service
+func NewGameCacheRefreshService(cfg *config.Config, gameService *GameService) *GameCacheRefreshService {
+	return &GameCacheRefreshService{
+		cfg:         cfg,
+		gameService: gameService,
+		done:        make(chan bool),
+	}
+}
+
+// Start begins the periodic refresh, runs outside CACHE_REFRESH_WINDOW are skipped
+func (s *GameCacheRefreshService) Start() {
+	if s.cfg.CacheRefreshBatchSize <= 0 {
+		log.Println("Game cache refresh disabled")
+		return
+	}
+
+	interval := time.Duration(s.cfg.CacheRefreshMinutes) * time.Minute
+	s.ticker = time.NewTicker(interval)
+	go s.watch()
+	log.Printf("Game cache refresh started (%d games every %v, window %q %s)", s.cfg.CacheRefreshBatchSize, interval, s.cfg.CacheRefreshWindow, s.cfg.EventTimezone)
+}
+
+// Stop stops the periodic refresh
+func (s *GameCacheRefreshService) Stop() {
+	if s.ticker == nil {
+		return
+	}
+	s.ticker.Stop()
+	s.done <- true
+	log.Println("Game cache refresh stopped")
+}
+
+// watch refreshes a batch on every tick
+func (s *GameCacheRefreshService) watch() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ticker.C:
+			s.refresh(context.Background())
+		}
+	}
+}
+
+// refresh refreshes the oldest stale games if now is within the refresh window
+func (s *GameCacheRefreshService) refresh(ctx context.Context) {
+	if !s.inWindow(time.Now().In(s.cfg.EventLocation)) {
+		return
+	}
+
+	refreshed, err := s.gameService.RefreshStaleGames(ctx, s.cfg.CacheRefreshBatchSize)
+	if err != nil {
+		log.Printf("Warning: Failed to refresh stale games: %v", err)
+		return
+	}
+	if refreshed > 0 {
+		log.Printf("Game cache refresh: %d stale games refreshed", refreshed)
+	}
+}
+
+// inWindow checks whether the time of day of now is within CACHE_REFRESH_WINDOW
+func (s *GameCacheRefreshService) inWindow(now time.Time) bool {
+	start, end, _ := config.ParseClockWindow(s.cfg.CacheRefreshWindow) // Validated by config.Load
+	if start == end {
+		return true
+	}
+
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	offset := now.Sub(midnight)
+	if start < end {
+		return offset >= start && offset < end
+	}
+	// Window over midnight, e.g. 23:00-05:00
+	return offset >= start || offset < end
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/websocket"
+)
+
+// GameDealService watches the prices of pinned and commonly owned games for discounts and free weekends
+// Prices are refreshed in batched store requests before every check, the daily game sync is too slow for sales
+type GameDealService struct {
+	cfg         *config.Config
+	wsHub       *websocket.Hub
+	gameService *GameService
+	ticker      *time.Ticker
+	done        chan bool
+
+	mu        sync.RWMutex
+	deals     map[int]models.GameDeal // appID -> active deal
+	paid      map[int]bool            // Games seen with a regular price, becoming free means a free weekend
+	updatedAt time.Time
+}
+
+// NewGameDealService creates a new game deal service
+func NewGameDealService(cfg *config.Config, wsHub *websocket.Hub, gameService *GameService) *GameDealService {
+	return &GameDealService{
+		cfg:         cfg,
+		wsHub:       wsHub,
+		gameService: gameService,
+		done:        make(chan bool),
+		deals:       make(map[int]models.GameDeal),
+		paid:        make(map[int]bool),
+	}
+}
+
+// Start begins checking for deals periodically, the first check runs in the background right away
+func (s *GameDealService) Start() {
+	if !s.cfg.GameDealsEnabled {
+		log.Println("Game deal service disabled")
+		return
+	}
+
+	interval := time.Duration(s.cfg.GameDealsCheckMinutes) * time.Minute
+	if interval < time.Minute {
+		interval = time.Minute
+	}
+	s.ticker = time.NewTicker(interval)
+	go s.watch()
+	log.Printf("Game deal service started (check every %v)", interval)
+}
+
+// Stop stops checking for deals
+func (s *GameDealService) Stop() {
+	if s.ticker == nil {
+		return
+	}
+	s.ticker.Stop()
+	s.done <- true
+	log.Println("Game deal service stopped")
+}
+
+// watch checks for deals on start and on every tick
+func (s *GameDealService) watch() {
+	s.check(context.Background())
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ticker.C:
+			s.check(context.Background())
+		}
+	}
+}
+
+// GetDeals returns the active deals, free games first, then by discount
+func (s *GameDealService) GetDeals() ([]models.GameDeal, time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]models.GameDeal, 0, len(s.deals))
+	for _, deal := range s.deals {
+		result = append(result, deal)
+	}
+	sortDeals(result)
+	return result, s.updatedAt
+}
+
+// watchedGames returns pinned games and games owned by at least GameDealsMinOwners players
+func (s *GameDealService) watchedGames(ctx context.Context) ([]models.Game, error) {
+	games, _, err := s.gameService.GetMultiplayerGamesCached(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.Game, 0, len(games.PinnedGames))
+	result = append(result, games.PinnedGames...)
+
+	// All games are sorted by owner count
+	for _, game := range games.AllGames {
+		if game.OwnerCount < s.cfg.GameDealsMinOwners {
+			break
+		}
+		result = append(result, game)
+	}
+	return result, nil
+}
+
+// check refreshes the prices of the watched games, compares them with the known deals and broadcasts new or deeper deals
+func (s *GameDealService) check(ctx context.Context) {
+	games, err := s.watchedGames(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to get games for deals: %v", err)
+		return
+	}
+
+	// A failed refresh leaves the cached prices, the deals are still checked against them
+	appIDs := make([]int, len(games))
+	for i, game := range games {
+		appIDs[i] = game.AppID
+	}
+	updated, err := s.gameService.RefreshPrices(ctx, appIDs)
+	if err != nil {
+		log.Printf("Warning: Failed to refresh prices for deals: %v", err)
+	}
+	if updated > 0 {
+		if games, err = s.watchedGames(ctx); err != nil {
+			log.Printf("Warning: Failed to get games for deals: %v", err)
+			return
+		}
+	}
+
+	now := time.Now()
+
+	s.mu.Lock()
+	// The first check only fills the state, running sales are listed but not announced
+	firstCheck := s.updatedAt.IsZero()
+	deals := make(map[int]models.GameDeal)
+	var newDeals []models.GameDeal
+	for _, game := range games {
+		kind := ""
+		switch {
+		case game.DiscountPercent >= 100 || (game.IsFree && s.paid[game.AppID]):
+			kind = models.GameDealFree
+		case game.DiscountPercent > 0:
+			kind = models.GameDealDiscount
+		}
+		if !game.IsFree && game.OriginalCents > 0 {
+			s.paid[game.AppID] = true
+		}
+		if kind == "" {
+			continue
+		}
+
+		deal := models.GameDeal{
+			AppID:           game.AppID,
+			Name:            game.Name,
+			HeaderImageURL:  game.HeaderImageURL,
+			OwnerCount:      game.OwnerCount,
+			IsPinned:        game.IsPinned,
+			Kind:            kind,
+			DiscountPercent: game.DiscountPercent,
+			PriceCents:      game.PriceCents,
+			OriginalCents:   game.OriginalCents,
+			PriceFormatted:  game.PriceFormatted,
+			DetectedAt:      now,
+		}
+
+		// A running deal is only announced again when it became free or the discount got deeper
+		previous, known := s.deals[game.AppID]
+		becameFree := kind == models.GameDealFree && previous.Kind != models.GameDealFree
+		deeper := deal.DiscountPercent > previous.DiscountPercent
+		if known && !becameFree && !deeper {
+			deal.DetectedAt = previous.DetectedAt
+		} else if !firstCheck {
+			newDeals = append(newDeals, deal)
+		}
+		deals[game.AppID] = deal
+	}
+	s.deals = deals
+	s.updatedAt = now
+	s.mu.Unlock()
+
+	log.Printf("Game deals checked: %d active deals for %d games, %d new", len(deals), len(games), len(newDeals))
+
+	if len(newDeals) > 0 {
+		sortDeals(newDeals)
+		s.wsHub.BroadcastGameDeal(&websocket.GameDealPayload{
+			Deals: newDeals,
+		})
+	}
+}
+
+// sortDeals sorts free games first, then by discount and owner count
+func sortDeals(deals []models.GameDeal) {
+	sort.Slice(deals, func(i, j int) bool {
+		if deals[i].Kind != deals[j].Kind {
+			return deals[i].Kind == models.GameDealFree
+		}
+		if deals[i].DiscountPercent != deals[j].DiscountPercent {
+			return deals[i].DiscountPercent > deals[j].DiscountPercent
+		}
+		return deals[i].OwnerCount > deals[j].OwnerCount
+	})
+}
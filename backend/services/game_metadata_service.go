@@ -5,6 +5,8 @@ import (
 	"log"
 	"os"
 	"sync"
+
+	"github.com/guided-traffic/rate-your-mate/backend/defaults"
 )
 
 // GameMetadata contains manually curated metadata for a game
@@ -37,12 +39,13 @@ func (s *GameMetadataService) loadMetadata() {
 	defer s.mu.Unlock()
 
 	data, err := os.ReadFile(s.filePath)
+	if os.IsNotExist(err) {
+		// Fall back to the metadata embedded into the binary
+		log.Printf("Game metadata file not found at %s, using embedded metadata", s.filePath)
+		data, err = defaults.FS.ReadFile("game_metadata.json")
+	}
 	if err != nil {
-		if os.IsNotExist(err) {
-			log.Printf("Game metadata file not found at %s, using empty metadata", s.filePath)
-		} else {
-			log.Printf("Error reading game metadata file: %v", err)
-		}
+		log.Printf("Error reading game metadata file: %v", err)
 		return
 	}
 
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/metrics"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/websocket"
+)
+
+const (
+	// News items fetched per game and call
+	gameNewsPerGame = 5
+	// Maximum length of the news contents returned by Steam
+	gameNewsMaxLength = 300
+)
+
+// GameNewsService periodically fetches Steam news of the most commonly owned games
+type GameNewsService struct {
+	cfg         *config.Config
+	wsHub       *websocket.Hub
+	gameService *GameService
+	httpClient  *http.Client
+	ticker      *time.Ticker
+	done        chan bool
+
+	mu        sync.RWMutex
+	items     []models.GameNewsItem
+	seen      map[string]bool
+	updatedAt time.Time
+}
+
+// NewGameNewsService creates a new game news service
+func NewGameNewsService(cfg *config.Config, wsHub *websocket.Hub, gameService *GameService) *GameNewsService {
+	return &GameNewsService{
+		cfg:         cfg,
+		wsHub:       wsHub,
+		gameService: gameService,
+		httpClient: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+		done:  make(chan bool),
+		items: []models.GameNewsItem{},
+		seen:  make(map[string]bool),
+	}
+}
+
+// Start begins fetching news periodically, the first fetch runs in the background right away
+func (s *GameNewsService) Start() {
+	if !s.cfg.GameNewsEnabled {
+		log.Println("Game news service disabled")
+		return
+	}
+
+	interval := time.Duration(s.cfg.GameNewsRefreshMinutes) * time.Minute
+	if interval < time.Minute {
+		interval = time.Minute
+	}
+	s.ticker = time.NewTicker(interval)
+	go s.watch()
+	log.Printf("Game news service started (refresh every %v)", interval)
+}
+
+// Stop stops fetching news
+func (s *GameNewsService) Stop() {
+	if s.ticker == nil {
+		return
+	}
+	s.ticker.Stop()
+	s.done <- true
+	log.Println("Game news service stopped")
+}
+
+// watch fetches news on start and on every tick
+func (s *GameNewsService) watch() {
+	s.refresh(context.Background())
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ticker.C:
+			s.refresh(context.Background())
+		}
+	}
+}
+
+// GetNews returns the cached news, newest first, optionally filtered by app ID (0 = all games)
+func (s *GameNewsService) GetNews(appID int) ([]models.GameNewsItem, time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]models.GameNewsItem, 0, len(s.items))
+	for _, item := range s.items {
+		if appID == 0 || item.AppID == appID {
+			result = append(result, item)
+		}
+	}
+	return result, s.updatedAt
+}
+
+// newsGames returns the games to fetch news for: pinned games and the most commonly owned ones
+func (s *GameNewsService) newsGames(ctx context.Context) ([]models.Game, error) {
+	games, _, err := s.gameService.GetMultiplayerGamesCached(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.Game, 0, len(games.PinnedGames)+s.cfg.GameNewsTopGames)
+	result = append(result, games.PinnedGames...)
+
+	// All games are sorted by owner count, a game owned by a single player is not common
+	for i, game := range games.AllGames {
+		if i >= s.cfg.GameNewsTopGames || game.OwnerCount < 2 {
+			break
+		}
+		result = append(result, game)
+	}
+	return result, nil
+}
+
+// refresh fetches the news of all news games and broadcasts items not seen before
+func (s *GameNewsService) refresh(ctx context.Context) {
+	games, err := s.newsGames(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to get games for news: %v", err)
+		return
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -s.cfg.GameNewsMaxAgeDays)
+	var items []models.GameNewsItem
+	for _, game := range games {
+		gameItems, err := s.fetchNewsForApp(game)
+		if err != nil {
+			// Keep going, news of the other games are still useful
+			log.Printf("Warning: Failed to fetch news for %s (%d): %v", game.Name, game.AppID, err)
+			continue
+		}
+		for _, item := range gameItems {
+			if item.Date.After(cutoff) {
+				items = append(items, item)
+			}
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Date.After(items[j].Date)
+	})
+
+	s.mu.Lock()
+	// The first fetch only fills the cache, announcing week-old news on startup would be noise
+	firstFetch := s.updatedAt.IsZero()
+	var newItems []models.GameNewsItem
+	for _, item := range items {
+		if !s.seen[item.GID] {
+			s.seen[item.GID] = true
+			if !firstFetch {
+				newItems = append(newItems, item)
+			}
+		}
+	}
+	if items == nil {
+		items = []models.GameNewsItem{}
+	}
+	s.items = items
+	s.updatedAt = time.Now()
+	s.mu.Unlock()
+
+	log.Printf("Game news refreshed: %d items for %d games, %d new", len(items), len(games), len(newItems))
+
+	if len(newItems) > 0 {
+		s.wsHub.BroadcastGameNews(&websocket.GameNewsPayload{
+			Items: newItems,
+		})
+	}
+}
+
+// steamNewsResponse represents the response of ISteamNews/GetNewsForApp
+type steamNewsResponse struct {
+	AppNews struct {
+		AppID     int `json:"appid"`
+		NewsItems []struct {
+			GID       string   `json:"gid"`
+			Title     string   `json:"title"`
+			URL       string   `json:"url"`
+			Author    string   `json:"author"`
+			Contents  string   `json:"contents"`
+			FeedLabel string   `json:"feedlabel"`
+			Date      int64    `json:"date"`
+			Tags      []string `json:"tags"`
+		} `json:"newsitems"`
+	} `json:"appnews"`
+}
+
+// fetchNewsForApp fetches the latest news of a game from the Steam API
+func (s *GameNewsService) fetchNewsForApp(game models.Game) ([]models.GameNewsItem, error) {
+	url := fmt.Sprintf(
+		"%s/ISteamNews/GetNewsForApp/v2/?appid=%d&count=%d&maxlength=%d&format=json",
+		steamAPIBaseURL,
+		game.AppID,
+		gameNewsPerGame,
+		gameNewsMaxLength,
+	)
+
+	log.Printf("[STEAM API] GET /ISteamNews/GetNewsForApp/v2 - Fetching news for app: %d", game.AppID)
+	start := time.Now()
+	resp, err := s.httpClient.Get(url)
+	duration := time.Since(start)
+	if err != nil {
+		metrics.SteamAPIErrors.Inc()
+		log.Printf("[STEAM API] ERROR - GetNewsForApp failed for app %d after %v: %v", game.AppID, duration, err)
+		return nil, fmt.Errorf("failed to call Steam API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		metrics.SteamAPIErrors.Inc()
+		log.Printf("[STEAM API] ERROR - GetNewsForApp returned status %d for app %d after %v", resp.StatusCode, game.AppID, duration)
+		return nil, fmt.Errorf("Steam API returned status %d", resp.StatusCode)
+	}
+
+	var apiResp steamNewsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		metrics.SteamAPIErrors.Inc()
+		log.Printf("[STEAM API] ERROR - Failed to parse GetNewsForApp response for app %d: %v", game.AppID, err)
+		return nil, fmt.Errorf("failed to parse Steam API response: %w", err)
+	}
+
+	log.Printf("[STEAM API] OK - GetNewsForApp returned %d items for app %d in %v", len(apiResp.AppNews.NewsItems), game.AppID, duration)
+
+	items := make([]models.GameNewsItem, 0, len(apiResp.AppNews.NewsItems))
+	for _, n := range apiResp.AppNews.NewsItems {
+		items = append(items, models.GameNewsItem{
+			GID:          n.GID,
+			AppID:        game.AppID,
+			GameName:     game.Name,
+			OwnerCount:   game.OwnerCount,
+			Title:        n.Title,
+			URL:          n.URL,
+			Author:       n.Author,
+			Contents:     n.Contents,
+			FeedLabel:    n.FeedLabel,
+			IsPatchNotes: containsString(n.Tags, "patchnotes"),
+			Date:         time.Unix(n.Date, 0).UTC(),
+		})
+	}
+	return items, nil
+}
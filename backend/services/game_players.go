@@ -0,0 +1,58 @@
+package services
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+)
+
+// Player counts outside this range are most likely not about a single session (e.g. MMO populations)
+const (
+	minParsedPlayers = 2
+	maxParsedPlayers = 128
+)
+
+var (
+	htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+	// Patterns whose first capture group is a player count, e.g. "up to 8 players", "4-player co-op", "1-16 players"
+	playerCountPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\bup\s+to\s+(\d{1,3})\s+(?:players|people|friends)`),
+		regexp.MustCompile(`(?i)\bbis\s+zu\s+(\d{1,3})\s+(?:spieler|personen|freunde)`),
+		regexp.MustCompile(`(?i)\b\d{1,3}\s*(?:-|–|to)\s*(\d{1,3})\s*-?\s*players?\b`),
+		regexp.MustCompile(`(?i)\b(\d{1,3})\s*-?\s*players?\b`),
+		regexp.MustCompile(`(?i)\b(\d{1,3})\s*-?\s*spieler\b`),
+	}
+
+	// Team sizes like "5v5" or "16 vs 16", the player count is the sum of both teams
+	teamSizePattern = regexp.MustCompile(`(?i)\b(\d{1,2})\s*(?:v|vs\.?)\s*(\d{1,2})\b`)
+)
+
+// parseMaxPlayers extracts the largest plausible player count from Steam Store description texts
+// Returns 0 if no player count is mentioned
+func parseMaxPlayers(texts ...string) int {
+	best := 0
+	consider := func(n int) {
+		if n >= minParsedPlayers && n <= maxParsedPlayers && n > best {
+			best = n
+		}
+	}
+
+	for _, text := range texts {
+		text = html.UnescapeString(htmlTagPattern.ReplaceAllString(text, " "))
+
+		for _, pattern := range playerCountPatterns {
+			for _, match := range pattern.FindAllStringSubmatch(text, -1) {
+				n, _ := strconv.Atoi(match[1])
+				consider(n)
+			}
+		}
+		for _, match := range teamSizePattern.FindAllStringSubmatch(text, -1) {
+			a, _ := strconv.Atoi(match[1])
+			b, _ := strconv.Atoi(match[2])
+			consider(a + b)
+		}
+	}
+
+	return best
+}
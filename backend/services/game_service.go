@@ -1,16 +1,22 @@
 package services
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/metrics"
 	"github.com/guided-traffic/rate-your-mate/backend/models"
 	"github.com/guided-traffic/rate-your-mate/backend/repository"
 )
@@ -19,11 +25,21 @@ const (
 	steamAPIBaseURL   = "https://api.steampowered.com"
 	steamStoreBaseURL = "https://store.steampowered.com/api"
 	steamCDNBaseURL   = "https://steamcdn-a.akamaihd.net/steam/apps"
+	steamSpyBaseURL   = "https://steamspy.com/api.php"
+
+	// SteamSpy allows one request per second
+	steamSpyRequestsPerMinute = 60
+
+	// Number of user tags stored per game
+	maxGameTags = 10
 
 	// Cache settings
-	gameCacheMaxAge       = 24 * time.Hour  // Refresh game data after 24 hours
-	failedFetchRetryDelay = 24 * time.Hour  // Wait 24 hours before retrying failed fetches (e.g., removed games)
-	rateLimitPausePeriod  = 5 * time.Minute // Pause for 5 minutes after 429 error
+	gameCacheMaxAge       = 24 * time.Hour // Refresh game data after 24 hours
+	failedFetchRetryDelay = 24 * time.Hour // Wait 24 hours before retrying failed fetches (e.g., removed games)
+
+	// Pause after a 429 response without Retry-After, doubled for every further 429 in a row
+	rateLimitBasePause = 30 * time.Second
+	rateLimitMaxPause  = 30 * time.Minute
 )
 
 // SyncProgressCallback is called to report sync progress
@@ -37,22 +53,37 @@ type GameService struct {
 	gameOwnerRepo       *repository.GameOwnerRepository
 	imageCacheService   *ImageCacheService
 	gameMetadataService *GameMetadataService
+	timerRepo           *repository.TimerRepository
+	checkpointRepo      *repository.SyncCheckpointRepository
+	gameRatingRepo      *repository.GameRatingRepository
+	pinnedGameRepo      *repository.PinnedGameRepository
 	httpClient          *http.Client
+	storeLimiter        *tokenBucket // Shared by all Steam Store requests
+	steamSpyLimiter     *tokenBucket
 	cache               *gamesCache
 	rateLimiter         *rateLimiter
 	syncProgress        *syncProgress
+	pinned              *pinnedGames
+
+	// ctx is canceled by Stop, background syncs and their Steam requests end with it
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // syncProgress tracks background sync status
 type syncProgress struct {
 	mu        sync.RWMutex
 	isSyncing bool
+	waiting   bool // An interrupted batch waits for the rate limit pause to end
 	phase     string
 	current   string
 	processed int
 	total     int
 }
 
+// syncPhaseRateLimited is reported while an interrupted sync waits for the rate limit pause to end
+const syncPhaseRateLimited = "rate_limited"
+
 // gamesCache caches the full response to avoid rebuilding it constantly
 type gamesCache struct {
 	mu        sync.RWMutex
@@ -65,10 +96,12 @@ type rateLimiter struct {
 	mu          sync.RWMutex
 	pausedUntil time.Time
 	isPaused    bool
+	strikes     int // 429 responses without a successful request in between
 }
 
 // NewGameService creates a new game service
-func NewGameService(cfg *config.Config, userRepo *repository.UserRepository, gameCacheRepo *repository.GameCacheRepository, gameOwnerRepo *repository.GameOwnerRepository, imageCacheService *ImageCacheService, gameMetadataService *GameMetadataService) *GameService {
+func NewGameService(cfg *config.Config, userRepo *repository.UserRepository, gameCacheRepo *repository.GameCacheRepository, gameOwnerRepo *repository.GameOwnerRepository, imageCacheService *ImageCacheService, gameMetadataService *GameMetadataService, timerRepo *repository.TimerRepository, checkpointRepo *repository.SyncCheckpointRepository, gameRatingRepo *repository.GameRatingRepository, pinnedGameRepo *repository.PinnedGameRepository) *GameService {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &GameService{
 		cfg:                 cfg,
 		userRepo:            userRepo,
@@ -76,39 +109,54 @@ func NewGameService(cfg *config.Config, userRepo *repository.UserRepository, gam
 		gameOwnerRepo:       gameOwnerRepo,
 		imageCacheService:   imageCacheService,
 		gameMetadataService: gameMetadataService,
+		timerRepo:           timerRepo,
+		checkpointRepo:      checkpointRepo,
+		gameRatingRepo:      gameRatingRepo,
+		pinnedGameRepo:      pinnedGameRepo,
 		httpClient: &http.Client{
 			Timeout: 15 * time.Second,
 		},
-		cache:        &gamesCache{},
-		rateLimiter:  &rateLimiter{},
-		syncProgress: &syncProgress{},
+		storeLimiter:    newTokenBucket(cfg.SteamStoreRequestsPerMinute, cfg.SteamSyncConcurrency),
+		steamSpyLimiter: newTokenBucket(steamSpyRequestsPerMinute, 1),
+		cache:           &gamesCache{},
+		rateLimiter:     &rateLimiter{},
+		syncProgress:    &syncProgress{},
+		pinned:          &pinnedGames{appIDs: cfg.PinnedGameIDs},
+		ctx:             ctx,
+		cancel:          cancel,
 	}
 }
 
-// GetMultiplayerGames returns all multiplayer games owned by registered players
-func (s *GameService) GetMultiplayerGames() (*models.GamesResponse, error) {
-	// Check in-memory cache first (5 minute TTL for response assembly)
-	s.cache.mu.RLock()
-	if s.cache.games != nil && time.Now().Before(s.cache.expiresAt) {
-		cached := s.cache.games
-		s.cache.mu.RUnlock()
-		return cached, nil
+// Stop cancels running Steam requests and background syncs, an interrupted sync batch keeps its checkpoint
+func (s *GameService) Stop() {
+	s.cancel()
+	log.Println("GameService: Stopped, running Steam requests canceled")
+}
+
+// withStop returns a context that is canceled with ctx or when the service stops
+func (s *GameService) withStop(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(s.ctx, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
 	}
-	s.cache.mu.RUnlock()
+}
 
-	// Fetch fresh data
-	games, err := s.fetchMultiplayerGames()
+// get sends a GET request that is canceled with ctx
+func (s *GameService) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
+	return s.httpClient.Do(req)
+}
 
-	// Update in-memory cache
-	s.cache.mu.Lock()
-	s.cache.games = games
-	s.cache.expiresAt = time.Now().Add(5 * time.Minute)
-	s.cache.mu.Unlock()
-
-	return games, nil
+// GetMultiplayerGames returns all multiplayer games owned by registered players
+// The list is built from game_owners and game_cache only, Steam is contacted by the sync
+func (s *GameService) GetMultiplayerGames(ctx context.Context) (*models.GamesResponse, error) {
+	games, _, err := s.GetMultiplayerGamesCached(ctx)
+	return games, err
 }
 
 // InvalidateCache clears the in-memory games cache (DB cache remains)
@@ -126,228 +174,164 @@ func (s *GameService) isRateLimited() bool {
 	return s.rateLimiter.isPaused && time.Now().Before(s.rateLimiter.pausedUntil)
 }
 
-// setRateLimited sets the rate limit pause
-func (s *GameService) setRateLimited() {
-	s.rateLimiter.mu.Lock()
-	defer s.rateLimiter.mu.Unlock()
-	s.rateLimiter.isPaused = true
-	s.rateLimiter.pausedUntil = time.Now().Add(rateLimitPausePeriod)
-	log.Printf("Steam API rate limited - pausing requests for %v", rateLimitPausePeriod)
-}
+// setRateLimited pauses all Steam Store requests after a 429 response and slows down the request rate
+// The pause honors Retry-After, without it the pause doubles with every 429 in a row
+// The pause is persisted, so a restarted instance doesn't hit the rate limit right away
+func (s *GameService) setRateLimited(ctx context.Context, retryAfter time.Duration) {
+	s.storeLimiter.SlowDown()
 
-// fetchMultiplayerGames fetches all games from all users and filters for multiplayer
-func (s *GameService) fetchMultiplayerGames() (*models.GamesResponse, error) {
-	// Get all registered users
-	users, err := s.userRepo.GetAll()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get users: %w", err)
+	s.rateLimiter.mu.Lock()
+	// Concurrent workers hitting the same limit count as a single 429
+	if s.rateLimiter.isPaused && time.Now().Before(s.rateLimiter.pausedUntil) {
+		s.rateLimiter.mu.Unlock()
+		return
 	}
-
-	if len(users) == 0 {
-		return &models.GamesResponse{
-			PinnedGames: []models.Game{},
-			AllGames:    []models.Game{},
-		}, nil
+	pause := retryAfter
+	if pause <= 0 {
+		pause = rateLimitBasePause << min(s.rateLimiter.strikes, 10)
 	}
+	pause = min(pause, rateLimitMaxPause)
+	pausedUntil := time.Now().Add(pause)
+	s.rateLimiter.strikes++
+	s.rateLimiter.isPaused = true
+	s.rateLimiter.pausedUntil = pausedUntil
+	strikes := s.rateLimiter.strikes
+	s.rateLimiter.mu.Unlock()
+	log.Printf("Steam API rate limited - pausing requests for %v (429 #%d in a row, %d requests/min)", pause, strikes, s.storeLimiter.PerMinute())
 
-	// Collect all games from all users
-	gameMap := make(map[int]*models.Game) // appID -> game
-	var mu sync.Mutex
-	var wg sync.WaitGroup
+	if err := s.timerRepo.Set(ctx, models.TimerSteamRateLimit, pausedUntil); err != nil {
+		log.Printf("Warning: Failed to persist Steam rate limit pause: %v", err)
+	}
+}
 
-	for _, user := range users {
-		wg.Add(1)
-		go func(steamID string) {
-			defer wg.Done()
+// clearRateLimitStrikes resets the backoff after a successful Steam Store request and speeds the requests up again
+func (s *GameService) clearRateLimitStrikes() {
+	s.storeLimiter.SpeedUp()
 
-			games, err := s.fetchUserGames(steamID)
-			if err != nil {
-				log.Printf("Failed to fetch games for user %s: %v", steamID, err)
-				return
-			}
+	s.rateLimiter.mu.Lock()
+	s.rateLimiter.strikes = 0
+	s.rateLimiter.mu.Unlock()
+}
 
-			mu.Lock()
-			for _, g := range games {
-				if existing, ok := gameMap[g.AppID]; ok {
-					// Game already exists, add this owner
-					existing.OwnerCount++
-					existing.Owners = append(existing.Owners, steamID)
-					// Keep the higher playtime
-					if g.PlaytimeForever > existing.PlaytimeForever {
-						existing.PlaytimeForever = g.PlaytimeForever
-					}
-				} else {
-					// New game - try to load from DB cache first
-					game := &models.Game{
-						AppID:           g.AppID,
-						Name:            g.Name,
-						HeaderImageURL:  s.imageCacheService.GetLocalImageURL(g.AppID),
-						CapsuleImageURL: fmt.Sprintf("%s/%d/capsule_231x87.jpg", steamCDNBaseURL, g.AppID),
-						PlaytimeForever: g.PlaytimeForever,
-						OwnerCount:      1,
-						Owners:          []string{steamID},
-						Categories:      []string{},
-					}
-
-					// Note: Image caching is deferred until after multiplayer filtering
-
-					// Try to load categories and price from DB cache
-					cached, err := s.gameCacheRepo.GetByAppID(g.AppID)
-					if err == nil && cached != nil && !cached.IsStale(gameCacheMaxAge) {
-						game.Categories = cached.GetCategories()
-						if cached.Name != "" {
-							game.Name = cached.Name
-						}
-						game.IsFree = cached.IsFree
-						game.PriceCents = cached.PriceCents
-						game.OriginalCents = cached.OriginalCents
-						game.DiscountPercent = cached.DiscountPercent
-						game.PriceFormatted = cached.PriceFormatted
-						game.ReviewScore = cached.ReviewScore
-					}
-
-					gameMap[g.AppID] = game
-				}
-			}
-			mu.Unlock()
-		}(user.SteamID)
+// GetRateLimitStatus returns the current state of the Steam Store rate limiter
+func (s *GameService) GetRateLimitStatus() models.SteamRateLimitStatus {
+	status := models.SteamRateLimitStatus{
+		RequestsPerMinute: s.storeLimiter.PerMinute(),
 	}
 
-	wg.Wait()
+	s.rateLimiter.mu.RLock()
+	defer s.rateLimiter.mu.RUnlock()
+	status.Strikes = s.rateLimiter.strikes
+	if s.rateLimiter.isPaused && time.Now().Before(s.rateLimiter.pausedUntil) {
+		pausedUntil := s.rateLimiter.pausedUntil
+		status.Paused = true
+		status.PausedUntil = &pausedUntil
+	}
+	return status
+}
 
-	// Identify games that need their categories fetched from Steam Store API
-	var gamesToFetch []*models.Game
-	for _, game := range gameMap {
-		if len(game.Categories) == 0 {
-			// Check DB cache again (might have been populated by another goroutine)
-			cached, err := s.gameCacheRepo.GetByAppID(game.AppID)
-			if err == nil && cached != nil && !cached.IsStale(gameCacheMaxAge) {
-				// Check if this was a failed fetch that we should skip
-				if cached.FetchFailed {
-					// Skip games that failed to fetch and are still within retry delay
-					if !cached.IsStale(failedFetchRetryDelay) {
-						log.Printf("Skipping unavailable game %s (%d) - will retry after %v", game.Name, game.AppID, failedFetchRetryDelay)
-						continue
-					}
-					// Retry delay expired, try fetching again
-					gamesToFetch = append(gamesToFetch, game)
-				} else {
-					game.Categories = cached.GetCategories()
-					if cached.Name != "" {
-						game.Name = cached.Name
-					}
-					game.IsFree = cached.IsFree
-					game.PriceCents = cached.PriceCents
-					game.OriginalCents = cached.OriginalCents
-					game.DiscountPercent = cached.DiscountPercent
-					game.PriceFormatted = cached.PriceFormatted
-					game.ReviewScore = cached.ReviewScore
-				}
-			} else {
-				gamesToFetch = append(gamesToFetch, game)
-			}
-		}
+// parseRetryAfter reads a Retry-After header given in seconds or as HTTP date, zero if missing or invalid
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
 	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return 0
+}
 
-	// Sort by owner count to prioritize popular games
-	sort.Slice(gamesToFetch, func(i, j int) bool {
-		return gamesToFetch[i].OwnerCount > gamesToFetch[j].OwnerCount
-	})
+// rateLimitRemaining returns how long the rate limit pause lasts, zero if not paused
+func (s *GameService) rateLimitRemaining() time.Duration {
+	s.rateLimiter.mu.RLock()
+	defer s.rateLimiter.mu.RUnlock()
+	if !s.rateLimiter.isPaused {
+		return 0
+	}
+	if remaining := time.Until(s.rateLimiter.pausedUntil); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
 
-	// Fetch categories from Steam Store API (with rate limiting and DB caching)
-	s.fetchGameCategories(gamesToFetch)
+// Restore loads the persisted rate limit pause and resumes a sync that was interrupted by a restart
+func (s *GameService) Restore(ctx context.Context, progressCallback SyncProgressCallback) {
+	pausedUntil, err := s.timerRepo.Get(ctx, models.TimerSteamRateLimit)
+	if err != nil {
+		log.Printf("Warning: Failed to load Steam rate limit pause: %v", err)
+	} else if time.Now().Before(pausedUntil) {
+		s.rateLimiter.mu.Lock()
+		s.rateLimiter.isPaused = true
+		s.rateLimiter.pausedUntil = pausedUntil
+		s.rateLimiter.mu.Unlock()
+		log.Printf("GameService: Restored Steam rate limit pause until %v", pausedUntil.Local())
+	}
 
-	// Filter for multiplayer games and build response
-	var allGames []models.Game
-	pinnedGameIDs := s.cfg.PinnedGameIDs
+	checkpoint, err := s.checkpointRepo.Get(ctx, models.SyncCheckpointSteamGames)
+	if err != nil {
+		log.Printf("Warning: Failed to load sync checkpoint: %v", err)
+		return
+	}
+	if checkpoint == nil {
+		return
+	}
 
-	for _, game := range gameMap {
-		if game.HasMultiplayerCategory() {
-			// Only cache images for multiplayer games (after filtering)
-			s.imageCacheService.CacheImageAsync(game.AppID)
+	log.Printf("GameService: Resuming interrupted sync batch %s after app %d (%d/%d games)", checkpoint.BatchID, checkpoint.LastAppID, checkpoint.Processed, checkpoint.Total)
+	s.setWaitingProgress(checkpoint.Processed, checkpoint.Total)
+	s.resumeSync(progressCallback)
+}
 
-			// Check if pinned
-			for _, pinnedID := range pinnedGameIDs {
-				if pinnedID == game.AppID {
-					game.IsPinned = true
-					break
-				}
-			}
-			allGames = append(allGames, *game)
-		}
+// resumeSync continues the sync right away or after the rate limit pause
+func (s *GameService) resumeSync(progressCallback SyncProgressCallback) {
+	remaining := s.rateLimitRemaining()
+	if remaining == 0 {
+		go s.TriggerSyncIfNeeded(progressCallback)
+		return
 	}
 
-	// Sort all games by owner count (most owners first), then by name
-	sort.Slice(allGames, func(i, j int) bool {
-		if allGames[i].OwnerCount != allGames[j].OwnerCount {
-			return allGames[i].OwnerCount > allGames[j].OwnerCount
+	log.Printf("GameService: Sync continues when the rate limit pause ends in %v", remaining.Round(time.Second))
+	time.AfterFunc(remaining, func() {
+		if s.ctx.Err() != nil {
+			return
 		}
-		return allGames[i].Name < allGames[j].Name
+		s.TriggerSyncIfNeeded(progressCallback)
 	})
+}
 
-	// Separate pinned games
-	var pinnedGames []models.Game
-	var unpinnedGames []models.Game
-
-	for _, game := range allGames {
-		if game.IsPinned {
-			pinnedGames = append(pinnedGames, game)
-		} else {
-			unpinnedGames = append(unpinnedGames, game)
-		}
+// saveCheckpoint stores the sync position, failures are logged since the sync can go on without it
+func (s *GameService) saveCheckpoint(ctx context.Context, batchID string, lastAppID, processed, total int) {
+	err := s.checkpointRepo.Save(ctx, &models.SyncCheckpoint{
+		Name:      models.SyncCheckpointSteamGames,
+		BatchID:   batchID,
+		LastAppID: lastAppID,
+		Processed: processed,
+		Total:     total,
+	})
+	if err != nil {
+		log.Printf("Warning: Failed to save sync checkpoint: %v", err)
 	}
+}
 
-	// Also add pinned games that might not be in any user's library
-	for _, pinnedID := range pinnedGameIDs {
-		found := false
-		for _, g := range pinnedGames {
-			if g.AppID == pinnedID {
-				found = true
-				break
-			}
-		}
-		if !found {
-			// Fetch this pinned game's details
-			game, err := s.fetchGameDetails(pinnedID)
-			if err != nil {
-				log.Printf("Failed to fetch pinned game %d: %v", pinnedID, err)
-				continue
-			}
-			if game != nil {
-				game.IsPinned = true
-				pinnedGames = append(pinnedGames, *game)
-			}
-		}
+// newSyncBatchID returns a random ID for a new sync batch
+func newSyncBatchID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
 	}
-
-	// Sort pinned games by their order in the config (not alphabetically)
-	sort.Slice(pinnedGames, func(i, j int) bool {
-		// Find index of each game in pinnedGameIDs
-		indexI := -1
-		indexJ := -1
-		for idx, id := range pinnedGameIDs {
-			if id == pinnedGames[i].AppID {
-				indexI = idx
-			}
-			if id == pinnedGames[j].AppID {
-				indexJ = idx
-			}
-		}
-		return indexI < indexJ
-	})
-
-	// Enrich games with custom metadata (e.g., max_players)
-	s.enrichGamesWithMetadata(pinnedGames)
-	s.enrichGamesWithMetadata(unpinnedGames)
-
-	return &models.GamesResponse{
-		PinnedGames: pinnedGames,
-		AllGames:    unpinnedGames,
-	}, nil
+	return hex.EncodeToString(buf)
 }
 
-// enrichGamesWithMetadata adds custom metadata to games
+// enrichGamesWithMetadata adds launch links, co-op modes and custom metadata to games
+// Curated max players override the count parsed from the store description
 func (s *GameService) enrichGamesWithMetadata(games []models.Game) {
+	for i := range games {
+		games[i].LaunchURL = models.SteamLaunchURL(games[i].AppID)
+		games[i].CoopModes = models.CoopModesFromCategories(games[i].Categories)
+	}
 	if s.gameMetadataService == nil {
 		return
 	}
@@ -372,7 +356,7 @@ type ownedGamesResponse struct {
 }
 
 // fetchUserGames fetches all games owned by a user
-func (s *GameService) fetchUserGames(steamID string) ([]models.GameOwnership, error) {
+func (s *GameService) fetchUserGames(ctx context.Context, steamID string) ([]models.GameOwnership, error) {
 	// Skip fake users (used for development/testing)
 	if strings.HasPrefix(steamID, "FAKE_") {
 		return []models.GameOwnership{}, nil
@@ -391,21 +375,27 @@ func (s *GameService) fetchUserGames(steamID string) ([]models.GameOwnership, er
 
 	log.Printf("[STEAM API] GET /IPlayerService/GetOwnedGames/v1 - Fetching games for user: %s", steamID)
 	start := time.Now()
-	resp, err := s.httpClient.Get(url)
+	resp, err := s.get(ctx, url)
 	duration := time.Since(start)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		metrics.SteamAPIErrors.Inc()
 		log.Printf("[STEAM API] ERROR - GetOwnedGames failed for user %s after %v: %v", steamID, duration, err)
 		return nil, fmt.Errorf("failed to call Steam API: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		metrics.SteamAPIErrors.Inc()
 		log.Printf("[STEAM API] ERROR - GetOwnedGames returned status %d for user %s after %v", resp.StatusCode, steamID, duration)
 		return nil, fmt.Errorf("Steam API returned status %d", resp.StatusCode)
 	}
 
 	var apiResp ownedGamesResponse
 	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		metrics.SteamAPIErrors.Inc()
 		log.Printf("[STEAM API] ERROR - Failed to parse GetOwnedGames response for user %s: %v", steamID, err)
 		return nil, fmt.Errorf("failed to parse Steam API response: %w", err)
 	}
@@ -434,7 +424,7 @@ func (s *GameService) fetchUserGames(steamID string) ([]models.GameOwnership, er
 
 	// Persist ownership data to database
 	if len(gamesToSave) > 0 {
-		if err := s.gameOwnerRepo.UpsertBatch(steamID, gamesToSave); err != nil {
+		if err := s.gameOwnerRepo.UpsertBatch(ctx, steamID, gamesToSave); err != nil {
 			log.Printf("Warning: Failed to persist game ownership for user %s: %v", steamID, err)
 			// Don't fail the whole operation, just log the warning
 		}
@@ -444,14 +434,17 @@ func (s *GameService) fetchUserGames(steamID string) ([]models.GameOwnership, er
 }
 
 // RefreshUserGames fetches and updates the games for a specific user from Steam API
-// Returns error if the user is on cooldown (can only refresh every 5 minutes)
-func (s *GameService) RefreshUserGames(steamID string) (int, error) {
+// The Steam request is canceled when ctx (usually the HTTP request) ends or the service stops
+func (s *GameService) RefreshUserGames(ctx context.Context, steamID string) (int, error) {
 	log.Printf("[GameRefresh] Refreshing games for user %s", steamID)
 
+	ctx, cancel := s.withStop(ctx)
+	defer cancel()
+
 	// Fetch games from Steam API
-	games, err := s.fetchUserGames(steamID)
+	games, err := s.storeUserGames(ctx, steamID)
 	if err != nil {
-		return 0, fmt.Errorf("failed to fetch games from Steam: %w", err)
+		return 0, err
 	}
 
 	log.Printf("[GameRefresh] User %s has %d games", steamID, len(games))
@@ -462,17 +455,76 @@ func (s *GameService) RefreshUserGames(steamID string) (int, error) {
 	return len(games), nil
 }
 
+// storeUserGames fetches a user's library from Steam and stores the ownership in game_owners
+// Games not known yet are added to game_cache, so the next sync fetches their store data
+func (s *GameService) storeUserGames(ctx context.Context, steamID string) ([]models.GameOwnership, error) {
+	games, err := s.fetchUserGames(ctx, steamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch games from Steam: %w", err)
+	}
+
+	for _, g := range games {
+		if err := s.gameCacheRepo.InsertIfNotExists(ctx, g.AppID, g.Name); err != nil {
+			log.Printf("GameService: Failed to insert game %d: %v", g.AppID, err)
+		}
+	}
+	return games, nil
+}
+
+// refreshAllOwnership reloads the libraries of all registered users from Steam
+// Returns false if a sync is already running
+func (s *GameService) refreshAllOwnership(ctx context.Context, progressCallback SyncProgressCallback) bool {
+	s.syncProgress.mu.Lock()
+	if s.syncProgress.isSyncing {
+		s.syncProgress.mu.Unlock()
+		return false
+	}
+	s.syncProgress.isSyncing = true
+	s.syncProgress.mu.Unlock()
+	defer s.setSyncProgress(false, "", "", 0, 0)
+
+	users, err := s.userRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("GameService: Failed to get users for ownership refresh: %v", err)
+		return true
+	}
+
+	log.Printf("GameService: Refreshing game ownership of %d users", len(users))
+	for i, user := range users {
+		if s.ctx.Err() != nil {
+			log.Println("GameService: Ownership refresh canceled")
+			break
+		}
+		s.setSyncProgress(true, "fetching_users", user.Username, i, len(users))
+		if progressCallback != nil {
+			progressCallback("fetching_users", user.Username, i, len(users))
+		}
+		if _, err := s.storeUserGames(s.ctx, user.SteamID); err != nil {
+			log.Printf("GameService: Failed to refresh games of user %s: %v", user.SteamID, err)
+		}
+	}
+
+	s.InvalidateCache()
+	return true
+}
+
 // storeAppDetailsResponse represents Steam Store API response
 type storeAppDetailsResponse map[string]struct {
 	Success bool `json:"success"`
 	Data    struct {
-		Name        string `json:"name"`
-		HeaderImage string `json:"header_image"`
-		IsFree      bool   `json:"is_free"`
-		Categories  []struct {
+		Name             string `json:"name"`
+		HeaderImage      string `json:"header_image"`
+		IsFree           bool   `json:"is_free"`
+		ShortDescription string `json:"short_description"`
+		AboutTheGame     string `json:"about_the_game"` // HTML
+		Categories       []struct {
 			ID          int    `json:"id"`
 			Description string `json:"description"`
 		} `json:"categories"`
+		Genres []struct {
+			ID          string `json:"id"`
+			Description string `json:"description"`
+		} `json:"genres"`
 		PriceOverview *struct {
 			Currency         string `json:"currency"`
 			Initial          int    `json:"initial"`
@@ -484,87 +536,23 @@ type storeAppDetailsResponse map[string]struct {
 	} `json:"data"`
 }
 
-// fetchGameCategories fetches categories for multiple games from Steam Store API
-// Uses DB caching and respects rate limits
-func (s *GameService) fetchGameCategories(games []*models.Game) {
-	if len(games) == 0 {
-		return
-	}
-
-	// Check if we're rate limited
-	if s.isRateLimited() {
-		log.Printf("Skipping Steam Store API calls - rate limited until %v", s.rateLimiter.pausedUntil)
-		return
-	}
-
-	// Process sequentially with delays to avoid rate limits
-	const delayBetweenRequests = 300 * time.Millisecond
-
-	for _, game := range games {
-		// Check rate limit before each request
-		if s.isRateLimited() {
-			log.Printf("Rate limit hit - stopping category fetches")
-			return
-		}
-
-		storeData, err := s.fetchGameCategoriesFromStore(game.AppID)
-		if err != nil {
-			log.Printf("Could not fetch data for %s (%d): %v", game.Name, game.AppID, err)
-
-			// Check if this is a "game not found" error (not a rate limit or network error)
-			// Cache the failure so we don't retry for 24 hours
-			if strings.Contains(err.Error(), "game not found") || strings.Contains(err.Error(), "not accessible") {
-				log.Printf("Game %s (%d) appears to be unavailable (removed from Steam Store?) - caching failure for %v", game.Name, game.AppID, failedFetchRetryDelay)
-				if cacheErr := s.gameCacheRepo.UpsertWithStatus(game.AppID, game.Name, []string{}, nil, true); cacheErr != nil {
-					log.Printf("Failed to cache failed fetch for game %d: %v", game.AppID, cacheErr)
-				}
-			}
-			continue
-		}
-
-		game.Categories = storeData.Categories
-		if storeData.Name != "" {
-			game.Name = storeData.Name
-		}
-		game.IsFree = storeData.IsFree
-		game.PriceCents = storeData.PriceCents
-		game.OriginalCents = storeData.OriginalCents
-		game.DiscountPercent = storeData.DiscountPercent
-		game.PriceFormatted = storeData.PriceFormatted
-		game.ReviewScore = storeData.ReviewScore
-
-		// Cache image using the header_image URL from Steam API
-		if storeData.HeaderImageURL != "" {
-			s.imageCacheService.CacheImageFromURLAsync(game.AppID, storeData.HeaderImageURL)
-		}
-
-		// Save to DB cache
-		priceInfo := &repository.GamePriceInfo{
-			IsFree:          storeData.IsFree,
-			PriceCents:      storeData.PriceCents,
-			OriginalCents:   storeData.OriginalCents,
-			DiscountPercent: storeData.DiscountPercent,
-			PriceFormatted:  storeData.PriceFormatted,
-			ReviewScore:     storeData.ReviewScore,
-		}
-		if err := s.gameCacheRepo.Upsert(game.AppID, game.Name, storeData.Categories, priceInfo); err != nil {
-			log.Printf("Failed to cache game %d: %v", game.AppID, err)
-		}
-
-		time.Sleep(delayBetweenRequests)
-	}
-}
-
 // fetchGameCategoriesFromStore fetches categories and price for a single game from Steam Store
 // Returns GameStoreData and error. Handles 429 rate limiting.
-func (s *GameService) fetchGameCategoriesFromStore(appID int) (*GameStoreData, error) {
-	url := fmt.Sprintf("%s/appdetails?appids=%d&cc=de", steamStoreBaseURL, appID)
+func (s *GameService) fetchGameCategoriesFromStore(ctx context.Context, appID int) (*GameStoreData, error) {
+	url := fmt.Sprintf("%s/appdetails?appids=%d&%s", steamStoreBaseURL, appID, s.storeLocaleQuery())
 
+	if err := s.storeLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
 	log.Printf("[STEAM STORE API] GET /appdetails - Fetching details for game %d", appID)
 	start := time.Now()
-	resp, err := s.httpClient.Get(url)
+	resp, err := s.get(ctx, url)
 	duration := time.Since(start)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		metrics.SteamAPIErrors.Inc()
 		log.Printf("[STEAM STORE API] ERROR - appdetails failed for game %d after %v: %v", appID, duration, err)
 		return nil, fmt.Errorf("failed to call Steam Store API: %w", err)
 	}
@@ -572,18 +560,22 @@ func (s *GameService) fetchGameCategoriesFromStore(appID int) (*GameStoreData, e
 
 	// Handle rate limiting
 	if resp.StatusCode == http.StatusTooManyRequests {
+		metrics.SteamAPIErrors.Inc()
 		log.Printf("[STEAM STORE API] WARN - Rate limited (429) for game %d after %v", appID, duration)
-		s.setRateLimited()
+		s.setRateLimited(ctx, parseRetryAfter(resp.Header.Get("Retry-After")))
 		return nil, fmt.Errorf("rate limited (429)")
 	}
 
 	if resp.StatusCode != http.StatusOK {
+		metrics.SteamAPIErrors.Inc()
 		log.Printf("[STEAM STORE API] ERROR - appdetails returned status %d for game %d after %v", resp.StatusCode, appID, duration)
 		return nil, fmt.Errorf("Steam Store API returned status %d", resp.StatusCode)
 	}
+	s.clearRateLimitStrikes()
 
 	var apiResp storeAppDetailsResponse
 	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		metrics.SteamAPIErrors.Inc()
 		log.Printf("[STEAM STORE API] ERROR - Failed to parse appdetails response for game %d: %v", appID, err)
 		return nil, fmt.Errorf("failed to parse Steam Store API response: %w", err)
 	}
@@ -599,7 +591,7 @@ func (s *GameService) fetchGameCategoriesFromStore(appID int) (*GameStoreData, e
 
 	var categories []string
 	for _, cat := range appData.Data.Categories {
-		categories = append(categories, cat.Description)
+		categories = append(categories, storeCategoryName(cat.ID, cat.Description))
 	}
 
 	// Build price info
@@ -608,6 +600,7 @@ func (s *GameService) fetchGameCategoriesFromStore(appID int) (*GameStoreData, e
 		HeaderImageURL: appData.Data.HeaderImage,
 		Categories:     categories,
 		IsFree:         appData.Data.IsFree,
+		MaxPlayers:     parseMaxPlayers(appData.Data.ShortDescription, appData.Data.AboutTheGame),
 	}
 
 	if appData.Data.IsFree {
@@ -619,8 +612,24 @@ func (s *GameService) fetchGameCategoriesFromStore(appID int) (*GameStoreData, e
 		data.PriceFormatted = appData.Data.PriceOverview.FinalFormatted
 	}
 
-	// Fetch review score from Steam Review API
-	data.ReviewScore = s.fetchGameReviewScore(appID)
+	for _, genre := range appData.Data.Genres {
+		data.Genres = append(data.Genres, genre.Description)
+	}
+
+	// Fetch review score, wording and count from Steam Review API
+	reviews := s.fetchGameReviews(ctx, appID)
+	data.ReviewScore = reviews.Score
+	data.ReviewScoreDesc = reviews.Desc
+	data.ReviewCount = reviews.Count
+
+	// User tags are not part of appdetails, SteamSpy has them
+	// SteamSpy allows only one request per second, so tags are only fetched for multiplayer games
+	for _, category := range categories {
+		if models.IsMultiplayerCategory(category) {
+			data.Tags = s.fetchGameTags(ctx, appID)
+			break
+		}
+	}
 
 	return data, nil
 }
@@ -635,7 +644,12 @@ type GameStoreData struct {
 	OriginalCents   int
 	DiscountPercent int
 	PriceFormatted  string
-	ReviewScore     int // Percentage of positive reviews (0-100), -1 if not enough reviews
+	ReviewScore     int    // Percentage of positive reviews (0-100), -1 if not enough reviews
+	ReviewScoreDesc string // Steam review wording, e.g. "Overwhelmingly Positive"
+	ReviewCount     int    // Total number of reviews
+	MaxPlayers      int    // Parsed from the store description, 0 if unknown
+	Genres          []string
+	Tags            []string // Top user tags from SteamSpy, most votes first
 }
 
 // steamReviewResponse represents the Steam Review API response
@@ -651,164 +665,142 @@ type steamReviewResponse struct {
 	} `json:"query_summary"`
 }
 
-// fetchGameReviewScore fetches the review score percentage from Steam Review API
-// Returns the percentage of positive reviews (0-100), or -1 if not enough reviews
-func (s *GameService) fetchGameReviewScore(appID int) int {
+// gameReviews is the review summary of a game on the Steam store
+type gameReviews struct {
+	Score int    // Percentage of positive reviews (0-100), -1 if not enough reviews
+	Desc  string // Steam review wording, e.g. "Very Positive" or "3 user reviews"
+	Count int    // Total number of reviews
+}
+
+// fetchGameReviews fetches the review score percentage, wording and count from Steam Review API
+// The score is -1 if there are not enough reviews or the request failed, the wording is then empty unless Steam sent one
+func (s *GameService) fetchGameReviews(ctx context.Context, appID int) gameReviews {
+	noReviews := gameReviews{Score: -1}
 	url := fmt.Sprintf("https://store.steampowered.com/appreviews/%d?json=1&purchase_type=all&language=all", appID)
 
+	if err := s.storeLimiter.Wait(ctx); err != nil {
+		return noReviews
+	}
 	log.Printf("[STEAM STORE API] GET /appreviews - Fetching reviews for game %d", appID)
 	start := time.Now()
-	resp, err := s.httpClient.Get(url)
+	resp, err := s.get(ctx, url)
 	duration := time.Since(start)
 	if err != nil {
+		if ctx.Err() != nil {
+			return noReviews
+		}
+		metrics.SteamAPIErrors.Inc()
 		log.Printf("[STEAM STORE API] ERROR - appreviews failed for game %d after %v: %v", appID, duration, err)
-		return -1
+		return noReviews
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		metrics.SteamAPIErrors.Inc()
+		log.Printf("[STEAM STORE API] WARN - Rate limited (429) for reviews of game %d after %v", appID, duration)
+		s.setRateLimited(ctx, parseRetryAfter(resp.Header.Get("Retry-After")))
+		return noReviews
+	}
+
 	if resp.StatusCode != http.StatusOK {
+		metrics.SteamAPIErrors.Inc()
 		log.Printf("[STEAM STORE API] ERROR - appreviews returned status %d for game %d after %v", resp.StatusCode, appID, duration)
-		return -1
+		return noReviews
 	}
+	s.clearRateLimitStrikes()
 
 	var reviewResp steamReviewResponse
 	if err := json.NewDecoder(resp.Body).Decode(&reviewResp); err != nil {
+		metrics.SteamAPIErrors.Inc()
 		log.Printf("[STEAM STORE API] ERROR - Failed to parse appreviews response for game %d: %v", appID, err)
-		return -1
+		return noReviews
 	}
 
 	if reviewResp.Success != 1 {
 		log.Printf("[STEAM STORE API] WARN - appreviews returned unsuccessful for game %d after %v", appID, duration)
-		return -1
+		return noReviews
 	}
 
 	totalReviews := reviewResp.QuerySummary.TotalPositive + reviewResp.QuerySummary.TotalNegative
+	reviews := gameReviews{
+		Score: -1,
+		Desc:  reviewResp.QuerySummary.ReviewScoreDesc,
+		Count: totalReviews,
+	}
 	if totalReviews < 10 {
 		// Not enough reviews for a meaningful percentage
 		log.Printf("[STEAM STORE API] OK - appreviews for game %d has only %d reviews (not enough) in %v", appID, totalReviews, duration)
-		return -1
+		return reviews
 	}
 
 	// Calculate percentage of positive reviews
-	percentage := (reviewResp.QuerySummary.TotalPositive * 100) / totalReviews
-	log.Printf("[STEAM STORE API] OK - appreviews for game %d: %d%% positive (%d reviews) in %v", appID, percentage, totalReviews, duration)
-	return percentage
+	reviews.Score = (reviewResp.QuerySummary.TotalPositive * 100) / totalReviews
+	log.Printf("[STEAM STORE API] OK - appreviews for game %d: %d%% positive, %s (%d reviews) in %v", appID, reviews.Score, reviews.Desc, totalReviews, duration)
+	return reviews
 }
 
-// fetchGameDetails fetches full details for a single game (used for pinned games not in library)
-// First checks DB cache, then fetches from Steam Store API if needed
-func (s *GameService) fetchGameDetails(appID int) (*models.Game, error) {
-	// First try DB cache
-	cached, err := s.gameCacheRepo.GetByAppID(appID)
-	if err == nil && cached != nil && !cached.IsStale(gameCacheMaxAge) {
-		// Check if this was a failed fetch - skip unavailable games
-		if cached.FetchFailed {
-			if !cached.IsStale(failedFetchRetryDelay) {
-				log.Printf("Skipping unavailable pinned game (%d) - will retry after %v", appID, failedFetchRetryDelay)
-				return nil, fmt.Errorf("game unavailable (cached failure)")
-			}
-			// Retry delay expired, continue to fetch again below
-		} else {
-			// For cached games, try to cache image asynchronously using old CDN URL as fallback
-			s.imageCacheService.CacheImageAsync(appID)
-			return &models.Game{
-				AppID:           appID,
-				Name:            cached.Name,
-				HeaderImageURL:  s.imageCacheService.GetLocalImageURL(appID),
-				CapsuleImageURL: fmt.Sprintf("%s/%d/capsule_231x87.jpg", steamCDNBaseURL, appID),
-				Categories:      cached.GetCategories(),
-				OwnerCount:      0,
-				Owners:          []string{},
-				IsFree:          cached.IsFree,
-				PriceCents:      cached.PriceCents,
-				OriginalCents:   cached.OriginalCents,
-				DiscountPercent: cached.DiscountPercent,
-				PriceFormatted:  cached.PriceFormatted,
-				ReviewScore:     cached.ReviewScore,
-			}, nil
-		}
-	}
+// steamSpyAppDetailsResponse represents the SteamSpy appdetails response, only tags are used
+type steamSpyAppDetailsResponse struct {
+	Tags json.RawMessage `json:"tags"` // Object of tag -> votes, an empty array if the game has no tags
+}
 
-	// Check rate limit
-	if s.isRateLimited() {
-		// Return partial data from stale cache if available
-		if cached != nil {
-			return &models.Game{
-				AppID:           appID,
-				Name:            cached.Name,
-				HeaderImageURL:  s.imageCacheService.GetLocalImageURL(appID),
-				CapsuleImageURL: fmt.Sprintf("%s/%d/capsule_231x87.jpg", steamCDNBaseURL, appID),
-				Categories:      cached.GetCategories(),
-				OwnerCount:      0,
-				Owners:          []string{},
-				IsFree:          cached.IsFree,
-				PriceCents:      cached.PriceCents,
-				OriginalCents:   cached.OriginalCents,
-				DiscountPercent: cached.DiscountPercent,
-				PriceFormatted:  cached.PriceFormatted,
-				ReviewScore:     cached.ReviewScore,
-			}, nil
-		}
-		return nil, fmt.Errorf("rate limited and no cache available")
-	}
+// fetchGameTags fetches the top user tags of a game from SteamSpy, most votes first
+// Returns nil on errors, tags are optional
+func (s *GameService) fetchGameTags(ctx context.Context, appID int) []string {
+	url := fmt.Sprintf("%s?request=appdetails&appid=%d", steamSpyBaseURL, appID)
 
-	// Fetch from Steam Store API
-	storeData, err := s.fetchGameCategoriesFromStore(appID)
+	if err := s.steamSpyLimiter.Wait(ctx); err != nil {
+		return nil
+	}
+	log.Printf("[STEAMSPY API] GET appdetails - Fetching tags for game %d", appID)
+	start := time.Now()
+	resp, err := s.get(ctx, url)
+	duration := time.Since(start)
 	if err != nil {
-		// Cache the failure if it's a "game not found" error
-		if strings.Contains(err.Error(), "game not found") || strings.Contains(err.Error(), "not accessible") {
-			log.Printf("Pinned game (%d) appears to be unavailable - caching failure for %v", appID, failedFetchRetryDelay)
-			if cacheErr := s.gameCacheRepo.UpsertWithStatus(appID, fmt.Sprintf("Unknown Game %d", appID), []string{}, nil, true); cacheErr != nil {
-				log.Printf("Failed to cache failed fetch for pinned game %d: %v", appID, cacheErr)
-			}
-		}
-		return nil, err
+		log.Printf("[STEAMSPY API] ERROR - appdetails failed for game %d after %v: %v", appID, duration, err)
+		return nil
 	}
+	defer resp.Body.Close()
 
-	// Cache image using the header_image URL from Steam API
-	if storeData.HeaderImageURL != "" {
-		s.imageCacheService.CacheImageFromURLAsync(appID, storeData.HeaderImageURL)
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("[STEAMSPY API] ERROR - appdetails returned status %d for game %d after %v", resp.StatusCode, appID, duration)
+		return nil
 	}
 
-	// Save to DB cache
-	priceInfo := &repository.GamePriceInfo{
-		IsFree:          storeData.IsFree,
-		PriceCents:      storeData.PriceCents,
-		OriginalCents:   storeData.OriginalCents,
-		DiscountPercent: storeData.DiscountPercent,
-		PriceFormatted:  storeData.PriceFormatted,
-		ReviewScore:     storeData.ReviewScore,
+	var spyResp steamSpyAppDetailsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&spyResp); err != nil {
+		log.Printf("[STEAMSPY API] ERROR - Failed to parse appdetails response for game %d: %v", appID, err)
+		return nil
 	}
-	if err := s.gameCacheRepo.Upsert(appID, storeData.Name, storeData.Categories, priceInfo); err != nil {
-		log.Printf("Failed to cache game %d: %v", appID, err)
+
+	var votes map[string]int
+	if err := json.Unmarshal(spyResp.Tags, &votes); err != nil {
+		// Games without tags return an empty array
+		return nil
 	}
 
-	return &models.Game{
-		AppID:           appID,
-		Name:            storeData.Name,
-		HeaderImageURL:  s.imageCacheService.GetLocalImageURL(appID),
-		CapsuleImageURL: fmt.Sprintf("%s/%d/capsule_231x87.jpg", steamCDNBaseURL, appID),
-		Categories:      storeData.Categories,
-		OwnerCount:      0,
-		Owners:          []string{},
-		IsFree:          storeData.IsFree,
-		PriceCents:      storeData.PriceCents,
-		OriginalCents:   storeData.OriginalCents,
-		DiscountPercent: storeData.DiscountPercent,
-		PriceFormatted:  storeData.PriceFormatted,
-		ReviewScore:     storeData.ReviewScore,
-	}, nil
-}
+	tags := make([]string, 0, len(votes))
+	for tag := range votes {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if votes[tags[i]] != votes[tags[j]] {
+			return votes[tags[i]] > votes[tags[j]]
+		}
+		return tags[i] < tags[j]
+	})
+	if len(tags) > maxGameTags {
+		tags = tags[:maxGameTags]
+	}
 
-// GetPinnedGameIDs returns the list of pinned game IDs
-func (s *GameService) GetPinnedGameIDs() []int {
-	return s.cfg.PinnedGameIDs
+	log.Printf("[STEAMSPY API] OK - appdetails for game %d: %d tags in %v", appID, len(tags), duration)
+	return tags
 }
 
 // PrefetchPinnedGames fetches and caches pinned games at startup
 // This runs in the background and doesn't block startup
 func (s *GameService) PrefetchPinnedGames() {
-	pinnedIDs := s.cfg.PinnedGameIDs
+	pinnedIDs := s.GetPinnedGameIDs()
 	if len(pinnedIDs) == 0 {
 		log.Println("[GameSync] No pinned games configured")
 		return
@@ -823,21 +815,24 @@ func (s *GameService) PrefetchPinnedGames() {
 
 		for _, appID := range pinnedIDs {
 			// Check if already in cache
-			cached, err := s.gameCacheRepo.GetByAppID(appID)
+			cached, err := s.gameCacheRepo.GetByAppID(s.ctx, appID)
 			if err == nil && cached != nil && !cached.IsStale(gameCacheMaxAge) && !cached.FetchFailed {
 				log.Printf("[GameSync] Pinned game %d already cached: %s", appID, cached.Name)
 				skipped++
 				continue
 			}
 
-			// Check rate limit
+			// Check rate limit and shutdown
 			if s.isRateLimited() {
 				log.Printf("[GameSync] Rate limited - stopping pinned game prefetch")
 				break
 			}
+			if s.ctx.Err() != nil {
+				break
+			}
 
 			// Fetch from Steam Store API
-			storeData, err := s.fetchGameCategoriesFromStore(appID)
+			storeData, err := s.fetchGameCategoriesFromStore(s.ctx, appID)
 			if err != nil {
 				log.Printf("[GameSync] Failed to prefetch pinned game %d: %v", appID, err)
 				continue
@@ -851,8 +846,13 @@ func (s *GameService) PrefetchPinnedGames() {
 				DiscountPercent: storeData.DiscountPercent,
 				PriceFormatted:  storeData.PriceFormatted,
 				ReviewScore:     storeData.ReviewScore,
+				ReviewScoreDesc: storeData.ReviewScoreDesc,
+				ReviewCount:     storeData.ReviewCount,
+				MaxPlayers:      storeData.MaxPlayers,
+				Genres:          storeData.Genres,
+				Tags:            storeData.Tags,
 			}
-			if err := s.gameCacheRepo.Upsert(appID, storeData.Name, storeData.Categories, priceInfo); err != nil {
+			if err := s.gameCacheRepo.Upsert(s.ctx, appID, storeData.Name, storeData.Categories, priceInfo); err != nil {
 				log.Printf("[GameSync] Failed to cache pinned game %d: %v", appID, err)
 			}
 
@@ -864,7 +864,10 @@ func (s *GameService) PrefetchPinnedGames() {
 			log.Printf("[GameSync] Prefetched pinned game %d: %s", appID, storeData.Name)
 			fetched++
 
-			time.Sleep(delayBetweenRequests)
+			select {
+			case <-s.ctx.Done():
+			case <-time.After(delayBetweenRequests):
+			}
 		}
 
 		log.Printf("[GameSync] Pinned games prefetch complete: %d fetched, %d already cached", fetched, skipped)
@@ -872,23 +875,25 @@ func (s *GameService) PrefetchPinnedGames() {
 }
 
 // GetSyncStatus returns the current sync status
+// A batch waiting for the rate limit pause to end counts as syncing with its last progress
 func (s *GameService) GetSyncStatus() (isSyncing bool, phase string, current string, processed, total int) {
 	s.syncProgress.mu.RLock()
 	defer s.syncProgress.mu.RUnlock()
-	return s.syncProgress.isSyncing, s.syncProgress.phase, s.syncProgress.current, s.syncProgress.processed, s.syncProgress.total
+	return s.syncProgress.isSyncing || s.syncProgress.waiting, s.syncProgress.phase, s.syncProgress.current, s.syncProgress.processed, s.syncProgress.total
 }
 
-// IsSyncing returns whether a background sync is in progress
+// IsSyncing returns whether a background sync is in progress or waiting to be resumed
 func (s *GameService) IsSyncing() bool {
 	s.syncProgress.mu.RLock()
 	defer s.syncProgress.mu.RUnlock()
-	return s.syncProgress.isSyncing
+	return s.syncProgress.isSyncing || s.syncProgress.waiting
 }
 
 // setSyncProgress updates the sync progress
 func (s *GameService) setSyncProgress(isSyncing bool, phase, current string, processed, total int) {
 	s.syncProgress.mu.Lock()
 	s.syncProgress.isSyncing = isSyncing
+	s.syncProgress.waiting = false
 	s.syncProgress.phase = phase
 	s.syncProgress.current = current
 	s.syncProgress.processed = processed
@@ -896,9 +901,22 @@ func (s *GameService) setSyncProgress(isSyncing bool, phase, current string, pro
 	s.syncProgress.mu.Unlock()
 }
 
+// setWaitingProgress marks an interrupted batch as waiting for the rate limit pause
+// The batch is not running, so TriggerSyncIfNeeded can pick it up again
+func (s *GameService) setWaitingProgress(processed, total int) {
+	s.syncProgress.mu.Lock()
+	s.syncProgress.isSyncing = false
+	s.syncProgress.waiting = true
+	s.syncProgress.phase = syncPhaseRateLimited
+	s.syncProgress.current = ""
+	s.syncProgress.processed = processed
+	s.syncProgress.total = total
+	s.syncProgress.mu.Unlock()
+}
+
 // GetMultiplayerGamesCached returns only cached games without triggering a sync
 // This is fast and returns immediately
-func (s *GameService) GetMultiplayerGamesCached() (*models.GamesResponse, bool, error) {
+func (s *GameService) GetMultiplayerGamesCached(ctx context.Context) (*models.GamesResponse, bool, error) {
 	// Check in-memory cache first
 	s.cache.mu.RLock()
 	if s.cache.games != nil && time.Now().Before(s.cache.expiresAt) {
@@ -909,12 +927,13 @@ func (s *GameService) GetMultiplayerGamesCached() (*models.GamesResponse, bool,
 	s.cache.mu.RUnlock()
 
 	// Try to build response from DB cache only (no Steam API calls)
-	games, needsSync, err := s.buildGamesFromCache()
+	games, needsSync, err := s.buildGamesFromCache(ctx)
 	if err != nil {
 		return nil, needsSync, err
 	}
 
 	// Update in-memory cache
+	games.ContentHash = contentHash(games)
 	s.cache.mu.Lock()
 	s.cache.games = games
 	s.cache.expiresAt = time.Now().Add(5 * time.Minute)
@@ -923,13 +942,180 @@ func (s *GameService) GetMultiplayerGamesCached() (*models.GamesResponse, bool,
 	return games, needsSync, nil
 }
 
+// GamesETag returns the ETag of a games list response built from the cached list
+// It covers the cached list, the current star ratings and the given request specific parts (filter, sync status)
+func (s *GameService) GamesETag(ctx context.Context, games *models.GamesResponse, parts ...interface{}) (string, error) {
+	ratings, err := s.gameRatingRepo.GetSummaries(ctx)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`"%s"`, contentHash([]interface{}{games.ContentHash, ratings, parts})), nil
+}
+
+// contentHash returns a short hash of the JSON encoding of v, empty if v cannot be encoded
+func contentHash(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16])
+}
+
+// FilterGames returns a filtered and sorted copy of the games list with the current star ratings of the players
+// The cached list is not modified, pinned games are filtered as well but keep their configured order
+func (s *GameService) FilterGames(ctx context.Context, games *models.GamesResponse, filter models.GameFilter) *models.GamesResponse {
+	// Ratings change with every vote, so they are added per request instead of being cached
+	ratings, err := s.gameRatingRepo.GetSummaries(ctx)
+	if err != nil {
+		log.Printf("Failed to load game ratings: %v", err)
+	}
+	search := strings.ToLower(strings.TrimSpace(filter.Search))
+	matches := func(g *models.Game) bool {
+		if search != "" && !strings.Contains(strings.ToLower(g.Name), search) {
+			return false
+		}
+		if g.OwnerCount < filter.MinOwners {
+			return false
+		}
+		if filter.MaxPriceCents >= 0 && !g.IsFree && g.PriceCents > filter.MaxPriceCents {
+			return false
+		}
+		if filter.Category != "" && !containsString(g.Categories, filter.Category) {
+			return false
+		}
+		if filter.Genre != "" && !containsString(g.Genres, filter.Genre) && !containsString(g.Tags, filter.Genre) {
+			return false
+		}
+		if filter.FreeOnly && !g.IsFree {
+			return false
+		}
+		if filter.MinReviewScore > 0 && g.ReviewScore < filter.MinReviewScore {
+			return false
+		}
+		if filter.MinPlayers > 0 && g.MaxPlayers < filter.MinPlayers {
+			return false
+		}
+		if filter.CoopOnly && !g.IsCoop() {
+			return false
+		}
+		return true
+	}
+
+	result := &models.GamesResponse{
+		PinnedGames: []models.Game{},
+		AllGames:    []models.Game{},
+	}
+	for i := range games.PinnedGames {
+		if matches(&games.PinnedGames[i]) {
+			result.PinnedGames = append(result.PinnedGames, withRating(games.PinnedGames[i], ratings))
+		}
+	}
+	for i := range games.AllGames {
+		if matches(&games.AllGames[i]) {
+			result.AllGames = append(result.AllGames, withRating(games.AllGames[i], ratings))
+		}
+	}
+
+	// The cached list is already sorted by owners
+	if filter.Sort != "" && filter.Sort != models.GameSortOwners {
+		all := result.AllGames
+		sort.SliceStable(all, func(i, j int) bool {
+			switch filter.Sort {
+			case models.GameSortPrice:
+				return effectivePrice(&all[i]) < effectivePrice(&all[j])
+			case models.GameSortReview:
+				return all[i].ReviewScore > all[j].ReviewScore
+			case models.GameSortPlaytime:
+				return all[i].PlaytimeForever > all[j].PlaytimeForever
+			case models.GameSortRating:
+				if all[i].CommunityRating != all[j].CommunityRating {
+					return all[i].CommunityRating > all[j].CommunityRating
+				}
+				return all[i].CommunityRatingCount > all[j].CommunityRatingCount
+			}
+			return false
+		})
+	}
+
+	return result
+}
+
+// AddOwnerDetails fills the owner profiles of all games in a filtered copy of the games list
+// Must not be called with the cached list, the profiles are not part of the cache
+func (s *GameService) AddOwnerDetails(ctx context.Context, games *models.GamesResponse) error {
+	owners, err := s.gameOwnerRepo.GetOwnerDetailsGroupedByAppID(ctx)
+	if err != nil {
+		return err
+	}
+	for _, list := range [][]models.Game{games.PinnedGames, games.AllGames} {
+		for i := range list {
+			list[i].OwnerDetails = owners[list[i].AppID]
+			if list[i].OwnerDetails == nil {
+				list[i].OwnerDetails = []models.GameOwnerInfo{}
+			}
+		}
+	}
+	return nil
+}
+
+// withRating returns the game with the star ratings of the players
+func withRating(game models.Game, ratings map[int]models.GameRatingSummary) models.Game {
+	if summary, ok := ratings[game.AppID]; ok {
+		game.CommunityRating = summary.Average
+		game.CommunityRatingCount = summary.Count
+	}
+	return game
+}
+
+// effectivePrice returns the price of a game in cents, 0 for free games
+func effectivePrice(g *models.Game) int {
+	if g.IsFree {
+		return 0
+	}
+	return g.PriceCents
+}
+
+// GetCommonGames returns the multiplayer games owned by all given users, most combined playtime first
+func (s *GameService) GetCommonGames(ctx context.Context, userIDs []uint64) ([]models.CommonGame, error) {
+	playtimes, err := s.gameOwnerRepo.GetCommonPlaytimeByAppID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	games, _, err := s.GetMultiplayerGamesCached(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []models.CommonGame{}
+	for _, list := range [][]models.Game{games.PinnedGames, games.AllGames} {
+		for _, game := range list {
+			if playtime, ok := playtimes[game.AppID]; ok {
+				result = append(result, models.CommonGame{Game: game, CombinedPlaytime: playtime})
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CombinedPlaytime != result[j].CombinedPlaytime {
+			return result[i].CombinedPlaytime > result[j].CombinedPlaytime
+		}
+		if result[i].OwnerCount != result[j].OwnerCount {
+			return result[i].OwnerCount > result[j].OwnerCount
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
 // buildGamesFromCache builds the games response using only DB-cached data (no Steam API calls)
-func (s *GameService) buildGamesFromCache() (*models.GamesResponse, bool, error) {
-	pinnedGameIDs := s.cfg.PinnedGameIDs
+func (s *GameService) buildGamesFromCache(ctx context.Context) (*models.GamesResponse, bool, error) {
+	pinnedGameIDs := s.GetPinnedGameIDs()
 	needsSync := false
 
 	// Load all game owners from DB
-	ownersMap, err := s.gameOwnerRepo.GetAllOwnersGroupedByAppID()
+	ownersMap, err := s.gameOwnerRepo.GetAllOwnersGroupedByAppID(ctx)
 	if err != nil {
 		return nil, false, fmt.Errorf("failed to get game owners: %w", err)
 	}
@@ -937,7 +1123,7 @@ func (s *GameService) buildGamesFromCache() (*models.GamesResponse, bool, error)
 	// If no game owners in DB, we need a sync
 	if len(ownersMap) == 0 {
 		log.Printf("[GameSync] No game owners in DB, loading pinned games only")
-		pinnedGames := s.loadPinnedGamesFromCache(&needsSync)
+		pinnedGames := s.loadPinnedGamesFromCache(ctx, &needsSync)
 		// Enrich pinned games with custom metadata
 		s.enrichGamesWithMetadata(pinnedGames)
 		needsSync = true // Trigger sync to populate game owners
@@ -949,12 +1135,18 @@ func (s *GameService) buildGamesFromCache() (*models.GamesResponse, bool, error)
 
 	log.Printf("[GameSync] Building games from DB cache, %d games with owners", len(ownersMap))
 
+	// Playtime is only used for sorting, the list still works without it
+	playtimeMap, err := s.gameOwnerRepo.GetMaxPlaytimeByAppID(ctx)
+	if err != nil {
+		log.Printf("[GameSync] Failed to load playtimes: %v", err)
+	}
+
 	// Build games from DB cache
 	gameMap := make(map[int]*models.Game)
 
 	for appID, owners := range ownersMap {
 		// Try to load game details from DB cache
-		cached, err := s.gameCacheRepo.GetByAppID(appID)
+		cached, err := s.gameCacheRepo.GetByAppID(ctx, appID)
 		if err != nil || cached == nil {
 			// Game not in cache - skip for now, will be fetched during sync
 			needsSync = true
@@ -971,6 +1163,7 @@ func (s *GameService) buildGamesFromCache() (*models.GamesResponse, bool, error)
 			Name:            cached.Name,
 			HeaderImageURL:  s.imageCacheService.GetLocalImageURL(appID),
 			CapsuleImageURL: fmt.Sprintf("%s/%d/capsule_231x87.jpg", steamCDNBaseURL, appID),
+			PlaytimeForever: playtimeMap[appID],
 			Categories:      cached.GetCategories(),
 			OwnerCount:      len(owners),
 			Owners:          owners,
@@ -980,6 +1173,11 @@ func (s *GameService) buildGamesFromCache() (*models.GamesResponse, bool, error)
 			DiscountPercent: cached.DiscountPercent,
 			PriceFormatted:  cached.PriceFormatted,
 			ReviewScore:     cached.ReviewScore,
+			ReviewScoreDesc: cached.ReviewScoreDesc,
+			ReviewCount:     cached.ReviewCount,
+			MaxPlayers:      cached.MaxPlayers,
+			Genres:          cached.GetGenres(),
+			Tags:            cached.GetTags(),
 		}
 
 		// Check if game data is stale
@@ -1041,7 +1239,7 @@ func (s *GameService) buildGamesFromCache() (*models.GamesResponse, bool, error)
 		}
 		if !found {
 			// Try to load from cache first
-			cached, err := s.gameCacheRepo.GetByAppID(pinnedID)
+			cached, err := s.gameCacheRepo.GetByAppID(ctx, pinnedID)
 			if err == nil && cached != nil && !cached.FetchFailed {
 				game := models.Game{
 					AppID:           pinnedID,
@@ -1058,6 +1256,11 @@ func (s *GameService) buildGamesFromCache() (*models.GamesResponse, bool, error)
 					DiscountPercent: cached.DiscountPercent,
 					PriceFormatted:  cached.PriceFormatted,
 					ReviewScore:     cached.ReviewScore,
+					ReviewScoreDesc: cached.ReviewScoreDesc,
+					ReviewCount:     cached.ReviewCount,
+					MaxPlayers:      cached.MaxPlayers,
+					Genres:          cached.GetGenres(),
+					Tags:            cached.GetTags(),
 				}
 				pinnedGames = append(pinnedGames, game)
 			} else {
@@ -1066,7 +1269,7 @@ func (s *GameService) buildGamesFromCache() (*models.GamesResponse, bool, error)
 		}
 	}
 
-	// Sort pinned games by config order
+	// Sort pinned games by their pinned order
 	sort.Slice(pinnedGames, func(i, j int) bool {
 		indexI := -1
 		indexJ := -1
@@ -1092,12 +1295,12 @@ func (s *GameService) buildGamesFromCache() (*models.GamesResponse, bool, error)
 }
 
 // loadPinnedGamesFromCache loads pinned games from DB cache
-func (s *GameService) loadPinnedGamesFromCache(needsSync *bool) []models.Game {
-	pinnedGameIDs := s.cfg.PinnedGameIDs
+func (s *GameService) loadPinnedGamesFromCache(ctx context.Context, needsSync *bool) []models.Game {
+	pinnedGameIDs := s.GetPinnedGameIDs()
 	var pinnedGames []models.Game
 
 	for _, pinnedID := range pinnedGameIDs {
-		cached, err := s.gameCacheRepo.GetByAppID(pinnedID)
+		cached, err := s.gameCacheRepo.GetByAppID(ctx, pinnedID)
 		if err == nil && cached != nil && !cached.FetchFailed {
 			game := models.Game{
 				AppID:           pinnedID,
@@ -1114,6 +1317,11 @@ func (s *GameService) loadPinnedGamesFromCache(needsSync *bool) []models.Game {
 				DiscountPercent: cached.DiscountPercent,
 				PriceFormatted:  cached.PriceFormatted,
 				ReviewScore:     cached.ReviewScore,
+				ReviewScoreDesc: cached.ReviewScoreDesc,
+				ReviewCount:     cached.ReviewCount,
+				MaxPlayers:      cached.MaxPlayers,
+				Genres:          cached.GetGenres(),
+				Tags:            cached.GetTags(),
 			}
 			pinnedGames = append(pinnedGames, game)
 			log.Printf("[GameSync] Loaded pinned game from cache: %s (%d)", cached.Name, pinnedID)
@@ -1126,10 +1334,16 @@ func (s *GameService) loadPinnedGamesFromCache(needsSync *bool) []models.Game {
 	return pinnedGames
 }
 
-// SyncGames triggers a sync for all games that need updating
-// This can be called at any time - it checks the DB for games with stale/missing data
+// SyncGames refreshes the game ownership of all users and syncs all games that need updating
+// This is the only place besides registration and the per-user refresh where libraries are loaded from Steam
 func (s *GameService) SyncGames(progressCallback SyncProgressCallback) {
-	s.TriggerSyncIfNeeded(progressCallback)
+	go func() {
+		if !s.refreshAllOwnership(s.ctx, progressCallback) {
+			log.Println("GameService: Sync already in progress, skipping")
+			return
+		}
+		s.TriggerSyncIfNeeded(progressCallback)
+	}()
 }
 
 // RegisterUserGames records a user's games in the cache and triggers sync if needed
@@ -1139,8 +1353,8 @@ func (s *GameService) RegisterUserGames(steamID string, progressCallback SyncPro
 	go func() {
 		log.Printf("GameService: Registering games for new user %s", steamID)
 
-		// Fetch new user's game library from Steam
-		userGames, err := s.fetchUserGames(steamID)
+		// Fetch new user's game library from Steam and store it (without overwriting existing game data)
+		userGames, err := s.storeUserGames(s.ctx, steamID)
 		if err != nil {
 			log.Printf("GameService: Failed to fetch games for new user %s: %v", steamID, err)
 			return
@@ -1151,21 +1365,7 @@ func (s *GameService) RegisterUserGames(steamID string, progressCallback SyncPro
 			return
 		}
 
-		log.Printf("GameService: New user %s has %d games, inserting into cache", steamID, len(userGames))
-
-		// Insert all games into cache (without overwriting existing data)
-		// Games that already exist will be skipped
-		newGames := 0
-		for _, g := range userGames {
-			err := s.gameCacheRepo.InsertIfNotExists(g.AppID, g.Name)
-			if err != nil {
-				log.Printf("GameService: Failed to insert game %d: %v", g.AppID, err)
-			} else {
-				newGames++
-			}
-		}
-
-		log.Printf("GameService: Inserted %d games for user %s", newGames, steamID)
+		log.Printf("GameService: Stored %d games for user %s", len(userGames), steamID)
 
 		// Invalidate response cache so new user's ownership is reflected
 		s.InvalidateCache()
@@ -1188,7 +1388,7 @@ func (s *GameService) TriggerSyncIfNeeded(progressCallback SyncProgressCallback)
 	}
 
 	// Check if there are games needing sync
-	count, err := s.gameCacheRepo.CountGamesNeedingSync(gameCacheMaxAge, failedFetchRetryDelay)
+	count, err := s.gameCacheRepo.CountGamesNeedingSync(s.ctx, gameCacheMaxAge, failedFetchRetryDelay)
 	if err != nil {
 		log.Printf("GameService: Failed to count games needing sync: %v", err)
 		return
@@ -1196,6 +1396,10 @@ func (s *GameService) TriggerSyncIfNeeded(progressCallback SyncProgressCallback)
 
 	if count == 0 {
 		log.Println("GameService: No games need syncing")
+		s.setSyncProgress(false, "", "", 0, 0)
+		if err := s.checkpointRepo.Clear(s.ctx, models.SyncCheckpointSteamGames); err != nil {
+			log.Printf("Warning: Failed to clear sync checkpoint: %v", err)
+		}
 		if progressCallback != nil {
 			progressCallback("complete", "", 0, 0)
 		}
@@ -1206,6 +1410,41 @@ func (s *GameService) TriggerSyncIfNeeded(progressCallback SyncProgressCallback)
 	s.runSync(progressCallback)
 }
 
+// RefreshStaleGames refreshes the store data of up to limit games needing a sync, oldest first
+// Nothing is fetched while a sync runs or the Steam Store rate limit pauses requests
+// Returns the number of refreshed games
+func (s *GameService) RefreshStaleGames(ctx context.Context, limit int) (int, error) {
+	if s.IsSyncing() || s.isRateLimited() {
+		return 0, nil
+	}
+
+	stale, err := s.gameCacheRepo.GetGamesNeedingSync(ctx, gameCacheMaxAge, failedFetchRetryDelay)
+	if err != nil {
+		return 0, err
+	}
+	if len(stale) > limit {
+		stale = stale[:limit]
+	}
+	if len(stale) == 0 {
+		return 0, nil
+	}
+
+	games := make([]*models.Game, 0, len(stale))
+	for _, g := range stale {
+		games = append(games, &models.Game{
+			AppID: g.AppID,
+			Name:  g.Name,
+		})
+	}
+
+	refreshed := 0
+	s.fetchGameCategoriesWithProgress(s.ctx, games, func(processed int, _ string) {
+		refreshed = processed
+	})
+	s.InvalidateCache()
+	return refreshed, nil
+}
+
 // runSync performs the actual sync work
 func (s *GameService) runSync(progressCallback SyncProgressCallback) {
 	// Set syncing state
@@ -1219,14 +1458,18 @@ func (s *GameService) runSync(progressCallback SyncProgressCallback) {
 	s.syncProgress.mu.Unlock()
 
 	go func() {
+		// An interrupted batch keeps its progress visible until it is resumed
+		interrupted := false
 		defer func() {
-			s.setSyncProgress(false, "", "", 0, 0)
+			if !interrupted {
+				s.setSyncProgress(false, "", "", 0, 0)
+			}
 		}()
 
 		log.Println("GameService: Starting sync")
 
 		// Get all games that need syncing
-		gamesToSync, err := s.gameCacheRepo.GetGamesNeedingSync(gameCacheMaxAge, failedFetchRetryDelay)
+		gamesToSync, err := s.gameCacheRepo.GetGamesNeedingSync(s.ctx, gameCacheMaxAge, failedFetchRetryDelay)
 		if err != nil {
 			log.Printf("GameService: Failed to get games needing sync: %v", err)
 			return
@@ -1240,34 +1483,86 @@ func (s *GameService) runSync(progressCallback SyncProgressCallback) {
 			return
 		}
 
+		// Sync in app ID order, so the checkpoint marks everything before it as done
+		sort.Slice(gamesToSync, func(i, j int) bool {
+			return gamesToSync[i].AppID < gamesToSync[j].AppID
+		})
+
+		// Resume an interrupted batch where it left off
+		batchID, lastAppID, alreadyProcessed := "", 0, 0
+		checkpoint, err := s.checkpointRepo.Get(s.ctx, models.SyncCheckpointSteamGames)
+		if err != nil {
+			log.Printf("Warning: Failed to load sync checkpoint: %v", err)
+		} else if checkpoint != nil {
+			batchID, lastAppID, alreadyProcessed = checkpoint.BatchID, checkpoint.LastAppID, checkpoint.Processed
+		}
+		// Checkpoints from before batch IDs were stored get one when they are resumed
+		if batchID == "" {
+			batchID = newSyncBatchID()
+		}
+
 		// Convert to models.Game for the fetch function
 		var games []*models.Game
 		for _, g := range gamesToSync {
+			if g.AppID <= lastAppID {
+				continue
+			}
 			games = append(games, &models.Game{
 				AppID: g.AppID,
 				Name:  g.Name,
 			})
 		}
 
-		totalToFetch := len(games)
-		log.Printf("GameService: Syncing %d games", totalToFetch)
+		totalToFetch := alreadyProcessed + len(games)
+		if alreadyProcessed > 0 {
+			log.Printf("GameService: Resuming sync batch %s with %d of %d games left", batchID, len(games), totalToFetch)
+		} else {
+			log.Printf("GameService: Syncing %d games in batch %s", totalToFetch, batchID)
+		}
+		s.saveCheckpoint(s.ctx, batchID, lastAppID, alreadyProcessed, totalToFetch)
 
-		s.setSyncProgress(true, "fetching_categories", "", 0, totalToFetch)
+		s.setSyncProgress(true, "fetching_categories", "", alreadyProcessed, totalToFetch)
 		if progressCallback != nil {
-			progressCallback("fetching_categories", "", 0, totalToFetch)
+			progressCallback("fetching_categories", "", alreadyProcessed, totalToFetch)
 		}
 
-		// Fetch game data with progress reporting
-		s.fetchGameCategoriesWithProgress(games, func(processed int, currentGame string) {
-			s.setSyncProgress(true, "fetching_categories", currentGame, processed, totalToFetch)
+		// Fetch game data with progress reporting, every reported game before the current one is done
+		s.fetchGameCategoriesWithProgress(s.ctx, games, func(processed int, currentGame string) {
+			if processed > 0 {
+				s.saveCheckpoint(s.ctx, batchID, games[processed-1].AppID, alreadyProcessed+processed, totalToFetch)
+			}
+			s.setSyncProgress(true, "fetching_categories", currentGame, alreadyProcessed+processed, totalToFetch)
 			if progressCallback != nil {
-				progressCallback("fetching_categories", currentGame, processed, totalToFetch)
+				progressCallback("fetching_categories", currentGame, alreadyProcessed+processed, totalToFetch)
 			}
 		})
 
 		// Invalidate response cache
 		s.InvalidateCache()
 
+		// A server shutdown stops the batch, the checkpoint lets the next start resume it
+		if s.ctx.Err() != nil {
+			log.Printf("GameService: Sync batch %s canceled by shutdown", batchID)
+			interrupted = true
+			return
+		}
+
+		// A rate limit stops the batch, keep the checkpoint and continue after the pause
+		if s.isRateLimited() {
+			log.Printf("GameService: Sync batch %s interrupted by rate limit", batchID)
+			interrupted = true
+			_, _, _, processed, total := s.GetSyncStatus()
+			s.setWaitingProgress(processed, total)
+			if progressCallback != nil {
+				progressCallback(syncPhaseRateLimited, "", processed, total)
+			}
+			s.resumeSync(progressCallback)
+			return
+		}
+		if err := s.checkpointRepo.Clear(s.ctx, models.SyncCheckpointSteamGames); err != nil {
+			log.Printf("Warning: Failed to clear sync checkpoint: %v", err)
+		}
+
 		// Count multiplayer games
 		multiplayerCount := 0
 		for _, game := range games {
@@ -1276,10 +1571,10 @@ func (s *GameService) runSync(progressCallback SyncProgressCallback) {
 			}
 		}
 
-		log.Printf("GameService: Sync batch complete. Synced %d games (%d multiplayer)", totalToFetch, multiplayerCount)
+		log.Printf("GameService: Sync batch %s complete. Synced %d games (%d multiplayer)", batchID, totalToFetch, multiplayerCount)
 
 		// Check if there are more games to sync (new users may have joined during sync)
-		remainingCount, err := s.gameCacheRepo.CountGamesNeedingSync(gameCacheMaxAge, failedFetchRetryDelay)
+		remainingCount, err := s.gameCacheRepo.CountGamesNeedingSync(s.ctx, gameCacheMaxAge, failedFetchRetryDelay)
 		if err != nil {
 			log.Printf("GameService: Failed to count remaining games: %v", err)
 		} else if remainingCount > 0 {
@@ -1300,8 +1595,12 @@ func (s *GameService) runSync(progressCallback SyncProgressCallback) {
 	}()
 }
 
-// fetchGameCategoriesWithProgress fetches categories with progress callback
-func (s *GameService) fetchGameCategoriesWithProgress(games []*models.Game, progressCallback func(processed int, currentGame string)) {
+// fetchGameCategoriesWithProgress fetches the store data of all games with a bounded worker pool
+// All workers share the Steam Store rate limiter, a 429 response stops handing out further games.
+// processed passed to the callback counts the finished games at the start of the list,
+// so every game before that index is done even though the workers finish out of order.
+// Canceling ctx stops handing out games, games whose requests were canceled don't count as finished.
+func (s *GameService) fetchGameCategoriesWithProgress(ctx context.Context, games []*models.Game, progressCallback func(processed int, currentGame string)) {
 	if len(games) == 0 {
 		return
 	}
@@ -1311,60 +1610,107 @@ func (s *GameService) fetchGameCategoriesWithProgress(games []*models.Game, prog
 		return
 	}
 
-	const delayBetweenRequests = 300 * time.Millisecond
-
-	for i, game := range games {
-		if s.isRateLimited() {
-			log.Printf("Rate limit hit - stopping category fetches")
-			return
-		}
+	workers := min(s.cfg.SteamSyncConcurrency, len(games))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
 
-		if progressCallback != nil {
-			progressCallback(i, game.Name)
-		}
+	var mu sync.Mutex
+	finished := make([]bool, len(games))
+	processed := 0
 
-		storeData, err := s.fetchGameCategoriesFromStore(game.AppID)
-		if err != nil {
-			log.Printf("Could not fetch data for %s (%d): %v", game.Name, game.AppID, err)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				s.syncGameStoreData(ctx, games[i])
+				if ctx.Err() != nil {
+					continue
+				}
 
-			if strings.Contains(err.Error(), "game not found") || strings.Contains(err.Error(), "not accessible") {
-				log.Printf("Game %s (%d) appears to be unavailable - caching failure", game.Name, game.AppID)
-				if cacheErr := s.gameCacheRepo.UpsertWithStatus(game.AppID, game.Name, []string{}, nil, true); cacheErr != nil {
-					log.Printf("Failed to cache failed fetch for game %d: %v", game.AppID, cacheErr)
+				mu.Lock()
+				finished[i] = true
+				for processed < len(games) && finished[processed] {
+					processed++
+				}
+				if progressCallback != nil {
+					progressCallback(processed, games[i].Name)
 				}
+				mu.Unlock()
 			}
-			continue
-		}
+		}()
+	}
 
-		game.Categories = storeData.Categories
-		if storeData.Name != "" {
-			game.Name = storeData.Name
+	for i := range games {
+		if s.isRateLimited() {
+			log.Printf("Rate limit hit - stopping category fetches")
+			break
 		}
-		game.IsFree = storeData.IsFree
-		game.PriceCents = storeData.PriceCents
-		game.OriginalCents = storeData.OriginalCents
-		game.DiscountPercent = storeData.DiscountPercent
-		game.PriceFormatted = storeData.PriceFormatted
-		game.ReviewScore = storeData.ReviewScore
-
-		// Save to DB cache
-		priceInfo := &repository.GamePriceInfo{
-			IsFree:          storeData.IsFree,
-			PriceCents:      storeData.PriceCents,
-			OriginalCents:   storeData.OriginalCents,
-			DiscountPercent: storeData.DiscountPercent,
-			PriceFormatted:  storeData.PriceFormatted,
-			ReviewScore:     storeData.ReviewScore,
+		if ctx.Err() != nil {
+			log.Printf("Sync canceled - stopping category fetches")
+			break
 		}
-		if err := s.gameCacheRepo.Upsert(game.AppID, game.Name, storeData.Categories, priceInfo); err != nil {
-			log.Printf("Failed to cache game %d: %v", game.AppID, err)
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if progressCallback != nil && processed == len(games) {
+		progressCallback(len(games), "")
+	}
+}
+
+// syncGameStoreData fetches the store data of a single game and stores it in the DB cache
+// Games that no longer exist on the store are cached as failed fetch, canceled fetches are not stored
+func (s *GameService) syncGameStoreData(ctx context.Context, game *models.Game) {
+	storeData, err := s.fetchGameCategoriesFromStore(ctx, game.AppID)
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		log.Printf("Could not fetch data for %s (%d): %v", game.Name, game.AppID, err)
+
+		if strings.Contains(err.Error(), "game not found") || strings.Contains(err.Error(), "not accessible") {
+			log.Printf("Game %s (%d) appears to be unavailable - caching failure", game.Name, game.AppID)
+			if cacheErr := s.gameCacheRepo.UpsertWithStatus(ctx, game.AppID, game.Name, []string{}, nil, true); cacheErr != nil {
+				log.Printf("Failed to cache failed fetch for game %d: %v", game.AppID, cacheErr)
+			}
 		}
+		return
+	}
 
-		time.Sleep(delayBetweenRequests)
+	game.Categories = storeData.Categories
+	if storeData.Name != "" {
+		game.Name = storeData.Name
 	}
+	game.IsFree = storeData.IsFree
+	game.PriceCents = storeData.PriceCents
+	game.OriginalCents = storeData.OriginalCents
+	game.DiscountPercent = storeData.DiscountPercent
+	game.PriceFormatted = storeData.PriceFormatted
+	game.ReviewScore = storeData.ReviewScore
+	game.ReviewScoreDesc = storeData.ReviewScoreDesc
+	game.ReviewCount = storeData.ReviewCount
+	game.MaxPlayers = storeData.MaxPlayers
+	game.Genres = storeData.Genres
+	game.Tags = storeData.Tags
 
-	if progressCallback != nil {
-		progressCallback(len(games), "")
+	// Save to DB cache
+	priceInfo := &repository.GamePriceInfo{
+		IsFree:          storeData.IsFree,
+		PriceCents:      storeData.PriceCents,
+		OriginalCents:   storeData.OriginalCents,
+		DiscountPercent: storeData.DiscountPercent,
+		PriceFormatted:  storeData.PriceFormatted,
+		ReviewScore:     storeData.ReviewScore,
+		ReviewScoreDesc: storeData.ReviewScoreDesc,
+		ReviewCount:     storeData.ReviewCount,
+		MaxPlayers:      storeData.MaxPlayers,
+		Genres:          storeData.Genres,
+		Tags:            storeData.Tags,
+	}
+	if err := s.gameCacheRepo.Upsert(ctx, game.AppID, game.Name, storeData.Categories, priceInfo); err != nil {
+		log.Printf("Failed to cache game %d: %v", game.AppID, err)
 	}
 }
 
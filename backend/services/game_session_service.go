@@ -0,0 +1,365 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/auth"
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/websocket"
+)
+
+// presenceChunkSize is the maximum number of profiles per GetPlayerSummaries request
+const presenceChunkSize = 100
+
+// GameSessionService records which games the players are in and summarizes the time spent per game and player
+// Sessions are started by the players or inferred from the "currently playing" status of their Steam profiles
+type GameSessionService struct {
+	cfg           *config.Config
+	wsHub         *websocket.Hub
+	sessionRepo   *repository.GameSessionRepository
+	userRepo      *repository.UserRepository
+	gameCacheRepo *repository.GameCacheRepository
+	steamAPI      *auth.SteamAPIClient
+	gameService   *GameService
+	ticker        *time.Ticker
+	done          chan bool
+}
+
+// NewGameSessionService creates a new game session service
+func NewGameSessionService(cfg *config.Config, wsHub *websocket.Hub, sessionRepo *repository.GameSessionRepository, userRepo *repository.UserRepository, gameCacheRepo *repository.GameCacheRepository, steamAPI *auth.SteamAPIClient, gameService *GameService) *GameSessionService {
+	return &GameSessionService{
+		cfg:           cfg,
+		wsHub:         wsHub,
+		sessionRepo:   sessionRepo,
+		userRepo:      userRepo,
+		gameCacheRepo: gameCacheRepo,
+		steamAPI:      steamAPI,
+		gameService:   gameService,
+		done:          make(chan bool),
+	}
+}
+
+// Start begins checking the Steam playing status periodically, the first check runs in the background right away
+func (s *GameSessionService) Start() {
+	if s.cfg.GameSessionPresenceMinutes <= 0 || s.cfg.SteamAPIKey == "" {
+		log.Println("Game session presence tracking disabled, only manual sessions are recorded")
+		return
+	}
+
+	interval := time.Duration(s.cfg.GameSessionPresenceMinutes) * time.Minute
+	s.ticker = time.NewTicker(interval)
+	go s.watch()
+	log.Printf("Game session service started (presence check every %v)", interval)
+}
+
+// Stop stops checking the Steam playing status
+func (s *GameSessionService) Stop() {
+	if s.ticker == nil {
+		return
+	}
+	s.ticker.Stop()
+	s.done <- true
+	log.Println("Game session service stopped")
+}
+
+// watch checks the playing status on start and on every tick
+func (s *GameSessionService) watch() {
+	s.checkPresence(context.Background())
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ticker.C:
+			s.checkPresence(context.Background())
+		}
+	}
+}
+
+// StartSession ends the running session of a player and starts a manual session in the given game
+func (s *GameSessionService) StartSession(ctx context.Context, userID uint64, appID int) error {
+	if err := s.sessionRepo.Start(ctx, userID, appID, models.GameSessionSourceManual); err != nil {
+		return err
+	}
+	s.broadcast(ctx)
+	return nil
+}
+
+// EndSession ends the running session of a player, returns false if the player was not in a game
+func (s *GameSessionService) EndSession(ctx context.Context, userID uint64) (bool, error) {
+	ended, err := s.sessionRepo.End(ctx, userID)
+	if err != nil || !ended {
+		return false, err
+	}
+	s.broadcast(ctx)
+	return true, nil
+}
+
+// GetActiveGames returns the games that are currently played, most players first
+func (s *GameSessionService) GetActiveGames(ctx context.Context) ([]models.ActiveGame, error) {
+	sessions, err := s.sessionRepo.GetActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := s.gameNames(ctx, sessions)
+	games := []models.ActiveGame{}
+	index := make(map[int]int) // appID -> position in games
+	for _, session := range sessions {
+		i, ok := index[session.AppID]
+		if !ok {
+			i = len(games)
+			index[session.AppID] = i
+			games = append(games, models.ActiveGame{
+				AppID:     session.AppID,
+				GameName:  names[session.AppID],
+				LaunchURL: models.SteamLaunchURL(session.AppID),
+				Players:   []models.ActivePlayer{},
+			})
+		}
+		games[i].Players = append(games[i].Players, models.ActivePlayer{
+			PublicUser: session.User,
+			Source:     session.Source,
+			Since:      session.StartedAt,
+		})
+	}
+
+	// Sessions are ordered by start, games with the same number of players keep the longest running first
+	sort.SliceStable(games, func(i, j int) bool {
+		return len(games[i].Players) > len(games[j].Players)
+	})
+	return games, nil
+}
+
+// GetStats returns the time spent per game and per player over all sessions, running sessions count up to now
+func (s *GameSessionService) GetStats(ctx context.Context) (*models.GameSessionStats, error) {
+	sessions, err := s.sessionRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	names := s.gameNames(ctx, sessions)
+
+	type playerEntry struct {
+		stats   models.GameSessionPlayerStats
+		minutes map[int]int // appID -> minutes
+	}
+	intervals := make(map[int][][2]time.Time) // appID -> sessions of all players
+	playerMinutes := make(map[int]int)        // appID -> minutes of all players
+	gamePlayers := make(map[int]map[uint64]bool)
+	players := make(map[uint64]*playerEntry)
+	for _, session := range sessions {
+		end := now
+		if session.EndedAt != nil {
+			end = *session.EndedAt
+		}
+		minutes := session.Minutes(now)
+
+		intervals[session.AppID] = append(intervals[session.AppID], [2]time.Time{session.StartedAt, end})
+		playerMinutes[session.AppID] += minutes
+		if gamePlayers[session.AppID] == nil {
+			gamePlayers[session.AppID] = make(map[uint64]bool)
+		}
+		gamePlayers[session.AppID][session.User.ID] = true
+
+		entry, ok := players[session.User.ID]
+		if !ok {
+			entry = &playerEntry{
+				stats:   models.GameSessionPlayerStats{User: session.User},
+				minutes: make(map[int]int),
+			}
+			players[session.User.ID] = entry
+		}
+		entry.minutes[session.AppID] += minutes
+		entry.stats.TotalMinutes += minutes
+	}
+
+	stats := &models.GameSessionStats{
+		Games:   make([]models.GameSessionGameStats, 0, len(intervals)),
+		Players: make([]models.GameSessionPlayerStats, 0, len(players)),
+	}
+	for appID, spans := range intervals {
+		stats.Games = append(stats.Games, models.GameSessionGameStats{
+			AppID:         appID,
+			GameName:      names[appID],
+			Minutes:       unionMinutes(spans),
+			PlayerMinutes: playerMinutes[appID],
+			PlayerCount:   len(gamePlayers[appID]),
+		})
+	}
+	sort.Slice(stats.Games, func(i, j int) bool {
+		if stats.Games[i].Minutes != stats.Games[j].Minutes {
+			return stats.Games[i].Minutes > stats.Games[j].Minutes
+		}
+		return stats.Games[i].AppID < stats.Games[j].AppID
+	})
+
+	for _, entry := range players {
+		games := make([]models.GameSessionPlayerGame, 0, len(entry.minutes))
+		for appID, minutes := range entry.minutes {
+			games = append(games, models.GameSessionPlayerGame{
+				AppID:    appID,
+				GameName: names[appID],
+				Minutes:  minutes,
+			})
+		}
+		sort.Slice(games, func(i, j int) bool {
+			if games[i].Minutes != games[j].Minutes {
+				return games[i].Minutes > games[j].Minutes
+			}
+			return games[i].AppID < games[j].AppID
+		})
+		entry.stats.Games = games
+		stats.Players = append(stats.Players, entry.stats)
+	}
+	sort.Slice(stats.Players, func(i, j int) bool {
+		if stats.Players[i].TotalMinutes != stats.Players[j].TotalMinutes {
+			return stats.Players[i].TotalMinutes > stats.Players[j].TotalMinutes
+		}
+		return stats.Players[i].User.Username < stats.Players[j].User.Username
+	})
+
+	return stats, nil
+}
+
+// checkPresence starts and ends presence sessions from the "currently playing" status of the Steam profiles
+// Manual sessions are left alone, the player ends them or replaces them with another game
+func (s *GameSessionService) checkPresence(ctx context.Context) {
+	users, err := s.userRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to get users for game sessions: %v", err)
+		return
+	}
+
+	// Players missing in the response (failed requests, fake users) keep their current session
+	playing := make(map[string]int) // steamID -> appID, 0 if not in a game
+	for start := 0; start < len(users); start += presenceChunkSize {
+		end := min(start+presenceChunkSize, len(users))
+		steamIDs := make([]string, 0, end-start)
+		for _, user := range users[start:end] {
+			steamIDs = append(steamIDs, user.SteamID)
+		}
+
+		summaries, err := s.steamAPI.GetPlayerSummaries(steamIDs)
+		if err != nil {
+			log.Printf("Warning: Failed to get Steam playing status: %v", err)
+			continue
+		}
+		for _, summary := range summaries {
+			appID, _ := strconv.Atoi(summary.GameID)
+			playing[summary.SteamID] = appID
+		}
+	}
+
+	active, err := s.sessionRepo.GetActive(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to get running game sessions: %v", err)
+		return
+	}
+	running := make(map[uint64]models.GameSession, len(active))
+	for _, session := range active {
+		running[session.User.ID] = session
+	}
+
+	started, ended := 0, 0
+	for _, user := range users {
+		appID, known := playing[user.SteamID]
+		if !known {
+			continue
+		}
+		session, inGame := running[user.ID]
+		if inGame && session.Source == models.GameSessionSourceManual {
+			continue
+		}
+
+		switch {
+		case appID == 0 && inGame:
+			if _, err := s.sessionRepo.End(ctx, user.ID); err != nil {
+				log.Printf("Warning: Failed to end game session of user %d: %v", user.ID, err)
+				continue
+			}
+			ended++
+		case appID != 0 && (!inGame || session.AppID != appID):
+			if err := s.sessionRepo.Start(ctx, user.ID, appID, models.GameSessionSourcePresence); err != nil {
+				log.Printf("Warning: Failed to start game session of user %d: %v", user.ID, err)
+				continue
+			}
+			started++
+		}
+	}
+
+	if started > 0 || ended > 0 {
+		log.Printf("Game sessions from Steam playing status: %d started, %d ended", started, ended)
+		s.broadcast(ctx)
+	}
+}
+
+// broadcast sends the games that are currently played to all clients
+func (s *GameSessionService) broadcast(ctx context.Context) {
+	games, err := s.GetActiveGames(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to get active games for broadcast: %v", err)
+		return
+	}
+	s.wsHub.BroadcastGameSessions(&websocket.GameSessionsPayload{
+		Games: games,
+	})
+}
+
+// gameNames returns the names of the games of the sessions by app ID
+// Names come from the multiplayer games list, other games are looked up in the game cache
+func (s *GameSessionService) gameNames(ctx context.Context, sessions []models.GameSession) map[int]string {
+	names := make(map[int]string)
+	if games, _, err := s.gameService.GetMultiplayerGamesCached(ctx); err == nil && games != nil {
+		for _, list := range [][]models.Game{games.PinnedGames, games.AllGames} {
+			for _, game := range list {
+				names[game.AppID] = game.Name
+			}
+		}
+	}
+
+	for _, session := range sessions {
+		if _, ok := names[session.AppID]; ok {
+			continue
+		}
+		name := fmt.Sprintf("App %d", session.AppID)
+		if cache, err := s.gameCacheRepo.GetByAppID(ctx, session.AppID); err == nil && cache != nil && cache.Name != "" {
+			name = cache.Name
+		}
+		names[session.AppID] = name
+	}
+	return names
+}
+
+// unionMinutes returns the minutes covered by at least one of the time spans
+func unionMinutes(spans [][2]time.Time) int {
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i][0].Before(spans[j][0])
+	})
+
+	var total time.Duration
+	var start, end time.Time
+	for i, span := range spans {
+		if i > 0 && !span[0].After(end) {
+			if span[1].After(end) {
+				end = span[1]
+			}
+			continue
+		}
+		if i > 0 {
+			total += end.Sub(start)
+		}
+		start, end = span[0], span[1]
+	}
+	if len(spans) > 0 {
+		total += end.Sub(start)
+	}
+	return int(total.Minutes())
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"encoding/json"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/guided-traffic/rate-your-mate/backend/defaults"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+// SourceLanguage is the language the built-in and admin-entered texts are written in
+const SourceLanguage = "de"
+
+// FallbackLanguage is used for texts without a translation in the requested language
+const FallbackLanguage = "en"
+
+// I18nService translates server texts like achievement names, categories and badges
+// Translations are loaded at startup from one <language>.json file per language,
+// each mapping keys like "achievement.<id>.name" to the translated text
+type I18nService struct {
+	translations map[string]map[string]string // language -> key -> text
+	mu           sync.RWMutex
+	dirPath      string
+}
+
+// NewI18nService creates a new i18n service
+// dirPath is the directory containing the translation files
+func NewI18nService(dirPath string) *I18nService {
+	service := &I18nService{
+		translations: make(map[string]map[string]string),
+		dirPath:      dirPath,
+	}
+	service.loadTranslations()
+	return service
+}
+
+// loadTranslations loads all translation files from the directory
+func (s *I18nService) loadTranslations() {
+	dir := os.DirFS(s.dirPath)
+	files, err := fs.Glob(dir, "*.json")
+	if err != nil || len(files) == 0 {
+		// Fall back to the translations embedded into the binary
+		dir, _ = fs.Sub(defaults.FS, "i18n")
+		files, err = fs.Glob(dir, "*.json")
+		if err != nil || len(files) == 0 {
+			log.Printf("No translation files found in %s, serving %s texts only", s.dirPath, SourceLanguage)
+			return
+		}
+		log.Printf("No translation files found in %s, using embedded translations", s.dirPath)
+	}
+
+	translations := make(map[string]map[string]string, len(files))
+	for _, file := range files {
+		data, err := fs.ReadFile(dir, file)
+		if err != nil {
+			log.Printf("Error reading translation file %s: %v", file, err)
+			continue
+		}
+
+		var texts map[string]string
+		if err := json.Unmarshal(data, &texts); err != nil {
+			log.Printf("Error parsing translation file %s: %v", file, err)
+			continue
+		}
+
+		language := strings.ToLower(strings.TrimSuffix(filepath.Base(file), ".json"))
+		translations[language] = texts
+		log.Printf("Loaded %d translations for language %s", len(texts), language)
+	}
+
+	s.mu.Lock()
+	s.translations = translations
+	s.mu.Unlock()
+}
+
+// Languages returns all supported languages, sorted
+func (s *I18nService) Languages() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	languages := []string{SourceLanguage}
+	for language := range s.translations {
+		if language != SourceLanguage {
+			languages = append(languages, language)
+		}
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// IsSupported checks if texts can be served in a language
+func (s *I18nService) IsSupported(language string) bool {
+	if language == SourceLanguage {
+		return true
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.translations[language]
+	return ok
+}
+
+// Negotiate picks the best supported language of an Accept-Language header
+// Without a header the source language is used, unsupported languages get the fallback language
+func (s *I18nService) Negotiate(acceptLanguage string) string {
+	if strings.TrimSpace(acceptLanguage) == "" {
+		return SourceLanguage
+	}
+
+	type candidate struct {
+		tag     string
+		quality float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			if q, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+		if quality > 0 {
+			candidates = append(candidates, candidate{tag: tag, quality: quality})
+		}
+	}
+
+	// Keep the header order for equal qualities
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	for _, c := range candidates {
+		if s.IsSupported(c.tag) {
+			return c.tag
+		}
+		// "en-US" matches "en"
+		if base, _, found := strings.Cut(c.tag, "-"); found && s.IsSupported(base) {
+			return base
+		}
+	}
+	return FallbackLanguage
+}
+
+// Translate returns the text of a key in a language
+// Missing translations fall back to the fallback language and then to the given source text
+func (s *I18nService) Translate(language, key, text string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if translated, ok := s.translations[language][key]; ok {
+		return translated
+	}
+	if language == SourceLanguage {
+		return text
+	}
+	if translated, ok := s.translations[FallbackLanguage][key]; ok {
+		return translated
+	}
+	return text
+}
+
+// Achievement returns an achievement with translated name and description
+func (s *I18nService) Achievement(language string, a models.Achievement) models.Achievement {
+	a.Name = s.Translate(language, "achievement."+a.ID+".name", a.Name)
+	a.Description = s.Translate(language, "achievement."+a.ID+".description", a.Description)
+	return a
+}
+
+// Achievements translates a list of achievements, the list itself is not modified
+func (s *I18nService) Achievements(language string, list []models.Achievement) []models.Achievement {
+	translated := make([]models.Achievement, len(list))
+	for i, a := range list {
+		translated[i] = s.Achievement(language, a)
+	}
+	return translated
+}
+
+// Category returns an achievement category with translated name
+func (s *I18nService) Category(language string, category models.AchievementCategory) models.AchievementCategory {
+	category.Name = s.Translate(language, "category."+category.ID, category.Name)
+	return category
+}
+
+// Badge returns a meta-badge with translated name and description
+func (s *I18nService) Badge(language string, badge models.Badge) models.Badge {
+	badge.Name = s.Translate(language, "badge."+badge.ID+".name", badge.Name)
+	badge.Description = s.Translate(language, "badge."+badge.ID+".description", badge.Description)
+	return badge
+}
+
+// Reload reloads the translations from disk
+func (s *I18nService) Reload() {
+	s.loadTranslations()
+}
@@ -1,24 +1,47 @@
 package services
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
 const (
 	gameImagesDir = "data/game_images"
 	steamCDNURL   = "https://steamcdn-a.akamaihd.net/steam/apps"
+
+	// gameImageOverridesDir holds the cover images uploaded by admins, below the images directory
+	gameImageOverridesDir = "overrides"
+	// MaxGameImageOverrideBytes is the maximum size of an uploaded cover image
+	MaxGameImageOverrideBytes = 5 << 20
 )
 
+// gameImageOverrideTypes maps the accepted image types of uploaded cover images to their file extension
+var gameImageOverrideTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// ErrUnsupportedImage is returned when an uploaded cover image is not a JPEG, PNG or WebP image
+var ErrUnsupportedImage = errors.New("image must be a JPEG, PNG or WebP file")
+
 // ImageCacheService handles caching of game images locally
+// Cover images uploaded by admins override the Steam image of a game
 type ImageCacheService struct {
 	httpClient *http.Client
 	baseDir    string
+
+	mu        sync.RWMutex
+	overrides map[int]string // appID -> file name of the uploaded cover image
 }
 
 // NewImageCacheService creates a new image cache service
@@ -27,17 +50,47 @@ func NewImageCacheService() *ImageCacheService {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		baseDir: gameImagesDir,
+		baseDir:   gameImagesDir,
+		overrides: make(map[int]string),
 	}
 
 	// Ensure the images directory exists at startup
 	if err := svc.ensureDir(); err != nil {
 		log.Printf("Warning: Could not create game images directory: %v", err)
 	}
+	svc.loadOverrides()
 
 	return svc
 }
 
+// loadOverrides reads the uploaded cover images from disk
+func (s *ImageCacheService) loadOverrides() {
+	entries, err := os.ReadDir(s.overridesDir())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Warning: Could not read game image overrides: %v", err)
+		}
+		return
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		appID, err := strconv.Atoi(strings.TrimSuffix(name, filepath.Ext(name)))
+		if err != nil || entry.IsDir() {
+			continue
+		}
+		s.overrides[appID] = name
+	}
+	if len(s.overrides) > 0 {
+		log.Printf("Loaded %d game image overrides", len(s.overrides))
+	}
+}
+
+// overridesDir returns the directory of the uploaded cover images
+func (s *ImageCacheService) overridesDir() string {
+	return filepath.Join(s.baseDir, gameImageOverridesDir)
+}
+
 // ensureDir creates the game images directory if it doesn't exist
 func (s *ImageCacheService) ensureDir() error {
 	return os.MkdirAll(s.baseDir, 0755)
@@ -56,11 +109,90 @@ func (s *ImageCacheService) HasImage(appID int) bool {
 }
 
 // GetLocalImageURL returns the URL path for serving the cached image
-// This is the path that will be used by the frontend
+// This is the path that will be used by the frontend, an uploaded cover image adds its version so browsers reload it
 func (s *ImageCacheService) GetLocalImageURL(appID int) string {
+	if version := s.overrideVersion(appID); version != "" {
+		return fmt.Sprintf("/api/v1/games/images/%d.jpg?v=%s", appID, version)
+	}
 	return fmt.Sprintf("/api/v1/games/images/%d.jpg", appID)
 }
 
+// GetOverridePath returns the file path of the cover image uploaded for a game, false if there is none
+func (s *ImageCacheService) GetOverridePath(appID int) (string, bool) {
+	s.mu.RLock()
+	name, ok := s.overrides[appID]
+	s.mu.RUnlock()
+	if !ok {
+		return "", false
+	}
+	return filepath.Join(s.overridesDir(), name), true
+}
+
+// overrideVersion returns the modification time of the uploaded cover image as version, empty if there is none
+func (s *ImageCacheService) overrideVersion(appID int) string {
+	path, ok := s.GetOverridePath(appID)
+	if !ok {
+		return ""
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+	return strconv.FormatInt(info.ModTime().Unix(), 36)
+}
+
+// SetOverride stores an uploaded cover image for a game, it replaces the Steam image until it is deleted
+// Returns ErrUnsupportedImage if the data is not a JPEG, PNG or WebP image
+func (s *ImageCacheService) SetOverride(appID int, data []byte) error {
+	ext, ok := gameImageOverrideTypes[http.DetectContentType(data)]
+	if !ok {
+		return ErrUnsupportedImage
+	}
+	if err := os.MkdirAll(s.overridesDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create image overrides directory: %w", err)
+	}
+
+	// Write to a temporary file first, so the old image is served until the new one is complete
+	name := fmt.Sprintf("%d%s", appID, ext)
+	tmpPath := filepath.Join(s.overridesDir(), name+".tmp")
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to save image override: %w", err)
+	}
+	if err := os.Rename(tmpPath, filepath.Join(s.overridesDir(), name)); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to save image override: %w", err)
+	}
+
+	s.mu.Lock()
+	previous := s.overrides[appID]
+	s.overrides[appID] = name
+	s.mu.Unlock()
+
+	// An image of another type is replaced by the new file
+	if previous != "" && previous != name {
+		os.Remove(filepath.Join(s.overridesDir(), previous))
+	}
+	return nil
+}
+
+// DeleteOverride removes the uploaded cover image of a game, the Steam image is served again
+// Returns false if the game has no uploaded cover image
+func (s *ImageCacheService) DeleteOverride(appID int) (bool, error) {
+	s.mu.Lock()
+	name, ok := s.overrides[appID]
+	delete(s.overrides, appID)
+	s.mu.Unlock()
+	if !ok {
+		return false, nil
+	}
+
+	if err := os.Remove(filepath.Join(s.overridesDir(), name)); err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to delete image override: %w", err)
+	}
+	return true, nil
+}
+
 // GetSteamImageURL returns the original Steam CDN URL for a game's header image
 func (s *ImageCacheService) GetSteamImageURL(appID int) string {
 	return fmt.Sprintf("%s/%d/header.jpg", steamCDNURL, appID)
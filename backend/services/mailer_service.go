@@ -0,0 +1,187 @@
+package services
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/defaults"
+)
+
+// smtpTimeout limits connecting to and talking with the SMTP server
+const smtpTimeout = 30 * time.Second
+
+// mailTemplateFuncs are available in all mail templates
+var mailTemplateFuncs = map[string]interface{}{
+	"join": strings.Join,
+}
+
+// MailerService renders e-mails from the embedded templates (defaults/mail) and sends them via SMTP
+// Every mail has a plain text part (<name>.txt) and an HTML part (<name>.html)
+type MailerService struct {
+	cfg  *config.Config
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// NewMailerService creates a new mailer service
+func NewMailerService(cfg *config.Config) *MailerService {
+	return &MailerService{
+		cfg:  cfg,
+		text: texttemplate.Must(texttemplate.New("mail").Funcs(mailTemplateFuncs).ParseFS(defaults.FS, "mail/*.txt")),
+		html: htmltemplate.Must(htmltemplate.New("mail").Funcs(mailTemplateFuncs).ParseFS(defaults.FS, "mail/*.html")),
+	}
+}
+
+// Enabled reports whether an SMTP server is configured
+func (s *MailerService) Enabled() bool {
+	return s.cfg.SMTPHost != ""
+}
+
+// Send renders the template with the given name and mails it to the recipients
+func (s *MailerService) Send(to []string, subject, name string, data interface{}) error {
+	if !s.Enabled() {
+		return errors.New("mail delivery is not configured (SMTP_HOST)")
+	}
+	if len(to) == 0 {
+		return errors.New("no recipients")
+	}
+
+	from, err := mail.ParseAddress(s.cfg.SMTPFrom)
+	if err != nil {
+		return fmt.Errorf("invalid SMTP_FROM %q: %w", s.cfg.SMTPFrom, err)
+	}
+
+	var text, html bytes.Buffer
+	if err := s.text.ExecuteTemplate(&text, name+".txt", data); err != nil {
+		return fmt.Errorf("failed to render mail %s: %w", name, err)
+	}
+	if err := s.html.ExecuteTemplate(&html, name+".html", data); err != nil {
+		return fmt.Errorf("failed to render mail %s: %w", name, err)
+	}
+
+	msg, err := buildMail(from, to, subject, text.Bytes(), html.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to build mail %s: %w", name, err)
+	}
+
+	if err := s.deliver(from.Address, to, msg); err != nil {
+		return fmt.Errorf("failed to send mail %s: %w", name, err)
+	}
+	return nil
+}
+
+// deliver sends a message via the configured SMTP server
+// Port 465 uses implicit TLS, other ports are upgraded with STARTTLS when the server offers it
+func (s *MailerService) deliver(from string, to []string, msg []byte) error {
+	addr := net.JoinHostPort(s.cfg.SMTPHost, strconv.Itoa(s.cfg.SMTPPort))
+	tlsConfig := &tls.Config{ServerName: s.cfg.SMTPHost}
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+
+	var conn net.Conn
+	var err error
+	if s.cfg.SMTPPort == 465 {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(smtpTimeout))
+
+	client, err := smtp.NewClient(conn, s.cfg.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if s.cfg.SMTPPort != 465 {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return err
+			}
+		}
+	}
+	if s.cfg.SMTPUsername != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.SMTPUsername, s.cfg.SMTPPassword, s.cfg.SMTPHost)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := client.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// buildMail assembles a multipart/alternative message with a text and an HTML part
+func buildMail(from *mail.Address, to []string, subject string, text, html []byte) ([]byte, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=utf-8", text},
+		{"text/html; charset=utf-8", html},
+	} {
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qw := quotedprintable.NewWriter(pw)
+		if _, err := qw.Write(part.content); err != nil {
+			return nil, err
+		}
+		if err := qw.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from.String())
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", mw.Boundary())
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/grandcat/zeroconf"
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+)
+
+// MDNSServiceType is the DNS-SD service type advertised on the LAN
+const MDNSServiceType = "_rateyourmate._tcp"
+
+// MDNSService advertises the backend on the local network via mDNS/DNS-SD
+// so clients on the party LAN can discover the instance without knowing its IP
+type MDNSService struct {
+	cfg    *config.Config
+	server *zeroconf.Server
+}
+
+// NewMDNSService creates a new mDNS advertisement service
+func NewMDNSService(cfg *config.Config) *MDNSService {
+	return &MDNSService{
+		cfg: cfg,
+	}
+}
+
+// Start registers the service on the LAN (no-op if mDNS is disabled)
+func (s *MDNSService) Start() error {
+	if !s.cfg.MDNSEnabled {
+		return nil
+	}
+
+	port, err := strconv.Atoi(s.cfg.Port)
+	if err != nil {
+		return fmt.Errorf("invalid port for mDNS advertisement: %w", err)
+	}
+
+	// TXT records carry the URLs so clients don't need to guess the scheme or path
+	txt := []string{
+		"url=" + s.cfg.BackendURL,
+		"frontend=" + s.cfg.FrontendURL,
+		"api=/api/v1",
+	}
+
+	server, err := zeroconf.Register(s.cfg.MDNSInstanceName, MDNSServiceType, "local.", port, txt, nil)
+	if err != nil {
+		return fmt.Errorf("failed to register mDNS service: %w", err)
+	}
+	s.server = server
+
+	log.Printf("mDNS: Advertising %q as %s on port %d", s.cfg.MDNSInstanceName, MDNSServiceType, port)
+	return nil
+}
+
+// Stop removes the advertisement from the LAN
+func (s *MDNSService) Stop() {
+	if s.server != nil {
+		s.server.Shutdown()
+		s.server = nil
+		log.Println("mDNS: Advertisement stopped")
+	}
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/metrics"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+)
+
+// Limits of the organizer digest
+const (
+	digestTopMovers    = 5    // Players listed as top movers
+	digestPendingLimit = 1000 // Pending items counted per queue
+)
+
+// ErrDigestDisabled is returned when SMTP or the digest recipients are not configured
+var ErrDigestDisabled = errors.New("organizer digest is not configured (SMTP_HOST, DIGEST_RECIPIENTS)")
+
+// OrganizerDigest is the content of the organizer digest e-mail
+type OrganizerDigest struct {
+	From               string                 `json:"from"` // Start of the covered period in the event timezone
+	To                 string                 `json:"to"`   // End of the covered period in the event timezone
+	NewUsers           []models.User          `json:"new_users"`
+	ReportedVotes      []models.VoteAppeal    `json:"reported_votes"`   // Appeals submitted in the period
+	FlaggedAccounts    []models.AccountReview `json:"flagged_accounts"` // Accounts held for review in the period
+	SteamAPIErrors     int64                  `json:"steam_api_errors"` // Failed Steam API requests since the last digest or server start
+	TopMovers          []DigestMover          `json:"top_movers"`
+	PendingAppeals     int                    `json:"pending_appeals"`
+	PendingReviews     int                    `json:"pending_account_reviews"`
+	PendingSuggestions int                    `json:"pending_achievement_suggestions"`
+}
+
+// DigestMover is a player whose score changed the most in the digest period
+type DigestMover struct {
+	Username string `json:"username"`
+	Rank     int    `json:"rank"`   // Current rank
+	Points   int    `json:"points"` // Weighted net points received in the period
+}
+
+// OrganizerDigestService mails a daily summary to the organizers during the event
+// The event lasts from the day before COUNTDOWN_TARGET until the day after EVENT_END_AT, unset ends are open
+type OrganizerDigestService struct {
+	cfg            *config.Config
+	mailer         *MailerService
+	userRepo       *repository.UserRepository
+	voteRepo       *repository.VoteRepository
+	appealRepo     *repository.AppealRepository
+	reviewRepo     *repository.AccountReviewRepository
+	suggestionRepo *repository.AchievementSuggestionRepository
+	ticker         *time.Ticker
+	done           chan bool
+
+	mu              sync.Mutex
+	lastSentAt      time.Time // End of the period covered by the last digest
+	lastSentDay     string    // Event-local date of the last scheduled digest
+	lastSteamErrors int64     // Steam API error counter at the last digest
+}
+
+// NewOrganizerDigestService creates a new organizer digest service
+func NewOrganizerDigestService(cfg *config.Config, mailer *MailerService, userRepo *repository.UserRepository, voteRepo *repository.VoteRepository,
+	appealRepo *repository.AppealRepository, reviewRepo *repository.AccountReviewRepository, suggestionRepo *repository.AchievementSuggestionRepository) *OrganizerDigestService {
+	return &OrganizerDigestService{
+		cfg:            cfg,
+		mailer:         mailer,
+		userRepo:       userRepo,
+		voteRepo:       voteRepo,
+		appealRepo:     appealRepo,
+		reviewRepo:     reviewRepo,
+		suggestionRepo: suggestionRepo,
+		done:           make(chan bool),
+	}
+}
+
+// Enabled reports whether SMTP and the digest recipients are configured
+func (s *OrganizerDigestService) Enabled() bool {
+	return s.mailer.Enabled() && len(s.cfg.DigestRecipients) > 0
+}
+
+// Start begins the digest watcher, it is disabled without SMTP server or recipients
+func (s *OrganizerDigestService) Start() {
+	if !s.Enabled() {
+		log.Println("Organizer digest disabled")
+		return
+	}
+
+	// A restart after the digest time must not send today's digest again
+	now := time.Now().In(s.cfg.EventLocation)
+	if !now.Before(s.scheduledAt(now)) {
+		s.lastSentDay = now.Format(time.DateOnly)
+	}
+
+	s.ticker = time.NewTicker(time.Minute)
+	go s.watch()
+	log.Printf("Organizer digest started (daily at %s %s to %d recipients)", s.cfg.DigestTime, s.cfg.EventTimezone, len(s.cfg.DigestRecipients))
+}
+
+// Stop stops the digest watcher
+func (s *OrganizerDigestService) Stop() {
+	if s.ticker == nil {
+		return
+	}
+	s.ticker.Stop()
+	s.done <- true
+	log.Println("Organizer digest stopped")
+}
+
+// watch checks every minute whether the digest is due
+func (s *OrganizerDigestService) watch() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ticker.C:
+			s.checkDigest(context.Background())
+		}
+	}
+}
+
+// checkDigest sends the digest once per day after DIGEST_TIME while the event lasts
+func (s *OrganizerDigestService) checkDigest(ctx context.Context) {
+	now := time.Now().In(s.cfg.EventLocation)
+	if !s.duringEvent(now) || now.Before(s.scheduledAt(now)) {
+		return
+	}
+
+	day := now.Format(time.DateOnly)
+	s.mu.Lock()
+	due := s.lastSentDay != day
+	s.lastSentDay = day
+	s.mu.Unlock()
+	if !due {
+		return
+	}
+
+	if err := s.Send(ctx); err != nil {
+		log.Printf("Warning: Failed to send organizer digest: %v", err)
+	}
+}
+
+// scheduledAt returns the digest time on the day of now
+func (s *OrganizerDigestService) scheduledAt(now time.Time) time.Time {
+	t, _ := time.Parse("15:04", s.cfg.DigestTime) // Validated by config.Load
+	return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+}
+
+// duringEvent checks whether now is within a day of the event
+func (s *OrganizerDigestService) duringEvent(now time.Time) bool {
+	if !s.cfg.CountdownTarget.IsZero() && now.Before(s.cfg.CountdownTarget.Add(-24*time.Hour)) {
+		return false
+	}
+	if !s.cfg.EventEndAt.IsZero() && now.After(s.cfg.EventEndAt.Add(24*time.Hour)) {
+		return false
+	}
+	return true
+}
+
+// Build collects the digest for the period since the last digest (the last 24 hours for the first one)
+func (s *OrganizerDigestService) Build(ctx context.Context) (*OrganizerDigest, error) {
+	s.mu.Lock()
+	since := s.lastSentAt
+	steamErrors := metrics.SteamAPIErrors.Value() - s.lastSteamErrors
+	s.mu.Unlock()
+
+	now := time.Now()
+	if since.IsZero() {
+		since = now.Add(-24 * time.Hour)
+	}
+	return s.build(ctx, since, now, steamErrors)
+}
+
+// Send mails the digest to the organizers and starts a new period
+func (s *OrganizerDigestService) Send(ctx context.Context) error {
+	if !s.Enabled() {
+		return ErrDigestDisabled
+	}
+
+	steamErrors := metrics.SteamAPIErrors.Value()
+	now := time.Now()
+	digest, err := s.Build(ctx)
+	if err != nil {
+		return err
+	}
+
+	subject := "Rate your Mate: Tagesübersicht " + now.In(s.cfg.EventLocation).Format("02.01.2006")
+	if err := s.mailer.Send(s.cfg.DigestRecipients, subject, "organizer_digest", digest); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.lastSentAt = now
+	s.lastSteamErrors = steamErrors
+	s.mu.Unlock()
+
+	log.Printf("Organizer digest sent to %d recipients", len(s.cfg.DigestRecipients))
+	return nil
+}
+
+// build reads the digest data of the given period
+func (s *OrganizerDigestService) build(ctx context.Context, since, now time.Time, steamErrors int64) (*OrganizerDigest, error) {
+	digest := &OrganizerDigest{
+		From:           since.In(s.cfg.EventLocation).Format("02.01.2006 15:04"),
+		To:             now.In(s.cfg.EventLocation).Format("02.01.2006 15:04"),
+		SteamAPIErrors: steamErrors,
+	}
+
+	var err error
+	if digest.NewUsers, err = s.userRepo.GetCreatedSince(ctx, since); err != nil {
+		return nil, err
+	}
+	if digest.ReportedVotes, err = s.appealRepo.GetCreatedSince(ctx, since); err != nil {
+		return nil, err
+	}
+	if digest.FlaggedAccounts, err = s.reviewRepo.GetCreatedSince(ctx, since); err != nil {
+		return nil, err
+	}
+	if digest.TopMovers, err = s.topMovers(ctx, since); err != nil {
+		return nil, err
+	}
+
+	appeals, err := s.appealRepo.GetByStatus(ctx, models.AppealStatusPending, digestPendingLimit)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := s.reviewRepo.GetByStatus(ctx, models.AccountReviewPending, digestPendingLimit)
+	if err != nil {
+		return nil, err
+	}
+	suggestions, err := s.suggestionRepo.GetByStatus(ctx, models.SuggestionStatusPending, digestPendingLimit)
+	if err != nil {
+		return nil, err
+	}
+	digest.PendingAppeals = len(appeals)
+	digest.PendingReviews = len(reviews)
+	digest.PendingSuggestions = len(suggestions)
+
+	return digest, nil
+}
+
+// topMovers returns the ranked players with the largest score change since the given time
+func (s *OrganizerDigestService) topMovers(ctx context.Context, since time.Time) ([]DigestMover, error) {
+	points, err := s.voteRepo.GetWeightedNetVotesSince(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	rankings, err := s.voteRepo.GetGlobalRanking(ctx, s.cfg.RankingTieBreakers)
+	if err != nil {
+		return nil, err
+	}
+
+	movers := []DigestMover{}
+	for _, r := range rankings {
+		if p := points[r.User.ID]; p != 0 {
+			movers = append(movers, DigestMover{Username: r.User.Username, Rank: r.Rank, Points: p})
+		}
+	}
+	sort.SliceStable(movers, func(i, j int) bool {
+		pi, pj := movers[i].Points, movers[j].Points
+		return max(pi, -pi) > max(pj, -pj)
+	})
+	if len(movers) > digestTopMovers {
+		movers = movers[:digestTopMovers]
+	}
+	return movers, nil
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/websocket"
+)
+
+// PhaseService applies the settings profile of event phases when they start
+// Settings are kept in memory, so after a restart the profile of the current phase is applied again
+type PhaseService struct {
+	cfg       *config.Config
+	wsHub     *websocket.Hub
+	phaseRepo *repository.PhaseRepository
+
+	mu       sync.Mutex
+	phases   []models.EventPhase // Cached phases ordered by start time
+	activeID uint64              // ID of the phase whose profile was applied last (0 = none)
+
+	ticker *time.Ticker
+	done   chan bool
+}
+
+// NewPhaseService creates a new phase service
+func NewPhaseService(cfg *config.Config, wsHub *websocket.Hub, phaseRepo *repository.PhaseRepository) *PhaseService {
+	return &PhaseService{
+		cfg:       cfg,
+		wsHub:     wsHub,
+		phaseRepo: phaseRepo,
+		done:      make(chan bool),
+	}
+}
+
+// Start loads the phases and begins the phase watcher
+func (s *PhaseService) Start(ctx context.Context) {
+	if err := s.Reload(ctx); err != nil {
+		log.Printf("Warning: Failed to load event phases: %v", err)
+	}
+
+	// Check every second whether the next phase has started
+	s.ticker = time.NewTicker(1 * time.Second)
+	go s.watch()
+	log.Println("Phase service started")
+}
+
+// Stop stops the phase watcher
+func (s *PhaseService) Stop() {
+	if s.ticker != nil {
+		s.ticker.Stop()
+	}
+	s.done <- true
+	log.Println("Phase service stopped")
+}
+
+// Reload re-reads the phases from the database after an admin changed them
+// The current phase is applied again on the next tick, so edits to it take effect immediately
+func (s *PhaseService) Reload(ctx context.Context) error {
+	phases, err := s.phaseRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.phases = phases
+	s.activeID = 0
+	s.mu.Unlock()
+	return nil
+}
+
+// ActivePhaseID returns the ID of the phase whose settings profile is active (0 = none)
+func (s *PhaseService) ActivePhaseID() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.activeID
+}
+
+// watch continuously checks if a new phase has started
+func (s *PhaseService) watch() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ticker.C:
+			s.checkPhase()
+		}
+	}
+}
+
+// currentPhase returns the most recently started phase, nil if no phase has started yet
+func (s *PhaseService) currentPhase(now time.Time) *models.EventPhase {
+	var current *models.EventPhase
+	for i := range s.phases {
+		if s.phases[i].StartsAt.After(now) {
+			break
+		}
+		current = &s.phases[i]
+	}
+	return current
+}
+
+// checkPhase applies the settings profile of the current phase once it has started
+func (s *PhaseService) checkPhase() {
+	s.mu.Lock()
+	current := s.currentPhase(time.Now())
+	if current == nil {
+		// All phases were deleted or moved into the future - keep the settings, drop the label
+		wasActive := s.cfg.ActivePhase != ""
+		s.activeID = 0
+		s.cfg.ActivePhase = ""
+		s.mu.Unlock()
+		if wasActive {
+			log.Println("No event phase active anymore")
+			s.broadcastSettings()
+		}
+		return
+	}
+	if current.ID == s.activeID {
+		s.mu.Unlock()
+		return
+	}
+
+	phase := *current
+	s.activeID = phase.ID
+	s.cfg.CreditIntervalMinutes = phase.CreditIntervalMinutes
+	s.cfg.NegativeVotingDisabled = phase.NegativeVotingDisabled
+	s.cfg.VoteVisibilityMode = phase.VoteVisibilityMode
+	s.cfg.ActivePhase = phase.Name
+	s.mu.Unlock()
+
+	log.Printf("Applied settings profile of event phase '%s' (started at %v): credit_interval_minutes=%d, negative_voting_disabled=%v, vote_visibility_mode=%s",
+		phase.Name, phase.StartsAt, phase.CreditIntervalMinutes, phase.NegativeVotingDisabled, phase.VoteVisibilityMode)
+
+	s.broadcastSettings()
+}
+
+// broadcastSettings sends the current settings to all connected clients
+func (s *PhaseService) broadcastSettings() {
+	var countdownTarget *string
+	if !s.cfg.CountdownTarget.IsZero() {
+		formatted := s.cfg.CountdownTarget.In(s.cfg.EventLocation).Format(time.RFC3339)
+		countdownTarget = &formatted
+	}
+	var secretRevealAt *string
+	if !s.cfg.SecretRevealAt.IsZero() {
+		formatted := s.cfg.SecretRevealAt.In(s.cfg.EventLocation).Format(time.RFC3339)
+		secretRevealAt = &formatted
+	}
+	s.wsHub.BroadcastSettingsUpdate(&websocket.SettingsPayload{
+		CreditIntervalMinutes:  s.cfg.CreditIntervalMinutes,
+		CreditMax:              s.cfg.CreditMax,
+		VotingPaused:           s.cfg.VotingPaused,
+		VoteVisibilityMode:     s.cfg.VoteVisibilityMode,
+		NegativeVotingDisabled: s.cfg.NegativeVotingDisabled,
+		CountdownTarget:        countdownTarget,
+		SecretRevealAt:         secretRevealAt,
+		AchievementDailyLimits: s.cfg.AchievementDailyLimits,
+		ActivePhase:            s.cfg.ActivePhase,
+		ChatSlowModeSeconds:    s.cfg.ChatSlowModeSeconds,
+	})
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"sync"
+
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+)
+
+var (
+	// ErrGameAlreadyPinned is returned when an admin pins a game twice
+	ErrGameAlreadyPinned = errors.New("game is already pinned")
+	// ErrGameNotPinned is returned when an admin unpins a game that is not pinned
+	ErrGameNotPinned = errors.New("game is not pinned")
+	// ErrPinnedGameNotFound is returned when a game to pin is not on the Steam store
+	ErrPinnedGameNotFound = errors.New("game not found on the Steam store")
+	// ErrPinnedGamesMismatch is returned when a new order does not list every pinned game exactly once
+	ErrPinnedGamesMismatch = errors.New("order must list every pinned game exactly once")
+)
+
+// pinnedGames holds the pinned games in their order, changes are serialized by mu
+type pinnedGames struct {
+	mu     sync.RWMutex
+	appIDs []int
+}
+
+// LoadPinnedGames reads the pinned games from the database
+// PINNED_GAME_IDS only seeds the empty table, afterwards admins manage the pinned games at runtime
+func (s *GameService) LoadPinnedGames(ctx context.Context) error {
+	appIDs, err := s.pinnedGameRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	if len(appIDs) == 0 && len(s.cfg.PinnedGameIDs) > 0 {
+		if err := s.pinnedGameRepo.Replace(ctx, s.cfg.PinnedGameIDs); err != nil {
+			return err
+		}
+		appIDs = slices.Clone(s.cfg.PinnedGameIDs)
+		log.Printf("[GameSync] Seeded %d pinned games from PINNED_GAME_IDS", len(appIDs))
+	}
+
+	s.pinned.mu.Lock()
+	s.pinned.appIDs = appIDs
+	s.pinned.mu.Unlock()
+	s.InvalidateCache()
+	return nil
+}
+
+// GetPinnedGameIDs returns the list of pinned game IDs in their order
+func (s *GameService) GetPinnedGameIDs() []int {
+	s.pinned.mu.RLock()
+	defer s.pinned.mu.RUnlock()
+	return slices.Clone(s.pinned.appIDs)
+}
+
+// GetPinnedGames returns the pinned games with their cached names in their order
+func (s *GameService) GetPinnedGames(ctx context.Context) ([]models.PinnedGame, error) {
+	appIDs := s.GetPinnedGameIDs()
+	games := make([]models.PinnedGame, 0, len(appIDs))
+	for _, appID := range appIDs {
+		game := models.PinnedGame{
+			AppID:          appID,
+			HeaderImageURL: s.imageCacheService.GetLocalImageURL(appID),
+		}
+		cached, err := s.gameCacheRepo.GetByAppID(ctx, appID)
+		if err != nil {
+			return nil, err
+		}
+		if cached != nil {
+			game.Name = cached.Name
+		}
+		games = append(games, game)
+	}
+	return games, nil
+}
+
+// PinGame pins a game at the end of the pinned games
+// Games not in the game cache yet are fetched from the Steam store first, ctx cancels that request
+func (s *GameService) PinGame(ctx context.Context, appID int) error {
+	if slices.Contains(s.GetPinnedGameIDs(), appID) {
+		return ErrGameAlreadyPinned
+	}
+
+	cached, err := s.gameCacheRepo.GetByAppID(ctx, appID)
+	if err != nil {
+		return err
+	}
+	if cached == nil || cached.FetchFailed {
+		ctx, cancel := s.withStop(ctx)
+		defer cancel()
+		s.syncGameStoreData(ctx, &models.Game{AppID: appID})
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if cached, err = s.gameCacheRepo.GetByAppID(ctx, appID); err != nil {
+			return err
+		}
+		if cached == nil || cached.FetchFailed {
+			return ErrPinnedGameNotFound
+		}
+	}
+	s.imageCacheService.CacheImageAsync(appID)
+
+	return s.updatePinnedGames(ctx, func(appIDs []int) ([]int, error) {
+		if slices.Contains(appIDs, appID) {
+			return nil, ErrGameAlreadyPinned
+		}
+		return append(appIDs, appID), nil
+	})
+}
+
+// UnpinGame removes a game from the pinned games
+func (s *GameService) UnpinGame(ctx context.Context, appID int) error {
+	return s.updatePinnedGames(ctx, func(appIDs []int) ([]int, error) {
+		i := slices.Index(appIDs, appID)
+		if i < 0 {
+			return nil, ErrGameNotPinned
+		}
+		return slices.Delete(appIDs, i, i+1), nil
+	})
+}
+
+// ReorderPinnedGames sets the order of the pinned games
+func (s *GameService) ReorderPinnedGames(ctx context.Context, order []int) error {
+	return s.updatePinnedGames(ctx, func(appIDs []int) ([]int, error) {
+		sorted := slices.Clone(order)
+		slices.Sort(sorted)
+		slices.Sort(appIDs)
+		if !slices.Equal(sorted, appIDs) {
+			return nil, ErrPinnedGamesMismatch
+		}
+		return slices.Clone(order), nil
+	})
+}
+
+// updatePinnedGames applies a change to a copy of the pinned games, stores the result and rebuilds the games list
+func (s *GameService) updatePinnedGames(ctx context.Context, change func(appIDs []int) ([]int, error)) error {
+	s.pinned.mu.Lock()
+	defer s.pinned.mu.Unlock()
+
+	appIDs, err := change(slices.Clone(s.pinned.appIDs))
+	if err != nil {
+		return err
+	}
+	if err := s.pinnedGameRepo.Replace(ctx, appIDs); err != nil {
+		return err
+	}
+	s.pinned.appIDs = appIDs
+	s.InvalidateCache()
+	return nil
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+	"github.com/guided-traffic/rate-your-mate/backend/websocket"
+)
+
+// RevealService handles the scheduled reveal of secret votes
+type RevealService struct {
+	cfg       *config.Config
+	wsHub     *websocket.Hub
+	voteRepo  *repository.VoteRepository
+	timerRepo *repository.TimerRepository
+	ticker    *time.Ticker
+	done      chan bool
+}
+
+// NewRevealService creates a new reveal service
+func NewRevealService(cfg *config.Config, wsHub *websocket.Hub, voteRepo *repository.VoteRepository, timerRepo *repository.TimerRepository) *RevealService {
+	return &RevealService{
+		cfg:       cfg,
+		wsHub:     wsHub,
+		voteRepo:  voteRepo,
+		timerRepo: timerRepo,
+		done:      make(chan bool),
+	}
+}
+
+// Restore loads the persisted reveal schedule, it takes precedence over SECRET_REVEAL_AT
+// A reveal that was due while the server was down happens immediately
+func (s *RevealService) Restore(ctx context.Context) {
+	revealAt, err := s.timerRepo.Get(ctx, models.TimerSecretReveal)
+	if err != nil {
+		log.Printf("Warning: Failed to restore secret reveal schedule: %v", err)
+		return
+	}
+	if revealAt.IsZero() {
+		return
+	}
+
+	s.cfg.SecretRevealAt = revealAt
+	if !time.Now().Before(revealAt) {
+		log.Printf("Secret reveal was due at %v while the server was down - revealing now", revealAt)
+		s.checkReveal(ctx)
+		return
+	}
+	log.Printf("Restored secret reveal schedule to %v", revealAt)
+}
+
+// Start begins the reveal watcher
+func (s *RevealService) Start() {
+	// Check every second whether the reveal time has been reached
+	s.ticker = time.NewTicker(1 * time.Second)
+	go s.watch()
+	log.Println("Reveal service started")
+}
+
+// Stop stops the reveal watcher
+func (s *RevealService) Stop() {
+	if s.ticker != nil {
+		s.ticker.Stop()
+	}
+	s.done <- true
+	log.Println("Reveal service stopped")
+}
+
+// watch continuously checks if the reveal time has been reached
+func (s *RevealService) watch() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ticker.C:
+			s.checkReveal(context.Background())
+		}
+	}
+}
+
+// checkReveal reveals all secret votes once the configured reveal time has passed
+func (s *RevealService) checkReveal(ctx context.Context) {
+	// Skip if no reveal is scheduled
+	if s.cfg.SecretRevealAt.IsZero() {
+		return
+	}
+
+	if time.Now().Before(s.cfg.SecretRevealAt) {
+		return
+	}
+
+	revealAt := s.cfg.SecretRevealAt
+	log.Printf("Secret reveal time reached at %v - revealing all votes", revealAt)
+
+	revealed, err := s.voteRepo.RevealAll(ctx)
+	if err != nil {
+		// Keep the schedule so the next tick retries
+		log.Printf("Warning: Failed to reveal secret votes: %v", err)
+		return
+	}
+
+	// Clear the schedule - the reveal only happens once
+	s.cfg.SecretRevealAt = time.Time{}
+	if err := s.timerRepo.Clear(ctx, models.TimerSecretReveal); err != nil {
+		log.Printf("Warning: Failed to clear persisted secret reveal schedule: %v", err)
+	}
+	log.Printf("Revealed %d votes, secret reveal schedule cleared", revealed)
+
+	s.wsHub.BroadcastSecretVotesRevealed(&websocket.SecretVotesRevealedPayload{
+		RevealedCount: revealed,
+		RevealedAt:    time.Now().In(s.cfg.EventLocation).Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Client uploads objects to an S3 compatible storage (AWS, MinIO) with path-style URLs
+type S3Client struct {
+	httpClient *http.Client
+	endpoint   string
+	region     string
+	bucket     string
+	accessKey  string
+	secretKey  string
+}
+
+// NewS3Client creates a new S3 client
+func NewS3Client(endpoint, region, bucket, accessKey, secretKey string) *S3Client {
+	return &S3Client{
+		httpClient: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+		endpoint:  strings.TrimSuffix(endpoint, "/"),
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+	}
+}
+
+// PutObject uploads an object, an existing object with the same key is replaced
+func (c *S3Client) PutObject(key string, body []byte, contentType string) error {
+	objectPath := "/" + s3EscapePath(c.bucket) + "/" + s3EscapePath(key)
+	u, err := url.Parse(c.endpoint + objectPath)
+	if err != nil {
+		return fmt.Errorf("invalid S3 endpoint: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create S3 request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	c.sign(req, objectPath, body, time.Now().UTC())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to upload %s: S3 returned status %d: %s", key, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	return nil
+}
+
+// sign adds an AWS Signature Version 4 Authorization header to the request
+// All headers set on the request are signed, together with the host
+func (c *S3Client) sign(req *http.Request, canonicalPath string, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalPath,
+		"", // No query string
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + c.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := sigV4SigningKey(c.secretKey, date, c.region, "s3")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.accessKey, scope, signedHeaders, signature))
+}
+
+// sigV4SigningKey derives the SigV4 signing key of a day, region and service from the secret key
+func sigV4SigningKey(secretKey, date, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+// s3EscapePath URI-encodes an object key as required by SigV4, slashes are kept
+func s3EscapePath(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		ch := path[i]
+		if ch == '/' || ch == '-' || ch == '_' || ch == '.' || ch == '~' ||
+			(ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') {
+			b.WriteByte(ch)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", ch)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+)
+
+// setupCodeAlphabet avoids characters that are easily confused when read from a log (0/O, 1/I/L)
+const setupCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// setupCodeLength is the number of characters of a setup code
+const setupCodeLength = 10
+
+// maxSetupAttempts is the number of wrong codes after which a new code is generated
+const maxSetupAttempts = 5
+
+// Setup claim errors
+var (
+	ErrSetupNotRequired = errors.New("setup has already been completed")
+	ErrInvalidSetupCode = errors.New("invalid setup code")
+)
+
+// SetupService bootstraps the first admin on instances without ADMIN_STEAM_IDS
+// A one-time setup code is printed to the server log, the first user to submit it becomes admin
+type SetupService struct {
+	cfg      *config.Config
+	roleRepo *repository.RoleRepository
+
+	mu       sync.Mutex
+	code     string // Empty once an admin exists
+	attempts int
+}
+
+// NewSetupService creates a new setup service
+func NewSetupService(cfg *config.Config, roleRepo *repository.RoleRepository) *SetupService {
+	return &SetupService{
+		cfg:      cfg,
+		roleRepo: roleRepo,
+	}
+}
+
+// Init loads the admins granted at runtime and prints a setup code if there is no admin yet
+func (s *SetupService) Init(ctx context.Context) error {
+	admins, err := s.roleRepo.GetSteamIDsByRole(ctx, models.RoleAdmin)
+	if err != nil {
+		return err
+	}
+	s.cfg.AddAdmins(admins...)
+	if len(admins) > 0 {
+		log.Printf("Loaded %d admin(s) from the database", len(admins))
+	}
+
+	if len(s.cfg.Admins()) > 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rotateCode()
+}
+
+// IsSetupRequired reports whether no admin exists yet
+func (s *SetupService) IsSetupRequired() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.code != ""
+}
+
+// ClaimAdmin makes the given user admin if the code matches
+// The code is consumed on success, after too many wrong attempts a new code is printed
+func (s *SetupService) ClaimAdmin(ctx context.Context, steamID, code string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.code == "" {
+		return ErrSetupNotRequired
+	}
+
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if subtle.ConstantTimeCompare([]byte(code), []byte(s.code)) != 1 {
+		s.attempts++
+		log.Printf("Invalid setup code submitted by %s (%d/%d)", steamID, s.attempts, maxSetupAttempts)
+		if s.attempts >= maxSetupAttempts {
+			if err := s.rotateCode(); err != nil {
+				log.Printf("Failed to rotate setup code: %v", err)
+			}
+		}
+		return ErrInvalidSetupCode
+	}
+
+	if err := s.roleRepo.Grant(ctx, steamID, models.RoleAdmin, "setup"); err != nil {
+		return err
+	}
+	s.cfg.AddAdmins(steamID)
+	s.code = ""
+	s.attempts = 0
+
+	log.Printf("Setup completed: %s is now admin", steamID)
+	return nil
+}
+
+// rotateCode generates and prints a new setup code, the caller must hold mu
+func (s *SetupService) rotateCode() error {
+	buf := make([]byte, setupCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Errorf("failed to generate setup code: %w", err)
+	}
+	for i, b := range buf {
+		buf[i] = setupCodeAlphabet[int(b)%len(setupCodeAlphabet)]
+	}
+	s.code = string(buf)
+	s.attempts = 0
+
+	log.Println("============================================================")
+	log.Println("No admin configured. Log in and claim admin rights with")
+	log.Printf("the one-time setup code: %s", s.code)
+	log.Println("============================================================")
+	return nil
+}
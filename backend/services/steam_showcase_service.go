@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/auth"
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+)
+
+// steamShowcaseCacheTTL is how long the Steam achievements of a player are cached
+const steamShowcaseCacheTTL = 30 * time.Minute
+
+// steamShowcaseRecentUnlocks limits the recently unlocked achievements per game
+const steamShowcaseRecentUnlocks = 5
+
+// steamLanguages maps languages of server texts to Steam language names
+var steamLanguages = map[string]string{
+	"de": "german",
+	"en": "english",
+	"es": "spanish",
+	"fr": "french",
+	"it": "italian",
+	"pl": "polish",
+}
+
+// SteamShowcaseService imports the real Steam achievements of players for the pinned games
+type SteamShowcaseService struct {
+	cfg           *config.Config
+	steamAPI      *auth.SteamAPIClient
+	gameCacheRepo *repository.GameCacheRepository
+	gameService   *GameService
+
+	mu    sync.Mutex
+	cache map[string]*models.SteamShowcase // steamID + language -> showcase
+}
+
+// NewSteamShowcaseService creates a new Steam showcase service
+func NewSteamShowcaseService(cfg *config.Config, steamAPI *auth.SteamAPIClient, gameCacheRepo *repository.GameCacheRepository, gameService *GameService) *SteamShowcaseService {
+	return &SteamShowcaseService{
+		cfg:           cfg,
+		steamAPI:      steamAPI,
+		gameCacheRepo: gameCacheRepo,
+		gameService:   gameService,
+		cache:         make(map[string]*models.SteamShowcase),
+	}
+}
+
+// GetShowcase returns the Steam achievement progress of a player in the pinned games
+// Results are cached, language selects the language of the achievement names
+func (s *SteamShowcaseService) GetShowcase(ctx context.Context, steamID, language string) (*models.SteamShowcase, error) {
+	steamLanguage, ok := steamLanguages[language]
+	if !ok {
+		steamLanguage = "english"
+	}
+	cacheKey := steamID + ":" + steamLanguage
+
+	s.mu.Lock()
+	cached := s.cache[cacheKey]
+	s.mu.Unlock()
+	if cached != nil && time.Since(cached.FetchedAt) < steamShowcaseCacheTTL {
+		return cached, nil
+	}
+
+	showcase, err := s.fetchShowcase(ctx, steamID, steamLanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.cache[cacheKey] = showcase
+	s.mu.Unlock()
+
+	return showcase, nil
+}
+
+// fetchShowcase loads the achievements of all pinned games the player owns from the Steam API
+func (s *SteamShowcaseService) fetchShowcase(ctx context.Context, steamID, steamLanguage string) (*models.SteamShowcase, error) {
+	showcase := &models.SteamShowcase{
+		Games:     []models.SteamGameProgress{},
+		FetchedAt: time.Now(),
+	}
+	pinnedGameIDs := s.gameService.GetPinnedGameIDs()
+	if len(pinnedGameIDs) == 0 || !s.steamAPI.IsConfigured() {
+		return showcase, nil
+	}
+
+	playtimes, err := s.steamAPI.GetOwnedGamePlaytimes(steamID)
+	if errors.Is(err, auth.ErrSteamProfilePrivate) {
+		showcase.IsPrivate = true
+		return showcase, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, appID := range pinnedGameIDs {
+		playtime, owned := playtimes[appID]
+		if !owned {
+			continue
+		}
+
+		stats, err := s.steamAPI.GetPlayerAchievements(steamID, appID, steamLanguage)
+		if errors.Is(err, auth.ErrSteamNoStats) {
+			continue
+		}
+		if errors.Is(err, auth.ErrSteamProfilePrivate) {
+			showcase.IsPrivate = true
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		showcase.Games = append(showcase.Games, s.buildProgress(ctx, appID, playtime, stats))
+	}
+
+	return showcase, nil
+}
+
+// buildProgress summarizes the achievements of one game
+func (s *SteamShowcaseService) buildProgress(ctx context.Context, appID, playtime int, stats *auth.SteamPlayerStats) models.SteamGameProgress {
+	progress := models.SteamGameProgress{
+		AppID:           appID,
+		Name:            stats.GameName,
+		Total:           len(stats.Achievements),
+		PlaytimeMinutes: playtime,
+		RecentUnlocks:   []models.SteamAchievementUnlock{},
+	}
+
+	// Prefer the store name of the game cache, the stats name is often an internal one
+	if cached, err := s.gameCacheRepo.GetByAppID(ctx, appID); err != nil {
+		log.Printf("Failed to get cached game %d: %v", appID, err)
+	} else if cached != nil && cached.Name != "" {
+		progress.Name = cached.Name
+	}
+
+	for _, a := range stats.Achievements {
+		if a.Achieved != 1 {
+			continue
+		}
+		progress.Unlocked++
+		progress.RecentUnlocks = append(progress.RecentUnlocks, models.SteamAchievementUnlock{
+			Name:        a.Name,
+			Description: a.Description,
+			UnlockedAt:  time.Unix(a.UnlockTime, 0).UTC(),
+		})
+	}
+	if progress.Total > 0 {
+		// Rounded to one decimal place
+		progress.CompletionPercent = float64(progress.Unlocked*1000/progress.Total) / 10
+	}
+
+	sort.Slice(progress.RecentUnlocks, func(i, j int) bool {
+		return progress.RecentUnlocks[i].UnlockedAt.After(progress.RecentUnlocks[j].UnlockedAt)
+	})
+	if len(progress.RecentUnlocks) > steamShowcaseRecentUnlocks {
+		progress.RecentUnlocks = progress.RecentUnlocks[:steamShowcaseRecentUnlocks]
+	}
+
+	return progress
+}
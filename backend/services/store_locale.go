@@ -0,0 +1,35 @@
+package services
+
+import "fmt"
+
+// storeCategoryNames are the English names of the Steam categories the app relies on by category ID
+// Multiplayer and co-op detection works on these names, so they must not follow the store language
+var storeCategoryNames = map[int]string{
+	1:  "Multi-player",
+	2:  "Single-player",
+	9:  "Co-op",
+	20: "MMO",
+	24: "Shared/Split Screen",
+	27: "Cross-Platform Multiplayer",
+	36: "Online PvP",
+	37: "Shared/Split Screen PvP",
+	38: "Online Co-op",
+	39: "Shared/Split Screen Co-op",
+	47: "LAN PvP",
+	48: "LAN Co-op",
+	49: "PvP",
+}
+
+// storeCategoryName returns the English name of a known category, other categories keep the localized description
+func storeCategoryName(id int, description string) string {
+	if name, ok := storeCategoryNames[id]; ok {
+		return name
+	}
+	return description
+}
+
+// storeLocaleQuery returns the country and language parameters of Steam Store requests
+// Both can be changed by admins at runtime, so they are read on every request
+func (s *GameService) storeLocaleQuery() string {
+	return fmt.Sprintf("cc=%s&l=%s", s.cfg.StoreCountry, s.cfg.StoreLanguage)
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/metrics"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+)
+
+// storePriceBatchSize is the number of games per batched appdetails request
+// The store only accepts several app IDs when the response is filtered to price_overview
+const storePriceBatchSize = 50
+
+// storePriceOverview is the price part of a Steam Store appdetails response
+type storePriceOverview struct {
+	Initial         int    `json:"initial"`
+	Final           int    `json:"final"`
+	DiscountPercent int    `json:"discount_percent"`
+	FinalFormatted  string `json:"final_formatted"`
+}
+
+// storePriceResponse represents a batched appdetails response filtered to price_overview
+// Data is an empty array instead of an object for games without a price (free or unreleased)
+type storePriceResponse map[string]struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// RefreshPrices updates the cached prices and discounts of the given games with one store request per batch
+// Categories, reviews and tags are left alone, they are refreshed by the per-game sync.
+// Games without a price in the response keep their cached price. Returns the number of updated games.
+func (s *GameService) RefreshPrices(ctx context.Context, appIDs []int) (int, error) {
+	updated := 0
+	for start := 0; start < len(appIDs); start += storePriceBatchSize {
+		if s.isRateLimited() {
+			log.Printf("Skipping Steam Store price refresh - rate limited until %v", s.rateLimiter.pausedUntil)
+			break
+		}
+
+		end := min(start+storePriceBatchSize, len(appIDs))
+		prices, err := s.fetchStorePrices(s.ctx, appIDs[start:end])
+		if err != nil {
+			if updated > 0 {
+				s.InvalidateCache()
+			}
+			return updated, err
+		}
+
+		for appID, price := range prices {
+			if err := s.gameCacheRepo.UpdatePrice(ctx, appID, price); err != nil {
+				log.Printf("Failed to update price of game %d: %v", appID, err)
+				continue
+			}
+			updated++
+		}
+	}
+
+	if updated > 0 {
+		s.InvalidateCache()
+	}
+	return updated, nil
+}
+
+// fetchStorePrices fetches the prices of up to storePriceBatchSize games in a single Steam Store request
+// Returns the prices by app ID, games without a price are missing. Handles 429 rate limiting.
+func (s *GameService) fetchStorePrices(ctx context.Context, appIDs []int) (map[int]*repository.GamePriceInfo, error) {
+	ids := make([]string, len(appIDs))
+	for i, appID := range appIDs {
+		ids[i] = strconv.Itoa(appID)
+	}
+	url := fmt.Sprintf("%s/appdetails?appids=%s&filters=price_overview&%s", steamStoreBaseURL, strings.Join(ids, ","), s.storeLocaleQuery())
+
+	if err := s.storeLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	log.Printf("[STEAM STORE API] GET /appdetails - Fetching prices for %d games", len(appIDs))
+	start := time.Now()
+	resp, err := s.get(ctx, url)
+	duration := time.Since(start)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		metrics.SteamAPIErrors.Inc()
+		log.Printf("[STEAM STORE API] ERROR - appdetails prices failed after %v: %v", duration, err)
+		return nil, fmt.Errorf("failed to call Steam Store API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		metrics.SteamAPIErrors.Inc()
+		log.Printf("[STEAM STORE API] WARN - Rate limited (429) for prices after %v", duration)
+		s.setRateLimited(ctx, parseRetryAfter(resp.Header.Get("Retry-After")))
+		return nil, fmt.Errorf("rate limited (429)")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		metrics.SteamAPIErrors.Inc()
+		log.Printf("[STEAM STORE API] ERROR - appdetails prices returned status %d after %v", resp.StatusCode, duration)
+		return nil, fmt.Errorf("Steam Store API returned status %d", resp.StatusCode)
+	}
+	s.clearRateLimitStrikes()
+
+	var apiResp storePriceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		metrics.SteamAPIErrors.Inc()
+		log.Printf("[STEAM STORE API] ERROR - Failed to parse appdetails prices response: %v", err)
+		return nil, fmt.Errorf("failed to parse Steam Store API response: %w", err)
+	}
+
+	prices := make(map[int]*repository.GamePriceInfo, len(apiResp))
+	for id, app := range apiResp {
+		appID, err := strconv.Atoi(id)
+		if err != nil || !app.Success || !strings.HasPrefix(strings.TrimSpace(string(app.Data)), "{") {
+			continue
+		}
+		var data struct {
+			PriceOverview *storePriceOverview `json:"price_overview"`
+		}
+		if err := json.Unmarshal(app.Data, &data); err != nil || data.PriceOverview == nil {
+			continue
+		}
+		prices[appID] = &repository.GamePriceInfo{
+			PriceCents:      data.PriceOverview.Final,
+			OriginalCents:   data.PriceOverview.Initial,
+			DiscountPercent: data.PriceOverview.DiscountPercent,
+			PriceFormatted:  data.PriceOverview.FinalFormatted,
+		}
+	}
+
+	log.Printf("[STEAM STORE API] OK - appdetails returned %d of %d prices in %v", len(prices), len(appIDs), duration)
+	return prices, nil
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+)
+
+// Weights of the skill signals, they add up to 1
+const (
+	teamSkillVoteWeight     = 0.3
+	teamSkillRankWeight     = 0.3
+	teamSkillPlaytimeWeight = 0.4
+)
+
+// maxTeamSwapPasses limits the rounds of player swaps after the first split
+const maxTeamSwapPasses = 50
+
+// TeamBalanceService splits players into teams of similar skill for a game
+// Skill combines the positive votes a player received, the global rank and the playtime in the game
+type TeamBalanceService struct {
+	cfg           *config.Config
+	voteRepo      *repository.VoteRepository
+	gameOwnerRepo *repository.GameOwnerRepository
+}
+
+// NewTeamBalanceService creates a new team balance service
+func NewTeamBalanceService(cfg *config.Config, voteRepo *repository.VoteRepository, gameOwnerRepo *repository.GameOwnerRepository) *TeamBalanceService {
+	return &TeamBalanceService{
+		cfg:           cfg,
+		voteRepo:      voteRepo,
+		gameOwnerRepo: gameOwnerRepo,
+	}
+}
+
+// Balance splits the players into teamCount teams whose sizes differ by at most one
+func (s *TeamBalanceService) Balance(ctx context.Context, appID int, gameName string, users []models.PublicUser, teamCount int) (*models.TeamBalance, error) {
+	players, err := s.skills(ctx, appID, users)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := splitTeams(players, teamCount)
+
+	result := &models.TeamBalance{
+		AppID:    appID,
+		GameName: gameName,
+		Teams:    make([]models.BalancedTeam, len(teams)),
+	}
+	for i, team := range teams {
+		sort.SliceStable(team, func(a, b int) bool {
+			return team[a].Skill > team[b].Skill
+		})
+		result.Teams[i] = models.BalancedTeam{
+			Number:  i + 1,
+			Skill:   roundSkill(teamSkill(team)),
+			Players: team,
+		}
+	}
+	result.Spread = roundSkill(skillSpread(teams))
+	return result, nil
+}
+
+// skills reads the skill signals of the players and combines them into a skill between 0 and 100
+// Votes and playtime are relative to the strongest of the given players, the rank to the whole ranking
+func (s *TeamBalanceService) skills(ctx context.Context, appID int, users []models.PublicUser) ([]models.TeamPlayer, error) {
+	votes, err := s.voteRepo.GetReceivedPositiveVoteCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Players who opted out of the public ranking count as average
+	rankings, err := s.voteRepo.GetGlobalRanking(ctx, s.cfg.RankingTieBreakers)
+	if err != nil {
+		return nil, err
+	}
+	ranks := make(map[uint64]int, len(rankings))
+	lastRank := 0
+	for _, ranking := range rankings {
+		ranks[ranking.User.ID] = ranking.Rank
+		lastRank = max(lastRank, ranking.Rank)
+	}
+
+	owners, err := s.gameOwnerRepo.GetOwnersByAppID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	playtimes := make(map[string]int, len(owners))
+	for _, owner := range owners {
+		playtimes[owner.SteamID] = owner.PlaytimeForever
+	}
+
+	players := make([]models.TeamPlayer, len(users))
+	maxVotes, maxPlaytime := 0, 0
+	for i, user := range users {
+		players[i] = models.TeamPlayer{
+			PublicUser:      user,
+			PositiveVotes:   votes[user.ID],
+			Rank:            ranks[user.ID],
+			PlaytimeMinutes: playtimes[user.SteamID],
+		}
+		maxVotes = max(maxVotes, players[i].PositiveVotes)
+		maxPlaytime = max(maxPlaytime, players[i].PlaytimeMinutes)
+	}
+
+	for i := range players {
+		p := &players[i]
+
+		voteSignal := 0.0
+		if maxVotes > 0 {
+			voteSignal = float64(p.PositiveVotes) / float64(maxVotes)
+		}
+
+		rankSignal := 0.5
+		switch {
+		case p.Rank > 0 && lastRank > 1:
+			rankSignal = 1 - float64(p.Rank-1)/float64(lastRank-1)
+		case p.Rank > 0:
+			rankSignal = 1
+		}
+
+		// The first hours in a game matter more than the hundredth
+		playtimeSignal := 0.0
+		if maxPlaytime > 0 {
+			playtimeSignal = math.Log1p(float64(p.PlaytimeMinutes)) / math.Log1p(float64(maxPlaytime))
+		}
+
+		p.Skill = roundSkill(100 * (teamSkillVoteWeight*voteSignal + teamSkillRankWeight*rankSignal + teamSkillPlaytimeWeight*playtimeSignal))
+	}
+
+	return players, nil
+}
+
+// splitTeams assigns the strongest remaining player to the weakest team with a free slot,
+// then swaps players between teams as long as that brings the strongest and the weakest team closer
+func splitTeams(players []models.TeamPlayer, teamCount int) [][]models.TeamPlayer {
+	sorted := make([]models.TeamPlayer, len(players))
+	copy(sorted, players)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Skill != sorted[j].Skill {
+			return sorted[i].Skill > sorted[j].Skill
+		}
+		return sorted[i].Username < sorted[j].Username
+	})
+
+	teams := make([][]models.TeamPlayer, teamCount)
+	sizes := make([]int, teamCount)
+	for i := range sizes {
+		sizes[i] = len(sorted) / teamCount
+		if i < len(sorted)%teamCount {
+			sizes[i]++
+		}
+	}
+	for _, player := range sorted {
+		best := -1
+		for i := range teams {
+			if len(teams[i]) >= sizes[i] {
+				continue
+			}
+			if best < 0 || teamSkill(teams[i]) < teamSkill(teams[best]) {
+				best = i
+			}
+		}
+		teams[best] = append(teams[best], player)
+	}
+
+	for pass := 0; pass < maxTeamSwapPasses; pass++ {
+		if !improveTeams(teams) {
+			break
+		}
+	}
+	return teams
+}
+
+// improveTeams applies the first swap of two players of different teams that lowers the skill spread
+// Returns false if no swap helps
+func improveTeams(teams [][]models.TeamPlayer) bool {
+	spread := skillSpread(teams)
+	for a := range teams {
+		for b := a + 1; b < len(teams); b++ {
+			for i := range teams[a] {
+				for j := range teams[b] {
+					teams[a][i], teams[b][j] = teams[b][j], teams[a][i]
+					if skillSpread(teams) < spread-1e-9 {
+						return true
+					}
+					teams[a][i], teams[b][j] = teams[b][j], teams[a][i]
+				}
+			}
+		}
+	}
+	return false
+}
+
+// teamSkill returns the summed skill of a team
+func teamSkill(team []models.TeamPlayer) float64 {
+	total := 0.0
+	for _, player := range team {
+		total += player.Skill
+	}
+	return total
+}
+
+// skillSpread returns the skill difference between the strongest and the weakest team
+func skillSpread(teams [][]models.TeamPlayer) float64 {
+	lowest, highest := math.Inf(1), math.Inf(-1)
+	for _, team := range teams {
+		skill := teamSkill(team)
+		lowest = min(lowest, skill)
+		highest = max(highest, skill)
+	}
+	return highest - lowest
+}
+
+// roundSkill rounds a skill value to one decimal
+func roundSkill(skill float64) float64 {
+	return math.Round(skill*10) / 10
+}
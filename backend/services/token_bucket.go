@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// minRateFactor is the lowest fraction of the configured rate a bucket slows down to
+const minRateFactor = 0.125
+
+// tokenBucket limits requests to an average rate while allowing short bursts
+// It is shared by all goroutines calling the same API
+type tokenBucket struct {
+	mu         sync.Mutex
+	tokens     float64
+	capacity   float64
+	perSec     float64
+	basePerSec float64 // Configured rate, perSec is lowered after rate limit responses
+	last       time.Time
+}
+
+// newTokenBucket creates a full bucket refilled with perMinute tokens per minute
+func newTokenBucket(perMinute, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		tokens:     float64(burst),
+		capacity:   float64(burst),
+		perSec:     float64(perMinute) / 60,
+		basePerSec: float64(perMinute) / 60,
+		last:       time.Now(),
+	}
+}
+
+// SlowDown halves the rate after a rate limit response, down to an eighth of the configured rate
+func (b *tokenBucket) SlowDown() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.perSec = max(b.perSec/2, b.basePerSec*minRateFactor)
+}
+
+// SpeedUp raises the rate by a tenth after a successful request, up to the configured rate
+func (b *tokenBucket) SpeedUp() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.perSec = min(b.perSec*1.1, b.basePerSec)
+}
+
+// PerMinute returns the current rate
+func (b *tokenBucket) PerMinute() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return int(b.perSec * 60)
+}
+
+// Wait blocks until a token is available and takes it
+// Returns the context error without taking a token if ctx is canceled while waiting
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens = min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.perSec)
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - b.tokens) / b.perSec * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
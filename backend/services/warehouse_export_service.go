@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/parquet"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+)
+
+// parquetContentType is the content type of the exported files
+const parquetContentType = "application/vnd.apache.parquet"
+
+// Schemas of the exported files
+var (
+	warehouseVoteColumns = []parquet.Column{
+		{Name: "id", Type: parquet.Int64},
+		{Name: "from_user_id", Type: parquet.Int64, Optional: true}, // null for unrevealed secret votes
+		{Name: "to_user_id", Type: parquet.Int64},
+		{Name: "achievement_id", Type: parquet.String},
+		{Name: "is_positive", Type: parquet.Bool},
+		{Name: "points", Type: parquet.Int64},
+		{Name: "is_secret", Type: parquet.Bool},
+		{Name: "is_revealed", Type: parquet.Bool},
+		{Name: "is_invalidated", Type: parquet.Bool},
+		{Name: "app_id", Type: parquet.Int64, Optional: true},
+		{Name: "created_at", Type: parquet.Timestamp},
+	}
+	warehouseChatColumns = []parquet.Column{
+		{Name: "id", Type: parquet.Int64},
+		{Name: "user_id", Type: parquet.Int64},
+		{Name: "message", Type: parquet.String},
+		{Name: "reply_to_id", Type: parquet.Int64, Optional: true},
+		{Name: "is_system", Type: parquet.Bool},
+		{Name: "created_at", Type: parquet.Timestamp},
+	}
+	warehouseRankingColumns = []parquet.Column{
+		{Name: "snapshot_at", Type: parquet.Timestamp},
+		{Name: "rank", Type: parquet.Int64},
+		{Name: "user_id", Type: parquet.Int64},
+		{Name: "username", Type: parquet.String},
+		{Name: "total_score", Type: parquet.Int64},
+		{Name: "net_votes", Type: parquet.Int64},
+		{Name: "bonus_points", Type: parquet.Int64},
+	}
+)
+
+// WarehouseExportService periodically writes votes, chat messages and ranking snapshots
+// as Parquet files to an S3 bucket, so they can be analyzed without touching the database
+type WarehouseExportService struct {
+	cfg           *config.Config
+	warehouseRepo *repository.WarehouseRepository
+	voteRepo      *repository.VoteRepository
+	s3            *S3Client
+	ticker        *time.Ticker
+	done          chan bool
+}
+
+// NewWarehouseExportService creates a new data warehouse export service
+func NewWarehouseExportService(cfg *config.Config, warehouseRepo *repository.WarehouseRepository, voteRepo *repository.VoteRepository) *WarehouseExportService {
+	return &WarehouseExportService{
+		cfg:           cfg,
+		warehouseRepo: warehouseRepo,
+		voteRepo:      voteRepo,
+		s3:            NewS3Client(cfg.WarehouseS3Endpoint, cfg.WarehouseS3Region, cfg.WarehouseS3Bucket, cfg.WarehouseS3AccessKey, cfg.WarehouseS3SecretKey),
+		done:          make(chan bool),
+	}
+}
+
+// Start begins the periodic export, it is disabled without interval or bucket
+func (s *WarehouseExportService) Start() {
+	if s.cfg.WarehouseExportIntervalMinutes <= 0 || s.cfg.WarehouseS3Bucket == "" {
+		log.Println("Data warehouse export disabled")
+		return
+	}
+
+	interval := time.Duration(s.cfg.WarehouseExportIntervalMinutes) * time.Minute
+	s.ticker = time.NewTicker(interval)
+	go s.watch()
+	log.Printf("Data warehouse export started (every %v to bucket %s)", interval, s.cfg.WarehouseS3Bucket)
+}
+
+// Stop stops the periodic export
+func (s *WarehouseExportService) Stop() {
+	if s.ticker == nil {
+		return
+	}
+	s.ticker.Stop()
+	s.done <- true
+	log.Println("Data warehouse export stopped")
+}
+
+// watch exports on start and on every tick
+func (s *WarehouseExportService) watch() {
+	s.runExport(context.Background())
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ticker.C:
+			s.runExport(context.Background())
+		}
+	}
+}
+
+// runExport exports and logs failures, the next tick retries
+func (s *WarehouseExportService) runExport(ctx context.Context) {
+	if err := s.Export(ctx, time.Now().UTC()); err != nil {
+		log.Printf("Warning: Data warehouse export failed: %v", err)
+	}
+}
+
+// Export writes all files of one export run
+// Votes and chat messages are replaced on every run, rankings get one file per run
+func (s *WarehouseExportService) Export(ctx context.Context, now time.Time) error {
+	votes, err := s.exportVotes(ctx)
+	if err != nil {
+		return err
+	}
+	messages, err := s.exportChatMessages(ctx)
+	if err != nil {
+		return err
+	}
+	rankings, err := s.exportRanking(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Data warehouse export: %d votes, %d chat messages, %d ranking entries", votes, messages, rankings)
+	return nil
+}
+
+// exportVotes writes votes/votes.parquet and returns the number of rows
+func (s *WarehouseExportService) exportVotes(ctx context.Context) (int, error) {
+	votes, err := s.warehouseRepo.GetVotes(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rows := make([][]interface{}, 0, len(votes))
+	for _, v := range votes {
+		isPositive := false
+		if achievement, ok := models.GetAchievement(v.AchievementID); ok {
+			isPositive = achievement.IsPositive
+		}
+		var fromUserID, appID interface{}
+		if v.FromUserID != nil {
+			fromUserID = int64(*v.FromUserID)
+		}
+		if v.AppID != nil {
+			appID = int64(*v.AppID)
+		}
+		rows = append(rows, []interface{}{
+			int64(v.ID), fromUserID, int64(v.ToUserID), v.AchievementID, isPositive, int64(v.Points),
+			v.IsSecret, v.IsRevealed, v.IsInvalidated, appID, v.CreatedAt,
+		})
+	}
+
+	return len(rows), s.upload("votes/votes.parquet", warehouseVoteColumns, rows)
+}
+
+// exportChatMessages writes chat/chat_messages.parquet and returns the number of rows
+func (s *WarehouseExportService) exportChatMessages(ctx context.Context) (int, error) {
+	messages, err := s.warehouseRepo.GetChatMessages(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rows := make([][]interface{}, 0, len(messages))
+	for _, m := range messages {
+		var replyToID interface{}
+		if m.ReplyToID != nil {
+			replyToID = int64(*m.ReplyToID)
+		}
+		rows = append(rows, []interface{}{
+			int64(m.ID), int64(m.UserID), m.Message, replyToID, m.IsSystem, m.CreatedAt,
+		})
+	}
+
+	return len(rows), s.upload("chat/chat_messages.parquet", warehouseChatColumns, rows)
+}
+
+// exportRanking writes a ranking snapshot to rankings/ and returns the number of rows
+// Users who opted out of the public ranking are not included
+func (s *WarehouseExportService) exportRanking(ctx context.Context, now time.Time) (int, error) {
+	rankings, err := s.voteRepo.GetGlobalRanking(ctx, s.cfg.RankingTieBreakers)
+	if err != nil {
+		return 0, err
+	}
+
+	rows := make([][]interface{}, 0, len(rankings))
+	for _, r := range rankings {
+		rows = append(rows, []interface{}{
+			now, int64(r.Rank), int64(r.User.ID), r.User.Username,
+			int64(r.TotalScore), int64(r.NetVotes), int64(r.BonusPoints),
+		})
+	}
+
+	key := fmt.Sprintf("rankings/ranking_%s.parquet", now.Format("20060102T150405Z"))
+	return len(rows), s.upload(key, warehouseRankingColumns, rows)
+}
+
+// upload encodes the rows and writes them below the configured prefix
+func (s *WarehouseExportService) upload(key string, columns []parquet.Column, rows [][]interface{}) error {
+	data, err := parquet.Encode(columns, rows)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+	return s.s3.PutObject(s.cfg.WarehouseS3Prefix+key, data, parquetContentType)
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/auth"
+	"github.com/guided-traffic/rate-your-mate/backend/config"
+	"github.com/guided-traffic/rate-your-mate/backend/models"
+	"github.com/guided-traffic/rate-your-mate/backend/repository"
+)
+
+// wishlistUserRefreshCooldown is the minimum time between two manual refreshes of the same wishlist
+const wishlistUserRefreshCooldown = 5 * time.Minute
+
+// ErrWishlistRefreshCooldown is returned when a player refreshes their wishlist again too early
+var ErrWishlistRefreshCooldown = errors.New("wishlist was refreshed recently")
+
+// WishlistService caches the Steam wishlists of all players and aggregates them for group purchases
+// Wishlisted games are added to the game cache so the next game sync fetches their prices
+type WishlistService struct {
+	cfg               *config.Config
+	steamAPI          *auth.SteamAPIClient
+	wishlistRepo      *repository.WishlistRepository
+	userRepo          *repository.UserRepository
+	gameCacheRepo     *repository.GameCacheRepository
+	gameService       *GameService
+	imageCacheService *ImageCacheService
+	syncCallback      SyncProgressCallback
+	ticker            *time.Ticker
+	done              chan bool
+
+	mu            sync.RWMutex
+	updatedAt     time.Time            // Last refresh of all wishlists
+	userRefreshes map[uint64]time.Time // Last manual refresh per player
+}
+
+// NewWishlistService creates a new wishlist service
+// syncCallback reports the progress of game syncs started for newly wishlisted games
+func NewWishlistService(cfg *config.Config, steamAPI *auth.SteamAPIClient, wishlistRepo *repository.WishlistRepository, userRepo *repository.UserRepository, gameCacheRepo *repository.GameCacheRepository, gameService *GameService, imageCacheService *ImageCacheService, syncCallback SyncProgressCallback) *WishlistService {
+	return &WishlistService{
+		cfg:               cfg,
+		steamAPI:          steamAPI,
+		wishlistRepo:      wishlistRepo,
+		userRepo:          userRepo,
+		gameCacheRepo:     gameCacheRepo,
+		gameService:       gameService,
+		imageCacheService: imageCacheService,
+		syncCallback:      syncCallback,
+		done:              make(chan bool),
+		userRefreshes:     make(map[uint64]time.Time),
+	}
+}
+
+// IsEnabled returns true if wishlists are fetched
+func (s *WishlistService) IsEnabled() bool {
+	return s.cfg.WishlistRefreshMinutes > 0 && s.steamAPI.IsConfigured()
+}
+
+// Start begins fetching all wishlists periodically, the first fetch runs in the background right away
+func (s *WishlistService) Start() {
+	if !s.IsEnabled() {
+		log.Println("Wishlist service disabled")
+		return
+	}
+
+	interval := time.Duration(s.cfg.WishlistRefreshMinutes) * time.Minute
+	s.ticker = time.NewTicker(interval)
+	go s.watch()
+	log.Printf("Wishlist service started (refresh every %v)", interval)
+}
+
+// Stop stops fetching wishlists
+func (s *WishlistService) Stop() {
+	if s.ticker == nil {
+		return
+	}
+	s.ticker.Stop()
+	s.done <- true
+	log.Println("Wishlist service stopped")
+}
+
+// watch fetches all wishlists on start and on every tick
+func (s *WishlistService) watch() {
+	s.refreshAll(context.Background())
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ticker.C:
+			s.refreshAll(context.Background())
+		}
+	}
+}
+
+// RefreshUser fetches and caches the wishlist of a single player, returns the number of wishlisted games
+// Returns ErrWishlistRefreshCooldown if the player refreshed within the last minutes
+func (s *WishlistService) RefreshUser(ctx context.Context, user *models.User) (int, error) {
+	s.mu.Lock()
+	if time.Since(s.userRefreshes[user.ID]) < wishlistUserRefreshCooldown {
+		s.mu.Unlock()
+		return 0, ErrWishlistRefreshCooldown
+	}
+	s.userRefreshes[user.ID] = time.Now()
+	s.mu.Unlock()
+
+	added, count, err := s.refreshUser(ctx, user)
+	if err != nil {
+		return 0, err
+	}
+	if added {
+		s.gameService.TriggerSyncIfNeeded(s.syncCallback)
+	}
+	return count, nil
+}
+
+// GetGames returns the games wishlisted by at least minUsers players, most wishlisted first, then by discount
+// The time is the last refresh of all wishlists, zero before the first refresh
+func (s *WishlistService) GetGames(ctx context.Context, minUsers int) ([]models.WishlistGame, time.Time, error) {
+	games, err := s.wishlistRepo.GetAggregated(ctx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	result := make([]models.WishlistGame, 0, len(games))
+	for _, game := range games {
+		if game.WishlistCount < minUsers {
+			continue
+		}
+		if game.Name == "" {
+			game.Name = fmt.Sprintf("App %d", game.AppID)
+		}
+		game.HeaderImageURL = s.imageCacheService.GetLocalImageURL(game.AppID)
+		result = append(result, game)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].WishlistCount != result[j].WishlistCount {
+			return result[i].WishlistCount > result[j].WishlistCount
+		}
+		return result[i].DiscountPercent > result[j].DiscountPercent
+	})
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return result, s.updatedAt, nil
+}
+
+// refreshAll fetches the wishlists of all players, a failed player keeps the cached wishlist
+func (s *WishlistService) refreshAll(ctx context.Context) {
+	users, err := s.userRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to get users for wishlists: %v", err)
+		return
+	}
+
+	anyAdded, failed := false, 0
+	for i := range users {
+		added, _, err := s.refreshUser(ctx, &users[i])
+		if err != nil {
+			log.Printf("Warning: Failed to refresh wishlist of user %d: %v", users[i].ID, err)
+			failed++
+			continue
+		}
+		anyAdded = anyAdded || added
+	}
+
+	s.mu.Lock()
+	s.updatedAt = time.Now()
+	s.mu.Unlock()
+
+	log.Printf("Wishlists refreshed for %d players, %d failed", len(users)-failed, failed)
+
+	if anyAdded {
+		s.gameService.TriggerSyncIfNeeded(s.syncCallback)
+	}
+}
+
+// refreshUser fetches and caches the wishlist of a player
+// Returns true if games unknown to the game cache were added, they need a game sync for name and price
+func (s *WishlistService) refreshUser(ctx context.Context, user *models.User) (bool, int, error) {
+	wishlist, err := s.steamAPI.GetWishlist(user.SteamID)
+	if err != nil {
+		return false, 0, err
+	}
+
+	items := make([]models.WishlistItem, 0, len(wishlist))
+	added := false
+	for _, w := range wishlist {
+		item := models.WishlistItem{
+			AppID:    w.AppID,
+			Priority: w.Priority,
+		}
+		if w.DateAdded > 0 {
+			addedAt := time.Unix(w.DateAdded, 0).UTC()
+			item.AddedAt = &addedAt
+		}
+		items = append(items, item)
+
+		cached, err := s.gameCacheRepo.GetByAppID(ctx, w.AppID)
+		if err != nil {
+			return false, 0, err
+		}
+		if cached == nil {
+			if err := s.gameCacheRepo.InsertIfNotExists(ctx, w.AppID, ""); err != nil {
+				return false, 0, err
+			}
+			added = true
+		}
+	}
+
+	if err := s.wishlistRepo.ReplaceForUser(ctx, user.ID, items); err != nil {
+		return false, 0, err
+	}
+	return added, len(items), nil
+}
@@ -0,0 +1,77 @@
+// Package web serves the frontend bundle and the achievement icons embedded into
+// the single binary release (scripts/build-release.sh copies them into web/dist)
+// Regular builds embed an empty directory and leave the frontend to nginx
+package web
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed all:dist
+var embedded embed.FS
+
+// dist is the embedded directory without the dist/ prefix
+var dist, _ = fs.Sub(embedded, "dist")
+
+// HasFiles reports whether anything besides the placeholder was embedded
+func HasFiles() bool {
+	entries, err := fs.ReadDir(dist, ".")
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if entry.Name() != ".gitkeep" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasFrontend reports whether the frontend bundle was embedded
+func HasFrontend() bool {
+	_, err := fs.Stat(dist, "index.html")
+	return err == nil
+}
+
+// Handler serves embedded files for all routes without a handler
+// Unknown paths get index.html, so the Angular router can handle them
+func Handler() gin.HandlerFunc {
+	fileServer := http.FileServer(http.FS(dist))
+	hasFrontend := HasFrontend()
+
+	return func(c *gin.Context) {
+		urlPath := c.Request.URL.Path
+		if (c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead) || strings.HasPrefix(urlPath, "/api/") {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Not found",
+			})
+			return
+		}
+
+		name := strings.TrimPrefix(path.Clean(urlPath), "/")
+		if info, err := fs.Stat(dist, name); err == nil && !info.IsDir() {
+			if strings.HasPrefix(name, "icons/") {
+				// Icons change only with a new release
+				c.Header("Cache-Control", "public, max-age=86400")
+			}
+			fileServer.ServeHTTP(c.Writer, c.Request)
+			return
+		}
+
+		if !hasFrontend {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Not found",
+			})
+			return
+		}
+
+		c.Header("Cache-Control", "no-cache")
+		c.FileFromFS("/", http.FS(dist)) // Serves index.html
+	}
+}
@@ -0,0 +1,67 @@
+package websocket
+
+import "encoding/json"
+
+// Accessibility describes an effect for clients that respect accessibility preferences
+type Accessibility struct {
+	PlainText     string `json:"plain_text"`     // Text alternative of the visual effect, e.g. for screen readers
+	ReducedMotion bool   `json:"reduced_motion"` // Receiver prefers effects without confetti, flashing and sounds
+}
+
+// effectMessage holds an effect marshaled for both reduced motion preferences
+type effectMessage struct {
+	standard []byte
+	reduced  []byte
+}
+
+// marshalEffect marshals an effect message once per reduced motion preference
+// accessibility must be the Accessibility field of payload
+func marshalEffect(msgType MessageType, payload interface{}, accessibility *Accessibility) (*effectMessage, error) {
+	msg := Message{
+		Type:    msgType,
+		Payload: payload,
+	}
+
+	accessibility.ReducedMotion = false
+	standard, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	accessibility.ReducedMotion = true
+	reduced, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &effectMessage{standard: standard, reduced: reduced}, nil
+}
+
+// SetReducedMotion updates the reduced motion preference of a user for all following effects, on all instances
+func (h *Hub) SetReducedMotion(userID uint64, reducedMotion bool) {
+	h.mutex.Lock()
+	h.setReducedMotion(userID, reducedMotion)
+	h.mutex.Unlock()
+
+	h.forward(&envelope{Kind: fanoutReducedMotion, UserID: userID, ReducedMotion: reducedMotion})
+}
+
+// setReducedMotion updates the reduced motion preference of a user (caller holds the lock)
+func (h *Hub) setReducedMotion(userID uint64, reducedMotion bool) {
+	if reducedMotion {
+		h.reducedMotion[userID] = true
+	} else {
+		delete(h.reducedMotion, userID)
+	}
+}
+
+// LoadReducedMotion replaces the reduced motion preferences, e.g. with the stored ones on startup
+func (h *Hub) LoadReducedMotion(userIDs []uint64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.reducedMotion = make(map[uint64]bool, len(userIDs))
+	for _, id := range userIDs {
+		h.reducedMotion[id] = true
+	}
+}
@@ -1,7 +1,9 @@
 package websocket
 
 import (
+	"errors"
 	"log"
+	"net"
 	"net/http"
 	"time"
 
@@ -18,8 +20,15 @@ const (
 	// Send pings to peer with this period (must be less than pongWait)
 	pingPeriod = (pongWait * 9) / 10
 
-	// Maximum message size allowed from peer
-	maxMessageSize = 512
+	// Connections without a pong or message for this long are evicted by the hub,
+	// in case the read deadline did not end the read pump (e.g. a blocked inbound handler)
+	staleAfter = pongWait + writeWait
+
+	// Maximum message size allowed from peer (chat_send carries up to 500 characters)
+	maxMessageSize = 4096
+
+	// Messages below this size are sent uncompressed, deflate barely shrinks them
+	compressionThreshold = 256
 )
 
 var upgrader = websocket.Upgrader{
@@ -39,24 +48,41 @@ func (c *Client) readPump() {
 	}()
 
 	c.conn.SetReadLimit(maxMessageSize)
-	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.touch()
 	c.conn.SetPongHandler(func(string) error {
-		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		c.touch()
 		return nil
 	})
 
 	for {
-		_, _, err := c.conn.ReadMessage()
+		_, data, err := c.conn.ReadMessage()
 		if err != nil {
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				log.Printf("WebSocket: Client %d (%s) missed pongs for %v - closing stale connection", c.userID, c.username, pongWait)
+			} else if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				log.Printf("WebSocket read error: %v", err)
 			}
 			break
 		}
-		// We don't expect messages from clients, just keep the connection alive
+		// Every message proves the connection is alive, not only pongs
+		c.touch()
+		c.hub.handleInbound(c, data)
 	}
 }
 
+// touch records that the peer is alive and extends the read deadline
+func (c *Client) touch() {
+	now := time.Now()
+	c.lastSeen.Store(now.UnixNano())
+	c.conn.SetReadDeadline(now.Add(pongWait))
+}
+
+// isStale reports whether the peer sent neither a pong nor a message for staleAfter
+func (c *Client) isStale(now time.Time) bool {
+	return now.Sub(time.Unix(0, c.lastSeen.Load())) > staleAfter
+}
+
 // writePump pumps messages from the hub to the websocket connection
 func (c *Client) writePump() {
 	ticker := time.NewTicker(pingPeriod)
@@ -71,12 +97,12 @@ func (c *Client) writePump() {
 			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if !ok {
 				// The hub closed the channel
-				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				c.conn.WriteMessage(websocket.CloseMessage, c.closeFrame())
 				return
 			}
 
 			// Send each message as a separate WebSocket frame
-			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+			if err := c.writeText(message); err != nil {
 				log.Printf("WebSocket: Failed to write message to client %d: %v", c.userID, err)
 				return
 			}
@@ -85,7 +111,7 @@ func (c *Client) writePump() {
 			n := len(c.send)
 			for i := 0; i < n; i++ {
 				msg := <-c.send
-				if err := c.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				if err := c.writeText(msg); err != nil {
 					log.Printf("WebSocket: Failed to write queued message to client %d: %v", c.userID, err)
 					return
 				}
@@ -101,22 +127,46 @@ func (c *Client) writePump() {
 	}
 }
 
+// closeFrame returns the payload of the close frame, empty for a normal closure
+func (c *Client) closeFrame() []byte {
+	if c.closeCode == 0 {
+		return []byte{}
+	}
+	return websocket.FormatCloseMessage(c.closeCode, c.closeText)
+}
+
+// writeText writes a message as text frame, compressed if permessage-deflate was negotiated and the message is large enough
+func (c *Client) writeText(message []byte) error {
+	c.conn.EnableWriteCompression(len(message) >= compressionThreshold)
+	return c.conn.WriteMessage(websocket.TextMessage, message)
+}
+
 // ServeWs handles websocket requests from clients
-func ServeWs(hub *Hub, w http.ResponseWriter, r *http.Request, userID uint64, steamID, username string) {
-	conn, err := upgrader.Upgrade(w, r, nil)
+// resume is the position of a reconnecting client, it receives the broadcasts it missed since (nil for a first connection)
+func ServeWs(hub *Hub, w http.ResponseWriter, r *http.Request, userID uint64, steamID, username string, resume *Resume) {
+	// Clients that support permessage-deflate get compressed messages if enabled
+	u := upgrader
+	u.EnableCompression = hub.compressionLevel > 0
+	conn, err := u.Upgrade(w, r, nil)
 	if err != nil {
 		log.Printf("WebSocket upgrade error: %v", err)
 		return
 	}
+	if hub.compressionLevel > 0 {
+		conn.SetCompressionLevel(hub.compressionLevel)
+	}
 
 	client := &Client{
-		hub:      hub,
-		conn:     conn,
-		send:     make(chan []byte, 256),
-		userID:   userID,
-		steamID:  steamID,
-		username: username,
+		hub:         hub,
+		conn:        conn,
+		send:        make(chan []byte, 256),
+		userID:      userID,
+		steamID:     steamID,
+		username:    username,
+		resume:      resume,
+		connectedAt: time.Now(),
 	}
+	client.lastSeen.Store(client.connectedAt.UnixNano())
 
 	client.hub.register <- client
 
@@ -0,0 +1,136 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+)
+
+// fanoutBufferSize is the number of messages waiting to be published before new ones are dropped
+const fanoutBufferSize = 256
+
+// Fanout relays hub messages between backend instances, e.g. via Redis pub/sub
+// Without a fanout the hub only reaches the clients connected to its own instance
+type Fanout interface {
+	// Publish sends a message to all instances
+	Publish(ctx context.Context, data []byte) error
+	// Subscribe calls handle for every published message, including the own ones, until ctx is done
+	// Lost connections are re-established, so it only returns when ctx is done
+	Subscribe(ctx context.Context, handle func(data []byte)) error
+}
+
+// Kinds of relayed hub messages
+const (
+	fanoutBroadcast     = "broadcast"      // Broadcast or effect, replayed after reconnects
+	fanoutTransient     = "transient"      // Broadcast without replay
+	fanoutUser          = "user"           // Message to all connections of a user
+	fanoutAdmins        = "admins"         // Message to all connected admins
+	fanoutReducedMotion = "reduced_motion" // Changed reduced motion preference of a user
+	fanoutDisconnect    = "disconnect"     // Kicked or banned user whose connections are closed
+)
+
+// envelope is a hub message relayed to the other instances
+type envelope struct {
+	Origin        string          `json:"origin"` // Epoch of the publishing hub, it skips its own messages
+	Kind          string          `json:"kind"`
+	UserID        uint64          `json:"user_id,omitempty"`
+	SteamIDs      []string        `json:"steam_ids,omitempty"`
+	Message       json.RawMessage `json:"message,omitempty"`
+	Reduced       json.RawMessage `json:"reduced,omitempty"` // Reduced motion variant of effects
+	ReducedMotion bool            `json:"reduced_motion,omitempty"`
+	Reason        string          `json:"reason,omitempty"` // Close reason of disconnects
+	Notice        string          `json:"notice,omitempty"` // Close message of disconnects
+}
+
+// adminMessage is a message to the connected admins, resolved by every instance for its own clients
+type adminMessage struct {
+	SteamIDs []string
+	Message  []byte
+}
+
+// UseFanout relays broadcasts and user messages to the other instances until ctx is done
+// Must be called before Run. Sequence numbers for replays stay per instance, so reconnecting clients need sticky sessions.
+func (h *Hub) UseFanout(ctx context.Context, fanout Fanout) {
+	h.fanout = fanout
+	h.outbox = make(chan *envelope, fanoutBufferSize)
+
+	go h.publishLoop(ctx)
+	go fanout.Subscribe(ctx, h.receive)
+	log.Printf("WebSocket: Relaying hub messages to other instances (instance %s)", h.epoch)
+}
+
+// forward queues a message for the other instances, dropping it if the fanout can't keep up
+func (h *Hub) forward(env *envelope) {
+	if h.fanout == nil {
+		return
+	}
+	env.Origin = h.epoch
+
+	select {
+	case h.outbox <- env:
+	default:
+		log.Printf("WebSocket: Fan-out queue full - dropping %s message for other instances", env.Kind)
+	}
+}
+
+// publishLoop publishes the queued messages one after another
+func (h *Hub) publishLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case env := <-h.outbox:
+			data, err := json.Marshal(env)
+			if err != nil {
+				log.Printf("WebSocket: Failed to marshal %s message for other instances: %v", env.Kind, err)
+				continue
+			}
+			if err := h.fanout.Publish(ctx, data); err != nil && ctx.Err() == nil {
+				log.Printf("WebSocket: Failed to publish %s message to other instances: %v", env.Kind, err)
+			}
+		}
+	}
+}
+
+// receive hands a message of another instance to the hub loop
+func (h *Hub) receive(data []byte) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		log.Printf("WebSocket: Ignoring invalid message from other instance: %v", err)
+		return
+	}
+	if env.Origin == h.epoch {
+		return
+	}
+	h.remote <- &env
+}
+
+// deliverRemote delivers a message of another instance to the own clients (caller holds the lock)
+func (h *Hub) deliverRemote(env *envelope) {
+	switch env.Kind {
+	case fanoutBroadcast:
+		h.sendToAll(h.record(env.Message, rawOrNil(env.Reduced)))
+	case fanoutTransient:
+		h.sendToAll(newEntry(env.Message, nil))
+	case fanoutUser:
+		h.sendToUserClients(env.UserID, env.Message, rawOrNil(env.Reduced))
+	case fanoutAdmins:
+		for _, userID := range h.adminUserIDs(env.SteamIDs) {
+			h.sendToUserClients(userID, env.Message, nil)
+		}
+	case fanoutReducedMotion:
+		h.setReducedMotion(env.UserID, env.ReducedMotion)
+	case fanoutDisconnect:
+		h.disconnectClients(env.UserID, env.Reason, env.Notice)
+	default:
+		log.Printf("WebSocket: Ignoring unknown %q message from other instance", env.Kind)
+	}
+}
+
+// rawOrNil keeps optional variants nil when they were not relayed
+func rawOrNil(raw json.RawMessage) []byte {
+	if len(raw) == 0 {
+		return nil
+	}
+	return raw
+}
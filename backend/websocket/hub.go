@@ -2,10 +2,14 @@ package websocket
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/guided-traffic/rate-your-mate/backend/metrics"
 )
 
 // MessageType defines the type of WebSocket message
@@ -40,6 +44,58 @@ const (
 	MessageTypeUserBanned MessageType = "user_banned"
 	// MessageTypeVoteInvalidation is sent when a vote's invalidation status changes
 	MessageTypeVoteInvalidation MessageType = "vote_invalidation"
+	// MessageTypeSecretVotesRevealed is sent when the scheduled reveal uncovers all secret votes
+	MessageTypeSecretVotesRevealed MessageType = "secret_votes_revealed"
+	// MessageTypeOnFire is sent when a user is on a vote streak
+	MessageTypeOnFire MessageType = "on_fire"
+	// MessageTypeVoteAppeal is sent to connected admins when a vote recipient appeals a negative vote
+	MessageTypeVoteAppeal MessageType = "vote_appeal"
+	// MessageTypeVoteAppealResolved is sent to the appellant when an admin resolved the appeal
+	MessageTypeVoteAppealResolved MessageType = "vote_appeal_resolved"
+	// MessageTypeAchievementsUpdate is sent when an admin creates, edits, disables or deletes an achievement
+	MessageTypeAchievementsUpdate MessageType = "achievements_update"
+	// MessageTypeConnectionClosed is sent right before the server closes a connection because of a connection limit
+	MessageTypeConnectionClosed MessageType = "connection_closed"
+	// MessageTypeGamesUpdated is sent when admins changed the pinned games or cover images, clients reload the games list
+	MessageTypeGamesUpdated MessageType = "games_updated"
+	// MessageTypeGameNews is sent when new news of commonly owned games were found
+	MessageTypeGameNews MessageType = "game_news"
+	// MessageTypeGameDeal is sent when a pinned or commonly owned game went on sale or free weekend
+	MessageTypeGameDeal MessageType = "game_deal"
+	// MessageTypeGameServer is sent when a player announced or removed the server of a game
+	MessageTypeGameServer MessageType = "game_server"
+	// MessageTypeGameSessions is sent when players started or left a game
+	MessageTypeGameSessions MessageType = "game_sessions"
+	// MessageTypeDownloadReminder is sent to players who have not confirmed all required downloads
+	MessageTypeDownloadReminder MessageType = "download_reminder"
+	// MessageTypeAchievementLive is sent when an achievement suggested by a player was approved and can be voted
+	MessageTypeAchievementLive MessageType = "achievement_live"
+	// MessageTypeBadgeAwarded is sent when a user earned a meta-badge
+	MessageTypeBadgeAwarded MessageType = "badge_awarded"
+	// MessageTypeChatMessageDeleted is sent when the author or an admin deleted a chat message
+	MessageTypeChatMessageDeleted MessageType = "chat_message_deleted"
+	// MessageTypeChatMention is sent to a user who was mentioned with @username in a chat message
+	MessageTypeChatMention MessageType = "chat_mention"
+	// MessageTypeChatUnread is sent to a user when they read the chat on one of their devices
+	MessageTypeChatUnread MessageType = "chat_unread"
+	// MessageTypeChatSend is sent by a client to post a chat message over the WebSocket connection
+	MessageTypeChatSend MessageType = "chat_send"
+	// MessageTypeChatSendResult answers a chat_send message of a client
+	MessageTypeChatSendResult MessageType = "chat_send_result"
+	// MessageTypePollUpdate is sent when a game poll was created, voted, closed or deleted
+	MessageTypePollUpdate MessageType = "poll_update"
+	// MessageTypeAccountReview is sent to connected admins when a new account was held for review
+	MessageTypeAccountReview MessageType = "account_review"
+	// MessageTypeUserMuted is sent to a user when an admin muted or unmuted them
+	MessageTypeUserMuted MessageType = "user_muted"
+	// MessageTypeAnnouncement is sent when an admin broadcast an announcement
+	MessageTypeAnnouncement MessageType = "announcement"
+	// MessageTypeSubscribe is sent by a client to choose the message types its connection receives
+	MessageTypeSubscribe MessageType = "subscribe"
+	// MessageTypeSubscribed answers a subscribe message of a client
+	MessageTypeSubscribed MessageType = "subscribed"
+	// MessageTypeResume is sent to every new connection, before the broadcasts it missed since its last connection
+	MessageTypeResume MessageType = "resume"
 	// MessageTypeError is sent when an error occurs
 	MessageTypeError MessageType = "error"
 )
@@ -65,16 +121,25 @@ type VotePayload struct {
 	IsSecret      bool   `json:"is_secret"`
 	CreatedAt     string `json:"created_at"`
 	Points        int    `json:"points,omitempty"` // Number of points awarded (1-3)
+
+	// Stored notification of vote_received messages, clients mark it read once shown
+	NotificationID uint64 `json:"notification_id,omitempty"`
+
+	Accessibility *Accessibility `json:"accessibility,omitempty"`
 }
 
 // SettingsPayload contains settings information for broadcasts
 type SettingsPayload struct {
-	CreditIntervalMinutes  int     `json:"credit_interval_minutes"`
-	CreditMax              int     `json:"credit_max"`
-	VotingPaused           bool    `json:"voting_paused"`
-	VoteVisibilityMode     string  `json:"vote_visibility_mode"`     // "user_choice", "all_secret", "all_public"
-	NegativeVotingDisabled bool    `json:"negative_voting_disabled"` // When true, negative achievements cannot be voted
-	CountdownTarget        *string `json:"countdown_target,omitempty"` // RFC3339 formatted time, null if not set
+	CreditIntervalMinutes  int            `json:"credit_interval_minutes"`
+	CreditMax              int            `json:"credit_max"`
+	VotingPaused           bool           `json:"voting_paused"`
+	VoteVisibilityMode     string         `json:"vote_visibility_mode"`       // "user_choice", "all_secret", "all_public"
+	NegativeVotingDisabled bool           `json:"negative_voting_disabled"`   // When true, negative achievements cannot be voted
+	CountdownTarget        *string        `json:"countdown_target,omitempty"` // RFC3339 formatted time, null if not set
+	SecretRevealAt         *string        `json:"secret_reveal_at,omitempty"` // RFC3339 formatted time, null if not set
+	AchievementDailyLimits map[string]int `json:"achievement_daily_limits"`   // Max votes per voter and day per achievement ID
+	ActivePhase            string         `json:"active_phase,omitempty"`     // Name of the active event phase
+	ChatSlowModeSeconds    int            `json:"chat_slow_mode_seconds"`     // Minimum seconds between two chat messages of a user, 0 = off
 }
 
 // ChatMessagePayload contains chat message information for broadcasts
@@ -86,6 +151,8 @@ type ChatMessagePayload struct {
 	AvatarSmall  string        `json:"avatar_small"`
 	Message      string        `json:"message"`
 	Achievements interface{}   `json:"achievements"` // Achievement badges at time of message
+	ReplyTo      interface{}   `json:"reply_to,omitempty"` // Quoted parent message of replies
+	IsSystem     bool          `json:"is_system,omitempty"` // Posted by the system, e.g. a scheduled reminder
 	CreatedAt    string        `json:"created_at"`
 }
 
@@ -97,12 +164,43 @@ type Client struct {
 	userID   uint64
 	steamID  string
 	username string
+	lastSeen atomic.Int64 // Unix nanoseconds of the last pong or message from the peer
+	resume   *Resume      // Position to replay from when reconnecting, nil for a first connection
+
+	// Start of the connection, reported by the status endpoint
+	connectedAt time.Time
+
+	// Message types the connection subscribed to, nil for all (guarded by the hub mutex)
+	subscriptions map[MessageType]bool
+
+	// Close frame the write pump sends when the hub closes the connection, normal closure if 0
+	// Set by the hub loop before it closes send, so the write pump reads it afterwards
+	closeCode int
+	closeText string
+}
+
+// Reasons sent with MessageTypeConnectionClosed
+const (
+	// CloseReasonReplaced means the user opened more connections than allowed and this was the oldest one
+	CloseReasonReplaced = "replaced"
+	// CloseReasonServerFull means the hub reached its total connection limit
+	CloseReasonServerFull = "server_full"
+	// CloseReasonKicked means an admin kicked the user
+	CloseReasonKicked = "kicked"
+	// CloseReasonBanned means an admin banned the user
+	CloseReasonBanned = "banned"
+)
+
+// ConnectionClosedPayload tells a client why its connection is closed, clients should not reconnect automatically
+type ConnectionClosedPayload struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
 }
 
 // Hub maintains the set of active clients and broadcasts messages
 type Hub struct {
-	// Registered clients by user ID
-	clients map[uint64]*Client
+	// Registered clients by user ID, oldest connection first
+	clients map[uint64][]*Client
 
 	// All clients for broadcast
 	allClients map[*Client]bool
@@ -113,110 +211,331 @@ type Hub struct {
 	// Unregister requests from clients
 	unregister chan *Client
 
-	// Broadcast to all clients
+	// Broadcast to all clients, numbered and kept for replay
 	broadcast chan []byte
 
+	// Broadcast to all clients without replay, for frequent messages that are outdated quickly
+	broadcastTransient chan []byte
+
 	// Send to specific user
 	sendToUser chan *UserMessage
 
+	// Broadcast effects to all clients, honoring their reduced motion preference
+	broadcastEffect chan *effectMessage
+
+	// Send to all connected admins
+	sendToAdmins chan *adminMessage
+
+	// Close all connections of a user
+	disconnectUser chan *disconnectRequest
+
+	// Relay to other backend instances, nil when running alone
+	fanout Fanout
+	outbox chan *envelope
+	remote chan *envelope
+
+	// Users that prefer effects without confetti, flashing and sounds
+	reducedMotion map[uint64]bool
+
+	// Connection limits (0 = unlimited)
+	maxConnectionsPerUser int
+	maxConnections        int
+
+	// Deflate level of messages to clients that support compression (0 = off, 1-9)
+	compressionLevel int
+
+	// Handles chat messages sent by clients, nil until set
+	chatSendHandler ChatSendHandler
+
+	// Replay of missed broadcasts: epoch of this hub start, latest sequence number and ring buffer by seq
+	epoch   string
+	seq     uint64
+	history []replayEntry
+
+	// Throughput of the last minute for the status endpoint
+	broadcasts throughput
+	messages   throughput
+
 	mutex sync.RWMutex
 }
 
 // UserMessage is a message targeted at a specific user
 type UserMessage struct {
-	UserID  uint64
-	Message []byte
+	UserID         uint64
+	Message        []byte
+	ReducedMessage []byte // Sent instead of Message if the user prefers reduced motion (optional)
 }
 
-// NewHub creates a new Hub
-func NewHub() *Hub {
+// NewHub creates a new Hub with the given connection limits (0 = unlimited) and compression level (0 = off, 1-9)
+func NewHub(maxConnectionsPerUser, maxConnections, compressionLevel int) *Hub {
 	return &Hub{
-		clients:    make(map[uint64]*Client),
-		allClients: make(map[*Client]bool),
-		register:   make(chan *Client),
-		unregister: make(chan *Client),
-		broadcast:  make(chan []byte),
-		sendToUser: make(chan *UserMessage),
+		clients:               make(map[uint64][]*Client),
+		allClients:            make(map[*Client]bool),
+		register:              make(chan *Client),
+		unregister:            make(chan *Client),
+		broadcast:             make(chan []byte),
+		broadcastTransient:    make(chan []byte),
+		sendToUser:            make(chan *UserMessage),
+		broadcastEffect:       make(chan *effectMessage),
+		sendToAdmins:          make(chan *adminMessage),
+		disconnectUser:        make(chan *disconnectRequest),
+		remote:                make(chan *envelope),
+		reducedMotion:         make(map[uint64]bool),
+		maxConnectionsPerUser: maxConnectionsPerUser,
+		maxConnections:        maxConnections,
+		compressionLevel:      compressionLevel,
+		epoch:                 newEpoch(),
+		history:               make([]replayEntry, replayBufferSize),
 	}
 }
 
 // Run starts the hub's main loop
 func (h *Hub) Run() {
+	staleTicker := time.NewTicker(pingPeriod)
+	defer staleTicker.Stop()
+
 	for {
 		select {
+		case now := <-staleTicker.C:
+			h.mutex.Lock()
+			h.evictStaleClients(now)
+			h.mutex.Unlock()
+
 		case client := <-h.register:
 			h.mutex.Lock()
-			h.clients[client.userID] = client
-			h.allClients[client] = true
+			h.registerClient(client)
 			h.mutex.Unlock()
-			log.Printf("WebSocket: Client connected - User %d (%s)", client.userID, client.username)
 
 		case client := <-h.unregister:
 			h.mutex.Lock()
 			if _, ok := h.allClients[client]; ok {
-				delete(h.allClients, client)
-				delete(h.clients, client.userID)
-				close(client.send)
+				h.removeClient(client)
 				log.Printf("WebSocket: Client disconnected - User %d (%s)", client.userID, client.username)
 			}
 			h.mutex.Unlock()
 
 		case message := <-h.broadcast:
-			h.mutex.RLock()
-			for client := range h.allClients {
-				select {
-				case client.send <- message:
-				default:
-					// Client send buffer full, close connection
-					close(client.send)
-					delete(h.allClients, client)
-					delete(h.clients, client.userID)
-				}
-			}
-			h.mutex.RUnlock()
+			h.mutex.Lock()
+			h.sendToAll(h.record(message, nil))
+			h.mutex.Unlock()
+			h.forward(&envelope{Kind: fanoutBroadcast, Message: message})
+
+		case message := <-h.broadcastTransient:
+			h.mutex.Lock()
+			h.sendToAll(newEntry(message, nil))
+			h.mutex.Unlock()
+			h.forward(&envelope{Kind: fanoutTransient, Message: message})
+
+		case effect := <-h.broadcastEffect:
+			h.mutex.Lock()
+			h.sendToAll(h.record(effect.standard, effect.reduced))
+			h.mutex.Unlock()
+			h.forward(&envelope{Kind: fanoutBroadcast, Message: effect.standard, Reduced: effect.reduced})
 
 		case userMsg := <-h.sendToUser:
-			h.mutex.RLock()
-			if client, ok := h.clients[userMsg.UserID]; ok {
-				select {
-				case client.send <- userMsg.Message:
-				default:
-					// Client send buffer full
-					close(client.send)
-					delete(h.allClients, client)
-					delete(h.clients, client.userID)
-				}
+			h.mutex.Lock()
+			h.sendToUserClients(userMsg.UserID, userMsg.Message, userMsg.ReducedMessage)
+			h.mutex.Unlock()
+			h.forward(&envelope{Kind: fanoutUser, UserID: userMsg.UserID, Message: userMsg.Message, Reduced: userMsg.ReducedMessage})
+
+		case adminMsg := <-h.sendToAdmins:
+			h.mutex.Lock()
+			for _, userID := range h.adminUserIDs(adminMsg.SteamIDs) {
+				h.sendToUserClients(userID, adminMsg.Message, nil)
 			}
-			h.mutex.RUnlock()
+			h.mutex.Unlock()
+			h.forward(&envelope{Kind: fanoutAdmins, SteamIDs: adminMsg.SteamIDs, Message: adminMsg.Message})
+
+		case req := <-h.disconnectUser:
+			h.mutex.Lock()
+			h.disconnectClients(req.UserID, req.Reason, req.Message)
+			h.mutex.Unlock()
+			h.forward(&envelope{Kind: fanoutDisconnect, UserID: req.UserID, Reason: req.Reason, Notice: req.Message})
+
+		case env := <-h.remote:
+			h.mutex.Lock()
+			h.deliverRemote(env)
+			h.mutex.Unlock()
+		}
+	}
+}
+
+// sendToUserClients queues a message for all connections of a user (caller holds the lock)
+// reduced is sent instead if the user prefers reduced motion (optional)
+func (h *Hub) sendToUserClients(userID uint64, message, reduced []byte) {
+	if reduced != nil && h.reducedMotion[userID] {
+		message = reduced
+	}
+	msgType := messageTypeOf(message)
+	// Copy the slice, removeClient modifies it
+	for _, client := range append([]*Client(nil), h.clients[userID]...) {
+		if !client.wants(msgType) {
+			continue
+		}
+		if !h.queue(client, message) {
+			// Client send buffer full
+			h.removeClient(client)
 		}
 	}
 }
 
+// registerClient adds a client while enforcing the connection limits (caller holds the lock)
+// A user over the per-user limit loses their oldest connection, a full hub rejects new users
+func (h *Hub) registerClient(client *Client) {
+	existing := h.clients[client.userID]
+
+	if h.maxConnectionsPerUser > 0 && len(existing) >= h.maxConnectionsPerUser {
+		oldest := existing[0]
+		log.Printf("WebSocket: User %d (%s) exceeded %d connections - closing the oldest one",
+			client.userID, client.username, h.maxConnectionsPerUser)
+		h.closeClient(oldest, CloseReasonReplaced, "Du hast zu viele Tabs offen – diese Verbindung wurde durch eine neuere ersetzt.")
+	} else if h.maxConnections > 0 && len(h.allClients) >= h.maxConnections {
+		if len(existing) == 0 {
+			log.Printf("WebSocket: Hub full (%d connections) - rejecting user %d (%s)",
+				h.maxConnections, client.userID, client.username)
+			h.rejectClient(client, CloseReasonServerFull, "Der Server ist gerade voll – bitte versuche es später erneut.")
+			return
+		}
+		// Users that are already connected may swap their oldest connection for the new one
+		h.closeClient(existing[0], CloseReasonReplaced, "Der Server ist gerade voll – diese Verbindung wurde durch eine neuere ersetzt.")
+	}
+
+	h.clients[client.userID] = append(h.clients[client.userID], client)
+	h.allClients[client] = true
+	log.Printf("WebSocket: Client connected - User %d (%s), %d connections", client.userID, client.username, len(h.clients[client.userID]))
+
+	h.replay(client, client.resume)
+}
+
+// sendToAll queues a broadcast for every subscribed client in its reduced motion variant (caller holds the lock)
+func (h *Hub) sendToAll(entry *replayEntry) {
+	h.broadcasts.add(time.Now())
+	metrics.WebSocketBroadcasts.Inc()
+
+	for client := range h.allClients {
+		if !client.wants(entry.msgType) {
+			continue
+		}
+		if !h.queue(client, entry.forUser(h.reducedMotion[client.userID])) {
+			// Client send buffer full, close connection
+			h.removeClient(client)
+		}
+	}
+}
+
+// removeClient unregisters a client and closes its send channel (caller holds the lock)
+func (h *Hub) removeClient(client *Client) {
+	if _, ok := h.allClients[client]; !ok {
+		return
+	}
+	delete(h.allClients, client)
+
+	remaining := h.clients[client.userID][:0]
+	for _, c := range h.clients[client.userID] {
+		if c != client {
+			remaining = append(remaining, c)
+		}
+	}
+	if len(remaining) == 0 {
+		delete(h.clients, client.userID)
+	} else {
+		h.clients[client.userID] = remaining
+	}
+
+	close(client.send)
+}
+
+// evictStaleClients removes connections that missed their pongs, e.g. of sleeping laptops (caller holds the lock)
+// They get no connection_closed message, a client that wakes up again should reconnect
+func (h *Hub) evictStaleClients(now time.Time) {
+	for client := range h.allClients {
+		if client.isStale(now) {
+			log.Printf("WebSocket: Evicting stale connection of user %d (%s), no pong for %v", client.userID, client.username, staleAfter)
+			h.removeClient(client)
+		}
+	}
+}
+
+// disconnectRequest asks the hub to close all connections of a user
+type disconnectRequest struct {
+	UserID  uint64
+	Reason  string
+	Message string
+}
+
+// DisconnectUser closes all connections of a kicked or banned user with a policy violation close frame
+// The client gets a connection_closed message with reason and message first, so it does not reconnect
+func (h *Hub) DisconnectUser(userID uint64, reason, message string) {
+	h.disconnectUser <- &disconnectRequest{UserID: userID, Reason: reason, Message: message}
+}
+
+// disconnectClients closes all connections of a user (caller holds the lock)
+func (h *Hub) disconnectClients(userID uint64, reason, message string) {
+	// Copy the slice, removeClient modifies it
+	clients := append([]*Client(nil), h.clients[userID]...)
+	for _, client := range clients {
+		client.closeCode = websocket.ClosePolicyViolation
+		client.closeText = reason
+		h.closeClient(client, reason, message)
+	}
+	if len(clients) > 0 {
+		log.Printf("WebSocket: Closed %d connections of user %d (%s)", len(clients), userID, reason)
+	}
+}
+
+// closeClient tells a registered client why it is closed and unregisters it (caller holds the lock)
+func (h *Hub) closeClient(client *Client, reason, message string) {
+	h.queueCloseMessage(client, reason, message)
+	h.removeClient(client)
+}
+
+// rejectClient tells a client that was never registered why it is closed
+func (h *Hub) rejectClient(client *Client, reason, message string) {
+	h.queueCloseMessage(client, reason, message)
+	close(client.send)
+}
+
+// queueCloseMessage queues the connection_closed message, the write pump sends it before closing the socket
+func (h *Hub) queueCloseMessage(client *Client, reason, message string) {
+	data, err := json.Marshal(Message{
+		Type:    MessageTypeConnectionClosed,
+		Payload: &ConnectionClosedPayload{Reason: reason, Message: message},
+	})
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal connection closed message: %v", err)
+		return
+	}
+
+	// If the send buffer is full, the client just gets disconnected
+	h.queue(client, data)
+}
+
 // BroadcastVote sends a new vote notification to all clients
 func (h *Hub) BroadcastVote(payload *VotePayload) {
-	msg := Message{
-		Type:    MessageTypeNewVote,
-		Payload: payload,
+	vote := *payload
+	vote.Accessibility = &Accessibility{
+		PlainText: fmt.Sprintf("%s hat „%s“ von %s erhalten", vote.ToUsername, vote.Achievement, vote.FromUsername),
 	}
 
-	data, err := json.Marshal(msg)
+	effect, err := marshalEffect(MessageTypeNewVote, &vote, vote.Accessibility)
 	if err != nil {
 		log.Printf("WebSocket: Failed to marshal broadcast message: %v", err)
 		return
 	}
 
-	log.Printf("WebSocket: Broadcasting new_vote to %d clients", h.GetConnectedUserCount())
-	h.broadcast <- data
+	log.Printf("WebSocket: Broadcasting new_vote to %d clients", h.GetConnectionCount())
+	h.broadcastEffect <- effect
 }
 
 // NotifyVoteReceived sends a notification to the user who received a vote
+// The caller stores it as notification first, offline users load it when they connect again
 func (h *Hub) NotifyVoteReceived(toUserID uint64, payload *VotePayload) {
-	msg := Message{
-		Type:    MessageTypeVoteReceived,
-		Payload: payload,
+	vote := *payload
+	vote.Accessibility = &Accessibility{
+		PlainText: fmt.Sprintf("Du hast „%s“ von %s erhalten", vote.Achievement, vote.FromUsername),
 	}
 
-	data, err := json.Marshal(msg)
+	effect, err := marshalEffect(MessageTypeVoteReceived, &vote, vote.Accessibility)
 	if err != nil {
 		log.Printf("WebSocket: Failed to marshal notification message: %v", err)
 		return
@@ -224,13 +543,21 @@ func (h *Hub) NotifyVoteReceived(toUserID uint64, payload *VotePayload) {
 
 	log.Printf("WebSocket: Sending vote_received notification to user %d (connected: %v)", toUserID, h.IsUserConnected(toUserID))
 	h.sendToUser <- &UserMessage{
-		UserID:  toUserID,
-		Message: data,
+		UserID:         toUserID,
+		Message:        effect.standard,
+		ReducedMessage: effect.reduced,
 	}
 }
 
-// GetConnectedUserCount returns the number of connected users
+// GetConnectedUserCount returns the number of connected users, stale connections are evicted by Run
 func (h *Hub) GetConnectedUserCount() int {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return len(h.clients)
+}
+
+// GetConnectionCount returns the number of open connections (a user may have several)
+func (h *Hub) GetConnectionCount() int {
 	h.mutex.RLock()
 	defer h.mutex.RUnlock()
 	return len(h.allClients)
@@ -244,13 +571,14 @@ func (h *Hub) IsUserConnected(userID uint64) bool {
 	return ok
 }
 
-// BroadcastVoteInvalidation sends vote invalidation update to all clients
-func (h *Hub) BroadcastVoteInvalidation(voteID uint64, isInvalidated bool) {
+// BroadcastVoteInvalidation sends vote invalidation update (including the admin's reason) to all clients
+func (h *Hub) BroadcastVoteInvalidation(voteID uint64, isInvalidated bool, reason string) {
 	msg := Message{
 		Type: MessageTypeVoteInvalidation,
 		Payload: map[string]interface{}{
 			"vote_id":        voteID,
 			"is_invalidated": isInvalidated,
+			"reason":         reason,
 		},
 	}
 
@@ -345,41 +673,203 @@ func (h *Hub) BroadcastChatMessage(payload *ChatMessagePayload) {
 		return
 	}
 
-	log.Printf("WebSocket: Broadcasting chat_message to %d clients", h.GetConnectedUserCount())
+	log.Printf("WebSocket: Broadcasting chat_message to %d clients", h.GetConnectionCount())
+	h.broadcast <- data
+}
+
+// ChatMessageDeletedPayload identifies a deleted chat message
+type ChatMessageDeletedPayload struct {
+	ID        uint64 `json:"id"`
+	DeletedBy string `json:"deleted_by"` // "author" or "admin"
+}
+
+// BroadcastChatMessageDeleted tells all clients to drop a deleted chat message
+func (h *Hub) BroadcastChatMessageDeleted(payload *ChatMessageDeletedPayload) {
+	msg := Message{
+		Type:    MessageTypeChatMessageDeleted,
+		Payload: payload,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal chat message deleted message: %v", err)
+		return
+	}
+
+	h.broadcast <- data
+	log.Printf("WebSocket: Broadcasted deletion of chat message %d", payload.ID)
+}
+
+// Events of a poll_update message
+const (
+	PollEventCreated = "created"
+	PollEventVoted   = "voted"
+	PollEventClosed  = "closed"
+	PollEventDeleted = "deleted"
+)
+
+// PollUpdatePayload contains the current results of a game poll
+type PollUpdatePayload struct {
+	Event  string      `json:"event"`
+	PollID uint64      `json:"poll_id"`
+	Poll   interface{} `json:"poll,omitempty"` // Not set for deleted polls
+}
+
+// BroadcastPollUpdate sends the current results of a game poll to all clients
+func (h *Hub) BroadcastPollUpdate(payload *PollUpdatePayload) {
+	msg := Message{
+		Type:    MessageTypePollUpdate,
+		Payload: payload,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal poll update message: %v", err)
+		return
+	}
+
 	h.broadcast <- data
 }
 
+// ChatMentionPayload notifies a user about a chat message mentioning them
+type ChatMentionPayload struct {
+	MessageID    uint64 `json:"message_id"`
+	FromUserID   uint64 `json:"from_user_id"`
+	FromUsername string `json:"from_username"`
+	Snippet      string `json:"snippet"`      // Start of the message
+	UnreadCount  int    `json:"unread_count"` // Unread mentions of the user including this one
+}
+
+// NotifyChatMention sends a mention notification to a specific user (all connected clients)
+func (h *Hub) NotifyChatMention(userID uint64, payload *ChatMentionPayload) {
+	msg := Message{
+		Type:    MessageTypeChatMention,
+		Payload: payload,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal chat mention message: %v", err)
+		return
+	}
+
+	h.sendToUser <- &UserMessage{
+		UserID:  userID,
+		Message: data,
+	}
+	log.Printf("WebSocket: Sent chat mention of message %d to user %d", payload.MessageID, userID)
+}
+
+// ChatUnreadPayload contains the chat read state of a user
+type ChatUnreadPayload struct {
+	LastReadMessageID uint64 `json:"last_read_message_id"`
+	UnreadCount       int    `json:"unread_count"` // Messages of others after the last read message
+}
+
+// NotifyChatUnread sends the updated unread chat counter to a specific user (all connected clients)
+// Clients count newer chat_message broadcasts themselves until the next update
+func (h *Hub) NotifyChatUnread(userID uint64, payload *ChatUnreadPayload) {
+	msg := Message{
+		Type:    MessageTypeChatUnread,
+		Payload: payload,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal chat unread message: %v", err)
+		return
+	}
+
+	h.sendToUser <- &UserMessage{
+		UserID:  userID,
+		Message: data,
+	}
+}
+
+// UserMutedPayload contains the mute state of a user
+type UserMutedPayload struct {
+	Muted        bool   `json:"muted"`
+	MutedUntil   string `json:"muted_until,omitempty"`
+	IncludeVotes bool   `json:"include_votes"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// NotifyUserMuted tells a user (all connected clients) that they were muted or unmuted
+func (h *Hub) NotifyUserMuted(userID uint64, payload *UserMutedPayload) {
+	msg := Message{
+		Type:    MessageTypeUserMuted,
+		Payload: payload,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal user muted message: %v", err)
+		return
+	}
+
+	h.sendToUser <- &UserMessage{
+		UserID:  userID,
+		Message: data,
+	}
+	log.Printf("WebSocket: Sent mute state (muted: %v) to user %d", payload.Muted, userID)
+}
+
+// NotifyCreditsGiven tells a user (all connected clients) that an admin gave them credits
+func (h *Hub) NotifyCreditsGiven(userID uint64, amount int) {
+	message := "Du hast 1 Credit erhalten"
+	if amount != 1 {
+		message = fmt.Sprintf("Du hast %d Credits erhalten", amount)
+	}
+	msg := Message{
+		Type:    MessageTypeCreditsGiven,
+		Payload: map[string]string{"message": message},
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal credits given message: %v", err)
+		return
+	}
+
+	h.sendToUser <- &UserMessage{
+		UserID:  userID,
+		Message: data,
+	}
+	log.Printf("WebSocket: Sent %d given credits to user %d", amount, userID)
+}
+
 // NewKingPayload contains info about the new king
 type NewKingPayload struct {
-	UserID   uint64 `json:"user_id"`
-	Username string `json:"username"`
-	Avatar   string `json:"avatar"`
+	UserID        uint64         `json:"user_id"`
+	Username      string         `json:"username"`
+	Avatar        string         `json:"avatar"`
+	Accessibility *Accessibility `json:"accessibility,omitempty"`
 }
 
 // BroadcastNewKing notifies all clients that there is a new king
 func (h *Hub) BroadcastNewKing(userID uint64, username string, avatar string) {
-	msg := Message{
-		Type: MessageTypeNewKing,
-		Payload: NewKingPayload{
-			UserID:   userID,
-			Username: username,
-			Avatar:   avatar,
+	payload := &NewKingPayload{
+		UserID:   userID,
+		Username: username,
+		Avatar:   avatar,
+		Accessibility: &Accessibility{
+			PlainText: fmt.Sprintf("%s ist der neue König", username),
 		},
 	}
 
-	data, err := json.Marshal(msg)
+	effect, err := marshalEffect(MessageTypeNewKing, payload, payload.Accessibility)
 	if err != nil {
 		log.Printf("WebSocket: Failed to marshal new king message: %v", err)
 		return
 	}
 
-	h.broadcast <- data
+	h.broadcastEffect <- effect
 	log.Printf("WebSocket: Broadcasted new king notification for user %s", username)
 }
 
 // GamesSyncProgressPayload contains progress info for game library sync
 type GamesSyncProgressPayload struct {
-	Phase         string `json:"phase"`          // "fetching_users", "fetching_categories", "complete"
+	Phase         string `json:"phase"`          // "fetching_users", "fetching_categories", "rate_limited", "complete"
 	CurrentGame   string `json:"current_game"`   // Name of current game being processed
 	ProcessedCount int   `json:"processed_count"` // Number of games processed so far
 	TotalCount    int    `json:"total_count"`    // Total games to process
@@ -399,7 +889,8 @@ func (h *Hub) BroadcastGamesSyncProgress(payload *GamesSyncProgressPayload) {
 		return
 	}
 
-	h.broadcast <- data
+	// Progress is outdated by the next update, replaying it would only push votes and chat out of the replay buffer
+	h.broadcastTransient <- data
 }
 
 // BroadcastGamesSyncComplete notifies all clients that game sync is complete
@@ -422,6 +913,28 @@ func (h *Hub) BroadcastGamesSyncComplete(totalGames int) {
 	log.Printf("WebSocket: Broadcasted games sync complete with %d games", totalGames)
 }
 
+// GamesUpdatedPayload lists the pinned games after admins changed the games list
+type GamesUpdatedPayload struct {
+	PinnedGameIDs []int `json:"pinned_game_ids"`
+}
+
+// BroadcastGamesUpdated notifies all clients that the games list changed
+func (h *Hub) BroadcastGamesUpdated(payload *GamesUpdatedPayload) {
+	msg := Message{
+		Type:    MessageTypeGamesUpdated,
+		Payload: payload,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal games updated message: %v", err)
+		return
+	}
+
+	h.broadcast <- data
+	log.Printf("WebSocket: Broadcasted games updated with %d pinned games", len(payload.PinnedGameIDs))
+}
+
 // UserActionPayload contains info about a user kick/ban
 type UserActionPayload struct {
 	UserID   uint64 `json:"user_id"`
@@ -467,3 +980,374 @@ func (h *Hub) BroadcastUserBanned(userID uint64, username string) {
 	h.broadcast <- data
 	log.Printf("WebSocket: Broadcasted user banned notification for %s", username)
 }
+
+// SecretVotesRevealedPayload contains info about the big reveal of secret votes
+type SecretVotesRevealedPayload struct {
+	RevealedCount int64          `json:"revealed_count"`
+	RevealedAt    string         `json:"revealed_at"`
+	Accessibility *Accessibility `json:"accessibility,omitempty"`
+}
+
+// BroadcastSecretVotesRevealed notifies all clients that all secret votes have been revealed
+func (h *Hub) BroadcastSecretVotesRevealed(payload *SecretVotesRevealedPayload) {
+	revealed := *payload
+	revealed.Accessibility = &Accessibility{
+		PlainText: fmt.Sprintf("%d geheime Votes wurden aufgedeckt", revealed.RevealedCount),
+	}
+
+	effect, err := marshalEffect(MessageTypeSecretVotesRevealed, &revealed, revealed.Accessibility)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal secret votes revealed message: %v", err)
+		return
+	}
+
+	h.broadcastEffect <- effect
+	log.Printf("WebSocket: Broadcasted secret votes reveal (%d votes) to all clients", payload.RevealedCount)
+}
+
+// OnFirePayload contains info about a vote streak
+type OnFirePayload struct {
+	UserID          uint64 `json:"user_id"`
+	Username        string `json:"username"`
+	Avatar          string `json:"avatar"`
+	AchievementID   string `json:"achievement_id"`
+	AchievementName string `json:"achievement_name"`
+	VoterCount      int    `json:"voter_count"`
+	WindowMinutes   int    `json:"window_minutes"`
+	BonusCredits    int    `json:"bonus_credits"`
+
+	Accessibility *Accessibility `json:"accessibility,omitempty"`
+}
+
+// BroadcastOnFire notifies all clients that a user is on fire (vote streak)
+func (h *Hub) BroadcastOnFire(payload *OnFirePayload) {
+	onFire := *payload
+	onFire.Accessibility = &Accessibility{
+		PlainText: fmt.Sprintf("%s ist on fire: %d Votes für „%s“ in %d Minuten", onFire.Username, onFire.VoterCount, onFire.AchievementName, onFire.WindowMinutes),
+	}
+
+	effect, err := marshalEffect(MessageTypeOnFire, &onFire, onFire.Accessibility)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal on fire message: %v", err)
+		return
+	}
+
+	h.broadcastEffect <- effect
+	log.Printf("WebSocket: Broadcasted on fire notification for %s (%s)", payload.Username, payload.AchievementID)
+}
+
+// VoteAppealPayload contains info about a vote appeal
+type VoteAppealPayload struct {
+	AppealID        uint64 `json:"appeal_id"`
+	VoteID          uint64 `json:"vote_id"`
+	UserID          uint64 `json:"user_id"`
+	Username        string `json:"username"`
+	AchievementID   string `json:"achievement_id"`
+	AchievementName string `json:"achievement_name"`
+	Reason          string `json:"reason"`
+	Status          string `json:"status"`
+	ResolutionNote  string `json:"resolution_note,omitempty"`
+}
+
+// NotifyVoteAppeal sends a new vote appeal to all connected admins
+func (h *Hub) NotifyVoteAppeal(adminSteamIDs []string, payload *VoteAppealPayload) {
+	msg := Message{
+		Type:    MessageTypeVoteAppeal,
+		Payload: payload,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal vote appeal message: %v", err)
+		return
+	}
+
+	h.sendToAdmins <- &adminMessage{SteamIDs: adminSteamIDs, Message: data}
+	log.Printf("WebSocket: Sent vote appeal %d to connected admins", payload.AppealID)
+}
+
+// adminUserIDs returns the user IDs of connected admins (caller holds the lock)
+func (h *Hub) adminUserIDs(adminSteamIDs []string) []uint64 {
+	isAdmin := make(map[string]bool, len(adminSteamIDs))
+	for _, steamID := range adminSteamIDs {
+		isAdmin[steamID] = true
+	}
+
+	var adminUserIDs []uint64
+	for userID, clients := range h.clients {
+		if isAdmin[clients[0].steamID] {
+			adminUserIDs = append(adminUserIDs, userID)
+		}
+	}
+	return adminUserIDs
+}
+
+// AccountReviewPayload contains info about a new account held for admin review
+type AccountReviewPayload struct {
+	ReviewID uint64   `json:"review_id"`
+	UserID   uint64   `json:"user_id"`
+	SteamID  string   `json:"steam_id"`
+	Username string   `json:"username"`
+	Reasons  []string `json:"reasons"`
+}
+
+// NotifyAccountReview sends a new account review to all connected admins
+func (h *Hub) NotifyAccountReview(adminSteamIDs []string, payload *AccountReviewPayload) {
+	msg := Message{
+		Type:    MessageTypeAccountReview,
+		Payload: payload,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal account review message: %v", err)
+		return
+	}
+
+	h.sendToAdmins <- &adminMessage{SteamIDs: adminSteamIDs, Message: data}
+	log.Printf("WebSocket: Sent account review %d to connected admins", payload.ReviewID)
+}
+
+// NotifyVoteAppealResolved tells the appellant how an admin resolved the appeal
+func (h *Hub) NotifyVoteAppealResolved(userID uint64, payload *VoteAppealPayload) {
+	msg := Message{
+		Type:    MessageTypeVoteAppealResolved,
+		Payload: payload,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal vote appeal resolved message: %v", err)
+		return
+	}
+
+	h.sendToUser <- &UserMessage{
+		UserID:  userID,
+		Message: data,
+	}
+	log.Printf("WebSocket: Sent vote appeal resolution (%s) to user %d", payload.Status, userID)
+}
+
+// AchievementsUpdatePayload contains the full achievement list after an admin change
+type AchievementsUpdatePayload struct {
+	Achievements interface{} `json:"achievements"` // All achievements including disabled ones
+}
+
+// BroadcastAchievementsUpdate notifies all clients that the achievements have changed
+func (h *Hub) BroadcastAchievementsUpdate(payload *AchievementsUpdatePayload) {
+	msg := Message{
+		Type:    MessageTypeAchievementsUpdate,
+		Payload: payload,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal achievements update message: %v", err)
+		return
+	}
+
+	h.broadcast <- data
+	log.Printf("WebSocket: Broadcasted achievements update to all clients")
+}
+
+// GameNewsPayload contains newly found news of commonly owned games
+type GameNewsPayload struct {
+	Items interface{} `json:"items"` // New news items, newest first
+}
+
+// BroadcastGameNews notifies all clients about new game news
+func (h *Hub) BroadcastGameNews(payload *GameNewsPayload) {
+	msg := Message{
+		Type:    MessageTypeGameNews,
+		Payload: payload,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal game news message: %v", err)
+		return
+	}
+
+	h.broadcast <- data
+	log.Printf("WebSocket: Broadcasted game news to all clients")
+}
+
+// GameDealPayload contains newly detected deals of pinned or commonly owned games
+type GameDealPayload struct {
+	Deals interface{} `json:"deals"` // New deals, free games first
+}
+
+// BroadcastGameDeal notifies all clients about new game deals
+func (h *Hub) BroadcastGameDeal(payload *GameDealPayload) {
+	msg := Message{
+		Type:    MessageTypeGameDeal,
+		Payload: payload,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal game deal message: %v", err)
+		return
+	}
+
+	h.broadcast <- data
+	log.Printf("WebSocket: Broadcasted game deals to all clients")
+}
+
+// Events of game server announcements
+const (
+	GameServerEventUp   = "up"
+	GameServerEventDown = "down"
+)
+
+// GameServerPayload announces a joinable game server or its removal
+type GameServerPayload struct {
+	Event  string      `json:"event"`
+	AppID  int         `json:"app_id"`
+	Server interface{} `json:"server,omitempty"` // Not set when the server was removed
+}
+
+// BroadcastGameServer notifies all clients about an announced or removed game server
+func (h *Hub) BroadcastGameServer(payload *GameServerPayload) {
+	msg := Message{
+		Type:    MessageTypeGameServer,
+		Payload: payload,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal game server message: %v", err)
+		return
+	}
+
+	h.broadcast <- data
+	log.Printf("WebSocket: Broadcasted game server %s of app %d to all clients", payload.Event, payload.AppID)
+}
+
+// GameSessionsPayload lists the games that are currently played
+type GameSessionsPayload struct {
+	Games interface{} `json:"games"`
+}
+
+// BroadcastGameSessions notifies all clients about the games that are currently played
+func (h *Hub) BroadcastGameSessions(payload *GameSessionsPayload) {
+	msg := Message{
+		Type:    MessageTypeGameSessions,
+		Payload: payload,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal game sessions message: %v", err)
+		return
+	}
+
+	h.broadcast <- data
+	log.Println("WebSocket: Broadcasted game sessions to all clients")
+}
+
+// DownloadReminderPayload lists the downloads a player has not confirmed yet
+type DownloadReminderPayload struct {
+	Missing       interface{} `json:"missing"`                   // Download requirements not confirmed by the player
+	EventStartsAt *string     `json:"event_starts_at,omitempty"` // RFC3339 formatted countdown target, if set
+}
+
+// NotifyDownloadReminder sends a download reminder to a specific user (all connected clients)
+func (h *Hub) NotifyDownloadReminder(userID uint64, payload *DownloadReminderPayload) {
+	msg := Message{
+		Type:    MessageTypeDownloadReminder,
+		Payload: payload,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal download reminder message: %v", err)
+		return
+	}
+
+	h.sendToUser <- &UserMessage{
+		UserID:  userID,
+		Message: data,
+	}
+}
+
+// AchievementLivePayload announces an achievement created from a player suggestion
+type AchievementLivePayload struct {
+	Achievement   interface{} `json:"achievement"`
+	SuggestionID  uint64      `json:"suggestion_id"`
+	SuggestedByID uint64      `json:"suggested_by_id"`
+	SuggestedBy   string      `json:"suggested_by"` // Username of the player who suggested it
+
+	Accessibility *Accessibility `json:"accessibility,omitempty"`
+}
+
+// BroadcastAchievementLive announces a newly approved achievement to all clients
+func (h *Hub) BroadcastAchievementLive(payload *AchievementLivePayload) {
+	live := *payload
+	live.Accessibility = &Accessibility{
+		PlainText: fmt.Sprintf("Ein neues Achievement von %s ist jetzt verfügbar", live.SuggestedBy),
+	}
+
+	effect, err := marshalEffect(MessageTypeAchievementLive, &live, live.Accessibility)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal achievement live message: %v", err)
+		return
+	}
+
+	h.broadcastEffect <- effect
+	log.Printf("WebSocket: Broadcasted approved achievement suggestion %d", payload.SuggestionID)
+}
+
+// BadgeAwardedPayload announces a meta-badge earned by a user
+type BadgeAwardedPayload struct {
+	UserID   uint64      `json:"user_id"`
+	Username string      `json:"username"`
+	Avatar   string      `json:"avatar"`
+	Badge    interface{} `json:"badge"`
+
+	Accessibility *Accessibility `json:"accessibility,omitempty"`
+}
+
+// BroadcastBadgeAwarded notifies all clients that a user earned a badge
+func (h *Hub) BroadcastBadgeAwarded(payload *BadgeAwardedPayload) {
+	award := *payload
+	award.Accessibility = &Accessibility{
+		PlainText: fmt.Sprintf("%s hat ein neues Abzeichen erhalten", award.Username),
+	}
+
+	effect, err := marshalEffect(MessageTypeBadgeAwarded, &award, award.Accessibility)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal badge awarded message: %v", err)
+		return
+	}
+
+	h.broadcastEffect <- effect
+	log.Printf("WebSocket: Broadcasted badge award for %s", payload.Username)
+}
+
+// AnnouncementPayload contains an announcement broadcast by an admin
+type AnnouncementPayload struct {
+	ID        uint64  `json:"id"`
+	Title     string  `json:"title"`
+	Body      string  `json:"body"`
+	Severity  string  `json:"severity"`             // info, warning or critical
+	ExpiresAt *string `json:"expires_at,omitempty"` // RFC3339 formatted time, null if it does not expire
+	CreatedAt string  `json:"created_at"`
+}
+
+// BroadcastAnnouncement sends an admin announcement to all clients
+func (h *Hub) BroadcastAnnouncement(payload *AnnouncementPayload) {
+	msg := Message{
+		Type:    MessageTypeAnnouncement,
+		Payload: payload,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal announcement message: %v", err)
+		return
+	}
+
+	h.broadcast <- data
+	log.Printf("WebSocket: Broadcasted announcement %d", payload.ID)
+}
@@ -0,0 +1,93 @@
+package websocket
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// inboundMessage is a message sent by a client, the payload depends on the type
+type inboundMessage struct {
+	Type    MessageType     `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// ChatSendPayload is the payload of a chat_send message from a client
+type ChatSendPayload struct {
+	Ref       string  `json:"ref"` // Chosen by the client, returned in the result to match it with the request
+	Message   string  `json:"message"`
+	ReplyToID *uint64 `json:"reply_to_id,omitempty"`
+}
+
+// ChatSendResultPayload answers a chat_send message
+// The message itself arrives as regular chat_message broadcast
+type ChatSendResultPayload struct {
+	Ref       string                 `json:"ref"`
+	OK        bool                   `json:"ok"`
+	Status    int                    `json:"status"`               // HTTP status POST /chat would have returned
+	MessageID uint64                 `json:"message_id,omitempty"` // ID of the created message
+	Error     map[string]interface{} `json:"error,omitempty"`      // Error body POST /chat would have returned
+}
+
+// ChatSendHandler validates, stores and broadcasts a chat message sent by a user
+type ChatSendHandler func(userID uint64, steamID, username string, payload *ChatSendPayload) *ChatSendResultPayload
+
+// SetChatSendHandler sets the handler for chat_send messages, without handler they are ignored
+func (h *Hub) SetChatSendHandler(handler ChatSendHandler) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.chatSendHandler = handler
+}
+
+// handleInbound dispatches a message received from a client, unknown types are ignored
+func (h *Hub) handleInbound(c *Client, data []byte) {
+	var msg inboundMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		log.Printf("WebSocket: Ignoring invalid message from client %d: %v", c.userID, err)
+		return
+	}
+
+	switch msg.Type {
+	case MessageTypeChatSend:
+		h.handleChatSend(c, msg.Payload)
+	case MessageTypeSubscribe:
+		h.handleSubscribe(c, msg.Payload)
+	}
+}
+
+// handleChatSend posts a chat message of a client and sends the result to the user
+// The result goes to all connections of the user, other devices ignore the unknown ref
+func (h *Hub) handleChatSend(c *Client, data json.RawMessage) {
+	h.mutex.RLock()
+	handler := h.chatSendHandler
+	h.mutex.RUnlock()
+	if handler == nil {
+		return
+	}
+
+	var payload ChatSendPayload
+	var result *ChatSendResultPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		result = &ChatSendResultPayload{
+			Ref:    payload.Ref,
+			Status: http.StatusBadRequest,
+			Error:  map[string]interface{}{"error": "Invalid chat_send payload"},
+		}
+	} else {
+		result = handler(c.userID, c.steamID, c.username, &payload)
+	}
+
+	response, err := json.Marshal(Message{
+		Type:    MessageTypeChatSendResult,
+		Payload: result,
+	})
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal chat send result: %v", err)
+		return
+	}
+
+	h.sendToUser <- &UserMessage{
+		UserID:  c.userID,
+		Message: response,
+	}
+}
@@ -0,0 +1,75 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Timeout for connecting to Redis and for a PUBLISH round trip
+const redisTimeout = 5 * time.Second
+
+// RedisFanout relays hub messages between backend instances over a Redis pub/sub channel
+type RedisFanout struct {
+	client  *redis.Client
+	channel string
+}
+
+// NewRedisFanout creates a fanout for a redis:// or rediss:// (TLS) URL, e.g. redis://:password@redis:6379
+// The database of the URL is ignored, pub/sub channels are shared by all databases
+func NewRedisFanout(rawURL, channel string) (*RedisFanout, error) {
+	opts, err := redis.ParseURL(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	if channel == "" {
+		return nil, errors.New("Redis channel is missing")
+	}
+
+	opts.DialTimeout = redisTimeout
+	opts.ReadTimeout = redisTimeout
+	opts.WriteTimeout = redisTimeout
+
+	return &RedisFanout{
+		client:  redis.NewClient(opts),
+		channel: channel,
+	}, nil
+}
+
+// Publish sends a message to the channel
+func (f *RedisFanout) Publish(ctx context.Context, data []byte) error {
+	if err := f.client.Publish(ctx, f.channel, data).Err(); err != nil {
+		return fmt.Errorf("failed to publish to Redis: %w", err)
+	}
+	return nil
+}
+
+// Subscribe calls handle for every message on the channel until ctx is done
+// The client re-establishes lost connections and subscribes again on its own
+func (f *RedisFanout) Subscribe(ctx context.Context, handle func(data []byte)) error {
+	pubsub := f.client.Subscribe(ctx, f.channel)
+	defer pubsub.Close()
+	log.Printf("WebSocket: Subscribing to Redis channel %s", f.channel)
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-messages:
+			if !ok {
+				return errors.New("redis subscription closed")
+			}
+			handle([]byte(msg.Payload))
+		}
+	}
+}
+
+// Close closes the connections to Redis
+func (f *RedisFanout) Close() error {
+	return f.client.Close()
+}
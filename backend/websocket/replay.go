@@ -0,0 +1,113 @@
+package websocket
+
+import (
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+)
+
+// replayBufferSize is the number of broadcasts kept for reconnecting clients
+// Must stay below the send buffer of a client, the whole gap is queued at once
+const replayBufferSize = 200
+
+// ResumePayload tells a client where the broadcasts continue
+// Complete is false if broadcasts were lost (hub restarted or gap older than the buffer), the client reloads its data
+type ResumePayload struct {
+	Epoch    string `json:"epoch"`    // Changes on every hub start, sequence numbers restart with it
+	Seq      uint64 `json:"seq"`      // Sequence number of the latest broadcast
+	Replayed int    `json:"replayed"` // Number of missed broadcasts sent after this message
+	Complete bool   `json:"complete"`
+}
+
+// Resume is the position of a reconnecting client, from the epoch and last_seq query parameters
+type Resume struct {
+	Epoch   string
+	LastSeq uint64
+}
+
+// replayEntry is a broadcast kept for replay, reduced is only set for effects
+type replayEntry struct {
+	msgType  MessageType // Read once for the subscription filters
+	standard []byte
+	reduced  []byte
+}
+
+// newEntry prepares a broadcast for delivery
+func newEntry(standard, reduced []byte) *replayEntry {
+	return &replayEntry{msgType: messageTypeOf(standard), standard: standard, reduced: reduced}
+}
+
+// forUser picks the variant matching a user's reduced motion preference
+func (e *replayEntry) forUser(reducedMotion bool) []byte {
+	if reducedMotion && e.reduced != nil {
+		return e.reduced
+	}
+	return e.standard
+}
+
+// newEpoch returns an identifier for this hub start
+func newEpoch() string {
+	return strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+// record numbers a broadcast and keeps it in the ring buffer, returns the message with its seq (caller holds the lock)
+func (h *Hub) record(standard, reduced []byte) *replayEntry {
+	h.seq++
+	entry := newEntry(withSeq(standard, h.seq), nil)
+	if reduced != nil {
+		entry.reduced = withSeq(reduced, h.seq)
+	}
+	h.history[h.seq%replayBufferSize] = *entry
+	return entry
+}
+
+// withSeq adds the sequence number as the first field of a marshaled Message
+func withSeq(data []byte, seq uint64) []byte {
+	stamped := make([]byte, 0, len(data)+24)
+	stamped = append(stamped, `{"seq":`...)
+	stamped = strconv.AppendUint(stamped, seq, 10)
+	stamped = append(stamped, ',')
+	return append(stamped, data[1:]...)
+}
+
+// replay queues the resume message and the broadcasts a registered client missed (caller holds the lock)
+// It runs in the hub loop before any live broadcast, so the client gets the gap first
+func (h *Hub) replay(client *Client, resume *Resume) {
+	payload := ResumePayload{Epoch: h.epoch, Seq: h.seq, Complete: true}
+
+	var missed []*replayEntry
+	if resume != nil && resume.Epoch != "" {
+		oldest := uint64(1)
+		if h.seq > replayBufferSize {
+			oldest = h.seq - replayBufferSize + 1
+		}
+		switch {
+		case resume.Epoch != h.epoch || resume.LastSeq > h.seq:
+			payload.Complete = false
+		case resume.LastSeq+1 < oldest:
+			payload.Complete = false
+		default:
+			for seq := resume.LastSeq + 1; seq <= h.seq; seq++ {
+				missed = append(missed, &h.history[seq%replayBufferSize])
+			}
+		}
+		payload.Replayed = len(missed)
+		log.Printf("WebSocket: User %d (%s) resumed at seq %d of %d, replaying %d broadcasts (complete: %v)",
+			client.userID, client.username, resume.LastSeq, h.seq, len(missed), payload.Complete)
+	}
+
+	data, err := json.Marshal(Message{Type: MessageTypeResume, Payload: &payload})
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal resume message: %v", err)
+		return
+	}
+	client.send <- data
+
+	reducedMotion := h.reducedMotion[client.userID]
+	for _, entry := range missed {
+		if client.wants(entry.msgType) {
+			client.send <- entry.forUser(reducedMotion)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package websocket
+
+import (
+	"sort"
+	"time"
+
+	"github.com/guided-traffic/rate-your-mate/backend/metrics"
+)
+
+// throughputWindow is the number of one-second buckets a throughput is summed over
+const throughputWindow = 60
+
+// throughput counts events over the last minute in one-second buckets (guarded by the hub mutex)
+type throughput struct {
+	seconds [throughputWindow]int64 // Unix second of each bucket, outdated buckets are reused
+	counts  [throughputWindow]int64
+}
+
+// add counts an event at now (caller holds the lock)
+func (t *throughput) add(now time.Time) {
+	second := now.Unix()
+	i := second % throughputWindow
+	if t.seconds[i] != second {
+		t.seconds[i] = second
+		t.counts[i] = 0
+	}
+	t.counts[i]++
+}
+
+// lastMinute sums the events of the last minute (caller holds at least the read lock)
+func (t *throughput) lastMinute(now time.Time) int64 {
+	oldest := now.Unix() - throughputWindow
+	var sum int64
+	for i, second := range t.seconds {
+		if second > oldest {
+			sum += t.counts[i]
+		}
+	}
+	return sum
+}
+
+// HubStatus describes the connections and message throughput of the hub, served by GET /api/v1/ws/status
+// (without the connections per user) and GET /api/v1/admin/ws/status
+// Totals count since the start of this instance, with a fan-out other instances are not included
+type HubStatus struct {
+	ConnectedUsers      int               `json:"connected_users"`
+	Connections         int               `json:"connections"`
+	ConnectionsPerUser  []UserConnections `json:"connections_per_user,omitempty"`
+	BroadcastsTotal     int64             `json:"broadcasts_total"`
+	BroadcastsPerMinute int64             `json:"broadcasts_per_minute"`
+	MessagesTotal       int64             `json:"messages_total"` // Messages queued for clients, a broadcast counts once per client
+	MessagesPerMinute   int64             `json:"messages_per_minute"`
+	DroppedTotal        int64             `json:"dropped_total"` // Messages dropped because a send buffer was full, the connection is closed
+}
+
+// UserConnections lists the open connections of a user
+type UserConnections struct {
+	UserID      uint64             `json:"user_id"`
+	Username    string             `json:"username"`
+	Connections []ConnectionStatus `json:"connections"`
+}
+
+// ConnectionStatus describes a single connection, flaky clients show old last_seen values or full queues
+type ConnectionStatus struct {
+	ConnectedAt time.Time `json:"connected_at"`
+	LastSeen    time.Time `json:"last_seen"` // Last pong or message from the client
+	Queued      int       `json:"queued"`    // Messages waiting in the send buffer
+	Buffer      int       `json:"buffer"`    // Size of the send buffer
+}
+
+// queue puts a message into the send buffer of a client and counts it (caller holds the lock)
+// It returns false if the buffer is full, the caller decides whether to close the connection
+func (h *Hub) queue(client *Client, message []byte) bool {
+	select {
+	case client.send <- message:
+		h.messages.add(time.Now())
+		metrics.WebSocketMessages.Inc()
+		return true
+	default:
+		metrics.WebSocketDropped.Inc()
+		return false
+	}
+}
+
+// Status returns the current connections and message throughput
+func (h *Hub) Status() *HubStatus {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	now := time.Now()
+	status := &HubStatus{
+		ConnectedUsers:      len(h.clients),
+		Connections:         len(h.allClients),
+		ConnectionsPerUser:  make([]UserConnections, 0, len(h.clients)),
+		BroadcastsTotal:     metrics.WebSocketBroadcasts.Value(),
+		BroadcastsPerMinute: h.broadcasts.lastMinute(now),
+		MessagesTotal:       metrics.WebSocketMessages.Value(),
+		MessagesPerMinute:   h.messages.lastMinute(now),
+		DroppedTotal:        metrics.WebSocketDropped.Value(),
+	}
+
+	for userID, clients := range h.clients {
+		user := UserConnections{UserID: userID, Connections: make([]ConnectionStatus, 0, len(clients))}
+		for _, client := range clients {
+			user.Username = client.username
+			user.Connections = append(user.Connections, ConnectionStatus{
+				ConnectedAt: client.connectedAt,
+				LastSeen:    time.Unix(0, client.lastSeen.Load()),
+				Queued:      len(client.send),
+				Buffer:      cap(client.send),
+			})
+		}
+		status.ConnectionsPerUser = append(status.ConnectionsPerUser, user)
+	}
+	// Users with the most connections first
+	sort.Slice(status.ConnectionsPerUser, func(i, j int) bool {
+		a, b := status.ConnectionsPerUser[i], status.ConnectionsPerUser[j]
+		if len(a.Connections) != len(b.Connections) {
+			return len(a.Connections) > len(b.Connections)
+		}
+		return a.UserID < b.UserID
+	})
+
+	return status
+}
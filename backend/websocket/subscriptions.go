@@ -0,0 +1,122 @@
+package websocket
+
+import (
+	"encoding/json"
+	"log"
+)
+
+// SubscribePayload is the payload of a subscribe message from a client
+// Types lists the message types the connection wants, an empty list subscribes to everything again
+type SubscribePayload struct {
+	Types []MessageType `json:"types"`
+}
+
+// SubscribedPayload answers a subscribe message with the accepted types, nil means everything
+type SubscribedPayload struct {
+	Types []MessageType `json:"types"`
+}
+
+// subscribableTypes are the message types a client can choose, unknown types in a subscribe message are dropped
+var subscribableTypes = map[MessageType]bool{
+	MessageTypeVoteReceived:        true,
+	MessageTypeNewVote:             true,
+	MessageTypeUserJoined:          true,
+	MessageTypeSettingsUpdate:      true,
+	MessageTypeCreditsReset:        true,
+	MessageTypeCreditsGiven:        true,
+	MessageTypeVotesReset:          true,
+	MessageTypeChatMessage:         true,
+	MessageTypeNewKing:             true,
+	MessageTypeGamesSyncProgress:   true,
+	MessageTypeGamesSyncComplete:   true,
+	MessageTypeUserKicked:          true,
+	MessageTypeUserBanned:          true,
+	MessageTypeVoteInvalidation:    true,
+	MessageTypeSecretVotesRevealed: true,
+	MessageTypeOnFire:              true,
+	MessageTypeVoteAppeal:          true,
+	MessageTypeVoteAppealResolved:  true,
+	MessageTypeAchievementsUpdate:  true,
+	MessageTypeGamesUpdated:        true,
+	MessageTypeGameNews:            true,
+	MessageTypeGameDeal:            true,
+	MessageTypeGameServer:          true,
+	MessageTypeGameSessions:        true,
+	MessageTypeDownloadReminder:    true,
+	MessageTypeAchievementLive:     true,
+	MessageTypeBadgeAwarded:        true,
+	MessageTypeChatMessageDeleted:  true,
+	MessageTypeChatMention:         true,
+	MessageTypeChatUnread:          true,
+	MessageTypePollUpdate:          true,
+	MessageTypeAccountReview:       true,
+	MessageTypeUserMuted:           true,
+	MessageTypeAnnouncement:        true,
+}
+
+// wants reports whether the client subscribed to a message type (caller holds the lock)
+// Control messages like resume, connection_closed and answers to the client's own requests are not subscribable and always pass
+func (c *Client) wants(msgType MessageType) bool {
+	return c.subscriptions == nil || !subscribableTypes[msgType] || c.subscriptions[msgType]
+}
+
+// messageTypeOf reads the type of a marshaled Message
+func messageTypeOf(data []byte) MessageType {
+	var msg struct {
+		Type MessageType `json:"type"`
+	}
+	json.Unmarshal(data, &msg)
+	return msg.Type
+}
+
+// handleSubscribe replaces the message types a connection receives and confirms the accepted ones
+func (h *Hub) handleSubscribe(c *Client, data json.RawMessage) {
+	var payload SubscribePayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		log.Printf("WebSocket: Ignoring invalid subscribe message from client %d: %v", c.userID, err)
+		return
+	}
+
+	var subscriptions map[MessageType]bool
+	var accepted []MessageType
+	if len(payload.Types) > 0 {
+		subscriptions = make(map[MessageType]bool, len(payload.Types))
+		for _, msgType := range payload.Types {
+			if subscribableTypes[msgType] && !subscriptions[msgType] {
+				subscriptions[msgType] = true
+				accepted = append(accepted, msgType)
+			}
+		}
+		// Only unknown types leave the connection with control messages, answered as [] since null means everything
+		if accepted == nil {
+			accepted = []MessageType{}
+		}
+	}
+
+	response, err := json.Marshal(Message{
+		Type:    MessageTypeSubscribed,
+		Payload: &SubscribedPayload{Types: accepted},
+	})
+	if err != nil {
+		log.Printf("WebSocket: Failed to marshal subscribed message: %v", err)
+		return
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	// The hub may have closed the send channel of the connection in the meantime
+	if !h.allClients[c] {
+		return
+	}
+	c.subscriptions = subscriptions
+	if !h.queue(c, response) {
+		h.removeClient(c)
+	}
+
+	if subscriptions == nil {
+		log.Printf("WebSocket: Client %d (%s) subscribed to all message types", c.userID, c.username)
+	} else {
+		log.Printf("WebSocket: Client %d (%s) subscribed to %d message types", c.userID, c.username, len(accepted))
+	}
+}
@@ -10,6 +10,7 @@ import { WebSocketService } from './services/websocket.service';
 import { ConnectionStatusService } from './services/connection-status.service';
 import { AchievementService } from './services/achievement.service';
 import { ChatService } from './services/chat.service';
+import { AnnouncementService } from './services/announcement.service';
 
 @Component({
   selector: 'app-root',
@@ -23,6 +24,7 @@ export class App implements OnInit, OnDestroy {
   private wsService = inject(WebSocketService);
   private connectionStatus = inject(ConnectionStatusService);
   private achievementService = inject(AchievementService);
+  private announcementService = inject(AnnouncementService);
   chatService = inject(ChatService);
 
   get isAuthenticated(): boolean {
@@ -39,6 +41,7 @@ export class App implements OnInit, OnDestroy {
       if (isAuth && user) {
         // User is authenticated and data is loaded
         this.wsService.connect();
+        this.announcementService.loadActive();
         this.connectionStatus.markInitialLoadComplete();
       } else if (isAuth && !user && !isLoading) {
         // Has token but user load failed (e.g., network error) - still complete initial load
@@ -4,6 +4,7 @@ import { Router, RouterLink, RouterLinkActive } from '@angular/router';
 import { AuthService } from '../../services/auth.service';
 import { WebSocketService } from '../../services/websocket.service';
 import { NotificationService } from '../../services/notification.service';
+import { InboxService } from '../../services/inbox.service';
 import { SettingsService } from '../../services/settings.service';
 import { SoundService } from '../../services/sound.service';
 import { RankingService, PlayerRanking, GlobalRankingResponse } from '../../services/ranking.service';
@@ -656,13 +657,16 @@ export class HeaderComponent implements OnInit, OnDestroy {
   private userService = inject(UserService);
   private router = inject(Router);
   private notifications = inject(NotificationService);
+  private inbox = inject(InboxService);
   private settingsService = inject(SettingsService);
   private soundService = inject(SoundService);
   private subscription?: Subscription;
+  private voteReceivedSubscription?: Subscription;
   private settingsSubscription?: Subscription;
   private creditsResetSubscription?: Subscription;
   private creditsGivenSubscription?: Subscription;
   private newKingSubscription?: Subscription;
+  private userMutedSubscription?: Subscription;
   private timerSubscription?: Subscription;
   private voteInvalidationSubscription?: Subscription;
   private timerInitialized = false;
@@ -792,25 +796,25 @@ export class HeaderComponent implements OnInit, OnDestroy {
       this.rankingService.loadMyRanking();
     }
 
-    // Listen for vote notifications - show popup only if current user is the recipient
-    this.subscription = this.ws.newVote$.subscribe((payload) => {
-      const currentUser = this.auth.user();
-      if (currentUser && payload.to_user_id === currentUser.id) {
-        // Play sound based on whether the review is positive or negative
-        if (payload.is_positive) {
-          this.soundService.playGoodReview();
-        } else {
-          this.soundService.playBadReview();
-        }
-        this.notifications.voteReceived(
-          payload.from_username,
-          payload.achievement_name,
-          payload.from_avatar,
-          payload.is_positive
-        );
-        // Refresh user data to update any stats
-        this.auth.refreshUser();
+    // Listen for personal vote notifications - missed ones are shown by the inbox after reconnecting
+    this.voteReceivedSubscription = this.inbox.voteReceived$.subscribe((payload) => {
+      // Play sound based on whether the review is positive or negative
+      if (payload.is_positive) {
+        this.soundService.playGoodReview();
+      } else {
+        this.soundService.playBadReview();
       }
+      this.notifications.voteReceived(
+        payload.from_username,
+        payload.achievement_name,
+        payload.from_avatar,
+        payload.is_positive
+      );
+      // Refresh user data to update any stats
+      this.auth.refreshUser();
+    });
+
+    this.subscription = this.ws.newVote$.subscribe(() => {
       // Refresh ranking on any new vote
       this.rankingService.refresh();
       // Reload overlay data if visible
@@ -875,11 +879,11 @@ export class HeaderComponent implements OnInit, OnDestroy {
     });
 
     // Listen for credits given from admin
-    this.creditsGivenSubscription = this.ws.creditsGiven$.subscribe(() => {
+    this.creditsGivenSubscription = this.ws.creditsGiven$.subscribe((payload) => {
       console.log('Credits given via WebSocket');
       this.soundService.playNewCredit();
       this.auth.refreshUser();
-      this.notifications.success('🎁 Credit erhalten', 'Der Admin hat dir 1 Credit gegeben');
+      this.notifications.success('🎁 Credit erhalten', payload.message || 'Der Admin hat dir 1 Credit gegeben');
     });
 
     // Listen for new king notifications
@@ -888,14 +892,34 @@ export class HeaderComponent implements OnInit, OnDestroy {
       this.soundService.playNewKing();
       this.notifications.success('👑 Neuer König!', `${payload.username} ist der neue König der LAN-Party!`);
     });
+
+    // Listen for mutes of the current user
+    this.userMutedSubscription = this.ws.userMuted$.subscribe((payload) => {
+      console.log('Mute state via WebSocket:', payload);
+      if (!payload.muted) {
+        this.notifications.info('🔊 Stummschaltung aufgehoben', 'Du kannst wieder im Chat schreiben');
+        return;
+      }
+      const until = new Date(payload.muted_until!).toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' });
+      const scope = payload.include_votes ? 'schreiben und voten' : 'schreiben';
+      const reason = payload.reason ? ` Grund: ${payload.reason}` : '';
+      this.notifications.show({
+        type: 'error',
+        title: '🔇 Stummgeschaltet',
+        message: `Ein Admin hat dich stummgeschaltet. Du kannst bis ${until} Uhr nicht ${scope}.${reason}`,
+        duration: 10000
+      });
+    });
   }
 
   ngOnDestroy(): void {
     this.subscription?.unsubscribe();
+    this.voteReceivedSubscription?.unsubscribe();
     this.settingsSubscription?.unsubscribe();
     this.creditsResetSubscription?.unsubscribe();
     this.creditsGivenSubscription?.unsubscribe();
     this.newKingSubscription?.unsubscribe();
+    this.userMutedSubscription?.unsubscribe();
     this.timerSubscription?.unsubscribe();
     this.voteInvalidationSubscription?.unsubscribe();
   }
@@ -1,13 +1,64 @@
+export type AchievementCategoryId = 'skill' | 'social' | 'meme' | 'negative';
+
 export interface Achievement {
   id: string;
   name: string;
   description: string;
   image_url: string;
   is_positive: boolean;
+  category: AchievementCategoryId;
+  tags: string[];
+  weight: number;
+  valid_from?: string | null;
+  valid_until?: string | null;
+  app_id?: number | null; // Game-scoped achievements can only be voted in the context of this Steam app
+}
+
+export interface AchievementCategoryGroup {
+  id: AchievementCategoryId;
+  name: string;
+  achievements: Achievement[];
 }
 
 export interface AchievementsResponse {
   achievements: Achievement[];
   positive: Achievement[];
   negative: Achievement[];
+  categories: AchievementCategoryGroup[];
+}
+
+export interface AchievementStats {
+  achievement: Achievement;
+  vote_count: number;
+  recipient_count: number;
+  recipient_percent: number; // Share of all players who received it
+  rarity_percentile: number; // Share of achievements received by more players, 100 = rarest
+}
+
+export interface AchievementStatsResponse {
+  stats: AchievementStats[];
+  total_players: number;
+}
+
+export type AchievementSuggestionStatus = 'pending' | 'approved' | 'rejected';
+
+export interface AchievementSuggestion {
+  id: number;
+  user_id: number;
+  username: string;
+  name: string;
+  description: string;
+  is_positive: boolean;
+  status: AchievementSuggestionStatus;
+  achievement_id?: string;
+  reviewed_by?: string;
+  review_note?: string;
+  reviewed_at?: string;
+  created_at: string;
+}
+
+export interface AchievementSuggestionRequest {
+  name: string;
+  description?: string;
+  is_positive: boolean;
 }
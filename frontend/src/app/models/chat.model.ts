@@ -9,18 +9,33 @@ export interface AchievementBadge {
   count: number;
 }
 
+export interface ChatReplyPreview {
+  id: number;
+  user: User;
+  snippet: string;
+}
+
 export interface ChatMessage {
   id: number;
   user: User;
   message: string;
   achievements: AchievementBadge[];
+  reply_to?: ChatReplyPreview;
+  is_system?: boolean;
   created_at: string;
 }
 
 export interface CreateChatMessageRequest {
   message: string;
+  reply_to_id?: number;
 }
 
 export interface ChatMessagesResponse {
   messages: ChatMessage[];
+  next_cursor: string; // Cursor of the older messages, empty if there are none
+}
+
+export interface ChatMentionsResponse {
+  mentions: ChatMessage[];
+  unread_count: number;
 }
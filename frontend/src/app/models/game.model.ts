@@ -1,12 +1,18 @@
+import { User } from './user.model';
+
 export interface Game {
   app_id: number;
   name: string;
   header_image_url: string;
   capsule_image_url: string;
+  launch_url: string; // steam://run/<appid>
   playtime_forever: number;
   categories: string[];
+  genres?: string[]; // Steam genres, e.g. "Action", "Racing"
+  tags?: string[]; // Top user tags, most votes first
   owner_count: number;
   owners: string[];
+  owner_details?: GameOwnerInfo[]; // Only with include_owners
   is_pinned: boolean;
   // Price information
   is_free: boolean;
@@ -16,8 +22,148 @@ export interface Game {
   price_formatted: string;
   // Review information
   review_score: number; // Percentage of positive reviews (0-100), -1 if not enough reviews
-  // Custom metadata (manually curated)
+  review_score_desc?: string; // Steam review wording, e.g. "Overwhelmingly Positive"
+  review_count: number; // Total number of reviews
+  // Player counts, curated metadata takes precedence over the store description
   max_players?: number; // Maximum number of players, 0 or undefined if unknown
+  coop_modes?: ('online' | 'lan' | 'local')[];
+  // Star ratings of the players (1-5)
+  community_rating?: number; // Average, one decimal
+  community_rating_count: number;
+}
+
+export interface PinnedGame {
+  app_id: number;
+  name: string; // Empty while the store data is not cached
+  header_image_url: string;
+}
+
+export interface GameServer {
+  app_id: number;
+  game_name: string;
+  address: string; // host or host:port
+  note?: string;
+  join_url: string; // steam://connect/<address>
+  launch_url: string;
+  updated_by: User;
+  updated_at: string;
+}
+
+export interface GameServerRequest {
+  address: string;
+  note?: string;
+}
+
+export interface WishlistGame {
+  app_id: number;
+  name: string;
+  header_image_url: string;
+  is_free: boolean;
+  price_cents: number;
+  original_cents: number;
+  discount_percent: number;
+  price_formatted: string; // Empty until the store details were synced
+  wishlist_count: number;
+  users: User[];
+}
+
+export interface WishlistsResponse {
+  games: WishlistGame[];
+  enabled: boolean;
+  updated_at?: string; // Last refresh of all wishlists
+}
+
+export interface TeamBalanceRequest {
+  user_ids: number[];
+  team_count?: number; // 2-8, default 2
+}
+
+export interface TeamPlayer extends User {
+  skill: number; // 0-100
+  positive_votes: number;
+  rank: number; // 0 if not in the public ranking
+  playtime_minutes: number;
+}
+
+export interface BalancedTeam {
+  number: number;
+  skill: number;
+  players: TeamPlayer[];
+}
+
+export interface TeamBalance {
+  app_id: number;
+  game_name: string;
+  teams: BalancedTeam[];
+  spread: number; // Skill difference between the strongest and the weakest team
+}
+
+export interface ActivePlayer extends User {
+  source: 'manual' | 'presence'; // presence = Steam "currently playing" status
+  since: string;
+}
+
+export interface ActiveGame {
+  app_id: number;
+  game_name: string;
+  launch_url: string;
+  players: ActivePlayer[];
+}
+
+export interface GameSessionGameStats {
+  app_id: number;
+  game_name: string;
+  minutes: number; // Time at least one player was in the game
+  player_minutes: number; // Sum of the time of all players
+  player_count: number;
+}
+
+export interface GameSessionPlayerGame {
+  app_id: number;
+  game_name: string;
+  minutes: number;
+}
+
+export interface GameSessionPlayerStats {
+  user: User;
+  total_minutes: number;
+  games: GameSessionPlayerGame[];
+}
+
+export interface GameSessionStats {
+  games: GameSessionGameStats[];
+  players: GameSessionPlayerStats[];
+}
+
+export interface GameRating {
+  app_id: number;
+  my_rating: number; // 0 if not rated
+  average: number;
+  count: number;
+}
+
+export interface GameOwnerInfo {
+  user_id: number;
+  steam_id: string;
+  username: string;
+  avatar_small: string;
+  playtime_forever: number; // minutes
+}
+
+export interface CommonGame extends Game {
+  combined_playtime: number; // Minutes, sum of the selected players
+}
+
+export interface CommonGamesResponse {
+  user_ids: number[];
+  games: CommonGame[];
+}
+
+export interface SteamRateLimitStatus {
+  paused: boolean;
+  paused_until?: string;
+  strikes: number; // 429 responses in a row
+  requests_per_minute: number;
 }
 
 export interface SyncStatus {
@@ -27,6 +173,7 @@ export interface SyncStatus {
   current_game: string;
   processed: number;
   total: number;
+  rate_limit?: SteamRateLimitStatus; // Only from the sync status endpoint
 }
 
 export interface GamesResponse {
@@ -35,6 +182,22 @@ export interface GamesResponse {
   sync_status?: SyncStatus;
 }
 
+export type GameSort = 'owners' | 'price' | 'review' | 'playtime' | 'rating';
+
+export interface GameFilter {
+  search?: string;
+  min_owners?: number;
+  max_price?: number; // cents
+  category?: string;
+  genre?: string; // Matches genres and user tags
+  free_only?: boolean;
+  min_review?: number;
+  min_players?: number;
+  coop_only?: boolean;
+  sort?: GameSort;
+  include_owners?: boolean; // Adds owner_details to every game
+}
+
 export interface RefreshMyGamesResponse {
   message: string;
   game_count: number;
@@ -47,3 +210,106 @@ export interface RefreshMyGamesError {
   remaining_seconds?: number;
   cooldown_ends_at?: string;
 }
+
+export interface GameNewsItem {
+  gid: string;
+  app_id: number;
+  game_name: string;
+  owner_count: number;
+  title: string;
+  url: string;
+  author: string;
+  contents: string;
+  feed_label: string;
+  is_patch_notes: boolean;
+  date: string;
+}
+
+export interface GameNewsResponse {
+  news: GameNewsItem[];
+  enabled: boolean;
+  updated_at: string | null;
+}
+
+export interface GameDeal {
+  app_id: number;
+  name: string;
+  header_image_url: string;
+  owner_count: number;
+  is_pinned: boolean;
+  kind: 'discount' | 'free';
+  discount_percent: number;
+  price_cents: number;
+  original_cents: number;
+  price_formatted: string;
+  detected_at: string;
+}
+
+export interface GameDealsResponse {
+  deals: GameDeal[];
+  enabled: boolean;
+  updated_at: string | null;
+}
+
+export interface DownloadRequirement {
+  id: number;
+  app_id: number | null;
+  name: string;
+  version: string;
+  note: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface DownloadReadiness extends DownloadRequirement {
+  ready_count: number;
+  player_count: number;
+  percentage: number;
+  is_ready: boolean;
+  missing_users?: User[]; // Only included for admins
+}
+
+export interface DownloadsResponse {
+  downloads: DownloadReadiness[];
+}
+
+export interface DownloadRequirementRequest {
+  app_id?: number | null;
+  name: string;
+  version?: string;
+  note?: string;
+}
+
+export interface PollOption {
+  id: number;
+  app_id: number;
+  name: string;
+  header_image_url?: string;
+  owner_count: number;
+  max_players?: number;
+  votes: number;
+  is_winner: boolean;
+}
+
+export interface Poll {
+  id: number;
+  question: string;
+  created_by: User;
+  closes_at: string | null;
+  closed_at: string | null;
+  is_closed: boolean;
+  total_votes: number;
+  options: PollOption[];
+  my_option_id?: number;
+  created_at: string;
+}
+
+export interface PollsResponse {
+  polls: Poll[];
+}
+
+export interface CreatePollRequest {
+  question?: string;
+  app_ids: number[];
+  duration_minutes?: number;
+}
@@ -0,0 +1,18 @@
+import { VotePayload } from './websocket.model';
+
+export type PersonalNotificationType = 'vote_received';
+
+// A stored personal notification, offline players load the unread ones when they connect again
+export interface PersonalNotification {
+  id: number;
+  user_id: number;
+  type: PersonalNotificationType;
+  payload: VotePayload;
+  is_read: boolean;
+  created_at: string;
+}
+
+export interface NotificationsResponse {
+  notifications: PersonalNotification[];
+  unread_count: number;
+}
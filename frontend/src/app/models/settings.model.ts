@@ -6,6 +6,9 @@ export interface Settings {
   min_votes_for_ranking: number;
   negative_voting_disabled: boolean;
   countdown_target?: string | null; // RFC3339 formatted time, null if not set
+  chat_slow_mode_seconds: number; // Minimum seconds between two chat messages of a user, 0 = off
+  store_country: string; // Steam Store country code for prices (e.g., 'de')
+  store_language: string; // Steam Store language (e.g., 'english')
 }
 
 export interface UpdateSettingsRequest {
@@ -16,6 +19,9 @@ export interface UpdateSettingsRequest {
   min_votes_for_ranking?: number;
   negative_voting_disabled?: boolean;
   countdown_target?: string | null; // RFC3339 formatted time, empty string or null to clear
+  chat_slow_mode_seconds?: number; // 0 turns slow mode off
+  store_country?: string; // Applies to games synced afterwards
+  store_language?: string; // Applies to games synced afterwards
 }
 
 export interface CreditActionResponse {
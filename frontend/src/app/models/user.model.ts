@@ -5,6 +5,47 @@ export interface User {
   avatar_url: string;
   avatar_small: string;
   profile_url: string;
+  badges?: UserBadge[]; // Only included for single user lookups
+}
+
+export interface Badge {
+  id: string;
+  name: string;
+  description: string;
+  icon: string;
+  stat: string;
+  threshold: number;
+}
+
+export interface UserBadge extends Badge {
+  awarded_at: string;
+}
+
+export interface UserBadgesResponse {
+  badges: UserBadge[];
+  available: Badge[];
+}
+
+export interface SteamAchievementUnlock {
+  name: string;
+  description: string;
+  unlocked_at: string;
+}
+
+export interface SteamGameProgress {
+  app_id: number;
+  name: string;
+  unlocked: number;
+  total: number;
+  completion_percent: number;
+  playtime_minutes: number;
+  recent_unlocks: SteamAchievementUnlock[]; // Newest first
+}
+
+export interface SteamShowcase {
+  games: SteamGameProgress[];
+  is_private: boolean; // Game details of the Steam profile are not public
+  fetched_at: string;
 }
 
 export interface CurrentUser extends User {
@@ -13,4 +54,7 @@ export interface CurrentUser extends User {
   credit_interval_seconds: number;
   credit_max: number;
   is_admin: boolean;
+  reduced_motion?: boolean; // Prefers effects without confetti, flashing and sounds
+  chat_unread_count?: number; // Chat messages of others after the last read message
+  mention_unread_count?: number;
 }
@@ -11,6 +11,7 @@ export interface Vote {
   is_secret: boolean;
   is_invalidated: boolean;
   comment?: string;
+  app_id?: number;
   created_at: string;
 }
 
@@ -20,6 +21,7 @@ export interface CreateVoteRequest {
   points?: number; // 1-3 points, defaults to 1
   is_secret?: boolean; // null = use default (negative=secret, positive=open)
   comment?: string; // optional comment, max 160 characters
+  app_id?: number; // optional game context, required for game-scoped achievements
 }
 
 export interface VoteResponse {
@@ -30,12 +32,14 @@ export interface VoteResponse {
 export interface LeaderboardEntry {
   user: User;
   vote_count: number;
+  points: number;
   rank: number;
 }
 
 export interface AchievementLeaderboard {
   achievement: Achievement;
   leaders: LeaderboardEntry[];
+  is_archived: boolean;
 }
 
 export interface Champion {
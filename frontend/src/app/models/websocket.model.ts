@@ -1,8 +1,18 @@
-export type WebSocketMessageType = 'vote_received' | 'new_vote' | 'user_joined' | 'settings_update' | 'credits_reset' | 'credits_given' | 'chat_message' | 'new_king' | 'games_sync_progress' | 'games_sync_complete' | 'vote_invalidation' | 'error';
+import { GameNewsItem, GameDeal, DownloadRequirement, Poll, GameServer, ActiveGame } from './game.model';
+import { Achievement } from './achievement.model';
+import { Badge } from './user.model';
+
+export type WebSocketMessageType = 'vote_received' | 'new_vote' | 'user_joined' | 'settings_update' | 'credits_reset' | 'credits_given' | 'chat_message' | 'chat_message_deleted' | 'chat_mention' | 'chat_unread' | 'chat_send_result' | 'poll_update' | 'user_muted' | 'new_king' | 'games_sync_progress' | 'games_sync_complete' | 'games_updated' | 'vote_invalidation' | 'connection_closed' | 'game_news' | 'game_deal' | 'game_server' | 'game_sessions' | 'download_reminder' | 'achievement_live' | 'badge_awarded' | 'announcement' | 'resume' | 'subscribed' | 'error';
 
 export interface WebSocketMessage<T = unknown> {
   type: WebSocketMessageType;
   payload: T;
+  seq?: number; // Set on broadcasts that are replayed after a reconnect
+}
+
+export interface AccessibilityInfo {
+  plain_text: string; // Text alternative of the visual effect
+  reduced_motion: boolean; // Skip confetti, flashing and sounds
 }
 
 export interface VotePayload {
@@ -18,6 +28,8 @@ export interface VotePayload {
   is_positive: boolean;
   is_secret: boolean;
   created_at: string;
+  notification_id?: number; // Only in vote_received, marked read once shown
+  accessibility?: AccessibilityInfo;
 }
 
 export interface SettingsPayload {
@@ -33,10 +45,51 @@ export interface CreditActionPayload {
   message: string;
 }
 
+export interface ChatMessageDeletedPayload {
+  id: number;
+  deleted_by: 'author' | 'admin';
+}
+
+export interface ChatMentionPayload {
+  message_id: number;
+  from_user_id: number;
+  from_username: string;
+  snippet: string;
+  unread_count: number;
+}
+
+export interface ChatUnreadPayload {
+  last_read_message_id: number;
+  unread_count: number;
+}
+
+// Answer to an outgoing { type: 'chat_send', payload: { ref, message, reply_to_id? } } message
+export interface ChatSendResultPayload {
+  ref: string;
+  ok: boolean;
+  status: number; // HTTP status POST /chat would have returned
+  message_id?: number;
+  error?: { error: string; [key: string]: unknown };
+}
+
+// Answer to an outgoing { type: 'subscribe', payload: { types } } message, an empty types list subscribes to everything
+// Kiosk screens use it to only receive e.g. new_vote and new_king, control messages always arrive
+export interface SubscribedPayload {
+  types: WebSocketMessageType[] | null; // Accepted types, null = everything
+}
+
+export interface UserMutedPayload {
+  muted: boolean;
+  muted_until?: string;
+  include_votes: boolean; // Voting is blocked as well
+  reason?: string;
+}
+
 export interface NewKingPayload {
   user_id: number;
   username: string;
   avatar: string;
+  accessibility?: AccessibilityInfo;
 }
 
 export interface ChatMessagePayload {
@@ -54,11 +107,24 @@ export interface ChatMessagePayload {
     is_positive: boolean;
     count: number;
   }>;
+  reply_to?: {
+    id: number;
+    user: {
+      id: number;
+      steam_id: string;
+      username: string;
+      avatar_url: string;
+      avatar_small: string;
+      profile_url: string;
+    };
+    snippet: string;
+  };
+  is_system?: boolean;
   created_at: string;
 }
 
 export interface GamesSyncProgressPayload {
-  phase: 'fetching_users' | 'fetching_categories' | 'complete';
+  phase: 'fetching_users' | 'fetching_categories' | 'rate_limited' | 'complete';
   current_game: string;
   processed_count: number;
   total_count: number;
@@ -70,7 +136,79 @@ export interface GamesSyncCompletePayload {
   total_games: number;
 }
 
+export interface GamesUpdatedPayload {
+  pinned_game_ids: number[];
+}
+
 export interface VoteInvalidationPayload {
   vote_id: number;
   is_invalidated: boolean;
 }
+
+export interface ConnectionClosedPayload {
+  reason: 'replaced' | 'server_full' | 'kicked' | 'banned';
+  message: string;
+}
+
+export type AnnouncementSeverity = 'info' | 'warning' | 'critical';
+
+export interface AnnouncementPayload {
+  id: number;
+  title: string;
+  body: string;
+  severity: AnnouncementSeverity;
+  expires_at?: string | null; // RFC3339, null if it does not expire
+  created_at: string;
+}
+
+export interface ResumePayload {
+  epoch: string; // Changes on every server start
+  seq: number; // Latest broadcast
+  replayed: number; // Missed broadcasts that follow this message
+  complete: boolean; // False if broadcasts were lost, the data has to be reloaded
+}
+
+export interface GameNewsPayload {
+  items: GameNewsItem[];
+}
+
+export interface GameDealPayload {
+  deals: GameDeal[];
+}
+
+export interface GameServerPayload {
+  event: 'up' | 'down';
+  app_id: number;
+  server?: GameServer; // Not set when the server was removed
+}
+
+export interface GameSessionsPayload {
+  games: ActiveGame[];
+}
+
+export interface DownloadReminderPayload {
+  missing: DownloadRequirement[];
+  event_starts_at?: string;
+}
+
+export interface AchievementLivePayload {
+  achievement: Achievement;
+  suggestion_id: number;
+  suggested_by_id: number;
+  suggested_by: string;
+  accessibility?: AccessibilityInfo;
+}
+
+export interface BadgeAwardedPayload {
+  user_id: number;
+  username: string;
+  avatar: string;
+  badge: Badge;
+  accessibility?: AccessibilityInfo;
+}
+
+export interface PollUpdatePayload {
+  event: 'created' | 'voted' | 'closed' | 'deleted';
+  poll_id: number;
+  poll?: Poll;
+}
@@ -31,7 +31,7 @@ import { ChatMessage, AchievementBadge } from '../../models/chat.model';
             </div>
           } @else {
             @for (msg of messages(); track msg.id) {
-              <div class="chat-message" [class.own]="isOwnMessage(msg)">
+              <div class="chat-message" [class.own]="isOwnMessage(msg)" [class.system]="msg.is_system">
                 <img
                   [src]="msg.user.avatar_small || msg.user.avatar_url || '/assets/default-avatar.png'"
                   [alt]="msg.user.username"
@@ -67,7 +67,14 @@ import { ChatMessage, AchievementBadge } from '../../models/chat.model';
                       </div>
                     }
                     <span class="timestamp">{{ formatTime(msg.created_at) }}</span>
+                    <button type="button" class="reply-button" (click)="startReply(msg)" title="Antworten">↩</button>
                   </div>
+                  @if (msg.reply_to) {
+                    <div class="reply-quote">
+                      <span class="reply-quote-user">{{ msg.reply_to.user.username }}</span>
+                      <span class="reply-quote-text">{{ msg.reply_to.snippet }}</span>
+                    </div>
+                  }
                   <div class="message-text">{{ msg.message }}</div>
                 </div>
               </div>
@@ -75,6 +82,13 @@ import { ChatMessage, AchievementBadge } from '../../models/chat.model';
           }
         </div>
 
+        @if (replyTo(); as reply) {
+          <div class="reply-bar">
+            <span>Antwort an <strong>{{ reply.user.username }}</strong>: {{ reply.message }}</span>
+            <button type="button" class="reply-cancel" (click)="cancelReply()" title="Abbrechen">✕</button>
+          </div>
+        }
+
         <form class="chat-input" (ngSubmit)="sendMessage()">
           <input
             #messageInput
@@ -160,6 +174,20 @@ import { ChatMessage, AchievementBadge } from '../../models/chat.model';
       gap: 12px;
       max-width: 80%;
 
+      &.system {
+        align-self: center;
+
+        .avatar,
+        .reply-button {
+          display: none;
+        }
+
+        .message-content {
+          background: rgba($accent-primary, 0.08);
+          border-style: dashed;
+        }
+      }
+
       &.own {
         align-self: flex-end;
         flex-direction: row-reverse;
@@ -207,6 +235,34 @@ import { ChatMessage, AchievementBadge } from '../../models/chat.model';
           color: $text-muted;
           margin-left: auto;
         }
+
+        .reply-button {
+          background: none;
+          border: none;
+          color: $text-muted;
+          cursor: pointer;
+          font-size: 14px;
+          padding: 0 4px;
+
+          &:hover {
+            color: $accent-primary;
+          }
+        }
+      }
+
+      .reply-quote {
+        border-left: 3px solid $accent-primary;
+        padding: 2px 8px;
+        margin-bottom: 6px;
+        font-size: 13px;
+        color: $text-muted;
+        word-break: break-word;
+
+        .reply-quote-user {
+          font-weight: 600;
+          color: $text-secondary;
+          margin-right: 6px;
+        }
       }
 
       .message-text {
@@ -336,6 +392,32 @@ import { ChatMessage, AchievementBadge } from '../../models/chat.model';
       }
     }
 
+    .reply-bar {
+      display: flex;
+      align-items: center;
+      gap: 12px;
+      padding: 8px 16px;
+      background: $bg-hover;
+      border-top: 1px solid $border-color;
+      border-left: 3px solid $accent-primary;
+      font-size: 13px;
+      color: $text-secondary;
+
+      span {
+        flex: 1;
+        overflow: hidden;
+        text-overflow: ellipsis;
+        white-space: nowrap;
+      }
+
+      .reply-cancel {
+        background: none;
+        border: none;
+        color: $text-muted;
+        cursor: pointer;
+      }
+    }
+
     .chat-input {
       display: flex;
       gap: 12px;
@@ -400,6 +482,7 @@ export class ChatComponent implements OnInit, OnDestroy, AfterViewChecked {
   messages = this.chatService.chatMessages;
   loading = signal(true);
   sending = signal(false);
+  replyTo = signal<ChatMessage | null>(null);
   newMessage = '';
 
   private shouldScrollToBottom = false;
@@ -449,9 +532,10 @@ export class ChatComponent implements OnInit, OnDestroy, AfterViewChecked {
     if (!message || this.sending()) return;
 
     this.sending.set(true);
-    this.chatService.sendMessage(message).subscribe({
+    this.chatService.sendMessage(message, this.replyTo()?.id).subscribe({
       next: () => {
         this.newMessage = '';
+        this.replyTo.set(null);
         this.sending.set(false);
         this.shouldScrollToBottom = true;
         // Keep focus on input field
@@ -466,6 +550,15 @@ export class ChatComponent implements OnInit, OnDestroy, AfterViewChecked {
     });
   }
 
+  startReply(msg: ChatMessage): void {
+    this.replyTo.set(msg);
+    setTimeout(() => this.messageInput?.nativeElement?.focus(), 0);
+  }
+
+  cancelReply(): void {
+    this.replyTo.set(null);
+  }
+
   canSend(): boolean {
     return this.newMessage.trim().length > 0 && !this.sending();
   }
@@ -51,6 +51,8 @@ import { Subscription } from 'rxjs';
                   Lade Spielerbibliotheken...
                 } @else if (syncPhase() === 'fetching_categories') {
                   Aktualisiere Spieldetails...
+                } @else if (syncPhase() === 'rate_limited') {
+                  Steam-Limit erreicht, Synchronisierung wird fortgesetzt...
                 } @else {
                   Synchronisiere Bibliothek...
                 }
@@ -118,7 +120,7 @@ import { Subscription } from 'rxjs';
                           </div>
                         }
                         @if (game.review_score >= 0) {
-                          <div class="review-score" [class.positive]="game.review_score >= 85" [class.mixed]="game.review_score >= 70 && game.review_score < 85" [class.negative]="game.review_score < 70">
+                          <div class="review-score" [class.positive]="game.review_score >= 85" [class.mixed]="game.review_score >= 70 && game.review_score < 85" [class.negative]="game.review_score < 70" [title]="reviewTooltip(game)">
                             <span class="thumb">👍</span>
                             <span class="score">{{ game.review_score }}%</span>
                           </div>
@@ -201,7 +203,7 @@ import { Subscription } from 'rxjs';
                           </div>
                         }
                         @if (game.review_score >= 0) {
-                          <div class="review-score" [class.positive]="game.review_score >= 85" [class.mixed]="game.review_score >= 70 && game.review_score < 85" [class.negative]="game.review_score < 70">
+                          <div class="review-score" [class.positive]="game.review_score >= 85" [class.mixed]="game.review_score >= 70 && game.review_score < 85" [class.negative]="game.review_score < 70" [title]="reviewTooltip(game)">
                             <span class="thumb">👍</span>
                             <span class="score">{{ game.review_score }}%</span>
                           </div>
@@ -961,6 +963,13 @@ export class GamesComponent implements OnInit, OnDestroy {
         this.loadGamesQuietly();
       })
     );
+
+    // Listen for pinned games changed by admins
+    this.subscriptions.push(
+      this.wsService.gamesUpdated$.subscribe(() => {
+        this.loadGamesQuietly();
+      })
+    );
   }
 
   loadUsers() {
@@ -1091,6 +1100,11 @@ export class GamesComponent implements OnInit, OnDestroy {
     return '€€€';
   }
 
+  reviewTooltip(game: Game): string {
+    const count = `${(game.review_count || 0).toLocaleString('de-DE')} Bewertungen`;
+    return game.review_score_desc ? `${game.review_score_desc} (${count})` : count;
+  }
+
   openSteamStore(appId: number) {
     window.open(`https://store.steampowered.com/app/${appId}`, '_blank');
   }
@@ -156,7 +156,12 @@ import { Subscription } from 'rxjs';
                     </div>
                   }
                   <div class="card-header-text">
-                    <h3 class="achievement-name">{{ item.achievement.name }}</h3>
+                    <h3 class="achievement-name">
+                      {{ item.achievement.name }}
+                      @if (item.is_archived) {
+                        <span class="archived-tag">Archiviert</span>
+                      }
+                    </h3>
                     <p class="achievement-desc">{{ item.achievement.description }}</p>
                   </div>
                 </div>
@@ -203,7 +208,12 @@ import { Subscription } from 'rxjs';
                     </div>
                   }
                   <div class="card-header-text">
-                    <h3 class="achievement-name">{{ item.achievement.name }}</h3>
+                    <h3 class="achievement-name">
+                      {{ item.achievement.name }}
+                      @if (item.is_archived) {
+                        <span class="archived-tag">Archiviert</span>
+                      }
+                    </h3>
                     <p class="achievement-desc">{{ item.achievement.description }}</p>
                   </div>
                 </div>
@@ -390,6 +400,16 @@ import { Subscription } from 'rxjs';
       font-size: 16px;
       font-weight: 600;
       margin-bottom: 4px;
+
+      .archived-tag {
+        margin-left: 6px;
+        padding: 1px 6px;
+        border: 1px solid $border-light;
+        border-radius: $radius-sm;
+        font-size: 11px;
+        font-weight: 500;
+        color: $text-muted;
+      }
     }
 
     .achievement-desc {
@@ -2,7 +2,7 @@ import { Injectable, signal } from '@angular/core';
 import { HttpClient } from '@angular/common/http';
 import { Observable, map, tap } from 'rxjs';
 import { environment } from '../../environments/environment';
-import { Achievement, AchievementsResponse } from '../models/achievement.model';
+import { Achievement, AchievementsResponse, AchievementStatsResponse, AchievementSuggestion, AchievementSuggestionRequest } from '../models/achievement.model';
 
 @Injectable({
   providedIn: 'root'
@@ -12,8 +12,9 @@ export class AchievementService {
 
   constructor(private http: HttpClient) {}
 
-  getAll(): Observable<AchievementsResponse> {
-    return this.http.get<AchievementsResponse>(`${environment.apiUrl}/achievements`)
+  getAll(appId?: number): Observable<AchievementsResponse> {
+    const params: Record<string, number> = appId ? { app_id: appId } : {};
+    return this.http.get<AchievementsResponse>(`${environment.apiUrl}/achievements`, { params })
       .pipe(
         tap(response => {
           // Cache all achievements
@@ -29,6 +30,19 @@ export class AchievementService {
       .pipe(map(response => response.achievement));
   }
 
+  getStats(): Observable<AchievementStatsResponse> {
+    return this.http.get<AchievementStatsResponse>(`${environment.apiUrl}/achievements/stats`);
+  }
+
+  suggest(request: AchievementSuggestionRequest): Observable<AchievementSuggestion> {
+    return this.http.post<AchievementSuggestion>(`${environment.apiUrl}/achievement-suggestions`, request);
+  }
+
+  getMySuggestions(): Observable<AchievementSuggestion[]> {
+    return this.http.get<{ suggestions: AchievementSuggestion[] }>(`${environment.apiUrl}/achievement-suggestions/mine`)
+      .pipe(map(response => response.suggestions));
+  }
+
   /**
    * Get achievement description from cache
    * Returns empty string if not found
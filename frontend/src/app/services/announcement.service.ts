@@ -0,0 +1,57 @@
+import { Injectable, inject } from '@angular/core';
+import { HttpClient } from '@angular/common/http';
+import { Observable } from 'rxjs';
+import { environment } from '../../environments/environment';
+import { AnnouncementPayload, AnnouncementSeverity } from '../models/websocket.model';
+import { WebSocketService } from './websocket.service';
+import { NotificationService } from './notification.service';
+
+export interface CreateAnnouncementRequest {
+  title: string;
+  body: string;
+  severity?: AnnouncementSeverity;
+  expires_at?: string; // RFC3339
+}
+
+@Injectable({
+  providedIn: 'root'
+})
+export class AnnouncementService {
+  private http = inject(HttpClient);
+  private wsService = inject(WebSocketService);
+  private notifications = inject(NotificationService);
+
+  private shown = new Set<number>(); // Each announcement is shown once per page load
+
+  constructor() {
+    this.wsService.announcement$.subscribe((announcement) => this.show(announcement));
+  }
+
+  // Shows the announcements broadcast before the player logged in
+  loadActive(): void {
+    this.http.get<{ announcements: AnnouncementPayload[] }>(`${environment.apiUrl}/announcements`).subscribe({
+      next: (response) => (response.announcements || []).forEach((a) => this.show(a)),
+      error: (err) => console.error('Failed to load announcements', err)
+    });
+  }
+
+  // Admin only
+  announce(request: CreateAnnouncementRequest): Observable<{ announcement: AnnouncementPayload }> {
+    return this.http.post<{ announcement: AnnouncementPayload }>(`${environment.apiUrl}/admin/announce`, request);
+  }
+
+  private show(announcement: AnnouncementPayload): void {
+    if (this.shown.has(announcement.id)) {
+      return;
+    }
+    this.shown.add(announcement.id);
+
+    const prefix = announcement.severity === 'critical' ? '🚨 ' : announcement.severity === 'warning' ? '⚠️ ' : '📢 ';
+    this.notifications.show({
+      type: announcement.severity === 'critical' ? 'error' : 'info',
+      title: prefix + announcement.title,
+      message: announcement.body,
+      duration: 0 // Stays until dismissed
+    });
+  }
+}
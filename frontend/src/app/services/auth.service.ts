@@ -1,6 +1,7 @@
 import { Injectable, signal, computed } from '@angular/core';
 import { HttpClient } from '@angular/common/http';
 import { Router } from '@angular/router';
+import { Observable, tap } from 'rxjs';
 import { environment } from '../../environments/environment';
 import { CurrentUser } from '../models/user.model';
 
@@ -181,4 +182,19 @@ export class AuthService {
   refreshUser(): void {
     this.loadCurrentUser();
   }
+
+  /**
+   * Check whether the instance still waits for its first admin
+   */
+  getSetupStatus(): Observable<{ setup_required: boolean }> {
+    return this.http.get<{ setup_required: boolean }>(`${environment.apiUrl}/setup/status`);
+  }
+
+  /**
+   * Claim admin rights with the one-time setup code from the server log
+   */
+  claimAdmin(code: string): Observable<{ message: string; is_admin: boolean }> {
+    return this.http.post<{ message: string; is_admin: boolean }>(`${environment.apiUrl}/setup/claim-admin`, { code })
+      .pipe(tap(() => this.loadCurrentUser()));
+  }
 }
@@ -2,7 +2,7 @@ import { Injectable, signal, inject, computed } from '@angular/core';
 import { HttpClient } from '@angular/common/http';
 import { Observable, map } from 'rxjs';
 import { environment } from '../../environments/environment';
-import { ChatMessage, ChatMessagesResponse, CreateChatMessageRequest } from '../models/chat.model';
+import { ChatMessage, ChatMessagesResponse, ChatMentionsResponse, CreateChatMessageRequest } from '../models/chat.model';
 import { ChatMessagePayload } from '../models/websocket.model';
 import { WebSocketService } from './websocket.service';
 import { AuthService } from './auth.service';
@@ -21,6 +21,9 @@ export class ChatService {
   private _unreadCount = signal<number>(0);
   readonly unreadCount = this._unreadCount.asReadonly();
 
+  private _unreadMentionCount = signal<number>(0);
+  readonly unreadMentionCount = this._unreadMentionCount.asReadonly();
+
   private _isChatOpen = signal<boolean>(false);
 
   constructor() {
@@ -28,6 +31,20 @@ export class ChatService {
     this.wsService.chatMessage$.subscribe((payload) => {
       this.addMessageFromPayload(payload);
     });
+
+    // Drop messages deleted by their author or an admin
+    this.wsService.chatMessageDeleted$.subscribe((payload) => {
+      this.removeMessage(payload.id);
+    });
+
+    // Mentions are sent only to the mentioned user, with their unread mention count
+    this.wsService.chatMention$.subscribe((payload) => {
+      if (this._isChatOpen()) {
+        this.markMentionsRead().subscribe();
+      } else {
+        this._unreadMentionCount.set(payload.unread_count);
+      }
+    });
   }
 
   loadMessages(): Observable<ChatMessage[]> {
@@ -41,11 +58,40 @@ export class ChatService {
       );
   }
 
-  sendMessage(message: string): Observable<{ message: ChatMessage }> {
+  sendMessage(message: string, replyToId?: number): Observable<{ message: ChatMessage }> {
     const request: CreateChatMessageRequest = { message };
+    if (replyToId) {
+      request.reply_to_id = replyToId;
+    }
     return this.http.post<{ message: ChatMessage }>(`${environment.apiUrl}/chat`, request);
   }
 
+  deleteMessage(id: number): Observable<{ message: string }> {
+    return this.http.delete<{ message: string }>(`${environment.apiUrl}/chat/${id}`);
+  }
+
+  loadMentions(): Observable<ChatMessage[]> {
+    return this.http.get<ChatMentionsResponse>(`${environment.apiUrl}/chat/mentions`)
+      .pipe(
+        map(response => {
+          this._unreadMentionCount.set(response.unread_count);
+          return response.mentions || [];
+        })
+      );
+  }
+
+  markMentionsRead(): Observable<{ unread_count: number }> {
+    this._unreadMentionCount.set(0);
+    return this.http.post<{ unread_count: number }>(`${environment.apiUrl}/chat/mentions/read`, {});
+  }
+
+  private removeMessage(id: number): void {
+    // Replies keep their text but lose the quote of the deleted message
+    this.messages.update(msgs => msgs
+      .filter(m => m.id !== id)
+      .map(m => m.reply_to?.id === id ? { ...m, reply_to: undefined } : m));
+  }
+
   private addMessageFromPayload(payload: ChatMessagePayload): void {
     // Convert payload to ChatMessage format
     const newMessage: ChatMessage = {
@@ -60,6 +106,8 @@ export class ChatService {
       },
       message: payload.message,
       achievements: payload.achievements || [],
+      reply_to: payload.reply_to,
+      is_system: payload.is_system,
       created_at: payload.created_at
     };
 
@@ -85,6 +133,9 @@ export class ChatService {
     this._isChatOpen.set(isOpen);
     if (isOpen) {
       this.markAsRead();
+      if (this._unreadMentionCount() > 0) {
+        this.markMentionsRead().subscribe();
+      }
     }
   }
 }
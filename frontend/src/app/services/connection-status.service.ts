@@ -15,6 +15,7 @@ export class ConnectionStatusService implements OnDestroy {
   private reconnectAttempt = signal(0);
   private reconnectTimer: ReturnType<typeof setTimeout> | null = null;
   private isCheckingHealth = false;
+  private resume: (() => void) | null = null; // Resumes the WebSocket instead of reloading the page
   private readonly maxReconnectAttempts = 10;
   private readonly reconnectBaseDelay = 2000;
 
@@ -31,10 +32,15 @@ export class ConnectionStatusService implements OnDestroy {
     this.reconnectAttempt.set(0);
   }
 
-  setDisconnected(): void {
+  // resume is called instead of a page reload when the backend is back, e.g. to replay missed WebSocket messages
+  setDisconnected(resume?: () => void): void {
     if (this.state() !== 'disconnected' && this.state() !== 'reconnecting') {
+      this.resume = resume ?? null;
       this.state.set('disconnected');
       this.startReconnect();
+    } else if (!resume) {
+      // A failed request needs the full reload
+      this.resume = null;
     }
   }
 
@@ -95,7 +101,13 @@ export class ConnectionStatusService implements OnDestroy {
       next: () => {
         console.log('[ConnectionStatus] Backend is back online');
         this.isCheckingHealth = false;
+        const resume = this.resume;
+        this.resume = null;
         this.setConnected();
+        if (resume) {
+          resume();
+          return;
+        }
         // Trigger a page reload to refresh all data
         window.location.reload();
       },
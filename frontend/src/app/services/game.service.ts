@@ -2,7 +2,7 @@ import { Injectable } from '@angular/core';
 import { HttpClient } from '@angular/common/http';
 import { Observable, map } from 'rxjs';
 import { environment } from '../../environments/environment';
-import { GamesResponse, Game, SyncStatus, RefreshMyGamesResponse } from '../models/game.model';
+import { GamesResponse, Game, SyncStatus, RefreshMyGamesResponse, GameNewsResponse, DownloadsResponse, DownloadRequirement, DownloadRequirementRequest, GameFilter, CommonGame, CommonGamesResponse, GameRating, GameServer, GameServerRequest, ActiveGame, GameSessionStats, TeamBalance, TeamBalanceRequest, WishlistsResponse, PinnedGame } from '../models/game.model';
 
 @Injectable({
   providedIn: 'root'
@@ -15,12 +15,86 @@ export class GameService {
     this.apiBase = environment.apiUrl.replace(/\/api\/v1$/, '');
   }
 
-  getMultiplayerGames(): Observable<GamesResponse> {
-    return this.http.get<GamesResponse>(`${environment.apiUrl}/games`).pipe(
+  getMultiplayerGames(filter: GameFilter = {}): Observable<GamesResponse> {
+    const params: Record<string, string | number | boolean> = {};
+    for (const [key, value] of Object.entries(filter)) {
+      if (value !== undefined && value !== null && value !== '') {
+        params[key] = value;
+      }
+    }
+    return this.http.get<GamesResponse>(`${environment.apiUrl}/games`, { params }).pipe(
       map(response => this.resolveImageUrls(response))
     );
   }
 
+  getCommonGames(userIds: number[]): Observable<CommonGamesResponse> {
+    return this.http.get<CommonGamesResponse>(`${environment.apiUrl}/games/common`, {
+      params: { user_ids: userIds.join(',') }
+    }).pipe(
+      map(response => ({
+        ...response,
+        games: this.resolveImageUrls({ pinned_games: [], all_games: response.games }).all_games as CommonGame[]
+      }))
+    );
+  }
+
+  getRating(appId: number): Observable<GameRating> {
+    return this.http.get<GameRating>(`${environment.apiUrl}/games/${appId}/rating`);
+  }
+
+  rateGame(appId: number, rating: number): Observable<GameRating> {
+    return this.http.post<GameRating>(`${environment.apiUrl}/games/${appId}/rating`, { rating });
+  }
+
+  deleteRating(appId: number): Observable<GameRating> {
+    return this.http.delete<GameRating>(`${environment.apiUrl}/games/${appId}/rating`);
+  }
+
+  getServers(): Observable<{ servers: GameServer[] }> {
+    return this.http.get<{ servers: GameServer[] }>(`${environment.apiUrl}/games/servers`);
+  }
+
+  setServer(appId: number, request: GameServerRequest): Observable<GameServer> {
+    return this.http.post<GameServer>(`${environment.apiUrl}/games/${appId}/server`, request);
+  }
+
+  deleteServer(appId: number): Observable<{ message: string }> {
+    return this.http.delete<{ message: string }>(`${environment.apiUrl}/games/${appId}/server`);
+  }
+
+  getWishlists(minUsers = 2): Observable<WishlistsResponse> {
+    return this.http.get<WishlistsResponse>(`${environment.apiUrl}/wishlists`, { params: { min_users: minUsers } }).pipe(
+      map(response => ({
+        ...response,
+        games: response.games.map(game => ({ ...game, header_image_url: `${this.apiBase}${game.header_image_url}` }))
+      }))
+    );
+  }
+
+  refreshMyWishlist(): Observable<{ message: string; game_count: number }> {
+    return this.http.post<{ message: string; game_count: number }>(`${environment.apiUrl}/wishlists/refresh`, {});
+  }
+
+  balanceTeams(appId: number, request: TeamBalanceRequest): Observable<TeamBalance> {
+    return this.http.post<TeamBalance>(`${environment.apiUrl}/games/${appId}/teams`, request);
+  }
+
+  getActiveSessions(): Observable<{ games: ActiveGame[] }> {
+    return this.http.get<{ games: ActiveGame[] }>(`${environment.apiUrl}/sessions`);
+  }
+
+  startSession(appId: number): Observable<{ message: string }> {
+    return this.http.post<{ message: string }>(`${environment.apiUrl}/sessions`, { app_id: appId });
+  }
+
+  endSession(): Observable<{ message: string }> {
+    return this.http.delete<{ message: string }>(`${environment.apiUrl}/sessions/me`);
+  }
+
+  getSessionStats(): Observable<GameSessionStats> {
+    return this.http.get<GameSessionStats>(`${environment.apiUrl}/sessions/stats`);
+  }
+
   refreshGames(): Observable<GamesResponse> {
     return this.http.post<GamesResponse>(`${environment.apiUrl}/games/refresh`, {}).pipe(
       map(response => this.resolveImageUrls(response))
@@ -42,6 +116,111 @@ export class GameService {
     return this.http.get<SyncStatus>(`${environment.apiUrl}/games/sync/status`);
   }
 
+  /**
+   * Gets Steam news (patch notes, announcements) of the most commonly owned games
+   * New items are also pushed via WebSocket
+   */
+  getNews(appId?: number): Observable<GameNewsResponse> {
+    const params: Record<string, string> = appId ? { app_id: String(appId) } : {};
+    return this.http.get<GameNewsResponse>(`${environment.apiUrl}/games/news`, { params });
+  }
+
+  /**
+   * Gets the download checklist with the readiness of all players
+   */
+  getDownloads(): Observable<DownloadsResponse> {
+    return this.http.get<DownloadsResponse>(`${environment.apiUrl}/downloads`);
+  }
+
+  /**
+   * Marks or unmarks a required download as pre-downloaded by the current user
+   */
+  setDownloadReady(id: number, ready: boolean): Observable<{ requirement_id: number; is_ready: boolean }> {
+    const url = `${environment.apiUrl}/downloads/${id}/ready`;
+    return ready
+      ? this.http.post<{ requirement_id: number; is_ready: boolean }>(url, {})
+      : this.http.delete<{ requirement_id: number; is_ready: boolean }>(url);
+  }
+
+  /**
+   * Gets the download checklist including the players with missing downloads (admin only)
+   */
+  getAdminDownloads(): Observable<DownloadsResponse> {
+    return this.http.get<DownloadsResponse>(`${environment.apiUrl}/admin/downloads`);
+  }
+
+  /**
+   * Adds a required download to the checklist (admin only)
+   */
+  createDownload(request: DownloadRequirementRequest): Observable<{ download: DownloadRequirement }> {
+    return this.http.post<{ download: DownloadRequirement }>(`${environment.apiUrl}/admin/downloads`, request);
+  }
+
+  /**
+   * Updates a required download (admin only)
+   */
+  updateDownload(id: number, request: DownloadRequirementRequest): Observable<{ download: DownloadRequirement }> {
+    return this.http.put<{ download: DownloadRequirement }>(`${environment.apiUrl}/admin/downloads/${id}`, request);
+  }
+
+  /**
+   * Removes a required download and all confirmations (admin only)
+   */
+  deleteDownload(id: number): Observable<{ message: string }> {
+    return this.http.delete<{ message: string }>(`${environment.apiUrl}/admin/downloads/${id}`);
+  }
+
+  /**
+   * Reminds all connected players with missing downloads right away (admin only)
+   */
+  remindMissingDownloads(): Observable<{ notified: number }> {
+    return this.http.post<{ notified: number }>(`${environment.apiUrl}/admin/downloads/remind`, {});
+  }
+
+  /**
+   * Get the pinned games in their order (admin only)
+   */
+  getPinnedGames(): Observable<{ pinned_games: PinnedGame[] }> {
+    return this.http.get<{ pinned_games: PinnedGame[] }>(`${environment.apiUrl}/admin/games/pinned`);
+  }
+
+  /**
+   * Pin a game at the end of the pinned games (admin only)
+   */
+  pinGame(appId: number): Observable<{ pinned_games: PinnedGame[] }> {
+    return this.http.post<{ pinned_games: PinnedGame[] }>(`${environment.apiUrl}/admin/games/pinned`, { app_id: appId });
+  }
+
+  /**
+   * Unpin a game (admin only)
+   */
+  unpinGame(appId: number): Observable<{ pinned_games: PinnedGame[] }> {
+    return this.http.delete<{ pinned_games: PinnedGame[] }>(`${environment.apiUrl}/admin/games/pinned/${appId}`);
+  }
+
+  /**
+   * Reorder the pinned games, the list must contain every pinned game exactly once (admin only)
+   */
+  reorderPinnedGames(appIds: number[]): Observable<{ pinned_games: PinnedGame[] }> {
+    return this.http.put<{ pinned_games: PinnedGame[] }>(`${environment.apiUrl}/admin/games/pinned`, { app_ids: appIds });
+  }
+
+  /**
+   * Replace the cover image of a game with a JPEG, PNG or WebP file of up to 5 MB (admin only)
+   */
+  uploadGameImage(appId: number, image: File): Observable<{ message: string; header_image_url: string }> {
+    const form = new FormData();
+    form.append('image', image);
+    return this.http.put<{ message: string; header_image_url: string }>(`${environment.apiUrl}/admin/games/${appId}/image`, form);
+  }
+
+  /**
+   * Remove the uploaded cover image of a game, the Steam image is shown again (admin only)
+   */
+  deleteGameImage(appId: number): Observable<{ message: string; header_image_url: string }> {
+    return this.http.delete<{ message: string; header_image_url: string }>(`${environment.apiUrl}/admin/games/${appId}/image`);
+  }
+
   /**
    * Invalidates the database cache (admin only)
    * Forces re-fetch of all game data from Steam on next request
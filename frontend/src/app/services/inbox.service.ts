@@ -0,0 +1,71 @@
+import { Injectable, inject } from '@angular/core';
+import { HttpClient } from '@angular/common/http';
+import { Subject } from 'rxjs';
+import { environment } from '../../environments/environment';
+import { VotePayload } from '../models/websocket.model';
+import { NotificationsResponse } from '../models/notification.model';
+import { WebSocketService } from './websocket.service';
+import { NotificationService } from './notification.service';
+
+// Missed votes shown one by one after reconnecting, more are summarized
+const MAX_MISSED_POPUPS = 3;
+
+@Injectable({
+  providedIn: 'root'
+})
+export class InboxService {
+  private http = inject(HttpClient);
+  private wsService = inject(WebSocketService);
+  private notifications = inject(NotificationService);
+
+  private shown = new Set<number>(); // Notification ids, a vote may arrive live and with the unread ones
+
+  // Votes the current user received while connected
+  readonly voteReceived$ = new Subject<VotePayload>();
+
+  constructor() {
+    this.wsService.voteReceived$.subscribe((vote) => {
+      if (vote.notification_id) {
+        if (this.shown.has(vote.notification_id)) {
+          return;
+        }
+        this.shown.add(vote.notification_id);
+        this.markRead([vote.notification_id]);
+      }
+      this.voteReceived$.next(vote);
+    });
+
+    // Every (re)connect catches up on the notifications received while offline
+    this.wsService.resumed$.subscribe(() => this.loadUnread());
+  }
+
+  private loadUnread(): void {
+    this.http.get<NotificationsResponse>(`${environment.apiUrl}/notifications?unread=true`).subscribe({
+      next: (response) => {
+        const missed = (response.notifications || []).filter((n) => !this.shown.has(n.id));
+        if (missed.length === 0) {
+          return;
+        }
+        missed.forEach((n) => this.shown.add(n.id));
+
+        const votes = missed.filter((n) => n.type === 'vote_received').map((n) => n.payload);
+        votes.slice(-MAX_MISSED_POPUPS).forEach((vote) => {
+          this.notifications.voteReceived(vote.from_username, vote.achievement_name, vote.from_avatar, vote.is_positive);
+        });
+        const more = votes.length - Math.min(votes.length, MAX_MISSED_POPUPS);
+        if (more > 0) {
+          this.notifications.info('📬 Während du weg warst', `Du hast ${more} weitere Achievements erhalten.`);
+        }
+
+        this.markRead(missed.map((n) => n.id));
+      },
+      error: (err) => console.error('Failed to load notifications', err)
+    });
+  }
+
+  private markRead(ids: number[]): void {
+    this.http.post<{ unread_count: number }>(`${environment.apiUrl}/notifications/read`, { ids }).subscribe({
+      error: (err) => console.error('Failed to mark notifications as read', err)
+    });
+  }
+}
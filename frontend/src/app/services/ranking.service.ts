@@ -9,6 +9,12 @@ export interface PlayerRanking {
   net_votes: number;     // positive - negative votes
   bonus_points: number;  // bonus from achievement placements
   rank: number;
+  tie_break?: string;    // rule that placed the player below the previous one with the same score
+}
+
+export interface TieBreakRule {
+  rule: string;
+  description: string;
 }
 
 export interface MyRankingResponse {
@@ -16,6 +22,7 @@ export interface MyRankingResponse {
   total_votes: number;
   min_votes_for_ranking: number;
   ranking_active: boolean;
+  tie_breakers: TieBreakRule[];
 }
 
 export interface GlobalRankingResponse {
@@ -23,6 +30,8 @@ export interface GlobalRankingResponse {
   total_votes: number;
   min_votes_for_ranking: number;
   ranking_active: boolean;
+  tie_breakers: TieBreakRule[];
+  at?: string; // Only set for historical rankings (?at=)
 }
 
 @Injectable({
@@ -1,6 +1,6 @@
 import { Injectable, signal, computed } from '@angular/core';
 import { HttpClient } from '@angular/common/http';
-import { Observable, tap } from 'rxjs';
+import { EMPTY, Observable, expand, map, reduce, tap } from 'rxjs';
 import { environment } from '../../environments/environment';
 import { Settings, UpdateSettingsRequest, CreditActionResponse } from '../models/settings.model';
 
@@ -14,6 +14,11 @@ export interface AdminPasswordRequiredResponse {
   password_required: boolean;
 }
 
+export interface AdminUsersPage {
+  users: AdminUserInfo[];
+  next_cursor: string;
+}
+
 export interface VerifyAdminPasswordResponse {
   valid: boolean;
   password_required: boolean;
@@ -130,8 +135,16 @@ export class SettingsService {
   }
 
   // User management
+  // Follows the cursors of the paginated user listing and returns all users sorted by name
   getAllUsers(): Observable<{ users: AdminUserInfo[] }> {
-    return this.http.get<{ users: AdminUserInfo[] }>(`${environment.apiUrl}/admin/users`);
+    const url = `${environment.apiUrl}/admin/users`;
+    return this.http.get<AdminUsersPage>(url).pipe(
+      expand(page => page.next_cursor
+        ? this.http.get<AdminUsersPage>(url, { params: { cursor: page.next_cursor } })
+        : EMPTY),
+      reduce((users, page) => users.concat(page.users), [] as AdminUserInfo[]),
+      map(users => ({ users: users.sort((a, b) => a.username.localeCompare(b.username)) }))
+    );
   }
 
   getBannedUsers(): Observable<{ banned_users: BannedUser[] }> {
@@ -2,7 +2,7 @@ import { Injectable } from '@angular/core';
 import { HttpClient } from '@angular/common/http';
 import { Observable, map } from 'rxjs';
 import { environment } from '../../environments/environment';
-import { User } from '../models/user.model';
+import { SteamShowcase, User, UserBadgesResponse } from '../models/user.model';
 
 @Injectable({
   providedIn: 'root'
@@ -24,4 +24,19 @@ export class UserService {
     return this.http.get<{ user: User }>(`${environment.apiUrl}/users/${id}`)
       .pipe(map(response => response.user));
   }
+
+  getBadges(id: number): Observable<UserBadgesResponse> {
+    return this.http.get<UserBadgesResponse>(`${environment.apiUrl}/users/${id}/badges`);
+  }
+
+  getSteamAchievements(id: number): Observable<SteamShowcase> {
+    return this.http.get<{ steam_achievements: SteamShowcase }>(`${environment.apiUrl}/users/${id}/steam-achievements`)
+      .pipe(map(response => response.steam_achievements));
+  }
+
+  updateAccessibility(reducedMotion: boolean): Observable<{ reduced_motion: boolean }> {
+    return this.http.put<{ reduced_motion: boolean }>(`${environment.apiUrl}/users/me/accessibility`, {
+      reduced_motion: reducedMotion
+    });
+  }
 }
@@ -28,8 +28,9 @@ export class VoteService {
     return this.getAll();
   }
 
-  getLeaderboard(): Observable<AchievementLeaderboard[]> {
-    return this.http.get<{ leaderboard: AchievementLeaderboard[] }>(`${environment.apiUrl}/leaderboard`)
+  getLeaderboard(appId?: number): Observable<AchievementLeaderboard[]> {
+    const params: Record<string, number> = appId ? { app_id: appId } : {};
+    return this.http.get<{ leaderboard: AchievementLeaderboard[] }>(`${environment.apiUrl}/leaderboard`, { params })
       .pipe(map(response => response.leaderboard || []));
   }
 
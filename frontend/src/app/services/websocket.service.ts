@@ -2,7 +2,7 @@ import { Injectable, signal, inject } from '@angular/core';
 import { environment } from '../../environments/environment';
 import { AuthService } from './auth.service';
 import { ConnectionStatusService } from './connection-status.service';
-import { WebSocketMessage, VotePayload, SettingsPayload, CreditActionPayload, ChatMessagePayload, NewKingPayload, GamesSyncProgressPayload, GamesSyncCompletePayload, VoteInvalidationPayload } from '../models/websocket.model';
+import { WebSocketMessage, VotePayload, SettingsPayload, CreditActionPayload, ChatMessagePayload, ChatMessageDeletedPayload, ChatMentionPayload, UserMutedPayload, NewKingPayload, GamesSyncProgressPayload, GamesSyncCompletePayload, GamesUpdatedPayload, VoteInvalidationPayload, ConnectionClosedPayload, GameNewsPayload, DownloadReminderPayload, AchievementLivePayload, BadgeAwardedPayload, AnnouncementPayload, ResumePayload } from '../models/websocket.model';
 import { Subject, Observable } from 'rxjs';
 
 @Injectable({
@@ -14,6 +14,9 @@ export class WebSocketService {
 
   private socket: WebSocket | null = null;
   private wasConnected = false; // Track if we were ever connected
+  private closedByServer = false; // Server closed the connection because of a connection limit
+  private epoch: string | null = null; // Server start of the received broadcasts
+  private lastSeq = 0; // Latest received broadcast, missed ones are replayed on reconnect
 
   private connected = signal(false);
   readonly isConnected = this.connected.asReadonly();
@@ -25,10 +28,21 @@ export class WebSocketService {
   readonly creditsReset$ = new Subject<CreditActionPayload>();
   readonly creditsGiven$ = new Subject<CreditActionPayload>();
   readonly chatMessage$ = new Subject<ChatMessagePayload>();
+  readonly chatMessageDeleted$ = new Subject<ChatMessageDeletedPayload>();
+  readonly chatMention$ = new Subject<ChatMentionPayload>();
+  readonly userMuted$ = new Subject<UserMutedPayload>();
   readonly newKing$ = new Subject<NewKingPayload>();
   readonly gamesSyncProgress$ = new Subject<GamesSyncProgressPayload>();
   readonly gamesSyncComplete$ = new Subject<GamesSyncCompletePayload>();
+  readonly gamesUpdated$ = new Subject<GamesUpdatedPayload>();
   readonly voteInvalidation$ = new Subject<VoteInvalidationPayload>();
+  readonly connectionClosed$ = new Subject<ConnectionClosedPayload>();
+  readonly gameNews$ = new Subject<GameNewsPayload>();
+  readonly downloadReminder$ = new Subject<DownloadReminderPayload>();
+  readonly achievementLive$ = new Subject<AchievementLivePayload>();
+  readonly badgeAwarded$ = new Subject<BadgeAwardedPayload>();
+  readonly announcement$ = new Subject<AnnouncementPayload>();
+  readonly resumed$ = new Subject<ResumePayload>();
 
   // General messages observable for timeline component
   private messagesSubject = new Subject<{ type: string; payload: VotePayload }>();
@@ -55,7 +69,12 @@ export class WebSocketService {
       this.socket = null;
     }
 
-    const wsUrl = `${environment.wsUrl}?token=${token}`;
+    this.closedByServer = false;
+
+    let wsUrl = `${environment.wsUrl}?token=${token}`;
+    if (this.epoch) {
+      wsUrl += `&epoch=${this.epoch}&last_seq=${this.lastSeq}`;
+    }
     console.log('WebSocket: Connecting to', wsUrl);
 
     try {
@@ -71,6 +90,9 @@ export class WebSocketService {
         try {
           const message: WebSocketMessage<VotePayload> = JSON.parse(event.data);
           console.log('WebSocket: Received message', message.type);
+          if (message.seq) {
+            this.lastSeq = Math.max(this.lastSeq, message.seq);
+          }
           this.handleMessage(message);
         } catch (error) {
           console.error('WebSocket: Failed to parse message', error, event.data);
@@ -84,10 +106,11 @@ export class WebSocketService {
 
         // Only show reconnect spinner if we were previously connected
         // This prevents showing spinner on initial connection failures
-        if (event.code !== 1000 && this.wasConnected && this.authService.isAuthenticated()) {
+        // Don't reconnect after a connection limit, it would just close another tab's connection
+        if (event.code !== 1000 && !this.closedByServer && this.wasConnected && this.authService.isAuthenticated()) {
           // Show spinner and start reconnect via ConnectionStatusService
-          // This will reload the page when backend is back
-          this.connectionStatus.setDisconnected();
+          // When the backend is back the socket reconnects and gets the missed broadcasts replayed
+          this.connectionStatus.setDisconnected(() => this.connect());
         }
       };
 
@@ -106,12 +129,18 @@ export class WebSocketService {
       console.log('WebSocket: Disconnecting...');
       this.socket.close(1000, 'User logout');
       this.socket = null;
+      this.epoch = null;
+      this.lastSeq = 0;
       this.connected.set(false);
     }
   }
 
-  private handleMessage(message: WebSocketMessage<VotePayload | SettingsPayload | CreditActionPayload | ChatMessagePayload | NewKingPayload | GamesSyncProgressPayload | GamesSyncCompletePayload | VoteInvalidationPayload>): void {
+  private handleMessage(message: WebSocketMessage<VotePayload | SettingsPayload | CreditActionPayload | ChatMessagePayload | NewKingPayload | GamesSyncProgressPayload | GamesSyncCompletePayload | VoteInvalidationPayload | ConnectionClosedPayload | GameNewsPayload | DownloadReminderPayload | AnnouncementPayload | ResumePayload>): void {
     switch (message.type) {
+      case 'vote_received':
+        console.log('WebSocket: Vote received', message.payload);
+        this.voteReceived$.next(message.payload as VotePayload);
+        break;
       case 'new_vote':
         console.log('WebSocket: New vote received', message.payload);
         this.newVote$.next(message.payload as VotePayload);
@@ -133,6 +162,18 @@ export class WebSocketService {
         console.log('WebSocket: Chat message received', message.payload);
         this.chatMessage$.next(message.payload as ChatMessagePayload);
         break;
+      case 'chat_message_deleted':
+        console.log('WebSocket: Chat message deleted', message.payload);
+        this.chatMessageDeleted$.next(message.payload as ChatMessageDeletedPayload);
+        break;
+      case 'chat_mention':
+        console.log('WebSocket: Chat mention received', message.payload);
+        this.chatMention$.next(message.payload as ChatMentionPayload);
+        break;
+      case 'user_muted':
+        console.log('WebSocket: Mute state received', message.payload);
+        this.userMuted$.next(message.payload as UserMutedPayload);
+        break;
       case 'new_king':
         console.log('WebSocket: New king received', message.payload);
         this.newKing$.next(message.payload as NewKingPayload);
@@ -145,12 +186,68 @@ export class WebSocketService {
         console.log('WebSocket: Games sync complete received', message.payload);
         this.gamesSyncComplete$.next(message.payload as GamesSyncCompletePayload);
         break;
+      case 'games_updated':
+        console.log('WebSocket: Games updated received', message.payload);
+        this.gamesUpdated$.next(message.payload as GamesUpdatedPayload);
+        break;
       case 'vote_invalidation':
         console.log('WebSocket: Vote invalidation received', message.payload);
         this.voteInvalidation$.next(message.payload as VoteInvalidationPayload);
         break;
+      case 'connection_closed':
+        console.warn('WebSocket: Connection closed by server', message.payload);
+        this.closedByServer = true;
+        this.connectionClosed$.next(message.payload as ConnectionClosedPayload);
+        // Kicked and banned players can't reconnect, their session ends here
+        if (['kicked', 'banned'].includes((message.payload as ConnectionClosedPayload).reason)) {
+          this.authService.logout();
+        }
+        break;
+      case 'game_news':
+        console.log('WebSocket: Game news received', message.payload);
+        this.gameNews$.next(message.payload as GameNewsPayload);
+        break;
+      case 'download_reminder':
+        console.log('WebSocket: Download reminder received', message.payload);
+        this.downloadReminder$.next(message.payload as DownloadReminderPayload);
+        break;
+      case 'achievement_live':
+        console.log('WebSocket: Achievement live received', message.payload);
+        this.achievementLive$.next(message.payload as AchievementLivePayload);
+        break;
+      case 'badge_awarded':
+        console.log('WebSocket: Badge awarded received', message.payload);
+        this.badgeAwarded$.next(message.payload as BadgeAwardedPayload);
+        break;
+      case 'announcement':
+        console.log('WebSocket: Announcement received', message.payload);
+        this.announcement$.next(message.payload as AnnouncementPayload);
+        break;
+      case 'resume':
+        this.handleResume(message.payload as ResumePayload);
+        break;
       default:
         console.log('WebSocket: Unknown message type', message.type);
     }
   }
+
+  // The first message of every connection, the missed broadcasts follow it
+  private handleResume(resume: ResumePayload): void {
+    const reconnected = this.epoch !== null;
+    this.epoch = resume.epoch;
+    if (!reconnected || !resume.complete) {
+      this.lastSeq = resume.seq;
+    }
+
+    if (reconnected && !resume.complete) {
+      // Broadcasts were lost (server restart or a long disconnect), reload all data
+      console.log('WebSocket: Missed broadcasts could not be replayed, reloading');
+      window.location.reload();
+      return;
+    }
+    if (resume.replayed > 0) {
+      console.log(`WebSocket: Replaying ${resume.replayed} missed broadcasts`);
+    }
+    this.resumed$.next(resume);
+  }
 }
@@ -1,6 +1,8 @@
 export const environment = {
   production: true,
   apiUrl: '/api/v1',
-  wsUrl: 'wss://' + (typeof window !== 'undefined' ? window.location.host : '') + '/api/v1/ws',
+  // ws:// for plain HTTP, e.g. the single binary on a Raspberry Pi at the venue
+  wsUrl: (typeof window !== 'undefined' && window.location.protocol === 'http:' ? 'ws://' : 'wss://') +
+    (typeof window !== 'undefined' ? window.location.host : '') + '/api/v1/ws',
   version: '__VERSION__'
 };
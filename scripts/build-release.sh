@@ -0,0 +1,84 @@
+#!/bin/bash
+
+# Release Build Script für rate-your-mate
+# Baut eine einzelne statische Binary pro Plattform (linux/amd64 und linux/arm64),
+# z.B. für einen Raspberry Pi auf der LAN-Party.
+#
+# Eingebettet werden immer die Datenbank-Migrationen, die Default-Konfiguration
+# (Spiel-Metadaten, Übersetzungen) und die Achievement-Icons.
+# Mit --with-frontend wird zusätzlich das Angular-Frontend eingebettet,
+# dann reicht eine Datei für das komplette Deployment.
+#
+# Usage: scripts/build-release.sh [--with-frontend] [version]
+#
+# Auf dem Zielrechner:
+#   ./rate-your-mate-linux-arm64 --create-env   # Starter-.env erzeugen
+#   ./rate-your-mate-linux-arm64                # Server starten
+
+set -e
+
+WITH_FRONTEND=false
+if [ "$1" = "--with-frontend" ]; then
+    WITH_FRONTEND=true
+    shift
+fi
+
+ROOT_DIR="$(cd "$(dirname "$0")/.." && pwd)"
+VERSION="${1:-$(git -C "$ROOT_DIR" describe --tags --always 2>/dev/null || echo dev)}"
+GIT_COMMIT="$(git -C "$ROOT_DIR" rev-parse --short HEAD 2>/dev/null || echo unknown)"
+BUILD_TIME="$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+PLATFORMS=("linux/amd64" "linux/arm64")
+
+WEB_DIST="$ROOT_DIR/backend/web/dist"
+OUT_DIR="$ROOT_DIR/dist"
+
+echo "📦 rate-your-mate Release Build"
+echo "==============================="
+echo ""
+echo "🏷️  Version:  $VERSION ($GIT_COMMIT)"
+echo "🌐 Frontend: $WITH_FRONTEND"
+echo ""
+
+# Eingebettete Dateien zurücksetzen, der Platzhalter bleibt für normale Builds erhalten
+cleanup() {
+    find "$WEB_DIST" -mindepth 1 ! -name .gitkeep -exec rm -rf {} +
+}
+trap cleanup EXIT
+cleanup
+
+if [ "$WITH_FRONTEND" = true ]; then
+    echo "🔨 Baue Frontend..."
+    (
+        cd "$ROOT_DIR/frontend"
+        npm ci
+        cp src/environments/environment.prod.ts /tmp/environment.prod.ts.bak
+        sed -i "s/__VERSION__/${VERSION}/g" src/environments/environment.prod.ts
+        npm run build -- --configuration=production
+        mv /tmp/environment.prod.ts.bak src/environments/environment.prod.ts
+    )
+    cp -r "$ROOT_DIR/frontend/dist/frontend/browser/." "$WEB_DIST/"
+else
+    # Ohne Frontend nur die Achievement-Icons einbetten
+    mkdir -p "$WEB_DIST/icons"
+    cp -r "$ROOT_DIR/frontend/public/icons/achievements" "$WEB_DIST/icons/"
+fi
+
+mkdir -p "$OUT_DIR"
+
+for PLATFORM in "${PLATFORMS[@]}"; do
+    GOOS="${PLATFORM%/*}"
+    GOARCH="${PLATFORM#*/}"
+    OUTPUT="$OUT_DIR/rate-your-mate-$GOOS-$GOARCH"
+
+    echo "🔨 Baue $OUTPUT..."
+    (
+        cd "$ROOT_DIR/backend"
+        CGO_ENABLED=0 GOOS="$GOOS" GOARCH="$GOARCH" go build -trimpath \
+            -ldflags="-w -s -X main.Version=$VERSION -X main.GitCommit=$GIT_COMMIT -X main.BuildTime=$BUILD_TIME" \
+            -o "$OUTPUT" .
+    )
+done
+
+echo ""
+echo "✅ Fertig! Binaries in $OUT_DIR:"
+ls -lh "$OUT_DIR"